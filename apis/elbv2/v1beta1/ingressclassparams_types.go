@@ -0,0 +1,89 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=internal;internet-facing
+// LoadBalancerScheme is the scheme of your LB
+//
+// * with `internal` scheme, the LB is only accessible within the VPC.
+// * with `internet-facing` scheme, the LB is accessible via the public internet.
+type LoadBalancerScheme string
+
+const (
+	LoadBalancerSchemeInternal       LoadBalancerScheme = "internal"
+	LoadBalancerSchemeInternetFacing LoadBalancerScheme = "internet-facing"
+)
+
+// IngressClassParamsSpec defines the desired state of IngressClassParams
+type IngressClassParamsSpec struct {
+	// Scheme defines the scheme for all Ingresses that belong to IngressClass with this IngressClassParams.
+	// +optional
+	Scheme *LoadBalancerScheme `json:"scheme,omitempty"`
+
+	// SchemeAuthoritative defines whether Scheme is authoritative.
+	// If true, Ingresses that belong to IngressClass with this IngressClassParams cannot override Scheme via annotation.
+	// Otherwise, Scheme is the default scheme, which can be overridden via annotation.
+	// +optional
+	SchemeAuthoritative bool `json:"schemeAuthoritative,omitempty"`
+
+	// TargetType defines the default targetType for all Ingresses that belong to IngressClass with this IngressClassParams.
+	// +optional
+	TargetType *TargetType `json:"targetType,omitempty"`
+
+	// TargetTypeAuthoritative defines whether TargetType is authoritative.
+	// If true, Ingresses that belong to IngressClass with this IngressClassParams cannot override TargetType via annotation.
+	// Otherwise, TargetType is the default targetType, which can be overridden via annotation.
+	// +optional
+	TargetTypeAuthoritative bool `json:"targetTypeAuthoritative,omitempty"`
+
+	// DeregistrationDelaySeconds defines the default deregistration delay for targetGroups of all Ingresses that belong to IngressClass with this IngressClassParams.
+	// It can be overridden via the deregistration_delay.timeout_seconds targetGroup attribute annotation.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	DeregistrationDelaySeconds *int64 `json:"deregistrationDelaySeconds,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="SCHEME",type="string",JSONPath=".spec.scheme",description="The Ingress Group's scheme"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// IngressClassParams is the Schema for the IngressClassParams API
+type IngressClassParams struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IngressClassParamsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IngressClassParamsList contains a list of IngressClassParams
+type IngressClassParamsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IngressClassParams `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IngressClassParams{}, &IngressClassParamsList{})
+}
@@ -0,0 +1,92 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodTargetGroupBindingSpec defines the desired state of PodTargetGroupBinding
+type PodTargetGroupBindingSpec struct {
+	// targetGroupARN is the Amazon Resource Name (ARN) for the TargetGroup.
+	// The TargetGroup must be of `ip` TargetType.
+	TargetGroupARN string `json:"targetGroupARN"`
+
+	// podSelector selects the pods within the same namespace that will be registered as targets.
+	// An empty podSelector is rejected to avoid registering all pods within the namespace by accident.
+	PodSelector metav1.LabelSelector `json:"podSelector"`
+
+	// port is the port on pods that receives traffic from TargetGroup.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int64 `json:"port"`
+}
+
+// PodTarget is a pod registered as target into TargetGroup.
+type PodTarget struct {
+	// ip is the IP address of the pod.
+	IP string `json:"ip"`
+
+	// port is the port on the pod.
+	Port int64 `json:"port"`
+}
+
+// PodTargetGroupBindingStatus defines the observed state of PodTargetGroupBinding
+type PodTargetGroupBindingStatus struct {
+	// The generation observed by the PodTargetGroupBinding controller.
+	// +optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// The number of pods registered as targets.
+	// +optional
+	Targets int32 `json:"targets,omitempty"`
+
+	// The targets registered by the PodTargetGroupBinding.
+	// Only these targets are deregistered by the controller, other targets within the TargetGroup are left untouched.
+	// +optional
+	RegisteredTargets []PodTarget `json:"registeredTargets,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=all
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="PORT",type="integer",JSONPath=".spec.port",description="The port on pods that receives traffic"
+// +kubebuilder:printcolumn:name="TARGETS",type="integer",JSONPath=".status.targets",description="The number of pods registered as targets"
+// +kubebuilder:printcolumn:name="ARN",type="string",JSONPath=".spec.targetGroupARN",description="The AWS TargetGroup's Amazon Resource Name",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// PodTargetGroupBinding is the Schema for the PodTargetGroupBinding API
+type PodTargetGroupBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PodTargetGroupBindingSpec   `json:"spec,omitempty"`
+	Status PodTargetGroupBindingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PodTargetGroupBindingList contains a list of PodTargetGroupBinding
+type PodTargetGroupBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PodTargetGroupBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PodTargetGroupBinding{}, &PodTargetGroupBindingList{})
+}
@@ -55,6 +55,12 @@ type SecurityGroup struct {
 	GroupID string `json:"groupID"`
 }
 
+// PrefixList defines reference to an AWS EC2 managed PrefixList.
+type PrefixList struct {
+	// PrefixListID is the EC2 managed PrefixListID.
+	PrefixListID string `json:"prefixListID"`
+}
+
 // NetworkingPeer defines the source/destination peer for networking rules.
 type NetworkingPeer struct {
 	// IPBlock defines an IPBlock peer.
@@ -66,9 +72,14 @@ type NetworkingPeer struct {
 	// If specified, none of the other fields can be set.
 	// +optional
 	SecurityGroup *SecurityGroup `json:"securityGroup,omitempty"`
+
+	// PrefixList defines a managed PrefixList peer.
+	// If specified, none of the other fields can be set.
+	// +optional
+	PrefixList *PrefixList `json:"prefixList,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=TCP;UDP
+// +kubebuilder:validation:Enum=TCP;UDP;ICMP;ICMPv6
 // NetworkingProtocol defines the protocol for networking rules.
 type NetworkingProtocol string
 
@@ -78,8 +89,23 @@ const (
 
 	// NetworkingProtocolUDP is the UDP protocol.
 	NetworkingProtocolUDP NetworkingProtocol = "UDP"
+
+	// NetworkingProtocolICMP is the ICMP protocol.
+	NetworkingProtocolICMP NetworkingProtocol = "ICMP"
+
+	// NetworkingProtocolICMPv6 is the ICMPv6 protocol.
+	NetworkingProtocolICMPv6 NetworkingProtocol = "ICMPv6"
 )
 
+// NetworkingICMP defines the ICMP type and code for networking rules.
+type NetworkingICMP struct {
+	// The ICMP type which traffic must match.
+	Type int64 `json:"type"`
+
+	// The ICMP code which traffic must match.
+	Code int64 `json:"code"`
+}
+
 // NetworkingPort defines the port and protocol for networking rules.
 type NetworkingPort struct {
 	// The protocol which traffic must match.
@@ -90,8 +116,14 @@ type NetworkingPort struct {
 	// When NodePort endpoints(instance TargetType) is used, this must be a numerical port.
 	// When Port endpoints(ip TargetType) is used, this can be either numerical or named port on pods.
 	// if port is unspecified, it defaults to all ports.
+	// This is ignored for ICMP and ICMPv6 protocols.
 	// +optional
 	Port *intstr.IntOrString `json:"port,omitempty"`
+
+	// The ICMP type and code which traffic must match, only applicable to ICMP and ICMPv6 protocols.
+	// if icmp is unspecified, it defaults to all ICMP types and codes.
+	// +optional
+	ICMP *NetworkingICMP `json:"icmp,omitempty"`
 }
 
 // NetworkingIngressRule defines a particular set of traffic that is allowed to access TargetGroup's targets.
@@ -112,6 +144,61 @@ type TargetGroupBindingNetworking struct {
 	Ingress []NetworkingIngressRule `json:"ingress,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=HTTP;HTTPS;TCP
+// HealthCheckProtocol is the protocol used when performing health checks on targets.
+type HealthCheckProtocol string
+
+const (
+	HealthCheckProtocolHTTP  HealthCheckProtocol = "HTTP"
+	HealthCheckProtocolHTTPS HealthCheckProtocol = "HTTPS"
+	HealthCheckProtocolTCP   HealthCheckProtocol = "TCP"
+)
+
+// HealthCheckPortTrafficPort indicates health checks are performed on the port each target receives traffic from.
+const HealthCheckPortTrafficPort = "traffic-port"
+
+// TargetGroupHealthCheck defines the health check settings of TargetGroup.
+// Any unspecified fields will be left unchanged on TargetGroup.
+type TargetGroupHealthCheck struct {
+	// The protocol the load balancer uses when performing health checks on targets.
+	// +optional
+	Protocol *HealthCheckProtocol `json:"protocol,omitempty"`
+
+	// The port the load balancer uses when performing health checks on targets.
+	// It can be either a numerical port or `traffic-port`. If unspecified, it defaults to `traffic-port`.
+	// +optional
+	Port *intstr.IntOrString `json:"port,omitempty"`
+
+	// [HTTP/HTTPS health checks] The ping path that is the destination on the targets for health checks.
+	// If unspecified, it defaults to `/` for HTTP/HTTPS health checks.
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// The approximate amount of time, in seconds, between health checks of an individual target.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	IntervalSeconds *int64 `json:"intervalSeconds,omitempty"`
+
+	// The amount of time, in seconds, during which no response from a target means a failed health check.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=120
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
+	// The number of consecutive health checks successes required before considering an unhealthy target healthy.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	HealthyThresholdCount *int64 `json:"healthyThresholdCount,omitempty"`
+
+	// The number of consecutive health check failures required before considering a target unhealthy.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	UnhealthyThresholdCount *int64 `json:"unhealthyThresholdCount,omitempty"`
+}
+
 // TargetGroupBindingSpec defines the desired state of TargetGroupBinding
 type TargetGroupBindingSpec struct {
 	// targetGroupARN is the Amazon Resource Name (ARN) for the TargetGroup.
@@ -127,6 +214,20 @@ type TargetGroupBindingSpec struct {
 	// networking defines the networking rules to allow ELBV2 LoadBalancer to access targets in TargetGroup.
 	// +optional
 	Networking *TargetGroupBindingNetworking `json:"networking,omitempty"`
+
+	// healthCheck defines the health check settings that will be applied to TargetGroup.
+	// +optional
+	HealthCheck *TargetGroupHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// TargetGroupBindingDrainingStatus defines the draining progress of targets deregistered from TargetGroup.
+type TargetGroupBindingDrainingStatus struct {
+	// The number of targets in draining state.
+	Targets int32 `json:"targets"`
+
+	// The estimated time when all targets complete draining, based on the deregistration delay of TargetGroup.
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
 }
 
 // TargetGroupBindingStatus defines the observed state of TargetGroupBinding
@@ -134,6 +235,10 @@ type TargetGroupBindingStatus struct {
 	// The generation observed by the TargetGroupBinding controller.
 	// +optional
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// The draining progress of targets, it's omitted when no targets are draining.
+	// +optional
+	Draining *TargetGroupBindingDrainingStatus `json:"draining,omitempty"`
 }
 
 // +kubebuilder:object:root=true
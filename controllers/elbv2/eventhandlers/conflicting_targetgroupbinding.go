@@ -0,0 +1,86 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForConflictingTargetGroupBindingEvent constructs new enqueueRequestsForConflictingTargetGroupBindingEvent.
+// other TargetGroupBindings referencing the same TargetGroup as the TargetGroupBinding are enqueued, so that conflicts are re-evaluated.
+func NewEnqueueRequestsForConflictingTargetGroupBindingEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+	return &enqueueRequestsForConflictingTargetGroupBindingEvent{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+type enqueueRequestsForConflictingTargetGroupBindingEvent struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+// Create is called in response to an create event - e.g. TargetGroupBinding Creation.
+func (h *enqueueRequestsForConflictingTargetGroupBindingEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	tgbNew := e.Object.(*elbv2api.TargetGroupBinding)
+	h.enqueueConflictingTargetGroupBindings(queue, tgbNew, tgbNew.Spec.TargetGroupARN)
+}
+
+// Update is called in response to an update event -  e.g. TargetGroupBinding Updated.
+// conflicts are only re-evaluated when TargetGroupBinding starts deletion, changes targetGroup, or whether it is managed changes.
+func (h *enqueueRequestsForConflictingTargetGroupBindingEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	tgbOld := e.ObjectOld.(*elbv2api.TargetGroupBinding)
+	tgbNew := e.ObjectNew.(*elbv2api.TargetGroupBinding)
+	if tgbOld.Spec.TargetGroupARN != tgbNew.Spec.TargetGroupARN {
+		h.enqueueConflictingTargetGroupBindings(queue, tgbNew, tgbOld.Spec.TargetGroupARN)
+		h.enqueueConflictingTargetGroupBindings(queue, tgbNew, tgbNew.Spec.TargetGroupARN)
+		return
+	}
+	if tgbOld.DeletionTimestamp.IsZero() == tgbNew.DeletionTimestamp.IsZero() &&
+		targetgroupbinding.IsManagedTargetGroupBinding(tgbOld) == targetgroupbinding.IsManagedTargetGroupBinding(tgbNew) {
+		return
+	}
+	h.enqueueConflictingTargetGroupBindings(queue, tgbNew, tgbNew.Spec.TargetGroupARN)
+}
+
+// Delete is called in response to a delete event - e.g. TargetGroupBinding Deleted.
+func (h *enqueueRequestsForConflictingTargetGroupBindingEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	tgbOld := e.Object.(*elbv2api.TargetGroupBinding)
+	h.enqueueConflictingTargetGroupBindings(queue, tgbOld, tgbOld.Spec.TargetGroupARN)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile AutoScaling, or a WebHook.
+func (h *enqueueRequestsForConflictingTargetGroupBindingEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// nothing to do here
+}
+
+// enqueueConflictingTargetGroupBindings will enqueue all TargetGroupBindings other than tgb that references the TargetGroup with tgARN.
+func (h *enqueueRequestsForConflictingTargetGroupBindingEvent) enqueueConflictingTargetGroupBindings(queue workqueue.RateLimitingInterface, tgb *elbv2api.TargetGroupBinding, tgARN string) {
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := h.k8sClient.List(context.Background(), tgbList,
+		client.MatchingFields{targetgroupbinding.IndexKeyTargetGroupARN: tgARN}); err != nil {
+		h.logger.Error(err, "failed to fetch targetGroupBindings")
+		return
+	}
+
+	tgbKey := k8s.NamespacedName(tgb)
+	for i := range tgbList.Items {
+		otherTGBKey := k8s.NamespacedName(&tgbList.Items[i])
+		if otherTGBKey == tgbKey {
+			continue
+		}
+		h.logger.V(1).Info("enqueue targetGroupBinding for conflicting targetGroupBinding event",
+			"targetGroupBinding", tgbKey,
+			"conflictingTargetGroupBinding", otherTGBKey,
+		)
+		queue.Add(reconcile.Request{NamespacedName: otherTGBKey})
+	}
+}
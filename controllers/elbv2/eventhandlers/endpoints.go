@@ -67,8 +67,14 @@ func (h *enqueueRequestsForEndpointsEvent) enqueueImpactedTargetGroupBindings(qu
 	}
 
 	epKey := k8s.NamespacedName(ep)
+	// endpoints share the key of their service, which is fetched once for all TargetGroupBindings referencing it.
+	externalTrafficPolicyLocal := h.isExternalTrafficPolicyLocal(epKey)
 	for _, tgb := range tgbList.Items {
-		if tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP {
+		if tgb.Spec.TargetType == nil {
+			continue
+		}
+		// instance targets only depend on endpoints when service uses externalTrafficPolicy Local.
+		if (*tgb.Spec.TargetType) == elbv2api.TargetTypeInstance && !externalTrafficPolicyLocal {
 			continue
 		}
 
@@ -84,3 +90,12 @@ func (h *enqueueRequestsForEndpointsEvent) enqueueImpactedTargetGroupBindings(qu
 		})
 	}
 }
+
+// isExternalTrafficPolicyLocal checks whether the service of endpoints uses externalTrafficPolicy Local.
+func (h *enqueueRequestsForEndpointsEvent) isExternalTrafficPolicyLocal(svcKey types.NamespacedName) bool {
+	svc := &corev1.Service{}
+	if err := h.k8sClient.Get(context.Background(), svcKey, svc); err != nil {
+		return false
+	}
+	return svc.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal
+}
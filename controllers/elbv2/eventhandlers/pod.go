@@ -0,0 +1,105 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForPodEvent constructs new enqueueRequestsForPodEvent.
+func NewEnqueueRequestsForPodEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+	return &enqueueRequestsForPodEvent{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+type enqueueRequestsForPodEvent struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *enqueueRequestsForPodEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	podNew := e.Object.(*corev1.Pod)
+	h.enqueueImpactedPodTargetGroupBindings(queue, nil, podNew)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *enqueueRequestsForPodEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	podOld := e.ObjectOld.(*corev1.Pod)
+	podNew := e.ObjectNew.(*corev1.Pod)
+	if !isPodTargetRelevantChange(podOld, podNew) {
+		return
+	}
+	h.enqueueImpactedPodTargetGroupBindings(queue, podOld, podNew)
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *enqueueRequestsForPodEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	podOld := e.Object.(*corev1.Pod)
+	h.enqueueImpactedPodTargetGroupBindings(queue, podOld, nil)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile AutoScaling, or a WebHook.
+func (h *enqueueRequestsForPodEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// nothing to do here
+}
+
+// enqueueImpactedPodTargetGroupBindings will enqueue all PodTargetGroupBindings that selects podOld or podNew.
+func (h *enqueueRequestsForPodEvent) enqueueImpactedPodTargetGroupBindings(queue workqueue.RateLimitingInterface, podOld *corev1.Pod, podNew *corev1.Pod) {
+	var podKey types.NamespacedName
+	if podOld != nil {
+		podKey = k8s.NamespacedName(podOld)
+	}
+	if podNew != nil {
+		podKey = k8s.NamespacedName(podNew)
+	}
+
+	ptgbList := &elbv2api.PodTargetGroupBindingList{}
+	if err := h.k8sClient.List(context.Background(), ptgbList, client.InNamespace(podKey.Namespace)); err != nil {
+		h.logger.Error(err, "failed to fetch podTargetGroupBindings")
+		return
+	}
+
+	for i := range ptgbList.Items {
+		ptgb := &ptgbList.Items[i]
+		selector, err := metav1.LabelSelectorAsSelector(&ptgb.Spec.PodSelector)
+		if err != nil {
+			continue
+		}
+		podOldIsSelected := podOld != nil && selector.Matches(labels.Set(podOld.Labels))
+		podNewIsSelected := podNew != nil && selector.Matches(labels.Set(podNew.Labels))
+		if podOldIsSelected || podNewIsSelected {
+			h.logger.V(1).Info("enqueue podTargetGroupBinding for pod event",
+				"pod", podKey,
+				"podTargetGroupBinding", k8s.NamespacedName(ptgb),
+			)
+			queue.Add(reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: ptgb.Namespace,
+					Name:      ptgb.Name,
+				},
+			})
+		}
+	}
+}
+
+// isPodTargetRelevantChange checks whether pod changed in a way that impacts its registration as target.
+func isPodTargetRelevantChange(podOld *corev1.Pod, podNew *corev1.Pod) bool {
+	return !labels.Equals(podOld.Labels, podNew.Labels) ||
+		podOld.Status.PodIP != podNew.Status.PodIP ||
+		podOld.DeletionTimestamp.IsZero() != podNew.DeletionTimestamp.IsZero() ||
+		k8s.IsPodContainersReady(podOld) != k8s.IsPodContainersReady(podNew)
+}
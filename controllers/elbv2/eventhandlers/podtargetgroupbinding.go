@@ -0,0 +1,75 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForPodTargetGroupBindingEvent constructs new enqueueRequestsForPodTargetGroupBindingEvent.
+// TargetGroupBindings referencing the same TargetGroup as the PodTargetGroupBinding are enqueued, so that conflicts are re-evaluated.
+func NewEnqueueRequestsForPodTargetGroupBindingEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+	return &enqueueRequestsForPodTargetGroupBindingEvent{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+type enqueueRequestsForPodTargetGroupBindingEvent struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+// Create is called in response to an create event - e.g. PodTargetGroupBinding Creation.
+func (h *enqueueRequestsForPodTargetGroupBindingEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	ptgbNew := e.Object.(*elbv2api.PodTargetGroupBinding)
+	h.enqueueImpactedTargetGroupBindings(queue, ptgbNew)
+}
+
+// Update is called in response to an update event -  e.g. PodTargetGroupBinding Updated.
+func (h *enqueueRequestsForPodTargetGroupBindingEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	ptgbOld := e.ObjectOld.(*elbv2api.PodTargetGroupBinding)
+	ptgbNew := e.ObjectNew.(*elbv2api.PodTargetGroupBinding)
+	if ptgbOld.DeletionTimestamp.IsZero() == ptgbNew.DeletionTimestamp.IsZero() {
+		return
+	}
+	h.enqueueImpactedTargetGroupBindings(queue, ptgbNew)
+}
+
+// Delete is called in response to a delete event - e.g. PodTargetGroupBinding Deleted.
+func (h *enqueueRequestsForPodTargetGroupBindingEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	ptgbOld := e.Object.(*elbv2api.PodTargetGroupBinding)
+	h.enqueueImpactedTargetGroupBindings(queue, ptgbOld)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile AutoScaling, or a WebHook.
+func (h *enqueueRequestsForPodTargetGroupBindingEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// nothing to do here
+}
+
+// enqueueImpactedTargetGroupBindings will enqueue all TargetGroupBindings that references the same TargetGroup as ptgb.
+func (h *enqueueRequestsForPodTargetGroupBindingEvent) enqueueImpactedTargetGroupBindings(queue workqueue.RateLimitingInterface, ptgb *elbv2api.PodTargetGroupBinding) {
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := h.k8sClient.List(context.Background(), tgbList,
+		client.MatchingFields{targetgroupbinding.IndexKeyTargetGroupARN: ptgb.Spec.TargetGroupARN}); err != nil {
+		h.logger.Error(err, "failed to fetch targetGroupBindings")
+		return
+	}
+
+	for i := range tgbList.Items {
+		tgb := &tgbList.Items[i]
+		h.logger.V(1).Info("enqueue targetGroupBinding for podTargetGroupBinding event",
+			"podTargetGroupBinding", k8s.NamespacedName(ptgb),
+			"targetGroupBinding", k8s.NamespacedName(tgb),
+		)
+		queue.Add(reconcile.Request{NamespacedName: k8s.NamespacedName(tgb)})
+	}
+}
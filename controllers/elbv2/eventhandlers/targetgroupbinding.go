@@ -0,0 +1,75 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForTargetGroupBindingEvent constructs new enqueueRequestsForTargetGroupBindingEvent.
+// PodTargetGroupBindings referencing the same TargetGroup as the TargetGroupBinding are enqueued, so that conflicts are re-evaluated.
+func NewEnqueueRequestsForTargetGroupBindingEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+	return &enqueueRequestsForTargetGroupBindingEvent{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+type enqueueRequestsForTargetGroupBindingEvent struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+// Create is called in response to an create event - e.g. TargetGroupBinding Creation.
+func (h *enqueueRequestsForTargetGroupBindingEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	tgbNew := e.Object.(*elbv2api.TargetGroupBinding)
+	h.enqueueImpactedPodTargetGroupBindings(queue, tgbNew)
+}
+
+// Update is called in response to an update event -  e.g. TargetGroupBinding Updated.
+func (h *enqueueRequestsForTargetGroupBindingEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	tgbOld := e.ObjectOld.(*elbv2api.TargetGroupBinding)
+	tgbNew := e.ObjectNew.(*elbv2api.TargetGroupBinding)
+	if tgbOld.DeletionTimestamp.IsZero() == tgbNew.DeletionTimestamp.IsZero() {
+		return
+	}
+	h.enqueueImpactedPodTargetGroupBindings(queue, tgbNew)
+}
+
+// Delete is called in response to a delete event - e.g. TargetGroupBinding Deleted.
+func (h *enqueueRequestsForTargetGroupBindingEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	tgbOld := e.Object.(*elbv2api.TargetGroupBinding)
+	h.enqueueImpactedPodTargetGroupBindings(queue, tgbOld)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile AutoScaling, or a WebHook.
+func (h *enqueueRequestsForTargetGroupBindingEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// nothing to do here
+}
+
+// enqueueImpactedPodTargetGroupBindings will enqueue all PodTargetGroupBindings that references the same TargetGroup as tgb.
+func (h *enqueueRequestsForTargetGroupBindingEvent) enqueueImpactedPodTargetGroupBindings(queue workqueue.RateLimitingInterface, tgb *elbv2api.TargetGroupBinding) {
+	ptgbList := &elbv2api.PodTargetGroupBindingList{}
+	if err := h.k8sClient.List(context.Background(), ptgbList,
+		client.MatchingFields{targetgroupbinding.IndexKeyTargetGroupARN: tgb.Spec.TargetGroupARN}); err != nil {
+		h.logger.Error(err, "failed to fetch podTargetGroupBindings")
+		return
+	}
+
+	for i := range ptgbList.Items {
+		ptgb := &ptgbList.Items[i]
+		h.logger.V(1).Info("enqueue podTargetGroupBinding for targetGroupBinding event",
+			"targetGroupBinding", k8s.NamespacedName(tgb),
+			"podTargetGroupBinding", k8s.NamespacedName(ptgb),
+		)
+		queue.Add(reconcile.Request{NamespacedName: k8s.NamespacedName(ptgb)})
+	}
+}
@@ -0,0 +1,219 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/controllers/elbv2/eventhandlers"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	podTargetGroupBindingFinalizer = "elbv2.k8s.aws/pod-targets"
+	podControllerName              = "podTargetGroupBinding"
+)
+
+// NewPodTargetGroupBindingReconciler constructs new podTargetGroupBindingReconciler
+func NewPodTargetGroupBindingReconciler(k8sClient client.Client, eventRecorder record.EventRecorder, finalizerManager k8s.FinalizerManager,
+	ptgbResourceManager targetgroupbinding.PodResourceManager, config config.ControllerConfig,
+	logger logr.Logger) *podTargetGroupBindingReconciler {
+
+	return &podTargetGroupBindingReconciler{
+		k8sClient:           k8sClient,
+		eventRecorder:       eventRecorder,
+		finalizerManager:    finalizerManager,
+		ptgbResourceManager: ptgbResourceManager,
+		logger:              logger,
+
+		maxConcurrentReconciles: config.TargetGroupBindingMaxConcurrentReconciles,
+	}
+}
+
+// podTargetGroupBindingReconciler reconciles a PodTargetGroupBinding object
+type podTargetGroupBindingReconciler struct {
+	k8sClient           client.Client
+	eventRecorder       record.EventRecorder
+	finalizerManager    k8s.FinalizerManager
+	ptgbResourceManager targetgroupbinding.PodResourceManager
+	logger              logr.Logger
+
+	maxConcurrentReconciles int
+}
+
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=podtargetgroupbindings,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=podtargetgroupbindings/status,verbs=update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *podTargetGroupBindingReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcileSpan(context.Background(), podControllerName, req)
+	err := r.reconcile(ctx, req)
+	tracing.EndSpan(ctx, span, err)
+	return runtime.HandleReconcileError(err, r.logger)
+}
+
+func (r *podTargetGroupBindingReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
+	ptgb := &elbv2api.PodTargetGroupBinding{}
+	if err := r.k8sClient.Get(ctx, req.NamespacedName, ptgb); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !ptgb.DeletionTimestamp.IsZero() {
+		return r.cleanupPodTargetGroupBinding(ctx, ptgb)
+	}
+	return r.reconcilePodTargetGroupBinding(ctx, ptgb)
+}
+
+func (r *podTargetGroupBindingReconciler) reconcilePodTargetGroupBinding(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding) error {
+	// an invalid spec cannot be fixed by retrying, it'll be reconciled again once spec is updated.
+	if err := targetgroupbinding.ValidatePodTargetGroupBinding(ptgb); err != nil {
+		r.eventRecorder.Event(ptgb, corev1.EventTypeWarning, k8s.PodTargetGroupBindingEventReasonInvalidSpec, fmt.Sprintf("Invalid spec due to %v", err))
+		return nil
+	}
+	conflictingOwner, err := r.findConflictingOwner(ctx, ptgb)
+	if err != nil {
+		return err
+	}
+	if conflictingOwner != "" {
+		r.eventRecorder.Event(ptgb, corev1.EventTypeWarning, k8s.PodTargetGroupBindingEventReasonConflictingTargetGroup,
+			fmt.Sprintf("Deactivated due to targetGroup %v is managed by %v", ptgb.Spec.TargetGroupARN, conflictingOwner))
+		return r.deactivatePodTargetGroupBinding(ctx, ptgb)
+	}
+	if err := r.finalizerManager.AddFinalizers(ctx, ptgb, podTargetGroupBindingFinalizer); err != nil {
+		r.eventRecorder.Event(ptgb, corev1.EventTypeWarning, k8s.PodTargetGroupBindingEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
+		return err
+	}
+	targets, err := r.ptgbResourceManager.Reconcile(ctx, ptgb)
+	if err != nil {
+		var registerTargetsErr *targetgroupbinding.RegisterTargetsError
+		if errors.As(err, &registerTargetsErr) {
+			r.eventRecorder.Event(ptgb, corev1.EventTypeWarning, k8s.PodTargetGroupBindingEventReasonFailedRegisterTargets,
+				fmt.Sprintf("Failed register targets %v due to %v", registerTargetsErr.FailedTargetIDs(), registerTargetsErr.Err))
+		}
+		return err
+	}
+	if err := r.updatePodTargetGroupBindingStatus(ctx, ptgb, targets); err != nil {
+		r.eventRecorder.Event(ptgb, corev1.EventTypeWarning, k8s.PodTargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+		return err
+	}
+
+	r.eventRecorder.Event(ptgb, corev1.EventTypeNormal, k8s.PodTargetGroupBindingEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	return nil
+}
+
+func (r *podTargetGroupBindingReconciler) cleanupPodTargetGroupBinding(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding) error {
+	if k8s.HasFinalizer(ptgb, podTargetGroupBindingFinalizer) {
+		conflictingOwner, err := r.findConflictingOwner(ctx, ptgb)
+		if err != nil {
+			return err
+		}
+		// deactivated podTargetGroupBinding shouldn't cleanup targets managed by the active one.
+		if conflictingOwner == "" {
+			if err := r.ptgbResourceManager.Cleanup(ctx, ptgb); err != nil {
+				r.eventRecorder.Event(ptgb, corev1.EventTypeWarning, k8s.PodTargetGroupBindingEventReasonFailedCleanup, fmt.Sprintf("Failed cleanup due to %v", err))
+				return err
+			}
+		}
+		if err := r.finalizerManager.RemoveFinalizers(ctx, ptgb, podTargetGroupBindingFinalizer); err != nil {
+			r.eventRecorder.Event(ptgb, corev1.EventTypeWarning, k8s.PodTargetGroupBindingEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
+			return err
+		}
+	}
+	return nil
+}
+
+// deactivatePodTargetGroupBinding hands over the targets registered by ptgb to the conflicting owner of its targetGroup.
+// the finalizer is removed as well, since there is nothing to cleanup for a deactivated podTargetGroupBinding.
+func (r *podTargetGroupBindingReconciler) deactivatePodTargetGroupBinding(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding) error {
+	if len(ptgb.Status.RegisteredTargets) != 0 {
+		ptgbOld := ptgb.DeepCopy()
+		ptgb.Status.RegisteredTargets = nil
+		if err := r.k8sClient.Status().Patch(ctx, ptgb, client.MergeFrom(ptgbOld)); err != nil {
+			r.eventRecorder.Event(ptgb, corev1.EventTypeWarning, k8s.PodTargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+			return errors.Wrapf(err, "failed to update podTargetGroupBinding status: %v", k8s.NamespacedName(ptgb))
+		}
+	}
+	if err := r.finalizerManager.RemoveFinalizers(ctx, ptgb, podTargetGroupBindingFinalizer); err != nil {
+		r.eventRecorder.Event(ptgb, corev1.EventTypeWarning, k8s.PodTargetGroupBindingEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
+		return err
+	}
+	return nil
+}
+
+// findConflictingOwner finds the precedent TargetGroupBinding or PodTargetGroupBinding that references the same targetGroup as ptgb.
+// a description of the conflicting owner is returned, or empty string if there is no conflict.
+func (r *podTargetGroupBindingReconciler) findConflictingOwner(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding) (string, error) {
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := r.k8sClient.List(ctx, tgbList,
+		client.MatchingFields{targetgroupbinding.IndexKeyTargetGroupARN: ptgb.Spec.TargetGroupARN}); err != nil {
+		return "", errors.Wrap(err, "failed to list targetGroupBindings")
+	}
+	if conflictingTGB := targetgroupbinding.FindPrecedentTargetGroupBinding(ptgb, tgbList.Items); conflictingTGB != nil {
+		return fmt.Sprintf("targetGroupBinding %v", k8s.NamespacedName(conflictingTGB)), nil
+	}
+	ptgbList := &elbv2api.PodTargetGroupBindingList{}
+	if err := r.k8sClient.List(ctx, ptgbList,
+		client.MatchingFields{targetgroupbinding.IndexKeyTargetGroupARN: ptgb.Spec.TargetGroupARN}); err != nil {
+		return "", errors.Wrap(err, "failed to list podTargetGroupBindings")
+	}
+	if conflictingPTGB := targetgroupbinding.FindConflictingPodTargetGroupBinding(ptgb, ptgbList.Items); conflictingPTGB != nil {
+		return fmt.Sprintf("podTargetGroupBinding %v", k8s.NamespacedName(conflictingPTGB)), nil
+	}
+	return "", nil
+}
+
+func (r *podTargetGroupBindingReconciler) updatePodTargetGroupBindingStatus(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding, targets int32) error {
+	if aws.Int64Value(ptgb.Status.ObservedGeneration) == ptgb.Generation && ptgb.Status.Targets == targets {
+		return nil
+	}
+	ptgbOld := ptgb.DeepCopy()
+	ptgb.Status.ObservedGeneration = aws.Int64(ptgb.Generation)
+	ptgb.Status.Targets = targets
+	if err := r.k8sClient.Status().Patch(ctx, ptgb, client.MergeFrom(ptgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update podTargetGroupBinding status: %v", k8s.NamespacedName(ptgb))
+	}
+	return nil
+}
+
+func (r *podTargetGroupBindingReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	podEventsHandler := eventhandlers.NewEnqueueRequestsForPodEvent(r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("pod"))
+	tgbEventsHandler := eventhandlers.NewEnqueueRequestsForTargetGroupBindingEvent(r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("targetGroupBinding"))
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elbv2api.PodTargetGroupBinding{}).
+		Named(podControllerName).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, podEventsHandler).
+		Watches(&source.Kind{Type: &elbv2api.TargetGroupBinding{}}, tgbEventsHandler).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.maxConcurrentReconciles}).
+		Complete(r)
+}
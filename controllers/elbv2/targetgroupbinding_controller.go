@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -76,16 +77,19 @@ type targetGroupBindingReconciler struct {
 // +kubebuilder:rbac:groups="",resources=pods/status,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *targetGroupBindingReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.logger)
+	ctx, span := tracing.StartReconcileSpan(context.Background(), controllerName, req)
+	err := r.reconcile(ctx, req)
+	tracing.EndSpan(ctx, span, err)
+	return runtime.HandleReconcileError(err, r.logger)
 }
 
-func (r *targetGroupBindingReconciler) reconcile(req ctrl.Request) error {
-	ctx := context.Background()
+func (r *targetGroupBindingReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
 	tgb := &elbv2api.TargetGroupBinding{}
 	if err := r.k8sClient.Get(ctx, req.NamespacedName, tgb); err != nil {
 		return client.IgnoreNotFound(err)
@@ -98,17 +102,35 @@ func (r *targetGroupBindingReconciler) reconcile(req ctrl.Request) error {
 }
 
 func (r *targetGroupBindingReconciler) reconcileTargetGroupBinding(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	conflictingOwner, err := r.findConflictingOwner(ctx, tgb)
+	if err != nil {
+		return err
+	}
+	if conflictingOwner != nil {
+		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonConflictingTargetGroup,
+			fmt.Sprintf("Deactivated due to targetGroup %v is managed by %v", tgb.Spec.TargetGroupARN, conflictingOwner.description))
+		return r.deactivateTargetGroupBinding(ctx, tgb, conflictingOwner)
+	}
 	if err := r.finalizerManager.AddFinalizers(ctx, tgb, targetGroupBindingFinalizer); err != nil {
 		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
 	if err := r.tgbResourceManager.Reconcile(ctx, tgb); err != nil {
+		var registerTargetsErr *targetgroupbinding.RegisterTargetsError
+		if errors.As(err, &registerTargetsErr) {
+			r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedRegisterTargets,
+				fmt.Sprintf("Failed register targets %v due to %v", registerTargetsErr.FailedTargetIDs(), registerTargetsErr.Err))
+		}
 		return err
 	}
 	if err := r.updateTargetGroupBindingStatus(ctx, tgb); err != nil {
 		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 		return err
 	}
+	if err := r.updateTargetGroupBindingGroupStatus(ctx, tgb); err != nil {
+		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update group status due to %v", err))
+		return err
+	}
 
 	r.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonSuccessfullyReconciled, "Successfully reconciled")
 	return nil
@@ -116,18 +138,83 @@ func (r *targetGroupBindingReconciler) reconcileTargetGroupBinding(ctx context.C
 
 func (r *targetGroupBindingReconciler) cleanupTargetGroupBinding(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	if k8s.HasFinalizer(tgb, targetGroupBindingFinalizer) {
-		if err := r.tgbResourceManager.Cleanup(ctx, tgb); err != nil {
-			r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedCleanup, fmt.Sprintf("Failed cleanup due to %v", err))
+		conflictingOwner, err := r.findConflictingOwner(ctx, tgb)
+		if err != nil {
 			return err
 		}
+		// deactivated targetGroupBinding shouldn't cleanup targets registered by the active one.
+		if conflictingOwner == nil {
+			if err := r.tgbResourceManager.Cleanup(ctx, tgb); err != nil {
+				r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedCleanup, fmt.Sprintf("Failed cleanup due to %v", err))
+				return err
+			}
+		}
 		if err := r.finalizerManager.RemoveFinalizers(ctx, tgb, targetGroupBindingFinalizer); err != nil {
 			r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
 			return err
 		}
+		if err := r.updateTargetGroupBindingGroupStatus(ctx, tgb); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// deactivateTargetGroupBinding hands over the targetGroup of tgb to conflictingOwner if tgb was active before.
+// the finalizer is removed afterwards, since there is nothing to cleanup for a deactivated targetGroupBinding.
+func (r *targetGroupBindingReconciler) deactivateTargetGroupBinding(ctx context.Context, tgb *elbv2api.TargetGroupBinding, conflictingOwner *targetGroupOwner) error {
+	if !k8s.HasFinalizer(tgb, targetGroupBindingFinalizer) {
+		return nil
+	}
+	if err := r.tgbResourceManager.HandOver(ctx, tgb, conflictingOwner.ptgb); err != nil {
+		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedCleanup, fmt.Sprintf("Failed hand over due to %v", err))
+		return err
+	}
+	if err := r.finalizerManager.RemoveFinalizers(ctx, tgb, targetGroupBindingFinalizer); err != nil {
+		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
+		return err
+	}
+	return r.updateTargetGroupBindingGroupStatus(ctx, tgb)
+}
+
+// targetGroupOwner is the active owner of a targetGroup that conflicts with a user created TargetGroupBinding.
+type targetGroupOwner struct {
+	// description of the owner in events.
+	description string
+	// the owner if it's a PodTargetGroupBinding, or nil if it's a managed TargetGroupBinding.
+	ptgb *elbv2api.PodTargetGroupBinding
+}
+
+// findConflictingOwner finds the managed TargetGroupBinding or the precedent PodTargetGroupBinding that references the same targetGroup as a user created tgb.
+// nil is returned if there is no conflict.
+func (r *targetGroupBindingReconciler) findConflictingOwner(ctx context.Context, tgb *elbv2api.TargetGroupBinding) (*targetGroupOwner, error) {
+	if targetgroupbinding.IsManagedTargetGroupBinding(tgb) {
+		return nil, nil
+	}
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := r.k8sClient.List(ctx, tgbList,
+		client.MatchingFields{targetgroupbinding.IndexKeyTargetGroupARN: tgb.Spec.TargetGroupARN}); err != nil {
+		return nil, errors.Wrap(err, "failed to list targetGroupBindings")
+	}
+	if conflictingTGB := targetgroupbinding.FindConflictingTargetGroupBinding(tgb, tgbList.Items); conflictingTGB != nil {
+		return &targetGroupOwner{
+			description: fmt.Sprintf("targetGroupBinding %v", k8s.NamespacedName(conflictingTGB)),
+		}, nil
+	}
+	ptgbList := &elbv2api.PodTargetGroupBindingList{}
+	if err := r.k8sClient.List(ctx, ptgbList,
+		client.MatchingFields{targetgroupbinding.IndexKeyTargetGroupARN: tgb.Spec.TargetGroupARN}); err != nil {
+		return nil, errors.Wrap(err, "failed to list podTargetGroupBindings")
+	}
+	if conflictingPTGB := targetgroupbinding.FindPrecedentPodTargetGroupBinding(tgb, ptgbList.Items); conflictingPTGB != nil {
+		return &targetGroupOwner{
+			description: fmt.Sprintf("podTargetGroupBinding %v", k8s.NamespacedName(conflictingPTGB)),
+			ptgb:        conflictingPTGB,
+		}, nil
+	}
+	return nil, nil
+}
+
 func (r *targetGroupBindingReconciler) updateTargetGroupBindingStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	if aws.Int64Value(tgb.Status.ObservedGeneration) == tgb.Generation {
 		return nil
@@ -140,6 +227,41 @@ func (r *targetGroupBindingReconciler) updateTargetGroupBindingStatus(ctx contex
 	return nil
 }
 
+// updateTargetGroupBindingGroupStatus updates the aggregated status annotation on all TargetGroupBindings within the same group as tgb.
+func (r *targetGroupBindingReconciler) updateTargetGroupBindingGroupStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	groupName, ok := tgb.Labels[targetgroupbinding.LabelKeyTargetGroupBindingGroup]
+	if !ok {
+		return nil
+	}
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := r.k8sClient.List(ctx, tgbList, client.InNamespace(tgb.Namespace),
+		client.MatchingLabels{targetgroupbinding.LabelKeyTargetGroupBindingGroup: groupName}); err != nil {
+		return errors.Wrap(err, "failed to list targetGroupBindings")
+	}
+	// the cached tgb might not reflect the status we just updated yet.
+	for i := range tgbList.Items {
+		if tgbList.Items[i].Name == tgb.Name {
+			tgbList.Items[i] = *tgb
+		}
+	}
+	groupStatus := targetgroupbinding.ComputeGroupStatusSummary(tgbList.Items).String()
+	for i := range tgbList.Items {
+		member := &tgbList.Items[i]
+		if !member.DeletionTimestamp.IsZero() || member.Annotations[targetgroupbinding.AnnotationKeyTargetGroupBindingGroupStatus] == groupStatus {
+			continue
+		}
+		memberOld := member.DeepCopy()
+		if member.Annotations == nil {
+			member.Annotations = make(map[string]string)
+		}
+		member.Annotations[targetgroupbinding.AnnotationKeyTargetGroupBindingGroupStatus] = groupStatus
+		if err := r.k8sClient.Patch(ctx, member, client.MergeFrom(memberOld)); err != nil {
+			return errors.Wrapf(err, "failed to update targetGroupBinding group status: %v", k8s.NamespacedName(member))
+		}
+	}
+	return nil
+}
+
 func (r *targetGroupBindingReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	if err := r.setupIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
 		return err
@@ -147,13 +269,22 @@ func (r *targetGroupBindingReconciler) SetupWithManager(ctx context.Context, mgr
 
 	epEventsHandler := eventhandlers.NewEnqueueRequestsForEndpointsEvent(r.k8sClient,
 		r.logger.WithName("eventHandlers").WithName("endpoints"))
+	svcEventsHandler := eventhandlers.NewEnqueueRequestsForServiceEvent(r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("service"))
 	nodeEventsHandler := eventhandlers.NewEnqueueRequestsForNodeEvent(r.k8sClient,
 		r.logger.WithName("eventHandlers").WithName("node"))
+	ptgbEventsHandler := eventhandlers.NewEnqueueRequestsForPodTargetGroupBindingEvent(r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("podTargetGroupBinding"))
+	tgbEventsHandler := eventhandlers.NewEnqueueRequestsForConflictingTargetGroupBindingEvent(r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("targetGroupBinding"))
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&elbv2api.TargetGroupBinding{}).
 		Named(controllerName).
 		Watches(&source.Kind{Type: &corev1.Endpoints{}}, epEventsHandler).
+		Watches(&source.Kind{Type: &corev1.Service{}}, svcEventsHandler).
 		Watches(&source.Kind{Type: &corev1.Node{}}, nodeEventsHandler).
+		Watches(&source.Kind{Type: &elbv2api.PodTargetGroupBinding{}}, ptgbEventsHandler).
+		Watches(&source.Kind{Type: &elbv2api.TargetGroupBinding{}}, tgbEventsHandler).
 		WithOptions(controller.Options{MaxConcurrentReconciles: r.maxConcurrentReconciles}).
 		Complete(r)
 }
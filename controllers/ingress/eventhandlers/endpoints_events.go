@@ -0,0 +1,98 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForEndpointsEvent constructs new enqueueRequestsForEndpointsEvent.
+func NewEnqueueRequestsForEndpointsEvent(ingEventChan chan<- event.GenericEvent,
+	k8sClient client.Client, logger logr.Logger) *enqueueRequestsForEndpointsEvent {
+	return &enqueueRequestsForEndpointsEvent{
+		ingEventChan: ingEventChan,
+		k8sClient:    k8sClient,
+		logger:       logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForEndpointsEvent)(nil)
+
+// enqueueRequestsForEndpointsEvent enqueues Ingresses when their backend service loses all ready endpoints or gets them back,
+// so that the empty endpoints action can be applied or reverted.
+type enqueueRequestsForEndpointsEvent struct {
+	ingEventChan chan<- event.GenericEvent
+	k8sClient    client.Client
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForEndpointsEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	epNew := e.Object.(*corev1.Endpoints)
+	if hasReadyAddresses(epNew) {
+		h.enqueueImpactedIngresses(epNew)
+	}
+}
+
+func (h *enqueueRequestsForEndpointsEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	epOld := e.ObjectOld.(*corev1.Endpoints)
+	epNew := e.ObjectNew.(*corev1.Endpoints)
+
+	// we only care whether there are ready endpoints, the targets are registered by targetGroupBindings.
+	if hasReadyAddresses(epOld) == hasReadyAddresses(epNew) {
+		return
+	}
+	h.enqueueImpactedIngresses(epNew)
+}
+
+func (h *enqueueRequestsForEndpointsEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	epOld := e.Object.(*corev1.Endpoints)
+	if hasReadyAddresses(epOld) {
+		h.enqueueImpactedIngresses(epOld)
+	}
+}
+
+func (h *enqueueRequestsForEndpointsEvent) Generic(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for endpoints.
+}
+
+func (h *enqueueRequestsForEndpointsEvent) enqueueImpactedIngresses(ep *corev1.Endpoints) {
+	ingList := &networking.IngressList{}
+	if err := h.k8sClient.List(context.Background(), ingList,
+		client.InNamespace(ep.Namespace),
+		client.MatchingFields{ingress.IndexKeyServiceRefName: ep.Name}); err != nil {
+		h.logger.Error(err, "failed to fetch ingresses")
+		return
+	}
+
+	epKey := k8s.NamespacedName(ep)
+	for index := range ingList.Items {
+		ing := &ingList.Items[index]
+		meta, _ := meta.Accessor(ing)
+
+		h.logger.V(1).Info("enqueue ingress for endpoints event",
+			"endpoints", epKey,
+			"ingress", k8s.NamespacedName(ing))
+		h.ingEventChan <- event.GenericEvent{
+			Meta:   meta,
+			Object: ing,
+		}
+	}
+}
+
+// hasReadyAddresses checks whether endpoints contains any ready address.
+func hasReadyAddresses(ep *corev1.Endpoints) bool {
+	for _, subset := range ep.Subsets {
+		if len(subset.Addresses) != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForIngressClassEvent constructs new enqueueRequestsForIngressClassEvent.
+func NewEnqueueRequestsForIngressClassEvent(ingEventChan chan<- event.GenericEvent,
+	k8sClient client.Client, logger logr.Logger) *enqueueRequestsForIngressClassEvent {
+	return &enqueueRequestsForIngressClassEvent{
+		ingEventChan: ingEventChan,
+		k8sClient:    k8sClient,
+		logger:       logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForIngressClassEvent)(nil)
+
+// enqueueRequestsForIngressClassEvent enqueues Ingresses that reference an IngressClass when it changes,
+// so that Ingresses referencing an IngressClass created later are picked up.
+type enqueueRequestsForIngressClassEvent struct {
+	ingEventChan chan<- event.GenericEvent
+	k8sClient    client.Client
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForIngressClassEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	ingClassNew := e.Object.(*networking.IngressClass)
+	h.enqueueImpactedIngresses(ingClassNew)
+}
+
+func (h *enqueueRequestsForIngressClassEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	ingClassOld := e.ObjectOld.(*networking.IngressClass)
+	ingClassNew := e.ObjectNew.(*networking.IngressClass)
+
+	// we only care about spec updates.
+	if equality.Semantic.DeepEqual(ingClassOld.Spec, ingClassNew.Spec) {
+		return
+	}
+	h.enqueueImpactedIngresses(ingClassNew)
+}
+
+func (h *enqueueRequestsForIngressClassEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	ingClassOld := e.Object.(*networking.IngressClass)
+	h.enqueueImpactedIngresses(ingClassOld)
+}
+
+func (h *enqueueRequestsForIngressClassEvent) Generic(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for ingressClasses.
+}
+
+func (h *enqueueRequestsForIngressClassEvent) enqueueImpactedIngresses(ingClass *networking.IngressClass) {
+	enqueueIngressesForIngressClass(context.Background(), h.k8sClient, h.ingEventChan, ingClass.Name, h.logger)
+}
+
+// enqueueIngressesForIngressClass enqueues the Ingresses that reference IngressClass.
+func enqueueIngressesForIngressClass(ctx context.Context, k8sClient client.Client, ingEventChan chan<- event.GenericEvent,
+	ingClassName string, logger logr.Logger) {
+	ingList := &networking.IngressList{}
+	if err := k8sClient.List(ctx, ingList,
+		client.MatchingFields{ingress.IndexKeyIngressClassRefName: ingClassName}); err != nil {
+		logger.Error(err, "failed to fetch ingresses")
+		return
+	}
+	for index := range ingList.Items {
+		ing := &ingList.Items[index]
+		meta, _ := meta.Accessor(ing)
+
+		logger.V(1).Info("enqueue ingress for ingressClass event",
+			"ingressClass", ingClassName,
+			"ingress", k8s.NamespacedName(ing))
+		ingEventChan <- event.GenericEvent{
+			Meta:   meta,
+			Object: ing,
+		}
+	}
+}
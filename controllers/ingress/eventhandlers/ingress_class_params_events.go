@@ -0,0 +1,71 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForIngressClassParamsEvent constructs new enqueueRequestsForIngressClassParamsEvent.
+func NewEnqueueRequestsForIngressClassParamsEvent(ingEventChan chan<- event.GenericEvent,
+	k8sClient client.Client, logger logr.Logger) *enqueueRequestsForIngressClassParamsEvent {
+	return &enqueueRequestsForIngressClassParamsEvent{
+		ingEventChan: ingEventChan,
+		k8sClient:    k8sClient,
+		logger:       logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForIngressClassParamsEvent)(nil)
+
+// enqueueRequestsForIngressClassParamsEvent enqueues Ingresses that belong to the IngressClasses referencing an IngressClassParams when it changes.
+type enqueueRequestsForIngressClassParamsEvent struct {
+	ingEventChan chan<- event.GenericEvent
+	k8sClient    client.Client
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForIngressClassParamsEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	ingClassParamsNew := e.Object.(*elbv2api.IngressClassParams)
+	h.enqueueImpactedIngresses(ingClassParamsNew)
+}
+
+func (h *enqueueRequestsForIngressClassParamsEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	ingClassParamsOld := e.ObjectOld.(*elbv2api.IngressClassParams)
+	ingClassParamsNew := e.ObjectNew.(*elbv2api.IngressClassParams)
+
+	// we only care about spec updates.
+	if equality.Semantic.DeepEqual(ingClassParamsOld.Spec, ingClassParamsNew.Spec) {
+		return
+	}
+	h.enqueueImpactedIngresses(ingClassParamsNew)
+}
+
+func (h *enqueueRequestsForIngressClassParamsEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	ingClassParamsOld := e.Object.(*elbv2api.IngressClassParams)
+	h.enqueueImpactedIngresses(ingClassParamsOld)
+}
+
+func (h *enqueueRequestsForIngressClassParamsEvent) Generic(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for ingressClassParams.
+}
+
+func (h *enqueueRequestsForIngressClassParamsEvent) enqueueImpactedIngresses(ingClassParams *elbv2api.IngressClassParams) {
+	ctx := context.Background()
+	ingClassList := &networking.IngressClassList{}
+	if err := h.k8sClient.List(ctx, ingClassList,
+		client.MatchingFields{ingress.IndexKeyIngressClassParamsRefName: ingClassParams.Name}); err != nil {
+		h.logger.Error(err, "failed to fetch ingressClasses")
+		return
+	}
+	for _, ingClass := range ingClassList.Items {
+		enqueueIngressesForIngressClass(ctx, h.k8sClient, h.ingEventChan, ingClass.Name, h.logger)
+	}
+}
@@ -8,46 +8,69 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/ingress/eventhandlers"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sort"
+	"strings"
+	"time"
 )
 
 const (
-	ingressTagPrefix        = "ingress.k8s.aws"
+	ingressTagPrefix        = tracking.IngressTagPrefix
 	ingressAnnotationPrefix = "alb.ingress.kubernetes.io"
 	controllerName          = "ingress"
+
+	// the annotation on Ingress that lists the TargetGroupBindings managed for it.
+	ingressTargetGroupBindingsAnnotationKey = "ingress.k8s.aws/target-group-bindings"
+
+	// the annotation on Ingress that summarizes the cost-relevant configuration of its LoadBalancer.
+	ingressCostSummaryAnnotationKey = "ingress.k8s.aws/cost-summary"
+	// the topic of LoadBalancer activation announcements.
+	announcementTopicLoadBalancerActivation = "loadbalancer-activation"
+
+	// the loadBalancer will be checked again per interval until it turns active.
+	defaultLoadBalancerProvisioningRequeueDuration = 15 * time.Second
 )
 
 // NewGroupReconciler constructs new GroupReconciler
 func NewGroupReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder record.EventRecorder,
 	finalizerManager k8s.FinalizerManager, networkingSGManager networkingpkg.SecurityGroupManager,
 	networkingSGReconciler networkingpkg.SecurityGroupReconciler, subnetsResolver networkingpkg.SubnetsResolver,
+	sgResolver networkingpkg.SecurityGroupResolver, accessLogBucketValidator aws.AccessLogBucketValidator,
 	config config.ControllerConfig, logger logr.Logger) *groupReconciler {
 
 	annotationParser := annotations.NewSuffixAnnotationParser(ingressAnnotationPrefix)
 	authConfigBuilder := ingress.NewDefaultAuthConfigBuilder(annotationParser)
 	enhancedBackendBuilder := ingress.NewDefaultEnhancedBackendBuilder(annotationParser)
 	referenceIndexer := ingress.NewDefaultReferenceIndexer(enhancedBackendBuilder, authConfigBuilder, logger)
+	trackingProvider := tracking.NewDefaultProvider(ingressTagPrefix, config.ClusterName)
+	elbv2TaggingManager := elbv2deploy.NewDefaultTaggingManager(cloud.ELBV2(), logger)
 	modelBuilder := ingress.NewDefaultModelBuilder(k8sClient, eventRecorder,
-		cloud.EC2(), cloud.ACM(),
-		annotationParser, subnetsResolver,
-		authConfigBuilder, enhancedBackendBuilder,
-		cloud.VpcID(), config.ClusterName, logger)
+		cloud.EC2(), cloud.ACM(), cloud.Lambda(), cloud.Route53(),
+		elbv2TaggingManager, trackingProvider,
+		annotationParser, subnetsResolver, sgResolver,
+		authConfigBuilder, enhancedBackendBuilder, accessLogBucketValidator,
+		cloud.VpcID(), config, logger)
 	stackMarshaller := deploy.NewDefaultStackMarshaller()
 	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingSGManager, networkingSGReconciler,
 		config, ingressTagPrefix, logger)
@@ -67,6 +90,8 @@ func NewGroupReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder
 		groupFinalizerManager: groupFinalizerManager,
 		logger:                logger,
 
+		lbActivationTracker: k8s.NewAnnouncementTracker(),
+
 		maxConcurrentReconciles: config.IngressConfig.MaxConcurrentReconciles,
 	}
 }
@@ -84,24 +109,31 @@ type groupReconciler struct {
 	groupFinalizerManager ingress.FinalizerManager
 	logger                logr.Logger
 
+	lbActivationTracker *k8s.AnnouncementTracker
+
 	maxConcurrentReconciles int
 }
 
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=ingressclassparams,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=update;patch
 // +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=extensions,resources=ingresses/status,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile
 func (r *groupReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.logger)
+	ctx, span := tracing.StartReconcileSpan(context.Background(), controllerName, req)
+	err := r.reconcile(ctx, req)
+	tracing.EndSpan(ctx, span, err)
+	return runtime.HandleReconcileError(err, r.logger)
 }
 
-func (r *groupReconciler) reconcile(req ctrl.Request) error {
-	ctx := context.Background()
+func (r *groupReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
 	ingGroupID := ingress.DecodeGroupIDFromReconcileRequest(req)
 	ingGroup, err := r.groupLoader.Load(ctx, ingGroupID)
 	if err != nil {
@@ -113,17 +145,21 @@ func (r *groupReconciler) reconcile(req ctrl.Request) error {
 		return err
 	}
 
-	_, lb, err := r.buildAndDeployModel(ctx, ingGroup)
+	stack, lb, err := r.buildAndDeployModel(ctx, ingGroup)
 	if err != nil {
 		return err
 	}
 
+	lbActive := true
 	if len(ingGroup.Members) > 0 && lb != nil {
+		lbActive = r.announceLoadBalancerActivation(ctx, ingGroup, lb)
 		lbDNS, err := lb.DNSName().Resolve(ctx)
 		if err != nil {
 			return err
 		}
-		if err := r.updateIngressGroupStatus(ctx, ingGroup, lbDNS); err != nil {
+		tgbNamesByIngress := buildTargetGroupBindingNamesByIngress(stack, ingGroup)
+		costSummary := deploy.BuildCostSummary(stack, lb)
+		if err := r.updateIngressGroupStatus(ctx, ingGroup, lbDNS, tgbNamesByIngress, costSummary); err != nil {
 			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 			return err
 		}
@@ -136,10 +172,32 @@ func (r *groupReconciler) reconcile(req ctrl.Request) error {
 		}
 	}
 
+	if len(ingGroup.Members) == 0 {
+		r.lbActivationTracker.Forget(ingGroupID.String())
+	}
+
 	r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeNormal, k8s.IngressEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	if !lbActive {
+		return runtime.NewRequeueNeededAfter("monitor loadBalancer provisioning", defaultLoadBalancerProvisioningRequeueDuration)
+	}
 	return nil
 }
 
+// announceLoadBalancerActivation emits an event with the LoadBalancer's DNS name and ARN once it transitions into active state.
+// returns whether the LoadBalancer is active, LoadBalancers without observed state are treated as active.
+func (r *groupReconciler) announceLoadBalancerActivation(ctx context.Context, ingGroup ingress.Group, lb *elbv2model.LoadBalancer) bool {
+	if lb.Status == nil || lb.Status.State == "" {
+		return true
+	}
+	if lb.Status.State != elbv2model.LoadBalancerStateActive {
+		r.lbActivationTracker.Reset(ingGroup.ID.String(), announcementTopicLoadBalancerActivation)
+	} else if r.lbActivationTracker.Observe(ingGroup.ID.String(), announcementTopicLoadBalancerActivation, lb.Status.LoadBalancerARN) {
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeNormal, k8s.IngressEventReasonSuccessfullyReconciled,
+			fmt.Sprintf("Successfully provisioned loadBalancer %v with DNS name %v", lb.Status.LoadBalancerARN, lb.Status.DNSName))
+	}
+	return lb.Status.State != elbv2model.LoadBalancerStateProvisioning
+}
+
 func (r *groupReconciler) buildAndDeployModel(ctx context.Context, ingGroup ingress.Group) (core.Stack, *elbv2model.LoadBalancer, error) {
 	stack, lb, err := r.modelBuilder.Build(ctx, ingGroup)
 	if err != nil {
@@ -167,11 +225,17 @@ func (r *groupReconciler) recordIngressGroupEvent(_ context.Context, ingGroup in
 	}
 }
 
-func (r *groupReconciler) updateIngressGroupStatus(ctx context.Context, ingGroup ingress.Group, lbDNS string) error {
+func (r *groupReconciler) updateIngressGroupStatus(ctx context.Context, ingGroup ingress.Group, lbDNS string, tgbNamesByIngress map[types.NamespacedName][]string, costSummary deploy.CostSummary) error {
 	for _, ing := range ingGroup.Members {
 		if err := r.updateIngressStatus(ctx, lbDNS, ing); err != nil {
 			return err
 		}
+		if err := r.updateIngressTargetGroupBindings(ctx, tgbNamesByIngress[k8s.NamespacedName(ing)], ing); err != nil {
+			return err
+		}
+		if err := r.updateIngressCostSummary(ctx, costSummary, ing); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -193,6 +257,78 @@ func (r *groupReconciler) updateIngressStatus(ctx context.Context, lbDNS string,
 	return nil
 }
 
+// updateIngressTargetGroupBindings records the TargetGroupBindings managed for Ingress via annotation,
+// since Ingress status doesn't have a place for them.
+func (r *groupReconciler) updateIngressTargetGroupBindings(ctx context.Context, tgbNames []string, ing *networking.Ingress) error {
+	desiredValue := strings.Join(tgbNames, ",")
+	currentValue, exists := ing.Annotations[ingressTargetGroupBindingsAnnotationKey]
+	if desiredValue == currentValue && (exists || len(tgbNames) == 0) {
+		return nil
+	}
+	ingOld := ing.DeepCopy()
+	if len(tgbNames) == 0 {
+		delete(ing.Annotations, ingressTargetGroupBindingsAnnotationKey)
+	} else {
+		if ing.Annotations == nil {
+			ing.Annotations = make(map[string]string)
+		}
+		ing.Annotations[ingressTargetGroupBindingsAnnotationKey] = desiredValue
+	}
+	if err := r.k8sClient.Patch(ctx, ing, client.MergeFrom(ingOld)); err != nil {
+		return errors.Wrapf(err, "failed to update ingress targetGroupBindings: %v", k8s.NamespacedName(ing))
+	}
+	return nil
+}
+
+// updateIngressCostSummary records the cost-relevant configuration of LoadBalancer for Ingress via annotation,
+// since Ingress status doesn't have a place for it.
+func (r *groupReconciler) updateIngressCostSummary(ctx context.Context, costSummary deploy.CostSummary, ing *networking.Ingress) error {
+	desiredValue, err := deploy.MarshalCostSummary(costSummary)
+	if err != nil {
+		return err
+	}
+	if ing.Annotations[ingressCostSummaryAnnotationKey] == desiredValue {
+		return nil
+	}
+	ingOld := ing.DeepCopy()
+	if ing.Annotations == nil {
+		ing.Annotations = make(map[string]string)
+	}
+	ing.Annotations[ingressCostSummaryAnnotationKey] = desiredValue
+	if err := r.k8sClient.Patch(ctx, ing, client.MergeFrom(ingOld)); err != nil {
+		return errors.Wrapf(err, "failed to update ingress cost summary: %v", k8s.NamespacedName(ing))
+	}
+	return nil
+}
+
+// buildTargetGroupBindingNamesByIngress returns the sorted names of TargetGroupBindings in stack, grouped by the Ingress they're linked to.
+// the ingress name label may be truncated, so that Ingresses are looked up by their label value within the IngressGroup.
+func buildTargetGroupBindingNamesByIngress(stack core.Stack, ingGroup ingress.Group) map[types.NamespacedName][]string {
+	ingKeyByLabelValue := make(map[types.NamespacedName]types.NamespacedName, len(ingGroup.Members))
+	for _, ing := range ingGroup.Members {
+		labelValueKey := types.NamespacedName{Namespace: ing.Namespace, Name: ingress.TargetGroupBindingIngressNameLabelValue(ing.Name)}
+		ingKeyByLabelValue[labelValueKey] = k8s.NamespacedName(ing)
+	}
+	var resTGBs []*elbv2model.TargetGroupBindingResource
+	stack.ListResources(&resTGBs)
+	tgbNamesByIngress := make(map[types.NamespacedName][]string)
+	for _, resTGB := range resTGBs {
+		labelValue, ok := resTGB.Spec.Template.Labels[ingress.TargetGroupBindingIngressNameLabelKey]
+		if !ok {
+			continue
+		}
+		ingKey, ok := ingKeyByLabelValue[types.NamespacedName{Namespace: resTGB.Spec.Template.Namespace, Name: labelValue}]
+		if !ok {
+			continue
+		}
+		tgbNamesByIngress[ingKey] = append(tgbNamesByIngress[ingKey], resTGB.Spec.Template.Name)
+	}
+	for _, tgbNames := range tgbNamesByIngress {
+		sort.Strings(tgbNames)
+	}
+	return tgbNamesByIngress
+}
+
 func (r *groupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	c, err := controller.New(controllerName, mgr, controller.Options{
 		MaxConcurrentReconciles: r.maxConcurrentReconciles,
@@ -232,6 +368,20 @@ func (r *groupReconciler) setupIndexes(ctx context.Context, fieldIndexer client.
 	); err != nil {
 		return err
 	}
+	if err := fieldIndexer.IndexField(ctx, &networking.Ingress{}, ingress.IndexKeyIngressClassRefName,
+		func(obj k8sruntime.Object) []string {
+			return ingress.BuildIngressClassRefIndexes(obj.(*networking.Ingress))
+		},
+	); err != nil {
+		return err
+	}
+	if err := fieldIndexer.IndexField(ctx, &networking.IngressClass{}, ingress.IndexKeyIngressClassParamsRefName,
+		func(obj k8sruntime.Object) []string {
+			return ingress.BuildIngressClassParamsRefIndexes(obj.(*networking.IngressClass))
+		},
+	); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -242,8 +392,14 @@ func (r *groupReconciler) setupWatches(_ context.Context, c controller.Controlle
 		r.logger.WithName("eventHandlers").WithName("ingress"))
 	svcEventHandler := eventhandlers.NewEnqueueRequestsForServiceEvent(ingEventChan, r.k8sClient, r.eventRecorder,
 		r.logger.WithName("eventHandlers").WithName("service"))
+	epsEventHandler := eventhandlers.NewEnqueueRequestsForEndpointsEvent(ingEventChan, r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("endpoints"))
 	secretEventHandler := eventhandlers.NewEnqueueRequestsForSecretEvent(ingEventChan, svcEventChan, r.k8sClient, r.eventRecorder,
 		r.logger.WithName("eventHandlers").WithName("secret"))
+	ingClassEventHandler := eventhandlers.NewEnqueueRequestsForIngressClassEvent(ingEventChan, r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("ingressClass"))
+	ingClassParamsEventHandler := eventhandlers.NewEnqueueRequestsForIngressClassParamsEvent(ingEventChan, r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("ingressClassParams"))
 
 	if err := c.Watch(&source.Channel{Source: ingEventChan}, ingEventHandler); err != nil {
 		return err
@@ -257,8 +413,17 @@ func (r *groupReconciler) setupWatches(_ context.Context, c controller.Controlle
 	if err := c.Watch(&source.Kind{Type: &corev1.Service{}}, svcEventHandler); err != nil {
 		return err
 	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Endpoints{}}, epsEventHandler); err != nil {
+		return err
+	}
 	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, secretEventHandler); err != nil {
 		return err
 	}
+	if err := c.Watch(&source.Kind{Type: &networking.IngressClass{}}, ingClassEventHandler); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &elbv2api.IngressClassParams{}}, ingClassParamsEventHandler); err != nil {
+		return err
+	}
 	return nil
 }
@@ -2,42 +2,73 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/service/eventhandlers"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sort"
+	"strings"
+	"time"
 )
 
 const (
 	serviceFinalizer        = "service.k8s.aws/resources"
-	serviceTagPrefix        = "service.k8s.aws"
+	serviceTagPrefix        = tracking.ServiceTagPrefix
 	serviceAnnotationPrefix = "service.beta.kubernetes.io"
 	controllerName          = "service"
+
+	// the annotation on Service that summarizes the cost-relevant configuration of its LoadBalancer.
+	serviceCostSummaryAnnotationKey = "service.k8s.aws/cost-summary"
+	// the annotation on Service that records the annotation validation errors found in dry-run mode.
+	serviceValidationErrorsAnnotationKey = "service.k8s.aws/validation-errors"
+
+	// the index key for Services by their EIP allocations.
+	serviceIndexKeyEIPAllocation = "service.eipAllocation"
+	// the topic prefix of EIP allocation reuse warnings, followed by the allocation ID.
+	announcementTopicEIPAllocationReused = "eip-allocation-reused/"
+	// the topic of LoadBalancer activation announcements.
+	announcementTopicLoadBalancerActivation = "loadbalancer-activation"
+
+	// the loadBalancer will be checked again per interval until it turns active.
+	defaultLoadBalancerProvisioningRequeueDuration = 15 * time.Second
 )
 
 func NewServiceReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder record.EventRecorder,
 	finalizerManager k8s.FinalizerManager, networkingSGManager networking.SecurityGroupManager,
 	networkingSGReconciler networking.SecurityGroupReconciler, subnetsResolver networking.SubnetsResolver,
+	sgResolver networking.SecurityGroupResolver, accessLogBucketValidator aws.AccessLogBucketValidator,
 	config config.ControllerConfig, logger logr.Logger) *serviceReconciler {
 
 	annotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
-	modelBuilder := service.NewDefaultModelBuilder(annotationParser, subnetsResolver, config.ClusterName)
+	trackingProvider := tracking.NewDefaultProvider(serviceTagPrefix, config.ClusterName)
+	elbv2TaggingManager := elbv2deploy.NewDefaultTaggingManager(cloud.ELBV2(), logger)
+	modelAnnouncementTracker := k8s.NewAnnouncementTracker()
+	modelBuilder := service.NewDefaultModelBuilder(annotationParser, subnetsResolver, sgResolver, cloud.EC2(),
+		elbv2TaggingManager, trackingProvider, eventRecorder, accessLogBucketValidator, modelAnnouncementTracker, config)
 	stackMarshaller := deploy.NewDefaultStackMarshaller()
 	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingSGManager, networkingSGReconciler, config, serviceTagPrefix, logger)
 	return &serviceReconciler{
@@ -46,10 +77,17 @@ func NewServiceReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorde
 		finalizerManager: finalizerManager,
 		annotationParser: annotationParser,
 
-		modelBuilder:    modelBuilder,
-		stackMarshaller: stackMarshaller,
-		stackDeployer:   stackDeployer,
-		logger:          logger,
+		modelBuilder:         modelBuilder,
+		annotationsValidator: modelBuilder,
+		stackMarshaller:      stackMarshaller,
+		stackDeployer:        stackDeployer,
+		logger:               logger,
+
+		modelAnnouncementTracker: modelAnnouncementTracker,
+		lbActivationTracker:      k8s.NewAnnouncementTracker(),
+		eipReuseTracker:          k8s.NewAnnouncementTracker(),
+		eipReuseDetection:        config.ServiceEIPReuseDetection,
+		dryRun:                   config.ServiceDryRun,
 
 		maxConcurrentReconciles: config.ServiceMaxConcurrentReconciles,
 	}
@@ -61,10 +99,18 @@ type serviceReconciler struct {
 	finalizerManager k8s.FinalizerManager
 	annotationParser annotations.Parser
 
-	modelBuilder    service.ModelBuilder
-	stackMarshaller deploy.StackMarshaller
-	stackDeployer   deploy.StackDeployer
-	logger          logr.Logger
+	modelBuilder         service.ModelBuilder
+	annotationsValidator service.AnnotationsValidator
+	stackMarshaller      deploy.StackMarshaller
+	stackDeployer        deploy.StackDeployer
+	logger               logr.Logger
+
+	// tracks the announcements made by modelBuilder.
+	modelAnnouncementTracker *k8s.AnnouncementTracker
+	lbActivationTracker      *k8s.AnnouncementTracker
+	eipReuseTracker          *k8s.AnnouncementTracker
+	eipReuseDetection        bool
+	dryRun                   bool
 
 	maxConcurrentReconciles int
 }
@@ -74,14 +120,29 @@ type serviceReconciler struct {
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *serviceReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.logger)
+	ctx, span := tracing.StartReconcileSpan(context.Background(), controllerName, req)
+	err := r.reconcile(ctx, req)
+	tracing.EndSpan(ctx, span, err)
+	return runtime.HandleReconcileError(err, r.logger)
 }
 
-func (r *serviceReconciler) reconcile(req ctrl.Request) error {
-	ctx := context.Background()
+func (r *serviceReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
 	svc := &corev1.Service{}
 	if err := r.k8sClient.Get(ctx, req.NamespacedName, svc); err != nil {
-		return client.IgnoreNotFound(err)
+		if apierrors.IsNotFound(err) {
+			if r.dryRun {
+				return nil
+			}
+			return r.cleanupOrphanedLoadBalancerResources(ctx, req.NamespacedName)
+		}
+		return err
+	}
+	// AWS resources are left untouched in dry-run mode, including the ones of deleting services.
+	if r.dryRun {
+		if !svc.DeletionTimestamp.IsZero() {
+			return nil
+		}
+		return r.validateServiceAnnotations(ctx, svc)
 	}
 	if !svc.DeletionTimestamp.IsZero() {
 		return r.cleanupLoadBalancerResources(ctx, svc)
@@ -116,7 +177,10 @@ func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context,
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
-	_, lb, err := r.buildAndDeployModel(ctx, svc)
+	if r.eipReuseDetection {
+		r.warnEIPAllocationReuse(ctx, svc)
+	}
+	stack, lb, err := r.buildAndDeployModel(ctx, svc)
 	if err != nil {
 		return err
 	}
@@ -129,10 +193,34 @@ func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context,
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 		return err
 	}
+	if err = r.updateServiceCostSummary(ctx, deploy.BuildCostSummary(stack, lb), svc); err != nil {
+		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+		return err
+	}
+	lbActive := r.announceLoadBalancerActivation(svc, lb)
 	r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	if !lbActive {
+		return runtime.NewRequeueNeededAfter("monitor loadBalancer provisioning", defaultLoadBalancerProvisioningRequeueDuration)
+	}
 	return nil
 }
 
+// announceLoadBalancerActivation emits an event with the LoadBalancer's DNS name and ARN once it transitions into active state.
+// returns whether the LoadBalancer is active, LoadBalancers without observed state are treated as active.
+func (r *serviceReconciler) announceLoadBalancerActivation(svc *corev1.Service, lb *elbv2model.LoadBalancer) bool {
+	if lb.Status == nil || lb.Status.State == "" {
+		return true
+	}
+	svcKey := k8s.NamespacedName(svc).String()
+	if lb.Status.State != elbv2model.LoadBalancerStateActive {
+		r.lbActivationTracker.Reset(svcKey, announcementTopicLoadBalancerActivation)
+	} else if r.lbActivationTracker.Observe(svcKey, announcementTopicLoadBalancerActivation, lb.Status.LoadBalancerARN) {
+		r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonSuccessfullyReconciled,
+			fmt.Sprintf("Successfully provisioned loadBalancer %v with DNS name %v", lb.Status.LoadBalancerARN, lb.Status.DNSName))
+	}
+	return lb.Status.State != elbv2model.LoadBalancerStateProvisioning
+}
+
 func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, svc *corev1.Service) error {
 	if k8s.HasFinalizer(svc, serviceFinalizer) {
 		_, _, err := r.buildAndDeployModel(ctx, svc)
@@ -144,9 +232,29 @@ func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, sv
 			return err
 		}
 	}
+	r.forgetAnnouncements(k8s.NamespacedName(svc))
+	return nil
+}
+
+// cleanupOrphanedLoadBalancerResources cleanups resources for Service that no longer exists.
+// resources can be orphaned when Service is deleted while creation is in-flight, they are identified by the stack tags.
+func (r *serviceReconciler) cleanupOrphanedLoadBalancerResources(ctx context.Context, svcKey types.NamespacedName) error {
+	stack := core.NewDefaultStack(core.StackID(svcKey))
+	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
+		return err
+	}
+	r.forgetAnnouncements(svcKey)
+	r.logger.V(1).Info("successfully cleaned up orphaned resources", "service", svcKey)
 	return nil
 }
 
+// forgetAnnouncements stops tracking the announcements made for Service, once its finalizer is removed or it no longer exists.
+func (r *serviceReconciler) forgetAnnouncements(svcKey types.NamespacedName) {
+	r.modelAnnouncementTracker.Forget(svcKey.String())
+	r.lbActivationTracker.Forget(svcKey.String())
+	r.eipReuseTracker.Forget(svcKey.String())
+}
+
 func (r *serviceReconciler) updateServiceStatus(ctx context.Context, lbDNS string, svc *corev1.Service) error {
 	if len(svc.Status.LoadBalancer.Ingress) != 1 ||
 		svc.Status.LoadBalancer.Ingress[0].IP != "" ||
@@ -164,6 +272,118 @@ func (r *serviceReconciler) updateServiceStatus(ctx context.Context, lbDNS strin
 	return nil
 }
 
+// updateServiceCostSummary records the cost-relevant configuration of LoadBalancer for Service via annotation,
+// since Service status doesn't have a place for it.
+func (r *serviceReconciler) updateServiceCostSummary(ctx context.Context, costSummary deploy.CostSummary, svc *corev1.Service) error {
+	desiredValue, err := deploy.MarshalCostSummary(costSummary)
+	if err != nil {
+		return err
+	}
+	if svc.Annotations[serviceCostSummaryAnnotationKey] == desiredValue {
+		return nil
+	}
+	svcOld := svc.DeepCopy()
+	if svc.Annotations == nil {
+		svc.Annotations = make(map[string]string)
+	}
+	svc.Annotations[serviceCostSummaryAnnotationKey] = desiredValue
+	if err := r.k8sClient.Patch(ctx, svc, client.MergeFrom(svcOld)); err != nil {
+		return errors.Wrapf(err, "failed to update service cost summary: %v", k8s.NamespacedName(svc))
+	}
+	return nil
+}
+
+// validateServiceAnnotations validates the annotations of Service in dry-run mode.
+// each validation error is reported via a warning event, and all of them are recorded on Service via annotation.
+func (r *serviceReconciler) validateServiceAnnotations(ctx context.Context, svc *corev1.Service) error {
+	validationErrs := r.annotationsValidator.ValidateAnnotations(ctx, svc)
+	for _, validationErr := range validationErrs {
+		if validationErr.Annotation != "" {
+			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonInvalidAnnotation,
+				fmt.Sprintf("Invalid annotation %v: %v", validationErr.Annotation, validationErr.Message))
+		} else {
+			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonInvalidAnnotation,
+				fmt.Sprintf("Validation failed: %v", validationErr.Message))
+		}
+	}
+	return r.updateServiceValidationErrors(ctx, validationErrs, svc)
+}
+
+// updateServiceValidationErrors records the annotation validation errors on Service via annotation,
+// the annotation is removed if there is no validation error.
+func (r *serviceReconciler) updateServiceValidationErrors(ctx context.Context, validationErrs []service.AnnotationValidationError, svc *corev1.Service) error {
+	currentValue, exists := svc.Annotations[serviceValidationErrorsAnnotationKey]
+	desiredValue := ""
+	if len(validationErrs) != 0 {
+		payload, err := json.Marshal(validationErrs)
+		if err != nil {
+			return err
+		}
+		desiredValue = string(payload)
+	}
+	if (desiredValue == "" && !exists) || (desiredValue != "" && currentValue == desiredValue) {
+		return nil
+	}
+	svcOld := svc.DeepCopy()
+	if desiredValue == "" {
+		delete(svc.Annotations, serviceValidationErrorsAnnotationKey)
+	} else {
+		if svc.Annotations == nil {
+			svc.Annotations = make(map[string]string)
+		}
+		svc.Annotations[serviceValidationErrorsAnnotationKey] = desiredValue
+	}
+	if err := r.k8sClient.Patch(ctx, svc, client.MergeFrom(svcOld)); err != nil {
+		return errors.Wrapf(err, "failed to update service validation errors: %v", k8s.NamespacedName(svc))
+	}
+	return nil
+}
+
+// warnEIPAllocationReuse emits a warning event when EIP allocations of service are referenced by other services as well.
+// the warning of an EIP allocation is only emitted when the services referencing it change.
+// the detection is best effort, the reconcile of service won't be blocked by it.
+func (r *serviceReconciler) warnEIPAllocationReuse(ctx context.Context, svc *corev1.Service) {
+	svcKey := k8s.NamespacedName(svc)
+	reportedTopics := sets.NewString()
+	for _, allocationID := range r.buildEIPAllocations(svc).List() {
+		topic := announcementTopicEIPAllocationReused + allocationID
+		svcList := &corev1.ServiceList{}
+		if err := r.k8sClient.List(ctx, svcList, client.MatchingFields{serviceIndexKeyEIPAllocation: allocationID}); err != nil {
+			r.logger.Error(err, "failed to fetch services by EIP allocation", "allocationID", allocationID)
+			// the last warning is kept, since whether the allocation is still reused is unknown.
+			reportedTopics.Insert(topic)
+			continue
+		}
+		var otherSVCKeys []string
+		for i := range svcList.Items {
+			otherSVC := &svcList.Items[i]
+			otherSVCKey := k8s.NamespacedName(otherSVC)
+			if otherSVCKey == svcKey || !r.buildEIPAllocations(otherSVC).Has(allocationID) {
+				continue
+			}
+			otherSVCKeys = append(otherSVCKeys, otherSVCKey.String())
+		}
+		if len(otherSVCKeys) == 0 {
+			continue
+		}
+		sort.Strings(otherSVCKeys)
+		message := fmt.Sprintf("EIP allocation %v is also referenced by services %v", allocationID, strings.Join(otherSVCKeys, ","))
+		reportedTopics.Insert(topic)
+		if r.eipReuseTracker.Observe(svcKey.String(), topic, message) {
+			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonEIPAllocationReused, message)
+		}
+	}
+	// warnings of allocations no longer reused are dropped, so that they're emitted again once reused.
+	r.eipReuseTracker.Retain(svcKey.String(), reportedTopics)
+}
+
+// buildEIPAllocations returns the EIP allocations referenced by service annotation.
+func (r *serviceReconciler) buildEIPAllocations(svc *corev1.Service) sets.String {
+	var eipAllocations []string
+	r.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixEIPAllocations, &eipAllocations, svc.Annotations)
+	return sets.NewString(eipAllocations...)
+}
+
 func (r *serviceReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	c, err := controller.New(controllerName, mgr, controller.Options{
 		MaxConcurrentReconciles: r.maxConcurrentReconciles,
@@ -172,12 +392,28 @@ func (r *serviceReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 	if err != nil {
 		return err
 	}
+	if r.eipReuseDetection {
+		if err := r.setupIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
+			return err
+		}
+	}
 	if err := r.setupWatches(ctx, c); err != nil {
 		return err
 	}
 	return nil
 }
 
+func (r *serviceReconciler) setupIndexes(ctx context.Context, fieldIndexer client.FieldIndexer) error {
+	if err := fieldIndexer.IndexField(ctx, &corev1.Service{}, serviceIndexKeyEIPAllocation,
+		func(obj k8sruntime.Object) []string {
+			return r.buildEIPAllocations(obj.(*corev1.Service)).List()
+		},
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (r *serviceReconciler) setupWatches(_ context.Context, c controller.Controller) error {
 	svcEventHandler := eventhandlers.NewEnqueueRequestForServiceEvent(r.eventRecorder, r.annotationParser,
 		r.logger.WithName("eventHandlers").WithName("service"))
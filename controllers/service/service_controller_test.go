@@ -0,0 +1,582 @@
+package service
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	mock_deploy "sigs.k8s.io/aws-load-balancer-controller/mocks/deploy"
+	mock_elbv2 "sigs.k8s.io/aws-load-balancer-controller/mocks/deploy/elbv2"
+	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+)
+
+func Test_serviceReconciler_reconcile_cleanup(t *testing.T) {
+	svcKey := types.NamespacedName{Namespace: "awesome-ns", Name: "svc-1"}
+	deletionTimestamp := metav1.Now()
+	tests := []struct {
+		name           string
+		existingSvc    *corev1.Service
+		deployErr      error
+		wantFinalizers []string
+		wantErr        error
+	}{
+		{
+			name: "service deleted while creation is in-flight",
+			existingSvc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "awesome-ns",
+					Name:              "svc-1",
+					DeletionTimestamp: &deletionTimestamp,
+					Finalizers:        []string{serviceFinalizer, "some-other-finalizer"},
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+					},
+				},
+			},
+			wantFinalizers: []string{"some-other-finalizer"},
+		},
+		{
+			name: "service deleted while creation is in-flight - failed to cleanup",
+			existingSvc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "awesome-ns",
+					Name:              "svc-1",
+					DeletionTimestamp: &deletionTimestamp,
+					Finalizers:        []string{serviceFinalizer, "some-other-finalizer"},
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+					},
+				},
+			},
+			deployErr:      errors.New("some error"),
+			wantFinalizers: []string{serviceFinalizer, "some-other-finalizer"},
+			wantErr:        errors.New("some error"),
+		},
+		{
+			name:        "service no longer exists",
+			existingSvc: nil,
+		},
+		{
+			name:        "service no longer exists - failed to cleanup",
+			existingSvc: nil,
+			deployErr:   errors.New("some error"),
+			wantErr:     errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			if tt.existingSvc != nil {
+				assert.NoError(t, k8sClient.Create(ctx, tt.existingSvc.DeepCopy()))
+			}
+
+			stackDeployer := mock_deploy.NewMockStackDeployer(ctrl)
+			stackDeployer.EXPECT().Deploy(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, stack core.Stack) error {
+				// an empty stack for the service is deployed, so that all resources tagged with the stack get deleted.
+				assert.Equal(t, core.StackID(svcKey), stack.StackID())
+				var resources []core.Resource
+				assert.NoError(t, stack.ListResources(&resources))
+				assert.Empty(t, resources)
+				return tt.deployErr
+			})
+
+			annotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
+			modelAnnouncementTracker := k8s.NewAnnouncementTracker()
+			modelAnnouncementTracker.Observe(svcKey.String(), "some-topic", "some-announcement")
+			modelBuilder := service.NewDefaultModelBuilder(annotationParser, nil, nil, nil, nil, nil, record.NewFakeRecorder(10),
+				nil, modelAnnouncementTracker, config.ControllerConfig{
+					ClusterName:                "cluster-name",
+					NLBMinAZCount:              1,
+					NLBSingleAZDiscoveryPolicy: config.SingleAZDiscoveryPolicyWarn,
+					NLBCrossZoneCostWarning:    true,
+				})
+			r := &serviceReconciler{
+				k8sClient:        k8sClient,
+				eventRecorder:    record.NewFakeRecorder(10),
+				finalizerManager: k8s.NewDefaultFinalizerManager(k8sClient, &log.NullLogger{}),
+				annotationParser: annotationParser,
+				modelBuilder:     modelBuilder,
+				stackMarshaller:  deploy.NewDefaultStackMarshaller(),
+				stackDeployer:    stackDeployer,
+				logger:           &log.NullLogger{},
+
+				modelAnnouncementTracker: modelAnnouncementTracker,
+				lbActivationTracker:      k8s.NewAnnouncementTracker(),
+				eipReuseTracker:          k8s.NewAnnouncementTracker(),
+			}
+			err := r.reconcile(context.Background(), reconcile.Request{NamespacedName: svcKey})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			// the announcements are forgotten once resources are cleaned up.
+			forgotten := modelAnnouncementTracker.Observe(svcKey.String(), "some-topic", "some-announcement")
+			assert.Equal(t, tt.wantErr == nil, forgotten)
+			if tt.existingSvc != nil {
+				gotSvc := &corev1.Service{}
+				assert.NoError(t, k8sClient.Get(ctx, svcKey, gotSvc))
+				assert.Equal(t, tt.wantFinalizers, gotSvc.Finalizers)
+			}
+		})
+	}
+}
+
+func Test_serviceReconciler_announceLoadBalancerActivation(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-1",
+		},
+	}
+	provisioningStatus := &elbv2model.LoadBalancerStatus{
+		LoadBalancerARN: "lb-arn",
+		DNSName:         "lb.example.com",
+		State:           elbv2model.LoadBalancerStateProvisioning,
+	}
+	activeStatus := &elbv2model.LoadBalancerStatus{
+		LoadBalancerARN: "lb-arn",
+		DNSName:         "lb.example.com",
+		State:           elbv2model.LoadBalancerStateActive,
+	}
+	tests := []struct {
+		name         string
+		lbStatuses   []*elbv2model.LoadBalancerStatus
+		wantLBActive []bool
+		wantEvents   []string
+	}{
+		{
+			name:         "event emitted once after loadBalancer turns active",
+			lbStatuses:   []*elbv2model.LoadBalancerStatus{provisioningStatus, provisioningStatus, activeStatus, activeStatus, activeStatus},
+			wantLBActive: []bool{false, false, true, true, true},
+			wantEvents: []string{
+				"Normal SuccessfullyReconciled Successfully provisioned loadBalancer lb-arn with DNS name lb.example.com",
+			},
+		},
+		{
+			name:         "loadBalancer without observed state",
+			lbStatuses:   []*elbv2model.LoadBalancerStatus{nil, {LoadBalancerARN: "lb-arn", DNSName: "lb.example.com"}},
+			wantLBActive: []bool{true, true},
+			wantEvents:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &serviceReconciler{
+				eventRecorder:            eventRecorder,
+				logger:                   &log.NullLogger{},
+				modelAnnouncementTracker: k8s.NewAnnouncementTracker(),
+				lbActivationTracker:      k8s.NewAnnouncementTracker(),
+				eipReuseTracker:          k8s.NewAnnouncementTracker(),
+			}
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "svc-1"})
+			lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+			var gotLBActive []bool
+			for _, lbStatus := range tt.lbStatuses {
+				lb.Status = lbStatus
+				gotLBActive = append(gotLBActive, r.announceLoadBalancerActivation(svc, lb))
+			}
+			assert.Equal(t, tt.wantLBActive, gotLBActive)
+
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_serviceReconciler_warnEIPAllocationReuse(t *testing.T) {
+	tests := []struct {
+		name        string
+		svc         *corev1.Service
+		existingSVC []*corev1.Service
+		// number of times to warn, defaults to once.
+		warnTimes  int
+		wantEvents []string
+	}{
+		{
+			name: "EIP allocations not reused",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip-1, eip-2",
+					},
+				},
+			},
+			existingSVC: []*corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "svc-2",
+						Annotations: map[string]string{
+							"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip-3, eip-4",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "svc-3",
+					},
+				},
+			},
+			wantEvents: nil,
+		},
+		{
+			name: "EIP allocations reused by other services",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip-1, eip-2",
+					},
+				},
+			},
+			existingSVC: []*corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "other-ns",
+						Name:      "svc-2",
+						Annotations: map[string]string{
+							"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip-2, eip-3",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "svc-3",
+						Annotations: map[string]string{
+							"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip-2",
+						},
+					},
+				},
+			},
+			wantEvents: []string{
+				"Warning EIPAllocationReused EIP allocation eip-2 is also referenced by services awesome-ns/svc-3,other-ns/svc-2",
+			},
+		},
+		{
+			name: "EIP allocation reuse is only warned once",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip-1",
+					},
+				},
+			},
+			existingSVC: []*corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "svc-2",
+						Annotations: map[string]string{
+							"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip-1",
+						},
+					},
+				},
+			},
+			warnTimes: 3,
+			wantEvents: []string{
+				"Warning EIPAllocationReused EIP allocation eip-1 is also referenced by services awesome-ns/svc-2",
+			},
+		},
+		{
+			name: "service without EIP allocations",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+				},
+			},
+			existingSVC: []*corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "svc-2",
+						Annotations: map[string]string{
+							"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip-1",
+						},
+					},
+				},
+			},
+			wantEvents: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			assert.NoError(t, k8sClient.Create(ctx, tt.svc.DeepCopy()))
+			for _, svc := range tt.existingSVC {
+				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &serviceReconciler{
+				k8sClient:        k8sClient,
+				eventRecorder:    eventRecorder,
+				annotationParser: annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix),
+				logger:           &log.NullLogger{},
+				eipReuseTracker:  k8s.NewAnnouncementTracker(),
+			}
+			warnTimes := 1
+			if tt.warnTimes != 0 {
+				warnTimes = tt.warnTimes
+			}
+			for i := 0; i < warnTimes; i++ {
+				r.warnEIPAllocationReuse(ctx, tt.svc)
+			}
+
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_serviceReconciler_updateServiceCostSummary(t *testing.T) {
+	tests := []struct {
+		name            string
+		svc             *corev1.Service
+		costSummary     deploy.CostSummary
+		wantAnnotations map[string]string
+	}{
+		{
+			name: "service without annotations",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+				},
+			},
+			costSummary: deploy.CostSummary{
+				CrossZoneLoadBalancing: true,
+			},
+			wantAnnotations: map[string]string{
+				"service.k8s.aws/cost-summary": `{"crossZoneLoadBalancing":true,"shieldAdvancedProtection":false}`,
+			},
+		},
+		{
+			name: "service with outdated cost summary",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "external",
+						"service.k8s.aws/cost-summary":                      `{"crossZoneLoadBalancing":true,"shieldAdvancedProtection":false}`,
+					},
+				},
+			},
+			costSummary: deploy.CostSummary{
+				CrossZoneLoadBalancing: false,
+			},
+			wantAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type": "external",
+				"service.k8s.aws/cost-summary":                      `{"crossZoneLoadBalancing":false,"shieldAdvancedProtection":false}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			assert.NoError(t, k8sClient.Create(ctx, tt.svc.DeepCopy()))
+			r := &serviceReconciler{
+				k8sClient: k8sClient,
+				logger:    &log.NullLogger{},
+			}
+			svc := &corev1.Service{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tt.svc), svc))
+			assert.NoError(t, r.updateServiceCostSummary(ctx, tt.costSummary, svc))
+
+			gotSVC := &corev1.Service{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tt.svc), gotSVC))
+			assert.Equal(t, tt.wantAnnotations, gotSVC.Annotations)
+		})
+	}
+}
+
+func Test_serviceReconciler_reconcile_dryRun(t *testing.T) {
+	svcKey := types.NamespacedName{Namespace: "awesome-ns", Name: "svc-1"}
+	deletionTimestamp := metav1.Now()
+	discoveredSubnets := []*ec2sdk.Subnet{
+		{
+			SubnetId:         awssdk.String("subnet-1"),
+			CidrBlock:        awssdk.String("192.168.0.0/19"),
+			AvailabilityZone: awssdk.String("us-west-2a"),
+		},
+	}
+	tests := []struct {
+		name            string
+		existingSvc     *corev1.Service
+		wantAnnotations map[string]string
+		wantFinalizers  []string
+		wantEvents      []string
+	}{
+		{
+			name: "service with invalid annotations",
+			existingSvc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-internal": "yes",
+					},
+				},
+			},
+			wantAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-internal": "yes",
+				"service.k8s.aws/validation-errors":                     `[{"annotation":"service.beta.kubernetes.io/aws-load-balancer-internal","message":"failed to parse bool annotation, service.beta.kubernetes.io/aws-load-balancer-internal: yes: strconv.ParseBool: parsing \"yes\": invalid syntax"}]`,
+			},
+			wantEvents: []string{
+				`Warning InvalidAnnotation Invalid annotation service.beta.kubernetes.io/aws-load-balancer-internal: failed to parse bool annotation, service.beta.kubernetes.io/aws-load-balancer-internal: yes: strconv.ParseBool: parsing "yes": invalid syntax`,
+			},
+		},
+		{
+			name: "service with fixed annotations",
+			existingSvc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+						"service.k8s.aws/validation-errors":                 `[{"annotation":"service.beta.kubernetes.io/aws-load-balancer-internal","message":"invalid"}]`,
+					},
+				},
+			},
+			wantAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+			},
+		},
+		{
+			name: "deleting service is left untouched",
+			existingSvc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "awesome-ns",
+					Name:              "svc-1",
+					DeletionTimestamp: &deletionTimestamp,
+					Finalizers:        []string{serviceFinalizer},
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-internal": "yes",
+					},
+				},
+			},
+			wantAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-internal": "yes",
+			},
+			wantFinalizers: []string{serviceFinalizer},
+		},
+		{
+			name:        "service no longer exists",
+			existingSvc: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			if tt.existingSvc != nil {
+				assert.NoError(t, k8sClient.Create(ctx, tt.existingSvc.DeepCopy()))
+			}
+
+			subnetsResolver := mock_networking.NewMockSubnetsResolver(ctrl)
+			subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any()).Return(discoveredSubnets, nil).AnyTimes()
+			elbv2TaggingManager := mock_elbv2.NewMockTaggingManager(ctrl)
+			elbv2TaggingManager.EXPECT().ListLoadBalancers(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			trackingProvider := tracking.NewDefaultProvider(serviceTagPrefix, "cluster-name")
+			eventRecorder := record.NewFakeRecorder(10)
+			annotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
+			modelBuilder := service.NewDefaultModelBuilder(annotationParser, subnetsResolver, nil, nil, elbv2TaggingManager, trackingProvider, eventRecorder,
+				nil, k8s.NewAnnouncementTracker(), config.ControllerConfig{
+					ClusterName:                "cluster-name",
+					NLBMinAZCount:              1,
+					NLBSingleAZDiscoveryPolicy: config.SingleAZDiscoveryPolicyWarn,
+					NLBCrossZoneCostWarning:    true,
+				})
+			// nothing is deployed in dry-run mode.
+			stackDeployer := mock_deploy.NewMockStackDeployer(ctrl)
+			r := &serviceReconciler{
+				k8sClient:            k8sClient,
+				eventRecorder:        eventRecorder,
+				finalizerManager:     k8s.NewDefaultFinalizerManager(k8sClient, &log.NullLogger{}),
+				annotationParser:     annotationParser,
+				modelBuilder:         modelBuilder,
+				annotationsValidator: modelBuilder,
+				stackMarshaller:      deploy.NewDefaultStackMarshaller(),
+				stackDeployer:        stackDeployer,
+				logger:               &log.NullLogger{},
+
+				modelAnnouncementTracker: k8s.NewAnnouncementTracker(),
+				lbActivationTracker:      k8s.NewAnnouncementTracker(),
+				eipReuseTracker:          k8s.NewAnnouncementTracker(),
+				dryRun:                   true,
+			}
+			err := r.reconcile(ctx, reconcile.Request{NamespacedName: svcKey})
+			assert.NoError(t, err)
+			if tt.existingSvc != nil {
+				gotSvc := &corev1.Service{}
+				assert.NoError(t, k8sClient.Get(ctx, svcKey, gotSvc))
+				assert.Equal(t, tt.wantAnnotations, gotSvc.Annotations)
+				assert.Equal(t, tt.wantFinalizers, gotSvc.Finalizers)
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
@@ -38,9 +38,11 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/version"
 	corewebhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/core"
 	elbv2webhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/elbv2"
+	networkingwebhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/networking"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -73,12 +75,21 @@ func main() {
 		os.Exit(1)
 	}
 	ctrl.SetLogger(getLoggerWithLogLevel(controllerCFG.LogLevel))
+	shutdownTracing, err := tracing.Setup(controllerCFG.TracingConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to setup tracing")
+		os.Exit(1)
+	}
 
 	cloud, err := aws.NewCloud(controllerCFG.AWSConfig, metrics.Registry)
 	if err != nil {
 		setupLog.Error(err, "unable to initialize AWS cloud")
 		os.Exit(1)
 	}
+	if controllerCFG.ValidateIAMPermissions {
+		iamPermissionsValidator := aws.NewDefaultIAMPermissionsValidator(cloud.EC2(), cloud.ELBV2(), cloud.VpcID(), ctrl.Log.WithName("iam-permissions-validator"))
+		iamPermissionsValidator.Validate(context.Background())
+	}
 	restCFG, err := config.BuildRestConfig(controllerCFG.RuntimeConfig)
 	if err != nil {
 		setupLog.Error(err, "unable to build REST config")
@@ -101,20 +112,40 @@ func main() {
 	nodeENIResolver := networking.NewDefaultNodeENIInfoResolver(cloud.EC2(), ctrl.Log)
 	sgManager := networking.NewDefaultSecurityGroupManager(cloud.EC2(), ctrl.Log)
 	sgReconciler := networking.NewDefaultSecurityGroupReconciler(sgManager, ctrl.Log)
-	subnetResolver := networking.NewDefaultSubnetsResolver(cloud.EC2(), cloud.VpcID(), controllerCFG.ClusterName, ctrl.Log.WithName("subnets-resolver"))
-	tgbResManager := targetgroupbinding.NewDefaultResourceManager(mgr.GetClient(), cloud.ELBV2(),
-		podInfoRepo, podENIResolver, nodeENIResolver, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName, ctrl.Log)
+	var subnetResolver networking.SubnetsResolver = networking.NewDefaultSubnetsResolver(cloud.EC2(), cloud.VpcID(), controllerCFG.ClusterName, ctrl.Log.WithName("subnets-resolver"))
+	if controllerCFG.SubnetResolveCacheTTL > 0 {
+		subnetResolver = networking.NewCachedSubnetsResolver(subnetResolver, controllerCFG.SubnetResolveCacheTTL)
+	}
+	sgResolver := networking.NewDefaultSecurityGroupResolver(cloud.EC2(), cloud.VpcID())
+	var accessLogBucketValidator aws.AccessLogBucketValidator
+	if controllerCFG.ValidateAccessLogBucket {
+		accessLogBucketValidator = aws.NewS3AccessLogBucketValidator(cloud.S3(), cloud.Region(), ctrl.Log.WithName("access-log-bucket-validator"))
+	}
+	tgbResManager := targetgroupbinding.NewDefaultResourceManager(mgr.GetClient(), mgr.GetEventRecorderFor("targetGroupBinding"), cloud.ELBV2(),
+		podInfoRepo, podENIResolver, nodeENIResolver, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName,
+		controllerCFG.TargetGroupBindingSkipOffAZNodes, controllerCFG.PodWebhookConfig.EnableInstanceTargetReadinessGate,
+		controllerCFG.PodWebhookConfig.ReadinessGateHealthyThresholdWait,
+		controllerCFG.TargetGroupBindingRegisterTargetsMaxRetries, metrics.Registry, ctrl.Log)
+	ptgbTargetsManager := targetgroupbinding.NewCachedTargetsManager(cloud.ELBV2(), controllerCFG.TargetGroupBindingRegisterTargetsMaxRetries, nil, ctrl.Log)
+	ptgbResManager := targetgroupbinding.NewDefaultPodResourceManager(mgr.GetClient(), ptgbTargetsManager, ctrl.Log)
 
 	ingGroupReconciler := ingress.NewGroupReconciler(cloud, mgr.GetClient(), mgr.GetEventRecorderFor("ingress"),
-		finalizerManager, sgManager, sgReconciler, subnetResolver,
+		finalizerManager, sgManager, sgReconciler, subnetResolver, sgResolver, accessLogBucketValidator,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("ingress"))
 	svcReconciler := service.NewServiceReconciler(cloud, mgr.GetClient(), mgr.GetEventRecorderFor("service"),
-		finalizerManager, sgManager, sgReconciler, subnetResolver,
+		finalizerManager, sgManager, sgReconciler, subnetResolver, sgResolver, accessLogBucketValidator,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("service"))
 	tgbReconciler := elbv2controller.NewTargetGroupBindingReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("targetGroupBinding"),
 		finalizerManager, tgbResManager,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("targetGroupBinding"))
+	ptgbReconciler := elbv2controller.NewPodTargetGroupBindingReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("podTargetGroupBinding"),
+		finalizerManager, ptgbResManager,
+		controllerCFG, ctrl.Log.WithName("controllers").WithName("podTargetGroupBinding"))
 	ctx := context.Background()
+	if err := targetgroupbinding.SetupTargetGroupARNIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to setup targetGroupARN indexes")
+		os.Exit(1)
+	}
 	if err = ingGroupReconciler.SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Ingress")
 		os.Exit(1)
@@ -127,6 +158,10 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "TargetGroupBinding")
 		os.Exit(1)
 	}
+	if err := ptgbReconciler.SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PodTargetGroupBinding")
+		os.Exit(1)
+	}
 
 	// Add liveness probe
 	err = mgr.AddHealthzCheck("health-ping", healthz.Ping)
@@ -141,6 +176,16 @@ func main() {
 	corewebhook.NewPodMutator(podReadinessGateInjector).SetupWithManager(mgr)
 	elbv2webhook.NewTargetGroupBindingMutator(cloud.ELBV2(), ctrl.Log).SetupWithManager(mgr)
 	elbv2webhook.NewTargetGroupBindingValidator(ctrl.Log).SetupWithManager(mgr)
+	elbv2webhook.NewPodTargetGroupBindingValidator(ctrl.Log).SetupWithManager(mgr)
+	// the ingress validating webhook fails closed, so it's only served when there is an annotation policy to enforce.
+	if controllerCFG.IngressConfig.AnnotationPolicyFile != "" {
+		annotationPolicy, err := networkingwebhook.LoadAnnotationPolicy(controllerCFG.IngressConfig.AnnotationPolicyFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load ingress annotation policy")
+			os.Exit(1)
+		}
+		networkingwebhook.NewIngressValidator(annotationPolicy, ctrl.Log).SetupWithManager(mgr)
+	}
 	//+kubebuilder:scaffold:builder
 
 	stopChan := ctrl.SetupSignalHandler()
@@ -159,6 +204,9 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "problem shutting down tracing")
+	}
 }
 
 // loadControllerConfig loads the controller configuration.
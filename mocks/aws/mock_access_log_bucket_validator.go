@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/aws (interfaces: AccessLogBucketValidator)
+
+// Package mock_aws is a generated GoMock package.
+package mock_aws
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockAccessLogBucketValidator is a mock of AccessLogBucketValidator interface
+type MockAccessLogBucketValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessLogBucketValidatorMockRecorder
+}
+
+// MockAccessLogBucketValidatorMockRecorder is the mock recorder for MockAccessLogBucketValidator
+type MockAccessLogBucketValidatorMockRecorder struct {
+	mock *MockAccessLogBucketValidator
+}
+
+// NewMockAccessLogBucketValidator creates a new mock instance
+func NewMockAccessLogBucketValidator(ctrl *gomock.Controller) *MockAccessLogBucketValidator {
+	mock := &MockAccessLogBucketValidator{ctrl: ctrl}
+	mock.recorder = &MockAccessLogBucketValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAccessLogBucketValidator) EXPECT() *MockAccessLogBucketValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method
+func (m *MockAccessLogBucketValidator) Validate(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate
+func (mr *MockAccessLogBucketValidatorMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockAccessLogBucketValidator)(nil).Validate), arg0, arg1)
+}
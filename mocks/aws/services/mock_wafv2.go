@@ -0,0 +1,2686 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services (interfaces: WAFv2)
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	request "github.com/aws/aws-sdk-go/aws/request"
+	wafv2 "github.com/aws/aws-sdk-go/service/wafv2"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockWAFv2 is a mock of WAFv2 interface
+type MockWAFv2 struct {
+	ctrl     *gomock.Controller
+	recorder *MockWAFv2MockRecorder
+}
+
+// MockWAFv2MockRecorder is the mock recorder for MockWAFv2
+type MockWAFv2MockRecorder struct {
+	mock *MockWAFv2
+}
+
+// NewMockWAFv2 creates a new mock instance
+func NewMockWAFv2(ctrl *gomock.Controller) *MockWAFv2 {
+	mock := &MockWAFv2{ctrl: ctrl}
+	mock.recorder = &MockWAFv2MockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockWAFv2) EXPECT() *MockWAFv2MockRecorder {
+	return m.recorder
+}
+
+// AssociateWebACL mocks base method
+func (m *MockWAFv2) AssociateWebACL(arg0 *wafv2.AssociateWebACLInput) (*wafv2.AssociateWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateWebACL", arg0)
+	ret0, _ := ret[0].(*wafv2.AssociateWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssociateWebACL indicates an expected call of AssociateWebACL
+func (mr *MockWAFv2MockRecorder) AssociateWebACL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateWebACL", reflect.TypeOf((*MockWAFv2)(nil).AssociateWebACL), arg0)
+}
+
+// AssociateWebACLRequest mocks base method
+func (m *MockWAFv2) AssociateWebACLRequest(arg0 *wafv2.AssociateWebACLInput) (*request.Request, *wafv2.AssociateWebACLOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateWebACLRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.AssociateWebACLOutput)
+	return ret0, ret1
+}
+
+// AssociateWebACLRequest indicates an expected call of AssociateWebACLRequest
+func (mr *MockWAFv2MockRecorder) AssociateWebACLRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateWebACLRequest", reflect.TypeOf((*MockWAFv2)(nil).AssociateWebACLRequest), arg0)
+}
+
+// AssociateWebACLWithContext mocks base method
+func (m *MockWAFv2) AssociateWebACLWithContext(arg0 context.Context, arg1 *wafv2.AssociateWebACLInput, arg2 ...request.Option) (*wafv2.AssociateWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AssociateWebACLWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.AssociateWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssociateWebACLWithContext indicates an expected call of AssociateWebACLWithContext
+func (mr *MockWAFv2MockRecorder) AssociateWebACLWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).AssociateWebACLWithContext), varargs...)
+}
+
+// CheckCapacity mocks base method
+func (m *MockWAFv2) CheckCapacity(arg0 *wafv2.CheckCapacityInput) (*wafv2.CheckCapacityOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCapacity", arg0)
+	ret0, _ := ret[0].(*wafv2.CheckCapacityOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCapacity indicates an expected call of CheckCapacity
+func (mr *MockWAFv2MockRecorder) CheckCapacity(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCapacity", reflect.TypeOf((*MockWAFv2)(nil).CheckCapacity), arg0)
+}
+
+// CheckCapacityRequest mocks base method
+func (m *MockWAFv2) CheckCapacityRequest(arg0 *wafv2.CheckCapacityInput) (*request.Request, *wafv2.CheckCapacityOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCapacityRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.CheckCapacityOutput)
+	return ret0, ret1
+}
+
+// CheckCapacityRequest indicates an expected call of CheckCapacityRequest
+func (mr *MockWAFv2MockRecorder) CheckCapacityRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCapacityRequest", reflect.TypeOf((*MockWAFv2)(nil).CheckCapacityRequest), arg0)
+}
+
+// CheckCapacityWithContext mocks base method
+func (m *MockWAFv2) CheckCapacityWithContext(arg0 context.Context, arg1 *wafv2.CheckCapacityInput, arg2 ...request.Option) (*wafv2.CheckCapacityOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckCapacityWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.CheckCapacityOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCapacityWithContext indicates an expected call of CheckCapacityWithContext
+func (mr *MockWAFv2MockRecorder) CheckCapacityWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCapacityWithContext", reflect.TypeOf((*MockWAFv2)(nil).CheckCapacityWithContext), varargs...)
+}
+
+// CreateAPIKey mocks base method
+func (m *MockWAFv2) CreateAPIKey(arg0 *wafv2.CreateAPIKeyInput) (*wafv2.CreateAPIKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0)
+	ret0, _ := ret[0].(*wafv2.CreateAPIKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey
+func (mr *MockWAFv2MockRecorder) CreateAPIKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockWAFv2)(nil).CreateAPIKey), arg0)
+}
+
+// CreateAPIKeyRequest mocks base method
+func (m *MockWAFv2) CreateAPIKeyRequest(arg0 *wafv2.CreateAPIKeyInput) (*request.Request, *wafv2.CreateAPIKeyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKeyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.CreateAPIKeyOutput)
+	return ret0, ret1
+}
+
+// CreateAPIKeyRequest indicates an expected call of CreateAPIKeyRequest
+func (mr *MockWAFv2MockRecorder) CreateAPIKeyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKeyRequest", reflect.TypeOf((*MockWAFv2)(nil).CreateAPIKeyRequest), arg0)
+}
+
+// CreateAPIKeyWithContext mocks base method
+func (m *MockWAFv2) CreateAPIKeyWithContext(arg0 context.Context, arg1 *wafv2.CreateAPIKeyInput, arg2 ...request.Option) (*wafv2.CreateAPIKeyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateAPIKeyWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.CreateAPIKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKeyWithContext indicates an expected call of CreateAPIKeyWithContext
+func (mr *MockWAFv2MockRecorder) CreateAPIKeyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKeyWithContext", reflect.TypeOf((*MockWAFv2)(nil).CreateAPIKeyWithContext), varargs...)
+}
+
+// CreateIPSet mocks base method
+func (m *MockWAFv2) CreateIPSet(arg0 *wafv2.CreateIPSetInput) (*wafv2.CreateIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIPSet", arg0)
+	ret0, _ := ret[0].(*wafv2.CreateIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIPSet indicates an expected call of CreateIPSet
+func (mr *MockWAFv2MockRecorder) CreateIPSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIPSet", reflect.TypeOf((*MockWAFv2)(nil).CreateIPSet), arg0)
+}
+
+// CreateIPSetRequest mocks base method
+func (m *MockWAFv2) CreateIPSetRequest(arg0 *wafv2.CreateIPSetInput) (*request.Request, *wafv2.CreateIPSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIPSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.CreateIPSetOutput)
+	return ret0, ret1
+}
+
+// CreateIPSetRequest indicates an expected call of CreateIPSetRequest
+func (mr *MockWAFv2MockRecorder) CreateIPSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIPSetRequest", reflect.TypeOf((*MockWAFv2)(nil).CreateIPSetRequest), arg0)
+}
+
+// CreateIPSetWithContext mocks base method
+func (m *MockWAFv2) CreateIPSetWithContext(arg0 context.Context, arg1 *wafv2.CreateIPSetInput, arg2 ...request.Option) (*wafv2.CreateIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateIPSetWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.CreateIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIPSetWithContext indicates an expected call of CreateIPSetWithContext
+func (mr *MockWAFv2MockRecorder) CreateIPSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIPSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).CreateIPSetWithContext), varargs...)
+}
+
+// CreateRegexPatternSet mocks base method
+func (m *MockWAFv2) CreateRegexPatternSet(arg0 *wafv2.CreateRegexPatternSetInput) (*wafv2.CreateRegexPatternSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRegexPatternSet", arg0)
+	ret0, _ := ret[0].(*wafv2.CreateRegexPatternSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRegexPatternSet indicates an expected call of CreateRegexPatternSet
+func (mr *MockWAFv2MockRecorder) CreateRegexPatternSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRegexPatternSet", reflect.TypeOf((*MockWAFv2)(nil).CreateRegexPatternSet), arg0)
+}
+
+// CreateRegexPatternSetRequest mocks base method
+func (m *MockWAFv2) CreateRegexPatternSetRequest(arg0 *wafv2.CreateRegexPatternSetInput) (*request.Request, *wafv2.CreateRegexPatternSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRegexPatternSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.CreateRegexPatternSetOutput)
+	return ret0, ret1
+}
+
+// CreateRegexPatternSetRequest indicates an expected call of CreateRegexPatternSetRequest
+func (mr *MockWAFv2MockRecorder) CreateRegexPatternSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRegexPatternSetRequest", reflect.TypeOf((*MockWAFv2)(nil).CreateRegexPatternSetRequest), arg0)
+}
+
+// CreateRegexPatternSetWithContext mocks base method
+func (m *MockWAFv2) CreateRegexPatternSetWithContext(arg0 context.Context, arg1 *wafv2.CreateRegexPatternSetInput, arg2 ...request.Option) (*wafv2.CreateRegexPatternSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateRegexPatternSetWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.CreateRegexPatternSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRegexPatternSetWithContext indicates an expected call of CreateRegexPatternSetWithContext
+func (mr *MockWAFv2MockRecorder) CreateRegexPatternSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRegexPatternSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).CreateRegexPatternSetWithContext), varargs...)
+}
+
+// CreateRuleGroup mocks base method
+func (m *MockWAFv2) CreateRuleGroup(arg0 *wafv2.CreateRuleGroupInput) (*wafv2.CreateRuleGroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRuleGroup", arg0)
+	ret0, _ := ret[0].(*wafv2.CreateRuleGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRuleGroup indicates an expected call of CreateRuleGroup
+func (mr *MockWAFv2MockRecorder) CreateRuleGroup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRuleGroup", reflect.TypeOf((*MockWAFv2)(nil).CreateRuleGroup), arg0)
+}
+
+// CreateRuleGroupRequest mocks base method
+func (m *MockWAFv2) CreateRuleGroupRequest(arg0 *wafv2.CreateRuleGroupInput) (*request.Request, *wafv2.CreateRuleGroupOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRuleGroupRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.CreateRuleGroupOutput)
+	return ret0, ret1
+}
+
+// CreateRuleGroupRequest indicates an expected call of CreateRuleGroupRequest
+func (mr *MockWAFv2MockRecorder) CreateRuleGroupRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRuleGroupRequest", reflect.TypeOf((*MockWAFv2)(nil).CreateRuleGroupRequest), arg0)
+}
+
+// CreateRuleGroupWithContext mocks base method
+func (m *MockWAFv2) CreateRuleGroupWithContext(arg0 context.Context, arg1 *wafv2.CreateRuleGroupInput, arg2 ...request.Option) (*wafv2.CreateRuleGroupOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateRuleGroupWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.CreateRuleGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRuleGroupWithContext indicates an expected call of CreateRuleGroupWithContext
+func (mr *MockWAFv2MockRecorder) CreateRuleGroupWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRuleGroupWithContext", reflect.TypeOf((*MockWAFv2)(nil).CreateRuleGroupWithContext), varargs...)
+}
+
+// CreateWebACL mocks base method
+func (m *MockWAFv2) CreateWebACL(arg0 *wafv2.CreateWebACLInput) (*wafv2.CreateWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebACL", arg0)
+	ret0, _ := ret[0].(*wafv2.CreateWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebACL indicates an expected call of CreateWebACL
+func (mr *MockWAFv2MockRecorder) CreateWebACL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebACL", reflect.TypeOf((*MockWAFv2)(nil).CreateWebACL), arg0)
+}
+
+// CreateWebACLRequest mocks base method
+func (m *MockWAFv2) CreateWebACLRequest(arg0 *wafv2.CreateWebACLInput) (*request.Request, *wafv2.CreateWebACLOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebACLRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.CreateWebACLOutput)
+	return ret0, ret1
+}
+
+// CreateWebACLRequest indicates an expected call of CreateWebACLRequest
+func (mr *MockWAFv2MockRecorder) CreateWebACLRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebACLRequest", reflect.TypeOf((*MockWAFv2)(nil).CreateWebACLRequest), arg0)
+}
+
+// CreateWebACLWithContext mocks base method
+func (m *MockWAFv2) CreateWebACLWithContext(arg0 context.Context, arg1 *wafv2.CreateWebACLInput, arg2 ...request.Option) (*wafv2.CreateWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateWebACLWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.CreateWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebACLWithContext indicates an expected call of CreateWebACLWithContext
+func (mr *MockWAFv2MockRecorder) CreateWebACLWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).CreateWebACLWithContext), varargs...)
+}
+
+// DeleteFirewallManagerRuleGroups mocks base method
+func (m *MockWAFv2) DeleteFirewallManagerRuleGroups(arg0 *wafv2.DeleteFirewallManagerRuleGroupsInput) (*wafv2.DeleteFirewallManagerRuleGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFirewallManagerRuleGroups", arg0)
+	ret0, _ := ret[0].(*wafv2.DeleteFirewallManagerRuleGroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFirewallManagerRuleGroups indicates an expected call of DeleteFirewallManagerRuleGroups
+func (mr *MockWAFv2MockRecorder) DeleteFirewallManagerRuleGroups(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFirewallManagerRuleGroups", reflect.TypeOf((*MockWAFv2)(nil).DeleteFirewallManagerRuleGroups), arg0)
+}
+
+// DeleteFirewallManagerRuleGroupsRequest mocks base method
+func (m *MockWAFv2) DeleteFirewallManagerRuleGroupsRequest(arg0 *wafv2.DeleteFirewallManagerRuleGroupsInput) (*request.Request, *wafv2.DeleteFirewallManagerRuleGroupsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFirewallManagerRuleGroupsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DeleteFirewallManagerRuleGroupsOutput)
+	return ret0, ret1
+}
+
+// DeleteFirewallManagerRuleGroupsRequest indicates an expected call of DeleteFirewallManagerRuleGroupsRequest
+func (mr *MockWAFv2MockRecorder) DeleteFirewallManagerRuleGroupsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFirewallManagerRuleGroupsRequest", reflect.TypeOf((*MockWAFv2)(nil).DeleteFirewallManagerRuleGroupsRequest), arg0)
+}
+
+// DeleteFirewallManagerRuleGroupsWithContext mocks base method
+func (m *MockWAFv2) DeleteFirewallManagerRuleGroupsWithContext(arg0 context.Context, arg1 *wafv2.DeleteFirewallManagerRuleGroupsInput, arg2 ...request.Option) (*wafv2.DeleteFirewallManagerRuleGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteFirewallManagerRuleGroupsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DeleteFirewallManagerRuleGroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFirewallManagerRuleGroupsWithContext indicates an expected call of DeleteFirewallManagerRuleGroupsWithContext
+func (mr *MockWAFv2MockRecorder) DeleteFirewallManagerRuleGroupsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFirewallManagerRuleGroupsWithContext", reflect.TypeOf((*MockWAFv2)(nil).DeleteFirewallManagerRuleGroupsWithContext), varargs...)
+}
+
+// DeleteIPSet mocks base method
+func (m *MockWAFv2) DeleteIPSet(arg0 *wafv2.DeleteIPSetInput) (*wafv2.DeleteIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIPSet", arg0)
+	ret0, _ := ret[0].(*wafv2.DeleteIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteIPSet indicates an expected call of DeleteIPSet
+func (mr *MockWAFv2MockRecorder) DeleteIPSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIPSet", reflect.TypeOf((*MockWAFv2)(nil).DeleteIPSet), arg0)
+}
+
+// DeleteIPSetRequest mocks base method
+func (m *MockWAFv2) DeleteIPSetRequest(arg0 *wafv2.DeleteIPSetInput) (*request.Request, *wafv2.DeleteIPSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIPSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DeleteIPSetOutput)
+	return ret0, ret1
+}
+
+// DeleteIPSetRequest indicates an expected call of DeleteIPSetRequest
+func (mr *MockWAFv2MockRecorder) DeleteIPSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIPSetRequest", reflect.TypeOf((*MockWAFv2)(nil).DeleteIPSetRequest), arg0)
+}
+
+// DeleteIPSetWithContext mocks base method
+func (m *MockWAFv2) DeleteIPSetWithContext(arg0 context.Context, arg1 *wafv2.DeleteIPSetInput, arg2 ...request.Option) (*wafv2.DeleteIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteIPSetWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DeleteIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteIPSetWithContext indicates an expected call of DeleteIPSetWithContext
+func (mr *MockWAFv2MockRecorder) DeleteIPSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIPSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).DeleteIPSetWithContext), varargs...)
+}
+
+// DeleteLoggingConfiguration mocks base method
+func (m *MockWAFv2) DeleteLoggingConfiguration(arg0 *wafv2.DeleteLoggingConfigurationInput) (*wafv2.DeleteLoggingConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoggingConfiguration", arg0)
+	ret0, _ := ret[0].(*wafv2.DeleteLoggingConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLoggingConfiguration indicates an expected call of DeleteLoggingConfiguration
+func (mr *MockWAFv2MockRecorder) DeleteLoggingConfiguration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoggingConfiguration", reflect.TypeOf((*MockWAFv2)(nil).DeleteLoggingConfiguration), arg0)
+}
+
+// DeleteLoggingConfigurationRequest mocks base method
+func (m *MockWAFv2) DeleteLoggingConfigurationRequest(arg0 *wafv2.DeleteLoggingConfigurationInput) (*request.Request, *wafv2.DeleteLoggingConfigurationOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoggingConfigurationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DeleteLoggingConfigurationOutput)
+	return ret0, ret1
+}
+
+// DeleteLoggingConfigurationRequest indicates an expected call of DeleteLoggingConfigurationRequest
+func (mr *MockWAFv2MockRecorder) DeleteLoggingConfigurationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoggingConfigurationRequest", reflect.TypeOf((*MockWAFv2)(nil).DeleteLoggingConfigurationRequest), arg0)
+}
+
+// DeleteLoggingConfigurationWithContext mocks base method
+func (m *MockWAFv2) DeleteLoggingConfigurationWithContext(arg0 context.Context, arg1 *wafv2.DeleteLoggingConfigurationInput, arg2 ...request.Option) (*wafv2.DeleteLoggingConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteLoggingConfigurationWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DeleteLoggingConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLoggingConfigurationWithContext indicates an expected call of DeleteLoggingConfigurationWithContext
+func (mr *MockWAFv2MockRecorder) DeleteLoggingConfigurationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoggingConfigurationWithContext", reflect.TypeOf((*MockWAFv2)(nil).DeleteLoggingConfigurationWithContext), varargs...)
+}
+
+// DeletePermissionPolicy mocks base method
+func (m *MockWAFv2) DeletePermissionPolicy(arg0 *wafv2.DeletePermissionPolicyInput) (*wafv2.DeletePermissionPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePermissionPolicy", arg0)
+	ret0, _ := ret[0].(*wafv2.DeletePermissionPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePermissionPolicy indicates an expected call of DeletePermissionPolicy
+func (mr *MockWAFv2MockRecorder) DeletePermissionPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermissionPolicy", reflect.TypeOf((*MockWAFv2)(nil).DeletePermissionPolicy), arg0)
+}
+
+// DeletePermissionPolicyRequest mocks base method
+func (m *MockWAFv2) DeletePermissionPolicyRequest(arg0 *wafv2.DeletePermissionPolicyInput) (*request.Request, *wafv2.DeletePermissionPolicyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePermissionPolicyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DeletePermissionPolicyOutput)
+	return ret0, ret1
+}
+
+// DeletePermissionPolicyRequest indicates an expected call of DeletePermissionPolicyRequest
+func (mr *MockWAFv2MockRecorder) DeletePermissionPolicyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermissionPolicyRequest", reflect.TypeOf((*MockWAFv2)(nil).DeletePermissionPolicyRequest), arg0)
+}
+
+// DeletePermissionPolicyWithContext mocks base method
+func (m *MockWAFv2) DeletePermissionPolicyWithContext(arg0 context.Context, arg1 *wafv2.DeletePermissionPolicyInput, arg2 ...request.Option) (*wafv2.DeletePermissionPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeletePermissionPolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DeletePermissionPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePermissionPolicyWithContext indicates an expected call of DeletePermissionPolicyWithContext
+func (mr *MockWAFv2MockRecorder) DeletePermissionPolicyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermissionPolicyWithContext", reflect.TypeOf((*MockWAFv2)(nil).DeletePermissionPolicyWithContext), varargs...)
+}
+
+// DeleteRegexPatternSet mocks base method
+func (m *MockWAFv2) DeleteRegexPatternSet(arg0 *wafv2.DeleteRegexPatternSetInput) (*wafv2.DeleteRegexPatternSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRegexPatternSet", arg0)
+	ret0, _ := ret[0].(*wafv2.DeleteRegexPatternSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRegexPatternSet indicates an expected call of DeleteRegexPatternSet
+func (mr *MockWAFv2MockRecorder) DeleteRegexPatternSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRegexPatternSet", reflect.TypeOf((*MockWAFv2)(nil).DeleteRegexPatternSet), arg0)
+}
+
+// DeleteRegexPatternSetRequest mocks base method
+func (m *MockWAFv2) DeleteRegexPatternSetRequest(arg0 *wafv2.DeleteRegexPatternSetInput) (*request.Request, *wafv2.DeleteRegexPatternSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRegexPatternSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DeleteRegexPatternSetOutput)
+	return ret0, ret1
+}
+
+// DeleteRegexPatternSetRequest indicates an expected call of DeleteRegexPatternSetRequest
+func (mr *MockWAFv2MockRecorder) DeleteRegexPatternSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRegexPatternSetRequest", reflect.TypeOf((*MockWAFv2)(nil).DeleteRegexPatternSetRequest), arg0)
+}
+
+// DeleteRegexPatternSetWithContext mocks base method
+func (m *MockWAFv2) DeleteRegexPatternSetWithContext(arg0 context.Context, arg1 *wafv2.DeleteRegexPatternSetInput, arg2 ...request.Option) (*wafv2.DeleteRegexPatternSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteRegexPatternSetWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DeleteRegexPatternSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRegexPatternSetWithContext indicates an expected call of DeleteRegexPatternSetWithContext
+func (mr *MockWAFv2MockRecorder) DeleteRegexPatternSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRegexPatternSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).DeleteRegexPatternSetWithContext), varargs...)
+}
+
+// DeleteRuleGroup mocks base method
+func (m *MockWAFv2) DeleteRuleGroup(arg0 *wafv2.DeleteRuleGroupInput) (*wafv2.DeleteRuleGroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRuleGroup", arg0)
+	ret0, _ := ret[0].(*wafv2.DeleteRuleGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRuleGroup indicates an expected call of DeleteRuleGroup
+func (mr *MockWAFv2MockRecorder) DeleteRuleGroup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRuleGroup", reflect.TypeOf((*MockWAFv2)(nil).DeleteRuleGroup), arg0)
+}
+
+// DeleteRuleGroupRequest mocks base method
+func (m *MockWAFv2) DeleteRuleGroupRequest(arg0 *wafv2.DeleteRuleGroupInput) (*request.Request, *wafv2.DeleteRuleGroupOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRuleGroupRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DeleteRuleGroupOutput)
+	return ret0, ret1
+}
+
+// DeleteRuleGroupRequest indicates an expected call of DeleteRuleGroupRequest
+func (mr *MockWAFv2MockRecorder) DeleteRuleGroupRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRuleGroupRequest", reflect.TypeOf((*MockWAFv2)(nil).DeleteRuleGroupRequest), arg0)
+}
+
+// DeleteRuleGroupWithContext mocks base method
+func (m *MockWAFv2) DeleteRuleGroupWithContext(arg0 context.Context, arg1 *wafv2.DeleteRuleGroupInput, arg2 ...request.Option) (*wafv2.DeleteRuleGroupOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteRuleGroupWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DeleteRuleGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRuleGroupWithContext indicates an expected call of DeleteRuleGroupWithContext
+func (mr *MockWAFv2MockRecorder) DeleteRuleGroupWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRuleGroupWithContext", reflect.TypeOf((*MockWAFv2)(nil).DeleteRuleGroupWithContext), varargs...)
+}
+
+// DeleteWebACL mocks base method
+func (m *MockWAFv2) DeleteWebACL(arg0 *wafv2.DeleteWebACLInput) (*wafv2.DeleteWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebACL", arg0)
+	ret0, _ := ret[0].(*wafv2.DeleteWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebACL indicates an expected call of DeleteWebACL
+func (mr *MockWAFv2MockRecorder) DeleteWebACL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebACL", reflect.TypeOf((*MockWAFv2)(nil).DeleteWebACL), arg0)
+}
+
+// DeleteWebACLRequest mocks base method
+func (m *MockWAFv2) DeleteWebACLRequest(arg0 *wafv2.DeleteWebACLInput) (*request.Request, *wafv2.DeleteWebACLOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebACLRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DeleteWebACLOutput)
+	return ret0, ret1
+}
+
+// DeleteWebACLRequest indicates an expected call of DeleteWebACLRequest
+func (mr *MockWAFv2MockRecorder) DeleteWebACLRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebACLRequest", reflect.TypeOf((*MockWAFv2)(nil).DeleteWebACLRequest), arg0)
+}
+
+// DeleteWebACLWithContext mocks base method
+func (m *MockWAFv2) DeleteWebACLWithContext(arg0 context.Context, arg1 *wafv2.DeleteWebACLInput, arg2 ...request.Option) (*wafv2.DeleteWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteWebACLWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DeleteWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebACLWithContext indicates an expected call of DeleteWebACLWithContext
+func (mr *MockWAFv2MockRecorder) DeleteWebACLWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).DeleteWebACLWithContext), varargs...)
+}
+
+// DescribeAllManagedProducts mocks base method
+func (m *MockWAFv2) DescribeAllManagedProducts(arg0 *wafv2.DescribeAllManagedProductsInput) (*wafv2.DescribeAllManagedProductsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAllManagedProducts", arg0)
+	ret0, _ := ret[0].(*wafv2.DescribeAllManagedProductsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAllManagedProducts indicates an expected call of DescribeAllManagedProducts
+func (mr *MockWAFv2MockRecorder) DescribeAllManagedProducts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAllManagedProducts", reflect.TypeOf((*MockWAFv2)(nil).DescribeAllManagedProducts), arg0)
+}
+
+// DescribeAllManagedProductsRequest mocks base method
+func (m *MockWAFv2) DescribeAllManagedProductsRequest(arg0 *wafv2.DescribeAllManagedProductsInput) (*request.Request, *wafv2.DescribeAllManagedProductsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAllManagedProductsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DescribeAllManagedProductsOutput)
+	return ret0, ret1
+}
+
+// DescribeAllManagedProductsRequest indicates an expected call of DescribeAllManagedProductsRequest
+func (mr *MockWAFv2MockRecorder) DescribeAllManagedProductsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAllManagedProductsRequest", reflect.TypeOf((*MockWAFv2)(nil).DescribeAllManagedProductsRequest), arg0)
+}
+
+// DescribeAllManagedProductsWithContext mocks base method
+func (m *MockWAFv2) DescribeAllManagedProductsWithContext(arg0 context.Context, arg1 *wafv2.DescribeAllManagedProductsInput, arg2 ...request.Option) (*wafv2.DescribeAllManagedProductsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeAllManagedProductsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DescribeAllManagedProductsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAllManagedProductsWithContext indicates an expected call of DescribeAllManagedProductsWithContext
+func (mr *MockWAFv2MockRecorder) DescribeAllManagedProductsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAllManagedProductsWithContext", reflect.TypeOf((*MockWAFv2)(nil).DescribeAllManagedProductsWithContext), varargs...)
+}
+
+// DescribeManagedProductsByVendor mocks base method
+func (m *MockWAFv2) DescribeManagedProductsByVendor(arg0 *wafv2.DescribeManagedProductsByVendorInput) (*wafv2.DescribeManagedProductsByVendorOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeManagedProductsByVendor", arg0)
+	ret0, _ := ret[0].(*wafv2.DescribeManagedProductsByVendorOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeManagedProductsByVendor indicates an expected call of DescribeManagedProductsByVendor
+func (mr *MockWAFv2MockRecorder) DescribeManagedProductsByVendor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeManagedProductsByVendor", reflect.TypeOf((*MockWAFv2)(nil).DescribeManagedProductsByVendor), arg0)
+}
+
+// DescribeManagedProductsByVendorRequest mocks base method
+func (m *MockWAFv2) DescribeManagedProductsByVendorRequest(arg0 *wafv2.DescribeManagedProductsByVendorInput) (*request.Request, *wafv2.DescribeManagedProductsByVendorOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeManagedProductsByVendorRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DescribeManagedProductsByVendorOutput)
+	return ret0, ret1
+}
+
+// DescribeManagedProductsByVendorRequest indicates an expected call of DescribeManagedProductsByVendorRequest
+func (mr *MockWAFv2MockRecorder) DescribeManagedProductsByVendorRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeManagedProductsByVendorRequest", reflect.TypeOf((*MockWAFv2)(nil).DescribeManagedProductsByVendorRequest), arg0)
+}
+
+// DescribeManagedProductsByVendorWithContext mocks base method
+func (m *MockWAFv2) DescribeManagedProductsByVendorWithContext(arg0 context.Context, arg1 *wafv2.DescribeManagedProductsByVendorInput, arg2 ...request.Option) (*wafv2.DescribeManagedProductsByVendorOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeManagedProductsByVendorWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DescribeManagedProductsByVendorOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeManagedProductsByVendorWithContext indicates an expected call of DescribeManagedProductsByVendorWithContext
+func (mr *MockWAFv2MockRecorder) DescribeManagedProductsByVendorWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeManagedProductsByVendorWithContext", reflect.TypeOf((*MockWAFv2)(nil).DescribeManagedProductsByVendorWithContext), varargs...)
+}
+
+// DescribeManagedRuleGroup mocks base method
+func (m *MockWAFv2) DescribeManagedRuleGroup(arg0 *wafv2.DescribeManagedRuleGroupInput) (*wafv2.DescribeManagedRuleGroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeManagedRuleGroup", arg0)
+	ret0, _ := ret[0].(*wafv2.DescribeManagedRuleGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeManagedRuleGroup indicates an expected call of DescribeManagedRuleGroup
+func (mr *MockWAFv2MockRecorder) DescribeManagedRuleGroup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeManagedRuleGroup", reflect.TypeOf((*MockWAFv2)(nil).DescribeManagedRuleGroup), arg0)
+}
+
+// DescribeManagedRuleGroupRequest mocks base method
+func (m *MockWAFv2) DescribeManagedRuleGroupRequest(arg0 *wafv2.DescribeManagedRuleGroupInput) (*request.Request, *wafv2.DescribeManagedRuleGroupOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeManagedRuleGroupRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DescribeManagedRuleGroupOutput)
+	return ret0, ret1
+}
+
+// DescribeManagedRuleGroupRequest indicates an expected call of DescribeManagedRuleGroupRequest
+func (mr *MockWAFv2MockRecorder) DescribeManagedRuleGroupRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeManagedRuleGroupRequest", reflect.TypeOf((*MockWAFv2)(nil).DescribeManagedRuleGroupRequest), arg0)
+}
+
+// DescribeManagedRuleGroupWithContext mocks base method
+func (m *MockWAFv2) DescribeManagedRuleGroupWithContext(arg0 context.Context, arg1 *wafv2.DescribeManagedRuleGroupInput, arg2 ...request.Option) (*wafv2.DescribeManagedRuleGroupOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeManagedRuleGroupWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DescribeManagedRuleGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeManagedRuleGroupWithContext indicates an expected call of DescribeManagedRuleGroupWithContext
+func (mr *MockWAFv2MockRecorder) DescribeManagedRuleGroupWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeManagedRuleGroupWithContext", reflect.TypeOf((*MockWAFv2)(nil).DescribeManagedRuleGroupWithContext), varargs...)
+}
+
+// DisassociateWebACL mocks base method
+func (m *MockWAFv2) DisassociateWebACL(arg0 *wafv2.DisassociateWebACLInput) (*wafv2.DisassociateWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateWebACL", arg0)
+	ret0, _ := ret[0].(*wafv2.DisassociateWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisassociateWebACL indicates an expected call of DisassociateWebACL
+func (mr *MockWAFv2MockRecorder) DisassociateWebACL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateWebACL", reflect.TypeOf((*MockWAFv2)(nil).DisassociateWebACL), arg0)
+}
+
+// DisassociateWebACLRequest mocks base method
+func (m *MockWAFv2) DisassociateWebACLRequest(arg0 *wafv2.DisassociateWebACLInput) (*request.Request, *wafv2.DisassociateWebACLOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateWebACLRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.DisassociateWebACLOutput)
+	return ret0, ret1
+}
+
+// DisassociateWebACLRequest indicates an expected call of DisassociateWebACLRequest
+func (mr *MockWAFv2MockRecorder) DisassociateWebACLRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateWebACLRequest", reflect.TypeOf((*MockWAFv2)(nil).DisassociateWebACLRequest), arg0)
+}
+
+// DisassociateWebACLWithContext mocks base method
+func (m *MockWAFv2) DisassociateWebACLWithContext(arg0 context.Context, arg1 *wafv2.DisassociateWebACLInput, arg2 ...request.Option) (*wafv2.DisassociateWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisassociateWebACLWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.DisassociateWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisassociateWebACLWithContext indicates an expected call of DisassociateWebACLWithContext
+func (mr *MockWAFv2MockRecorder) DisassociateWebACLWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).DisassociateWebACLWithContext), varargs...)
+}
+
+// GenerateMobileSdkReleaseUrl mocks base method
+func (m *MockWAFv2) GenerateMobileSdkReleaseUrl(arg0 *wafv2.GenerateMobileSdkReleaseUrlInput) (*wafv2.GenerateMobileSdkReleaseUrlOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateMobileSdkReleaseUrl", arg0)
+	ret0, _ := ret[0].(*wafv2.GenerateMobileSdkReleaseUrlOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateMobileSdkReleaseUrl indicates an expected call of GenerateMobileSdkReleaseUrl
+func (mr *MockWAFv2MockRecorder) GenerateMobileSdkReleaseUrl(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateMobileSdkReleaseUrl", reflect.TypeOf((*MockWAFv2)(nil).GenerateMobileSdkReleaseUrl), arg0)
+}
+
+// GenerateMobileSdkReleaseUrlRequest mocks base method
+func (m *MockWAFv2) GenerateMobileSdkReleaseUrlRequest(arg0 *wafv2.GenerateMobileSdkReleaseUrlInput) (*request.Request, *wafv2.GenerateMobileSdkReleaseUrlOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateMobileSdkReleaseUrlRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GenerateMobileSdkReleaseUrlOutput)
+	return ret0, ret1
+}
+
+// GenerateMobileSdkReleaseUrlRequest indicates an expected call of GenerateMobileSdkReleaseUrlRequest
+func (mr *MockWAFv2MockRecorder) GenerateMobileSdkReleaseUrlRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateMobileSdkReleaseUrlRequest", reflect.TypeOf((*MockWAFv2)(nil).GenerateMobileSdkReleaseUrlRequest), arg0)
+}
+
+// GenerateMobileSdkReleaseUrlWithContext mocks base method
+func (m *MockWAFv2) GenerateMobileSdkReleaseUrlWithContext(arg0 context.Context, arg1 *wafv2.GenerateMobileSdkReleaseUrlInput, arg2 ...request.Option) (*wafv2.GenerateMobileSdkReleaseUrlOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GenerateMobileSdkReleaseUrlWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GenerateMobileSdkReleaseUrlOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateMobileSdkReleaseUrlWithContext indicates an expected call of GenerateMobileSdkReleaseUrlWithContext
+func (mr *MockWAFv2MockRecorder) GenerateMobileSdkReleaseUrlWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateMobileSdkReleaseUrlWithContext", reflect.TypeOf((*MockWAFv2)(nil).GenerateMobileSdkReleaseUrlWithContext), varargs...)
+}
+
+// GetDecryptedAPIKey mocks base method
+func (m *MockWAFv2) GetDecryptedAPIKey(arg0 *wafv2.GetDecryptedAPIKeyInput) (*wafv2.GetDecryptedAPIKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDecryptedAPIKey", arg0)
+	ret0, _ := ret[0].(*wafv2.GetDecryptedAPIKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDecryptedAPIKey indicates an expected call of GetDecryptedAPIKey
+func (mr *MockWAFv2MockRecorder) GetDecryptedAPIKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDecryptedAPIKey", reflect.TypeOf((*MockWAFv2)(nil).GetDecryptedAPIKey), arg0)
+}
+
+// GetDecryptedAPIKeyRequest mocks base method
+func (m *MockWAFv2) GetDecryptedAPIKeyRequest(arg0 *wafv2.GetDecryptedAPIKeyInput) (*request.Request, *wafv2.GetDecryptedAPIKeyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDecryptedAPIKeyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetDecryptedAPIKeyOutput)
+	return ret0, ret1
+}
+
+// GetDecryptedAPIKeyRequest indicates an expected call of GetDecryptedAPIKeyRequest
+func (mr *MockWAFv2MockRecorder) GetDecryptedAPIKeyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDecryptedAPIKeyRequest", reflect.TypeOf((*MockWAFv2)(nil).GetDecryptedAPIKeyRequest), arg0)
+}
+
+// GetDecryptedAPIKeyWithContext mocks base method
+func (m *MockWAFv2) GetDecryptedAPIKeyWithContext(arg0 context.Context, arg1 *wafv2.GetDecryptedAPIKeyInput, arg2 ...request.Option) (*wafv2.GetDecryptedAPIKeyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDecryptedAPIKeyWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetDecryptedAPIKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDecryptedAPIKeyWithContext indicates an expected call of GetDecryptedAPIKeyWithContext
+func (mr *MockWAFv2MockRecorder) GetDecryptedAPIKeyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDecryptedAPIKeyWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetDecryptedAPIKeyWithContext), varargs...)
+}
+
+// GetIPSet mocks base method
+func (m *MockWAFv2) GetIPSet(arg0 *wafv2.GetIPSetInput) (*wafv2.GetIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIPSet", arg0)
+	ret0, _ := ret[0].(*wafv2.GetIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIPSet indicates an expected call of GetIPSet
+func (mr *MockWAFv2MockRecorder) GetIPSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPSet", reflect.TypeOf((*MockWAFv2)(nil).GetIPSet), arg0)
+}
+
+// GetIPSetRequest mocks base method
+func (m *MockWAFv2) GetIPSetRequest(arg0 *wafv2.GetIPSetInput) (*request.Request, *wafv2.GetIPSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIPSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetIPSetOutput)
+	return ret0, ret1
+}
+
+// GetIPSetRequest indicates an expected call of GetIPSetRequest
+func (mr *MockWAFv2MockRecorder) GetIPSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPSetRequest", reflect.TypeOf((*MockWAFv2)(nil).GetIPSetRequest), arg0)
+}
+
+// GetIPSetWithContext mocks base method
+func (m *MockWAFv2) GetIPSetWithContext(arg0 context.Context, arg1 *wafv2.GetIPSetInput, arg2 ...request.Option) (*wafv2.GetIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetIPSetWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIPSetWithContext indicates an expected call of GetIPSetWithContext
+func (mr *MockWAFv2MockRecorder) GetIPSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetIPSetWithContext), varargs...)
+}
+
+// GetLoggingConfiguration mocks base method
+func (m *MockWAFv2) GetLoggingConfiguration(arg0 *wafv2.GetLoggingConfigurationInput) (*wafv2.GetLoggingConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoggingConfiguration", arg0)
+	ret0, _ := ret[0].(*wafv2.GetLoggingConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoggingConfiguration indicates an expected call of GetLoggingConfiguration
+func (mr *MockWAFv2MockRecorder) GetLoggingConfiguration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoggingConfiguration", reflect.TypeOf((*MockWAFv2)(nil).GetLoggingConfiguration), arg0)
+}
+
+// GetLoggingConfigurationRequest mocks base method
+func (m *MockWAFv2) GetLoggingConfigurationRequest(arg0 *wafv2.GetLoggingConfigurationInput) (*request.Request, *wafv2.GetLoggingConfigurationOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoggingConfigurationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetLoggingConfigurationOutput)
+	return ret0, ret1
+}
+
+// GetLoggingConfigurationRequest indicates an expected call of GetLoggingConfigurationRequest
+func (mr *MockWAFv2MockRecorder) GetLoggingConfigurationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoggingConfigurationRequest", reflect.TypeOf((*MockWAFv2)(nil).GetLoggingConfigurationRequest), arg0)
+}
+
+// GetLoggingConfigurationWithContext mocks base method
+func (m *MockWAFv2) GetLoggingConfigurationWithContext(arg0 context.Context, arg1 *wafv2.GetLoggingConfigurationInput, arg2 ...request.Option) (*wafv2.GetLoggingConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLoggingConfigurationWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetLoggingConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoggingConfigurationWithContext indicates an expected call of GetLoggingConfigurationWithContext
+func (mr *MockWAFv2MockRecorder) GetLoggingConfigurationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoggingConfigurationWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetLoggingConfigurationWithContext), varargs...)
+}
+
+// GetManagedRuleSet mocks base method
+func (m *MockWAFv2) GetManagedRuleSet(arg0 *wafv2.GetManagedRuleSetInput) (*wafv2.GetManagedRuleSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManagedRuleSet", arg0)
+	ret0, _ := ret[0].(*wafv2.GetManagedRuleSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManagedRuleSet indicates an expected call of GetManagedRuleSet
+func (mr *MockWAFv2MockRecorder) GetManagedRuleSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagedRuleSet", reflect.TypeOf((*MockWAFv2)(nil).GetManagedRuleSet), arg0)
+}
+
+// GetManagedRuleSetRequest mocks base method
+func (m *MockWAFv2) GetManagedRuleSetRequest(arg0 *wafv2.GetManagedRuleSetInput) (*request.Request, *wafv2.GetManagedRuleSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManagedRuleSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetManagedRuleSetOutput)
+	return ret0, ret1
+}
+
+// GetManagedRuleSetRequest indicates an expected call of GetManagedRuleSetRequest
+func (mr *MockWAFv2MockRecorder) GetManagedRuleSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagedRuleSetRequest", reflect.TypeOf((*MockWAFv2)(nil).GetManagedRuleSetRequest), arg0)
+}
+
+// GetManagedRuleSetWithContext mocks base method
+func (m *MockWAFv2) GetManagedRuleSetWithContext(arg0 context.Context, arg1 *wafv2.GetManagedRuleSetInput, arg2 ...request.Option) (*wafv2.GetManagedRuleSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetManagedRuleSetWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetManagedRuleSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManagedRuleSetWithContext indicates an expected call of GetManagedRuleSetWithContext
+func (mr *MockWAFv2MockRecorder) GetManagedRuleSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagedRuleSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetManagedRuleSetWithContext), varargs...)
+}
+
+// GetMobileSdkRelease mocks base method
+func (m *MockWAFv2) GetMobileSdkRelease(arg0 *wafv2.GetMobileSdkReleaseInput) (*wafv2.GetMobileSdkReleaseOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMobileSdkRelease", arg0)
+	ret0, _ := ret[0].(*wafv2.GetMobileSdkReleaseOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMobileSdkRelease indicates an expected call of GetMobileSdkRelease
+func (mr *MockWAFv2MockRecorder) GetMobileSdkRelease(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMobileSdkRelease", reflect.TypeOf((*MockWAFv2)(nil).GetMobileSdkRelease), arg0)
+}
+
+// GetMobileSdkReleaseRequest mocks base method
+func (m *MockWAFv2) GetMobileSdkReleaseRequest(arg0 *wafv2.GetMobileSdkReleaseInput) (*request.Request, *wafv2.GetMobileSdkReleaseOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMobileSdkReleaseRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetMobileSdkReleaseOutput)
+	return ret0, ret1
+}
+
+// GetMobileSdkReleaseRequest indicates an expected call of GetMobileSdkReleaseRequest
+func (mr *MockWAFv2MockRecorder) GetMobileSdkReleaseRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMobileSdkReleaseRequest", reflect.TypeOf((*MockWAFv2)(nil).GetMobileSdkReleaseRequest), arg0)
+}
+
+// GetMobileSdkReleaseWithContext mocks base method
+func (m *MockWAFv2) GetMobileSdkReleaseWithContext(arg0 context.Context, arg1 *wafv2.GetMobileSdkReleaseInput, arg2 ...request.Option) (*wafv2.GetMobileSdkReleaseOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMobileSdkReleaseWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetMobileSdkReleaseOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMobileSdkReleaseWithContext indicates an expected call of GetMobileSdkReleaseWithContext
+func (mr *MockWAFv2MockRecorder) GetMobileSdkReleaseWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMobileSdkReleaseWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetMobileSdkReleaseWithContext), varargs...)
+}
+
+// GetPermissionPolicy mocks base method
+func (m *MockWAFv2) GetPermissionPolicy(arg0 *wafv2.GetPermissionPolicyInput) (*wafv2.GetPermissionPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissionPolicy", arg0)
+	ret0, _ := ret[0].(*wafv2.GetPermissionPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissionPolicy indicates an expected call of GetPermissionPolicy
+func (mr *MockWAFv2MockRecorder) GetPermissionPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionPolicy", reflect.TypeOf((*MockWAFv2)(nil).GetPermissionPolicy), arg0)
+}
+
+// GetPermissionPolicyRequest mocks base method
+func (m *MockWAFv2) GetPermissionPolicyRequest(arg0 *wafv2.GetPermissionPolicyInput) (*request.Request, *wafv2.GetPermissionPolicyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissionPolicyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetPermissionPolicyOutput)
+	return ret0, ret1
+}
+
+// GetPermissionPolicyRequest indicates an expected call of GetPermissionPolicyRequest
+func (mr *MockWAFv2MockRecorder) GetPermissionPolicyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionPolicyRequest", reflect.TypeOf((*MockWAFv2)(nil).GetPermissionPolicyRequest), arg0)
+}
+
+// GetPermissionPolicyWithContext mocks base method
+func (m *MockWAFv2) GetPermissionPolicyWithContext(arg0 context.Context, arg1 *wafv2.GetPermissionPolicyInput, arg2 ...request.Option) (*wafv2.GetPermissionPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPermissionPolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetPermissionPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissionPolicyWithContext indicates an expected call of GetPermissionPolicyWithContext
+func (mr *MockWAFv2MockRecorder) GetPermissionPolicyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionPolicyWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetPermissionPolicyWithContext), varargs...)
+}
+
+// GetRateBasedStatementManagedKeys mocks base method
+func (m *MockWAFv2) GetRateBasedStatementManagedKeys(arg0 *wafv2.GetRateBasedStatementManagedKeysInput) (*wafv2.GetRateBasedStatementManagedKeysOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateBasedStatementManagedKeys", arg0)
+	ret0, _ := ret[0].(*wafv2.GetRateBasedStatementManagedKeysOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateBasedStatementManagedKeys indicates an expected call of GetRateBasedStatementManagedKeys
+func (mr *MockWAFv2MockRecorder) GetRateBasedStatementManagedKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateBasedStatementManagedKeys", reflect.TypeOf((*MockWAFv2)(nil).GetRateBasedStatementManagedKeys), arg0)
+}
+
+// GetRateBasedStatementManagedKeysRequest mocks base method
+func (m *MockWAFv2) GetRateBasedStatementManagedKeysRequest(arg0 *wafv2.GetRateBasedStatementManagedKeysInput) (*request.Request, *wafv2.GetRateBasedStatementManagedKeysOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateBasedStatementManagedKeysRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetRateBasedStatementManagedKeysOutput)
+	return ret0, ret1
+}
+
+// GetRateBasedStatementManagedKeysRequest indicates an expected call of GetRateBasedStatementManagedKeysRequest
+func (mr *MockWAFv2MockRecorder) GetRateBasedStatementManagedKeysRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateBasedStatementManagedKeysRequest", reflect.TypeOf((*MockWAFv2)(nil).GetRateBasedStatementManagedKeysRequest), arg0)
+}
+
+// GetRateBasedStatementManagedKeysWithContext mocks base method
+func (m *MockWAFv2) GetRateBasedStatementManagedKeysWithContext(arg0 context.Context, arg1 *wafv2.GetRateBasedStatementManagedKeysInput, arg2 ...request.Option) (*wafv2.GetRateBasedStatementManagedKeysOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRateBasedStatementManagedKeysWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetRateBasedStatementManagedKeysOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateBasedStatementManagedKeysWithContext indicates an expected call of GetRateBasedStatementManagedKeysWithContext
+func (mr *MockWAFv2MockRecorder) GetRateBasedStatementManagedKeysWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateBasedStatementManagedKeysWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetRateBasedStatementManagedKeysWithContext), varargs...)
+}
+
+// GetRegexPatternSet mocks base method
+func (m *MockWAFv2) GetRegexPatternSet(arg0 *wafv2.GetRegexPatternSetInput) (*wafv2.GetRegexPatternSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegexPatternSet", arg0)
+	ret0, _ := ret[0].(*wafv2.GetRegexPatternSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegexPatternSet indicates an expected call of GetRegexPatternSet
+func (mr *MockWAFv2MockRecorder) GetRegexPatternSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegexPatternSet", reflect.TypeOf((*MockWAFv2)(nil).GetRegexPatternSet), arg0)
+}
+
+// GetRegexPatternSetRequest mocks base method
+func (m *MockWAFv2) GetRegexPatternSetRequest(arg0 *wafv2.GetRegexPatternSetInput) (*request.Request, *wafv2.GetRegexPatternSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegexPatternSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetRegexPatternSetOutput)
+	return ret0, ret1
+}
+
+// GetRegexPatternSetRequest indicates an expected call of GetRegexPatternSetRequest
+func (mr *MockWAFv2MockRecorder) GetRegexPatternSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegexPatternSetRequest", reflect.TypeOf((*MockWAFv2)(nil).GetRegexPatternSetRequest), arg0)
+}
+
+// GetRegexPatternSetWithContext mocks base method
+func (m *MockWAFv2) GetRegexPatternSetWithContext(arg0 context.Context, arg1 *wafv2.GetRegexPatternSetInput, arg2 ...request.Option) (*wafv2.GetRegexPatternSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRegexPatternSetWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetRegexPatternSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegexPatternSetWithContext indicates an expected call of GetRegexPatternSetWithContext
+func (mr *MockWAFv2MockRecorder) GetRegexPatternSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegexPatternSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetRegexPatternSetWithContext), varargs...)
+}
+
+// GetRuleGroup mocks base method
+func (m *MockWAFv2) GetRuleGroup(arg0 *wafv2.GetRuleGroupInput) (*wafv2.GetRuleGroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleGroup", arg0)
+	ret0, _ := ret[0].(*wafv2.GetRuleGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleGroup indicates an expected call of GetRuleGroup
+func (mr *MockWAFv2MockRecorder) GetRuleGroup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleGroup", reflect.TypeOf((*MockWAFv2)(nil).GetRuleGroup), arg0)
+}
+
+// GetRuleGroupRequest mocks base method
+func (m *MockWAFv2) GetRuleGroupRequest(arg0 *wafv2.GetRuleGroupInput) (*request.Request, *wafv2.GetRuleGroupOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleGroupRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetRuleGroupOutput)
+	return ret0, ret1
+}
+
+// GetRuleGroupRequest indicates an expected call of GetRuleGroupRequest
+func (mr *MockWAFv2MockRecorder) GetRuleGroupRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleGroupRequest", reflect.TypeOf((*MockWAFv2)(nil).GetRuleGroupRequest), arg0)
+}
+
+// GetRuleGroupWithContext mocks base method
+func (m *MockWAFv2) GetRuleGroupWithContext(arg0 context.Context, arg1 *wafv2.GetRuleGroupInput, arg2 ...request.Option) (*wafv2.GetRuleGroupOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRuleGroupWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetRuleGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleGroupWithContext indicates an expected call of GetRuleGroupWithContext
+func (mr *MockWAFv2MockRecorder) GetRuleGroupWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleGroupWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetRuleGroupWithContext), varargs...)
+}
+
+// GetSampledRequests mocks base method
+func (m *MockWAFv2) GetSampledRequests(arg0 *wafv2.GetSampledRequestsInput) (*wafv2.GetSampledRequestsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSampledRequests", arg0)
+	ret0, _ := ret[0].(*wafv2.GetSampledRequestsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSampledRequests indicates an expected call of GetSampledRequests
+func (mr *MockWAFv2MockRecorder) GetSampledRequests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSampledRequests", reflect.TypeOf((*MockWAFv2)(nil).GetSampledRequests), arg0)
+}
+
+// GetSampledRequestsRequest mocks base method
+func (m *MockWAFv2) GetSampledRequestsRequest(arg0 *wafv2.GetSampledRequestsInput) (*request.Request, *wafv2.GetSampledRequestsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSampledRequestsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetSampledRequestsOutput)
+	return ret0, ret1
+}
+
+// GetSampledRequestsRequest indicates an expected call of GetSampledRequestsRequest
+func (mr *MockWAFv2MockRecorder) GetSampledRequestsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSampledRequestsRequest", reflect.TypeOf((*MockWAFv2)(nil).GetSampledRequestsRequest), arg0)
+}
+
+// GetSampledRequestsWithContext mocks base method
+func (m *MockWAFv2) GetSampledRequestsWithContext(arg0 context.Context, arg1 *wafv2.GetSampledRequestsInput, arg2 ...request.Option) (*wafv2.GetSampledRequestsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSampledRequestsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetSampledRequestsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSampledRequestsWithContext indicates an expected call of GetSampledRequestsWithContext
+func (mr *MockWAFv2MockRecorder) GetSampledRequestsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSampledRequestsWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetSampledRequestsWithContext), varargs...)
+}
+
+// GetWebACL mocks base method
+func (m *MockWAFv2) GetWebACL(arg0 *wafv2.GetWebACLInput) (*wafv2.GetWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebACL", arg0)
+	ret0, _ := ret[0].(*wafv2.GetWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebACL indicates an expected call of GetWebACL
+func (mr *MockWAFv2MockRecorder) GetWebACL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebACL", reflect.TypeOf((*MockWAFv2)(nil).GetWebACL), arg0)
+}
+
+// GetWebACLForResource mocks base method
+func (m *MockWAFv2) GetWebACLForResource(arg0 *wafv2.GetWebACLForResourceInput) (*wafv2.GetWebACLForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebACLForResource", arg0)
+	ret0, _ := ret[0].(*wafv2.GetWebACLForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebACLForResource indicates an expected call of GetWebACLForResource
+func (mr *MockWAFv2MockRecorder) GetWebACLForResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebACLForResource", reflect.TypeOf((*MockWAFv2)(nil).GetWebACLForResource), arg0)
+}
+
+// GetWebACLForResourceRequest mocks base method
+func (m *MockWAFv2) GetWebACLForResourceRequest(arg0 *wafv2.GetWebACLForResourceInput) (*request.Request, *wafv2.GetWebACLForResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebACLForResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetWebACLForResourceOutput)
+	return ret0, ret1
+}
+
+// GetWebACLForResourceRequest indicates an expected call of GetWebACLForResourceRequest
+func (mr *MockWAFv2MockRecorder) GetWebACLForResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebACLForResourceRequest", reflect.TypeOf((*MockWAFv2)(nil).GetWebACLForResourceRequest), arg0)
+}
+
+// GetWebACLForResourceWithContext mocks base method
+func (m *MockWAFv2) GetWebACLForResourceWithContext(arg0 context.Context, arg1 *wafv2.GetWebACLForResourceInput, arg2 ...request.Option) (*wafv2.GetWebACLForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetWebACLForResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetWebACLForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebACLForResourceWithContext indicates an expected call of GetWebACLForResourceWithContext
+func (mr *MockWAFv2MockRecorder) GetWebACLForResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebACLForResourceWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetWebACLForResourceWithContext), varargs...)
+}
+
+// GetWebACLRequest mocks base method
+func (m *MockWAFv2) GetWebACLRequest(arg0 *wafv2.GetWebACLInput) (*request.Request, *wafv2.GetWebACLOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebACLRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.GetWebACLOutput)
+	return ret0, ret1
+}
+
+// GetWebACLRequest indicates an expected call of GetWebACLRequest
+func (mr *MockWAFv2MockRecorder) GetWebACLRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebACLRequest", reflect.TypeOf((*MockWAFv2)(nil).GetWebACLRequest), arg0)
+}
+
+// GetWebACLWithContext mocks base method
+func (m *MockWAFv2) GetWebACLWithContext(arg0 context.Context, arg1 *wafv2.GetWebACLInput, arg2 ...request.Option) (*wafv2.GetWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetWebACLWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.GetWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebACLWithContext indicates an expected call of GetWebACLWithContext
+func (mr *MockWAFv2MockRecorder) GetWebACLWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetWebACLWithContext), varargs...)
+}
+
+// ListAPIKeys mocks base method
+func (m *MockWAFv2) ListAPIKeys(arg0 *wafv2.ListAPIKeysInput) (*wafv2.ListAPIKeysOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", arg0)
+	ret0, _ := ret[0].(*wafv2.ListAPIKeysOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys
+func (mr *MockWAFv2MockRecorder) ListAPIKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockWAFv2)(nil).ListAPIKeys), arg0)
+}
+
+// ListAPIKeysRequest mocks base method
+func (m *MockWAFv2) ListAPIKeysRequest(arg0 *wafv2.ListAPIKeysInput) (*request.Request, *wafv2.ListAPIKeysOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeysRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListAPIKeysOutput)
+	return ret0, ret1
+}
+
+// ListAPIKeysRequest indicates an expected call of ListAPIKeysRequest
+func (mr *MockWAFv2MockRecorder) ListAPIKeysRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeysRequest", reflect.TypeOf((*MockWAFv2)(nil).ListAPIKeysRequest), arg0)
+}
+
+// ListAPIKeysWithContext mocks base method
+func (m *MockWAFv2) ListAPIKeysWithContext(arg0 context.Context, arg1 *wafv2.ListAPIKeysInput, arg2 ...request.Option) (*wafv2.ListAPIKeysOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAPIKeysWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListAPIKeysOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeysWithContext indicates an expected call of ListAPIKeysWithContext
+func (mr *MockWAFv2MockRecorder) ListAPIKeysWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeysWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListAPIKeysWithContext), varargs...)
+}
+
+// ListAvailableManagedRuleGroupVersions mocks base method
+func (m *MockWAFv2) ListAvailableManagedRuleGroupVersions(arg0 *wafv2.ListAvailableManagedRuleGroupVersionsInput) (*wafv2.ListAvailableManagedRuleGroupVersionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvailableManagedRuleGroupVersions", arg0)
+	ret0, _ := ret[0].(*wafv2.ListAvailableManagedRuleGroupVersionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailableManagedRuleGroupVersions indicates an expected call of ListAvailableManagedRuleGroupVersions
+func (mr *MockWAFv2MockRecorder) ListAvailableManagedRuleGroupVersions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableManagedRuleGroupVersions", reflect.TypeOf((*MockWAFv2)(nil).ListAvailableManagedRuleGroupVersions), arg0)
+}
+
+// ListAvailableManagedRuleGroupVersionsRequest mocks base method
+func (m *MockWAFv2) ListAvailableManagedRuleGroupVersionsRequest(arg0 *wafv2.ListAvailableManagedRuleGroupVersionsInput) (*request.Request, *wafv2.ListAvailableManagedRuleGroupVersionsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvailableManagedRuleGroupVersionsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListAvailableManagedRuleGroupVersionsOutput)
+	return ret0, ret1
+}
+
+// ListAvailableManagedRuleGroupVersionsRequest indicates an expected call of ListAvailableManagedRuleGroupVersionsRequest
+func (mr *MockWAFv2MockRecorder) ListAvailableManagedRuleGroupVersionsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableManagedRuleGroupVersionsRequest", reflect.TypeOf((*MockWAFv2)(nil).ListAvailableManagedRuleGroupVersionsRequest), arg0)
+}
+
+// ListAvailableManagedRuleGroupVersionsWithContext mocks base method
+func (m *MockWAFv2) ListAvailableManagedRuleGroupVersionsWithContext(arg0 context.Context, arg1 *wafv2.ListAvailableManagedRuleGroupVersionsInput, arg2 ...request.Option) (*wafv2.ListAvailableManagedRuleGroupVersionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAvailableManagedRuleGroupVersionsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListAvailableManagedRuleGroupVersionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailableManagedRuleGroupVersionsWithContext indicates an expected call of ListAvailableManagedRuleGroupVersionsWithContext
+func (mr *MockWAFv2MockRecorder) ListAvailableManagedRuleGroupVersionsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableManagedRuleGroupVersionsWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListAvailableManagedRuleGroupVersionsWithContext), varargs...)
+}
+
+// ListAvailableManagedRuleGroups mocks base method
+func (m *MockWAFv2) ListAvailableManagedRuleGroups(arg0 *wafv2.ListAvailableManagedRuleGroupsInput) (*wafv2.ListAvailableManagedRuleGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvailableManagedRuleGroups", arg0)
+	ret0, _ := ret[0].(*wafv2.ListAvailableManagedRuleGroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailableManagedRuleGroups indicates an expected call of ListAvailableManagedRuleGroups
+func (mr *MockWAFv2MockRecorder) ListAvailableManagedRuleGroups(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableManagedRuleGroups", reflect.TypeOf((*MockWAFv2)(nil).ListAvailableManagedRuleGroups), arg0)
+}
+
+// ListAvailableManagedRuleGroupsRequest mocks base method
+func (m *MockWAFv2) ListAvailableManagedRuleGroupsRequest(arg0 *wafv2.ListAvailableManagedRuleGroupsInput) (*request.Request, *wafv2.ListAvailableManagedRuleGroupsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvailableManagedRuleGroupsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListAvailableManagedRuleGroupsOutput)
+	return ret0, ret1
+}
+
+// ListAvailableManagedRuleGroupsRequest indicates an expected call of ListAvailableManagedRuleGroupsRequest
+func (mr *MockWAFv2MockRecorder) ListAvailableManagedRuleGroupsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableManagedRuleGroupsRequest", reflect.TypeOf((*MockWAFv2)(nil).ListAvailableManagedRuleGroupsRequest), arg0)
+}
+
+// ListAvailableManagedRuleGroupsWithContext mocks base method
+func (m *MockWAFv2) ListAvailableManagedRuleGroupsWithContext(arg0 context.Context, arg1 *wafv2.ListAvailableManagedRuleGroupsInput, arg2 ...request.Option) (*wafv2.ListAvailableManagedRuleGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAvailableManagedRuleGroupsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListAvailableManagedRuleGroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailableManagedRuleGroupsWithContext indicates an expected call of ListAvailableManagedRuleGroupsWithContext
+func (mr *MockWAFv2MockRecorder) ListAvailableManagedRuleGroupsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableManagedRuleGroupsWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListAvailableManagedRuleGroupsWithContext), varargs...)
+}
+
+// ListIPSets mocks base method
+func (m *MockWAFv2) ListIPSets(arg0 *wafv2.ListIPSetsInput) (*wafv2.ListIPSetsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIPSets", arg0)
+	ret0, _ := ret[0].(*wafv2.ListIPSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIPSets indicates an expected call of ListIPSets
+func (mr *MockWAFv2MockRecorder) ListIPSets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIPSets", reflect.TypeOf((*MockWAFv2)(nil).ListIPSets), arg0)
+}
+
+// ListIPSetsRequest mocks base method
+func (m *MockWAFv2) ListIPSetsRequest(arg0 *wafv2.ListIPSetsInput) (*request.Request, *wafv2.ListIPSetsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIPSetsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListIPSetsOutput)
+	return ret0, ret1
+}
+
+// ListIPSetsRequest indicates an expected call of ListIPSetsRequest
+func (mr *MockWAFv2MockRecorder) ListIPSetsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIPSetsRequest", reflect.TypeOf((*MockWAFv2)(nil).ListIPSetsRequest), arg0)
+}
+
+// ListIPSetsWithContext mocks base method
+func (m *MockWAFv2) ListIPSetsWithContext(arg0 context.Context, arg1 *wafv2.ListIPSetsInput, arg2 ...request.Option) (*wafv2.ListIPSetsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListIPSetsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListIPSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIPSetsWithContext indicates an expected call of ListIPSetsWithContext
+func (mr *MockWAFv2MockRecorder) ListIPSetsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIPSetsWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListIPSetsWithContext), varargs...)
+}
+
+// ListLoggingConfigurations mocks base method
+func (m *MockWAFv2) ListLoggingConfigurations(arg0 *wafv2.ListLoggingConfigurationsInput) (*wafv2.ListLoggingConfigurationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoggingConfigurations", arg0)
+	ret0, _ := ret[0].(*wafv2.ListLoggingConfigurationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoggingConfigurations indicates an expected call of ListLoggingConfigurations
+func (mr *MockWAFv2MockRecorder) ListLoggingConfigurations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoggingConfigurations", reflect.TypeOf((*MockWAFv2)(nil).ListLoggingConfigurations), arg0)
+}
+
+// ListLoggingConfigurationsRequest mocks base method
+func (m *MockWAFv2) ListLoggingConfigurationsRequest(arg0 *wafv2.ListLoggingConfigurationsInput) (*request.Request, *wafv2.ListLoggingConfigurationsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoggingConfigurationsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListLoggingConfigurationsOutput)
+	return ret0, ret1
+}
+
+// ListLoggingConfigurationsRequest indicates an expected call of ListLoggingConfigurationsRequest
+func (mr *MockWAFv2MockRecorder) ListLoggingConfigurationsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoggingConfigurationsRequest", reflect.TypeOf((*MockWAFv2)(nil).ListLoggingConfigurationsRequest), arg0)
+}
+
+// ListLoggingConfigurationsWithContext mocks base method
+func (m *MockWAFv2) ListLoggingConfigurationsWithContext(arg0 context.Context, arg1 *wafv2.ListLoggingConfigurationsInput, arg2 ...request.Option) (*wafv2.ListLoggingConfigurationsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListLoggingConfigurationsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListLoggingConfigurationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoggingConfigurationsWithContext indicates an expected call of ListLoggingConfigurationsWithContext
+func (mr *MockWAFv2MockRecorder) ListLoggingConfigurationsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoggingConfigurationsWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListLoggingConfigurationsWithContext), varargs...)
+}
+
+// ListManagedRuleSets mocks base method
+func (m *MockWAFv2) ListManagedRuleSets(arg0 *wafv2.ListManagedRuleSetsInput) (*wafv2.ListManagedRuleSetsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListManagedRuleSets", arg0)
+	ret0, _ := ret[0].(*wafv2.ListManagedRuleSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListManagedRuleSets indicates an expected call of ListManagedRuleSets
+func (mr *MockWAFv2MockRecorder) ListManagedRuleSets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListManagedRuleSets", reflect.TypeOf((*MockWAFv2)(nil).ListManagedRuleSets), arg0)
+}
+
+// ListManagedRuleSetsRequest mocks base method
+func (m *MockWAFv2) ListManagedRuleSetsRequest(arg0 *wafv2.ListManagedRuleSetsInput) (*request.Request, *wafv2.ListManagedRuleSetsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListManagedRuleSetsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListManagedRuleSetsOutput)
+	return ret0, ret1
+}
+
+// ListManagedRuleSetsRequest indicates an expected call of ListManagedRuleSetsRequest
+func (mr *MockWAFv2MockRecorder) ListManagedRuleSetsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListManagedRuleSetsRequest", reflect.TypeOf((*MockWAFv2)(nil).ListManagedRuleSetsRequest), arg0)
+}
+
+// ListManagedRuleSetsWithContext mocks base method
+func (m *MockWAFv2) ListManagedRuleSetsWithContext(arg0 context.Context, arg1 *wafv2.ListManagedRuleSetsInput, arg2 ...request.Option) (*wafv2.ListManagedRuleSetsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListManagedRuleSetsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListManagedRuleSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListManagedRuleSetsWithContext indicates an expected call of ListManagedRuleSetsWithContext
+func (mr *MockWAFv2MockRecorder) ListManagedRuleSetsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListManagedRuleSetsWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListManagedRuleSetsWithContext), varargs...)
+}
+
+// ListMobileSdkReleases mocks base method
+func (m *MockWAFv2) ListMobileSdkReleases(arg0 *wafv2.ListMobileSdkReleasesInput) (*wafv2.ListMobileSdkReleasesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMobileSdkReleases", arg0)
+	ret0, _ := ret[0].(*wafv2.ListMobileSdkReleasesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMobileSdkReleases indicates an expected call of ListMobileSdkReleases
+func (mr *MockWAFv2MockRecorder) ListMobileSdkReleases(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMobileSdkReleases", reflect.TypeOf((*MockWAFv2)(nil).ListMobileSdkReleases), arg0)
+}
+
+// ListMobileSdkReleasesRequest mocks base method
+func (m *MockWAFv2) ListMobileSdkReleasesRequest(arg0 *wafv2.ListMobileSdkReleasesInput) (*request.Request, *wafv2.ListMobileSdkReleasesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMobileSdkReleasesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListMobileSdkReleasesOutput)
+	return ret0, ret1
+}
+
+// ListMobileSdkReleasesRequest indicates an expected call of ListMobileSdkReleasesRequest
+func (mr *MockWAFv2MockRecorder) ListMobileSdkReleasesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMobileSdkReleasesRequest", reflect.TypeOf((*MockWAFv2)(nil).ListMobileSdkReleasesRequest), arg0)
+}
+
+// ListMobileSdkReleasesWithContext mocks base method
+func (m *MockWAFv2) ListMobileSdkReleasesWithContext(arg0 context.Context, arg1 *wafv2.ListMobileSdkReleasesInput, arg2 ...request.Option) (*wafv2.ListMobileSdkReleasesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListMobileSdkReleasesWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListMobileSdkReleasesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMobileSdkReleasesWithContext indicates an expected call of ListMobileSdkReleasesWithContext
+func (mr *MockWAFv2MockRecorder) ListMobileSdkReleasesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMobileSdkReleasesWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListMobileSdkReleasesWithContext), varargs...)
+}
+
+// ListRegexPatternSets mocks base method
+func (m *MockWAFv2) ListRegexPatternSets(arg0 *wafv2.ListRegexPatternSetsInput) (*wafv2.ListRegexPatternSetsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegexPatternSets", arg0)
+	ret0, _ := ret[0].(*wafv2.ListRegexPatternSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegexPatternSets indicates an expected call of ListRegexPatternSets
+func (mr *MockWAFv2MockRecorder) ListRegexPatternSets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegexPatternSets", reflect.TypeOf((*MockWAFv2)(nil).ListRegexPatternSets), arg0)
+}
+
+// ListRegexPatternSetsRequest mocks base method
+func (m *MockWAFv2) ListRegexPatternSetsRequest(arg0 *wafv2.ListRegexPatternSetsInput) (*request.Request, *wafv2.ListRegexPatternSetsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegexPatternSetsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListRegexPatternSetsOutput)
+	return ret0, ret1
+}
+
+// ListRegexPatternSetsRequest indicates an expected call of ListRegexPatternSetsRequest
+func (mr *MockWAFv2MockRecorder) ListRegexPatternSetsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegexPatternSetsRequest", reflect.TypeOf((*MockWAFv2)(nil).ListRegexPatternSetsRequest), arg0)
+}
+
+// ListRegexPatternSetsWithContext mocks base method
+func (m *MockWAFv2) ListRegexPatternSetsWithContext(arg0 context.Context, arg1 *wafv2.ListRegexPatternSetsInput, arg2 ...request.Option) (*wafv2.ListRegexPatternSetsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRegexPatternSetsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListRegexPatternSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegexPatternSetsWithContext indicates an expected call of ListRegexPatternSetsWithContext
+func (mr *MockWAFv2MockRecorder) ListRegexPatternSetsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegexPatternSetsWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListRegexPatternSetsWithContext), varargs...)
+}
+
+// ListResourcesForWebACL mocks base method
+func (m *MockWAFv2) ListResourcesForWebACL(arg0 *wafv2.ListResourcesForWebACLInput) (*wafv2.ListResourcesForWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourcesForWebACL", arg0)
+	ret0, _ := ret[0].(*wafv2.ListResourcesForWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourcesForWebACL indicates an expected call of ListResourcesForWebACL
+func (mr *MockWAFv2MockRecorder) ListResourcesForWebACL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourcesForWebACL", reflect.TypeOf((*MockWAFv2)(nil).ListResourcesForWebACL), arg0)
+}
+
+// ListResourcesForWebACLRequest mocks base method
+func (m *MockWAFv2) ListResourcesForWebACLRequest(arg0 *wafv2.ListResourcesForWebACLInput) (*request.Request, *wafv2.ListResourcesForWebACLOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourcesForWebACLRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListResourcesForWebACLOutput)
+	return ret0, ret1
+}
+
+// ListResourcesForWebACLRequest indicates an expected call of ListResourcesForWebACLRequest
+func (mr *MockWAFv2MockRecorder) ListResourcesForWebACLRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourcesForWebACLRequest", reflect.TypeOf((*MockWAFv2)(nil).ListResourcesForWebACLRequest), arg0)
+}
+
+// ListResourcesForWebACLWithContext mocks base method
+func (m *MockWAFv2) ListResourcesForWebACLWithContext(arg0 context.Context, arg1 *wafv2.ListResourcesForWebACLInput, arg2 ...request.Option) (*wafv2.ListResourcesForWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListResourcesForWebACLWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListResourcesForWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourcesForWebACLWithContext indicates an expected call of ListResourcesForWebACLWithContext
+func (mr *MockWAFv2MockRecorder) ListResourcesForWebACLWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourcesForWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListResourcesForWebACLWithContext), varargs...)
+}
+
+// ListRuleGroups mocks base method
+func (m *MockWAFv2) ListRuleGroups(arg0 *wafv2.ListRuleGroupsInput) (*wafv2.ListRuleGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuleGroups", arg0)
+	ret0, _ := ret[0].(*wafv2.ListRuleGroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuleGroups indicates an expected call of ListRuleGroups
+func (mr *MockWAFv2MockRecorder) ListRuleGroups(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleGroups", reflect.TypeOf((*MockWAFv2)(nil).ListRuleGroups), arg0)
+}
+
+// ListRuleGroupsRequest mocks base method
+func (m *MockWAFv2) ListRuleGroupsRequest(arg0 *wafv2.ListRuleGroupsInput) (*request.Request, *wafv2.ListRuleGroupsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuleGroupsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListRuleGroupsOutput)
+	return ret0, ret1
+}
+
+// ListRuleGroupsRequest indicates an expected call of ListRuleGroupsRequest
+func (mr *MockWAFv2MockRecorder) ListRuleGroupsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleGroupsRequest", reflect.TypeOf((*MockWAFv2)(nil).ListRuleGroupsRequest), arg0)
+}
+
+// ListRuleGroupsWithContext mocks base method
+func (m *MockWAFv2) ListRuleGroupsWithContext(arg0 context.Context, arg1 *wafv2.ListRuleGroupsInput, arg2 ...request.Option) (*wafv2.ListRuleGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRuleGroupsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListRuleGroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuleGroupsWithContext indicates an expected call of ListRuleGroupsWithContext
+func (mr *MockWAFv2MockRecorder) ListRuleGroupsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleGroupsWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListRuleGroupsWithContext), varargs...)
+}
+
+// ListTagsForResource mocks base method
+func (m *MockWAFv2) ListTagsForResource(arg0 *wafv2.ListTagsForResourceInput) (*wafv2.ListTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForResource", arg0)
+	ret0, _ := ret[0].(*wafv2.ListTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResource indicates an expected call of ListTagsForResource
+func (mr *MockWAFv2MockRecorder) ListTagsForResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResource", reflect.TypeOf((*MockWAFv2)(nil).ListTagsForResource), arg0)
+}
+
+// ListTagsForResourceRequest mocks base method
+func (m *MockWAFv2) ListTagsForResourceRequest(arg0 *wafv2.ListTagsForResourceInput) (*request.Request, *wafv2.ListTagsForResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListTagsForResourceOutput)
+	return ret0, ret1
+}
+
+// ListTagsForResourceRequest indicates an expected call of ListTagsForResourceRequest
+func (mr *MockWAFv2MockRecorder) ListTagsForResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResourceRequest", reflect.TypeOf((*MockWAFv2)(nil).ListTagsForResourceRequest), arg0)
+}
+
+// ListTagsForResourceWithContext mocks base method
+func (m *MockWAFv2) ListTagsForResourceWithContext(arg0 context.Context, arg1 *wafv2.ListTagsForResourceInput, arg2 ...request.Option) (*wafv2.ListTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTagsForResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResourceWithContext indicates an expected call of ListTagsForResourceWithContext
+func (mr *MockWAFv2MockRecorder) ListTagsForResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResourceWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListTagsForResourceWithContext), varargs...)
+}
+
+// ListWebACLs mocks base method
+func (m *MockWAFv2) ListWebACLs(arg0 *wafv2.ListWebACLsInput) (*wafv2.ListWebACLsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebACLs", arg0)
+	ret0, _ := ret[0].(*wafv2.ListWebACLsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebACLs indicates an expected call of ListWebACLs
+func (mr *MockWAFv2MockRecorder) ListWebACLs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebACLs", reflect.TypeOf((*MockWAFv2)(nil).ListWebACLs), arg0)
+}
+
+// ListWebACLsRequest mocks base method
+func (m *MockWAFv2) ListWebACLsRequest(arg0 *wafv2.ListWebACLsInput) (*request.Request, *wafv2.ListWebACLsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebACLsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.ListWebACLsOutput)
+	return ret0, ret1
+}
+
+// ListWebACLsRequest indicates an expected call of ListWebACLsRequest
+func (mr *MockWAFv2MockRecorder) ListWebACLsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebACLsRequest", reflect.TypeOf((*MockWAFv2)(nil).ListWebACLsRequest), arg0)
+}
+
+// ListWebACLsWithContext mocks base method
+func (m *MockWAFv2) ListWebACLsWithContext(arg0 context.Context, arg1 *wafv2.ListWebACLsInput, arg2 ...request.Option) (*wafv2.ListWebACLsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListWebACLsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.ListWebACLsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebACLsWithContext indicates an expected call of ListWebACLsWithContext
+func (mr *MockWAFv2MockRecorder) ListWebACLsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebACLsWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListWebACLsWithContext), varargs...)
+}
+
+// PutLoggingConfiguration mocks base method
+func (m *MockWAFv2) PutLoggingConfiguration(arg0 *wafv2.PutLoggingConfigurationInput) (*wafv2.PutLoggingConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutLoggingConfiguration", arg0)
+	ret0, _ := ret[0].(*wafv2.PutLoggingConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutLoggingConfiguration indicates an expected call of PutLoggingConfiguration
+func (mr *MockWAFv2MockRecorder) PutLoggingConfiguration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutLoggingConfiguration", reflect.TypeOf((*MockWAFv2)(nil).PutLoggingConfiguration), arg0)
+}
+
+// PutLoggingConfigurationRequest mocks base method
+func (m *MockWAFv2) PutLoggingConfigurationRequest(arg0 *wafv2.PutLoggingConfigurationInput) (*request.Request, *wafv2.PutLoggingConfigurationOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutLoggingConfigurationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.PutLoggingConfigurationOutput)
+	return ret0, ret1
+}
+
+// PutLoggingConfigurationRequest indicates an expected call of PutLoggingConfigurationRequest
+func (mr *MockWAFv2MockRecorder) PutLoggingConfigurationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutLoggingConfigurationRequest", reflect.TypeOf((*MockWAFv2)(nil).PutLoggingConfigurationRequest), arg0)
+}
+
+// PutLoggingConfigurationWithContext mocks base method
+func (m *MockWAFv2) PutLoggingConfigurationWithContext(arg0 context.Context, arg1 *wafv2.PutLoggingConfigurationInput, arg2 ...request.Option) (*wafv2.PutLoggingConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutLoggingConfigurationWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.PutLoggingConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutLoggingConfigurationWithContext indicates an expected call of PutLoggingConfigurationWithContext
+func (mr *MockWAFv2MockRecorder) PutLoggingConfigurationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutLoggingConfigurationWithContext", reflect.TypeOf((*MockWAFv2)(nil).PutLoggingConfigurationWithContext), varargs...)
+}
+
+// PutManagedRuleSetVersions mocks base method
+func (m *MockWAFv2) PutManagedRuleSetVersions(arg0 *wafv2.PutManagedRuleSetVersionsInput) (*wafv2.PutManagedRuleSetVersionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutManagedRuleSetVersions", arg0)
+	ret0, _ := ret[0].(*wafv2.PutManagedRuleSetVersionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutManagedRuleSetVersions indicates an expected call of PutManagedRuleSetVersions
+func (mr *MockWAFv2MockRecorder) PutManagedRuleSetVersions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutManagedRuleSetVersions", reflect.TypeOf((*MockWAFv2)(nil).PutManagedRuleSetVersions), arg0)
+}
+
+// PutManagedRuleSetVersionsRequest mocks base method
+func (m *MockWAFv2) PutManagedRuleSetVersionsRequest(arg0 *wafv2.PutManagedRuleSetVersionsInput) (*request.Request, *wafv2.PutManagedRuleSetVersionsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutManagedRuleSetVersionsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.PutManagedRuleSetVersionsOutput)
+	return ret0, ret1
+}
+
+// PutManagedRuleSetVersionsRequest indicates an expected call of PutManagedRuleSetVersionsRequest
+func (mr *MockWAFv2MockRecorder) PutManagedRuleSetVersionsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutManagedRuleSetVersionsRequest", reflect.TypeOf((*MockWAFv2)(nil).PutManagedRuleSetVersionsRequest), arg0)
+}
+
+// PutManagedRuleSetVersionsWithContext mocks base method
+func (m *MockWAFv2) PutManagedRuleSetVersionsWithContext(arg0 context.Context, arg1 *wafv2.PutManagedRuleSetVersionsInput, arg2 ...request.Option) (*wafv2.PutManagedRuleSetVersionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutManagedRuleSetVersionsWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.PutManagedRuleSetVersionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutManagedRuleSetVersionsWithContext indicates an expected call of PutManagedRuleSetVersionsWithContext
+func (mr *MockWAFv2MockRecorder) PutManagedRuleSetVersionsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutManagedRuleSetVersionsWithContext", reflect.TypeOf((*MockWAFv2)(nil).PutManagedRuleSetVersionsWithContext), varargs...)
+}
+
+// PutPermissionPolicy mocks base method
+func (m *MockWAFv2) PutPermissionPolicy(arg0 *wafv2.PutPermissionPolicyInput) (*wafv2.PutPermissionPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutPermissionPolicy", arg0)
+	ret0, _ := ret[0].(*wafv2.PutPermissionPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutPermissionPolicy indicates an expected call of PutPermissionPolicy
+func (mr *MockWAFv2MockRecorder) PutPermissionPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPermissionPolicy", reflect.TypeOf((*MockWAFv2)(nil).PutPermissionPolicy), arg0)
+}
+
+// PutPermissionPolicyRequest mocks base method
+func (m *MockWAFv2) PutPermissionPolicyRequest(arg0 *wafv2.PutPermissionPolicyInput) (*request.Request, *wafv2.PutPermissionPolicyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutPermissionPolicyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.PutPermissionPolicyOutput)
+	return ret0, ret1
+}
+
+// PutPermissionPolicyRequest indicates an expected call of PutPermissionPolicyRequest
+func (mr *MockWAFv2MockRecorder) PutPermissionPolicyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPermissionPolicyRequest", reflect.TypeOf((*MockWAFv2)(nil).PutPermissionPolicyRequest), arg0)
+}
+
+// PutPermissionPolicyWithContext mocks base method
+func (m *MockWAFv2) PutPermissionPolicyWithContext(arg0 context.Context, arg1 *wafv2.PutPermissionPolicyInput, arg2 ...request.Option) (*wafv2.PutPermissionPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutPermissionPolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.PutPermissionPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutPermissionPolicyWithContext indicates an expected call of PutPermissionPolicyWithContext
+func (mr *MockWAFv2MockRecorder) PutPermissionPolicyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPermissionPolicyWithContext", reflect.TypeOf((*MockWAFv2)(nil).PutPermissionPolicyWithContext), varargs...)
+}
+
+// TagResource mocks base method
+func (m *MockWAFv2) TagResource(arg0 *wafv2.TagResourceInput) (*wafv2.TagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagResource", arg0)
+	ret0, _ := ret[0].(*wafv2.TagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagResource indicates an expected call of TagResource
+func (mr *MockWAFv2MockRecorder) TagResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResource", reflect.TypeOf((*MockWAFv2)(nil).TagResource), arg0)
+}
+
+// TagResourceRequest mocks base method
+func (m *MockWAFv2) TagResourceRequest(arg0 *wafv2.TagResourceInput) (*request.Request, *wafv2.TagResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.TagResourceOutput)
+	return ret0, ret1
+}
+
+// TagResourceRequest indicates an expected call of TagResourceRequest
+func (mr *MockWAFv2MockRecorder) TagResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResourceRequest", reflect.TypeOf((*MockWAFv2)(nil).TagResourceRequest), arg0)
+}
+
+// TagResourceWithContext mocks base method
+func (m *MockWAFv2) TagResourceWithContext(arg0 context.Context, arg1 *wafv2.TagResourceInput, arg2 ...request.Option) (*wafv2.TagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TagResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.TagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagResourceWithContext indicates an expected call of TagResourceWithContext
+func (mr *MockWAFv2MockRecorder) TagResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResourceWithContext", reflect.TypeOf((*MockWAFv2)(nil).TagResourceWithContext), varargs...)
+}
+
+// UntagResource mocks base method
+func (m *MockWAFv2) UntagResource(arg0 *wafv2.UntagResourceInput) (*wafv2.UntagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagResource", arg0)
+	ret0, _ := ret[0].(*wafv2.UntagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UntagResource indicates an expected call of UntagResource
+func (mr *MockWAFv2MockRecorder) UntagResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagResource", reflect.TypeOf((*MockWAFv2)(nil).UntagResource), arg0)
+}
+
+// UntagResourceRequest mocks base method
+func (m *MockWAFv2) UntagResourceRequest(arg0 *wafv2.UntagResourceInput) (*request.Request, *wafv2.UntagResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.UntagResourceOutput)
+	return ret0, ret1
+}
+
+// UntagResourceRequest indicates an expected call of UntagResourceRequest
+func (mr *MockWAFv2MockRecorder) UntagResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagResourceRequest", reflect.TypeOf((*MockWAFv2)(nil).UntagResourceRequest), arg0)
+}
+
+// UntagResourceWithContext mocks base method
+func (m *MockWAFv2) UntagResourceWithContext(arg0 context.Context, arg1 *wafv2.UntagResourceInput, arg2 ...request.Option) (*wafv2.UntagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UntagResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.UntagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UntagResourceWithContext indicates an expected call of UntagResourceWithContext
+func (mr *MockWAFv2MockRecorder) UntagResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagResourceWithContext", reflect.TypeOf((*MockWAFv2)(nil).UntagResourceWithContext), varargs...)
+}
+
+// UpdateIPSet mocks base method
+func (m *MockWAFv2) UpdateIPSet(arg0 *wafv2.UpdateIPSetInput) (*wafv2.UpdateIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIPSet", arg0)
+	ret0, _ := ret[0].(*wafv2.UpdateIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIPSet indicates an expected call of UpdateIPSet
+func (mr *MockWAFv2MockRecorder) UpdateIPSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIPSet", reflect.TypeOf((*MockWAFv2)(nil).UpdateIPSet), arg0)
+}
+
+// UpdateIPSetRequest mocks base method
+func (m *MockWAFv2) UpdateIPSetRequest(arg0 *wafv2.UpdateIPSetInput) (*request.Request, *wafv2.UpdateIPSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIPSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.UpdateIPSetOutput)
+	return ret0, ret1
+}
+
+// UpdateIPSetRequest indicates an expected call of UpdateIPSetRequest
+func (mr *MockWAFv2MockRecorder) UpdateIPSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIPSetRequest", reflect.TypeOf((*MockWAFv2)(nil).UpdateIPSetRequest), arg0)
+}
+
+// UpdateIPSetWithContext mocks base method
+func (m *MockWAFv2) UpdateIPSetWithContext(arg0 context.Context, arg1 *wafv2.UpdateIPSetInput, arg2 ...request.Option) (*wafv2.UpdateIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateIPSetWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.UpdateIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIPSetWithContext indicates an expected call of UpdateIPSetWithContext
+func (mr *MockWAFv2MockRecorder) UpdateIPSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIPSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).UpdateIPSetWithContext), varargs...)
+}
+
+// UpdateManagedRuleSetVersionExpiryDate mocks base method
+func (m *MockWAFv2) UpdateManagedRuleSetVersionExpiryDate(arg0 *wafv2.UpdateManagedRuleSetVersionExpiryDateInput) (*wafv2.UpdateManagedRuleSetVersionExpiryDateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateManagedRuleSetVersionExpiryDate", arg0)
+	ret0, _ := ret[0].(*wafv2.UpdateManagedRuleSetVersionExpiryDateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateManagedRuleSetVersionExpiryDate indicates an expected call of UpdateManagedRuleSetVersionExpiryDate
+func (mr *MockWAFv2MockRecorder) UpdateManagedRuleSetVersionExpiryDate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateManagedRuleSetVersionExpiryDate", reflect.TypeOf((*MockWAFv2)(nil).UpdateManagedRuleSetVersionExpiryDate), arg0)
+}
+
+// UpdateManagedRuleSetVersionExpiryDateRequest mocks base method
+func (m *MockWAFv2) UpdateManagedRuleSetVersionExpiryDateRequest(arg0 *wafv2.UpdateManagedRuleSetVersionExpiryDateInput) (*request.Request, *wafv2.UpdateManagedRuleSetVersionExpiryDateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateManagedRuleSetVersionExpiryDateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.UpdateManagedRuleSetVersionExpiryDateOutput)
+	return ret0, ret1
+}
+
+// UpdateManagedRuleSetVersionExpiryDateRequest indicates an expected call of UpdateManagedRuleSetVersionExpiryDateRequest
+func (mr *MockWAFv2MockRecorder) UpdateManagedRuleSetVersionExpiryDateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateManagedRuleSetVersionExpiryDateRequest", reflect.TypeOf((*MockWAFv2)(nil).UpdateManagedRuleSetVersionExpiryDateRequest), arg0)
+}
+
+// UpdateManagedRuleSetVersionExpiryDateWithContext mocks base method
+func (m *MockWAFv2) UpdateManagedRuleSetVersionExpiryDateWithContext(arg0 context.Context, arg1 *wafv2.UpdateManagedRuleSetVersionExpiryDateInput, arg2 ...request.Option) (*wafv2.UpdateManagedRuleSetVersionExpiryDateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateManagedRuleSetVersionExpiryDateWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.UpdateManagedRuleSetVersionExpiryDateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateManagedRuleSetVersionExpiryDateWithContext indicates an expected call of UpdateManagedRuleSetVersionExpiryDateWithContext
+func (mr *MockWAFv2MockRecorder) UpdateManagedRuleSetVersionExpiryDateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateManagedRuleSetVersionExpiryDateWithContext", reflect.TypeOf((*MockWAFv2)(nil).UpdateManagedRuleSetVersionExpiryDateWithContext), varargs...)
+}
+
+// UpdateRegexPatternSet mocks base method
+func (m *MockWAFv2) UpdateRegexPatternSet(arg0 *wafv2.UpdateRegexPatternSetInput) (*wafv2.UpdateRegexPatternSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRegexPatternSet", arg0)
+	ret0, _ := ret[0].(*wafv2.UpdateRegexPatternSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRegexPatternSet indicates an expected call of UpdateRegexPatternSet
+func (mr *MockWAFv2MockRecorder) UpdateRegexPatternSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRegexPatternSet", reflect.TypeOf((*MockWAFv2)(nil).UpdateRegexPatternSet), arg0)
+}
+
+// UpdateRegexPatternSetRequest mocks base method
+func (m *MockWAFv2) UpdateRegexPatternSetRequest(arg0 *wafv2.UpdateRegexPatternSetInput) (*request.Request, *wafv2.UpdateRegexPatternSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRegexPatternSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.UpdateRegexPatternSetOutput)
+	return ret0, ret1
+}
+
+// UpdateRegexPatternSetRequest indicates an expected call of UpdateRegexPatternSetRequest
+func (mr *MockWAFv2MockRecorder) UpdateRegexPatternSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRegexPatternSetRequest", reflect.TypeOf((*MockWAFv2)(nil).UpdateRegexPatternSetRequest), arg0)
+}
+
+// UpdateRegexPatternSetWithContext mocks base method
+func (m *MockWAFv2) UpdateRegexPatternSetWithContext(arg0 context.Context, arg1 *wafv2.UpdateRegexPatternSetInput, arg2 ...request.Option) (*wafv2.UpdateRegexPatternSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateRegexPatternSetWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.UpdateRegexPatternSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRegexPatternSetWithContext indicates an expected call of UpdateRegexPatternSetWithContext
+func (mr *MockWAFv2MockRecorder) UpdateRegexPatternSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRegexPatternSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).UpdateRegexPatternSetWithContext), varargs...)
+}
+
+// UpdateRuleGroup mocks base method
+func (m *MockWAFv2) UpdateRuleGroup(arg0 *wafv2.UpdateRuleGroupInput) (*wafv2.UpdateRuleGroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRuleGroup", arg0)
+	ret0, _ := ret[0].(*wafv2.UpdateRuleGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRuleGroup indicates an expected call of UpdateRuleGroup
+func (mr *MockWAFv2MockRecorder) UpdateRuleGroup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRuleGroup", reflect.TypeOf((*MockWAFv2)(nil).UpdateRuleGroup), arg0)
+}
+
+// UpdateRuleGroupRequest mocks base method
+func (m *MockWAFv2) UpdateRuleGroupRequest(arg0 *wafv2.UpdateRuleGroupInput) (*request.Request, *wafv2.UpdateRuleGroupOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRuleGroupRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.UpdateRuleGroupOutput)
+	return ret0, ret1
+}
+
+// UpdateRuleGroupRequest indicates an expected call of UpdateRuleGroupRequest
+func (mr *MockWAFv2MockRecorder) UpdateRuleGroupRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRuleGroupRequest", reflect.TypeOf((*MockWAFv2)(nil).UpdateRuleGroupRequest), arg0)
+}
+
+// UpdateRuleGroupWithContext mocks base method
+func (m *MockWAFv2) UpdateRuleGroupWithContext(arg0 context.Context, arg1 *wafv2.UpdateRuleGroupInput, arg2 ...request.Option) (*wafv2.UpdateRuleGroupOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateRuleGroupWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.UpdateRuleGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRuleGroupWithContext indicates an expected call of UpdateRuleGroupWithContext
+func (mr *MockWAFv2MockRecorder) UpdateRuleGroupWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRuleGroupWithContext", reflect.TypeOf((*MockWAFv2)(nil).UpdateRuleGroupWithContext), varargs...)
+}
+
+// UpdateWebACL mocks base method
+func (m *MockWAFv2) UpdateWebACL(arg0 *wafv2.UpdateWebACLInput) (*wafv2.UpdateWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebACL", arg0)
+	ret0, _ := ret[0].(*wafv2.UpdateWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebACL indicates an expected call of UpdateWebACL
+func (mr *MockWAFv2MockRecorder) UpdateWebACL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebACL", reflect.TypeOf((*MockWAFv2)(nil).UpdateWebACL), arg0)
+}
+
+// UpdateWebACLRequest mocks base method
+func (m *MockWAFv2) UpdateWebACLRequest(arg0 *wafv2.UpdateWebACLInput) (*request.Request, *wafv2.UpdateWebACLOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebACLRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*wafv2.UpdateWebACLOutput)
+	return ret0, ret1
+}
+
+// UpdateWebACLRequest indicates an expected call of UpdateWebACLRequest
+func (mr *MockWAFv2MockRecorder) UpdateWebACLRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebACLRequest", reflect.TypeOf((*MockWAFv2)(nil).UpdateWebACLRequest), arg0)
+}
+
+// UpdateWebACLWithContext mocks base method
+func (m *MockWAFv2) UpdateWebACLWithContext(arg0 context.Context, arg1 *wafv2.UpdateWebACLInput, arg2 ...request.Option) (*wafv2.UpdateWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateWebACLWithContext", varargs...)
+	ret0, _ := ret[0].(*wafv2.UpdateWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebACLWithContext indicates an expected call of UpdateWebACLWithContext
+func (mr *MockWAFv2MockRecorder) UpdateWebACLWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).UpdateWebACLWithContext), varargs...)
+}
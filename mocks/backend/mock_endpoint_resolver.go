@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/backend (interfaces: EndpointResolver)
+
+// Package mock_backend is a generated GoMock package.
+package mock_backend
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	types "k8s.io/apimachinery/pkg/types"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+	reflect "reflect"
+	backend "sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+)
+
+// MockEndpointResolver is a mock of EndpointResolver interface
+type MockEndpointResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockEndpointResolverMockRecorder
+}
+
+// MockEndpointResolverMockRecorder is the mock recorder for MockEndpointResolver
+type MockEndpointResolverMockRecorder struct {
+	mock *MockEndpointResolver
+}
+
+// NewMockEndpointResolver creates a new mock instance
+func NewMockEndpointResolver(ctrl *gomock.Controller) *MockEndpointResolver {
+	mock := &MockEndpointResolver{ctrl: ctrl}
+	mock.recorder = &MockEndpointResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockEndpointResolver) EXPECT() *MockEndpointResolverMockRecorder {
+	return m.recorder
+}
+
+// ResolveNodePortEndpoints mocks base method
+func (m *MockEndpointResolver) ResolveNodePortEndpoints(arg0 context.Context, arg1 types.NamespacedName, arg2 intstr.IntOrString, arg3 ...backend.EndpointResolveOption) ([]backend.NodePortEndpoint, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResolveNodePortEndpoints", varargs...)
+	ret0, _ := ret[0].([]backend.NodePortEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveNodePortEndpoints indicates an expected call of ResolveNodePortEndpoints
+func (mr *MockEndpointResolverMockRecorder) ResolveNodePortEndpoints(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveNodePortEndpoints", reflect.TypeOf((*MockEndpointResolver)(nil).ResolveNodePortEndpoints), varargs...)
+}
+
+// ResolvePodEndpoints mocks base method
+func (m *MockEndpointResolver) ResolvePodEndpoints(arg0 context.Context, arg1 types.NamespacedName, arg2 intstr.IntOrString, arg3 ...backend.EndpointResolveOption) ([]backend.PodEndpoint, bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResolvePodEndpoints", varargs...)
+	ret0, _ := ret[0].([]backend.PodEndpoint)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolvePodEndpoints indicates an expected call of ResolvePodEndpoints
+func (mr *MockEndpointResolverMockRecorder) ResolvePodEndpoints(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePodEndpoints", reflect.TypeOf((*MockEndpointResolver)(nil).ResolvePodEndpoints), varargs...)
+}
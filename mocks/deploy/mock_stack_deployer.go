@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/deploy (interfaces: StackDeployer)
+
+// Package mock_deploy is a generated GoMock package.
+package mock_deploy
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	core "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+)
+
+// MockStackDeployer is a mock of StackDeployer interface
+type MockStackDeployer struct {
+	ctrl     *gomock.Controller
+	recorder *MockStackDeployerMockRecorder
+}
+
+// MockStackDeployerMockRecorder is the mock recorder for MockStackDeployer
+type MockStackDeployerMockRecorder struct {
+	mock *MockStackDeployer
+}
+
+// NewMockStackDeployer creates a new mock instance
+func NewMockStackDeployer(ctrl *gomock.Controller) *MockStackDeployer {
+	mock := &MockStackDeployer{ctrl: ctrl}
+	mock.recorder = &MockStackDeployerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStackDeployer) EXPECT() *MockStackDeployerMockRecorder {
+	return m.recorder
+}
+
+// Deploy mocks base method
+func (m *MockStackDeployer) Deploy(arg0 context.Context, arg1 core.Stack) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deploy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deploy indicates an expected call of Deploy
+func (mr *MockStackDeployerMockRecorder) Deploy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deploy", reflect.TypeOf((*MockStackDeployer)(nil).Deploy), arg0, arg1)
+}
@@ -47,3 +47,19 @@ func (mr *MockCertDiscoveryMockRecorder) Discover(arg0, arg1 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discover", reflect.TypeOf((*MockCertDiscovery)(nil).Discover), arg0, arg1)
 }
+
+// DiscoverWithWildcardFallback mocks base method
+func (m *MockCertDiscovery) DiscoverWithWildcardFallback(arg0 context.Context, arg1 []string) ([]string, *string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverWithWildcardFallback", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DiscoverWithWildcardFallback indicates an expected call of DiscoverWithWildcardFallback
+func (mr *MockCertDiscoveryMockRecorder) DiscoverWithWildcardFallback(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverWithWildcardFallback", reflect.TypeOf((*MockCertDiscovery)(nil).DiscoverWithWildcardFallback), arg0, arg1)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/ingress (interfaces: CertValidator)
+
+// Package mock_ingress is a generated GoMock package.
+package mock_ingress
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockCertValidator is a mock of CertValidator interface
+type MockCertValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertValidatorMockRecorder
+}
+
+// MockCertValidatorMockRecorder is the mock recorder for MockCertValidator
+type MockCertValidatorMockRecorder struct {
+	mock *MockCertValidator
+}
+
+// NewMockCertValidator creates a new mock instance
+func NewMockCertValidator(ctrl *gomock.Controller) *MockCertValidator {
+	mock := &MockCertValidator{ctrl: ctrl}
+	mock.recorder = &MockCertValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCertValidator) EXPECT() *MockCertValidatorMockRecorder {
+	return m.recorder
+}
+
+// FindMissingCerts mocks base method
+func (m *MockCertValidator) FindMissingCerts(arg0 context.Context, arg1 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMissingCerts", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMissingCerts indicates an expected call of FindMissingCerts
+func (mr *MockCertValidatorMockRecorder) FindMissingCerts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMissingCerts", reflect.TypeOf((*MockCertValidator)(nil).FindMissingCerts), arg0, arg1)
+}
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/ingress (interfaces: LambdaPermissionValidator)
+
+// Package mock_ingress is a generated GoMock package.
+package mock_ingress
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockLambdaPermissionValidator is a mock of LambdaPermissionValidator interface
+type MockLambdaPermissionValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockLambdaPermissionValidatorMockRecorder
+}
+
+// MockLambdaPermissionValidatorMockRecorder is the mock recorder for MockLambdaPermissionValidator
+type MockLambdaPermissionValidatorMockRecorder struct {
+	mock *MockLambdaPermissionValidator
+}
+
+// NewMockLambdaPermissionValidator creates a new mock instance
+func NewMockLambdaPermissionValidator(ctrl *gomock.Controller) *MockLambdaPermissionValidator {
+	mock := &MockLambdaPermissionValidator{ctrl: ctrl}
+	mock.recorder = &MockLambdaPermissionValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLambdaPermissionValidator) EXPECT() *MockLambdaPermissionValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method
+func (m *MockLambdaPermissionValidator) Validate(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate
+func (mr *MockLambdaPermissionValidatorMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockLambdaPermissionValidator)(nil).Validate), arg0, arg1)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/networking (interfaces: SecurityGroupReconciler)
+
+// Package mock_networking is a generated GoMock package.
+package mock_networking
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	networking "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+)
+
+// MockSecurityGroupReconciler is a mock of SecurityGroupReconciler interface
+type MockSecurityGroupReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecurityGroupReconcilerMockRecorder
+}
+
+// MockSecurityGroupReconcilerMockRecorder is the mock recorder for MockSecurityGroupReconciler
+type MockSecurityGroupReconcilerMockRecorder struct {
+	mock *MockSecurityGroupReconciler
+}
+
+// NewMockSecurityGroupReconciler creates a new mock instance
+func NewMockSecurityGroupReconciler(ctrl *gomock.Controller) *MockSecurityGroupReconciler {
+	mock := &MockSecurityGroupReconciler{ctrl: ctrl}
+	mock.recorder = &MockSecurityGroupReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSecurityGroupReconciler) EXPECT() *MockSecurityGroupReconcilerMockRecorder {
+	return m.recorder
+}
+
+// ReconcileIngress mocks base method
+func (m *MockSecurityGroupReconciler) ReconcileIngress(arg0 context.Context, arg1 string, arg2 []networking.IPPermissionInfo, arg3 ...networking.SecurityGroupReconcileOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReconcileIngress", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileIngress indicates an expected call of ReconcileIngress
+func (mr *MockSecurityGroupReconcilerMockRecorder) ReconcileIngress(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileIngress", reflect.TypeOf((*MockSecurityGroupReconciler)(nil).ReconcileIngress), varargs...)
+}
@@ -13,18 +13,29 @@ const (
 	IngressSuffixSubnets                      = "subnets"
 	IngressSuffixLoadBalancerAttributes       = "load-balancer-attributes"
 	IngressSuffixWAFv2ACLARN                  = "wafv2-acl-arn"
+	IngressSuffixWAFv2LoggingDestinationARN   = "wafv2-logging-destination-arn"
+	IngressSuffixWAFv2FailOpen                = "wafv2-fail-open"
 	IngressSuffixWAFACLID                     = "waf-acl-id"
 	IngressSuffixWebACLID                     = "web-acl-id" // deprecated, use "waf-acl-id" instead.
 	IngressSuffixShieldAdvancedProtection     = "shield-advanced-protection"
+	IngressSuffixShieldAdvancedHealthCheckID  = "shield-advanced-health-check-id"
 	IngressSuffixSecurityGroups               = "security-groups"
 	IngressSuffixListenPorts                  = "listen-ports"
 	IngressSuffixInboundCIDRs                 = "inbound-cidrs"
+	IngressSuffixSecurityGroupPrefixLists     = "security-group-prefix-lists"
 	IngressSuffixCertificateARN               = "certificate-arn"
+	IngressSuffixDefaultCertificateARN        = "default-certificate-arn"
+	IngressSuffixWildcardCertificateFallback  = "wildcard-certificate-fallback"
 	IngressSuffixSSLPolicy                    = "ssl-policy"
+	IngressSuffixStrictTransportSecurity      = "strict-transport-security"
 	IngressSuffixTargetType                   = "target-type"
+	IngressSuffixTargetGroupPort              = "target-group-port"
 	IngressSuffixBackendProtocol              = "backend-protocol"
 	IngressSuffixBackendProtocolVersion       = "backend-protocol-version"
+	IngressSuffixTargetGroupRecreation        = "target-group-recreation-confirmed"
 	IngressSuffixTargetGroupAttributes        = "target-group-attributes"
+	IngressSuffixLambdaMultiValueHeaders      = "lambda-multi-value-headers-enabled"
+	IngressSuffixStickinessPaths              = "stickiness-paths"
 	IngressSuffixHealthCheckPort              = "healthcheck-port"
 	IngressSuffixHealthCheckProtocol          = "healthcheck-protocol"
 	IngressSuffixHealthCheckPath              = "healthcheck-path"
@@ -40,10 +51,13 @@ const (
 	IngressSuffixAuthScope                    = "auth-scope"
 	IngressSuffixAuthSessionCookie            = "auth-session-cookie"
 	IngressSuffixAuthSessionTimeout           = "auth-session-timeout"
+	IngressSuffixEmptyEndpointsAction         = "empty-endpoints-action"
+	IngressSuffixEmptyEndpointsFixedResponse  = "empty-endpoints-fixed-response"
 
 	// NLB annotation suffixes
 	// prefixes service.beta.kubernetes.io, service.kubernetes.io
 	SvcLBSuffixSourceRanges                  = "load-balancer-source-ranges"
+	SvcLBSuffixSourcePrefixLists             = "aws-load-balancer-security-group-prefix-lists"
 	SvcLBSuffixLoadBalancerType              = "aws-load-balancer-type"
 	SvcLBSuffixInternal                      = "aws-load-balancer-internal"
 	SvcLBSuffixProxyProtocol                 = "aws-load-balancer-proxy-protocol"
@@ -63,7 +77,26 @@ const (
 	SvcLBSuffixHCProtocol                    = "aws-load-balancer-healthcheck-protocol"
 	SvcLBSuffixHCPort                        = "aws-load-balancer-healthcheck-port"
 	SvcLBSuffixHCPath                        = "aws-load-balancer-healthcheck-path"
+	SvcLBSuffixHCConfig                      = "aws-load-balancer-healthcheck-config"
+	SvcLBSuffixHCProtocolDefaults            = "aws-load-balancer-healthcheck-protocol-defaults"
+	SvcLBSuffixHCSuccessCodes                = "aws-load-balancer-healthcheck-success-codes"
+	SvcLBSuffixTargetGroupWeights            = "aws-load-balancer-target-group-weights"
 	SvcLBSuffixEIPAllocations                = "aws-load-balancer-eip-allocations"
+	SvcLBSuffixPrivateIPv4Addresses          = "aws-load-balancer-private-ipv4-addresses"
+	SvcLBSuffixIPAddressType                 = "aws-load-balancer-ip-address-type"
+	SvcLBSuffixIPv6Addresses                 = "aws-load-balancer-ipv6-addresses"
 	SvcLBSuffixTargetGroupAttributes         = "aws-load-balancer-target-group-attributes"
 	SvcLBSuffixSubnets                       = "aws-load-balancer-subnets"
+	SvcLBSuffixEndpointServiceEnabled        = "aws-load-balancer-endpoint-service-enabled"
+	SvcLBSuffixEndpointServiceAcceptance     = "aws-load-balancer-endpoint-service-acceptance-required"
+	SvcLBSuffixEndpointServicePrincipals     = "aws-load-balancer-endpoint-service-allowed-principals"
+	SvcLBSuffixDNSRecordClientRoutingPolicy  = "aws-load-balancer-dns-record-client-routing-policy"
+	SvcLBSuffixLoadBalancerAttributes        = "aws-load-balancer-attributes"
+	SvcLBSuffixPreserveClientIP              = "aws-load-balancer-preserve-client-ip"
+	SvcLBSuffixSecurityGroups                = "aws-load-balancer-security-groups"
+	SvcLBSuffixManageSGRules                 = "aws-load-balancer-manage-backend-security-group-rules"
+	SvcLBSuffixEnforceSGPrivateLinkTraffic   = "aws-load-balancer-enforce-security-group-inbound-rules-on-private-link-traffic"
+	SvcLBSuffixAllowICMPFragmentation        = "aws-load-balancer-security-group-allow-icmp-fragmentation"
+	SvcLBSuffixTargetIPAddressType           = "aws-load-balancer-target-ip-address-type"
+	SvcLBSuffixTargetGroupRecreation         = "aws-load-balancer-target-group-recreation-confirmed"
 )
@@ -0,0 +1,28 @@
+package annotations
+
+import "github.com/pkg/errors"
+
+// InvalidAnnotationError is returned when the value of an annotation is invalid.
+type InvalidAnnotationError struct {
+	// Key is the full key of the invalid annotation.
+	Key string
+	// Err is the error describing why the value is invalid.
+	Err error
+}
+
+func (e *InvalidAnnotationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidAnnotationError) Unwrap() error {
+	return e.Err
+}
+
+// NewInvalidAnnotationError constructs new InvalidAnnotationError, err is returned as is if it's already attributed to an annotation.
+func NewInvalidAnnotationError(key string, err error) error {
+	var invalidAnnotationErr *InvalidAnnotationError
+	if errors.As(err, &invalidAnnotationErr) {
+		return err
+	}
+	return &InvalidAnnotationError{Key: key, Err: err}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type ParseOptions struct {
@@ -13,6 +14,8 @@ type ParseOptions struct {
 	exact bool
 	// alternative prefixes to lookup
 	alternativePrefixes []string
+	// reject duplicate keys in stringMap annotations
+	rejectDuplicateKeys bool
 }
 
 type ParseOption func(opts *ParseOptions)
@@ -29,6 +32,13 @@ func WithAlternativePrefixes(prefixes ...string) ParseOption {
 	}
 }
 
+// WithRejectDuplicateKeys rejects stringMap annotations that specify the same key more than once.
+func WithRejectDuplicateKeys() ParseOption {
+	return func(opts *ParseOptions) {
+		opts.rejectDuplicateKeys = true
+	}
+}
+
 // Parser is responsible for loading annotations into structured objects.
 // It accepts an list of Object annotations and will search through them until desired annotation is found.
 type Parser interface {
@@ -44,6 +54,10 @@ type Parser interface {
 	// returns whether annotation exists and parser error if any.
 	ParseInt64Annotation(annotation string, value *int64, annotations map[string]string, opts ...ParseOption) (bool, error)
 
+	// ParseDurationAnnotation parses annotation in Go duration format(e.g. 30s, 2m) into time.Duration value,
+	// returns whether annotation exists and parser error if any.
+	ParseDurationAnnotation(annotation string, value *time.Duration, annotations map[string]string, opts ...ParseOption) (bool, error)
+
 	// ParseStringSliceAnnotation parses comma separated values from the annotation into string slice
 	// returns true if the annotation exists
 	ParseStringSliceAnnotation(annotation string, value *[]string, annotations map[string]string, opts ...ParseOption) bool
@@ -52,7 +66,7 @@ type Parser interface {
 	// returns true if the annotation exists and parser error if any
 	ParseJSONAnnotation(annotation string, value interface{}, annotations map[string]string, opts ...ParseOption) (bool, error)
 
-	// ParseStringMapAnnotation parses comma separated key=value pairs into a map
+	// ParseStringMapAnnotation parses comma separated key=value pairs into a map, whitespaces around keys and values are trimmed
 	// returns true if the annotation exists
 	ParseStringMapAnnotation(annotation string, value *map[string]string, annotations map[string]string, opts ...ParseOption) (bool, error)
 }
@@ -86,7 +100,7 @@ func (p *suffixAnnotationParser) ParseBoolAnnotation(annotation string, value *b
 	}
 	val, err := strconv.ParseBool(raw)
 	if err != nil {
-		return true, errors.Wrapf(err, "failed to parse bool annotation, %v: %v", matchedKey, raw)
+		return true, NewInvalidAnnotationError(matchedKey, errors.Wrapf(err, "failed to parse bool annotation, %v: %v", matchedKey, raw))
 	}
 	*value = val
 	return true, nil
@@ -100,12 +114,26 @@ func (p *suffixAnnotationParser) ParseInt64Annotation(annotation string, value *
 	}
 	i, err := strconv.ParseInt(raw, 10, 64)
 	if err != nil {
-		return true, errors.Wrapf(err, "failed to parse int64 annotation, %v: %v", matchedKey, raw)
+		return true, NewInvalidAnnotationError(matchedKey, errors.Wrapf(err, "failed to parse int64 annotation, %v: %v", matchedKey, raw))
 	}
 	*value = i
 	return true, nil
 }
 
+func (p *suffixAnnotationParser) ParseDurationAnnotation(annotation string, value *time.Duration, annotations map[string]string, opts ...ParseOption) (bool, error) {
+	raw := ""
+	exists, matchedKey := p.parseStringAnnotation(annotation, &raw, annotations, opts...)
+	if !exists {
+		return false, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return true, NewInvalidAnnotationError(matchedKey, errors.Wrapf(err, "failed to parse duration annotation, %v: %v", matchedKey, raw))
+	}
+	*value = d
+	return true, nil
+}
+
 func (p *suffixAnnotationParser) ParseStringSliceAnnotation(annotation string, value *[]string, annotations map[string]string, opts ...ParseOption) bool {
 	raw := ""
 	if exists, _ := p.parseStringAnnotation(annotation, &raw, annotations, opts...); !exists {
@@ -122,7 +150,7 @@ func (p *suffixAnnotationParser) ParseJSONAnnotation(annotation string, value in
 		return false, nil
 	}
 	if err := json.Unmarshal([]byte(raw), value); err != nil {
-		return true, errors.Wrapf(err, "failed to parse json annotation, %v: %v", matchedKey, raw)
+		return true, NewInvalidAnnotationError(matchedKey, errors.Wrapf(err, "failed to parse json annotation, %v: %v", matchedKey, raw))
 	}
 	return true, nil
 }
@@ -133,17 +161,24 @@ func (p *suffixAnnotationParser) ParseStringMapAnnotation(annotation string, val
 	if !exists {
 		return false, nil
 	}
+	parseOpts := ParseOptions{}
+	for _, opt := range opts {
+		opt(&parseOpts)
+	}
 	rawKVPairs := splitCommaSeparatedString(raw)
 	keyValues := make(map[string]string)
 	for _, kvPair := range rawKVPairs {
 		parts := strings.Split(kvPair, "=")
 		if len(parts) != 2 {
-			return false, errors.Errorf("failed to parse stringMap annotation, %v: %v", matchedKey, raw)
+			return false, NewInvalidAnnotationError(matchedKey, errors.Errorf("failed to parse stringMap annotation, %v: %v", matchedKey, raw))
 		}
-		key := parts[0]
-		value := parts[1]
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
 		if len(key) == 0 {
-			return false, errors.Errorf("failed to parse stringMap annotation, %v: %v", matchedKey, raw)
+			return false, NewInvalidAnnotationError(matchedKey, errors.Errorf("failed to parse stringMap annotation, %v: %v", matchedKey, raw))
+		}
+		if _, ok := keyValues[key]; ok && parseOpts.rejectDuplicateKeys {
+			return false, NewInvalidAnnotationError(matchedKey, errors.Errorf("failed to parse stringMap annotation, %v: %v: duplicate key %v", matchedKey, raw, key))
 		}
 		keyValues[key] = value
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func Test_annotationParser_ParseStringAnnotation(t *testing.T) {
@@ -126,6 +127,7 @@ func Test_serviceAnnotationParser_ParseInt64Annotation(t *testing.T) {
 		wantExist   bool
 		wantValue   int64
 		wantError   bool
+		wantErrKey  string
 	}{
 		{
 			name:        "no annotation",
@@ -154,7 +156,8 @@ func Test_serviceAnnotationParser_ParseInt64Annotation(t *testing.T) {
 			annotations: map[string]string{
 				"prefix/test/invalid": "22d",
 			},
-			wantError: true,
+			wantError:  true,
+			wantErrKey: "prefix/test/invalid",
 		},
 	}
 
@@ -165,6 +168,9 @@ func Test_serviceAnnotationParser_ParseInt64Annotation(t *testing.T) {
 			exists, err := parser.ParseInt64Annotation(tt.suffix, &value, tt.annotations, tt.opts...)
 			if tt.wantError {
 				assert.True(t, err != nil)
+				var invalidAnnotationErr *InvalidAnnotationError
+				assert.True(t, errors.As(err, &invalidAnnotationErr))
+				assert.Equal(t, tt.wantErrKey, invalidAnnotationErr.Key)
 			} else {
 				assert.Equal(t, nil, err)
 				assert.Equal(t, tt.wantExist, exists)
@@ -176,6 +182,93 @@ func Test_serviceAnnotationParser_ParseInt64Annotation(t *testing.T) {
 	}
 }
 
+func Test_serviceAnnotationParser_ParseDurationAnnotation(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		opts        []ParseOption
+		suffix      string
+		annotations map[string]string
+		wantExist   bool
+		wantValue   time.Duration
+		wantErr     error
+	}{
+		{
+			name:        "no annotation",
+			prefix:      "",
+			suffix:      "some-suffix",
+			annotations: nil,
+			wantExist:   false,
+		},
+		{
+			name:   "seconds",
+			prefix: "p.co",
+			suffix: "interval",
+			annotations: map[string]string{
+				"p.co/interval": "30s",
+			},
+			wantExist: true,
+			wantValue: 30 * time.Second,
+		},
+		{
+			name:   "compound duration",
+			prefix: "p.co",
+			suffix: "interval",
+			annotations: map[string]string{
+				"p.co/interval": "1m30s",
+			},
+			wantExist: true,
+			wantValue: 90 * time.Second,
+		},
+		{
+			name:   "alternative prefix",
+			prefix: "p.co",
+			suffix: "interval",
+			opts:   []ParseOption{WithAlternativePrefixes("alt.co")},
+			annotations: map[string]string{
+				"alt.co/interval": "2m",
+			},
+			wantExist: true,
+			wantValue: 2 * time.Minute,
+		},
+		{
+			name:   "malformed duration",
+			prefix: "p.co",
+			suffix: "interval",
+			annotations: map[string]string{
+				"p.co/interval": "30 seconds",
+			},
+			wantExist: true,
+			wantErr:   errors.New("failed to parse duration annotation, p.co/interval: 30 seconds: time: unknown unit \" seconds\" in duration \"30 seconds\""),
+		},
+		{
+			name:   "duration without unit",
+			prefix: "p.co",
+			suffix: "interval",
+			annotations: map[string]string{
+				"p.co/interval": "30",
+			},
+			wantExist: true,
+			wantErr:   errors.New("failed to parse duration annotation, p.co/interval: 30: time: missing unit in duration \"30\""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewSuffixAnnotationParser(tt.prefix)
+			var value time.Duration
+			exists, err := parser.ParseDurationAnnotation(tt.suffix, &value, tt.annotations, tt.opts...)
+			assert.Equal(t, tt.wantExist, exists)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantValue, value)
+			}
+		})
+	}
+}
+
 func Test_serviceAnnotationParser_ParseStringSliceAnnotation(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -278,6 +371,20 @@ func Test_serviceAnnotationParser_ParseStringMapAnnotation(t *testing.T) {
 				"key4/empty-value": "",
 			},
 		},
+		{
+			name:   "whitespaces around keys and values",
+			prefix: "p.co",
+			suffix: "sfx",
+			annotations: map[string]string{
+				"p.co/sfx": "a = b , c=d,\te =\tf g ",
+			},
+			wantExist: true,
+			wantValue: map[string]string{
+				"a": "b",
+				"c": "d",
+				"e": "f g",
+			},
+		},
 		{
 			name:   "invalid key-value pair - no '=' between k/v",
 			prefix: "p.co",
@@ -288,6 +395,24 @@ func Test_serviceAnnotationParser_ParseStringMapAnnotation(t *testing.T) {
 			},
 			wantError: errors.New("failed to parse stringMap annotation, p.co/sfx: key1,key2"),
 		},
+		{
+			name:   "invalid key-value pair - entry without '='",
+			prefix: "p.co",
+			suffix: "sfx",
+			annotations: map[string]string{
+				"p.co/sfx": "key1=value1, key2",
+			},
+			wantError: errors.New("failed to parse stringMap annotation, p.co/sfx: key1=value1, key2"),
+		},
+		{
+			name:   "invalid key-value pair - blank key",
+			prefix: "p.co",
+			suffix: "sfx",
+			annotations: map[string]string{
+				"p.co/sfx": " =value",
+			},
+			wantError: errors.New("failed to parse stringMap annotation, p.co/sfx:  =value"),
+		},
 		{
 			name:   "invalid key-value pair - emptyKey",
 			prefix: "p.co",
@@ -298,6 +423,28 @@ func Test_serviceAnnotationParser_ParseStringMapAnnotation(t *testing.T) {
 			},
 			wantError: errors.New("failed to parse stringMap annotation, p.co/sfx: =value"),
 		},
+		{
+			name:   "duplicate keys - last value wins",
+			prefix: "p.co",
+			suffix: "sfx",
+			annotations: map[string]string{
+				"p.co/sfx": "key1=value1, key1=value2",
+			},
+			wantExist: true,
+			wantValue: map[string]string{
+				"key1": "value2",
+			},
+		},
+		{
+			name:   "duplicate keys - rejected",
+			prefix: "p.co",
+			suffix: "sfx",
+			opts:   []ParseOption{WithRejectDuplicateKeys()},
+			annotations: map[string]string{
+				"p.co/sfx": "key1=value1, key1=value2",
+			},
+			wantError: errors.New("failed to parse stringMap annotation, p.co/sfx: key1=value1, key1=value2: duplicate key key1"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
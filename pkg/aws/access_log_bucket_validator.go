@@ -0,0 +1,125 @@
+package aws
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"strings"
+	"time"
+)
+
+const (
+	// error code when bucket doesn't have default encryption configured.
+	s3ErrCodeServerSideEncryptionConfigurationNotFound = "ServerSideEncryptionConfigurationNotFoundError"
+	// the buckets that permit log delivery will be cached for 5 minute.
+	defaultAccessLogBucketCacheTTL = 5 * time.Minute
+	// alias of the AWS managed KMS key for S3.
+	s3AWSManagedKMSKeyAlias = "alias/aws/s3"
+)
+
+// AccessLogBucketValidator is responsible for validate S3 buckets used for LoadBalancer logs.
+type AccessLogBucketValidator interface {
+	// Validate checks whether bucket exists within the same region as LoadBalancer, and whether its default encryption permits log delivery by ELB.
+	// buckets encrypted with the AWS managed KMS key are rejected, while buckets encrypted with customer managed KMS keys are only warned about,
+	// since log delivery works when the key policy grants access to ELB, which isn't checked.
+	Validate(ctx context.Context, bucket string) error
+}
+
+// NewS3AccessLogBucketValidator constructs new s3AccessLogBucketValidator.
+func NewS3AccessLogBucketValidator(s3Client services.S3, region string, logger logr.Logger) *s3AccessLogBucketValidator {
+	return &s3AccessLogBucketValidator{
+		s3Client:       s3Client,
+		region:         region,
+		logger:         logger,
+		bucketCache:    cache.NewExpiring(),
+		bucketCacheTTL: defaultAccessLogBucketCacheTTL,
+	}
+}
+
+var _ AccessLogBucketValidator = &s3AccessLogBucketValidator{}
+
+// AccessLogBucketValidator implementation that inspects bucket's location and default encryption.
+// ELB only delivers logs to buckets within the same region as the LoadBalancer,
+// and encrypted with Amazon S3-managed keys(SSE-S3), or with customer managed KMS keys whose key policy grants access to ELB.
+type s3AccessLogBucketValidator struct {
+	s3Client services.S3
+	region   string
+	logger   logr.Logger
+
+	bucketCache    *cache.Expiring
+	bucketCacheTTL time.Duration
+}
+
+func (v *s3AccessLogBucketValidator) Validate(ctx context.Context, bucket string) error {
+	if _, exists := v.bucketCache.Get(bucket); exists {
+		return nil
+	}
+	if err := v.validateBucketLocation(ctx, bucket); err != nil {
+		return err
+	}
+	if err := v.validateBucketEncryption(ctx, bucket); err != nil {
+		return err
+	}
+	v.bucketCache.Set(bucket, true, v.bucketCacheTTL)
+	return nil
+}
+
+func (v *s3AccessLogBucketValidator) validateBucketLocation(ctx context.Context, bucket string) error {
+	req := &s3.GetBucketLocationInput{
+		Bucket: awssdk.String(bucket),
+	}
+	resp, err := v.s3Client.GetBucketLocationWithContext(ctx, req)
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchBucket {
+			return errors.Errorf("access log bucket %v doesn't exist", bucket)
+		}
+		return errors.Wrapf(err, "failed to get location for access log bucket %v", bucket)
+	}
+	bucketRegion := s3.NormalizeBucketLocation(awssdk.StringValue(resp.LocationConstraint))
+	if bucketRegion != v.region {
+		return errors.Errorf("access log bucket %v is in region %v, which differs from the loadBalancer region %v", bucket, bucketRegion, v.region)
+	}
+	return nil
+}
+
+func (v *s3AccessLogBucketValidator) validateBucketEncryption(ctx context.Context, bucket string) error {
+	req := &s3.GetBucketEncryptionInput{
+		Bucket: awssdk.String(bucket),
+	}
+	resp, err := v.s3Client.GetBucketEncryptionWithContext(ctx, req)
+	if err != nil {
+		var awsErr awserr.Error
+		if !errors.As(err, &awsErr) || awsErr.Code() != s3ErrCodeServerSideEncryptionConfigurationNotFound {
+			return errors.Wrapf(err, "failed to get default encryption for access log bucket %v", bucket)
+		}
+		return nil
+	}
+	if resp.ServerSideEncryptionConfiguration == nil {
+		return nil
+	}
+	for _, rule := range resp.ServerSideEncryptionConfiguration.Rules {
+		if rule.ApplyServerSideEncryptionByDefault == nil {
+			continue
+		}
+		sseAlgorithm := awssdk.StringValue(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+		if !strings.HasPrefix(sseAlgorithm, s3.ServerSideEncryptionAwsKms) {
+			continue
+		}
+		kmsKeyID := awssdk.StringValue(rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID)
+		// S3 uses the AWS managed key when no key is specified, its key policy can't grant access to ELB.
+		if kmsKeyID == "" || kmsKeyID == s3AWSManagedKMSKeyAlias || strings.HasSuffix(kmsKeyID, ":"+s3AWSManagedKMSKeyAlias) {
+			return errors.Errorf("access log bucket %v is encrypted with the AWS managed KMS key aws/s3, which ELB can't deliver logs with, "+
+				"use Amazon S3-managed keys(SSE-S3) or a customer managed KMS key whose key policy grants access to ELB as the bucket default encryption", bucket)
+		}
+		// the key policy isn't checked, since it requires kms:GetKeyPolicy on keys that may belong to another account.
+		v.logger.Info("access log bucket uses customer managed KMS key for default encryption, log delivery by ELB requires the key policy granting access to ELB",
+			"bucket", bucket, "sseAlgorithm", sseAlgorithm, "kmsKeyID", kmsKeyID)
+	}
+	return nil
+}
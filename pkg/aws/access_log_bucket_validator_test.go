@@ -0,0 +1,260 @@
+package aws
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_s3AccessLogBucketValidator_Validate(t *testing.T) {
+	type getBucketLocationCall struct {
+		req  *s3.GetBucketLocationInput
+		resp *s3.GetBucketLocationOutput
+		err  error
+	}
+	type getBucketEncryptionCall struct {
+		req  *s3.GetBucketEncryptionInput
+		resp *s3.GetBucketEncryptionOutput
+		err  error
+	}
+	sseS3Encryption := &s3.GetBucketEncryptionOutput{
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{
+				{
+					ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+						SSEAlgorithm: awssdk.String("AES256"),
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                     string
+		region                   string
+		getBucketLocationCalls   []getBucketLocationCall
+		getBucketEncryptionCalls []getBucketEncryptionCall
+		validateTimes            int
+		wantErr                  error
+	}{
+		{
+			name:   "bucket encrypted with SSE-S3 within same region",
+			region: "us-west-2",
+			getBucketLocationCalls: []getBucketLocationCall{
+				{
+					req:  &s3.GetBucketLocationInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketLocationOutput{LocationConstraint: awssdk.String("us-west-2")},
+				},
+			},
+			getBucketEncryptionCalls: []getBucketEncryptionCall{
+				{
+					req:  &s3.GetBucketEncryptionInput{Bucket: awssdk.String("my-bucket")},
+					resp: sseS3Encryption,
+				},
+			},
+			validateTimes: 1,
+		},
+		{
+			name:   "bucket encrypted with SSE-S3 within same region should be cached",
+			region: "us-west-2",
+			getBucketLocationCalls: []getBucketLocationCall{
+				{
+					req:  &s3.GetBucketLocationInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketLocationOutput{LocationConstraint: awssdk.String("us-west-2")},
+				},
+			},
+			getBucketEncryptionCalls: []getBucketEncryptionCall{
+				{
+					req:  &s3.GetBucketEncryptionInput{Bucket: awssdk.String("my-bucket")},
+					resp: sseS3Encryption,
+				},
+			},
+			validateTimes: 2,
+		},
+		{
+			name:   "bucket within us-east-1 without default encryption",
+			region: "us-east-1",
+			getBucketLocationCalls: []getBucketLocationCall{
+				{
+					req:  &s3.GetBucketLocationInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketLocationOutput{},
+				},
+			},
+			getBucketEncryptionCalls: []getBucketEncryptionCall{
+				{
+					req: &s3.GetBucketEncryptionInput{Bucket: awssdk.String("my-bucket")},
+					err: awserr.New("ServerSideEncryptionConfigurationNotFoundError", "The server side encryption configuration was not found", nil),
+				},
+			},
+			validateTimes: 1,
+		},
+		{
+			name:   "bucket encrypted with SSE-KMS using customer managed key",
+			region: "us-west-2",
+			getBucketLocationCalls: []getBucketLocationCall{
+				{
+					req:  &s3.GetBucketLocationInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketLocationOutput{LocationConstraint: awssdk.String("us-west-2")},
+				},
+			},
+			getBucketEncryptionCalls: []getBucketEncryptionCall{
+				{
+					req: &s3.GetBucketEncryptionInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketEncryptionOutput{
+						ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+							Rules: []*s3.ServerSideEncryptionRule{
+								{
+									ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+										SSEAlgorithm:   awssdk.String("aws:kms"),
+										KMSMasterKeyID: awssdk.String("arn:aws:kms:us-west-2:123456789012:key/my-key"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			validateTimes: 1,
+		},
+		{
+			name:   "bucket encrypted with SSE-KMS without key specified",
+			region: "us-west-2",
+			getBucketLocationCalls: []getBucketLocationCall{
+				{
+					req:  &s3.GetBucketLocationInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketLocationOutput{LocationConstraint: awssdk.String("us-west-2")},
+				},
+			},
+			getBucketEncryptionCalls: []getBucketEncryptionCall{
+				{
+					req: &s3.GetBucketEncryptionInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketEncryptionOutput{
+						ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+							Rules: []*s3.ServerSideEncryptionRule{
+								{
+									ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+										SSEAlgorithm: awssdk.String("aws:kms"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("access log bucket my-bucket is encrypted with the AWS managed KMS key aws/s3, which ELB can't deliver logs with, use Amazon S3-managed keys(SSE-S3) or a customer managed KMS key whose key policy grants access to ELB as the bucket default encryption"),
+		},
+		{
+			name:   "bucket encrypted with SSE-KMS using AWS managed key",
+			region: "us-west-2",
+			getBucketLocationCalls: []getBucketLocationCall{
+				{
+					req:  &s3.GetBucketLocationInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketLocationOutput{LocationConstraint: awssdk.String("us-west-2")},
+				},
+			},
+			getBucketEncryptionCalls: []getBucketEncryptionCall{
+				{
+					req: &s3.GetBucketEncryptionInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketEncryptionOutput{
+						ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+							Rules: []*s3.ServerSideEncryptionRule{
+								{
+									ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+										SSEAlgorithm:   awssdk.String("aws:kms"),
+										KMSMasterKeyID: awssdk.String("arn:aws:kms:us-west-2:123456789012:alias/aws/s3"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("access log bucket my-bucket is encrypted with the AWS managed KMS key aws/s3, which ELB can't deliver logs with, use Amazon S3-managed keys(SSE-S3) or a customer managed KMS key whose key policy grants access to ELB as the bucket default encryption"),
+		},
+		{
+			name:   "bucket exists within different region",
+			region: "us-west-2",
+			getBucketLocationCalls: []getBucketLocationCall{
+				{
+					req:  &s3.GetBucketLocationInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketLocationOutput{LocationConstraint: awssdk.String("eu-west-1")},
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("access log bucket my-bucket is in region eu-west-1, which differs from the loadBalancer region us-west-2"),
+		},
+		{
+			name:   "bucket doesn't exist",
+			region: "us-west-2",
+			getBucketLocationCalls: []getBucketLocationCall{
+				{
+					req: &s3.GetBucketLocationInput{Bucket: awssdk.String("my-bucket")},
+					err: awserr.New("NoSuchBucket", "The specified bucket does not exist", nil),
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("access log bucket my-bucket doesn't exist"),
+		},
+		{
+			name:   "failed to get bucket location",
+			region: "us-west-2",
+			getBucketLocationCalls: []getBucketLocationCall{
+				{
+					req: &s3.GetBucketLocationInput{Bucket: awssdk.String("my-bucket")},
+					err: awserr.New("AccessDenied", "Access Denied", nil),
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("failed to get location for access log bucket my-bucket: AccessDenied: Access Denied"),
+		},
+		{
+			name:   "failed to get bucket encryption",
+			region: "us-west-2",
+			getBucketLocationCalls: []getBucketLocationCall{
+				{
+					req:  &s3.GetBucketLocationInput{Bucket: awssdk.String("my-bucket")},
+					resp: &s3.GetBucketLocationOutput{LocationConstraint: awssdk.String("us-west-2")},
+				},
+			},
+			getBucketEncryptionCalls: []getBucketEncryptionCall{
+				{
+					req: &s3.GetBucketEncryptionInput{Bucket: awssdk.String("my-bucket")},
+					err: awserr.New("AccessDenied", "Access Denied", nil),
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("failed to get default encryption for access log bucket my-bucket: AccessDenied: Access Denied"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s3Client := mock_services.NewMockS3(ctrl)
+			for _, call := range tt.getBucketLocationCalls {
+				s3Client.EXPECT().GetBucketLocationWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.getBucketEncryptionCalls {
+				s3Client.EXPECT().GetBucketEncryptionWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			v := NewS3AccessLogBucketValidator(s3Client, tt.region, &log.NullLogger{})
+			for i := 0; i < tt.validateTimes; i++ {
+				err := v.Validate(context.Background(), "my-bucket")
+				if tt.wantErr != nil {
+					assert.EqualError(t, err, tt.wantErr.Error())
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/metrics"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 )
 
 type Cloud interface {
@@ -33,6 +34,15 @@ type Cloud interface {
 	// RGT provides API to AWS RGT
 	RGT() services.RGT
 
+	// Lambda provides API to AWS Lambda
+	Lambda() services.Lambda
+
+	// Route53 provides API to AWS Route53
+	Route53() services.Route53
+
+	// S3 provides API to AWS S3
+	S3() services.S3
+
 	// Region for the kubernetes cluster
 	Region() string
 
@@ -75,6 +85,7 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 		}
 		metricsCollector.InjectHandlers(&sess.Handlers)
 	}
+	tracing.InjectAWSHandlers(&sess.Handlers)
 
 	return &defaultCloud{
 		cfg:         cfg,
@@ -85,6 +96,9 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 		wafRegional: services.NewWAFRegional(sess, cfg.Region),
 		shield:      services.NewShield(sess),
 		rgt:         services.NewRGT(sess),
+		lambda:      services.NewLambda(sess),
+		route53:     services.NewRoute53(sess),
+		s3:          services.NewS3(sess),
 	}, nil
 }
 
@@ -101,6 +115,9 @@ type defaultCloud struct {
 	wafRegional services.WAFRegional
 	shield      services.Shield
 	rgt         services.RGT
+	lambda      services.Lambda
+	route53     services.Route53
+	s3          services.S3
 }
 
 func (c *defaultCloud) EC2() services.EC2 {
@@ -131,6 +148,18 @@ func (c *defaultCloud) RGT() services.RGT {
 	return c.rgt
 }
 
+func (c *defaultCloud) Lambda() services.Lambda {
+	return c.lambda
+}
+
+func (c *defaultCloud) Route53() services.Route53 {
+	return c.route53
+}
+
+func (c *defaultCloud) S3() services.S3 {
+	return c.s3
+}
+
 func (c *defaultCloud) Region() string {
 	return c.cfg.Region
 }
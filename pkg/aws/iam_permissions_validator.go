@@ -0,0 +1,179 @@
+package aws
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	// the ec2 error code when dryRun request would have succeeded.
+	errCodeDryRunOperation = "DryRunOperation"
+	// the ec2 error code when caller isn't authorized.
+	errCodeUnauthorizedOperation = "UnauthorizedOperation"
+	// the elbv2 error code when caller isn't authorized.
+	errCodeAccessDenied = "AccessDenied"
+
+	iamPermissionsCheckSGName = "k8s-elb-iam-permissions-check"
+)
+
+// IAMPermissionsValidator validates the controller's IAM permissions for key AWS operations.
+type IAMPermissionsValidator interface {
+	// Validate checks key AWS operations with dryRun or describe calls, and returns the IAM permissions that are missing.
+	Validate(ctx context.Context) []string
+}
+
+// NewDefaultIAMPermissionsValidator constructs new defaultIAMPermissionsValidator.
+func NewDefaultIAMPermissionsValidator(ec2Client services.EC2, elbv2Client services.ELBV2, vpcID string, logger logr.Logger) *defaultIAMPermissionsValidator {
+	return &defaultIAMPermissionsValidator{
+		ec2Client:   ec2Client,
+		elbv2Client: elbv2Client,
+		vpcID:       vpcID,
+		logger:      logger,
+	}
+}
+
+var _ IAMPermissionsValidator = &defaultIAMPermissionsValidator{}
+
+// default implementation for IAMPermissionsValidator.
+// ec2 operations are checked with dryRun requests, elbv2 operations are checked with describe requests since it doesn't support dryRun.
+type defaultIAMPermissionsValidator struct {
+	ec2Client   services.EC2
+	elbv2Client services.ELBV2
+	vpcID       string
+	logger      logr.Logger
+}
+
+// iamPermissionCheck checks whether a single IAM permission is granted.
+type iamPermissionCheck struct {
+	// the IAM permission to check, e.g. ec2:DescribeSubnets
+	permission string
+	// invokes the AWS operation requires the permission.
+	invoke func(ctx context.Context) error
+}
+
+func (v *defaultIAMPermissionsValidator) Validate(ctx context.Context) []string {
+	var missingPermissions []string
+	for _, check := range v.buildIAMPermissionChecks() {
+		granted, err := isIAMPermissionGranted(check.invoke(ctx))
+		if err != nil {
+			// permissions that cannot be verified are not reported as missing, since the failure might be transient.
+			v.logger.Info("unable to verify IAM permission", "permission", check.permission, "error", err.Error())
+			continue
+		}
+		if !granted {
+			missingPermissions = append(missingPermissions, check.permission)
+		}
+	}
+	if len(missingPermissions) != 0 {
+		v.logger.Info("controller IAM role is missing permissions, reconcile of ingresses and services might fail",
+			"missingPermissions", missingPermissions)
+	} else {
+		v.logger.Info("validated controller IAM permissions")
+	}
+	return missingPermissions
+}
+
+func (v *defaultIAMPermissionsValidator) buildIAMPermissionChecks() []iamPermissionCheck {
+	vpcFilter := []*ec2sdk.Filter{
+		{
+			Name:   awssdk.String("vpc-id"),
+			Values: awssdk.StringSlice([]string{v.vpcID}),
+		},
+	}
+	return []iamPermissionCheck{
+		{
+			permission: "ec2:DescribeSubnets",
+			invoke: func(ctx context.Context) error {
+				_, err := v.ec2Client.DescribeSubnetsWithContext(ctx, &ec2sdk.DescribeSubnetsInput{
+					DryRun:  awssdk.Bool(true),
+					Filters: vpcFilter,
+				})
+				return err
+			},
+		},
+		{
+			permission: "ec2:DescribeSecurityGroups",
+			invoke: func(ctx context.Context) error {
+				_, err := v.ec2Client.DescribeSecurityGroupsWithContext(ctx, &ec2sdk.DescribeSecurityGroupsInput{
+					DryRun:  awssdk.Bool(true),
+					Filters: vpcFilter,
+				})
+				return err
+			},
+		},
+		{
+			permission: "ec2:DescribeInstances",
+			invoke: func(ctx context.Context) error {
+				_, err := v.ec2Client.DescribeInstancesWithContext(ctx, &ec2sdk.DescribeInstancesInput{
+					DryRun:  awssdk.Bool(true),
+					Filters: vpcFilter,
+				})
+				return err
+			},
+		},
+		{
+			permission: "ec2:DescribeNetworkInterfaces",
+			invoke: func(ctx context.Context) error {
+				_, err := v.ec2Client.DescribeNetworkInterfacesWithContext(ctx, &ec2sdk.DescribeNetworkInterfacesInput{
+					DryRun:  awssdk.Bool(true),
+					Filters: vpcFilter,
+				})
+				return err
+			},
+		},
+		{
+			permission: "ec2:CreateSecurityGroup",
+			invoke: func(ctx context.Context) error {
+				_, err := v.ec2Client.CreateSecurityGroupWithContext(ctx, &ec2sdk.CreateSecurityGroupInput{
+					DryRun:      awssdk.Bool(true),
+					GroupName:   awssdk.String(iamPermissionsCheckSGName),
+					Description: awssdk.String("[k8s] IAM permissions check"),
+					VpcId:       awssdk.String(v.vpcID),
+				})
+				return err
+			},
+		},
+		{
+			permission: "elasticloadbalancing:DescribeLoadBalancers",
+			invoke: func(ctx context.Context) error {
+				_, err := v.elbv2Client.DescribeLoadBalancersWithContext(ctx, &elbv2sdk.DescribeLoadBalancersInput{
+					PageSize: awssdk.Int64(1),
+				})
+				return err
+			},
+		},
+		{
+			permission: "elasticloadbalancing:DescribeTargetGroups",
+			invoke: func(ctx context.Context) error {
+				_, err := v.elbv2Client.DescribeTargetGroupsWithContext(ctx, &elbv2sdk.DescribeTargetGroupsInput{
+					PageSize: awssdk.Int64(1),
+				})
+				return err
+			},
+		},
+	}
+}
+
+// isIAMPermissionGranted tests whether the IAM permission is granted per error from the AWS operation.
+// an error is returned if it cannot be determined.
+func isIAMPermissionGranted(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		switch awsErr.Code() {
+		case errCodeDryRunOperation:
+			return true, nil
+		case errCodeUnauthorizedOperation, errCodeAccessDenied:
+			return false, nil
+		}
+	}
+	return false, err
+}
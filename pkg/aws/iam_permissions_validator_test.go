@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultIAMPermissionsValidator_Validate(t *testing.T) {
+	dryRunErr := awserr.New("DryRunOperation", "Request would have succeeded, but DryRun flag is set.", nil)
+	unauthorizedErr := awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil)
+	accessDeniedErr := awserr.New("AccessDenied", "User is not authorized to perform this operation.", nil)
+	type ec2Errors struct {
+		describeSubnets           error
+		describeSecurityGroups    error
+		describeInstances         error
+		describeNetworkInterfaces error
+		createSecurityGroup       error
+	}
+	type elbv2Errors struct {
+		describeLoadBalancers error
+		describeTargetGroups  error
+	}
+	tests := []struct {
+		name        string
+		ec2Errors   ec2Errors
+		elbv2Errors elbv2Errors
+		want        []string
+	}{
+		{
+			name: "all permissions granted",
+			ec2Errors: ec2Errors{
+				describeSubnets:           dryRunErr,
+				describeSecurityGroups:    dryRunErr,
+				describeInstances:         dryRunErr,
+				describeNetworkInterfaces: dryRunErr,
+				createSecurityGroup:       dryRunErr,
+			},
+			want: nil,
+		},
+		{
+			name: "ec2 and elbv2 permissions missing",
+			ec2Errors: ec2Errors{
+				describeSubnets:           dryRunErr,
+				describeSecurityGroups:    dryRunErr,
+				describeInstances:         unauthorizedErr,
+				describeNetworkInterfaces: dryRunErr,
+				createSecurityGroup:       unauthorizedErr,
+			},
+			elbv2Errors: elbv2Errors{
+				describeTargetGroups: accessDeniedErr,
+			},
+			want: []string{"ec2:DescribeInstances", "ec2:CreateSecurityGroup", "elasticloadbalancing:DescribeTargetGroups"},
+		},
+		{
+			name: "permissions cannot be verified are not reported",
+			ec2Errors: ec2Errors{
+				describeSubnets:           errors.New("connection reset by peer"),
+				describeSecurityGroups:    dryRunErr,
+				describeInstances:         dryRunErr,
+				describeNetworkInterfaces: dryRunErr,
+				createSecurityGroup:       awserr.New("InvalidVpcID.NotFound", "The vpc ID 'vpc-xxx' does not exist", nil),
+			},
+			elbv2Errors: elbv2Errors{
+				describeLoadBalancers: accessDeniedErr,
+			},
+			want: []string{"elasticloadbalancing:DescribeLoadBalancers"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			ec2Client.EXPECT().DescribeSubnetsWithContext(gomock.Any(), gomock.Any()).Return(nil, tt.ec2Errors.describeSubnets)
+			ec2Client.EXPECT().DescribeSecurityGroupsWithContext(gomock.Any(), gomock.Any()).Return(nil, tt.ec2Errors.describeSecurityGroups)
+			ec2Client.EXPECT().DescribeInstancesWithContext(gomock.Any(), gomock.Any()).Return(nil, tt.ec2Errors.describeInstances)
+			ec2Client.EXPECT().DescribeNetworkInterfacesWithContext(gomock.Any(), gomock.Any()).Return(nil, tt.ec2Errors.describeNetworkInterfaces)
+			ec2Client.EXPECT().CreateSecurityGroupWithContext(gomock.Any(), gomock.Any()).Return(nil, tt.ec2Errors.createSecurityGroup)
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeLoadBalancersWithContext(gomock.Any(), gomock.Any()).Return(nil, tt.elbv2Errors.describeLoadBalancers)
+			elbv2Client.EXPECT().DescribeTargetGroupsWithContext(gomock.Any(), gomock.Any()).Return(nil, tt.elbv2Errors.describeTargetGroups)
+
+			v := NewDefaultIAMPermissionsValidator(ec2Client, elbv2Client, "vpc-xxx", &log.NullLogger{})
+			got := v.Validate(context.Background())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_isIAMPermissionGranted(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "succeeded without error",
+			err:  nil,
+			want: true,
+		},
+		{
+			name: "dryRun would have succeeded",
+			err:  awserr.New("DryRunOperation", "Request would have succeeded, but DryRun flag is set.", nil),
+			want: true,
+		},
+		{
+			name: "wrapped unauthorized error",
+			err:  errors.Wrap(awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil), "wrapped message"),
+			want: false,
+		},
+		{
+			name: "access denied",
+			err:  awserr.New("AccessDenied", "User is not authorized to perform this operation.", nil),
+			want: false,
+		},
+		{
+			name:    "other error",
+			err:     errors.New("some other error"),
+			wantErr: errors.New("some other error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isIAMPermissionGranted(tt.err)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+type Lambda interface {
+	lambdaiface.LambdaAPI
+}
+
+// NewLambda constructs new Lambda implementation.
+func NewLambda(session *session.Session) Lambda {
+	return &defaultLambda{
+		LambdaAPI: lambda.New(session),
+	}
+}
+
+type defaultLambda struct {
+	lambdaiface.LambdaAPI
+}
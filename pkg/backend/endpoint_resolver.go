@@ -5,8 +5,10 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -129,6 +131,14 @@ func (r *defaultEndpointResolver) ResolveNodePortEndpoints(ctx context.Context,
 	if err := r.k8sClient.List(ctx, nodeList, client.MatchingLabelsSelector{Selector: resolveOpts.NodeSelector}); err != nil {
 		return nil, err
 	}
+	// with externalTrafficPolicy Local, nodes only forward traffic to pods running on them, so nodes without local endpoints are excluded.
+	var localEndpointNodeNames sets.String
+	if svc.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal {
+		localEndpointNodeNames, err = r.findNodeNamesWithReadyEndpoints(ctx, svc, svcPort)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	var endpoints []NodePortEndpoint
 	for i := range nodeList.Items {
@@ -136,6 +146,9 @@ func (r *defaultEndpointResolver) ResolveNodePortEndpoints(ctx context.Context,
 		if !k8s.IsNodeReady(node) {
 			continue
 		}
+		if localEndpointNodeNames != nil && !localEndpointNodeNames.Has(node.Name) {
+			continue
+		}
 		instanceID, err := k8s.ExtractNodeInstanceID(node)
 		if err != nil {
 			return nil, err
@@ -159,6 +172,34 @@ func (r *defaultEndpointResolver) findServiceAndServicePort(ctx context.Context,
 	return svc, svcPort, nil
 }
 
+// findNodeNamesWithReadyEndpoints returns the names of nodes that have ready endpoints for specific service & service port.
+func (r *defaultEndpointResolver) findNodeNamesWithReadyEndpoints(ctx context.Context, svc *corev1.Service, svcPort corev1.ServicePort) (sets.String, error) {
+	epsKey := k8s.NamespacedName(svc) // k8s Endpoints have same name as k8s Service
+	eps := &corev1.Endpoints{}
+	if err := r.k8sClient.Get(ctx, epsKey, eps); err != nil {
+		if apierrors.IsNotFound(err) {
+			return sets.NewString(), nil
+		}
+		return nil, err
+	}
+
+	nodeNames := sets.NewString()
+	for _, epSubset := range eps.Subsets {
+		for _, epPort := range epSubset.Ports {
+			// servicePort.Name is optional if there is only one port
+			if svcPort.Name != "" && svcPort.Name != epPort.Name {
+				continue
+			}
+			for _, epAddr := range epSubset.Addresses {
+				if epAddr.NodeName != nil {
+					nodeNames.Insert(*epAddr.NodeName)
+				}
+			}
+		}
+	}
+	return nodeNames, nil
+}
+
 func (r *defaultEndpointResolver) findPodByReference(ctx context.Context, namespace string, podRef corev1.ObjectReference) (k8s.PodInfo, bool, error) {
 	podKey := types.NamespacedName{Namespace: namespace, Name: podRef.Name}
 	return r.podInfoRepo.Get(ctx, podKey)
@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -872,9 +873,80 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 		},
 	}
 
+	svc3 := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-3",
+		},
+		Spec: corev1.ServiceSpec{
+			Type:                  corev1.ServiceTypeLoadBalancer,
+			ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+			Ports: []corev1.ServicePort{
+				{
+					Name:     "http",
+					Port:     80,
+					NodePort: 18080,
+				},
+				{
+					Name:     "https",
+					Port:     443,
+					NodePort: 18443,
+				},
+			},
+		},
+	}
+	svc4 := svc3.DeepCopy()
+	svc4.Name = "svc-4"
+	svc4.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+	svc3Endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-3",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{
+					{
+						IP:       "192.168.1.1",
+						NodeName: awssdk.String("node-1"),
+					},
+				},
+				NotReadyAddresses: []corev1.EndpointAddress{
+					{
+						IP:       "192.168.1.2",
+						NodeName: awssdk.String("node-2"),
+					},
+				},
+				Ports: []corev1.EndpointPort{
+					{
+						Name: "http",
+						Port: 8080,
+					},
+				},
+			},
+			{
+				Addresses: []corev1.EndpointAddress{
+					{
+						IP:       "192.168.1.3",
+						NodeName: awssdk.String("node-2"),
+					},
+				},
+				Ports: []corev1.EndpointPort{
+					{
+						Name: "https",
+						Port: 8443,
+					},
+				},
+			},
+		},
+	}
+	svc4Endpoints := svc3Endpoints.DeepCopy()
+	svc4Endpoints.Name = "svc-4"
+
 	type env struct {
-		nodes    []*corev1.Node
-		services []*corev1.Service
+		nodes     []*corev1.Node
+		services  []*corev1.Service
+		endpoints []*corev1.Endpoints
 	}
 	type args struct {
 		svcKey types.NamespacedName
@@ -957,6 +1029,84 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 			},
 			wantErr: errors.New("service type must be either 'NodePort' or 'LoadBalancer': test-ns/svc-2"),
 		},
+		{
+			name: "externalTrafficPolicy Local only chooses ready nodes with ready endpoints",
+			env: env{
+				nodes:     []*corev1.Node{node1, node2, node3, node4},
+				services:  []*corev1.Service{svc3},
+				endpoints: []*corev1.Endpoints{svc3Endpoints},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc3),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithNodeSelector(labels.Everything())},
+			},
+			want: []NodePortEndpoint{
+				{
+					InstanceID: "i-abcdefg1",
+					Port:       18080,
+					Node:       node1,
+				},
+			},
+		},
+		{
+			name: "externalTrafficPolicy Local only chooses nodes with ready endpoints for service port",
+			env: env{
+				nodes:     []*corev1.Node{node1, node2, node3, node4},
+				services:  []*corev1.Service{svc3},
+				endpoints: []*corev1.Endpoints{svc3Endpoints},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc3),
+				port:   intstr.FromString("https"),
+				opts:   []EndpointResolveOption{WithNodeSelector(labels.Everything())},
+			},
+			want: []NodePortEndpoint{
+				{
+					InstanceID: "i-abcdefg2",
+					Port:       18443,
+					Node:       node2,
+				},
+			},
+		},
+		{
+			name: "externalTrafficPolicy Local chooses no node without endpoints",
+			env: env{
+				nodes:    []*corev1.Node{node1, node2, node3, node4},
+				services: []*corev1.Service{svc3},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc3),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithNodeSelector(labels.Everything())},
+			},
+			want: nil,
+		},
+		{
+			name: "externalTrafficPolicy Cluster chooses every ready node regardless of endpoints",
+			env: env{
+				nodes:     []*corev1.Node{node1, node2, node3, node4},
+				services:  []*corev1.Service{svc4},
+				endpoints: []*corev1.Endpoints{svc4Endpoints},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc4),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithNodeSelector(labels.Everything())},
+			},
+			want: []NodePortEndpoint{
+				{
+					InstanceID: "i-abcdefg1",
+					Port:       18080,
+					Node:       node1,
+				},
+				{
+					InstanceID: "i-abcdefg2",
+					Port:       18080,
+					Node:       node2,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -970,6 +1120,9 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 			for _, svc := range tt.env.services {
 				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
 			}
+			for _, eps := range tt.env.endpoints {
+				assert.NoError(t, k8sClient.Create(ctx, eps.DeepCopy()))
+			}
 
 			r := &defaultEndpointResolver{
 				k8sClient: k8sClient,
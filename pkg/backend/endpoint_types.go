@@ -29,6 +29,7 @@ type NodePortEndpoint struct {
 // options for Endpoints resolve APIs
 type EndpointResolveOptions struct {
 	// [NodePort Endpoint] only nodes that are ready and matched by nodeSelector will be included.
+	// For services with externalTrafficPolicy Local, only nodes with ready endpoints of the service will be included as well.
 	// By default, no node will be selected.
 	NodeSelector labels.Selector
 
@@ -3,10 +3,13 @@ package config
 import "github.com/spf13/pflag"
 
 const (
-	flagWAFEnabled    = "enable-waf"
-	flagWAFV2Enabled  = "enable-wafv2"
-	flagShieldEnabled = "enable-shield"
-	defaultEnabled    = true
+	flagWAFEnabled             = "enable-waf"
+	flagWAFV2Enabled           = "enable-wafv2"
+	flagShieldEnabled          = "enable-shield"
+	flagEndpointServiceEnabled = "enable-endpoint-service"
+	defaultEnabled             = true
+	// VPC endpoint service addon is disabled by default since it requires additional IAM permissions for all reconciles.
+	defaultEndpointServiceEnabled = false
 )
 
 // AddonsConfig contains configuration for the addon features
@@ -17,6 +20,8 @@ type AddonsConfig struct {
 	WAFV2Enabled bool
 	// Shield addon for ALB
 	ShieldEnabled bool
+	// VPC endpoint service addon for NLB
+	EndpointServiceEnabled bool
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -24,4 +29,5 @@ func (f *AddonsConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&f.WAFEnabled, flagWAFEnabled, defaultEnabled, "Enable WAF addon for ALB")
 	fs.BoolVar(&f.WAFV2Enabled, flagWAFV2Enabled, defaultEnabled, "Enable WAF V2 addon for ALB")
 	fs.BoolVar(&f.ShieldEnabled, flagShieldEnabled, defaultEnabled, "Enable Shield addon for ALB")
+	fs.BoolVar(&f.EndpointServiceEnabled, flagEndpointServiceEnabled, defaultEndpointServiceEnabled, "Enable VPC endpoint service addon for NLB")
 }
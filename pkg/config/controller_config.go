@@ -3,8 +3,12 @@ package config
 import (
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
+	"regexp"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
+	"strings"
+	"time"
 )
 
 const (
@@ -12,10 +16,63 @@ const (
 	flagK8sClusterName                            = "cluster-name"
 	flagServiceMaxConcurrentReconciles            = "service-max-concurrent-reconciles"
 	flagTargetGroupBindingMaxConcurrentReconciles = "targetgroupbinding-max-concurrent-reconciles"
+	flagTargetGroupBindingSkipOffAZNodes          = "targetgroupbinding-skip-off-az-nodes"
+	flagTargetGroupBindingRegisterTargetsRetries  = "targetgroupbinding-register-targets-max-retries"
+	flagLoadBalancerAZExpansionPolicy             = "load-balancer-az-expansion-policy"
+	flagNLBMinAZCount                             = "nlb-min-az-count"
+	flagNLBSingleAZDiscoveryPolicy                = "nlb-single-az-discovery-policy"
+	flagNLBCrossZoneCostWarning                   = "nlb-cross-zone-cost-warning"
+	flagServiceEIPReuseDetection                  = "service-eip-reuse-detection"
+	flagServiceLenientAnnotationParsing           = "service-lenient-annotation-parsing"
+	flagServiceDryRun                             = "service-dry-run"
+	flagServiceTargetGroupNamePrefix              = "target-group-name-prefix"
+	flagSubnetDiscoveryPreferAvailableIPs         = "subnet-discovery-prefer-available-ips"
+	flagValidateAccessLogBucket                   = "validate-access-log-bucket"
+	flagSecurityGroupRulesCleanupPolicy           = "security-group-rules-cleanup-policy"
+	flagValidateIAMPermissions                    = "validate-iam-permissions"
+	flagValidateELBV2Quotas                       = "validate-elbv2-quotas"
+	flagDefaultTags                               = "default-tags"
+	flagSubnetResolveCacheTTL                     = "subnet-resolve-cache-ttl"
 	defaultLogLevel                               = "info"
 	defaultMaxConcurrentReconciles                = 3
+	defaultRegisterTargetsMaxRetries              = 3
+	defaultLoadBalancerAZExpansionPolicy          = AZExpansionPolicyExpand
+	defaultNLBMinAZCount                          = 1
+	defaultNLBSingleAZDiscoveryPolicy             = SingleAZDiscoveryPolicyWarn
+	defaultServiceTargetGroupNamePrefix           = ""
+	defaultSecurityGroupRulesCleanupPolicy        = SGRulesCleanupPolicyAll
+	defaultSubnetResolveCacheTTL                  = 60 * time.Second
+	// the targetGroup name prefix is limited, so that namespace and name keep at least 3 characters alongside the minimum hash portion.
+	maxServiceTargetGroupNamePrefixLength = 8
+	// the tag key prefix reserved for AWS use.
+	reservedTagKeyPrefixAWS = "aws:"
 )
 
+const (
+	// AZExpansionPolicyExpand adds auto-discovered subnets in newly eligible availabilityZones to existing LoadBalancers.
+	AZExpansionPolicyExpand = "expand"
+	// AZExpansionPolicyIgnore keeps existing LoadBalancers with auto-discovered subnets within their current availabilityZones.
+	AZExpansionPolicyIgnore = "ignore"
+)
+
+const (
+	// SingleAZDiscoveryPolicyWarn emits warning events for Services whose auto-discovered subnets span a single availabilityZone.
+	SingleAZDiscoveryPolicyWarn = "warn"
+	// SingleAZDiscoveryPolicyError fails the reconcile of Services whose auto-discovered subnets span a single availabilityZone.
+	SingleAZDiscoveryPolicyError = "error"
+	// SingleAZDiscoveryPolicyProceed accepts auto-discovered subnets that span a single availabilityZone silently.
+	SingleAZDiscoveryPolicyProceed = "proceed"
+)
+
+const (
+	// SGRulesCleanupPolicyAll revokes every rule on managed securityGroups that isn't desired, including rules added out-of-band.
+	SGRulesCleanupPolicyAll = "all"
+	// SGRulesCleanupPolicyOwned only revokes the rules on managed securityGroups that are owned by the controller.
+	SGRulesCleanupPolicyOwned = "owned"
+)
+
+var validServiceTargetGroupNamePrefixPattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+
 // ControllerConfig contains the controller configuration
 type ControllerConfig struct {
 	// Log level for the controller logs
@@ -32,11 +89,48 @@ type ControllerConfig struct {
 	IngressConfig IngressConfig
 	// Configurations for Addons feature
 	AddonsConfig AddonsConfig
+	// Configurations for tracing
+	TracingConfig tracing.Config
 
 	// Max concurrent reconcile loops for Service objects
 	ServiceMaxConcurrentReconciles int
 	// Max concurrent reconcile loops for TargetGroupBinding objects
 	TargetGroupBindingMaxConcurrentReconciles int
+	// Whether to skip registering instance targets whose node is outside LoadBalancer's availabilityZones
+	TargetGroupBindingSkipOffAZNodes bool
+	// Max retries for each target that failed to be registered into TargetGroup
+	TargetGroupBindingRegisterTargetsMaxRetries int
+	// How to handle auto-discovered subnets in availabilityZones not yet enabled for existing LoadBalancers
+	LoadBalancerAZExpansionPolicy string
+	// Minimum number of availabilityZones the subnets of NLBs must span
+	NLBMinAZCount int
+	// How to handle auto-discovered NLB subnets that span a single availabilityZone
+	NLBSingleAZDiscoveryPolicy string
+	// Whether to emit warning events for NLBs with cross-zone load balancing enabled, given the data transfer cost
+	NLBCrossZoneCostWarning bool
+	// Whether to emit warning events for Services that reference the same EIP allocations
+	ServiceEIPReuseDetection bool
+	// Whether to ignore malformed non-critical Service annotations with warning events, keeping the currently applied values
+	ServiceLenientAnnotationParsing bool
+	// Whether to only validate the annotations of Services without provisioning any AWS resources
+	ServiceDryRun bool
+	// Prefix for the name of targetGroups created for Services
+	// If empty, targetGroup names are generated as k8s-namespace-service-hash
+	ServiceTargetGroupNamePrefix string
+	// Whether to prefer the subnet with most available IP addresses when multiple subnets are discovered within an availabilityZone
+	SubnetDiscoveryPreferAvailableIPs bool
+	// Whether to validate the S3 buckets for LoadBalancer logs exist within the LoadBalancer region and permit log delivery
+	ValidateAccessLogBucket bool
+	// Which undesired rules on managed securityGroups should be revoked
+	SecurityGroupRulesCleanupPolicy string
+	// Whether to validate the controller's IAM permissions for key AWS operations at startup
+	ValidateIAMPermissions bool
+	// Whether to validate the LoadBalancers, TargetGroups, Listeners and ListenerRules of stacks against ELBV2 quotas before deployment
+	ValidateELBV2Quotas bool
+	// Default tags applied to LoadBalancers, TargetGroups and SecurityGroups, user-specified tags take precedence
+	DefaultTags map[string]string
+	// TTL of the cache for subnets resolved via name or ID, caching is disabled if zero
+	SubnetResolveCacheTTL time.Duration
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -48,6 +142,40 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 		"Maximum number of concurrently running reconcile loops for service")
 	fs.IntVar(&cfg.TargetGroupBindingMaxConcurrentReconciles, flagTargetGroupBindingMaxConcurrentReconciles, defaultMaxConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for targetGroupBinding")
+	fs.BoolVar(&cfg.TargetGroupBindingSkipOffAZNodes, flagTargetGroupBindingSkipOffAZNodes, false,
+		"Skip registering instance targets whose node is outside the availabilityZones of LoadBalancer")
+	fs.IntVar(&cfg.TargetGroupBindingRegisterTargetsMaxRetries, flagTargetGroupBindingRegisterTargetsRetries, defaultRegisterTargetsMaxRetries,
+		"Maximum number of retries with backoff for each target that failed to be registered into targetGroup")
+	fs.StringVar(&cfg.LoadBalancerAZExpansionPolicy, flagLoadBalancerAZExpansionPolicy, defaultLoadBalancerAZExpansionPolicy,
+		"How to handle auto-discovered subnets in availabilityZones not yet enabled for existing load balancers - expand(default), ignore")
+	fs.IntVar(&cfg.NLBMinAZCount, flagNLBMinAZCount, defaultNLBMinAZCount,
+		"Minimum number of availabilityZones the subnets of network load balancers must span")
+	fs.StringVar(&cfg.NLBSingleAZDiscoveryPolicy, flagNLBSingleAZDiscoveryPolicy, defaultNLBSingleAZDiscoveryPolicy,
+		"How to handle auto-discovered subnets of network load balancers that span a single availabilityZone - warn(default), error, proceed")
+	fs.BoolVar(&cfg.NLBCrossZoneCostWarning, flagNLBCrossZoneCostWarning, true,
+		"Emit warning events for network load balancers with cross-zone load balancing enabled, which incurs inter-AZ data transfer charges")
+	fs.BoolVar(&cfg.ServiceEIPReuseDetection, flagServiceEIPReuseDetection, true,
+		"Emit warning events for services that reference EIP allocations already referenced by other services")
+	fs.BoolVar(&cfg.ServiceLenientAnnotationParsing, flagServiceLenientAnnotationParsing, false,
+		"Ignore malformed non-critical service annotations with warning events instead of failing reconcile, keeping the currently applied values")
+	fs.BoolVar(&cfg.ServiceDryRun, flagServiceDryRun, false,
+		"Only validate the annotations of services and report the errors, without provisioning or deleting any AWS resources")
+	fs.StringVar(&cfg.ServiceTargetGroupNamePrefix, flagServiceTargetGroupNamePrefix, defaultServiceTargetGroupNamePrefix,
+		"Prefix for the name of targetGroups created for services, replaces the default k8s prefix")
+	fs.BoolVar(&cfg.SubnetDiscoveryPreferAvailableIPs, flagSubnetDiscoveryPreferAvailableIPs, false,
+		"Prefer the subnet with most available IP addresses instead of the lowest subnet ID when multiple subnets are discovered within an availabilityZone")
+	fs.BoolVar(&cfg.ValidateAccessLogBucket, flagValidateAccessLogBucket, false,
+		"Validate the S3 buckets for load balancer logs exist within the load balancer region, and warn about buckets encrypted with AWS KMS keys")
+	fs.StringVar(&cfg.SecurityGroupRulesCleanupPolicy, flagSecurityGroupRulesCleanupPolicy, defaultSecurityGroupRulesCleanupPolicy,
+		"Which undesired rules on managed security groups are revoked - all(default), owned")
+	fs.BoolVar(&cfg.ValidateIAMPermissions, flagValidateIAMPermissions, true,
+		"Validate the controller's IAM permissions for key AWS operations at startup and report the missing ones")
+	fs.BoolVar(&cfg.ValidateELBV2Quotas, flagValidateELBV2Quotas, true,
+		"Validate the load balancers, target groups, listeners and listener rules to be deployed against ELBV2 quotas")
+	fs.StringToStringVar(&cfg.DefaultTags, flagDefaultTags, nil,
+		"Default tags applied to load balancers, target groups and security groups, overridden by tags specified via annotations")
+	fs.DurationVar(&cfg.SubnetResolveCacheTTL, flagSubnetResolveCacheTTL, defaultSubnetResolveCacheTTL,
+		"TTL of the cache for subnets resolved via name or ID, set to 0 to disable caching")
 
 	cfg.AWSConfig.BindFlags(fs)
 	cfg.RuntimeConfig.BindFlags(fs)
@@ -55,6 +183,7 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 	cfg.PodWebhookConfig.BindFlags(fs)
 	cfg.IngressConfig.BindFlags(fs)
 	cfg.AddonsConfig.BindFlags(fs)
+	cfg.TracingConfig.BindFlags(fs)
 }
 
 // Validate the controller configuration
@@ -62,5 +191,56 @@ func (cfg *ControllerConfig) Validate() error {
 	if len(cfg.ClusterName) == 0 {
 		return errors.New("kubernetes cluster name must be specified")
 	}
+	if err := cfg.IngressConfig.Validate(); err != nil {
+		return err
+	}
+	if cfg.TargetGroupBindingRegisterTargetsMaxRetries < 0 {
+		return errors.Errorf("%v must be non-negative: %v", flagTargetGroupBindingRegisterTargetsRetries, cfg.TargetGroupBindingRegisterTargetsMaxRetries)
+	}
+	if cfg.SubnetResolveCacheTTL < 0 {
+		return errors.Errorf("%v must be non-negative: %v", flagSubnetResolveCacheTTL, cfg.SubnetResolveCacheTTL)
+	}
+	if cfg.NLBMinAZCount < 1 {
+		return errors.Errorf("%v must be positive: %v", flagNLBMinAZCount, cfg.NLBMinAZCount)
+	}
+	switch cfg.LoadBalancerAZExpansionPolicy {
+	case AZExpansionPolicyExpand, AZExpansionPolicyIgnore:
+	default:
+		return errors.Errorf("%v must be within [%v, %v]: %v", flagLoadBalancerAZExpansionPolicy,
+			AZExpansionPolicyExpand, AZExpansionPolicyIgnore, cfg.LoadBalancerAZExpansionPolicy)
+	}
+	switch cfg.SecurityGroupRulesCleanupPolicy {
+	case SGRulesCleanupPolicyAll, SGRulesCleanupPolicyOwned:
+	default:
+		return errors.Errorf("%v must be within [%v, %v]: %v", flagSecurityGroupRulesCleanupPolicy,
+			SGRulesCleanupPolicyAll, SGRulesCleanupPolicyOwned, cfg.SecurityGroupRulesCleanupPolicy)
+	}
+	switch cfg.NLBSingleAZDiscoveryPolicy {
+	case SingleAZDiscoveryPolicyWarn, SingleAZDiscoveryPolicyError, SingleAZDiscoveryPolicyProceed:
+	default:
+		return errors.Errorf("%v must be within [%v, %v, %v]: %v", flagNLBSingleAZDiscoveryPolicy,
+			SingleAZDiscoveryPolicyWarn, SingleAZDiscoveryPolicyError, SingleAZDiscoveryPolicyProceed, cfg.NLBSingleAZDiscoveryPolicy)
+	}
+	if len(cfg.ServiceTargetGroupNamePrefix) > maxServiceTargetGroupNamePrefixLength {
+		return errors.Errorf("%v must be at most %v characters: %v", flagServiceTargetGroupNamePrefix,
+			maxServiceTargetGroupNamePrefixLength, cfg.ServiceTargetGroupNamePrefix)
+	}
+	if cfg.ServiceTargetGroupNamePrefix != "" && !validServiceTargetGroupNamePrefixPattern.MatchString(cfg.ServiceTargetGroupNamePrefix) {
+		return errors.Errorf("%v must consist of alphanumeric characters or hyphens, and must not begin or end with a hyphen: %v",
+			flagServiceTargetGroupNamePrefix, cfg.ServiceTargetGroupNamePrefix)
+	}
+	if err := validateDefaultTags(cfg.DefaultTags); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateDefaultTags validates the default tags don't use tag keys reserved for AWS use.
+func validateDefaultTags(defaultTags map[string]string) error {
+	for tagKey := range defaultTags {
+		if strings.HasPrefix(strings.ToLower(tagKey), reservedTagKeyPrefixAWS) {
+			return errors.Errorf("%v must not contain tag keys with reserved prefix %v: %v", flagDefaultTags, reservedTagKeyPrefixAWS, tagKey)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_validateDefaultTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultTags map[string]string
+		wantErr     error
+	}{
+		{
+			name:        "without default tags",
+			defaultTags: nil,
+		},
+		{
+			name: "valid default tags",
+			defaultTags: map[string]string{
+				"kubernetes.io/cluster/my-cluster": "owned",
+				"cost-center":                      "platform",
+			},
+		},
+		{
+			name: "tag key with reserved prefix",
+			defaultTags: map[string]string{
+				"aws:cloudformation:stack-name": "my-stack",
+			},
+			wantErr: errors.New("default-tags must not contain tag keys with reserved prefix aws:: aws:cloudformation:stack-name"),
+		},
+		{
+			name: "tag key with reserved prefix in uppercase",
+			defaultTags: map[string]string{
+				"AWS:createdBy": "me",
+			},
+			wantErr: errors.New("default-tags must not contain tag keys with reserved prefix aws:: AWS:createdBy"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDefaultTags(tt.defaultTags)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
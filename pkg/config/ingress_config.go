@@ -1,14 +1,62 @@
 package config
 
-import "github.com/spf13/pflag"
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"regexp"
+)
 
 const (
 	flagIngressClass                      = "ingress-class"
 	flagIngressMaxConcurrentReconciles    = "ingress-max-concurrent-reconciles"
+	flagIngressMissingCertificatePolicy   = "ingress-missing-certificate-policy"
+	flagIngressDuplicateRulePolicy        = "ingress-duplicate-rule-policy"
+	flagIngressTargetGroupNameTemplate    = "ingress-target-group-name-template"
+	flagIngressAnnotationPolicyFile       = "ingress-annotation-policy-file"
+	flagIngressRuleConditionValuesLimit   = "ingress-rule-condition-values-limit"
+	flagIngressRuleValuesLimit            = "ingress-rule-values-limit"
+	flagIngressSplitRuleConditions        = "ingress-split-rule-conditions"
+	flagIngressDefaultTargetType          = "default-target-type"
 	defaultIngressClass                   = ""
 	defaultMaxIngressConcurrentReconciles = 3
+	defaultMissingCertificatePolicy       = MissingCertificatePolicyError
+	defaultDuplicateRulePolicy            = DuplicateRulePolicyWarn
+	defaultTargetGroupNameTemplate        = ""
+	defaultAnnotationPolicyFile           = ""
+	defaultIngressTargetType              = "instance"
+	// ELBV2 allows up to 5 condition values per listener rule, there is no separate limit per condition.
+	defaultRuleConditionValuesLimit = 0
+	defaultRuleValuesLimit          = 5
+	defaultSplitRuleConditions      = false
+)
+
+const (
+	// MissingCertificatePolicyError fails the reconcile of Ingresses that reference missing certificates.
+	MissingCertificatePolicyError = "error"
+	// MissingCertificatePolicyFallback drops missing certificates, and falls back to auto-discovered certificates if none left.
+	MissingCertificatePolicyFallback = "fallback"
+	// MissingCertificatePolicySkipListener skips the HTTPS listeners of Ingresses that reference missing certificates.
+	MissingCertificatePolicySkipListener = "skip-listener"
+)
+
+const (
+	// DuplicateRulePolicyError fails the reconcile of IngressGroups that contain Ingresses claiming the same host and path.
+	DuplicateRulePolicyError = "error"
+	// DuplicateRulePolicyWarn emits warning events for Ingresses claiming the same host and path, the rule from the first Ingress takes precedence.
+	DuplicateRulePolicyWarn = "warn"
 )
 
+const (
+	// TargetGroupNamePlaceholderNamespace is replaced with the namespace of backend service in targetGroup name template.
+	TargetGroupNamePlaceholderNamespace = "{namespace}"
+	// TargetGroupNamePlaceholderService is replaced with the name of backend service in targetGroup name template.
+	TargetGroupNamePlaceholderService = "{service}"
+	// TargetGroupNamePlaceholderPort is replaced with the port of backend service in targetGroup name template.
+	TargetGroupNamePlaceholderPort = "{port}"
+)
+
+var targetGroupNamePlaceholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+
 // IngressConfig contains the configurations for the Ingress controller
 type IngressConfig struct {
 	// Name of the Ingress class this controller satisfies
@@ -16,6 +64,25 @@ type IngressConfig struct {
 	IngressClass string
 	// Max concurrent reconcile loops for Ingress objects
 	MaxConcurrentReconciles int
+	// How to handle certificates referenced by Ingress that no longer exist
+	MissingCertificatePolicy string
+	// How to handle Ingresses within the same IngressGroup that claim the same host and path
+	DuplicateRulePolicy string
+	// Template for the name of targetGroups created for Ingress backends
+	// If empty, targetGroup names are generated as k8s-namespace-service-hash
+	TargetGroupNameTemplate string
+	// Path to the file containing allowed values for Ingress annotations, enforced by the Ingress validating webhook
+	// If empty, all annotation values are allowed
+	AnnotationPolicyFile string
+	// Max number of values per listener rule condition, 0 disables the validation
+	RuleConditionValuesLimit int
+	// Max number of condition values per listener rule, 0 disables the validation
+	RuleValuesLimit int
+	// Whether to split listener rules with conditions exceeding the limits into multiple rules with the same actions
+	SplitRuleConditions bool
+	// Default targetType of targetGroups for Ingress backends
+	// Overridden by targetType in IngressClassParams and the target-type annotation
+	DefaultTargetType string
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -24,4 +91,56 @@ func (cfg *IngressConfig) BindFlags(fs *pflag.FlagSet) {
 		"Name of the ingress class this controller satisfies")
 	fs.IntVar(&cfg.MaxConcurrentReconciles, flagIngressMaxConcurrentReconciles, defaultMaxIngressConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for ingress")
+	fs.StringVar(&cfg.MissingCertificatePolicy, flagIngressMissingCertificatePolicy, defaultMissingCertificatePolicy,
+		"How to handle certificates referenced by ingress that no longer exist - error(default), fallback, skip-listener")
+	fs.StringVar(&cfg.DuplicateRulePolicy, flagIngressDuplicateRulePolicy, defaultDuplicateRulePolicy,
+		"How to handle ingresses within the same ingress group that claim the same host and path - error(default), warn")
+	fs.StringVar(&cfg.TargetGroupNameTemplate, flagIngressTargetGroupNameTemplate, defaultTargetGroupNameTemplate,
+		"Template for the name of targetGroups created for ingress backends, supports {namespace}, {service} and {port} placeholders. A hash suffix is always appended")
+	fs.StringVar(&cfg.AnnotationPolicyFile, flagIngressAnnotationPolicyFile, defaultAnnotationPolicyFile,
+		"Path to the JSON file containing allowed values for ingress annotations, enforced by the ingress validating webhook")
+	fs.IntVar(&cfg.RuleConditionValuesLimit, flagIngressRuleConditionValuesLimit, defaultRuleConditionValuesLimit,
+		"Maximum number of values per listener rule condition, 0 disables the validation")
+	fs.IntVar(&cfg.RuleValuesLimit, flagIngressRuleValuesLimit, defaultRuleValuesLimit,
+		"Maximum number of condition values per listener rule, 0 disables the validation")
+	fs.BoolVar(&cfg.SplitRuleConditions, flagIngressSplitRuleConditions, defaultSplitRuleConditions,
+		"Split listener rules with conditions exceeding the limits into multiple rules with the same actions instead of failing the reconcile")
+	fs.StringVar(&cfg.DefaultTargetType, flagIngressDefaultTargetType, defaultIngressTargetType,
+		"Default targetType of target groups for ingress backends if not specified via IngressClassParams or annotation - instance(default), ip")
+}
+
+// Validate the ingress configuration
+func (cfg *IngressConfig) Validate() error {
+	switch cfg.MissingCertificatePolicy {
+	case MissingCertificatePolicyError, MissingCertificatePolicyFallback, MissingCertificatePolicySkipListener:
+	default:
+		return errors.Errorf("%v must be within [%v, %v, %v]: %v", flagIngressMissingCertificatePolicy,
+			MissingCertificatePolicyError, MissingCertificatePolicyFallback, MissingCertificatePolicySkipListener, cfg.MissingCertificatePolicy)
+	}
+	switch cfg.DuplicateRulePolicy {
+	case DuplicateRulePolicyError, DuplicateRulePolicyWarn:
+	default:
+		return errors.Errorf("%v must be within [%v, %v]: %v", flagIngressDuplicateRulePolicy,
+			DuplicateRulePolicyError, DuplicateRulePolicyWarn, cfg.DuplicateRulePolicy)
+	}
+	switch cfg.DefaultTargetType {
+	case "instance", "ip":
+	default:
+		return errors.Errorf("%v must be within [instance, ip]: %v", flagIngressDefaultTargetType, cfg.DefaultTargetType)
+	}
+	if cfg.RuleConditionValuesLimit < 0 {
+		return errors.Errorf("%v must be non-negative: %v", flagIngressRuleConditionValuesLimit, cfg.RuleConditionValuesLimit)
+	}
+	if cfg.RuleValuesLimit < 0 {
+		return errors.Errorf("%v must be non-negative: %v", flagIngressRuleValuesLimit, cfg.RuleValuesLimit)
+	}
+	for _, placeholder := range targetGroupNamePlaceholderPattern.FindAllString(cfg.TargetGroupNameTemplate, -1) {
+		switch placeholder {
+		case TargetGroupNamePlaceholderNamespace, TargetGroupNamePlaceholderService, TargetGroupNamePlaceholderPort:
+		default:
+			return errors.Errorf("%v contains unsupported placeholder %v, supported placeholders: [%v, %v, %v]", flagIngressTargetGroupNameTemplate,
+				placeholder, TargetGroupNamePlaceholderNamespace, TargetGroupNamePlaceholderService, TargetGroupNamePlaceholderPort)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,80 @@
+package deploy
+
+import (
+	"encoding/json"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
+	wafregionalmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafregional"
+	wafv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafv2"
+)
+
+const (
+	lbAttrsLoadBalancingCrossZoneEnabled = "load_balancing.cross_zone.enabled"
+)
+
+// CostSummary summarizes the cost-relevant configuration of the LoadBalancer within stack.
+// capacity reservation isn't reported, since it isn't supported by the ELBV2 API of the AWS SDK in use.
+type CostSummary struct {
+	// whether cross-zone load balancing is enabled.
+	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing"`
+
+	// the WAFv2 WebACL associated with LoadBalancer, empty if none is managed.
+	WAFv2WebACLARN string `json:"wafv2WebACLARN,omitempty"`
+
+	// the WAFRegional WebACL associated with LoadBalancer, empty if none is managed.
+	WAFRegionalWebACLID string `json:"wafRegionalWebACLID,omitempty"`
+
+	// whether shield advanced protection is enabled.
+	ShieldAdvancedProtection bool `json:"shieldAdvancedProtection"`
+}
+
+// BuildCostSummary builds the CostSummary for LoadBalancer within stack, based on the desired state of the model,
+// except for cross-zone load balancing, which is reported as applied to the deployed LoadBalancer if known.
+func BuildCostSummary(stack core.Stack, lb *elbv2model.LoadBalancer) CostSummary {
+	summary := CostSummary{
+		CrossZoneLoadBalancing: isCrossZoneLoadBalancingEnabled(lb),
+	}
+
+	var resWAFv2Associations []*wafv2model.WebACLAssociation
+	stack.ListResources(&resWAFv2Associations)
+	for _, resAssociation := range resWAFv2Associations {
+		summary.WAFv2WebACLARN = resAssociation.Spec.WebACLARN
+	}
+	var resWAFRegionalAssociations []*wafregionalmodel.WebACLAssociation
+	stack.ListResources(&resWAFRegionalAssociations)
+	for _, resAssociation := range resWAFRegionalAssociations {
+		summary.WAFRegionalWebACLID = resAssociation.Spec.WebACLID
+	}
+	var resProtections []*shieldmodel.Protection
+	stack.ListResources(&resProtections)
+	summary.ShieldAdvancedProtection = len(resProtections) != 0
+	return summary
+}
+
+// MarshalCostSummary will marshall CostSummary into JSON.
+func MarshalCostSummary(summary CostSummary) (string, error) {
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// isCrossZoneLoadBalancingEnabled checks whether cross-zone load balancing is enabled for LoadBalancer.
+// cross-zone load balancing is always enabled for ALB, and is disabled by default for NLB.
+// the value applied to the deployed LoadBalancer takes precedence, since the attribute is left out of the model when its annotation is ignored.
+func isCrossZoneLoadBalancingEnabled(lb *elbv2model.LoadBalancer) bool {
+	if lb.Spec.Type == elbv2model.LoadBalancerTypeApplication {
+		return true
+	}
+	if lb.Status != nil && lb.Status.CrossZoneLoadBalancingEnabled != nil {
+		return *lb.Status.CrossZoneLoadBalancingEnabled
+	}
+	for _, attr := range lb.Spec.LoadBalancerAttributes {
+		if attr.Key == lbAttrsLoadBalancingCrossZoneEnabled {
+			return attr.Value == "true"
+		}
+	}
+	return false
+}
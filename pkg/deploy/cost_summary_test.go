@@ -0,0 +1,193 @@
+package deploy
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
+	wafregionalmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafregional"
+	wafv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafv2"
+	"testing"
+)
+
+func Test_BuildCostSummary(t *testing.T) {
+	tests := []struct {
+		name                 string
+		lbSpec               elbv2model.LoadBalancerSpec
+		lbStatus             *elbv2model.LoadBalancerStatus
+		wafv2WebACLARN       string
+		wafRegionalWebACLID  string
+		withShieldProtection bool
+		want                 CostSummary
+	}{
+		{
+			name: "NLB without cross-zone attribute",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Type: elbv2model.LoadBalancerTypeNetwork,
+			},
+			want: CostSummary{
+				CrossZoneLoadBalancing: false,
+			},
+		},
+		{
+			name: "NLB with cross-zone enabled",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Type: elbv2model.LoadBalancerTypeNetwork,
+				LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+					{
+						Key:   "access_logs.s3.enabled",
+						Value: "false",
+					},
+					{
+						Key:   "load_balancing.cross_zone.enabled",
+						Value: "true",
+					},
+				},
+			},
+			want: CostSummary{
+				CrossZoneLoadBalancing: true,
+			},
+		},
+		{
+			name: "NLB with cross-zone disabled",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Type: elbv2model.LoadBalancerTypeNetwork,
+				LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+					{
+						Key:   "load_balancing.cross_zone.enabled",
+						Value: "false",
+					},
+				},
+			},
+			want: CostSummary{
+				CrossZoneLoadBalancing: false,
+			},
+		},
+		{
+			name: "NLB with cross-zone enabled on deployed LoadBalancer but not in the model",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Type: elbv2model.LoadBalancerTypeNetwork,
+			},
+			lbStatus: &elbv2model.LoadBalancerStatus{
+				LoadBalancerARN:               "my-arn",
+				CrossZoneLoadBalancingEnabled: awssdk.Bool(true),
+			},
+			want: CostSummary{
+				CrossZoneLoadBalancing: true,
+			},
+		},
+		{
+			name: "NLB with cross-zone disabled on deployed LoadBalancer",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Type: elbv2model.LoadBalancerTypeNetwork,
+				LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+					{
+						Key:   "load_balancing.cross_zone.enabled",
+						Value: "true",
+					},
+				},
+			},
+			lbStatus: &elbv2model.LoadBalancerStatus{
+				LoadBalancerARN:               "my-arn",
+				CrossZoneLoadBalancingEnabled: awssdk.Bool(false),
+			},
+			want: CostSummary{
+				CrossZoneLoadBalancing: false,
+			},
+		},
+		{
+			name: "ALB without addons",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Type: elbv2model.LoadBalancerTypeApplication,
+			},
+			want: CostSummary{
+				CrossZoneLoadBalancing: true,
+			},
+		},
+		{
+			name: "ALB with WAFv2 and shield protection",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Type: elbv2model.LoadBalancerTypeApplication,
+			},
+			wafv2WebACLARN:       "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/abcd",
+			withShieldProtection: true,
+			want: CostSummary{
+				CrossZoneLoadBalancing:   true,
+				WAFv2WebACLARN:           "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/abcd",
+				ShieldAdvancedProtection: true,
+			},
+		},
+		{
+			name: "ALB with WAFRegional",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Type: elbv2model.LoadBalancerTypeApplication,
+			},
+			wafRegionalWebACLID: "web-acl-id",
+			want: CostSummary{
+				CrossZoneLoadBalancing: true,
+				WAFRegionalWebACLID:    "web-acl-id",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+			lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", tt.lbSpec)
+			if tt.lbStatus != nil {
+				lb.SetStatus(*tt.lbStatus)
+			}
+			if tt.wafv2WebACLARN != "" {
+				wafv2model.NewWebACLAssociation(stack, "LoadBalancer", wafv2model.WebACLAssociationSpec{
+					WebACLARN:   tt.wafv2WebACLARN,
+					ResourceARN: lb.LoadBalancerARN(),
+				})
+			}
+			if tt.wafRegionalWebACLID != "" {
+				wafregionalmodel.NewWebACLAssociation(stack, "LoadBalancer", wafregionalmodel.WebACLAssociationSpec{
+					WebACLID:    tt.wafRegionalWebACLID,
+					ResourceARN: lb.LoadBalancerARN(),
+				})
+			}
+			if tt.withShieldProtection {
+				shieldmodel.NewProtection(stack, "LoadBalancer", shieldmodel.ProtectionSpec{
+					ResourceARN: lb.LoadBalancerARN(),
+				})
+			}
+			got := BuildCostSummary(stack, lb)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_MarshalCostSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary CostSummary
+		want    string
+	}{
+		{
+			name: "without addons",
+			summary: CostSummary{
+				CrossZoneLoadBalancing: false,
+			},
+			want: `{"crossZoneLoadBalancing":false,"shieldAdvancedProtection":false}`,
+		},
+		{
+			name: "with addons",
+			summary: CostSummary{
+				CrossZoneLoadBalancing:   true,
+				WAFv2WebACLARN:           "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/abcd",
+				ShieldAdvancedProtection: true,
+			},
+			want: `{"crossZoneLoadBalancing":true,"wafv2WebACLARN":"arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/abcd","shieldAdvancedProtection":true}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalCostSummary(tt.summary)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
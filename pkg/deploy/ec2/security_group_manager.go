@@ -7,7 +7,9 @@ import (
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
@@ -18,6 +20,10 @@ import (
 const (
 	defaultWaitSGDeletionPollInterval = 2 * time.Second
 	defaultWaitSGDeletionTimeout      = 2 * time.Minute
+
+	// the label that identifies the rules on managed securityGroups owned by the controller, it's encoded into rule descriptions.
+	managedSGRulesLabelKey   = "elbv2.k8s.aws/managedSecurityGroup"
+	managedSGRulesLabelValue = "owned"
 )
 
 // SecurityGroupManager is responsible for create/update/delete SecurityGroup resources.
@@ -31,13 +37,14 @@ type SecurityGroupManager interface {
 
 // NewDefaultSecurityGroupManager constructs new defaultSecurityGroupManager.
 func NewDefaultSecurityGroupManager(ec2Client services.EC2, trackingProvider tracking.Provider, taggingManager TaggingManager,
-	networkingSGReconciler networking.SecurityGroupReconciler, vpcID string, logger logr.Logger) *defaultSecurityGroupManager {
+	networkingSGReconciler networking.SecurityGroupReconciler, vpcID string, rulesCleanupPolicy string, logger logr.Logger) *defaultSecurityGroupManager {
 	return &defaultSecurityGroupManager{
 		ec2Client:              ec2Client,
 		trackingProvider:       trackingProvider,
 		taggingManager:         taggingManager,
 		networkingSGReconciler: networkingSGReconciler,
 		vpcID:                  vpcID,
+		rulesCleanupPolicy:     rulesCleanupPolicy,
 		logger:                 logger,
 
 		waitSGDeletionPollInterval: defaultWaitSGDeletionPollInterval,
@@ -52,7 +59,9 @@ type defaultSecurityGroupManager struct {
 	taggingManager         TaggingManager
 	networkingSGReconciler networking.SecurityGroupReconciler
 	vpcID                  string
-	logger                 logr.Logger
+	// rulesCleanupPolicy determines which undesired rules are revoked, rules that are not owned by the controller are kept under owned policy.
+	rulesCleanupPolicy string
+	logger             logr.Logger
 
 	waitSGDeletionPollInterval time.Duration
 	waitSGDeletionTimeout      time.Duration
@@ -88,7 +97,7 @@ func (m *defaultSecurityGroupManager) Create(ctx context.Context, resSG *ec2mode
 		"resourceID", resSG.ID(),
 		"securityGroupID", sgID)
 
-	if err := m.networkingSGReconciler.ReconcileIngress(ctx, sgID, permissionInfos); err != nil {
+	if err := m.reconcileIngress(ctx, sgID, permissionInfos); err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
 
@@ -105,7 +114,10 @@ func (m *defaultSecurityGroupManager) Update(ctx context.Context, resSG *ec2mode
 	if err := m.updateSDKSecurityGroupGroupWithTags(ctx, resSG, sdkSG); err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
-	if err := m.networkingSGReconciler.ReconcileIngress(ctx, sdkSG.SecurityGroupID, permissionInfos); err != nil {
+	if err := m.adoptLegacyIngressRules(ctx, sdkSG, permissionInfos); err != nil {
+		return ec2model.SecurityGroupStatus{}, err
+	}
+	if err := m.reconcileIngress(ctx, sdkSG.SecurityGroupID, permissionInfos); err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
 	return ec2model.SecurityGroupStatus{
@@ -132,6 +144,56 @@ func (m *defaultSecurityGroupManager) Delete(ctx context.Context, sdkSG networki
 	return nil
 }
 
+// reconcileIngress reconciles the ingress rules on securityGroup according to rulesCleanupPolicy.
+// undesired rules, such as rules for listener ports no longer in use, are revoked if they are owned by the controller, or regardless of ownership by default.
+func (m *defaultSecurityGroupManager) reconcileIngress(ctx context.Context, sgID string, permissionInfos []networking.IPPermissionInfo) error {
+	var opts []networking.SecurityGroupReconcileOption
+	if m.rulesCleanupPolicy == config.SGRulesCleanupPolicyOwned {
+		permissionSelector := labels.SelectorFromSet(labels.Set{managedSGRulesLabelKey: managedSGRulesLabelValue})
+		opts = append(opts, networking.WithPermissionSelector(permissionSelector))
+	}
+	return m.networkingSGReconciler.ReconcileIngress(ctx, sgID, permissionInfos, opts...)
+}
+
+// adoptLegacyIngressRules labels the desired rules that were created without description as owned by the controller under owned policy.
+// rules were created without description before the controller labels its rules, so they'd be kept forever once they become undesired.
+// rules with description are left untouched, since they are added out-of-band.
+func (m *defaultSecurityGroupManager) adoptLegacyIngressRules(ctx context.Context, sdkSG networking.SecurityGroupInfo, permissionInfos []networking.IPPermissionInfo) error {
+	if m.rulesCleanupPolicy != config.SGRulesCleanupPolicyOwned {
+		return nil
+	}
+	desiredPermissionByHashCode := make(map[string]networking.IPPermissionInfo, len(permissionInfos))
+	for _, permission := range permissionInfos {
+		desiredPermissionByHashCode[permission.HashCode()] = permission
+	}
+	var sdkPermissions []*ec2sdk.IpPermission
+	for _, permission := range sdkSG.Ingress {
+		if !isLegacyIPPermission(permission) {
+			continue
+		}
+		if desiredPermission, exists := desiredPermissionByHashCode[permission.HashCode()]; exists {
+			sdkPermission := desiredPermission.Permission
+			sdkPermissions = append(sdkPermissions, &sdkPermission)
+		}
+	}
+	if len(sdkPermissions) == 0 {
+		return nil
+	}
+	req := &ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressInput{
+		GroupId:       awssdk.String(sdkSG.SecurityGroupID),
+		IpPermissions: sdkPermissions,
+	}
+	m.logger.Info("adopting legacy securityGroup rules",
+		"securityGroupID", sdkSG.SecurityGroupID,
+		"permissions", sdkPermissions)
+	if _, err := m.ec2Client.UpdateSecurityGroupRuleDescriptionsIngressWithContext(ctx, req); err != nil {
+		return errors.Wrap(err, "failed to adopt legacy securityGroup rules")
+	}
+	m.logger.Info("adopted legacy securityGroup rules",
+		"securityGroupID", sdkSG.SecurityGroupID)
+	return nil
+}
+
 func (m *defaultSecurityGroupManager) updateSDKSecurityGroupGroupWithTags(ctx context.Context, resSG *ec2model.SecurityGroup, sdkSG networking.SecurityGroupInfo) error {
 	desiredSGTags := m.trackingProvider.ResourceTags(resSG.Stack(), resSG, resSG.Spec.Tags)
 	return m.taggingManager.ReconcileTags(ctx, sdkSG.SecurityGroupID, desiredSGTags,
@@ -154,20 +216,50 @@ func buildIPPermissionInfos(permissions []ec2model.IPPermission) ([]networking.I
 func buildIPPermissionInfo(permission ec2model.IPPermission) (networking.IPPermissionInfo, error) {
 	protocol := permission.IPProtocol
 	if len(permission.IPRanges) == 1 {
-		labels := networking.NewIPPermissionLabelsForRawDescription(permission.IPRanges[0].Description)
+		labels := buildIPPermissionLabels(permission.IPRanges[0].Description)
 		return networking.NewCIDRIPPermission(protocol, permission.FromPort, permission.ToPort, permission.IPRanges[0].CIDRIP, labels), nil
 	}
 	if len(permission.IPv6Range) == 1 {
-		labels := networking.NewIPPermissionLabelsForRawDescription(permission.IPv6Range[0].Description)
+		labels := buildIPPermissionLabels(permission.IPv6Range[0].Description)
 		return networking.NewCIDRv6IPPermission(protocol, permission.FromPort, permission.ToPort, permission.IPv6Range[0].CIDRIPv6, labels), nil
 	}
 	if len(permission.UserIDGroupPairs) == 1 {
-		labels := networking.NewIPPermissionLabelsForRawDescription(permission.UserIDGroupPairs[0].Description)
+		labels := buildIPPermissionLabels(permission.UserIDGroupPairs[0].Description)
 		return networking.NewGroupIDIPPermission(protocol, permission.FromPort, permission.ToPort, permission.UserIDGroupPairs[0].GroupID, labels), nil
 	}
+	if len(permission.PrefixLists) == 1 {
+		labels := buildIPPermissionLabels(permission.PrefixLists[0].Description)
+		return networking.NewPrefixListIDPermission(protocol, permission.FromPort, permission.ToPort, permission.PrefixLists[0].ListID, labels), nil
+	}
 	return networking.IPPermissionInfo{}, errors.New("invalid ipPermission")
 }
 
+// buildIPPermissionLabels builds the labels for permission with description.
+// permissions without description are labeled as owned by the controller, so that they can be identified during cleanup.
+func buildIPPermissionLabels(description string) map[string]string {
+	if description == "" {
+		return map[string]string{managedSGRulesLabelKey: managedSGRulesLabelValue}
+	}
+	return networking.NewIPPermissionLabelsForRawDescription(description)
+}
+
+// isLegacyIPPermission checks whether permission is created without description, i.e. neither labeled as owned nor added out-of-band with description.
+// Note: the permission must be expanded(i.e. only contains one source configuration)
+func isLegacyIPPermission(permission networking.IPPermissionInfo) bool {
+	sdkPermission := permission.Permission
+	switch {
+	case len(sdkPermission.IpRanges) == 1:
+		return awssdk.StringValue(sdkPermission.IpRanges[0].Description) == ""
+	case len(sdkPermission.Ipv6Ranges) == 1:
+		return awssdk.StringValue(sdkPermission.Ipv6Ranges[0].Description) == ""
+	case len(sdkPermission.PrefixListIds) == 1:
+		return awssdk.StringValue(sdkPermission.PrefixListIds[0].Description) == ""
+	case len(sdkPermission.UserIdGroupPairs) == 1:
+		return awssdk.StringValue(sdkPermission.UserIdGroupPairs[0].Description) == ""
+	}
+	return false
+}
+
 func isSecurityGroupDependencyViolationError(err error) bool {
 	var awsErr awserr.Error
 	if errors.As(err, &awsErr) {
@@ -1,12 +1,170 @@
 package ec2
 
 import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
+func Test_defaultSecurityGroupManager_Update_staleRulesCleanup(t *testing.T) {
+	newSDKPermission := func(port int64, cidr string, description string) networking.IPPermissionInfo {
+		return networking.NewRawIPPermission(ec2sdk.IpPermission{
+			IpProtocol: awssdk.String("tcp"),
+			FromPort:   awssdk.Int64(port),
+			ToPort:     awssdk.Int64(port),
+			IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String(cidr), Description: awssdk.String(description)}},
+		})
+	}
+	ownedLabels := map[string]string{managedSGRulesLabelKey: managedSGRulesLabelValue}
+	// the rule for listener port 80, which has been changed to 8080.
+	staleOwnedPermission := newSDKPermission(80, "0.0.0.0/0", "elbv2.k8s.aws/managedSecurityGroup=owned")
+	// the rule added out-of-band.
+	unownedPermission := newSDKPermission(22, "10.0.0.0/8", "ssh access")
+	// the rules created without description before the upgrade, which are still desired or have become stale.
+	legacyDesiredPermission := newSDKPermission(8080, "10.0.0.0/16", "")
+	legacyStalePermission := newSDKPermission(443, "0.0.0.0/0", "")
+	desiredPermission := networking.NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8080), "0.0.0.0/0", ownedLabels)
+	adoptedPermission := networking.NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8080), "10.0.0.0/16", ownedLabels)
+	tests := []struct {
+		name                string
+		rulesCleanupPolicy  string
+		wantAdoptReq        *ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressInput
+		wantRevokedRules    []networking.IPPermissionInfo
+		wantAuthorizedRules []networking.IPPermissionInfo
+	}{
+		{
+			name:                "all policy revokes stale rules regardless of ownership",
+			rulesCleanupPolicy:  config.SGRulesCleanupPolicyAll,
+			wantRevokedRules:    []networking.IPPermissionInfo{unownedPermission, legacyStalePermission, staleOwnedPermission},
+			wantAuthorizedRules: []networking.IPPermissionInfo{desiredPermission},
+		},
+		{
+			name:               "owned policy only revokes stale rules owned by controller, and adopts desired legacy rules",
+			rulesCleanupPolicy: config.SGRulesCleanupPolicyOwned,
+			wantAdoptReq: &ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressInput{
+				GroupId:       awssdk.String("sg-abcdefg"),
+				IpPermissions: []*ec2sdk.IpPermission{&adoptedPermission.Permission},
+			},
+			wantRevokedRules:    []networking.IPPermissionInfo{staleOwnedPermission},
+			wantAuthorizedRules: []networking.IPPermissionInfo{desiredPermission},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sgID := "sg-abcdefg"
+			existingPermissions := []networking.IPPermissionInfo{staleOwnedPermission, unownedPermission, legacyDesiredPermission, legacyStalePermission}
+			networkingSGManager := mock_networking.NewMockSecurityGroupManager(ctrl)
+			networkingSGManager.EXPECT().FetchSGInfosByID(gomock.Any(), []string{sgID}).Return(map[string]networking.SecurityGroupInfo{
+				sgID: {
+					SecurityGroupID: sgID,
+					Ingress:         existingPermissions,
+				},
+			}, nil)
+			networkingSGManager.EXPECT().RevokeSGIngress(gomock.Any(), sgID, tt.wantRevokedRules).Return(nil)
+			networkingSGManager.EXPECT().AuthorizeSGIngress(gomock.Any(), sgID, tt.wantAuthorizedRules).Return(nil)
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			if tt.wantAdoptReq != nil {
+				ec2Client.EXPECT().UpdateSecurityGroupRuleDescriptionsIngressWithContext(gomock.Any(), tt.wantAdoptReq).
+					Return(&ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressOutput{}, nil)
+			}
+			trackingProvider := tracking.NewDefaultProvider("elbv2.k8s.aws", "cluster-name")
+			taggingManager := NewDefaultTaggingManager(ec2Client, networkingSGManager, "vpc-xxx", &log.NullLogger{})
+			networkingSGReconciler := networking.NewDefaultSecurityGroupReconciler(networkingSGManager, &log.NullLogger{})
+			m := NewDefaultSecurityGroupManager(ec2Client, trackingProvider, taggingManager, networkingSGReconciler, "vpc-xxx",
+				tt.rulesCleanupPolicy, &log.NullLogger{})
+
+			stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+			resSG := ec2model.NewSecurityGroup(stack, "ManagedLBSecurityGroup", ec2model.SecurityGroupSpec{
+				GroupName: "k8s-namespace-name-abcdefg",
+				Ingress: []ec2model.IPPermission{
+					{
+						IPProtocol: "tcp",
+						FromPort:   awssdk.Int64(8080),
+						ToPort:     awssdk.Int64(8080),
+						IPRanges:   []ec2model.IPRange{{CIDRIP: "0.0.0.0/0"}},
+					},
+					{
+						IPProtocol: "tcp",
+						FromPort:   awssdk.Int64(8080),
+						ToPort:     awssdk.Int64(8080),
+						IPRanges:   []ec2model.IPRange{{CIDRIP: "10.0.0.0/16"}},
+					},
+				},
+			})
+			sdkSG := networking.SecurityGroupInfo{
+				SecurityGroupID: sgID,
+				Ingress:         existingPermissions,
+				Tags:            trackingProvider.ResourceTags(stack, resSG, nil),
+			}
+			got, err := m.Update(context.Background(), resSG, sdkSG)
+			assert.NoError(t, err)
+			assert.Equal(t, ec2model.SecurityGroupStatus{GroupID: sgID}, got)
+		})
+	}
+}
+
+func Test_isLegacyIPPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission networking.IPPermissionInfo
+		want       bool
+	}{
+		{
+			name: "rule without description",
+			permission: networking.NewRawIPPermission(ec2sdk.IpPermission{
+				IpProtocol:       awssdk.String("tcp"),
+				FromPort:         awssdk.Int64(80),
+				ToPort:           awssdk.Int64(80),
+				UserIdGroupPairs: []*ec2sdk.UserIdGroupPair{{GroupId: awssdk.String("sg-xxx")}},
+			}),
+			want: true,
+		},
+		{
+			name: "rule labeled as owned",
+			permission: networking.NewRawIPPermission(ec2sdk.IpPermission{
+				IpProtocol: awssdk.String("tcp"),
+				FromPort:   awssdk.Int64(80),
+				ToPort:     awssdk.Int64(80),
+				Ipv6Ranges: []*ec2sdk.Ipv6Range{{CidrIpv6: awssdk.String("::/0"), Description: awssdk.String("elbv2.k8s.aws/managedSecurityGroup=owned")}},
+			}),
+			want: false,
+		},
+		{
+			name: "rule with out-of-band description",
+			permission: networking.NewRawIPPermission(ec2sdk.IpPermission{
+				IpProtocol:    awssdk.String("tcp"),
+				FromPort:      awssdk.Int64(80),
+				ToPort:        awssdk.Int64(80),
+				PrefixListIds: []*ec2sdk.PrefixListId{{PrefixListId: awssdk.String("pl-xxx"), Description: awssdk.String("corporate network")}},
+			}),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isLegacyIPPermission(tt.permission)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_isSecurityGroupDependencyViolationError(t *testing.T) {
 	type args struct {
 		err error
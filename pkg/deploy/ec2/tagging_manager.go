@@ -14,6 +14,12 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 )
 
+// VPCEndpointService with it's tags.
+type VPCEndpointServiceWithTags struct {
+	ServiceConfiguration *ec2sdk.ServiceConfiguration
+	Tags                 map[string]string
+}
+
 // options for ReconcileTags API.
 type ReconcileTagsOptions struct {
 	// CurrentTags on resources.
@@ -54,6 +60,9 @@ type TaggingManager interface {
 
 	// ListSecurityGroups returns SecurityGroups that matches any of the tagging requirements.
 	ListSecurityGroups(ctx context.Context, tagFilters ...tracking.TagFilter) ([]networking.SecurityGroupInfo, error)
+
+	// ListVPCEndpointServices returns VPCEndpointServices that matches any of the tagging requirements.
+	ListVPCEndpointServices(ctx context.Context, tagFilters ...tracking.TagFilter) ([]VPCEndpointServiceWithTags, error)
 }
 
 // NewDefaultTaggingManager constructs new defaultTaggingManager.
@@ -157,6 +166,56 @@ func (m *defaultTaggingManager) listSecurityGroupsWithTagFilter(ctx context.Cont
 		},
 	}
 
+	req.Filters = append(req.Filters, buildSDKTagFilters(tagFilter)...)
+	return m.networkingSGManager.FetchSGInfosByRequest(ctx, req)
+}
+
+func (m *defaultTaggingManager) ListVPCEndpointServices(ctx context.Context, tagFilters ...tracking.TagFilter) ([]VPCEndpointServiceWithTags, error) {
+	esByID := make(map[string]VPCEndpointServiceWithTags)
+	for _, tagFilter := range tagFilters {
+		esByIDForTagFilter, err := m.listVPCEndpointServicesWithTagFilter(ctx, tagFilter)
+		if err != nil {
+			return nil, err
+		}
+		for serviceID, es := range esByIDForTagFilter {
+			esByID[serviceID] = es
+		}
+	}
+
+	esList := make([]VPCEndpointServiceWithTags, 0, len(esByID))
+	for _, serviceID := range sets.StringKeySet(esByID).List() {
+		esList = append(esList, esByID[serviceID])
+	}
+	return esList, nil
+}
+
+func (m *defaultTaggingManager) listVPCEndpointServicesWithTagFilter(ctx context.Context, tagFilter tracking.TagFilter) (map[string]VPCEndpointServiceWithTags, error) {
+	req := &ec2sdk.DescribeVpcEndpointServiceConfigurationsInput{
+		Filters: buildSDKTagFilters(tagFilter),
+	}
+	esByID := make(map[string]VPCEndpointServiceWithTags)
+	if err := m.ec2Client.DescribeVpcEndpointServiceConfigurationsPagesWithContext(ctx, req, func(output *ec2sdk.DescribeVpcEndpointServiceConfigurationsOutput, _ bool) bool {
+		for _, serviceConfig := range output.ServiceConfigurations {
+			// endpoint services being deleted are ignored.
+			switch awssdk.StringValue(serviceConfig.ServiceState) {
+			case ec2sdk.ServiceStateDeleting, ec2sdk.ServiceStateDeleted:
+				continue
+			}
+			esByID[awssdk.StringValue(serviceConfig.ServiceId)] = VPCEndpointServiceWithTags{
+				ServiceConfiguration: serviceConfig,
+				Tags:                 convertSDKTagsToTags(serviceConfig.Tags),
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return esByID, nil
+}
+
+// buildSDKTagFilters converts tagFilter into AWS SDK filter presentation.
+func buildSDKTagFilters(tagFilter tracking.TagFilter) []*ec2sdk.Filter {
+	var filters []*ec2sdk.Filter
 	for _, tagKey := range sets.StringKeySet(tagFilter).List() {
 		tagValues := tagFilter[tagKey]
 		var filter ec2sdk.Filter
@@ -169,10 +228,9 @@ func (m *defaultTaggingManager) listSecurityGroupsWithTagFilter(ctx context.Cont
 			filter.Name = awssdk.String(tagFilterName)
 			filter.Values = awssdk.StringSlice(tagValues)
 		}
-		req.Filters = append(req.Filters, &filter)
+		filters = append(filters, &filter)
 	}
-
-	return m.networkingSGManager.FetchSGInfosByRequest(ctx, req)
+	return filters
 }
 
 // convert tags into AWS SDK tag presentation.
@@ -190,3 +248,12 @@ func convertTagsToSDKTags(tags map[string]string) []*ec2sdk.Tag {
 	}
 	return sdkTags
 }
+
+// convert AWS SDK tag presentation into tags.
+func convertSDKTagsToTags(sdkTags []*ec2sdk.Tag) map[string]string {
+	tags := make(map[string]string, len(sdkTags))
+	for _, sdkTag := range sdkTags {
+		tags[awssdk.StringValue(sdkTag.Key)] = awssdk.StringValue(sdkTag.Value)
+	}
+	return tags
+}
@@ -0,0 +1,228 @@
+package ec2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+)
+
+// VPCEndpointServiceManager is responsible for create/update/delete VPCEndpointService resources.
+type VPCEndpointServiceManager interface {
+	Create(ctx context.Context, resES *ec2model.VPCEndpointService) (ec2model.VPCEndpointServiceStatus, error)
+
+	Update(ctx context.Context, resES *ec2model.VPCEndpointService, sdkES VPCEndpointServiceWithTags) (ec2model.VPCEndpointServiceStatus, error)
+
+	Delete(ctx context.Context, sdkES VPCEndpointServiceWithTags) error
+}
+
+// NewDefaultVPCEndpointServiceManager constructs new defaultVPCEndpointServiceManager.
+func NewDefaultVPCEndpointServiceManager(ec2Client services.EC2, trackingProvider tracking.Provider, taggingManager TaggingManager,
+	logger logr.Logger) *defaultVPCEndpointServiceManager {
+	return &defaultVPCEndpointServiceManager{
+		ec2Client:        ec2Client,
+		trackingProvider: trackingProvider,
+		taggingManager:   taggingManager,
+		logger:           logger,
+	}
+}
+
+var _ VPCEndpointServiceManager = &defaultVPCEndpointServiceManager{}
+
+// default implementation for VPCEndpointServiceManager.
+type defaultVPCEndpointServiceManager struct {
+	ec2Client        services.EC2
+	trackingProvider tracking.Provider
+	taggingManager   TaggingManager
+	logger           logr.Logger
+}
+
+func (m *defaultVPCEndpointServiceManager) Create(ctx context.Context, resES *ec2model.VPCEndpointService) (ec2model.VPCEndpointServiceStatus, error) {
+	esTags := m.trackingProvider.ResourceTags(resES.Stack(), resES, resES.Spec.Tags)
+	sdkTags := convertTagsToSDKTags(esTags)
+	lbARNs, err := resolveNetworkLoadBalancerARNs(ctx, resES)
+	if err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+
+	req := &ec2sdk.CreateVpcEndpointServiceConfigurationInput{
+		AcceptanceRequired:      awssdk.Bool(resES.Spec.AcceptanceRequired),
+		NetworkLoadBalancerArns: awssdk.StringSlice(lbARNs),
+		TagSpecifications: []*ec2sdk.TagSpecification{
+			{
+				ResourceType: awssdk.String("vpc-endpoint-service"),
+				Tags:         sdkTags,
+			},
+		},
+	}
+	m.logger.Info("creating vpcEndpointService",
+		"resourceID", resES.ID())
+	resp, err := m.ec2Client.CreateVpcEndpointServiceConfigurationWithContext(ctx, req)
+	if err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	serviceID := awssdk.StringValue(resp.ServiceConfiguration.ServiceId)
+	m.logger.Info("created vpcEndpointService",
+		"resourceID", resES.ID(),
+		"serviceID", serviceID)
+
+	if err := m.reconcileAllowedPrincipals(ctx, serviceID, resES.Spec.AllowedPrincipals, sets.NewString()); err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	return buildResVPCEndpointServiceStatus(resp.ServiceConfiguration), nil
+}
+
+func (m *defaultVPCEndpointServiceManager) Update(ctx context.Context, resES *ec2model.VPCEndpointService, sdkES VPCEndpointServiceWithTags) (ec2model.VPCEndpointServiceStatus, error) {
+	if err := m.updateSDKVPCEndpointServiceWithTags(ctx, resES, sdkES); err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	if err := m.updateSDKVPCEndpointServiceConfiguration(ctx, resES, sdkES); err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	if err := m.updateSDKVPCEndpointServicePermissions(ctx, resES, sdkES); err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	return buildResVPCEndpointServiceStatus(sdkES.ServiceConfiguration), nil
+}
+
+func (m *defaultVPCEndpointServiceManager) Delete(ctx context.Context, sdkES VPCEndpointServiceWithTags) error {
+	serviceID := awssdk.StringValue(sdkES.ServiceConfiguration.ServiceId)
+	req := &ec2sdk.DeleteVpcEndpointServiceConfigurationsInput{
+		ServiceIds: awssdk.StringSlice([]string{serviceID}),
+	}
+	m.logger.Info("deleting vpcEndpointService",
+		"serviceID", serviceID)
+	resp, err := m.ec2Client.DeleteVpcEndpointServiceConfigurationsWithContext(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete vpcEndpointService")
+	}
+	for _, item := range resp.Unsuccessful {
+		if item.Error != nil {
+			return errors.Errorf("failed to delete vpcEndpointService %v: %v", awssdk.StringValue(item.ResourceId), awssdk.StringValue(item.Error.Message))
+		}
+	}
+	m.logger.Info("deleted vpcEndpointService",
+		"serviceID", serviceID)
+	return nil
+}
+
+func (m *defaultVPCEndpointServiceManager) updateSDKVPCEndpointServiceWithTags(ctx context.Context, resES *ec2model.VPCEndpointService, sdkES VPCEndpointServiceWithTags) error {
+	desiredESTags := m.trackingProvider.ResourceTags(resES.Stack(), resES, resES.Spec.Tags)
+	return m.taggingManager.ReconcileTags(ctx, awssdk.StringValue(sdkES.ServiceConfiguration.ServiceId), desiredESTags,
+		WithCurrentTags(sdkES.Tags),
+		WithIgnoredTagKeys(m.trackingProvider.LegacyTagKeys()))
+}
+
+func (m *defaultVPCEndpointServiceManager) updateSDKVPCEndpointServiceConfiguration(ctx context.Context, resES *ec2model.VPCEndpointService, sdkES VPCEndpointServiceWithTags) error {
+	lbARNs, err := resolveNetworkLoadBalancerARNs(ctx, resES)
+	if err != nil {
+		return err
+	}
+	desiredLBARNs := sets.NewString(lbARNs...)
+	currentLBARNs := sets.NewString(awssdk.StringValueSlice(sdkES.ServiceConfiguration.NetworkLoadBalancerArns)...)
+	lbARNsToAdd := desiredLBARNs.Difference(currentLBARNs)
+	lbARNsToRemove := currentLBARNs.Difference(desiredLBARNs)
+	acceptanceRequiredDrifted := resES.Spec.AcceptanceRequired != awssdk.BoolValue(sdkES.ServiceConfiguration.AcceptanceRequired)
+	if len(lbARNsToAdd) == 0 && len(lbARNsToRemove) == 0 && !acceptanceRequiredDrifted {
+		return nil
+	}
+
+	serviceID := awssdk.StringValue(sdkES.ServiceConfiguration.ServiceId)
+	req := &ec2sdk.ModifyVpcEndpointServiceConfigurationInput{
+		ServiceId: awssdk.String(serviceID),
+	}
+	if acceptanceRequiredDrifted {
+		req.AcceptanceRequired = awssdk.Bool(resES.Spec.AcceptanceRequired)
+	}
+	if len(lbARNsToAdd) != 0 {
+		req.AddNetworkLoadBalancerArns = awssdk.StringSlice(lbARNsToAdd.List())
+	}
+	if len(lbARNsToRemove) != 0 {
+		req.RemoveNetworkLoadBalancerArns = awssdk.StringSlice(lbARNsToRemove.List())
+	}
+	m.logger.Info("modifying vpcEndpointService",
+		"serviceID", serviceID)
+	if _, err := m.ec2Client.ModifyVpcEndpointServiceConfigurationWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("modified vpcEndpointService",
+		"serviceID", serviceID)
+	return nil
+}
+
+func (m *defaultVPCEndpointServiceManager) updateSDKVPCEndpointServicePermissions(ctx context.Context, resES *ec2model.VPCEndpointService, sdkES VPCEndpointServiceWithTags) error {
+	serviceID := awssdk.StringValue(sdkES.ServiceConfiguration.ServiceId)
+	currentPrincipals, err := m.fetchAllowedPrincipals(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	return m.reconcileAllowedPrincipals(ctx, serviceID, resES.Spec.AllowedPrincipals, currentPrincipals)
+}
+
+// reconcileAllowedPrincipals reconciles the principals allowed to discover the endpoint service to match desired principals.
+func (m *defaultVPCEndpointServiceManager) reconcileAllowedPrincipals(ctx context.Context, serviceID string, desiredPrincipals []string, currentPrincipals sets.String) error {
+	principalsToAdd := sets.NewString(desiredPrincipals...).Difference(currentPrincipals)
+	principalsToRemove := currentPrincipals.Difference(sets.NewString(desiredPrincipals...))
+	if len(principalsToAdd) == 0 && len(principalsToRemove) == 0 {
+		return nil
+	}
+	req := &ec2sdk.ModifyVpcEndpointServicePermissionsInput{
+		ServiceId: awssdk.String(serviceID),
+	}
+	if len(principalsToAdd) != 0 {
+		req.AddAllowedPrincipals = awssdk.StringSlice(principalsToAdd.List())
+	}
+	if len(principalsToRemove) != 0 {
+		req.RemoveAllowedPrincipals = awssdk.StringSlice(principalsToRemove.List())
+	}
+	m.logger.Info("modifying vpcEndpointService allowed principals",
+		"serviceID", serviceID,
+		"addedPrincipals", principalsToAdd.List(),
+		"removedPrincipals", principalsToRemove.List())
+	if _, err := m.ec2Client.ModifyVpcEndpointServicePermissionsWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("modified vpcEndpointService allowed principals",
+		"serviceID", serviceID)
+	return nil
+}
+
+func (m *defaultVPCEndpointServiceManager) fetchAllowedPrincipals(ctx context.Context, serviceID string) (sets.String, error) {
+	req := &ec2sdk.DescribeVpcEndpointServicePermissionsInput{
+		ServiceId: awssdk.String(serviceID),
+	}
+	principals := sets.NewString()
+	if err := m.ec2Client.DescribeVpcEndpointServicePermissionsPagesWithContext(ctx, req, func(output *ec2sdk.DescribeVpcEndpointServicePermissionsOutput, _ bool) bool {
+		for _, allowedPrincipal := range output.AllowedPrincipals {
+			principals.Insert(awssdk.StringValue(allowedPrincipal.Principal))
+		}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return principals, nil
+}
+
+func resolveNetworkLoadBalancerARNs(ctx context.Context, resES *ec2model.VPCEndpointService) ([]string, error) {
+	lbARNs := make([]string, 0, len(resES.Spec.NetworkLoadBalancerARNs))
+	for _, lbARNToken := range resES.Spec.NetworkLoadBalancerARNs {
+		lbARN, err := lbARNToken.Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		lbARNs = append(lbARNs, lbARN)
+	}
+	return lbARNs, nil
+}
+
+func buildResVPCEndpointServiceStatus(sdkServiceConfig *ec2sdk.ServiceConfiguration) ec2model.VPCEndpointServiceStatus {
+	return ec2model.VPCEndpointServiceStatus{
+		ServiceID:   awssdk.StringValue(sdkServiceConfig.ServiceId),
+		ServiceName: awssdk.StringValue(sdkServiceConfig.ServiceName),
+	}
+}
@@ -0,0 +1,557 @@
+package ec2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultVPCEndpointServiceManager_Create(t *testing.T) {
+	type createVpcEndpointServiceConfigurationWithContextCall struct {
+		req  *ec2sdk.CreateVpcEndpointServiceConfigurationInput
+		resp *ec2sdk.CreateVpcEndpointServiceConfigurationOutput
+		err  error
+	}
+	type modifyVpcEndpointServicePermissionsWithContextCall struct {
+		req  *ec2sdk.ModifyVpcEndpointServicePermissionsInput
+		resp *ec2sdk.ModifyVpcEndpointServicePermissionsOutput
+		err  error
+	}
+	type fields struct {
+		createCalls      []createVpcEndpointServiceConfigurationWithContextCall
+		modifyPermsCalls []modifyVpcEndpointServicePermissionsWithContextCall
+	}
+	stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+	sdkESTags := []*ec2sdk.Tag{
+		{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+		{Key: awssdk.String("service.k8s.aws/resource"), Value: awssdk.String("EndpointService")},
+		{Key: awssdk.String("service.k8s.aws/stack"), Value: awssdk.String("namespace/name")},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		resES   *ec2model.VPCEndpointService
+		want    ec2model.VPCEndpointServiceStatus
+		wantErr error
+	}{
+		{
+			name: "create without allowed principals",
+			fields: fields{
+				createCalls: []createVpcEndpointServiceConfigurationWithContextCall{
+					{
+						req: &ec2sdk.CreateVpcEndpointServiceConfigurationInput{
+							AcceptanceRequired:      awssdk.Bool(true),
+							NetworkLoadBalancerArns: awssdk.StringSlice([]string{"lb-arn"}),
+							TagSpecifications: []*ec2sdk.TagSpecification{
+								{
+									ResourceType: awssdk.String("vpc-endpoint-service"),
+									Tags:         sdkESTags,
+								},
+							},
+						},
+						resp: &ec2sdk.CreateVpcEndpointServiceConfigurationOutput{
+							ServiceConfiguration: &ec2sdk.ServiceConfiguration{
+								ServiceId:   awssdk.String("vpce-svc-a"),
+								ServiceName: awssdk.String("com.amazonaws.vpce.us-west-2.vpce-svc-a"),
+							},
+						},
+					},
+				},
+			},
+			resES: &ec2model.VPCEndpointService{
+				ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::VPCEndpointService", "EndpointService"),
+				Spec: ec2model.VPCEndpointServiceSpec{
+					AcceptanceRequired:      true,
+					NetworkLoadBalancerARNs: []core.StringToken{core.LiteralStringToken("lb-arn")},
+				},
+			},
+			want: ec2model.VPCEndpointServiceStatus{
+				ServiceID:   "vpce-svc-a",
+				ServiceName: "com.amazonaws.vpce.us-west-2.vpce-svc-a",
+			},
+		},
+		{
+			name: "create with allowed principals",
+			fields: fields{
+				createCalls: []createVpcEndpointServiceConfigurationWithContextCall{
+					{
+						req: &ec2sdk.CreateVpcEndpointServiceConfigurationInput{
+							AcceptanceRequired:      awssdk.Bool(false),
+							NetworkLoadBalancerArns: awssdk.StringSlice([]string{"lb-arn"}),
+							TagSpecifications: []*ec2sdk.TagSpecification{
+								{
+									ResourceType: awssdk.String("vpc-endpoint-service"),
+									Tags:         sdkESTags,
+								},
+							},
+						},
+						resp: &ec2sdk.CreateVpcEndpointServiceConfigurationOutput{
+							ServiceConfiguration: &ec2sdk.ServiceConfiguration{
+								ServiceId:   awssdk.String("vpce-svc-a"),
+								ServiceName: awssdk.String("com.amazonaws.vpce.us-west-2.vpce-svc-a"),
+							},
+						},
+					},
+				},
+				modifyPermsCalls: []modifyVpcEndpointServicePermissionsWithContextCall{
+					{
+						req: &ec2sdk.ModifyVpcEndpointServicePermissionsInput{
+							ServiceId:            awssdk.String("vpce-svc-a"),
+							AddAllowedPrincipals: awssdk.StringSlice([]string{"arn:aws:iam::123456789012:root"}),
+						},
+						resp: &ec2sdk.ModifyVpcEndpointServicePermissionsOutput{},
+					},
+				},
+			},
+			resES: &ec2model.VPCEndpointService{
+				ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::VPCEndpointService", "EndpointService"),
+				Spec: ec2model.VPCEndpointServiceSpec{
+					AcceptanceRequired:      false,
+					NetworkLoadBalancerARNs: []core.StringToken{core.LiteralStringToken("lb-arn")},
+					AllowedPrincipals:       []string{"arn:aws:iam::123456789012:root"},
+				},
+			},
+			want: ec2model.VPCEndpointServiceStatus{
+				ServiceID:   "vpce-svc-a",
+				ServiceName: "com.amazonaws.vpce.us-west-2.vpce-svc-a",
+			},
+		},
+		{
+			name: "create failed",
+			fields: fields{
+				createCalls: []createVpcEndpointServiceConfigurationWithContextCall{
+					{
+						req: &ec2sdk.CreateVpcEndpointServiceConfigurationInput{
+							AcceptanceRequired:      awssdk.Bool(true),
+							NetworkLoadBalancerArns: awssdk.StringSlice([]string{"lb-arn"}),
+							TagSpecifications: []*ec2sdk.TagSpecification{
+								{
+									ResourceType: awssdk.String("vpc-endpoint-service"),
+									Tags:         sdkESTags,
+								},
+							},
+						},
+						err: errors.New("some AWS API error"),
+					},
+				},
+			},
+			resES: &ec2model.VPCEndpointService{
+				ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::VPCEndpointService", "EndpointService"),
+				Spec: ec2model.VPCEndpointServiceSpec{
+					AcceptanceRequired:      true,
+					NetworkLoadBalancerARNs: []core.StringToken{core.LiteralStringToken("lb-arn")},
+				},
+			},
+			wantErr: errors.New("some AWS API error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.fields.createCalls {
+				ec2Client.EXPECT().CreateVpcEndpointServiceConfigurationWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.modifyPermsCalls {
+				ec2Client.EXPECT().ModifyVpcEndpointServicePermissionsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			trackingProvider := tracking.NewDefaultProvider("service.k8s.aws", "cluster-name")
+			m := &defaultVPCEndpointServiceManager{
+				ec2Client:        ec2Client,
+				trackingProvider: trackingProvider,
+				logger:           &log.NullLogger{},
+			}
+			got, err := m.Create(context.Background(), tt.resES)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultVPCEndpointServiceManager_updateSDKVPCEndpointServiceConfiguration(t *testing.T) {
+	type modifyVpcEndpointServiceConfigurationWithContextCall struct {
+		req  *ec2sdk.ModifyVpcEndpointServiceConfigurationInput
+		resp *ec2sdk.ModifyVpcEndpointServiceConfigurationOutput
+		err  error
+	}
+	type fields struct {
+		modifyCalls []modifyVpcEndpointServiceConfigurationWithContextCall
+	}
+	type args struct {
+		resES *ec2model.VPCEndpointService
+		sdkES VPCEndpointServiceWithTags
+	}
+	stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "configuration unchanged",
+			args: args{
+				resES: &ec2model.VPCEndpointService{
+					ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::VPCEndpointService", "EndpointService"),
+					Spec: ec2model.VPCEndpointServiceSpec{
+						AcceptanceRequired:      true,
+						NetworkLoadBalancerARNs: []core.StringToken{core.LiteralStringToken("lb-arn")},
+					},
+				},
+				sdkES: VPCEndpointServiceWithTags{
+					ServiceConfiguration: &ec2sdk.ServiceConfiguration{
+						ServiceId:               awssdk.String("vpce-svc-a"),
+						AcceptanceRequired:      awssdk.Bool(true),
+						NetworkLoadBalancerArns: awssdk.StringSlice([]string{"lb-arn"}),
+					},
+				},
+			},
+		},
+		{
+			name: "acceptanceRequired changed",
+			fields: fields{
+				modifyCalls: []modifyVpcEndpointServiceConfigurationWithContextCall{
+					{
+						req: &ec2sdk.ModifyVpcEndpointServiceConfigurationInput{
+							ServiceId:          awssdk.String("vpce-svc-a"),
+							AcceptanceRequired: awssdk.Bool(false),
+						},
+						resp: &ec2sdk.ModifyVpcEndpointServiceConfigurationOutput{},
+					},
+				},
+			},
+			args: args{
+				resES: &ec2model.VPCEndpointService{
+					ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::VPCEndpointService", "EndpointService"),
+					Spec: ec2model.VPCEndpointServiceSpec{
+						AcceptanceRequired:      false,
+						NetworkLoadBalancerARNs: []core.StringToken{core.LiteralStringToken("lb-arn")},
+					},
+				},
+				sdkES: VPCEndpointServiceWithTags{
+					ServiceConfiguration: &ec2sdk.ServiceConfiguration{
+						ServiceId:               awssdk.String("vpce-svc-a"),
+						AcceptanceRequired:      awssdk.Bool(true),
+						NetworkLoadBalancerArns: awssdk.StringSlice([]string{"lb-arn"}),
+					},
+				},
+			},
+		},
+		{
+			name: "loadBalancer replaced",
+			fields: fields{
+				modifyCalls: []modifyVpcEndpointServiceConfigurationWithContextCall{
+					{
+						req: &ec2sdk.ModifyVpcEndpointServiceConfigurationInput{
+							ServiceId:                     awssdk.String("vpce-svc-a"),
+							AddNetworkLoadBalancerArns:    awssdk.StringSlice([]string{"lb-arn-new"}),
+							RemoveNetworkLoadBalancerArns: awssdk.StringSlice([]string{"lb-arn-old"}),
+						},
+						err: errors.New("some AWS API error"),
+					},
+				},
+			},
+			args: args{
+				resES: &ec2model.VPCEndpointService{
+					ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::VPCEndpointService", "EndpointService"),
+					Spec: ec2model.VPCEndpointServiceSpec{
+						AcceptanceRequired:      true,
+						NetworkLoadBalancerARNs: []core.StringToken{core.LiteralStringToken("lb-arn-new")},
+					},
+				},
+				sdkES: VPCEndpointServiceWithTags{
+					ServiceConfiguration: &ec2sdk.ServiceConfiguration{
+						ServiceId:               awssdk.String("vpce-svc-a"),
+						AcceptanceRequired:      awssdk.Bool(true),
+						NetworkLoadBalancerArns: awssdk.StringSlice([]string{"lb-arn-old"}),
+					},
+				},
+			},
+			wantErr: errors.New("some AWS API error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.fields.modifyCalls {
+				ec2Client.EXPECT().ModifyVpcEndpointServiceConfigurationWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := &defaultVPCEndpointServiceManager{
+				ec2Client: ec2Client,
+				logger:    &log.NullLogger{},
+			}
+			err := m.updateSDKVPCEndpointServiceConfiguration(context.Background(), tt.args.resES, tt.args.sdkES)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultVPCEndpointServiceManager_Delete(t *testing.T) {
+	type deleteVpcEndpointServiceConfigurationsWithContextCall struct {
+		req  *ec2sdk.DeleteVpcEndpointServiceConfigurationsInput
+		resp *ec2sdk.DeleteVpcEndpointServiceConfigurationsOutput
+		err  error
+	}
+	type fields struct {
+		deleteCalls []deleteVpcEndpointServiceConfigurationsWithContextCall
+	}
+	sdkES := VPCEndpointServiceWithTags{
+		ServiceConfiguration: &ec2sdk.ServiceConfiguration{
+			ServiceId: awssdk.String("vpce-svc-a"),
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr error
+	}{
+		{
+			name: "delete succeeded",
+			fields: fields{
+				deleteCalls: []deleteVpcEndpointServiceConfigurationsWithContextCall{
+					{
+						req: &ec2sdk.DeleteVpcEndpointServiceConfigurationsInput{
+							ServiceIds: awssdk.StringSlice([]string{"vpce-svc-a"}),
+						},
+						resp: &ec2sdk.DeleteVpcEndpointServiceConfigurationsOutput{},
+					},
+				},
+			},
+		},
+		{
+			name: "delete unsuccessful",
+			fields: fields{
+				deleteCalls: []deleteVpcEndpointServiceConfigurationsWithContextCall{
+					{
+						req: &ec2sdk.DeleteVpcEndpointServiceConfigurationsInput{
+							ServiceIds: awssdk.StringSlice([]string{"vpce-svc-a"}),
+						},
+						resp: &ec2sdk.DeleteVpcEndpointServiceConfigurationsOutput{
+							Unsuccessful: []*ec2sdk.UnsuccessfulItem{
+								{
+									ResourceId: awssdk.String("vpce-svc-a"),
+									Error: &ec2sdk.UnsuccessfulItemError{
+										Code:    awssdk.String("ExistingVpcEndpointConnections"),
+										Message: awssdk.String("Service has existing active VPC Endpoint connections"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("failed to delete vpcEndpointService vpce-svc-a: Service has existing active VPC Endpoint connections"),
+		},
+		{
+			name: "delete failed",
+			fields: fields{
+				deleteCalls: []deleteVpcEndpointServiceConfigurationsWithContextCall{
+					{
+						req: &ec2sdk.DeleteVpcEndpointServiceConfigurationsInput{
+							ServiceIds: awssdk.StringSlice([]string{"vpce-svc-a"}),
+						},
+						err: errors.New("some AWS API error"),
+					},
+				},
+			},
+			wantErr: errors.New("failed to delete vpcEndpointService: some AWS API error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.fields.deleteCalls {
+				ec2Client.EXPECT().DeleteVpcEndpointServiceConfigurationsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := &defaultVPCEndpointServiceManager{
+				ec2Client: ec2Client,
+				logger:    &log.NullLogger{},
+			}
+			err := m.Delete(context.Background(), sdkES)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultVPCEndpointServiceManager_updateSDKVPCEndpointServicePermissions(t *testing.T) {
+	type describeVpcEndpointServicePermissionsPagesWithContextCall struct {
+		req  *ec2sdk.DescribeVpcEndpointServicePermissionsInput
+		resp *ec2sdk.DescribeVpcEndpointServicePermissionsOutput
+		err  error
+	}
+	type modifyVpcEndpointServicePermissionsWithContextCall struct {
+		req  *ec2sdk.ModifyVpcEndpointServicePermissionsInput
+		resp *ec2sdk.ModifyVpcEndpointServicePermissionsOutput
+		err  error
+	}
+	type fields struct {
+		describePermsCalls []describeVpcEndpointServicePermissionsPagesWithContextCall
+		modifyPermsCalls   []modifyVpcEndpointServicePermissionsWithContextCall
+	}
+	stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+	sdkES := VPCEndpointServiceWithTags{
+		ServiceConfiguration: &ec2sdk.ServiceConfiguration{
+			ServiceId: awssdk.String("vpce-svc-a"),
+		},
+	}
+	tests := []struct {
+		name              string
+		fields            fields
+		allowedPrincipals []string
+		wantErr           error
+	}{
+		{
+			name: "allowed principals unchanged",
+			fields: fields{
+				describePermsCalls: []describeVpcEndpointServicePermissionsPagesWithContextCall{
+					{
+						req: &ec2sdk.DescribeVpcEndpointServicePermissionsInput{
+							ServiceId: awssdk.String("vpce-svc-a"),
+						},
+						resp: &ec2sdk.DescribeVpcEndpointServicePermissionsOutput{
+							AllowedPrincipals: []*ec2sdk.AllowedPrincipal{
+								{Principal: awssdk.String("arn:aws:iam::123456789012:root")},
+							},
+						},
+					},
+				},
+			},
+			allowedPrincipals: []string{"arn:aws:iam::123456789012:root"},
+		},
+		{
+			name: "allowed principals added and removed",
+			fields: fields{
+				describePermsCalls: []describeVpcEndpointServicePermissionsPagesWithContextCall{
+					{
+						req: &ec2sdk.DescribeVpcEndpointServicePermissionsInput{
+							ServiceId: awssdk.String("vpce-svc-a"),
+						},
+						resp: &ec2sdk.DescribeVpcEndpointServicePermissionsOutput{
+							AllowedPrincipals: []*ec2sdk.AllowedPrincipal{
+								{Principal: awssdk.String("arn:aws:iam::123456789012:root")},
+								{Principal: awssdk.String("arn:aws:iam::210987654321:role/consumer")},
+							},
+						},
+					},
+				},
+				modifyPermsCalls: []modifyVpcEndpointServicePermissionsWithContextCall{
+					{
+						req: &ec2sdk.ModifyVpcEndpointServicePermissionsInput{
+							ServiceId:               awssdk.String("vpce-svc-a"),
+							AddAllowedPrincipals:    awssdk.StringSlice([]string{"arn:aws:iam::111122223333:user/consumer"}),
+							RemoveAllowedPrincipals: awssdk.StringSlice([]string{"arn:aws:iam::210987654321:role/consumer"}),
+						},
+						resp: &ec2sdk.ModifyVpcEndpointServicePermissionsOutput{},
+					},
+				},
+			},
+			allowedPrincipals: []string{"arn:aws:iam::123456789012:root", "arn:aws:iam::111122223333:user/consumer"},
+		},
+		{
+			name: "all allowed principals removed",
+			fields: fields{
+				describePermsCalls: []describeVpcEndpointServicePermissionsPagesWithContextCall{
+					{
+						req: &ec2sdk.DescribeVpcEndpointServicePermissionsInput{
+							ServiceId: awssdk.String("vpce-svc-a"),
+						},
+						resp: &ec2sdk.DescribeVpcEndpointServicePermissionsOutput{
+							AllowedPrincipals: []*ec2sdk.AllowedPrincipal{
+								{Principal: awssdk.String("arn:aws:iam::123456789012:root")},
+							},
+						},
+					},
+				},
+				modifyPermsCalls: []modifyVpcEndpointServicePermissionsWithContextCall{
+					{
+						req: &ec2sdk.ModifyVpcEndpointServicePermissionsInput{
+							ServiceId:               awssdk.String("vpce-svc-a"),
+							RemoveAllowedPrincipals: awssdk.StringSlice([]string{"arn:aws:iam::123456789012:root"}),
+						},
+						resp: &ec2sdk.ModifyVpcEndpointServicePermissionsOutput{},
+					},
+				},
+			},
+			allowedPrincipals: nil,
+		},
+		{
+			name: "failed to describe allowed principals",
+			fields: fields{
+				describePermsCalls: []describeVpcEndpointServicePermissionsPagesWithContextCall{
+					{
+						req: &ec2sdk.DescribeVpcEndpointServicePermissionsInput{
+							ServiceId: awssdk.String("vpce-svc-a"),
+						},
+						resp: &ec2sdk.DescribeVpcEndpointServicePermissionsOutput{},
+						err:  errors.New("some AWS API error"),
+					},
+				},
+			},
+			allowedPrincipals: []string{"arn:aws:iam::123456789012:root"},
+			wantErr:           errors.New("some AWS API error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.fields.describePermsCalls {
+				call := call
+				ec2Client.EXPECT().DescribeVpcEndpointServicePermissionsPagesWithContext(gomock.Any(), call.req, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req *ec2sdk.DescribeVpcEndpointServicePermissionsInput, fn func(*ec2sdk.DescribeVpcEndpointServicePermissionsOutput, bool) bool, opts ...interface{}) error {
+						if call.err != nil {
+							return call.err
+						}
+						fn(call.resp, true)
+						return nil
+					})
+			}
+			for _, call := range tt.fields.modifyPermsCalls {
+				ec2Client.EXPECT().ModifyVpcEndpointServicePermissionsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := &defaultVPCEndpointServiceManager{
+				ec2Client: ec2Client,
+				logger:    &log.NullLogger{},
+			}
+			resES := &ec2model.VPCEndpointService{
+				ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::VPCEndpointService", "EndpointService"),
+				Spec: ec2model.VPCEndpointServiceSpec{
+					AllowedPrincipals: tt.allowedPrincipals,
+				},
+			}
+			err := m.updateSDKVPCEndpointServicePermissions(context.Background(), resES, sdkES)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package ec2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+)
+
+const (
+	// the ec2 error code when caller isn't authorized.
+	errCodeUnauthorizedOperation = "UnauthorizedOperation"
+)
+
+// NewVPCEndpointServiceSynthesizer constructs new vpcEndpointServiceSynthesizer.
+// when the endpoint service addon is disabled, VPCEndpointServices in stack are left untouched,
+// while the ones no longer in stack are still deleted so that they won't block the deletion of LoadBalancers.
+func NewVPCEndpointServiceSynthesizer(trackingProvider tracking.Provider, taggingManager TaggingManager,
+	esManager VPCEndpointServiceManager, enabled bool, logger logr.Logger, stack core.Stack) *vpcEndpointServiceSynthesizer {
+	return &vpcEndpointServiceSynthesizer{
+		trackingProvider: trackingProvider,
+		taggingManager:   taggingManager,
+		esManager:        esManager,
+		enabled:          enabled,
+		logger:           logger,
+		stack:            stack,
+	}
+}
+
+type vpcEndpointServiceSynthesizer struct {
+	trackingProvider tracking.Provider
+	taggingManager   TaggingManager
+	esManager        VPCEndpointServiceManager
+	enabled          bool
+	logger           logr.Logger
+
+	stack                core.Stack
+	matchedResAndSDKESes []resAndSDKVPCEndpointServicePair
+	unmatchedResESes     []*ec2model.VPCEndpointService
+}
+
+func (s *vpcEndpointServiceSynthesizer) Synthesize(ctx context.Context) error {
+	var resESes []*ec2model.VPCEndpointService
+	s.stack.ListResources(&resESes)
+	sdkESes, err := s.findSDKVPCEndpointServices(ctx)
+	if err != nil {
+		// without the addon enabled, the controller may not be granted permissions for VPCEndpointServices,
+		// in which case it cannot have created any VPCEndpointServices either.
+		if !s.enabled && isEC2UnauthorizedOperationError(err) {
+			s.logger.V(1).Info("skipping VPCEndpointService cleanup due to missing permissions", "error", err)
+			return nil
+		}
+		return err
+	}
+	matchedResAndSDKESes, unmatchedResESes, unmatchedSDKESes, err := matchResAndSDKVPCEndpointServices(resESes, sdkESes, s.trackingProvider.ResourceIDTagKey())
+	if err != nil {
+		return err
+	}
+
+	// For VPCEndpointService, we delete unmatched ones during synthesize given LoadBalancers cannot be deleted while used by endpoint services,
+	// and create or update the others during post synthesize after LoadBalancers are fulfilled.
+	for _, sdkES := range unmatchedSDKESes {
+		if err := s.esManager.Delete(ctx, sdkES); err != nil {
+			return err
+		}
+	}
+	if !s.enabled {
+		return nil
+	}
+	s.matchedResAndSDKESes = matchedResAndSDKESes
+	s.unmatchedResESes = unmatchedResESes
+	return nil
+}
+
+func (s *vpcEndpointServiceSynthesizer) PostSynthesize(ctx context.Context) error {
+	for _, resES := range s.unmatchedResESes {
+		esStatus, err := s.esManager.Create(ctx, resES)
+		if err != nil {
+			return err
+		}
+		resES.SetStatus(esStatus)
+	}
+	for _, resAndSDKES := range s.matchedResAndSDKESes {
+		esStatus, err := s.esManager.Update(ctx, resAndSDKES.resES, resAndSDKES.sdkES)
+		if err != nil {
+			return err
+		}
+		resAndSDKES.resES.SetStatus(esStatus)
+	}
+	return nil
+}
+
+// findSDKVPCEndpointServices will find all AWS VPCEndpointServices created for stack.
+func (s *vpcEndpointServiceSynthesizer) findSDKVPCEndpointServices(ctx context.Context) ([]VPCEndpointServiceWithTags, error) {
+	stackTags := s.trackingProvider.StackTags(s.stack)
+	return s.taggingManager.ListVPCEndpointServices(ctx, tracking.TagsAsTagFilter(stackTags))
+}
+
+type resAndSDKVPCEndpointServicePair struct {
+	resES *ec2model.VPCEndpointService
+	sdkES VPCEndpointServiceWithTags
+}
+
+func matchResAndSDKVPCEndpointServices(resESes []*ec2model.VPCEndpointService, sdkESes []VPCEndpointServiceWithTags,
+	resourceIDTagKey string) ([]resAndSDKVPCEndpointServicePair, []*ec2model.VPCEndpointService, []VPCEndpointServiceWithTags, error) {
+	var matchedResAndSDKESes []resAndSDKVPCEndpointServicePair
+	var unmatchedResESes []*ec2model.VPCEndpointService
+	var unmatchedSDKESes []VPCEndpointServiceWithTags
+
+	resESesByID := mapResVPCEndpointServiceByResourceID(resESes)
+	sdkESesByID, err := mapSDKVPCEndpointServiceByResourceID(sdkESes, resourceIDTagKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	resESIDs := sets.StringKeySet(resESesByID)
+	sdkESIDs := sets.StringKeySet(sdkESesByID)
+	for _, resID := range resESIDs.Intersection(sdkESIDs).List() {
+		resES := resESesByID[resID]
+		sdkESes := sdkESesByID[resID]
+		matchedResAndSDKESes = append(matchedResAndSDKESes, resAndSDKVPCEndpointServicePair{
+			resES: resES,
+			sdkES: sdkESes[0],
+		})
+		for _, sdkES := range sdkESes[1:] {
+			unmatchedSDKESes = append(unmatchedSDKESes, sdkES)
+		}
+	}
+	for _, resID := range resESIDs.Difference(sdkESIDs).List() {
+		unmatchedResESes = append(unmatchedResESes, resESesByID[resID])
+	}
+	for _, resID := range sdkESIDs.Difference(resESIDs).List() {
+		unmatchedSDKESes = append(unmatchedSDKESes, sdkESesByID[resID]...)
+	}
+
+	return matchedResAndSDKESes, unmatchedResESes, unmatchedSDKESes, nil
+}
+
+func mapResVPCEndpointServiceByResourceID(resESes []*ec2model.VPCEndpointService) map[string]*ec2model.VPCEndpointService {
+	resESesByID := make(map[string]*ec2model.VPCEndpointService, len(resESes))
+	for _, resES := range resESes {
+		resESesByID[resES.ID()] = resES
+	}
+	return resESesByID
+}
+
+func mapSDKVPCEndpointServiceByResourceID(sdkESes []VPCEndpointServiceWithTags, resourceIDTagKey string) (map[string][]VPCEndpointServiceWithTags, error) {
+	sdkESesByID := make(map[string][]VPCEndpointServiceWithTags, len(sdkESes))
+	for _, sdkES := range sdkESes {
+		resourceID, ok := sdkES.Tags[resourceIDTagKey]
+		if !ok {
+			return nil, errors.Errorf("unexpected vpcEndpointService with no resourceID: %v", awssdk.StringValue(sdkES.ServiceConfiguration.ServiceId))
+		}
+		sdkESesByID[resourceID] = append(sdkESesByID[resourceID], sdkES)
+	}
+	return sdkESesByID, nil
+}
+
+// isEC2UnauthorizedOperationError checks whether the error is due to the caller isn't authorized.
+func isEC2UnauthorizedOperationError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == errCodeUnauthorizedOperation
+	}
+	return false
+}
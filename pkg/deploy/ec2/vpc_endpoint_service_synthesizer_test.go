@@ -0,0 +1,194 @@
+package ec2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_vpcEndpointServiceSynthesizer_Synthesize(t *testing.T) {
+	sdkES := &ec2sdk.ServiceConfiguration{
+		ServiceId:    awssdk.String("vpce-svc-a"),
+		ServiceState: awssdk.String(ec2sdk.ServiceStateAvailable),
+		Tags: []*ec2sdk.Tag{
+			{Key: awssdk.String("service.k8s.aws/resource"), Value: awssdk.String("EndpointService")},
+		},
+	}
+	tests := []struct {
+		name              string
+		enabled           bool
+		withResES         bool
+		describeErr       error
+		wantDelete        bool
+		wantMatchedResSDK int
+		wantErr           error
+	}{
+		{
+			name:              "enabled with endpoint service in stack",
+			enabled:           true,
+			withResES:         true,
+			wantMatchedResSDK: 1,
+		},
+		{
+			name:       "enabled without endpoint service in stack",
+			enabled:    true,
+			wantDelete: true,
+		},
+		{
+			name:      "disabled with endpoint service in stack",
+			enabled:   false,
+			withResES: true,
+		},
+		{
+			name:       "disabled without endpoint service in stack",
+			enabled:    false,
+			wantDelete: true,
+		},
+		{
+			name:        "disabled without permissions",
+			enabled:     false,
+			describeErr: awserr.New("UnauthorizedOperation", "not authorized", nil),
+		},
+		{
+			name:        "enabled without permissions",
+			enabled:     true,
+			describeErr: awserr.New("UnauthorizedOperation", "not authorized", nil),
+			wantErr:     errors.New("UnauthorizedOperation: not authorized"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			ec2Client.EXPECT().DescribeVpcEndpointServiceConfigurationsPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ *ec2sdk.DescribeVpcEndpointServiceConfigurationsInput, fn func(*ec2sdk.DescribeVpcEndpointServiceConfigurationsOutput, bool) bool, _ ...interface{}) error {
+					if tt.describeErr != nil {
+						return tt.describeErr
+					}
+					fn(&ec2sdk.DescribeVpcEndpointServiceConfigurationsOutput{ServiceConfigurations: []*ec2sdk.ServiceConfiguration{sdkES}}, true)
+					return nil
+				})
+			if tt.wantDelete {
+				ec2Client.EXPECT().DeleteVpcEndpointServiceConfigurationsWithContext(gomock.Any(), &ec2sdk.DeleteVpcEndpointServiceConfigurationsInput{
+					ServiceIds: awssdk.StringSlice([]string{"vpce-svc-a"}),
+				}).Return(&ec2sdk.DeleteVpcEndpointServiceConfigurationsOutput{}, nil)
+			}
+
+			stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+			if tt.withResES {
+				_ = ec2model.NewVPCEndpointService(stack, "EndpointService", ec2model.VPCEndpointServiceSpec{})
+			}
+			trackingProvider := tracking.NewDefaultProvider("service.k8s.aws", "cluster-name")
+			taggingManager := NewDefaultTaggingManager(ec2Client, nil, "vpc-xxx", &log.NullLogger{})
+			esManager := NewDefaultVPCEndpointServiceManager(ec2Client, trackingProvider, taggingManager, &log.NullLogger{})
+			s := NewVPCEndpointServiceSynthesizer(trackingProvider, taggingManager, esManager, tt.enabled, &log.NullLogger{}, stack)
+			err := s.Synthesize(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Empty(t, s.unmatchedResESes)
+				assert.Len(t, s.matchedResAndSDKESes, tt.wantMatchedResSDK)
+			}
+		})
+	}
+}
+
+func Test_matchResAndSDKVPCEndpointServices(t *testing.T) {
+	stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+	resES := &ec2model.VPCEndpointService{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::VPCEndpointService", "EndpointService"),
+	}
+	sdkESA := VPCEndpointServiceWithTags{
+		ServiceConfiguration: &ec2sdk.ServiceConfiguration{ServiceId: awssdk.String("vpce-svc-a")},
+		Tags:                 map[string]string{"service.k8s.aws/resource": "EndpointService"},
+	}
+	sdkESB := VPCEndpointServiceWithTags{
+		ServiceConfiguration: &ec2sdk.ServiceConfiguration{ServiceId: awssdk.String("vpce-svc-b")},
+		Tags:                 map[string]string{"service.k8s.aws/resource": "EndpointService"},
+	}
+	sdkESC := VPCEndpointServiceWithTags{
+		ServiceConfiguration: &ec2sdk.ServiceConfiguration{ServiceId: awssdk.String("vpce-svc-c")},
+		Tags:                 map[string]string{"service.k8s.aws/resource": "OtherEndpointService"},
+	}
+	type args struct {
+		resESes []*ec2model.VPCEndpointService
+		sdkESes []VPCEndpointServiceWithTags
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []resAndSDKVPCEndpointServicePair
+		want1   []*ec2model.VPCEndpointService
+		want2   []VPCEndpointServiceWithTags
+		wantErr error
+	}{
+		{
+			name: "endpoint service has match",
+			args: args{
+				resESes: []*ec2model.VPCEndpointService{resES},
+				sdkESes: []VPCEndpointServiceWithTags{sdkESA},
+			},
+			want: []resAndSDKVPCEndpointServicePair{
+				{resES: resES, sdkES: sdkESA},
+			},
+		},
+		{
+			name: "endpoint service has duplicated and unexpected matches",
+			args: args{
+				resESes: []*ec2model.VPCEndpointService{resES},
+				sdkESes: []VPCEndpointServiceWithTags{sdkESA, sdkESB, sdkESC},
+			},
+			want: []resAndSDKVPCEndpointServicePair{
+				{resES: resES, sdkES: sdkESA},
+			},
+			want2: []VPCEndpointServiceWithTags{sdkESB, sdkESC},
+		},
+		{
+			name: "endpoint service has no match",
+			args: args{
+				resESes: []*ec2model.VPCEndpointService{resES},
+				sdkESes: []VPCEndpointServiceWithTags{sdkESC},
+			},
+			want1: []*ec2model.VPCEndpointService{resES},
+			want2: []VPCEndpointServiceWithTags{sdkESC},
+		},
+		{
+			name: "endpoint service without resourceID tag",
+			args: args{
+				resESes: []*ec2model.VPCEndpointService{resES},
+				sdkESes: []VPCEndpointServiceWithTags{
+					{
+						ServiceConfiguration: &ec2sdk.ServiceConfiguration{ServiceId: awssdk.String("vpce-svc-d")},
+					},
+				},
+			},
+			wantErr: errors.New("unexpected vpcEndpointService with no resourceID: vpce-svc-d"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, got2, err := matchResAndSDKVPCEndpointServices(tt.args.resESes, tt.args.sdkESes, "service.k8s.aws/resource")
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.want1, got1)
+				assert.Equal(t, tt.want2, got2)
+			}
+		})
+	}
+}
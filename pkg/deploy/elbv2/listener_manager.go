@@ -65,7 +65,7 @@ func (m *defaultListenerManager) Create(ctx context.Context, resLS *elbv2model.L
 		"arn", awssdk.StringValue(sdkLS.ListenerArn))
 
 	if err := runtime.RetryImmediateOnError(m.waitLSExistencePollInterval, m.waitLSExistenceTimeout, isListenerNotFoundError, func() error {
-		return m.updateSDKListenerWithExtraCertificates(ctx, resLS, sdkLS, true)
+		return m.updateSDKListenerWithExtraCertificates(ctx, resLS, sdkLS, sets.NewString())
 	}); err != nil {
 		return elbv2model.ListenerStatus{}, errors.Wrap(err, "failed to update extra certificates on listener")
 	}
@@ -73,10 +73,18 @@ func (m *defaultListenerManager) Create(ctx context.Context, resLS *elbv2model.L
 }
 
 func (m *defaultListenerManager) Update(ctx context.Context, resLS *elbv2model.Listener, sdkLS *elbv2sdk.Listener) (elbv2model.ListenerStatus, error) {
+	certARNs, err := m.fetchSDKListenerExtraCertificateARNs(ctx, sdkLS)
+	if err != nil {
+		return elbv2model.ListenerStatus{}, err
+	}
+	currentExtraCertARNs := sets.NewString(certARNs...)
+	if err := m.addSDKListenerCertificatesBeforeSettings(ctx, resLS, sdkLS, currentExtraCertARNs); err != nil {
+		return elbv2model.ListenerStatus{}, err
+	}
 	if err := m.updateSDKListenerWithSettings(ctx, resLS, sdkLS); err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
-	if err := m.updateSDKListenerWithExtraCertificates(ctx, resLS, sdkLS, false); err != nil {
+	if err := m.updateSDKListenerWithExtraCertificates(ctx, resLS, sdkLS, currentExtraCertARNs); err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
 	return buildResListenerStatus(sdkLS), nil
@@ -121,49 +129,58 @@ func (m *defaultListenerManager) updateSDKListenerWithSettings(ctx context.Conte
 	return nil
 }
 
+// addSDKListenerCertificatesBeforeSettings adds the desired certificates missing on listener before its default certificate is modified.
+// during certificate rotation, the new certificates are added first, then the default certificate is modified, and stale certificates are removed last,
+// so that the listener is never left without a valid certificate for TLS handshakes.
+// currentExtraCertARNs will be updated to include the added certificates.
+func (m *defaultListenerManager) addSDKListenerCertificatesBeforeSettings(ctx context.Context, resLS *elbv2model.Listener,
+	sdkLS *elbv2sdk.Listener, currentExtraCertARNs sets.String) error {
+	// certificates can only be added to listeners that already have a default certificate.
+	if len(sdkLS.Certificates) == 0 {
+		return nil
+	}
+	currentDefaultCertARN := awssdk.StringValue(sdkLS.Certificates[0].CertificateArn)
+	desiredDefaultCerts, desiredExtraCerts := buildSDKCertificates(resLS.Spec.Certificates)
+	certARNsToAdd := sets.NewString()
+	for _, cert := range desiredDefaultCerts {
+		if certARN := awssdk.StringValue(cert.CertificateArn); certARN != currentDefaultCertARN {
+			certARNsToAdd.Insert(certARN)
+		}
+	}
+	for _, cert := range desiredExtraCerts {
+		certARNsToAdd.Insert(awssdk.StringValue(cert.CertificateArn))
+	}
+	for _, certARN := range certARNsToAdd.Difference(currentExtraCertARNs).List() {
+		if err := m.addSDKListenerCertificate(ctx, resLS, sdkLS, certARN); err != nil {
+			return err
+		}
+		currentExtraCertARNs.Insert(certARN)
+	}
+	return nil
+}
+
 // updateSDKListenerWithExtraCertificates will update the extra certificates on listener.
-// currentExtraCertificates is the current extra certificates, if it's nil, the current extra certificates will be fetched from AWS.
+// currentExtraCertARNs is the current extra certificates on listener.
 func (m *defaultListenerManager) updateSDKListenerWithExtraCertificates(ctx context.Context, resLS *elbv2model.Listener,
-	sdkLS *elbv2sdk.Listener, isNewSDKListener bool) error {
+	sdkLS *elbv2sdk.Listener, currentExtraCertARNs sets.String) error {
 	desiredExtraCertARNs := sets.NewString()
-	_, desiredExtraCerts := buildSDKCertificates(resLS.Spec.Certificates)
+	desiredDefaultCerts, desiredExtraCerts := buildSDKCertificates(resLS.Spec.Certificates)
 	for _, cert := range desiredExtraCerts {
 		desiredExtraCertARNs.Insert(awssdk.StringValue(cert.CertificateArn))
 	}
-	currentExtraCertARNs := sets.NewString()
-	if !isNewSDKListener {
-		certARNs, err := m.fetchSDKListenerExtraCertificateARNs(ctx, sdkLS)
-		if err != nil {
-			return err
-		}
-		currentExtraCertARNs.Insert(certARNs...)
+	// the default certificate cannot be removed from listener, it might be in extra certificates after rotation.
+	retainedCertARNs := sets.NewString()
+	for _, cert := range desiredDefaultCerts {
+		retainedCertARNs.Insert(awssdk.StringValue(cert.CertificateArn))
 	}
 
 	for _, certARN := range desiredExtraCertARNs.Difference(currentExtraCertARNs).List() {
-		req := &elbv2sdk.AddListenerCertificatesInput{
-			ListenerArn: sdkLS.ListenerArn,
-			Certificates: []*elbv2sdk.Certificate{
-				{
-					CertificateArn: awssdk.String(certARN),
-				},
-			},
-		}
-		m.logger.Info("adding certificate to listener",
-			"stackID", resLS.Stack().StackID(),
-			"resourceID", resLS.ID(),
-			"arn", awssdk.StringValue(sdkLS.ListenerArn),
-			"certificateARN", certARN)
-		if _, err := m.elbv2Client.AddListenerCertificatesWithContext(ctx, req); err != nil {
+		if err := m.addSDKListenerCertificate(ctx, resLS, sdkLS, certARN); err != nil {
 			return err
 		}
-		m.logger.Info("added certificate to listener",
-			"stackID", resLS.Stack().StackID(),
-			"resourceID", resLS.ID(),
-			"arn", awssdk.StringValue(sdkLS.ListenerArn),
-			"certificateARN", certARN)
 	}
 
-	for _, certARN := range currentExtraCertARNs.Difference(desiredExtraCertARNs).List() {
+	for _, certARN := range currentExtraCertARNs.Difference(desiredExtraCertARNs).Difference(retainedCertARNs).List() {
 		req := &elbv2sdk.RemoveListenerCertificatesInput{
 			ListenerArn: sdkLS.ListenerArn,
 			Certificates: []*elbv2sdk.Certificate{
@@ -190,6 +207,31 @@ func (m *defaultListenerManager) updateSDKListenerWithExtraCertificates(ctx cont
 	return nil
 }
 
+func (m *defaultListenerManager) addSDKListenerCertificate(ctx context.Context, resLS *elbv2model.Listener, sdkLS *elbv2sdk.Listener, certARN string) error {
+	req := &elbv2sdk.AddListenerCertificatesInput{
+		ListenerArn: sdkLS.ListenerArn,
+		Certificates: []*elbv2sdk.Certificate{
+			{
+				CertificateArn: awssdk.String(certARN),
+			},
+		},
+	}
+	m.logger.Info("adding certificate to listener",
+		"stackID", resLS.Stack().StackID(),
+		"resourceID", resLS.ID(),
+		"arn", awssdk.StringValue(sdkLS.ListenerArn),
+		"certificateARN", certARN)
+	if _, err := m.elbv2Client.AddListenerCertificatesWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("added certificate to listener",
+		"stackID", resLS.Stack().StackID(),
+		"resourceID", resLS.ID(),
+		"arn", awssdk.StringValue(sdkLS.ListenerArn),
+		"certificateARN", certARN)
+	return nil
+}
+
 func (m *defaultListenerManager) fetchSDKListenerExtraCertificateARNs(ctx context.Context, sdkLS *elbv2sdk.Listener) ([]string, error) {
 	req := &elbv2sdk.DescribeListenerCertificatesInput{
 		ListenerArn: sdkLS.ListenerArn,
@@ -1,10 +1,15 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -73,3 +78,131 @@ func Test_isSDKListenerSettingsDrifted(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultListenerManager_Update_certificates(t *testing.T) {
+	type fields struct {
+		currentDefaultCertARN string
+		currentExtraCertARNs  []string
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		desiredCerts []string
+		wantAPICalls []string
+	}{
+		{
+			name: "no API calls when certificates are unchanged",
+			fields: fields{
+				currentDefaultCertARN: "cert-1",
+				currentExtraCertARNs:  []string{"cert-2"},
+			},
+			desiredCerts: []string{"cert-1", "cert-2"},
+			wantAPICalls: nil,
+		},
+		{
+			name: "new default certificate is added before the old one is removed",
+			fields: fields{
+				currentDefaultCertARN: "cert-old",
+			},
+			desiredCerts: []string{"cert-new"},
+			wantAPICalls: []string{
+				"AddListenerCertificates cert-new",
+				"ModifyListener cert-new",
+			},
+		},
+		{
+			name: "old default certificate is retained until new default certificate is designated",
+			fields: fields{
+				currentDefaultCertARN: "cert-old",
+				currentExtraCertARNs:  []string{"cert-old", "cert-2"},
+			},
+			desiredCerts: []string{"cert-new", "cert-2", "cert-3"},
+			wantAPICalls: []string{
+				"AddListenerCertificates cert-3",
+				"AddListenerCertificates cert-new",
+				"ModifyListener cert-new",
+				"RemoveListenerCertificates cert-old",
+			},
+		},
+		{
+			name: "extra certificate promoted to default",
+			fields: fields{
+				currentDefaultCertARN: "cert-old",
+				currentExtraCertARNs:  []string{"cert-new"},
+			},
+			desiredCerts: []string{"cert-new"},
+			wantAPICalls: []string{
+				"ModifyListener cert-new",
+			},
+		},
+		{
+			name: "new certificates are added after default is set for listener without certificates",
+			fields: fields{
+				currentDefaultCertARN: "",
+			},
+			desiredCerts: []string{"cert-1", "cert-2"},
+			wantAPICalls: []string{
+				"ModifyListener cert-1",
+				"AddListenerCertificates cert-2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var gotAPICalls []string
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			var sdkListenerCerts []*elbv2sdk.Certificate
+			var sdkCerts []*elbv2sdk.Certificate
+			if tt.fields.currentDefaultCertARN != "" {
+				sdkListenerCerts = append(sdkListenerCerts, &elbv2sdk.Certificate{CertificateArn: awssdk.String(tt.fields.currentDefaultCertARN)})
+				sdkCerts = append(sdkCerts, &elbv2sdk.Certificate{CertificateArn: awssdk.String(tt.fields.currentDefaultCertARN), IsDefault: awssdk.Bool(true)})
+			}
+			for _, certARN := range tt.fields.currentExtraCertARNs {
+				sdkCerts = append(sdkCerts, &elbv2sdk.Certificate{CertificateArn: awssdk.String(certARN), IsDefault: awssdk.Bool(false)})
+			}
+			elbv2Client.EXPECT().DescribeListenerCertificatesAsList(gomock.Any(), &elbv2sdk.DescribeListenerCertificatesInput{
+				ListenerArn: awssdk.String("my-listener"),
+			}).Return(sdkCerts, nil)
+			elbv2Client.EXPECT().AddListenerCertificatesWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req *elbv2sdk.AddListenerCertificatesInput, _ ...interface{}) (*elbv2sdk.AddListenerCertificatesOutput, error) {
+					gotAPICalls = append(gotAPICalls, "AddListenerCertificates "+awssdk.StringValue(req.Certificates[0].CertificateArn))
+					return &elbv2sdk.AddListenerCertificatesOutput{}, nil
+				}).AnyTimes()
+			elbv2Client.EXPECT().ModifyListenerWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req *elbv2sdk.ModifyListenerInput, _ ...interface{}) (*elbv2sdk.ModifyListenerOutput, error) {
+					gotAPICalls = append(gotAPICalls, "ModifyListener "+awssdk.StringValue(req.Certificates[0].CertificateArn))
+					return &elbv2sdk.ModifyListenerOutput{}, nil
+				}).AnyTimes()
+			elbv2Client.EXPECT().RemoveListenerCertificatesWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req *elbv2sdk.RemoveListenerCertificatesInput, _ ...interface{}) (*elbv2sdk.RemoveListenerCertificatesOutput, error) {
+					gotAPICalls = append(gotAPICalls, "RemoveListenerCertificates "+awssdk.StringValue(req.Certificates[0].CertificateArn))
+					return &elbv2sdk.RemoveListenerCertificatesOutput{}, nil
+				}).AnyTimes()
+
+			var desiredCerts []elbv2model.Certificate
+			for _, certARN := range tt.desiredCerts {
+				desiredCerts = append(desiredCerts, elbv2model.Certificate{CertificateARN: awssdk.String(certARN)})
+			}
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resLS := elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{
+				LoadBalancerARN: coremodel.LiteralStringToken("my-lb"),
+				Port:            443,
+				Protocol:        elbv2model.ProtocolHTTPS,
+				Certificates:    desiredCerts,
+			})
+			sdkLS := &elbv2sdk.Listener{
+				ListenerArn:  awssdk.String("my-listener"),
+				Port:         awssdk.Int64(443),
+				Protocol:     awssdk.String("HTTPS"),
+				Certificates: sdkListenerCerts,
+			}
+			m := NewDefaultListenerManager(elbv2Client, &log.NullLogger{})
+			_, err := m.Update(context.Background(), resLS, sdkLS)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAPICalls, gotAPICalls)
+		})
+	}
+}
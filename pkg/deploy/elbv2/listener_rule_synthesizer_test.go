@@ -0,0 +1,84 @@
+package elbv2
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func Test_matchResAndSDKListenerRules(t *testing.T) {
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	resLR1 := &elbv2model.ListenerRule{
+		ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::ListenerRule", "80:1"),
+		Spec:         elbv2model.ListenerRuleSpec{Priority: 1},
+	}
+	resLR2 := &elbv2model.ListenerRule{
+		ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::ListenerRule", "80:2"),
+		Spec:         elbv2model.ListenerRuleSpec{Priority: 2},
+	}
+	resLR3 := &elbv2model.ListenerRule{
+		ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::ListenerRule", "80:3"),
+		Spec:         elbv2model.ListenerRuleSpec{Priority: 3},
+	}
+	sdkLR1 := &elbv2sdk.Rule{RuleArn: awssdk.String("rule-1"), Priority: awssdk.String("1")}
+	sdkLR2 := &elbv2sdk.Rule{RuleArn: awssdk.String("rule-2"), Priority: awssdk.String("2")}
+	sdkLR5 := &elbv2sdk.Rule{RuleArn: awssdk.String("rule-5"), Priority: awssdk.String("5")}
+	sdkLR9 := &elbv2sdk.Rule{RuleArn: awssdk.String("rule-9"), Priority: awssdk.String("9")}
+	type args struct {
+		resLRs []*elbv2model.ListenerRule
+		sdkLRs []*elbv2sdk.Rule
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  []resAndSDKListenerRulePair
+		want1 []*elbv2model.ListenerRule
+		want2 []*elbv2sdk.Rule
+	}{
+		{
+			name: "all listenerRules has match",
+			args: args{
+				resLRs: []*elbv2model.ListenerRule{resLR1, resLR2},
+				sdkLRs: []*elbv2sdk.Rule{sdkLR1, sdkLR2},
+			},
+			want: []resAndSDKListenerRulePair{
+				{resLR: resLR1, sdkLR: sdkLR1},
+				{resLR: resLR2, sdkLR: sdkLR2},
+			},
+		},
+		{
+			name: "fragmented priorities are compacted into contiguous priorities",
+			args: args{
+				resLRs: []*elbv2model.ListenerRule{resLR1, resLR2, resLR3},
+				sdkLRs: []*elbv2sdk.Rule{sdkLR9, sdkLR1, sdkLR5},
+			},
+			want: []resAndSDKListenerRulePair{
+				{resLR: resLR1, sdkLR: sdkLR1},
+			},
+			want1: []*elbv2model.ListenerRule{resLR2, resLR3},
+			want2: []*elbv2sdk.Rule{sdkLR5, sdkLR9},
+		},
+		{
+			name: "removed listenerRules are deleted",
+			args: args{
+				resLRs: []*elbv2model.ListenerRule{resLR1},
+				sdkLRs: []*elbv2sdk.Rule{sdkLR1, sdkLR2},
+			},
+			want: []resAndSDKListenerRulePair{
+				{resLR: resLR1, sdkLR: sdkLR1},
+			},
+			want2: []*elbv2sdk.Rule{sdkLR2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, got2 := matchResAndSDKListenerRules(tt.args.resLRs, tt.args.sdkLRs)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+			assert.Equal(t, tt.want2, got2)
+		})
+	}
+}
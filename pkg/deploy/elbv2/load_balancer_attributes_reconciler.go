@@ -13,8 +13,8 @@ import (
 
 // reconciler for LoadBalancer attributes
 type LoadBalancerAttributeReconciler interface {
-	// Reconcile loadBalancer attributes
-	Reconcile(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) error
+	// Reconcile loadBalancer attributes, returns the attributes applied to loadBalancer.
+	Reconcile(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) (map[string]string, error)
 }
 
 // NewDefaultLoadBalancerAttributeReconciler constructs new defaultLoadBalancerAttributeReconciler.
@@ -33,11 +33,11 @@ type defaultLoadBalancerAttributeReconciler struct {
 	logger      logr.Logger
 }
 
-func (r *defaultLoadBalancerAttributeReconciler) Reconcile(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) error {
+func (r *defaultLoadBalancerAttributeReconciler) Reconcile(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) (map[string]string, error) {
 	desiredAttrs := r.getDesiredLoadBalancerAttributes(ctx, resLB)
 	currentAttrs, err := r.getCurrentLoadBalancerAttributes(ctx, sdkLB)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	attributesToUpdate, _ := algorithm.DiffStringMap(desiredAttrs, currentAttrs)
@@ -59,14 +59,17 @@ func (r *defaultLoadBalancerAttributeReconciler) Reconcile(ctx context.Context,
 			"arn", awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
 			"change", attributesToUpdate)
 		if _, err := r.elbv2Client.ModifyLoadBalancerAttributesWithContext(ctx, req); err != nil {
-			return err
+			return nil, err
 		}
 		r.logger.Info("modified loadBalancer attributes",
 			"stackID", resLB.Stack().StackID(),
 			"resourceID", resLB.ID(),
 			"arn", awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn))
 	}
-	return nil
+	for attrKey, attrValue := range attributesToUpdate {
+		currentAttrs[attrKey] = attrValue
+	}
+	return currentAttrs, nil
 }
 
 func (r *defaultLoadBalancerAttributeReconciler) getDesiredLoadBalancerAttributes(ctx context.Context, resLB *elbv2model.LoadBalancer) map[string]string {
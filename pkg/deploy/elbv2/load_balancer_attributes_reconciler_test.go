@@ -41,6 +41,7 @@ func Test_defaultLoadBalancerAttributeReconciler_updateSDKLoadBalancerWithAttrib
 		name    string
 		fields  fields
 		args    args
+		want    map[string]string
 		wantErr error
 	}{
 		{
@@ -105,6 +106,10 @@ func Test_defaultLoadBalancerAttributeReconciler_updateSDKLoadBalancerWithAttrib
 					},
 				},
 			},
+			want: map[string]string{
+				"idle_timeout.timeout_seconds":      "100",
+				"load_balancing.cross_zone.enabled": "true",
+			},
 		},
 		{
 			name: "no attributes should be updated",
@@ -148,6 +153,10 @@ func Test_defaultLoadBalancerAttributeReconciler_updateSDKLoadBalancerWithAttrib
 					},
 				},
 			},
+			want: map[string]string{
+				"idle_timeout.timeout_seconds":      "50",
+				"load_balancing.cross_zone.enabled": "false",
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -165,11 +174,12 @@ func Test_defaultLoadBalancerAttributeReconciler_updateSDKLoadBalancerWithAttrib
 				elbv2Client: elbv2Client,
 				logger:      &log.NullLogger{},
 			}
-			err := r.Reconcile(context.Background(), tt.args.resLB, tt.args.sdkLB)
+			got, err := r.Reconcile(context.Background(), tt.args.resLB, tt.args.sdkLB)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
 			}
 		})
 	}
@@ -4,15 +4,30 @@ import (
 	"context"
 	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"time"
 )
 
+const (
+	defaultWaitLBDeletionPollInterval = 2 * time.Second
+	defaultWaitLBDeletionTimeout      = 20 * time.Second
+
+	lbAttrsLoadBalancingCrossZoneEnabled = "load_balancing.cross_zone.enabled"
+)
+
+// LoadBalancerTagKeyWAFv2LoggingDestination is the tag key on LoadBalancer that records the WAFv2 webACL logging destination configured by controller.
+// it's managed along with the WAFv2 webACL association, instead of the LoadBalancer tags.
+const LoadBalancerTagKeyWAFv2LoggingDestination = "elbv2.k8s.aws/wafv2-logging-destination"
+
 // LoadBalancerManager is responsible for create/update/delete LoadBalancer resources.
 type LoadBalancerManager interface {
 	Create(ctx context.Context, resLB *elbv2model.LoadBalancer) (elbv2model.LoadBalancerStatus, error)
@@ -31,6 +46,9 @@ func NewDefaultLoadBalancerManager(elbv2Client services.ELBV2, trackingProvider
 		taggingManager:       taggingManager,
 		attributesReconciler: NewDefaultLoadBalancerAttributeReconciler(elbv2Client, logger),
 		logger:               logger,
+
+		waitLBDeletionPollInterval: defaultWaitLBDeletionPollInterval,
+		waitLBDeletionTimeout:      defaultWaitLBDeletionTimeout,
 	}
 }
 
@@ -44,6 +62,9 @@ type defaultLoadBalancerManager struct {
 	attributesReconciler LoadBalancerAttributeReconciler
 
 	logger logr.Logger
+
+	waitLBDeletionPollInterval time.Duration
+	waitLBDeletionTimeout      time.Duration
 }
 
 func (m *defaultLoadBalancerManager) Create(ctx context.Context, resLB *elbv2model.LoadBalancer) (elbv2model.LoadBalancerStatus, error) {
@@ -69,11 +90,18 @@ func (m *defaultLoadBalancerManager) Create(ctx context.Context, resLB *elbv2mod
 		"stackID", resLB.Stack().StackID(),
 		"resourceID", resLB.ID(),
 		"arn", awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn))
-	if err := m.attributesReconciler.Reconcile(ctx, resLB, sdkLB); err != nil {
+	// the evaluation of inbound rules on PrivateLink traffic can only be set after the LoadBalancer is created.
+	if resLB.Spec.EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic != nil {
+		if err := m.updateSDKLoadBalancerWithSecurityGroups(ctx, resLB, sdkLB); err != nil {
+			return elbv2model.LoadBalancerStatus{}, err
+		}
+	}
+	lbAttributes, err := m.attributesReconciler.Reconcile(ctx, resLB, sdkLB)
+	if err != nil {
 		return elbv2model.LoadBalancerStatus{}, err
 	}
 
-	return buildResLoadBalancerStatus(sdkLB), nil
+	return buildResLoadBalancerStatus(sdkLB, lbAttributes), nil
 }
 
 func (m *defaultLoadBalancerManager) Update(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) (elbv2model.LoadBalancerStatus, error) {
@@ -89,10 +117,11 @@ func (m *defaultLoadBalancerManager) Update(ctx context.Context, resLB *elbv2mod
 	if err := m.updateSDKLoadBalancerWithIPAddressType(ctx, resLB, sdkLB); err != nil {
 		return elbv2model.LoadBalancerStatus{}, err
 	}
-	if err := m.attributesReconciler.Reconcile(ctx, resLB, sdkLB); err != nil {
+	lbAttributes, err := m.attributesReconciler.Reconcile(ctx, resLB, sdkLB)
+	if err != nil {
 		return elbv2model.LoadBalancerStatus{}, err
 	}
-	return buildResLoadBalancerStatus(sdkLB), nil
+	return buildResLoadBalancerStatus(sdkLB, lbAttributes), nil
 }
 
 func (m *defaultLoadBalancerManager) Delete(ctx context.Context, sdkLB LoadBalancerWithTags) error {
@@ -101,8 +130,11 @@ func (m *defaultLoadBalancerManager) Delete(ctx context.Context, sdkLB LoadBalan
 	}
 	m.logger.Info("deleting loadBalancer",
 		"arn", awssdk.StringValue(req.LoadBalancerArn))
-	if _, err := m.elbv2Client.DeleteLoadBalancerWithContext(ctx, req); err != nil {
+	if err := runtime.RetryImmediateOnError(m.waitLBDeletionPollInterval, m.waitLBDeletionTimeout, isLoadBalancerResourceInUseError, func() error {
+		_, err := m.elbv2Client.DeleteLoadBalancerWithContext(ctx, req)
 		return err
+	}); err != nil {
+		return errors.Wrap(err, "failed to delete loadBalancer")
 	}
 	m.logger.Info("deleted loadBalancer",
 		"arn", awssdk.StringValue(req.LoadBalancerArn))
@@ -181,7 +213,12 @@ func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithSecurityGroups(ctx
 	}
 	desiredSecurityGroups := sets.NewString(awssdk.StringValueSlice(securityGroups)...)
 	currentSecurityGroups := sets.NewString(awssdk.StringValueSlice(sdkLB.LoadBalancer.SecurityGroups)...)
-	if desiredSecurityGroups.Equal(currentSecurityGroups) {
+	// the evaluation of inbound rules on PrivateLink traffic is only changed when specified.
+	desiredEnforceInboundRulesOnPrivateLinkTraffic := awssdk.StringValue(resLB.Spec.EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic)
+	currentEnforceInboundRulesOnPrivateLinkTraffic := awssdk.StringValue(sdkLB.LoadBalancer.EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic)
+	enforceInboundRulesOnPrivateLinkTrafficChanged := desiredEnforceInboundRulesOnPrivateLinkTraffic != "" &&
+		desiredEnforceInboundRulesOnPrivateLinkTraffic != currentEnforceInboundRulesOnPrivateLinkTraffic
+	if desiredSecurityGroups.Equal(currentSecurityGroups) && !enforceInboundRulesOnPrivateLinkTrafficChanged {
 		return nil
 	}
 
@@ -189,12 +226,16 @@ func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithSecurityGroups(ctx
 		LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
 		SecurityGroups:  securityGroups,
 	}
+	if desiredEnforceInboundRulesOnPrivateLinkTraffic != "" {
+		req.EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic = awssdk.String(desiredEnforceInboundRulesOnPrivateLinkTraffic)
+	}
 	changeDesc := fmt.Sprintf("%v => %v", currentSecurityGroups.List(), desiredSecurityGroups.List())
 	m.logger.Info("modifying loadBalancer securityGroups",
 		"stackID", resLB.Stack().StackID(),
 		"resourceID", resLB.ID(),
 		"arn", awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
-		"change", changeDesc)
+		"change", changeDesc,
+		"enforceInboundRulesOnPrivateLinkTraffic", desiredEnforceInboundRulesOnPrivateLinkTraffic)
 	if _, err := m.elbv2Client.SetSecurityGroupsWithContext(ctx, req); err != nil {
 		return err
 	}
@@ -208,9 +249,10 @@ func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithSecurityGroups(ctx
 
 func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithTags(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) error {
 	desiredLBTags := m.trackingProvider.ResourceTags(resLB.Stack(), resLB, resLB.Spec.Tags)
+	ignoredTagKeys := append(m.trackingProvider.LegacyTagKeys(), LoadBalancerTagKeyWAFv2LoggingDestination)
 	return m.taggingManager.ReconcileTags(ctx, awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn), desiredLBTags,
 		WithCurrentTags(sdkLB.Tags),
-		WithIgnoredTagKeys(m.trackingProvider.LegacyTagKeys()))
+		WithIgnoredTagKeys(ignoredTagKeys))
 }
 
 func buildSDKCreateLoadBalancerInput(lbSpec elbv2model.LoadBalancerSpec) (*elbv2sdk.CreateLoadBalancerInput, error) {
@@ -270,13 +312,36 @@ func buildSDKSubnetMapping(modelSubnetMapping elbv2model.SubnetMapping) *elbv2sd
 	return &elbv2sdk.SubnetMapping{
 		AllocationId:       modelSubnetMapping.AllocationID,
 		PrivateIPv4Address: modelSubnetMapping.PrivateIPv4Address,
+		IPv6Address:        modelSubnetMapping.IPv6Address,
 		SubnetId:           awssdk.String(modelSubnetMapping.SubnetID),
 	}
 }
 
-func buildResLoadBalancerStatus(sdkLB LoadBalancerWithTags) elbv2model.LoadBalancerStatus {
+// buildResLoadBalancerStatus builds the LoadBalancerStatus from sdkLB and the attributes applied to it.
+func buildResLoadBalancerStatus(sdkLB LoadBalancerWithTags, lbAttributes map[string]string) elbv2model.LoadBalancerStatus {
+	var state elbv2model.LoadBalancerState
+	if sdkLB.LoadBalancer.State != nil {
+		state = elbv2model.LoadBalancerState(awssdk.StringValue(sdkLB.LoadBalancer.State.Code))
+	}
+	var crossZoneEnabled *bool
+	if rawCrossZoneEnabled, exists := lbAttributes[lbAttrsLoadBalancingCrossZoneEnabled]; exists {
+		crossZoneEnabled = awssdk.Bool(rawCrossZoneEnabled == "true")
+	}
 	return elbv2model.LoadBalancerStatus{
 		LoadBalancerARN: awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
 		DNSName:         awssdk.StringValue(sdkLB.LoadBalancer.DNSName),
+		State:           state,
+
+		CrossZoneLoadBalancingEnabled: crossZoneEnabled,
 	}
 }
+
+// isLoadBalancerResourceInUseError checks whether the LoadBalancer cannot be deleted yet because it's still in use,
+// e.g. by a VPCEndpointService that is being deleted.
+func isLoadBalancerResourceInUseError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == "ResourceInUse"
+	}
+	return false
+}
@@ -1,11 +1,16 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -212,6 +217,19 @@ func Test_buildSDKSubnetMapping(t *testing.T) {
 				SubnetId:           awssdk.String("subnet-abc"),
 			},
 		},
+		{
+			name: "ipv6 address specified",
+			args: args{
+				modelSubnetMapping: elbv2model.SubnetMapping{
+					IPv6Address: awssdk.String("2600:1f14:f8c:2700::1"),
+					SubnetID:    "subnet-abc",
+				},
+			},
+			want: &elbv2sdk.SubnetMapping{
+				IPv6Address: awssdk.String("2600:1f14:f8c:2700::1"),
+				SubnetId:    awssdk.String("subnet-abc"),
+			},
+		},
 		{
 			name: "only-subnet specified",
 			args: args{
@@ -234,7 +252,8 @@ func Test_buildSDKSubnetMapping(t *testing.T) {
 
 func Test_buildResLoadBalancerStatus(t *testing.T) {
 	type args struct {
-		sdkLB LoadBalancerWithTags
+		sdkLB        LoadBalancerWithTags
+		lbAttributes map[string]string
 	}
 	tests := []struct {
 		name string
@@ -256,11 +275,471 @@ func Test_buildResLoadBalancerStatus(t *testing.T) {
 				DNSName:         "www.example.com",
 			},
 		},
+		{
+			name: "loadBalancer with state",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						DNSName:         awssdk.String("www.example.com"),
+						State: &elbv2sdk.LoadBalancerState{
+							Code: awssdk.String(elbv2sdk.LoadBalancerStateEnumProvisioning),
+						},
+					},
+				},
+			},
+			want: elbv2model.LoadBalancerStatus{
+				LoadBalancerARN: "my-arn",
+				DNSName:         "www.example.com",
+				State:           elbv2model.LoadBalancerStateProvisioning,
+			},
+		},
+		{
+			name: "loadBalancer with cross-zone attribute",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						DNSName:         awssdk.String("www.example.com"),
+					},
+				},
+				lbAttributes: map[string]string{
+					"access_logs.s3.enabled":            "false",
+					"load_balancing.cross_zone.enabled": "true",
+				},
+			},
+			want: elbv2model.LoadBalancerStatus{
+				LoadBalancerARN:               "my-arn",
+				DNSName:                       "www.example.com",
+				CrossZoneLoadBalancingEnabled: awssdk.Bool(true),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := buildResLoadBalancerStatus(tt.args.sdkLB)
+			got := buildResLoadBalancerStatus(tt.args.sdkLB, tt.args.lbAttributes)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func Test_defaultLoadBalancerManager_updateSDKLoadBalancerWithSubnetMappings(t *testing.T) {
+	type setSubnetsWithContextCall struct {
+		req  *elbv2sdk.SetSubnetsInput
+		resp *elbv2sdk.SetSubnetsOutput
+		err  error
+	}
+	type fields struct {
+		setSubnetsWithContextCalls []setSubnetsWithContextCall
+	}
+	type args struct {
+		resLB *elbv2model.LoadBalancer
+		sdkLB LoadBalancerWithTags
+	}
+
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "subnets unchanged",
+			fields: fields{
+				setSubnetsWithContextCalls: nil,
+			},
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						SubnetMappings: []elbv2model.SubnetMapping{
+							{SubnetID: "subnet-b"},
+							{SubnetID: "subnet-a"},
+						},
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{SubnetId: awssdk.String("subnet-a")},
+							{SubnetId: awssdk.String("subnet-b")},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "subnets changed should be updated in place",
+			fields: fields{
+				setSubnetsWithContextCalls: []setSubnetsWithContextCall{
+					{
+						req: &elbv2sdk.SetSubnetsInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							SubnetMappings: []*elbv2sdk.SubnetMapping{
+								{SubnetId: awssdk.String("subnet-a")},
+								{SubnetId: awssdk.String("subnet-c")},
+							},
+						},
+						resp: &elbv2sdk.SetSubnetsOutput{},
+					},
+				},
+			},
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						SubnetMappings: []elbv2model.SubnetMapping{
+							{SubnetID: "subnet-a"},
+							{SubnetID: "subnet-c"},
+						},
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{SubnetId: awssdk.String("subnet-a")},
+							{SubnetId: awssdk.String("subnet-b")},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.setSubnetsWithContextCalls {
+				elbv2Client.EXPECT().SetSubnetsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := &defaultLoadBalancerManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := m.updateSDKLoadBalancerWithSubnetMappings(context.Background(), tt.args.resLB, tt.args.sdkLB)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultLoadBalancerManager_updateSDKLoadBalancerWithIPAddressType(t *testing.T) {
+	type setIPAddressTypeWithContextCall struct {
+		req  *elbv2sdk.SetIpAddressTypeInput
+		resp *elbv2sdk.SetIpAddressTypeOutput
+		err  error
+	}
+	type fields struct {
+		setIPAddressTypeWithContextCalls []setIPAddressTypeWithContextCall
+	}
+	type args struct {
+		resLB *elbv2model.LoadBalancer
+		sdkLB LoadBalancerWithTags
+	}
+
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	ipAddressTypeIPV4 := elbv2model.IPAddressTypeIPV4
+	ipAddressTypeDualStack := elbv2model.IPAddressTypeDualStack
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ipAddressType unchanged",
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						IPAddressType: &ipAddressTypeIPV4,
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						IpAddressType:   awssdk.String("ipv4"),
+					},
+				},
+			},
+		},
+		{
+			name: "ipAddressType unspecified",
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec:         elbv2model.LoadBalancerSpec{},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						IpAddressType:   awssdk.String("dualstack"),
+					},
+				},
+			},
+		},
+		{
+			name: "ipv4 to dualstack should be updated in place",
+			fields: fields{
+				setIPAddressTypeWithContextCalls: []setIPAddressTypeWithContextCall{
+					{
+						req: &elbv2sdk.SetIpAddressTypeInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							IpAddressType:   awssdk.String("dualstack"),
+						},
+						resp: &elbv2sdk.SetIpAddressTypeOutput{},
+					},
+				},
+			},
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						IPAddressType: &ipAddressTypeDualStack,
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						IpAddressType:   awssdk.String("ipv4"),
+					},
+				},
+			},
+		},
+		{
+			name: "dualstack to ipv4 should be updated in place",
+			fields: fields{
+				setIPAddressTypeWithContextCalls: []setIPAddressTypeWithContextCall{
+					{
+						req: &elbv2sdk.SetIpAddressTypeInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							IpAddressType:   awssdk.String("ipv4"),
+						},
+						resp: &elbv2sdk.SetIpAddressTypeOutput{},
+					},
+				},
+			},
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						IPAddressType: &ipAddressTypeIPV4,
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						IpAddressType:   awssdk.String("dualstack"),
+					},
+				},
+			},
+		},
+		{
+			name: "failed to update ipAddressType",
+			fields: fields{
+				setIPAddressTypeWithContextCalls: []setIPAddressTypeWithContextCall{
+					{
+						req: &elbv2sdk.SetIpAddressTypeInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							IpAddressType:   awssdk.String("dualstack"),
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						IPAddressType: &ipAddressTypeDualStack,
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						IpAddressType:   awssdk.String("ipv4"),
+					},
+				},
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.setIPAddressTypeWithContextCalls {
+				elbv2Client.EXPECT().SetIpAddressTypeWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := &defaultLoadBalancerManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := m.updateSDKLoadBalancerWithIPAddressType(context.Background(), tt.args.resLB, tt.args.sdkLB)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultLoadBalancerManager_updateSDKLoadBalancerWithSecurityGroups(t *testing.T) {
+	type setSecurityGroupsWithContextCall struct {
+		req *elbv2sdk.SetSecurityGroupsInput
+	}
+	type args struct {
+		resLB *elbv2model.LoadBalancer
+		sdkLB LoadBalancerWithTags
+	}
+
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	tests := []struct {
+		name                              string
+		setSecurityGroupsWithContextCalls []setSecurityGroupsWithContextCall
+		args                              args
+	}{
+		{
+			name: "securityGroups unchanged",
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						SecurityGroups: []coremodel.StringToken{coremodel.LiteralStringToken("sg-1")},
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SecurityGroups:  awssdk.StringSlice([]string{"sg-1"}),
+					},
+				},
+			},
+		},
+		{
+			name: "securityGroups changed",
+			setSecurityGroupsWithContextCalls: []setSecurityGroupsWithContextCall{
+				{
+					req: &elbv2sdk.SetSecurityGroupsInput{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SecurityGroups:  awssdk.StringSlice([]string{"sg-1", "sg-2"}),
+					},
+				},
+			},
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						SecurityGroups: []coremodel.StringToken{coremodel.LiteralStringToken("sg-1"), coremodel.LiteralStringToken("sg-2")},
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SecurityGroups:  awssdk.StringSlice([]string{"sg-1"}),
+					},
+				},
+			},
+		},
+		{
+			name: "securityGroups unchanged with evaluation of inbound rules on PrivateLink traffic changed",
+			setSecurityGroupsWithContextCalls: []setSecurityGroupsWithContextCall{
+				{
+					req: &elbv2sdk.SetSecurityGroupsInput{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SecurityGroups:  awssdk.StringSlice([]string{"sg-1"}),
+						EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic: awssdk.String("off"),
+					},
+				},
+			},
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						SecurityGroups: []coremodel.StringToken{coremodel.LiteralStringToken("sg-1")},
+						EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic: awssdk.String("off"),
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SecurityGroups:  awssdk.StringSlice([]string{"sg-1"}),
+						EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic: awssdk.String("on"),
+					},
+				},
+			},
+		},
+		{
+			name: "securityGroups and evaluation of inbound rules on PrivateLink traffic unchanged",
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						SecurityGroups: []coremodel.StringToken{coremodel.LiteralStringToken("sg-1")},
+						EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic: awssdk.String("off"),
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SecurityGroups:  awssdk.StringSlice([]string{"sg-1"}),
+						EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic: awssdk.String("off"),
+					},
+				},
+			},
+		},
+		{
+			name: "securityGroups changed with evaluation of inbound rules on PrivateLink traffic unchanged",
+			setSecurityGroupsWithContextCalls: []setSecurityGroupsWithContextCall{
+				{
+					req: &elbv2sdk.SetSecurityGroupsInput{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SecurityGroups:  awssdk.StringSlice([]string{"sg-1", "sg-2"}),
+						EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic: awssdk.String("on"),
+					},
+				},
+			},
+			args: args{
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						SecurityGroups: []coremodel.StringToken{coremodel.LiteralStringToken("sg-1"), coremodel.LiteralStringToken("sg-2")},
+						EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic: awssdk.String("on"),
+					},
+				},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SecurityGroups:  awssdk.StringSlice([]string{"sg-1"}),
+						EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic: awssdk.String("on"),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.setSecurityGroupsWithContextCalls {
+				elbv2Client.EXPECT().SetSecurityGroupsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.SetSecurityGroupsOutput{}, nil)
+			}
+			m := &defaultLoadBalancerManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := m.updateSDKLoadBalancerWithSecurityGroups(context.Background(), tt.args.resLB, tt.args.sdkLB)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package elbv2
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -14,12 +15,14 @@ import (
 
 // NewLoadBalancerSynthesizer constructs loadBalancerSynthesizer
 func NewLoadBalancerSynthesizer(elbv2Client services.ELBV2, trackingProvider tracking.Provider, taggingManager TaggingManager,
-	lbManager LoadBalancerManager, logger logr.Logger, stack core.Stack) *loadBalancerSynthesizer {
+	lbManager LoadBalancerManager, lsManager ListenerManager, tgManager TargetGroupManager, logger logr.Logger, stack core.Stack) *loadBalancerSynthesizer {
 	return &loadBalancerSynthesizer{
 		elbv2Client:      elbv2Client,
 		trackingProvider: trackingProvider,
 		taggingManager:   taggingManager,
 		lbManager:        lbManager,
+		lsManager:        lsManager,
+		tgManager:        tgManager,
 		logger:           logger,
 		stack:            stack,
 	}
@@ -31,6 +34,8 @@ type loadBalancerSynthesizer struct {
 	trackingProvider tracking.Provider
 	taggingManager   TaggingManager
 	lbManager        LoadBalancerManager
+	lsManager        ListenerManager
+	tgManager        TargetGroupManager
 	logger           logr.Logger
 
 	stack core.Stack
@@ -50,13 +55,19 @@ func (s *loadBalancerSynthesizer) Synthesize(ctx context.Context) error {
 	}
 
 	// For LoadBalancers, we delete unmatched ones first given below facts:
-	//  * LoadBalancer delete will automatically delete listeners attached to it.
+	//  * listeners and unmatched targetGroups attached to LoadBalancer are deleted together with it, see deleteSDKLoadBalancer.
 	//  * we can avoid the operation to detach a targetGroup from unmatched LBs. (a targetGroup can only attach to one LB).
 	// I don't like this, but it's the easiest solution to meet our requirement :D.
-	for _, sdkLB := range unmatchedSDKLBs {
-		if err := s.lbManager.Delete(ctx, sdkLB); err != nil {
+	if len(unmatchedSDKLBs) != 0 {
+		unmatchedSDKTGs, err := s.findUnmatchedSDKTargetGroups(ctx)
+		if err != nil {
 			return err
 		}
+		for _, sdkLB := range unmatchedSDKLBs {
+			if err := s.deleteSDKLoadBalancer(ctx, sdkLB, unmatchedSDKTGs); err != nil {
+				return err
+			}
+		}
 	}
 	for _, resLB := range unmatchedResLBs {
 		lbStatus, err := s.lbManager.Create(ctx, resLB)
@@ -89,6 +100,53 @@ func (s *loadBalancerSynthesizer) findSDKLoadBalancers(ctx context.Context) ([]L
 		tracking.TagsAsTagFilter(stackTagsLegacy))
 }
 
+// findUnmatchedSDKTargetGroups will find AWS TargetGroups created for stack that are no longer desired by stack.
+func (s *loadBalancerSynthesizer) findUnmatchedSDKTargetGroups(ctx context.Context) ([]TargetGroupWithTags, error) {
+	var resTGs []*elbv2model.TargetGroup
+	s.stack.ListResources(&resTGs)
+	stackTags := s.trackingProvider.StackTags(s.stack)
+	stackTagsLegacy := s.trackingProvider.StackTagsLegacy(s.stack)
+	sdkTGs, err := s.taggingManager.ListTargetGroups(ctx,
+		tracking.TagsAsTagFilter(stackTags),
+		tracking.TagsAsTagFilter(stackTagsLegacy))
+	if err != nil {
+		return nil, err
+	}
+	_, _, unmatchedSDKTGs, err := matchResAndSDKTargetGroups(resTGs, sdkTGs, s.trackingProvider.ResourceIDTagKey())
+	if err != nil {
+		return nil, err
+	}
+	return unmatchedSDKTGs, nil
+}
+
+// deleteSDKLoadBalancer deletes LoadBalancer along with its dependent resources in dependency order:
+//   - listeners(and their rules) on the LoadBalancer are deleted first, so that targetGroups are no longer in use.
+//   - unmatched targetGroups of stack that attached to the LoadBalancer are deleted next, so that they won't be orphaned.
+//   - the LoadBalancer is deleted last.
+func (s *loadBalancerSynthesizer) deleteSDKLoadBalancer(ctx context.Context, sdkLB LoadBalancerWithTags, unmatchedSDKTGs []TargetGroupWithTags) error {
+	lbARN := awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn)
+	sdkLSs, err := s.elbv2Client.DescribeListenersAsList(ctx, &elbv2sdk.DescribeListenersInput{
+		LoadBalancerArn: awssdk.String(lbARN),
+	})
+	if err != nil {
+		return err
+	}
+	for _, sdkLS := range sdkLSs {
+		if err := s.lsManager.Delete(ctx, sdkLS); err != nil && !isListenerNotFoundError(err) {
+			return err
+		}
+	}
+	for _, sdkTG := range unmatchedSDKTGs {
+		if !sets.NewString(awssdk.StringValueSlice(sdkTG.TargetGroup.LoadBalancerArns)...).Has(lbARN) {
+			continue
+		}
+		if err := s.tgManager.Delete(ctx, sdkTG); err != nil {
+			return err
+		}
+	}
+	return s.lbManager.Delete(ctx, sdkLB)
+}
+
 type resAndSDKLoadBalancerPair struct {
 	resLB *elbv2model.LoadBalancer
 	sdkLB LoadBalancerWithTags
@@ -165,5 +223,26 @@ func isSDKLoadBalancerRequiresReplacement(sdkLB LoadBalancerWithTags, resLB *elb
 	if resLB.Spec.Scheme != nil && string(*resLB.Spec.Scheme) != awssdk.StringValue(sdkLB.LoadBalancer.Scheme) {
 		return true
 	}
+	if isSDKLoadBalancerRequiresReplacementDueToSubnets(sdkLB, resLB) {
+		return true
+	}
 	return false
 }
+
+// isSDKLoadBalancerRequiresReplacementDueToSubnets checks whether a sdk LoadBalancer requires replacement to fulfill subnet changes.
+// ApplicationLoadBalancer supports arbitrary subnet changes in place.
+// NetworkLoadBalancer supports adding new subnets in place, but existing subnets cannot be removed.
+func isSDKLoadBalancerRequiresReplacementDueToSubnets(sdkLB LoadBalancerWithTags, resLB *elbv2model.LoadBalancer) bool {
+	if resLB.Spec.Type != elbv2model.LoadBalancerTypeNetwork {
+		return false
+	}
+	desiredSubnets := sets.NewString()
+	for _, mapping := range resLB.Spec.SubnetMappings {
+		desiredSubnets.Insert(mapping.SubnetID)
+	}
+	currentSubnets := sets.NewString()
+	for _, az := range sdkLB.LoadBalancer.AvailabilityZones {
+		currentSubnets.Insert(awssdk.StringValue(az.SubnetId))
+	}
+	return !desiredSubnets.IsSuperset(currentSubnets)
+}
@@ -1,13 +1,19 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_matchResAndSDKLoadBalancers(t *testing.T) {
@@ -497,6 +503,7 @@ func Test_mapSDKLoadBalancerByResourceID(t *testing.T) {
 
 func Test_isSDKLoadBalancerRequiresReplacement(t *testing.T) {
 	schemaInternetFacing := elbv2model.LoadBalancerSchemeInternetFacing
+	ipAddressTypeDualStack := elbv2model.IPAddressTypeDualStack
 	type args struct {
 		sdkLB LoadBalancerWithTags
 		resLB *elbv2model.LoadBalancer
@@ -546,6 +553,28 @@ func Test_isSDKLoadBalancerRequiresReplacement(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "ipAddressType-only change shouldn't need replacement",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						Type:             awssdk.String("application"),
+						Scheme:           awssdk.String("internet-facing"),
+						IpAddressType:    awssdk.String("ipv4"),
+						LoadBalancerName: awssdk.String("my-lb"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					Spec: elbv2model.LoadBalancerSpec{
+						Type:          elbv2model.LoadBalancerTypeApplication,
+						Scheme:        &schemaInternetFacing,
+						IPAddressType: &ipAddressTypeDualStack,
+						Name:          "my-lb",
+					},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "type change need replacement",
 			args: args{
@@ -586,6 +615,88 @@ func Test_isSDKLoadBalancerRequiresReplacement(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "application loadBalancer subnet change shouldn't need replacement",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						Type:             awssdk.String("application"),
+						Scheme:           awssdk.String("internet-facing"),
+						LoadBalancerName: awssdk.String("my-lb"),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{SubnetId: awssdk.String("subnet-a")},
+							{SubnetId: awssdk.String("subnet-b")},
+						},
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					Spec: elbv2model.LoadBalancerSpec{
+						Type:   elbv2model.LoadBalancerTypeApplication,
+						Scheme: &schemaInternetFacing,
+						Name:   "my-lb",
+						SubnetMappings: []elbv2model.SubnetMapping{
+							{SubnetID: "subnet-b"},
+							{SubnetID: "subnet-c"},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "network loadBalancer subnet addition shouldn't need replacement",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						Type:             awssdk.String("network"),
+						Scheme:           awssdk.String("internet-facing"),
+						LoadBalancerName: awssdk.String("my-lb"),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{SubnetId: awssdk.String("subnet-a")},
+						},
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					Spec: elbv2model.LoadBalancerSpec{
+						Type:   elbv2model.LoadBalancerTypeNetwork,
+						Scheme: &schemaInternetFacing,
+						Name:   "my-lb",
+						SubnetMappings: []elbv2model.SubnetMapping{
+							{SubnetID: "subnet-a"},
+							{SubnetID: "subnet-b"},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "network loadBalancer subnet removal need replacement",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						Type:             awssdk.String("network"),
+						Scheme:           awssdk.String("internet-facing"),
+						LoadBalancerName: awssdk.String("my-lb"),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{SubnetId: awssdk.String("subnet-a")},
+							{SubnetId: awssdk.String("subnet-b")},
+						},
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					Spec: elbv2model.LoadBalancerSpec{
+						Type:   elbv2model.LoadBalancerTypeNetwork,
+						Scheme: &schemaInternetFacing,
+						Name:   "my-lb",
+						SubnetMappings: []elbv2model.SubnetMapping{
+							{SubnetID: "subnet-a"},
+						},
+					},
+				},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -594,3 +705,220 @@ func Test_isSDKLoadBalancerRequiresReplacement(t *testing.T) {
 		})
 	}
 }
+
+func Test_loadBalancerSynthesizer_deleteSDKLoadBalancer(t *testing.T) {
+	type describeListenersAsListCall struct {
+		req  *elbv2sdk.DescribeListenersInput
+		resp []*elbv2sdk.Listener
+		err  error
+	}
+	type deleteListenerCall struct {
+		req *elbv2sdk.DeleteListenerInput
+		err error
+	}
+	type deleteTargetGroupCall struct {
+		req *elbv2sdk.DeleteTargetGroupInput
+		err error
+	}
+	type deleteLoadBalancerCall struct {
+		req *elbv2sdk.DeleteLoadBalancerInput
+		err error
+	}
+	type fields struct {
+		describeListenersAsListCalls []describeListenersAsListCall
+		deleteListenerCalls          []deleteListenerCall
+		deleteTargetGroupCalls       []deleteTargetGroupCall
+		deleteLoadBalancerCalls      []deleteLoadBalancerCall
+	}
+	type args struct {
+		sdkLB           LoadBalancerWithTags
+		unmatchedSDKTGs []TargetGroupWithTags
+	}
+	sdkLB := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			LoadBalancerArn: awssdk.String("lb-arn"),
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "listeners and attached targetGroups are deleted before loadBalancer",
+			fields: fields{
+				describeListenersAsListCalls: []describeListenersAsListCall{
+					{
+						req: &elbv2sdk.DescribeListenersInput{LoadBalancerArn: awssdk.String("lb-arn")},
+						resp: []*elbv2sdk.Listener{
+							{ListenerArn: awssdk.String("ls-arn-1")},
+							{ListenerArn: awssdk.String("ls-arn-2")},
+						},
+					},
+				},
+				deleteListenerCalls: []deleteListenerCall{
+					{req: &elbv2sdk.DeleteListenerInput{ListenerArn: awssdk.String("ls-arn-1")}},
+					{req: &elbv2sdk.DeleteListenerInput{ListenerArn: awssdk.String("ls-arn-2")}},
+				},
+				deleteTargetGroupCalls: []deleteTargetGroupCall{
+					{req: &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String("tg-arn-1")}},
+				},
+				deleteLoadBalancerCalls: []deleteLoadBalancerCall{
+					{req: &elbv2sdk.DeleteLoadBalancerInput{LoadBalancerArn: awssdk.String("lb-arn")}},
+				},
+			},
+			args: args{
+				sdkLB: sdkLB,
+				unmatchedSDKTGs: []TargetGroupWithTags{
+					{
+						TargetGroup: &elbv2sdk.TargetGroup{
+							TargetGroupArn:   awssdk.String("tg-arn-1"),
+							LoadBalancerArns: awssdk.StringSlice([]string{"lb-arn"}),
+						},
+					},
+					{
+						TargetGroup: &elbv2sdk.TargetGroup{
+							TargetGroupArn:   awssdk.String("tg-arn-2"),
+							LoadBalancerArns: awssdk.StringSlice([]string{"other-lb-arn"}),
+						},
+					},
+					{
+						TargetGroup: &elbv2sdk.TargetGroup{
+							TargetGroupArn: awssdk.String("tg-arn-3"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "listeners already deleted are ignored",
+			fields: fields{
+				describeListenersAsListCalls: []describeListenersAsListCall{
+					{
+						req: &elbv2sdk.DescribeListenersInput{LoadBalancerArn: awssdk.String("lb-arn")},
+						resp: []*elbv2sdk.Listener{
+							{ListenerArn: awssdk.String("ls-arn-1")},
+						},
+					},
+				},
+				deleteListenerCalls: []deleteListenerCall{
+					{
+						req: &elbv2sdk.DeleteListenerInput{ListenerArn: awssdk.String("ls-arn-1")},
+						err: awserr.New("ListenerNotFound", "listener not found", nil),
+					},
+				},
+				deleteLoadBalancerCalls: []deleteLoadBalancerCall{
+					{req: &elbv2sdk.DeleteLoadBalancerInput{LoadBalancerArn: awssdk.String("lb-arn")}},
+				},
+			},
+			args: args{
+				sdkLB: sdkLB,
+			},
+		},
+		{
+			name: "deletion of in use targetGroup and loadBalancer are retried",
+			fields: fields{
+				describeListenersAsListCalls: []describeListenersAsListCall{
+					{
+						req: &elbv2sdk.DescribeListenersInput{LoadBalancerArn: awssdk.String("lb-arn")},
+					},
+				},
+				deleteTargetGroupCalls: []deleteTargetGroupCall{
+					{
+						req: &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String("tg-arn-1")},
+						err: awserr.New("ResourceInUse", "targetGroup is in use", nil),
+					},
+					{req: &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String("tg-arn-1")}},
+				},
+				deleteLoadBalancerCalls: []deleteLoadBalancerCall{
+					{
+						req: &elbv2sdk.DeleteLoadBalancerInput{LoadBalancerArn: awssdk.String("lb-arn")},
+						err: awserr.New("ResourceInUse", "loadBalancer is in use", nil),
+					},
+					{req: &elbv2sdk.DeleteLoadBalancerInput{LoadBalancerArn: awssdk.String("lb-arn")}},
+				},
+			},
+			args: args{
+				sdkLB: sdkLB,
+				unmatchedSDKTGs: []TargetGroupWithTags{
+					{
+						TargetGroup: &elbv2sdk.TargetGroup{
+							TargetGroupArn:   awssdk.String("tg-arn-1"),
+							LoadBalancerArns: awssdk.StringSlice([]string{"lb-arn"}),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "loadBalancer won't be deleted if listener deletion failed",
+			fields: fields{
+				describeListenersAsListCalls: []describeListenersAsListCall{
+					{
+						req: &elbv2sdk.DescribeListenersInput{LoadBalancerArn: awssdk.String("lb-arn")},
+						resp: []*elbv2sdk.Listener{
+							{ListenerArn: awssdk.String("ls-arn-1")},
+						},
+					},
+				},
+				deleteListenerCalls: []deleteListenerCall{
+					{
+						req: &elbv2sdk.DeleteListenerInput{ListenerArn: awssdk.String("ls-arn-1")},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			args: args{
+				sdkLB: sdkLB,
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			var calls []*gomock.Call
+			for _, call := range tt.fields.describeListenersAsListCalls {
+				calls = append(calls, elbv2Client.EXPECT().DescribeListenersAsList(gomock.Any(), call.req).Return(call.resp, call.err))
+			}
+			for _, call := range tt.fields.deleteListenerCalls {
+				calls = append(calls, elbv2Client.EXPECT().DeleteListenerWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeleteListenerOutput{}, call.err))
+			}
+			for _, call := range tt.fields.deleteTargetGroupCalls {
+				calls = append(calls, elbv2Client.EXPECT().DeleteTargetGroupWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeleteTargetGroupOutput{}, call.err))
+			}
+			for _, call := range tt.fields.deleteLoadBalancerCalls {
+				calls = append(calls, elbv2Client.EXPECT().DeleteLoadBalancerWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeleteLoadBalancerOutput{}, call.err))
+			}
+			gomock.InOrder(calls...)
+
+			s := &loadBalancerSynthesizer{
+				elbv2Client: elbv2Client,
+				lbManager: &defaultLoadBalancerManager{
+					elbv2Client:                elbv2Client,
+					logger:                     &log.NullLogger{},
+					waitLBDeletionPollInterval: time.Millisecond,
+					waitLBDeletionTimeout:      time.Second,
+				},
+				lsManager: NewDefaultListenerManager(elbv2Client, &log.NullLogger{}),
+				tgManager: &defaultTargetGroupManager{
+					elbv2Client:                elbv2Client,
+					logger:                     &log.NullLogger{},
+					waitTGDeletionPollInterval: time.Millisecond,
+					waitTGDeletionTimeout:      time.Second,
+				},
+				logger: &log.NullLogger{},
+			}
+			err := s.deleteSDKLoadBalancer(context.Background(), tt.args.sdkLB, tt.args.unmatchedSDKTGs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
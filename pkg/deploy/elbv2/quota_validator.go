@@ -0,0 +1,340 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	limitNameTargetGroups                        = "target-groups"
+	limitNameApplicationLoadBalancers            = "application-load-balancers"
+	limitNameNetworkLoadBalancers                = "network-load-balancers"
+	limitNameListenersPerApplicationLoadBalancer = "listeners-per-application-load-balancer"
+	limitNameListenersPerNetworkLoadBalancer     = "listeners-per-network-load-balancer"
+	limitNameRulesPerApplicationLoadBalancer     = "rules-per-application-load-balancer"
+
+	accountLimitsCacheKey = "accountLimits"
+	// the account limits will be cached for 30 minutes.
+	defaultAccountLimitsCacheTTL = 30 * time.Minute
+
+	accountTargetGroupsCountCacheKey  = "accountTargetGroupsCount"
+	accountLoadBalancersCountCacheKey = "accountLoadBalancersCount"
+	// the count of existing resources within account will be cached for 5 minutes, so that they're not listed on every reconcile.
+	defaultAccountResourceCountsCacheTTL = 5 * time.Minute
+)
+
+// QuotaValidator is responsible for validate resources within stack don't exceed ELBV2 quotas.
+type QuotaValidator interface {
+	// Validate checks the count of loadBalancers, targetGroups, listeners and listenerRules within stack against ELBV2 quotas.
+	// the account-wide quotas are checked against the resources within stack together with the existing ones outside stack.
+	Validate(ctx context.Context, stack core.Stack) error
+}
+
+// NewDefaultQuotaValidator constructs new defaultQuotaValidator.
+func NewDefaultQuotaValidator(elbv2Client services.ELBV2, trackingProvider tracking.Provider, taggingManager TaggingManager, logger logr.Logger) *defaultQuotaValidator {
+	return &defaultQuotaValidator{
+		elbv2Client:           elbv2Client,
+		trackingProvider:      trackingProvider,
+		taggingManager:        taggingManager,
+		logger:                logger,
+		accountLimitsCache:    cache.NewExpiring(),
+		accountLimitsCacheTTL: defaultAccountLimitsCacheTTL,
+
+		accountResourceCountsCache:    cache.NewExpiring(),
+		accountResourceCountsCacheTTL: defaultAccountResourceCountsCacheTTL,
+	}
+}
+
+var _ QuotaValidator = &defaultQuotaValidator{}
+
+// default implementation for QuotaValidator.
+// the quotas are queried by DescribeAccountLimits API and refreshed per accountLimitsCacheTTL.
+// the count of existing loadBalancers and targetGroups within account are refreshed per accountResourceCountsCacheTTL.
+// listeners and listenerRules on loadBalancers within stack are fully managed by stack, so they're checked against the per loadBalancer quotas as is.
+type defaultQuotaValidator struct {
+	elbv2Client      services.ELBV2
+	trackingProvider tracking.Provider
+	taggingManager   TaggingManager
+	logger           logr.Logger
+
+	accountLimitsCache    *cache.Expiring
+	accountLimitsCacheTTL time.Duration
+	// accountLimitsCacheMutex protects accountLimitsCache
+	accountLimitsCacheMutex sync.Mutex
+
+	accountResourceCountsCache    *cache.Expiring
+	accountResourceCountsCacheTTL time.Duration
+	// accountResourceCountsCacheMutex protects accountResourceCountsCache
+	accountResourceCountsCacheMutex sync.Mutex
+}
+
+func (v *defaultQuotaValidator) Validate(ctx context.Context, stack core.Stack) error {
+	var resTGs []*elbv2model.TargetGroup
+	stack.ListResources(&resTGs)
+	var resLSs []*elbv2model.Listener
+	stack.ListResources(&resLSs)
+	var resLRs []*elbv2model.ListenerRule
+	stack.ListResources(&resLRs)
+	var stackLBs []*elbv2model.LoadBalancer
+	stack.ListResources(&stackLBs)
+	if len(stackLBs) == 0 && len(resTGs) == 0 && len(resLSs) == 0 && len(resLRs) == 0 {
+		return nil
+	}
+
+	limits, err := v.fetchAccountLimits(ctx)
+	if err != nil {
+		// quota validation is best effort, the deployment shouldn't be blocked when quotas are unavailable.
+		v.logger.Info("skipping quota validation, unable to describe account limits", "error", err.Error())
+		return nil
+	}
+
+	if err := v.validateTargetGroupsQuota(ctx, stack, limits, len(resTGs)); err != nil {
+		return err
+	}
+	if err := v.validateLoadBalancersQuota(ctx, stack, limits, stackLBs); err != nil {
+		return err
+	}
+	var resLBs []*elbv2model.LoadBalancer
+	listenersByLB := make(map[*elbv2model.LoadBalancer][]*elbv2model.Listener)
+	for _, resLS := range resLSs {
+		resLB := findDependentLoadBalancer(resLS.Spec.LoadBalancerARN)
+		if resLB == nil {
+			continue
+		}
+		if _, exists := listenersByLB[resLB]; !exists {
+			resLBs = append(resLBs, resLB)
+		}
+		listenersByLB[resLB] = append(listenersByLB[resLB], resLS)
+	}
+	rulesByListener := make(map[*elbv2model.Listener]int)
+	for _, resLR := range resLRs {
+		if resLS := findDependentListener(resLR.Spec.ListenerARN); resLS != nil {
+			rulesByListener[resLS]++
+		}
+	}
+	for _, resLB := range resLBs {
+		lbListeners := listenersByLB[resLB]
+		listenersLimitName := limitNameListenersPerApplicationLoadBalancer
+		if resLB.Spec.Type == elbv2model.LoadBalancerTypeNetwork {
+			listenersLimitName = limitNameListenersPerNetworkLoadBalancer
+		}
+		if err := validateResourceCountWithinLimit(limits, listenersLimitName, len(lbListeners),
+			"listeners on loadBalancer "+resLB.ID()); err != nil {
+			return err
+		}
+		if resLB.Spec.Type != elbv2model.LoadBalancerTypeApplication {
+			continue
+		}
+		lbRuleCount := 0
+		for _, resLS := range lbListeners {
+			lbRuleCount += rulesByListener[resLS]
+		}
+		if err := validateResourceCountWithinLimit(limits, limitNameRulesPerApplicationLoadBalancer, lbRuleCount,
+			"listenerRules on loadBalancer "+resLB.ID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTargetGroupsQuota checks the targetGroups within stack together with the existing ones outside stack doesn't exceed the account-wide quota.
+func (v *defaultQuotaValidator) validateTargetGroupsQuota(ctx context.Context, stack core.Stack, limits map[string]int64, desiredCount int) error {
+	if desiredCount == 0 {
+		return nil
+	}
+	existingCount, err := v.fetchAccountTargetGroupsCount(ctx)
+	if err != nil {
+		v.logger.Info("skipping targetGroups quota validation, unable to describe targetGroups", "error", err.Error())
+		return nil
+	}
+	return v.validateAccountResourceCountWithinLimit(limits, limitNameTargetGroups, desiredCount, existingCount, "targetGroups", func() (int, error) {
+		stackTGs, err := v.taggingManager.ListTargetGroups(ctx, v.buildStackTagFilters(stack)...)
+		return len(stackTGs), err
+	})
+}
+
+// validateLoadBalancersQuota checks the loadBalancers within stack together with the existing ones outside stack doesn't exceed the account-wide quota per loadBalancer type.
+func (v *defaultQuotaValidator) validateLoadBalancersQuota(ctx context.Context, stack core.Stack, limits map[string]int64, stackLBs []*elbv2model.LoadBalancer) error {
+	if len(stackLBs) == 0 {
+		return nil
+	}
+	existingCountByType, err := v.fetchAccountLoadBalancersCountByType(ctx)
+	if err != nil {
+		v.logger.Info("skipping loadBalancers quota validation, unable to describe loadBalancers", "error", err.Error())
+		return nil
+	}
+	var stackSDKLBs []LoadBalancerWithTags
+	stackSDKLBsListed := false
+	for _, lbType := range []elbv2model.LoadBalancerType{elbv2model.LoadBalancerTypeApplication, elbv2model.LoadBalancerTypeNetwork} {
+		desiredCount := 0
+		for _, resLB := range stackLBs {
+			if resLB.Spec.Type == lbType {
+				desiredCount++
+			}
+		}
+		if desiredCount == 0 {
+			continue
+		}
+		limitName := limitNameApplicationLoadBalancers
+		if lbType == elbv2model.LoadBalancerTypeNetwork {
+			limitName = limitNameNetworkLoadBalancers
+		}
+		if err := v.validateAccountResourceCountWithinLimit(limits, limitName, desiredCount, existingCountByType[lbType],
+			fmt.Sprintf("%v loadBalancers", lbType), func() (int, error) {
+				if !stackSDKLBsListed {
+					lbs, err := v.taggingManager.ListLoadBalancers(ctx, v.buildStackTagFilters(stack)...)
+					if err != nil {
+						return 0, err
+					}
+					stackSDKLBs = lbs
+					stackSDKLBsListed = true
+				}
+				count := 0
+				for _, sdkLB := range stackSDKLBs {
+					if awssdk.StringValue(sdkLB.LoadBalancer.Type) == string(lbType) {
+						count++
+					}
+				}
+				return count, nil
+			}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAccountResourceCountWithinLimit checks the desiredCount resources within stack together with the existing ones outside stack doesn't exceed the account-wide limit.
+// the existing resources of stack will be reused or deleted, they're only listed by listStackResourceCount when existingCount together with desiredCount exceeds limit.
+func (v *defaultQuotaValidator) validateAccountResourceCountWithinLimit(limits map[string]int64, limitName string, desiredCount int, existingCount int,
+	resourceDesc string, listStackResourceCount func() (int, error)) error {
+	limitMax, exists := limits[limitName]
+	if !exists || int64(desiredCount+existingCount) <= limitMax {
+		return nil
+	}
+	stackResourceCount, err := listStackResourceCount()
+	if err != nil {
+		v.logger.Info("skipping quota validation, unable to list existing resources of stack", "limitName", limitName, "error", err.Error())
+		return nil
+	}
+	outsideStackCount := existingCount - stackResourceCount
+	return validateResourceCountWithinLimit(limits, limitName, desiredCount+outsideStackCount,
+		fmt.Sprintf("%v (%v within stack and %v outside stack)", resourceDesc, desiredCount, outsideStackCount))
+}
+
+// buildStackTagFilters builds the tagFilters that matches existing resources of stack.
+func (v *defaultQuotaValidator) buildStackTagFilters(stack core.Stack) []tracking.TagFilter {
+	stackTags := v.trackingProvider.StackTags(stack)
+	stackTagsLegacy := v.trackingProvider.StackTagsLegacy(stack)
+	return []tracking.TagFilter{
+		tracking.TagsAsTagFilter(stackTags),
+		tracking.TagsAsTagFilter(stackTagsLegacy),
+	}
+}
+
+// fetchAccountLimits returns the ELBV2 account limits by limit name.
+func (v *defaultQuotaValidator) fetchAccountLimits(ctx context.Context) (map[string]int64, error) {
+	v.accountLimitsCacheMutex.Lock()
+	defer v.accountLimitsCacheMutex.Unlock()
+
+	if rawCacheItem, exists := v.accountLimitsCache.Get(accountLimitsCacheKey); exists {
+		return rawCacheItem.(map[string]int64), nil
+	}
+	limits := make(map[string]int64)
+	req := &elbv2sdk.DescribeAccountLimitsInput{}
+	for {
+		resp, err := v.elbv2Client.DescribeAccountLimitsWithContext(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, limit := range resp.Limits {
+			limitMax, err := strconv.ParseInt(awssdk.StringValue(limit.Max), 10, 64)
+			if err != nil {
+				continue
+			}
+			limits[awssdk.StringValue(limit.Name)] = limitMax
+		}
+		if awssdk.StringValue(resp.NextMarker) == "" {
+			break
+		}
+		req.Marker = resp.NextMarker
+	}
+	v.accountLimitsCache.Set(accountLimitsCacheKey, limits, v.accountLimitsCacheTTL)
+	return limits, nil
+}
+
+// fetchAccountTargetGroupsCount returns the count of existing targetGroups within account.
+func (v *defaultQuotaValidator) fetchAccountTargetGroupsCount(ctx context.Context) (int, error) {
+	v.accountResourceCountsCacheMutex.Lock()
+	defer v.accountResourceCountsCacheMutex.Unlock()
+
+	if rawCacheItem, exists := v.accountResourceCountsCache.Get(accountTargetGroupsCountCacheKey); exists {
+		return rawCacheItem.(int), nil
+	}
+	sdkTGs, err := v.elbv2Client.DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{})
+	if err != nil {
+		return 0, err
+	}
+	v.accountResourceCountsCache.Set(accountTargetGroupsCountCacheKey, len(sdkTGs), v.accountResourceCountsCacheTTL)
+	return len(sdkTGs), nil
+}
+
+// fetchAccountLoadBalancersCountByType returns the count of existing loadBalancers within account by loadBalancer type.
+func (v *defaultQuotaValidator) fetchAccountLoadBalancersCountByType(ctx context.Context) (map[elbv2model.LoadBalancerType]int, error) {
+	v.accountResourceCountsCacheMutex.Lock()
+	defer v.accountResourceCountsCacheMutex.Unlock()
+
+	if rawCacheItem, exists := v.accountResourceCountsCache.Get(accountLoadBalancersCountCacheKey); exists {
+		return rawCacheItem.(map[elbv2model.LoadBalancerType]int), nil
+	}
+	sdkLBs, err := v.elbv2Client.DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{})
+	if err != nil {
+		return nil, err
+	}
+	countByType := make(map[elbv2model.LoadBalancerType]int)
+	for _, sdkLB := range sdkLBs {
+		countByType[elbv2model.LoadBalancerType(awssdk.StringValue(sdkLB.Type))]++
+	}
+	v.accountResourceCountsCache.Set(accountLoadBalancersCountCacheKey, countByType, v.accountResourceCountsCacheTTL)
+	return countByType, nil
+}
+
+// validateResourceCountWithinLimit checks the resource count doesn't exceed limit, limits that are unknown are ignored.
+func validateResourceCountWithinLimit(limits map[string]int64, limitName string, count int, resourceDesc string) error {
+	limitMax, exists := limits[limitName]
+	if !exists || int64(count) <= limitMax {
+		return nil
+	}
+	return errors.Errorf("%v %v exceeds the quota %v of %v", count, resourceDesc, limitMax, limitName)
+}
+
+// findDependentLoadBalancer returns the LoadBalancer within stack referenced by lbARN token, or nil if it references an existing LoadBalancer.
+func findDependentLoadBalancer(lbARN core.StringToken) *elbv2model.LoadBalancer {
+	for _, dep := range lbARN.Dependencies() {
+		if resLB, ok := dep.(*elbv2model.LoadBalancer); ok {
+			return resLB
+		}
+	}
+	return nil
+}
+
+// findDependentListener returns the Listener within stack referenced by lsARN token, or nil if it references an existing Listener.
+func findDependentListener(lsARN core.StringToken) *elbv2model.Listener {
+	for _, dep := range lsARN.Dependencies() {
+		if resLS, ok := dep.(*elbv2model.Listener); ok {
+			return resLS
+		}
+	}
+	return nil
+}
@@ -0,0 +1,432 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"strconv"
+	"testing"
+)
+
+func Test_defaultQuotaValidator_Validate(t *testing.T) {
+	type describeAccountLimitsCall struct {
+		req  *elbv2sdk.DescribeAccountLimitsInput
+		resp *elbv2sdk.DescribeAccountLimitsOutput
+		err  error
+	}
+	type describeTargetGroupsAsListCall struct {
+		resp []*elbv2sdk.TargetGroup
+	}
+	type describeLoadBalancersAsListCall struct {
+		resp []*elbv2sdk.LoadBalancer
+	}
+	type describeTagsCall struct {
+		req  *elbv2sdk.DescribeTagsInput
+		resp *elbv2sdk.DescribeTagsOutput
+	}
+	type stackShape struct {
+		lbType           elbv2model.LoadBalancerType
+		listenerCount    int
+		rulesPerListener int
+		tgCount          int
+	}
+	accountLimits := &elbv2sdk.DescribeAccountLimitsOutput{
+		Limits: []*elbv2sdk.Limit{
+			{Name: awssdk.String("target-groups"), Max: awssdk.String("3")},
+			{Name: awssdk.String("listeners-per-application-load-balancer"), Max: awssdk.String("2")},
+			{Name: awssdk.String("listeners-per-network-load-balancer"), Max: awssdk.String("3")},
+			{Name: awssdk.String("rules-per-application-load-balancer"), Max: awssdk.String("4")},
+			{Name: awssdk.String("network-load-balancers"), Max: awssdk.String("1")},
+		},
+	}
+	stackTags := []*elbv2sdk.Tag{
+		{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+		{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("namespace/name")},
+	}
+	tests := []struct {
+		name                             string
+		describeAccountLimitsCalls       []describeAccountLimitsCall
+		describeTargetGroupsAsListCalls  []describeTargetGroupsAsListCall
+		describeLoadBalancersAsListCalls []describeLoadBalancersAsListCall
+		describeTagsCalls                []describeTagsCall
+		shape                            stackShape
+		wantErr                          error
+	}{
+		{
+			name: "application loadBalancer within quotas",
+			describeAccountLimitsCalls: []describeAccountLimitsCall{
+				{
+					req:  &elbv2sdk.DescribeAccountLimitsInput{},
+					resp: accountLimits,
+				},
+			},
+			describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+				{
+					resp: nil,
+				},
+			},
+			describeLoadBalancersAsListCalls: []describeLoadBalancersAsListCall{
+				{
+					resp: nil,
+				},
+			},
+			shape: stackShape{
+				lbType:           elbv2model.LoadBalancerTypeApplication,
+				listenerCount:    2,
+				rulesPerListener: 2,
+				tgCount:          3,
+			},
+		},
+		{
+			name: "targetGroups exceeds quota",
+			describeAccountLimitsCalls: []describeAccountLimitsCall{
+				{
+					req:  &elbv2sdk.DescribeAccountLimitsInput{},
+					resp: accountLimits,
+				},
+			},
+			describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+				{
+					resp: nil,
+				},
+				{
+					resp: nil,
+				},
+			},
+			shape: stackShape{
+				lbType:        elbv2model.LoadBalancerTypeApplication,
+				listenerCount: 1,
+				tgCount:       4,
+			},
+			wantErr: errors.New("4 targetGroups (4 within stack and 0 outside stack) exceeds the quota 3 of target-groups"),
+		},
+		{
+			name: "targetGroups within stack together with existing ones outside stack exceeds quota",
+			describeAccountLimitsCalls: []describeAccountLimitsCall{
+				{
+					req:  &elbv2sdk.DescribeAccountLimitsInput{},
+					resp: accountLimits,
+				},
+			},
+			describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+				{
+					resp: []*elbv2sdk.TargetGroup{
+						{TargetGroupArn: awssdk.String("tg-1")},
+						{TargetGroupArn: awssdk.String("tg-2")},
+						{TargetGroupArn: awssdk.String("tg-3")},
+					},
+				},
+				{
+					resp: []*elbv2sdk.TargetGroup{
+						{TargetGroupArn: awssdk.String("tg-1")},
+						{TargetGroupArn: awssdk.String("tg-2")},
+						{TargetGroupArn: awssdk.String("tg-3")},
+					},
+				},
+			},
+			describeTagsCalls: []describeTagsCall{
+				{
+					req: &elbv2sdk.DescribeTagsInput{ResourceArns: awssdk.StringSlice([]string{"tg-1", "tg-2", "tg-3"})},
+					resp: &elbv2sdk.DescribeTagsOutput{
+						TagDescriptions: []*elbv2sdk.TagDescription{
+							{ResourceArn: awssdk.String("tg-1"), Tags: stackTags},
+							{ResourceArn: awssdk.String("tg-2")},
+							{ResourceArn: awssdk.String("tg-3")},
+						},
+					},
+				},
+			},
+			shape: stackShape{
+				tgCount: 2,
+			},
+			wantErr: errors.New("4 targetGroups (2 within stack and 2 outside stack) exceeds the quota 3 of target-groups"),
+		},
+		{
+			name: "existing targetGroups of stack are reused within quota",
+			describeAccountLimitsCalls: []describeAccountLimitsCall{
+				{
+					req:  &elbv2sdk.DescribeAccountLimitsInput{},
+					resp: accountLimits,
+				},
+			},
+			describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+				{
+					resp: []*elbv2sdk.TargetGroup{
+						{TargetGroupArn: awssdk.String("tg-1")},
+						{TargetGroupArn: awssdk.String("tg-2")},
+					},
+				},
+				{
+					resp: []*elbv2sdk.TargetGroup{
+						{TargetGroupArn: awssdk.String("tg-1")},
+						{TargetGroupArn: awssdk.String("tg-2")},
+					},
+				},
+			},
+			describeTagsCalls: []describeTagsCall{
+				{
+					req: &elbv2sdk.DescribeTagsInput{ResourceArns: awssdk.StringSlice([]string{"tg-1", "tg-2"})},
+					resp: &elbv2sdk.DescribeTagsOutput{
+						TagDescriptions: []*elbv2sdk.TagDescription{
+							{ResourceArn: awssdk.String("tg-1"), Tags: stackTags},
+							{ResourceArn: awssdk.String("tg-2"), Tags: stackTags},
+						},
+					},
+				},
+			},
+			shape: stackShape{
+				tgCount: 3,
+			},
+		},
+		{
+			name: "network loadBalancers within stack together with existing ones outside stack exceeds quota",
+			describeAccountLimitsCalls: []describeAccountLimitsCall{
+				{
+					req:  &elbv2sdk.DescribeAccountLimitsInput{},
+					resp: accountLimits,
+				},
+			},
+			describeLoadBalancersAsListCalls: []describeLoadBalancersAsListCall{
+				{
+					resp: []*elbv2sdk.LoadBalancer{
+						{LoadBalancerArn: awssdk.String("lb-1"), Type: awssdk.String("network")},
+						{LoadBalancerArn: awssdk.String("lb-2"), Type: awssdk.String("application")},
+					},
+				},
+				{
+					resp: []*elbv2sdk.LoadBalancer{
+						{LoadBalancerArn: awssdk.String("lb-1"), Type: awssdk.String("network")},
+						{LoadBalancerArn: awssdk.String("lb-2"), Type: awssdk.String("application")},
+					},
+				},
+			},
+			describeTagsCalls: []describeTagsCall{
+				{
+					req: &elbv2sdk.DescribeTagsInput{ResourceArns: awssdk.StringSlice([]string{"lb-1", "lb-2"})},
+					resp: &elbv2sdk.DescribeTagsOutput{
+						TagDescriptions: []*elbv2sdk.TagDescription{
+							{ResourceArn: awssdk.String("lb-1")},
+							{ResourceArn: awssdk.String("lb-2")},
+						},
+					},
+				},
+			},
+			shape: stackShape{
+				lbType:        elbv2model.LoadBalancerTypeNetwork,
+				listenerCount: 1,
+			},
+			wantErr: errors.New("2 network loadBalancers (1 within stack and 1 outside stack) exceeds the quota 1 of network-load-balancers"),
+		},
+		{
+			name: "listeners of application loadBalancer exceeds quota",
+			describeAccountLimitsCalls: []describeAccountLimitsCall{
+				{
+					req:  &elbv2sdk.DescribeAccountLimitsInput{},
+					resp: accountLimits,
+				},
+			},
+			describeLoadBalancersAsListCalls: []describeLoadBalancersAsListCall{
+				{
+					resp: nil,
+				},
+			},
+			shape: stackShape{
+				lbType:        elbv2model.LoadBalancerTypeApplication,
+				listenerCount: 3,
+			},
+			wantErr: errors.New("3 listeners on loadBalancer LoadBalancer exceeds the quota 2 of listeners-per-application-load-balancer"),
+		},
+		{
+			name: "listeners of network loadBalancer within quota",
+			describeAccountLimitsCalls: []describeAccountLimitsCall{
+				{
+					req:  &elbv2sdk.DescribeAccountLimitsInput{},
+					resp: accountLimits,
+				},
+			},
+			describeLoadBalancersAsListCalls: []describeLoadBalancersAsListCall{
+				{
+					resp: nil,
+				},
+			},
+			shape: stackShape{
+				lbType:        elbv2model.LoadBalancerTypeNetwork,
+				listenerCount: 3,
+			},
+		},
+		{
+			name: "listenerRules of application loadBalancer exceeds quota",
+			describeAccountLimitsCalls: []describeAccountLimitsCall{
+				{
+					req:  &elbv2sdk.DescribeAccountLimitsInput{},
+					resp: accountLimits,
+				},
+			},
+			describeLoadBalancersAsListCalls: []describeLoadBalancersAsListCall{
+				{
+					resp: nil,
+				},
+			},
+			shape: stackShape{
+				lbType:           elbv2model.LoadBalancerTypeApplication,
+				listenerCount:    2,
+				rulesPerListener: 3,
+			},
+			wantErr: errors.New("6 listenerRules on loadBalancer LoadBalancer exceeds the quota 4 of rules-per-application-load-balancer"),
+		},
+		{
+			name: "account limits spans multiple pages",
+			describeAccountLimitsCalls: []describeAccountLimitsCall{
+				{
+					req: &elbv2sdk.DescribeAccountLimitsInput{},
+					resp: &elbv2sdk.DescribeAccountLimitsOutput{
+						Limits: []*elbv2sdk.Limit{
+							{Name: awssdk.String("target-groups"), Max: awssdk.String("3")},
+						},
+						NextMarker: awssdk.String("marker"),
+					},
+				},
+				{
+					req: &elbv2sdk.DescribeAccountLimitsInput{Marker: awssdk.String("marker")},
+					resp: &elbv2sdk.DescribeAccountLimitsOutput{
+						Limits: []*elbv2sdk.Limit{
+							{Name: awssdk.String("listeners-per-application-load-balancer"), Max: awssdk.String("2")},
+						},
+					},
+				},
+			},
+			describeLoadBalancersAsListCalls: []describeLoadBalancersAsListCall{
+				{
+					resp: nil,
+				},
+			},
+			shape: stackShape{
+				lbType:        elbv2model.LoadBalancerTypeApplication,
+				listenerCount: 3,
+			},
+			wantErr: errors.New("3 listeners on loadBalancer LoadBalancer exceeds the quota 2 of listeners-per-application-load-balancer"),
+		},
+		{
+			name: "validation skipped when account limits unavailable",
+			describeAccountLimitsCalls: []describeAccountLimitsCall{
+				{
+					req: &elbv2sdk.DescribeAccountLimitsInput{},
+					err: errors.New("AccessDenied"),
+				},
+			},
+			shape: stackShape{
+				lbType:        elbv2model.LoadBalancerTypeApplication,
+				listenerCount: 3,
+				tgCount:       4,
+			},
+		},
+		{
+			name:  "validation skipped for stack without resources",
+			shape: stackShape{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeAccountLimitsCalls {
+				elbv2Client.EXPECT().DescribeAccountLimitsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.describeTargetGroupsAsListCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{}).Return(call.resp, nil)
+			}
+			for _, call := range tt.describeLoadBalancersAsListCalls {
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), &elbv2sdk.DescribeLoadBalancersInput{}).Return(call.resp, nil)
+			}
+			for _, call := range tt.describeTagsCalls {
+				elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			if tt.shape.listenerCount != 0 {
+				lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{Type: tt.shape.lbType})
+				for i := 0; i < tt.shape.listenerCount; i++ {
+					ls := elbv2model.NewListener(stack, "Listener-"+strconv.Itoa(i), elbv2model.ListenerSpec{
+						LoadBalancerARN: lb.LoadBalancerARN(),
+					})
+					for j := 0; j < tt.shape.rulesPerListener; j++ {
+						elbv2model.NewListenerRule(stack, ls.ID()+"-Rule-"+strconv.Itoa(j), elbv2model.ListenerRuleSpec{
+							ListenerARN: ls.ListenerARN(),
+						})
+					}
+				}
+			}
+			for i := 0; i < tt.shape.tgCount; i++ {
+				elbv2model.NewTargetGroup(stack, "TargetGroup-"+strconv.Itoa(i), elbv2model.TargetGroupSpec{})
+			}
+
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+			taggingManager := NewDefaultTaggingManager(elbv2Client, &log.NullLogger{})
+			v := NewDefaultQuotaValidator(elbv2Client, trackingProvider, taggingManager, &log.NullLogger{})
+			err := v.Validate(context.Background(), stack)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultQuotaValidator_fetchAccountLimits_cached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	elbv2Client := mock_services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeAccountLimitsWithContext(gomock.Any(), &elbv2sdk.DescribeAccountLimitsInput{}).Return(&elbv2sdk.DescribeAccountLimitsOutput{
+		Limits: []*elbv2sdk.Limit{
+			{Name: awssdk.String("target-groups"), Max: awssdk.String("3000")},
+		},
+	}, nil).Times(1)
+
+	v := NewDefaultQuotaValidator(elbv2Client, nil, nil, &log.NullLogger{})
+	for i := 0; i < 2; i++ {
+		got, err := v.fetchAccountLimits(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{"target-groups": 3000}, got)
+	}
+}
+
+func Test_defaultQuotaValidator_fetchAccountResourceCounts_cached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	elbv2Client := mock_services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{}).Return([]*elbv2sdk.TargetGroup{
+		{TargetGroupArn: awssdk.String("tg-1")},
+		{TargetGroupArn: awssdk.String("tg-2")},
+	}, nil).Times(1)
+	elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), &elbv2sdk.DescribeLoadBalancersInput{}).Return([]*elbv2sdk.LoadBalancer{
+		{LoadBalancerArn: awssdk.String("lb-1"), Type: awssdk.String("application")},
+		{LoadBalancerArn: awssdk.String("lb-2"), Type: awssdk.String("network")},
+		{LoadBalancerArn: awssdk.String("lb-3"), Type: awssdk.String("network")},
+	}, nil).Times(1)
+
+	v := NewDefaultQuotaValidator(elbv2Client, nil, nil, &log.NullLogger{})
+	for i := 0; i < 2; i++ {
+		gotTGsCount, err := v.fetchAccountTargetGroupsCount(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, gotTGsCount)
+		gotLBsCountByType, err := v.fetchAccountLoadBalancersCountByType(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, map[elbv2model.LoadBalancerType]int{
+			elbv2model.LoadBalancerTypeApplication: 1,
+			elbv2model.LoadBalancerTypeNetwork:     2,
+		}, gotLBsCountByType)
+	}
+}
@@ -3,6 +3,7 @@ package elbv2
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -22,6 +23,40 @@ type LoadBalancerWithTags struct {
 	Tags         map[string]string
 }
 
+// ExtractLoadBalancerSubnetIDs returns the subnetIDs of LoadBalancer, nil is returned if LoadBalancer is nil.
+func ExtractLoadBalancerSubnetIDs(sdkLB *LoadBalancerWithTags) []string {
+	if sdkLB == nil || sdkLB.LoadBalancer == nil {
+		return nil
+	}
+	subnetIDs := make([]string, 0, len(sdkLB.LoadBalancer.AvailabilityZones))
+	for _, az := range sdkLB.LoadBalancer.AvailabilityZones {
+		subnetIDs = append(subnetIDs, awssdk.StringValue(az.SubnetId))
+	}
+	return subnetIDs
+}
+
+// FilterSubnetsWithinLoadBalancerAZs returns the subnets within the availabilityZones of LoadBalancer.
+// subnets are returned as is if LoadBalancer is nil or none of them is within its availabilityZones.
+func FilterSubnetsWithinLoadBalancerAZs(subnets []*ec2sdk.Subnet, sdkLB *LoadBalancerWithTags) []*ec2sdk.Subnet {
+	if sdkLB == nil || sdkLB.LoadBalancer == nil {
+		return subnets
+	}
+	lbAZs := sets.NewString()
+	for _, az := range sdkLB.LoadBalancer.AvailabilityZones {
+		lbAZs.Insert(awssdk.StringValue(az.ZoneName))
+	}
+	var subnetsWithinLBAZs []*ec2sdk.Subnet
+	for _, subnet := range subnets {
+		if lbAZs.Has(awssdk.StringValue(subnet.AvailabilityZone)) {
+			subnetsWithinLBAZs = append(subnetsWithinLBAZs, subnet)
+		}
+	}
+	if len(subnetsWithinLBAZs) == 0 {
+		return subnets
+	}
+	return subnetsWithinLBAZs
+}
+
 // TargetGroup with it's tags.
 type TargetGroupWithTags struct {
 	TargetGroup *elbv2sdk.TargetGroup
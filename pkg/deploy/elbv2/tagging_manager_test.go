@@ -3,6 +3,7 @@ package elbv2
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -1071,3 +1072,57 @@ func Test_convertSDKTagsToTags(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterSubnetsWithinLoadBalancerAZs(t *testing.T) {
+	subnetA := &ec2sdk.Subnet{SubnetId: awssdk.String("subnet-a"), AvailabilityZone: awssdk.String("us-west-2a")}
+	subnetB := &ec2sdk.Subnet{SubnetId: awssdk.String("subnet-b"), AvailabilityZone: awssdk.String("us-west-2b")}
+	subnetC := &ec2sdk.Subnet{SubnetId: awssdk.String("subnet-c"), AvailabilityZone: awssdk.String("us-west-2c")}
+	sdkLBInTwoAZs := &LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+				{SubnetId: awssdk.String("subnet-a"), ZoneName: awssdk.String("us-west-2a")},
+				{SubnetId: awssdk.String("subnet-b"), ZoneName: awssdk.String("us-west-2b")},
+			},
+		},
+	}
+	type args struct {
+		subnets []*ec2sdk.Subnet
+		sdkLB   *LoadBalancerWithTags
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*ec2sdk.Subnet
+	}{
+		{
+			name: "subnets in new availabilityZones are dropped",
+			args: args{
+				subnets: []*ec2sdk.Subnet{subnetA, subnetB, subnetC},
+				sdkLB:   sdkLBInTwoAZs,
+			},
+			want: []*ec2sdk.Subnet{subnetA, subnetB},
+		},
+		{
+			name: "subnets are returned as is if none of them is within availabilityZones of LoadBalancer",
+			args: args{
+				subnets: []*ec2sdk.Subnet{subnetC},
+				sdkLB:   sdkLBInTwoAZs,
+			},
+			want: []*ec2sdk.Subnet{subnetC},
+		},
+		{
+			name: "subnets are returned as is if there is no LoadBalancer",
+			args: args{
+				subnets: []*ec2sdk.Subnet{subnetA, subnetB, subnetC},
+				sdkLB:   nil,
+			},
+			want: []*ec2sdk.Subnet{subnetA, subnetB, subnetC},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterSubnetsWithinLoadBalancerAZs(tt.args.subnets, tt.args.sdkLB)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
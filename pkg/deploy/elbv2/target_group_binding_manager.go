@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
@@ -68,12 +69,12 @@ func (m *defaultTargetGroupBindingManager) Create(ctx context.Context, resTGB *e
 		return elbv2model.TargetGroupBindingResourceStatus{}, err
 	}
 
-	stackLabels := m.trackingProvider.StackLabels(resTGB.Stack())
+	tgbLabels := m.buildK8sTargetGroupBindingLabels(resTGB)
 	k8sTGB := &elbv2api.TargetGroupBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: resTGB.Spec.Template.Namespace,
 			Name:      resTGB.Spec.Template.Name,
-			Labels:    stackLabels,
+			Labels:    tgbLabels,
 		},
 		Spec: k8sTGBSpec,
 	}
@@ -96,12 +97,15 @@ func (m *defaultTargetGroupBindingManager) Update(ctx context.Context, resTGB *e
 	if err != nil {
 		return elbv2model.TargetGroupBindingResourceStatus{}, err
 	}
-	if equality.Semantic.DeepEqual(k8sTGB.Spec, k8sTGBSpec) {
+	tgbLabels := m.buildK8sTargetGroupBindingLabels(resTGB)
+	labelsToUpdate, _ := algorithm.DiffStringMap(tgbLabels, k8sTGB.Labels)
+	if equality.Semantic.DeepEqual(k8sTGB.Spec, k8sTGBSpec) && len(labelsToUpdate) == 0 {
 		return buildResTargetGroupBindingStatus(k8sTGB), nil
 	}
 
 	oldK8sTGB := k8sTGB.DeepCopy()
 	k8sTGB.Spec = k8sTGBSpec
+	k8sTGB.Labels = algorithm.MergeStringMap(labelsToUpdate, k8sTGB.Labels)
 	m.logger.Info("modifying targetGroupBinding",
 		"stackID", resTGB.Stack().StackID(),
 		"resourceID", resTGB.ID(),
@@ -166,6 +170,12 @@ func (m *defaultTargetGroupBindingManager) waitUntilTargetGroupBindingDeleted(ct
 	}, ctx.Done())
 }
 
+// buildK8sTargetGroupBindingLabels builds the labels for TargetGroupBinding, which consists of stack labels and labels from template.
+func (m *defaultTargetGroupBindingManager) buildK8sTargetGroupBindingLabels(resTGB *elbv2model.TargetGroupBindingResource) map[string]string {
+	stackLabels := m.trackingProvider.StackLabels(resTGB.Stack())
+	return algorithm.MergeStringMap(stackLabels, resTGB.Spec.Template.Labels)
+}
+
 func buildK8sTargetGroupBindingSpec(ctx context.Context, resTGB *elbv2model.TargetGroupBindingResource) (elbv2api.TargetGroupBindingSpec, error) {
 	tgARN, err := resTGB.Spec.Template.Spec.TargetGroupARN.Resolve(ctx)
 	if err != nil {
@@ -226,7 +236,12 @@ func buildK8sNetworkingPeer(ctx context.Context, resNetworkingPeer elbv2model.Ne
 			},
 		}, nil
 	}
-	return elbv2api.NetworkingPeer{}, errors.New("either ipBlock or securityGroup should be specified")
+	if resNetworkingPeer.PrefixList != nil {
+		return elbv2api.NetworkingPeer{
+			PrefixList: resNetworkingPeer.PrefixList,
+		}, nil
+	}
+	return elbv2api.NetworkingPeer{}, errors.New("either ipBlock, securityGroup or prefixList should be specified")
 }
 
 func buildResTargetGroupBindingStatus(k8sTGB *elbv2api.TargetGroupBinding) elbv2model.TargetGroupBindingResourceStatus {
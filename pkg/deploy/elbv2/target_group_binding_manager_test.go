@@ -0,0 +1,217 @@
+package elbv2
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_defaultTargetGroupBindingManager_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		templateLabels map[string]string
+		wantLabels     map[string]string
+	}{
+		{
+			name: "template labels should be applied along with stack labels",
+			templateLabels: map[string]string{
+				"ingress.k8s.aws/ingress-name": "ing-1",
+				"ingress.k8s.aws/service-name": "svc-1",
+				"ingress.k8s.aws/service-port": "http",
+			},
+			wantLabels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "ns-1",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+				"ingress.k8s.aws/ingress-name":    "ing-1",
+				"ingress.k8s.aws/service-name":    "svc-1",
+				"ingress.k8s.aws/service-port":    "http",
+			},
+		},
+		{
+			name:           "stack labels should be applied without template labels",
+			templateLabels: nil,
+			wantLabels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "ns-1",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+			},
+		},
+		{
+			name: "stack labels should take precedence over template labels",
+			templateLabels: map[string]string{
+				"ingress.k8s.aws/stack-name": "ing-2",
+			},
+			wantLabels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "ns-1",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := newFakeClientForTargetGroupBindingManagerTest()
+			m := NewDefaultTargetGroupBindingManager(k8sClient, tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name"), &log.NullLogger{})
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "ns-1", Name: "ing-1"})
+			resTGB := newTargetGroupBindingResourceForTest(stack, tt.templateLabels)
+
+			ctx := context.Background()
+			_, err := m.Create(ctx, resTGB)
+			assert.NoError(t, err)
+
+			gotTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "ns-1", Name: "tgb-1"}, gotTGB))
+			assert.Equal(t, tt.wantLabels, gotTGB.Labels)
+		})
+	}
+}
+
+func Test_defaultTargetGroupBindingManager_Update(t *testing.T) {
+	tests := []struct {
+		name           string
+		existingLabels map[string]string
+		templateLabels map[string]string
+		wantLabels     map[string]string
+	}{
+		{
+			name: "missing template labels should be added",
+			existingLabels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "ns-1",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+			},
+			templateLabels: map[string]string{
+				"ingress.k8s.aws/ingress-name": "ing-1",
+				"ingress.k8s.aws/service-name": "svc-1",
+				"ingress.k8s.aws/service-port": "http",
+			},
+			wantLabels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "ns-1",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+				"ingress.k8s.aws/ingress-name":    "ing-1",
+				"ingress.k8s.aws/service-name":    "svc-1",
+				"ingress.k8s.aws/service-port":    "http",
+			},
+		},
+		{
+			name: "drifted template labels should be updated and other labels should be preserved",
+			existingLabels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "ns-1",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+				"ingress.k8s.aws/ingress-name":    "ing-1",
+				"ingress.k8s.aws/service-name":    "svc-1",
+				"ingress.k8s.aws/service-port":    "80",
+				"some-key":                        "some-value",
+			},
+			templateLabels: map[string]string{
+				"ingress.k8s.aws/ingress-name": "ing-1",
+				"ingress.k8s.aws/service-name": "svc-1",
+				"ingress.k8s.aws/service-port": "http",
+			},
+			wantLabels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "ns-1",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+				"ingress.k8s.aws/ingress-name":    "ing-1",
+				"ingress.k8s.aws/service-name":    "svc-1",
+				"ingress.k8s.aws/service-port":    "http",
+				"some-key":                        "some-value",
+			},
+		},
+		{
+			name: "labels already in sync",
+			existingLabels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "ns-1",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+				"ingress.k8s.aws/ingress-name":    "ing-1",
+				"ingress.k8s.aws/service-name":    "svc-1",
+				"ingress.k8s.aws/service-port":    "http",
+			},
+			templateLabels: map[string]string{
+				"ingress.k8s.aws/ingress-name": "ing-1",
+				"ingress.k8s.aws/service-name": "svc-1",
+				"ingress.k8s.aws/service-port": "http",
+			},
+			wantLabels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "ns-1",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+				"ingress.k8s.aws/ingress-name":    "ing-1",
+				"ingress.k8s.aws/service-name":    "svc-1",
+				"ingress.k8s.aws/service-port":    "http",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := newFakeClientForTargetGroupBindingManagerTest()
+			m := NewDefaultTargetGroupBindingManager(k8sClient, tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name"), &log.NullLogger{})
+			m.waitTGBObservedPollInterval = 10 * time.Millisecond
+			m.waitTGBObservedTimout = 1 * time.Second
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "ns-1", Name: "ing-1"})
+			resTGB := newTargetGroupBindingResourceForTest(stack, tt.templateLabels)
+
+			ctx := context.Background()
+			targetType := elbv2api.TargetTypeInstance
+			k8sTGB := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "tgb-1",
+					Labels:    tt.existingLabels,
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1/abcdef",
+					TargetType:     &targetType,
+					ServiceRef: elbv2api.ServiceReference{
+						Name: "svc-1",
+						Port: intstr.FromString("http"),
+					},
+				},
+			}
+			assert.NoError(t, k8sClient.Create(ctx, k8sTGB))
+
+			_, err := m.Update(ctx, resTGB, k8sTGB)
+			assert.NoError(t, err)
+
+			gotTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "ns-1", Name: "tgb-1"}, gotTGB))
+			assert.Equal(t, tt.wantLabels, gotTGB.Labels)
+		})
+	}
+}
+
+func newFakeClientForTargetGroupBindingManagerTest() client.Client {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	return testclient.NewFakeClientWithScheme(k8sSchema)
+}
+
+func newTargetGroupBindingResourceForTest(stack coremodel.Stack, templateLabels map[string]string) *elbv2model.TargetGroupBindingResource {
+	targetType := elbv2api.TargetTypeInstance
+	return elbv2model.NewTargetGroupBindingResource(stack, "ns-1/ing-1-svc-1:http", elbv2model.TargetGroupBindingResourceSpec{
+		Template: elbv2model.TargetGroupBindingTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-1",
+				Name:      "tgb-1",
+				Labels:    templateLabels,
+			},
+			Spec: elbv2model.TargetGroupBindingSpec{
+				TargetGroupARN: coremodel.LiteralStringToken("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1/abcdef"),
+				TargetType:     &targetType,
+				ServiceRef: elbv2api.ServiceReference{
+					Name: "svc-1",
+					Port: intstr.FromString("http"),
+				},
+			},
+		},
+	})
+}
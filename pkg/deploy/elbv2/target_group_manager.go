@@ -62,7 +62,9 @@ type defaultTargetGroupManager struct {
 
 func (m *defaultTargetGroupManager) Create(ctx context.Context, resTG *elbv2model.TargetGroup) (elbv2model.TargetGroupStatus, error) {
 	req := buildSDKCreateTargetGroupInput(resTG.Spec)
-	req.VpcId = awssdk.String(m.vpcID)
+	if resTG.Spec.TargetType != elbv2model.TargetTypeLambda {
+		req.VpcId = awssdk.String(m.vpcID)
+	}
 	tgTags := m.trackingProvider.ResourceTags(resTG.Stack(), resTG, resTG.Spec.Tags)
 	req.Tags = convertTagsToSDKTags(tgTags)
 
@@ -84,6 +86,9 @@ func (m *defaultTargetGroupManager) Create(ctx context.Context, resTG *elbv2mode
 	if err := m.attributesReconciler.Reconcile(ctx, resTG, sdkTG); err != nil {
 		return elbv2model.TargetGroupStatus{}, err
 	}
+	if err := m.updateSDKTargetGroupWithLambdaTarget(ctx, resTG, sdkTG); err != nil {
+		return elbv2model.TargetGroupStatus{}, err
+	}
 
 	return buildResTargetGroupStatus(sdkTG), nil
 }
@@ -98,6 +103,9 @@ func (m *defaultTargetGroupManager) Update(ctx context.Context, resTG *elbv2mode
 	if err := m.attributesReconciler.Reconcile(ctx, resTG, sdkTG); err != nil {
 		return elbv2model.TargetGroupStatus{}, err
 	}
+	if err := m.updateSDKTargetGroupWithLambdaTarget(ctx, resTG, sdkTG); err != nil {
+		return elbv2model.TargetGroupStatus{}, err
+	}
 
 	return buildResTargetGroupStatus(sdkTG), nil
 }
@@ -113,6 +121,12 @@ func (m *defaultTargetGroupManager) Delete(ctx context.Context, sdkTG TargetGrou
 		_, err := m.elbv2Client.DeleteTargetGroupWithContext(ctx, req)
 		return err
 	}); err != nil {
+		// the targetGroup might have been deleted together with the LoadBalancer it's attached to.
+		if isTargetGroupNotFoundError(err) {
+			m.logger.Info("targetGroup already deleted",
+				"arn", awssdk.StringValue(req.TargetGroupArn))
+			return nil
+		}
 		return errors.Wrap(err, "failed to delete targetGroup")
 	}
 	m.logger.Info("deleted targetGroup",
@@ -143,6 +157,64 @@ func (m *defaultTargetGroupManager) updateSDKTargetGroupWithHealthCheck(ctx cont
 	return nil
 }
 
+func (m *defaultTargetGroupManager) updateSDKTargetGroupWithLambdaTarget(ctx context.Context, resTG *elbv2model.TargetGroup, sdkTG TargetGroupWithTags) error {
+	if resTG.Spec.LambdaFunctionARN == nil {
+		return nil
+	}
+	if resTG.Spec.TargetType != elbv2model.TargetTypeLambda {
+		return errors.Errorf("lambda function can only be registered into targetGroup with targetType %v: %v",
+			elbv2model.TargetTypeLambda, resTG.Spec.TargetType)
+	}
+	desiredLambdaARN := awssdk.StringValue(resTG.Spec.LambdaFunctionARN)
+	resp, err := m.elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: sdkTG.TargetGroup.TargetGroupArn,
+	})
+	if err != nil {
+		return err
+	}
+	var unmatchedTargets []*elbv2sdk.TargetDescription
+	for _, elem := range resp.TargetHealthDescriptions {
+		if awssdk.StringValue(elem.Target.Id) == desiredLambdaARN {
+			return nil
+		}
+		unmatchedTargets = append(unmatchedTargets, &elbv2sdk.TargetDescription{Id: elem.Target.Id})
+	}
+	if len(unmatchedTargets) != 0 {
+		m.logger.Info("deRegistering lambda targets",
+			"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+			"targets", unmatchedTargets)
+		if _, err := m.elbv2Client.DeregisterTargetsWithContext(ctx, &elbv2sdk.DeregisterTargetsInput{
+			TargetGroupArn: sdkTG.TargetGroup.TargetGroupArn,
+			Targets:        unmatchedTargets,
+		}); err != nil {
+			return err
+		}
+		m.logger.Info("deRegistered lambda targets",
+			"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn))
+	}
+
+	m.logger.Info("registering lambda target",
+		"stackID", resTG.Stack().StackID(),
+		"resourceID", resTG.ID(),
+		"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+		"lambdaFunctionARN", desiredLambdaARN)
+	if _, err := m.elbv2Client.RegisterTargetsWithContext(ctx, &elbv2sdk.RegisterTargetsInput{
+		TargetGroupArn: sdkTG.TargetGroup.TargetGroupArn,
+		Targets: []*elbv2sdk.TargetDescription{
+			{
+				Id: awssdk.String(desiredLambdaARN),
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	m.logger.Info("registered lambda target",
+		"stackID", resTG.Stack().StackID(),
+		"resourceID", resTG.ID(),
+		"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn))
+	return nil
+}
+
 func (m *defaultTargetGroupManager) updateSDKTargetGroupWithTags(ctx context.Context, resTG *elbv2model.TargetGroup, sdkTG TargetGroupWithTags) error {
 	desiredTGTags := m.trackingProvider.ResourceTags(resTG.Stack(), resTG, resTG.Spec.Tags)
 	return m.taggingManager.ReconcileTags(ctx, awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn), desiredTGTags,
@@ -187,10 +259,14 @@ func buildSDKCreateTargetGroupInput(tgSpec elbv2model.TargetGroupSpec) *elbv2sdk
 	sdkObj := &elbv2sdk.CreateTargetGroupInput{}
 	sdkObj.Name = awssdk.String(tgSpec.Name)
 	sdkObj.TargetType = awssdk.String(string(tgSpec.TargetType))
-	sdkObj.Port = awssdk.Int64(tgSpec.Port)
-	sdkObj.Protocol = awssdk.String(string(tgSpec.Protocol))
-	if tgSpec.ProtocolVersion != nil {
-		sdkObj.ProtocolVersion = (*string)(tgSpec.ProtocolVersion)
+	sdkObj.IpAddressType = (*string)(tgSpec.IPAddressType)
+	// port and protocol are not applicable for lambda targetGroups.
+	if tgSpec.TargetType != elbv2model.TargetTypeLambda {
+		sdkObj.Port = awssdk.Int64(tgSpec.Port)
+		sdkObj.Protocol = awssdk.String(string(tgSpec.Protocol))
+		if tgSpec.ProtocolVersion != nil {
+			sdkObj.ProtocolVersion = (*string)(tgSpec.ProtocolVersion)
+		}
 	}
 	if tgSpec.HealthCheckConfig != nil {
 		hcConfig := *tgSpec.HealthCheckConfig
@@ -253,3 +329,11 @@ func isTargetGroupResourceInUseError(err error) bool {
 	}
 	return false
 }
+
+func isTargetGroupNotFoundError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == elbv2sdk.ErrCodeTargetGroupNotFoundException
+	}
+	return false
+}
@@ -1,13 +1,18 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -382,10 +387,189 @@ func Test_isSDKTargetGroupHealthCheckDrifted(t *testing.T) {
 	}
 }
 
+func Test_defaultTargetGroupManager_updateSDKTargetGroupWithLambdaTarget(t *testing.T) {
+	type describeTargetHealthWithContextCall struct {
+		req  *elbv2sdk.DescribeTargetHealthInput
+		resp *elbv2sdk.DescribeTargetHealthOutput
+		err  error
+	}
+	type deregisterTargetsWithContextCall struct {
+		req  *elbv2sdk.DeregisterTargetsInput
+		resp *elbv2sdk.DeregisterTargetsOutput
+		err  error
+	}
+	type registerTargetsWithContextCall struct {
+		req  *elbv2sdk.RegisterTargetsInput
+		resp *elbv2sdk.RegisterTargetsOutput
+		err  error
+	}
+	type fields struct {
+		describeTargetHealthWithContextCalls []describeTargetHealthWithContextCall
+		deregisterTargetsWithContextCalls    []deregisterTargetsWithContextCall
+		registerTargetsWithContextCalls      []registerTargetsWithContextCall
+	}
+	type args struct {
+		tgSpec elbv2model.TargetGroupSpec
+		sdkTG  TargetGroupWithTags
+	}
+
+	lambdaARN := "arn:aws:lambda:us-west-2:123456789012:function:my-function"
+	sdkTG := TargetGroupWithTags{
+		TargetGroup: &elbv2sdk.TargetGroup{
+			TargetGroupArn: awssdk.String("my-tg-arn"),
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "no lambda function specified",
+			args: args{
+				tgSpec: elbv2model.TargetGroupSpec{
+					TargetType: elbv2model.TargetTypeIP,
+				},
+				sdkTG: sdkTG,
+			},
+		},
+		{
+			name: "lambda function specified on non-lambda targetGroup",
+			args: args{
+				tgSpec: elbv2model.TargetGroupSpec{
+					TargetType:        elbv2model.TargetTypeIP,
+					LambdaFunctionARN: awssdk.String(lambdaARN),
+				},
+				sdkTG: sdkTG,
+			},
+			wantErr: errors.New("lambda function can only be registered into targetGroup with targetType lambda: ip"),
+		},
+		{
+			name: "lambda function already registered",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-tg-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{
+							TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+								{Target: &elbv2sdk.TargetDescription{Id: awssdk.String(lambdaARN)}},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				tgSpec: elbv2model.TargetGroupSpec{
+					TargetType:        elbv2model.TargetTypeLambda,
+					LambdaFunctionARN: awssdk.String(lambdaARN),
+				},
+				sdkTG: sdkTG,
+			},
+		},
+		{
+			name: "lambda function not registered",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-tg-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{},
+					},
+				},
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg-arn"),
+							Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String(lambdaARN)}},
+						},
+						resp: &elbv2sdk.RegisterTargetsOutput{},
+					},
+				},
+			},
+			args: args{
+				tgSpec: elbv2model.TargetGroupSpec{
+					TargetType:        elbv2model.TargetTypeLambda,
+					LambdaFunctionARN: awssdk.String(lambdaARN),
+				},
+				sdkTG: sdkTG,
+			},
+		},
+		{
+			name: "another lambda function registered",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-tg-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{
+							TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+								{Target: &elbv2sdk.TargetDescription{Id: awssdk.String("arn:aws:lambda:us-west-2:123456789012:function:other-function")}},
+							},
+						},
+					},
+				},
+				deregisterTargetsWithContextCalls: []deregisterTargetsWithContextCall{
+					{
+						req: &elbv2sdk.DeregisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg-arn"),
+							Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String("arn:aws:lambda:us-west-2:123456789012:function:other-function")}},
+						},
+						resp: &elbv2sdk.DeregisterTargetsOutput{},
+					},
+				},
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg-arn"),
+							Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String(lambdaARN)}},
+						},
+						resp: &elbv2sdk.RegisterTargetsOutput{},
+					},
+				},
+			},
+			args: args{
+				tgSpec: elbv2model.TargetGroupSpec{
+					TargetType:        elbv2model.TargetTypeLambda,
+					LambdaFunctionARN: awssdk.String(lambdaARN),
+				},
+				sdkTG: sdkTG,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetHealthWithContextCalls {
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.deregisterTargetsWithContextCalls {
+				elbv2Client.EXPECT().DeregisterTargetsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.registerTargetsWithContextCalls {
+				elbv2Client.EXPECT().RegisterTargetsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := &defaultTargetGroupManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resTG := elbv2model.NewTargetGroup(stack, "id-1", tt.args.tgSpec)
+			err := m.updateSDKTargetGroupWithLambdaTarget(context.Background(), resTG, tt.args.sdkTG)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_buildSDKCreateTargetGroupInput(t *testing.T) {
 	port9090 := intstr.FromInt(9090)
 	protocolHTTP := elbv2model.ProtocolHTTP
 	protocolVersionHTTP2 := elbv2model.ProtocolVersionHTTP2
+	ipAddressTypeIPv6 := elbv2model.TargetGroupIPAddressTypeIPv6
 	type args struct {
 		tgSpec elbv2model.TargetGroupSpec
 	}
@@ -468,6 +652,40 @@ func Test_buildSDKCreateTargetGroupInput(t *testing.T) {
 				TargetType:                 awssdk.String("ip"),
 			},
 		},
+		{
+			name: "ipv6 targetGroup",
+			args: args{
+				tgSpec: elbv2model.TargetGroupSpec{
+					Name:          "my-tg",
+					TargetType:    elbv2model.TargetTypeIP,
+					Port:          8080,
+					Protocol:      elbv2model.ProtocolTCP,
+					IPAddressType: &ipAddressTypeIPv6,
+				},
+			},
+			want: &elbv2sdk.CreateTargetGroupInput{
+				IpAddressType: awssdk.String("ipv6"),
+				Name:          awssdk.String("my-tg"),
+				Port:          awssdk.Int64(8080),
+				Protocol:      awssdk.String("TCP"),
+				TargetType:    awssdk.String("ip"),
+			},
+		},
+		{
+			name: "lambda targetGroup",
+			args: args{
+				tgSpec: elbv2model.TargetGroupSpec{
+					Name:              "my-tg",
+					TargetType:        elbv2model.TargetTypeLambda,
+					ProtocolVersion:   &protocolVersionHTTP2,
+					LambdaFunctionARN: awssdk.String("arn:aws:lambda:us-west-2:123456789012:function:my-function"),
+				},
+			},
+			want: &elbv2sdk.CreateTargetGroupInput{
+				Name:       awssdk.String("my-tg"),
+				TargetType: awssdk.String("lambda"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -124,6 +124,9 @@ func matchResAndSDKTargetGroups(resTGs []*elbv2model.TargetGroup, sdkTGs []Targe
 			foundMatch = true
 		}
 		if !foundMatch {
+			if err := validateTargetGroupRecreation(resTG, sdkTGs); err != nil {
+				return nil, nil, nil, err
+			}
 			unmatchedResTGs = append(unmatchedResTGs, resTG)
 		}
 	}
@@ -157,21 +160,55 @@ func mapSDKTargetGroupByResourceID(sdkTGs []TargetGroupWithTags, resourceIDTagKe
 	return sdkTGsByID, nil
 }
 
+// validateTargetGroupRecreation validates that TargetGroup resource can be recreated when none of its sdk TargetGroups can fulfill it.
+// recreation due to changes of immutable fields must be confirmed explicitly, since the existing targets will be deregistered.
+func validateTargetGroupRecreation(resTG *elbv2model.TargetGroup, sdkTGs []TargetGroupWithTags) error {
+	if resTG.Spec.RecreationConfirmed {
+		return nil
+	}
+	for _, sdkTG := range sdkTGs {
+		if changedFields := findSDKTargetGroupImmutableFieldChanges(sdkTG, resTG); len(changedFields) != 0 {
+			return errors.Errorf("targetGroup %v requires recreation due to changes of immutable fields %v, recreation must be confirmed via target-group-recreation-confirmed annotation",
+				awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn), changedFields)
+		}
+	}
+	return nil
+}
+
 // isSDKTargetGroupRequiresReplacement checks whether a sdk TargetGroup requires replacement to fulfill a TargetGroup resource.
 func isSDKTargetGroupRequiresReplacement(sdkTG TargetGroupWithTags, resTG *elbv2model.TargetGroup) bool {
-	if string(resTG.Spec.TargetType) != awssdk.StringValue(sdkTG.TargetGroup.TargetType) {
+	if len(findSDKTargetGroupImmutableFieldChanges(sdkTG, resTG)) != 0 {
 		return true
 	}
+	return isSDKTargetGroupRequiresReplacementDueToNLBHealthCheck(sdkTG, resTG)
+}
+
+// findSDKTargetGroupImmutableFieldChanges returns the immutable fields of sdk TargetGroup that differ from TargetGroup resource.
+// port isn't considered since targets are always registered with explicit ports.
+func findSDKTargetGroupImmutableFieldChanges(sdkTG TargetGroupWithTags, resTG *elbv2model.TargetGroup) []string {
+	var changedFields []string
+	if string(resTG.Spec.TargetType) != awssdk.StringValue(sdkTG.TargetGroup.TargetType) {
+		changedFields = append(changedFields, "targetType")
+	}
 	if string(resTG.Spec.Protocol) != awssdk.StringValue(sdkTG.TargetGroup.Protocol) {
-		return true
+		changedFields = append(changedFields, "protocol")
 	}
 	if resTG.Spec.ProtocolVersion != nil {
 		if string(*resTG.Spec.ProtocolVersion) != awssdk.StringValue(sdkTG.TargetGroup.ProtocolVersion) {
-			return true
+			changedFields = append(changedFields, "protocolVersion")
 		}
 	}
-
-	return isSDKTargetGroupRequiresReplacementDueToNLBHealthCheck(sdkTG, resTG)
+	// targetGroups created without ipAddressType are ipv4.
+	if resTG.Spec.IPAddressType != nil {
+		sdkIPAddressType := awssdk.StringValue(sdkTG.TargetGroup.IpAddressType)
+		if sdkIPAddressType == "" {
+			sdkIPAddressType = string(elbv2model.TargetGroupIPAddressTypeIPv4)
+		}
+		if string(*resTG.Spec.IPAddressType) != sdkIPAddressType {
+			changedFields = append(changedFields, "ipAddressType")
+		}
+	}
+	return changedFields
 }
 
 // most of the healthCheck settings for NLB targetGroups cannot be changed for now.
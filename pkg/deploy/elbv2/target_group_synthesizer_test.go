@@ -280,6 +280,87 @@ func Test_matchResAndSDKTargetGroups(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TargetGroup recreation due to immutable field changes is confirmed",
+			args: args{
+				resTGs: []*elbv2model.TargetGroup{
+					{
+						ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+						Spec: elbv2model.TargetGroupSpec{
+							Name:                "my-name",
+							TargetType:          elbv2model.TargetTypeIP,
+							Protocol:            elbv2model.ProtocolHTTPS,
+							RecreationConfirmed: true,
+						},
+					},
+				},
+				sdkTGs: []TargetGroupWithTags{
+					{
+						TargetGroup: &elbv2sdk.TargetGroup{
+							TargetGroupArn: awssdk.String("arn-1"),
+							TargetType:     awssdk.String("ip"),
+							Protocol:       awssdk.String("HTTP"),
+						},
+						Tags: map[string]string{
+							"ingress.k8s.aws/resource": "id-1",
+						},
+					},
+				},
+				resourceIDTagKey: "ingress.k8s.aws/resource",
+			},
+			want1: []*elbv2model.TargetGroup{
+				{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+					Spec: elbv2model.TargetGroupSpec{
+						Name:                "my-name",
+						TargetType:          elbv2model.TargetTypeIP,
+						Protocol:            elbv2model.ProtocolHTTPS,
+						RecreationConfirmed: true,
+					},
+				},
+			},
+			want2: []TargetGroupWithTags{
+				{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetGroupArn: awssdk.String("arn-1"),
+						TargetType:     awssdk.String("ip"),
+						Protocol:       awssdk.String("HTTP"),
+					},
+					Tags: map[string]string{
+						"ingress.k8s.aws/resource": "id-1",
+					},
+				},
+			},
+		},
+		{
+			name: "TargetGroup recreation due to immutable field changes isn't confirmed",
+			args: args{
+				resTGs: []*elbv2model.TargetGroup{
+					{
+						ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+						Spec: elbv2model.TargetGroupSpec{
+							Name:       "my-name",
+							TargetType: elbv2model.TargetTypeIP,
+							Protocol:   elbv2model.ProtocolHTTPS,
+						},
+					},
+				},
+				sdkTGs: []TargetGroupWithTags{
+					{
+						TargetGroup: &elbv2sdk.TargetGroup{
+							TargetGroupArn: awssdk.String("arn-1"),
+							TargetType:     awssdk.String("instance"),
+							Protocol:       awssdk.String("HTTP"),
+						},
+						Tags: map[string]string{
+							"ingress.k8s.aws/resource": "id-1",
+						},
+					},
+				},
+				resourceIDTagKey: "ingress.k8s.aws/resource",
+			},
+			wantErr: errors.New("targetGroup arn-1 requires recreation due to changes of immutable fields [targetType protocol], recreation must be confirmed via target-group-recreation-confirmed annotation"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -499,6 +580,8 @@ func Test_mapSDKTargetGroupByResourceID(t *testing.T) {
 func Test_isSDKTargetGroupRequiresReplacement(t *testing.T) {
 	port8080 := intstr.FromInt(8080)
 	protocolHTTP := elbv2model.ProtocolHTTP
+	ipAddressTypeIPv4 := elbv2model.TargetGroupIPAddressTypeIPv4
+	ipAddressTypeIPv6 := elbv2model.TargetGroupIPAddressTypeIPv6
 	type args struct {
 		sdkTG TargetGroupWithTags
 		resTG *elbv2model.TargetGroup
@@ -618,6 +701,53 @@ func Test_isSDKTargetGroupRequiresReplacement(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "ipAddressType change need replacement",
+			args: args{
+				sdkTG: TargetGroupWithTags{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetType:      awssdk.String("ip"),
+						Port:            awssdk.Int64(8080),
+						Protocol:        awssdk.String("TCP"),
+						TargetGroupName: awssdk.String("my-tg"),
+						IpAddressType:   awssdk.String("ipv4"),
+					},
+				},
+				resTG: &elbv2model.TargetGroup{
+					Spec: elbv2model.TargetGroupSpec{
+						TargetType:    elbv2model.TargetTypeIP,
+						Port:          8080,
+						Protocol:      elbv2model.ProtocolTCP,
+						Name:          "my-tg",
+						IPAddressType: &ipAddressTypeIPv6,
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "ipAddressType defaults to ipv4",
+			args: args{
+				sdkTG: TargetGroupWithTags{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetType:      awssdk.String("ip"),
+						Port:            awssdk.Int64(8080),
+						Protocol:        awssdk.String("TCP"),
+						TargetGroupName: awssdk.String("my-tg"),
+					},
+				},
+				resTG: &elbv2model.TargetGroup{
+					Spec: elbv2model.TargetGroupSpec{
+						TargetType:    elbv2model.TargetTypeIP,
+						Port:          8080,
+						Protocol:      elbv2model.ProtocolTCP,
+						Name:          "my-tg",
+						IPAddressType: &ipAddressTypeIPv4,
+					},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "healthCheck change need replacement",
 			args: args{
@@ -3,6 +3,7 @@ package shield
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	shieldsdk "github.com/aws/aws-sdk-go/service/shield"
 	"github.com/go-logr/logr"
@@ -23,6 +24,12 @@ type ProtectionManager interface {
 	// GetProtection returns shield protection information for resource.
 	// returns nil if no protection exists.
 	GetProtection(ctx context.Context, resourceARN string) (*ProtectionInfo, error)
+
+	// AssociateHealthCheck associates Route53 health check with shield protection for resource.
+	AssociateHealthCheck(ctx context.Context, resourceARN string, protectionID string, healthCheckID string) error
+
+	// DisassociateHealthCheck disassociates Route53 health check from shield protection for resource.
+	DisassociateHealthCheck(ctx context.Context, resourceARN string, protectionID string, healthCheckID string) error
 }
 
 func NewDefaultProtectionManager(shieldClient services.Shield, logger logr.Logger) *defaultProtectionManager {
@@ -47,6 +54,8 @@ type defaultProtectionManager struct {
 type ProtectionInfo struct {
 	Name string
 	ID   string
+	// Route53 health checks associated with protection.
+	HealthCheckIDs []string
 }
 
 func (m *defaultProtectionManager) CreateProtection(ctx context.Context, resourceARN string, protectionName string) (string, error) {
@@ -114,10 +123,72 @@ func (m *defaultProtectionManager) GetProtection(ctx context.Context, resourceAR
 	}
 	if resp.Protection != nil {
 		protectionInfo = &ProtectionInfo{
-			Name: awssdk.StringValue(resp.Protection.Name),
-			ID:   awssdk.StringValue(resp.Protection.Id),
+			Name:           awssdk.StringValue(resp.Protection.Name),
+			ID:             awssdk.StringValue(resp.Protection.Id),
+			HealthCheckIDs: awssdk.StringValueSlice(resp.Protection.HealthCheckIds),
 		}
 	}
 	m.protectionInfoByResourceARNCache.Set(resourceARN, protectionInfo, m.protectionInfoByResourceARNCacheTTL)
 	return protectionInfo, nil
 }
+
+func (m *defaultProtectionManager) AssociateHealthCheck(ctx context.Context, resourceARN string, protectionID string, healthCheckID string) error {
+	healthCheckARN, err := buildHealthCheckARN(resourceARN, healthCheckID)
+	if err != nil {
+		return err
+	}
+	req := &shieldsdk.AssociateHealthCheckInput{
+		ProtectionId:   awssdk.String(protectionID),
+		HealthCheckArn: awssdk.String(healthCheckARN),
+	}
+	m.logger.Info("associating shield protection health check",
+		"resourceARN", resourceARN,
+		"protectionID", protectionID,
+		"healthCheckID", healthCheckID)
+	if _, err := m.shieldClient.AssociateHealthCheckWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("associated shield protection health check",
+		"resourceARN", resourceARN,
+		"protectionID", protectionID,
+		"healthCheckID", healthCheckID)
+	m.protectionInfoByResourceARNCache.Delete(resourceARN)
+	return nil
+}
+
+func (m *defaultProtectionManager) DisassociateHealthCheck(ctx context.Context, resourceARN string, protectionID string, healthCheckID string) error {
+	healthCheckARN, err := buildHealthCheckARN(resourceARN, healthCheckID)
+	if err != nil {
+		return err
+	}
+	req := &shieldsdk.DisassociateHealthCheckInput{
+		ProtectionId:   awssdk.String(protectionID),
+		HealthCheckArn: awssdk.String(healthCheckARN),
+	}
+	m.logger.Info("disassociating shield protection health check",
+		"resourceARN", resourceARN,
+		"protectionID", protectionID,
+		"healthCheckID", healthCheckID)
+	if _, err := m.shieldClient.DisassociateHealthCheckWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("disassociated shield protection health check",
+		"resourceARN", resourceARN,
+		"protectionID", protectionID,
+		"healthCheckID", healthCheckID)
+	m.protectionInfoByResourceARNCache.Delete(resourceARN)
+	return nil
+}
+
+// buildHealthCheckARN builds the ARN of Route53 health check within the same partition as resource.
+func buildHealthCheckARN(resourceARN string, healthCheckID string) (string, error) {
+	parsedResourceARN, err := arn.Parse(resourceARN)
+	if err != nil {
+		return "", err
+	}
+	return arn.ARN{
+		Partition: parsedResourceARN.Partition,
+		Service:   "route53",
+		Resource:  "healthcheck/" + healthCheckID,
+	}.String(), nil
+}
@@ -89,9 +89,42 @@ func (s *protectionSynthesizer) synthesizeProtectionsOnLB(ctx context.Context, l
 				"protectionID", protectionInfo.ID)
 		}
 	case enableProtection && protectionInfo == nil:
-		if _, err := s.protectionManager.CreateProtection(ctx, lbARN, protectionNameManaged); err != nil {
+		protectionID, err := s.protectionManager.CreateProtection(ctx, lbARN, protectionNameManaged)
+		if err != nil {
 			return errors.Wrap(err, "failed to create shield protection on LoadBalancer")
 		}
+		protectionInfo = &ProtectionInfo{
+			Name: protectionNameManaged,
+			ID:   protectionID,
+		}
+	}
+	if enableProtection {
+		if err := s.synthesizeHealthCheckOnProtection(ctx, lbARN, protectionInfo, resProtections[0].Spec.HealthCheckID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// synthesizeHealthCheckOnProtection ensures the Route53 health check for health-based detection is associated with protection.
+// health checks are only disassociated from protections managed by this controller.
+func (s *protectionSynthesizer) synthesizeHealthCheckOnProtection(ctx context.Context, lbARN string, protectionInfo *ProtectionInfo, desiredHealthCheckID string) error {
+	managedProtectionNames := sets.NewString(protectionNameManaged, protectionNameManagedLegacy)
+	currentHealthCheckIDs := sets.NewString(protectionInfo.HealthCheckIDs...)
+	if managedProtectionNames.Has(protectionInfo.Name) {
+		for _, healthCheckID := range currentHealthCheckIDs.List() {
+			if healthCheckID == desiredHealthCheckID {
+				continue
+			}
+			if err := s.protectionManager.DisassociateHealthCheck(ctx, lbARN, protectionInfo.ID, healthCheckID); err != nil {
+				return errors.Wrap(err, "failed to disassociate health check from shield protection on LoadBalancer")
+			}
+		}
+	}
+	if desiredHealthCheckID != "" && !currentHealthCheckIDs.Has(desiredHealthCheckID) {
+		if err := s.protectionManager.AssociateHealthCheck(ctx, lbARN, protectionInfo.ID, desiredHealthCheckID); err != nil {
+			return errors.Wrap(err, "failed to associate health check with shield protection on LoadBalancer")
+		}
 	}
 	return nil
 }
@@ -0,0 +1,258 @@
+package shield
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	shieldsdk "github.com/aws/aws-sdk-go/service/shield"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_protectionSynthesizer_synthesizeProtectionsOnLB(t *testing.T) {
+	type describeProtectionCall struct {
+		req  *shieldsdk.DescribeProtectionInput
+		resp *shieldsdk.DescribeProtectionOutput
+		err  error
+	}
+	type createProtectionCall struct {
+		req  *shieldsdk.CreateProtectionInput
+		resp *shieldsdk.CreateProtectionOutput
+		err  error
+	}
+	type associateHealthCheckCall struct {
+		req  *shieldsdk.AssociateHealthCheckInput
+		resp *shieldsdk.AssociateHealthCheckOutput
+		err  error
+	}
+	type disassociateHealthCheckCall struct {
+		req  *shieldsdk.DisassociateHealthCheckInput
+		resp *shieldsdk.DisassociateHealthCheckOutput
+		err  error
+	}
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1234567890abcdef"
+	hcID1 := "abcdef01-2345-6789-abcd-ef0123456789"
+	hcARN1 := "arn:aws:route53:::healthcheck/abcdef01-2345-6789-abcd-ef0123456789"
+	hcID2 := "fedcba98-7654-3210-fedc-ba9876543210"
+	hcARN2 := "arn:aws:route53:::healthcheck/fedcba98-7654-3210-fedc-ba9876543210"
+	tests := []struct {
+		name                         string
+		desiredHealthCheckID         *string
+		describeProtectionCalls      []describeProtectionCall
+		createProtectionCalls        []createProtectionCall
+		associateHealthCheckCalls    []associateHealthCheckCall
+		disassociateHealthCheckCalls []disassociateHealthCheckCall
+		wantErr                      error
+	}{
+		{
+			name:                 "create protection along with health check association",
+			desiredHealthCheckID: awssdk.String(hcID1),
+			describeProtectionCalls: []describeProtectionCall{
+				{
+					req:  &shieldsdk.DescribeProtectionInput{ResourceArn: awssdk.String(lbARN)},
+					resp: &shieldsdk.DescribeProtectionOutput{},
+				},
+			},
+			createProtectionCalls: []createProtectionCall{
+				{
+					req: &shieldsdk.CreateProtectionInput{
+						ResourceArn: awssdk.String(lbARN),
+						Name:        awssdk.String(protectionNameManaged),
+					},
+					resp: &shieldsdk.CreateProtectionOutput{ProtectionId: awssdk.String("protection-id")},
+				},
+			},
+			associateHealthCheckCalls: []associateHealthCheckCall{
+				{
+					req: &shieldsdk.AssociateHealthCheckInput{
+						ProtectionId:   awssdk.String("protection-id"),
+						HealthCheckArn: awssdk.String(hcARN1),
+					},
+					resp: &shieldsdk.AssociateHealthCheckOutput{},
+				},
+			},
+		},
+		{
+			name:                 "associate health check with existing protection",
+			desiredHealthCheckID: awssdk.String(hcID1),
+			describeProtectionCalls: []describeProtectionCall{
+				{
+					req: &shieldsdk.DescribeProtectionInput{ResourceArn: awssdk.String(lbARN)},
+					resp: &shieldsdk.DescribeProtectionOutput{
+						Protection: &shieldsdk.Protection{
+							Id:   awssdk.String("protection-id"),
+							Name: awssdk.String(protectionNameManaged),
+						},
+					},
+				},
+			},
+			associateHealthCheckCalls: []associateHealthCheckCall{
+				{
+					req: &shieldsdk.AssociateHealthCheckInput{
+						ProtectionId:   awssdk.String("protection-id"),
+						HealthCheckArn: awssdk.String(hcARN1),
+					},
+					resp: &shieldsdk.AssociateHealthCheckOutput{},
+				},
+			},
+		},
+		{
+			name:                 "health check already associated",
+			desiredHealthCheckID: awssdk.String(hcID1),
+			describeProtectionCalls: []describeProtectionCall{
+				{
+					req: &shieldsdk.DescribeProtectionInput{ResourceArn: awssdk.String(lbARN)},
+					resp: &shieldsdk.DescribeProtectionOutput{
+						Protection: &shieldsdk.Protection{
+							Id:             awssdk.String("protection-id"),
+							Name:           awssdk.String(protectionNameManaged),
+							HealthCheckIds: awssdk.StringSlice([]string{hcID1}),
+						},
+					},
+				},
+			},
+		},
+		{
+			name:                 "replace health check on managed protection",
+			desiredHealthCheckID: awssdk.String(hcID1),
+			describeProtectionCalls: []describeProtectionCall{
+				{
+					req: &shieldsdk.DescribeProtectionInput{ResourceArn: awssdk.String(lbARN)},
+					resp: &shieldsdk.DescribeProtectionOutput{
+						Protection: &shieldsdk.Protection{
+							Id:             awssdk.String("protection-id"),
+							Name:           awssdk.String(protectionNameManaged),
+							HealthCheckIds: awssdk.StringSlice([]string{hcID2}),
+						},
+					},
+				},
+			},
+			disassociateHealthCheckCalls: []disassociateHealthCheckCall{
+				{
+					req: &shieldsdk.DisassociateHealthCheckInput{
+						ProtectionId:   awssdk.String("protection-id"),
+						HealthCheckArn: awssdk.String(hcARN2),
+					},
+					resp: &shieldsdk.DisassociateHealthCheckOutput{},
+				},
+			},
+			associateHealthCheckCalls: []associateHealthCheckCall{
+				{
+					req: &shieldsdk.AssociateHealthCheckInput{
+						ProtectionId:   awssdk.String("protection-id"),
+						HealthCheckArn: awssdk.String(hcARN1),
+					},
+					resp: &shieldsdk.AssociateHealthCheckOutput{},
+				},
+			},
+		},
+		{
+			name:                 "disassociate health check from managed protection",
+			desiredHealthCheckID: awssdk.String(""),
+			describeProtectionCalls: []describeProtectionCall{
+				{
+					req: &shieldsdk.DescribeProtectionInput{ResourceArn: awssdk.String(lbARN)},
+					resp: &shieldsdk.DescribeProtectionOutput{
+						Protection: &shieldsdk.Protection{
+							Id:             awssdk.String("protection-id"),
+							Name:           awssdk.String(protectionNameManaged),
+							HealthCheckIds: awssdk.StringSlice([]string{hcID1}),
+						},
+					},
+				},
+			},
+			disassociateHealthCheckCalls: []disassociateHealthCheckCall{
+				{
+					req: &shieldsdk.DisassociateHealthCheckInput{
+						ProtectionId:   awssdk.String("protection-id"),
+						HealthCheckArn: awssdk.String(hcARN1),
+					},
+					resp: &shieldsdk.DisassociateHealthCheckOutput{},
+				},
+			},
+		},
+		{
+			name:                 "health check on unmanaged protection should be left untouched",
+			desiredHealthCheckID: awssdk.String(""),
+			describeProtectionCalls: []describeProtectionCall{
+				{
+					req: &shieldsdk.DescribeProtectionInput{ResourceArn: awssdk.String(lbARN)},
+					resp: &shieldsdk.DescribeProtectionOutput{
+						Protection: &shieldsdk.Protection{
+							Id:             awssdk.String("protection-id"),
+							Name:           awssdk.String("my-protection"),
+							HealthCheckIds: awssdk.StringSlice([]string{hcID1}),
+						},
+					},
+				},
+			},
+		},
+		{
+			name:                 "failed to associate health check",
+			desiredHealthCheckID: awssdk.String(hcID1),
+			describeProtectionCalls: []describeProtectionCall{
+				{
+					req: &shieldsdk.DescribeProtectionInput{ResourceArn: awssdk.String(lbARN)},
+					resp: &shieldsdk.DescribeProtectionOutput{
+						Protection: &shieldsdk.Protection{
+							Id:   awssdk.String("protection-id"),
+							Name: awssdk.String(protectionNameManaged),
+						},
+					},
+				},
+			},
+			associateHealthCheckCalls: []associateHealthCheckCall{
+				{
+					req: &shieldsdk.AssociateHealthCheckInput{
+						ProtectionId:   awssdk.String("protection-id"),
+						HealthCheckArn: awssdk.String(hcARN1),
+					},
+					err: errors.New("some error"),
+				},
+			},
+			wantErr: errors.New("failed to associate health check with shield protection on LoadBalancer: some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			shieldClient := mock_services.NewMockShield(ctrl)
+			for _, call := range tt.describeProtectionCalls {
+				shieldClient.EXPECT().DescribeProtectionWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.createProtectionCalls {
+				shieldClient.EXPECT().CreateProtectionWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.associateHealthCheckCalls {
+				shieldClient.EXPECT().AssociateHealthCheckWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.disassociateHealthCheckCalls {
+				shieldClient.EXPECT().DisassociateHealthCheckWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			var resProtections []*shieldmodel.Protection
+			if tt.desiredHealthCheckID != nil {
+				resProtections = append(resProtections, shieldmodel.NewProtection(stack, "LoadBalancer", shieldmodel.ProtectionSpec{
+					ResourceARN:   core.LiteralStringToken(lbARN),
+					HealthCheckID: awssdk.StringValue(tt.desiredHealthCheckID),
+				}))
+			}
+			protectionManager := NewDefaultProtectionManager(shieldClient, &log.NullLogger{})
+			s := NewProtectionSynthesizer(protectionManager, &log.NullLogger{}, stack)
+			err := s.synthesizeProtectionsOnLB(context.Background(), lbARN, resProtections)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
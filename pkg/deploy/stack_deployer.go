@@ -30,6 +30,10 @@ func NewDefaultStackDeployer(cloud aws.Cloud, k8sClient client.Client,
 	trackingProvider := tracking.NewDefaultProvider(tagPrefix, config.ClusterName)
 	ec2TaggingManager := ec2.NewDefaultTaggingManager(cloud.EC2(), networkingSGManager, cloud.VpcID(), logger)
 	elbv2TaggingManager := elbv2.NewDefaultTaggingManager(cloud.ELBV2(), logger)
+	var elbv2QuotaValidator elbv2.QuotaValidator
+	if config.ValidateELBV2Quotas {
+		elbv2QuotaValidator = elbv2.NewDefaultQuotaValidator(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, logger)
+	}
 
 	return &defaultStackDeployer{
 		cloud:                               cloud,
@@ -37,14 +41,17 @@ func NewDefaultStackDeployer(cloud aws.Cloud, k8sClient client.Client,
 		addonsConfig:                        config.AddonsConfig,
 		trackingProvider:                    trackingProvider,
 		ec2TaggingManager:                   ec2TaggingManager,
-		ec2SGManager:                        ec2.NewDefaultSecurityGroupManager(cloud.EC2(), trackingProvider, ec2TaggingManager, networkingSGReconciler, cloud.VpcID(), logger),
+		ec2SGManager:                        ec2.NewDefaultSecurityGroupManager(cloud.EC2(), trackingProvider, ec2TaggingManager, networkingSGReconciler, cloud.VpcID(), config.SecurityGroupRulesCleanupPolicy, logger),
+		ec2ESManager:                        ec2.NewDefaultVPCEndpointServiceManager(cloud.EC2(), trackingProvider, ec2TaggingManager, logger),
 		elbv2TaggingManager:                 elbv2TaggingManager,
 		elbv2LBManager:                      elbv2.NewDefaultLoadBalancerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, logger),
 		elbv2LSManager:                      elbv2.NewDefaultListenerManager(cloud.ELBV2(), logger),
 		elbv2LRManager:                      elbv2.NewDefaultListenerRuleManager(cloud.ELBV2(), logger),
 		elbv2TGManager:                      elbv2.NewDefaultTargetGroupManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, cloud.VpcID(), logger),
 		elbv2TGBManager:                     elbv2.NewDefaultTargetGroupBindingManager(k8sClient, trackingProvider, logger),
+		elbv2QuotaValidator:                 elbv2QuotaValidator,
 		wafv2WebACLAssociationManager:       wafv2.NewDefaultWebACLAssociationManager(cloud.WAFv2(), logger),
+		wafv2WebACLLoggingManager:           wafv2.NewDefaultWebACLLoggingManager(cloud.WAFv2(), logger),
 		wafRegionalWebACLAssociationManager: wafregional.NewDefaultWebACLAssociationManager(cloud.WAFRegional(), logger),
 		shieldProtectionManager:             shield.NewDefaultProtectionManager(cloud.Shield(), logger),
 		vpcID:                               cloud.VpcID(),
@@ -62,13 +69,16 @@ type defaultStackDeployer struct {
 	trackingProvider                    tracking.Provider
 	ec2TaggingManager                   ec2.TaggingManager
 	ec2SGManager                        ec2.SecurityGroupManager
+	ec2ESManager                        ec2.VPCEndpointServiceManager
 	elbv2TaggingManager                 elbv2.TaggingManager
 	elbv2LBManager                      elbv2.LoadBalancerManager
 	elbv2LSManager                      elbv2.ListenerManager
 	elbv2LRManager                      elbv2.ListenerRuleManager
 	elbv2TGManager                      elbv2.TargetGroupManager
 	elbv2TGBManager                     elbv2.TargetGroupBindingManager
+	elbv2QuotaValidator                 elbv2.QuotaValidator
 	wafv2WebACLAssociationManager       wafv2.WebACLAssociationManager
+	wafv2WebACLLoggingManager           wafv2.WebACLLoggingManager
 	wafRegionalWebACLAssociationManager wafregional.WebACLAssociationManager
 	shieldProtectionManager             shield.ProtectionManager
 	vpcID                               string
@@ -83,17 +93,30 @@ type ResourceSynthesizer interface {
 
 // Deploy a resource stack.
 func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack) error {
+	// validate quotas prior to synthesize, so that stack exceeding quotas won't be partially deployed.
+	if d.elbv2QuotaValidator != nil {
+		if err := d.elbv2QuotaValidator.Validate(ctx, stack); err != nil {
+			return err
+		}
+	}
 	synthesizers := []ResourceSynthesizer{
 		ec2.NewSecurityGroupSynthesizer(d.cloud.EC2(), d.trackingProvider, d.ec2TaggingManager, d.ec2SGManager, d.vpcID, d.logger, stack),
 		elbv2.NewTargetGroupSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2TGManager, d.logger, stack),
-		elbv2.NewLoadBalancerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LBManager, d.logger, stack),
+	}
+	// VPCEndpointServices must be synthesized prior to LoadBalancers, given LoadBalancers cannot be deleted while used by endpoint services.
+	// they're synthesized even if the addon is disabled, so that leftover VPCEndpointServices are cleaned up.
+	synthesizers = append(synthesizers,
+		ec2.NewVPCEndpointServiceSynthesizer(d.trackingProvider, d.ec2TaggingManager, d.ec2ESManager, d.addonsConfig.EndpointServiceEnabled, d.logger, stack),
+		elbv2.NewLoadBalancerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LBManager, d.elbv2LSManager, d.elbv2TGManager, d.logger, stack),
 		elbv2.NewListenerSynthesizer(d.cloud.ELBV2(), d.elbv2LSManager, d.logger, stack),
 		elbv2.NewListenerRuleSynthesizer(d.cloud.ELBV2(), d.elbv2LRManager, d.logger, stack),
 		elbv2.NewTargetGroupBindingSynthesizer(d.k8sClient, d.trackingProvider, d.elbv2TGBManager, d.logger, stack),
-	}
+	)
 
+	// WAFv2 WebACL associations must be synthesized after LoadBalancers, so that loadBalancerAttributes like waf.fail_open.enabled
+	// are already in place when WebACL gets associated.
 	if d.addonsConfig.WAFV2Enabled {
-		synthesizers = append(synthesizers, wafv2.NewWebACLAssociationSynthesizer(d.wafv2WebACLAssociationManager, d.logger, stack))
+		synthesizers = append(synthesizers, wafv2.NewWebACLAssociationSynthesizer(d.cloud.ELBV2(), d.wafv2WebACLAssociationManager, d.wafv2WebACLLoggingManager, d.logger, stack))
 	}
 	if d.addonsConfig.WAFEnabled && d.cloud.WAFRegional().Available() {
 		synthesizers = append(synthesizers, wafregional.NewWebACLAssociationSynthesizer(d.wafRegionalWebACLAssociationManager, d.logger, stack))
@@ -32,6 +32,13 @@ import (
 //    * `service.k8s.aws/stack-namespace: namespace`
 //    * `service.k8s.aws/stack-name: serviceName`
 
+const (
+	// TagPrefix for resources provisioned for Ingress resources.
+	IngressTagPrefix = "ingress.k8s.aws"
+	// TagPrefix for resources provisioned for Service resources.
+	ServiceTagPrefix = "service.k8s.aws"
+)
+
 // AWS TagKey for cluster resources.
 const clusterNameTagKey = "elbv2.k8s.aws/cluster"
 
@@ -52,6 +59,9 @@ type Provider interface {
 	// StackLabels provide the suitable k8s labels for stack.
 	StackLabels(stack core.Stack) map[string]string
 
+	// StackLabelKeys provide all k8s label keys that StackLabels may use.
+	StackLabelKeys() []string
+
 	// StackTagsLegacy provides the tags for stack with legacy clusterName.
 	// this is for backwards compatibility with AWSALBIngressController(v1.1.3+)
 	StackTagsLegacy(stack core.Stack) map[string]string
@@ -111,6 +121,14 @@ func (p *defaultProvider) StackLabels(stack core.Stack) map[string]string {
 	}
 }
 
+func (p *defaultProvider) StackLabelKeys() []string {
+	return []string{
+		p.prefixedTrackingKey("stack"),
+		p.prefixedTrackingKey("stack-namespace"),
+		p.prefixedTrackingKey("stack-name"),
+	}
+}
+
 func (p *defaultProvider) StackTagsLegacy(stack core.Stack) map[string]string {
 	stackID := stack.StackID()
 	return map[string]string{
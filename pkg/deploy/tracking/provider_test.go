@@ -159,6 +159,31 @@ func Test_defaultProvider_StackLabels(t *testing.T) {
 	}
 }
 
+func Test_defaultProvider_StackLabelKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider *defaultProvider
+		want     []string
+	}{
+		{
+			name:     "stackLabelKeys for Ingress",
+			provider: NewDefaultProvider("ingress.k8s.aws", "cluster-name"),
+			want:     []string{"ingress.k8s.aws/stack", "ingress.k8s.aws/stack-namespace", "ingress.k8s.aws/stack-name"},
+		},
+		{
+			name:     "stackLabelKeys for Service",
+			provider: NewDefaultProvider("service.k8s.aws", "cluster-name"),
+			want:     []string{"service.k8s.aws/stack", "service.k8s.aws/stack-namespace", "service.k8s.aws/stack-name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.provider.StackLabelKeys()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_defaultProvider_StackTagsLegacy(t *testing.T) {
 	type args struct {
 		stack core.Stack
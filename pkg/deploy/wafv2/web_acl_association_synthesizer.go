@@ -2,24 +2,32 @@ package wafv2
 
 import (
 	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	wafv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafv2"
 )
 
 // NewWebACLAssociationSynthesizer constructs new webACLAssociationSynthesizer.
-func NewWebACLAssociationSynthesizer(associationManager WebACLAssociationManager, logger logr.Logger, stack core.Stack) *webACLAssociationSynthesizer {
+func NewWebACLAssociationSynthesizer(elbv2Client services.ELBV2, associationManager WebACLAssociationManager, loggingManager WebACLLoggingManager, logger logr.Logger, stack core.Stack) *webACLAssociationSynthesizer {
 	return &webACLAssociationSynthesizer{
+		elbv2Client:        elbv2Client,
 		associationManager: associationManager,
+		loggingManager:     loggingManager,
 		logger:             logger,
 		stack:              stack,
 	}
 }
 
 type webACLAssociationSynthesizer struct {
+	elbv2Client        services.ELBV2
 	associationManager WebACLAssociationManager
+	loggingManager     WebACLLoggingManager
 	logger             logr.Logger
 	stack              core.Stack
 }
@@ -83,6 +91,46 @@ func (s *webACLAssociationSynthesizer) synthesizeWebACLAssociationsOnLB(ctx cont
 			return errors.Wrap(err, "failed to update WAFv2 webACL association on LoadBalancer")
 		}
 	}
+	if desiredWebACLARN != "" {
+		return s.synthesizeWebACLLoggingOnLB(ctx, lbARN, resAssociations[0].Spec)
+	}
+	return nil
+}
+
+// synthesizeWebACLLoggingOnLB reconciles the logging configuration of webACL associated with LoadBalancer.
+// the logging destination configured is recorded on the LoadBalancer, so that it can be deleted once no longer desired.
+func (s *webACLAssociationSynthesizer) synthesizeWebACLLoggingOnLB(ctx context.Context, lbARN string, associationSpec wafv2model.WebACLAssociationSpec) error {
+	switch {
+	case associationSpec.LoggingDestinationARN != "":
+		if err := s.loggingManager.ReconcileLoggingDestination(ctx, associationSpec.WebACLARN, associationSpec.LoggingDestinationARN); err != nil {
+			return errors.Wrap(err, "failed to reconcile WAFv2 webACL logging configuration")
+		}
+		if associationSpec.LoggingDestinationARN != associationSpec.AppliedLoggingDestinationARN {
+			req := &elbv2sdk.AddTagsInput{
+				ResourceArns: awssdk.StringSlice([]string{lbARN}),
+				Tags: []*elbv2sdk.Tag{
+					{
+						Key:   awssdk.String(elbv2deploy.LoadBalancerTagKeyWAFv2LoggingDestination),
+						Value: awssdk.String(associationSpec.LoggingDestinationARN),
+					},
+				},
+			}
+			if _, err := s.elbv2Client.AddTagsWithContext(ctx, req); err != nil {
+				return errors.Wrap(err, "failed to record WAFv2 webACL logging destination on LoadBalancer")
+			}
+		}
+	case associationSpec.AppliedLoggingDestinationARN != "":
+		if err := s.loggingManager.DeleteLoggingConfiguration(ctx, associationSpec.WebACLARN, associationSpec.AppliedLoggingDestinationARN); err != nil {
+			return errors.Wrap(err, "failed to delete WAFv2 webACL logging configuration")
+		}
+		req := &elbv2sdk.RemoveTagsInput{
+			ResourceArns: awssdk.StringSlice([]string{lbARN}),
+			TagKeys:      awssdk.StringSlice([]string{elbv2deploy.LoadBalancerTagKeyWAFv2LoggingDestination}),
+		}
+		if _, err := s.elbv2Client.RemoveTagsWithContext(ctx, req); err != nil {
+			return errors.Wrap(err, "failed to remove WAFv2 webACL logging destination from LoadBalancer")
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,138 @@
+package wafv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	wafv2sdk "github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"time"
+)
+
+const defaultLoggingDestinationByWebACLARNCacheTTL = 10 * time.Minute
+
+// WebACLLoggingManager is responsible for manage WAFv2 webACL logging configurations.
+type WebACLLoggingManager interface {
+	// ReconcileLoggingDestination ensures webACL logs to the destination.
+	ReconcileLoggingDestination(ctx context.Context, webACLARN string, destinationARN string) error
+
+	// DeleteLoggingConfiguration deletes the logging configuration of webACL if it still logs to the destination.
+	DeleteLoggingConfiguration(ctx context.Context, webACLARN string, destinationARN string) error
+}
+
+// NewDefaultWebACLLoggingManager constructs new defaultWebACLLoggingManager.
+func NewDefaultWebACLLoggingManager(wafv2Client services.WAFv2, logger logr.Logger) *defaultWebACLLoggingManager {
+	return &defaultWebACLLoggingManager{
+		wafv2Client:                           wafv2Client,
+		logger:                                logger,
+		loggingDestinationByWebACLARNCache:    cache.NewExpiring(),
+		loggingDestinationByWebACLARNCacheTTL: defaultLoggingDestinationByWebACLARNCacheTTL,
+	}
+}
+
+var _ WebACLLoggingManager = &defaultWebACLLoggingManager{}
+
+// default implementation for WebACLLoggingManager.
+type defaultWebACLLoggingManager struct {
+	wafv2Client services.WAFv2
+	logger      logr.Logger
+
+	// cache that stores logging destinationARN indexed by webACLARN
+	loggingDestinationByWebACLARNCache *cache.Expiring
+	// ttl for loggingDestinationByWebACLARNCache
+	loggingDestinationByWebACLARNCacheTTL time.Duration
+}
+
+func (m *defaultWebACLLoggingManager) ReconcileLoggingDestination(ctx context.Context, webACLARN string, destinationARN string) error {
+	if rawCacheItem, exists := m.loggingDestinationByWebACLARNCache.Get(webACLARN); exists && rawCacheItem.(string) == destinationARN {
+		return nil
+	}
+
+	loggingConfig, err := m.getLoggingConfiguration(ctx, webACLARN)
+	if err != nil {
+		return err
+	}
+	if loggingConfig != nil && len(loggingConfig.LogDestinationConfigs) == 1 &&
+		awssdk.StringValue(loggingConfig.LogDestinationConfigs[0]) == destinationARN {
+		m.loggingDestinationByWebACLARNCache.Set(webACLARN, destinationARN, m.loggingDestinationByWebACLARNCacheTTL)
+		return nil
+	}
+	if loggingConfig != nil && awssdk.BoolValue(loggingConfig.ManagedByFirewallManager) {
+		return errors.Errorf("logging configuration of WAFv2 webACL %v is managed by Firewall Manager", webACLARN)
+	}
+
+	desiredLoggingConfig := &wafv2sdk.LoggingConfiguration{
+		ResourceArn:           awssdk.String(webACLARN),
+		LogDestinationConfigs: awssdk.StringSlice([]string{destinationARN}),
+	}
+	// preserve other logging settings applied out-of-band.
+	if loggingConfig != nil {
+		desiredLoggingConfig.RedactedFields = loggingConfig.RedactedFields
+	}
+	req := &wafv2sdk.PutLoggingConfigurationInput{
+		LoggingConfiguration: desiredLoggingConfig,
+	}
+	m.logger.Info("configuring WAFv2 webACL logging",
+		"webACLARN", webACLARN,
+		"destinationARN", destinationARN)
+	if _, err := m.wafv2Client.PutLoggingConfigurationWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("configured WAFv2 webACL logging",
+		"webACLARN", webACLARN,
+		"destinationARN", destinationARN)
+	m.loggingDestinationByWebACLARNCache.Set(webACLARN, destinationARN, m.loggingDestinationByWebACLARNCacheTTL)
+	return nil
+}
+
+func (m *defaultWebACLLoggingManager) DeleteLoggingConfiguration(ctx context.Context, webACLARN string, destinationARN string) error {
+	m.loggingDestinationByWebACLARNCache.Delete(webACLARN)
+
+	loggingConfig, err := m.getLoggingConfiguration(ctx, webACLARN)
+	if err != nil {
+		return err
+	}
+	// logging configuration changed out-of-band is left untouched.
+	if loggingConfig == nil || len(loggingConfig.LogDestinationConfigs) != 1 ||
+		awssdk.StringValue(loggingConfig.LogDestinationConfigs[0]) != destinationARN ||
+		awssdk.BoolValue(loggingConfig.ManagedByFirewallManager) {
+		return nil
+	}
+
+	req := &wafv2sdk.DeleteLoggingConfigurationInput{
+		ResourceArn: awssdk.String(webACLARN),
+	}
+	m.logger.Info("deleting WAFv2 webACL logging",
+		"webACLARN", webACLARN,
+		"destinationARN", destinationARN)
+	if _, err := m.wafv2Client.DeleteLoggingConfigurationWithContext(ctx, req); err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == wafv2sdk.ErrCodeWAFNonexistentItemException {
+			return nil
+		}
+		return err
+	}
+	m.logger.Info("deleted WAFv2 webACL logging",
+		"webACLARN", webACLARN,
+		"destinationARN", destinationARN)
+	return nil
+}
+
+// getLoggingConfiguration returns the logging configuration for webACL, returns nil if webACL doesn't have logging configured.
+func (m *defaultWebACLLoggingManager) getLoggingConfiguration(ctx context.Context, webACLARN string) (*wafv2sdk.LoggingConfiguration, error) {
+	req := &wafv2sdk.GetLoggingConfigurationInput{
+		ResourceArn: awssdk.String(webACLARN),
+	}
+	resp, err := m.wafv2Client.GetLoggingConfigurationWithContext(ctx, req)
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == wafv2sdk.ErrCodeWAFNonexistentItemException {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.LoggingConfiguration, nil
+}
@@ -0,0 +1,324 @@
+package wafv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	wafv2sdk "github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultWebACLLoggingManager_ReconcileLoggingDestination(t *testing.T) {
+	type getLoggingConfigurationCall struct {
+		req  *wafv2sdk.GetLoggingConfigurationInput
+		resp *wafv2sdk.GetLoggingConfigurationOutput
+		err  error
+	}
+	type putLoggingConfigurationCall struct {
+		req  *wafv2sdk.PutLoggingConfigurationInput
+		resp *wafv2sdk.PutLoggingConfigurationOutput
+		err  error
+	}
+	webACLARN := "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/3ab78708-85b0-49d3-b4e1-7a9615a6613b"
+	destinationARN := "arn:aws:firehose:us-west-2:123456789012:deliverystream/aws-waf-logs-my-stream"
+	anotherDestinationARN := "arn:aws:firehose:us-west-2:123456789012:deliverystream/aws-waf-logs-another-stream"
+	redactedFields := []*wafv2sdk.FieldToMatch{
+		{
+			SingleHeader: &wafv2sdk.SingleHeader{Name: awssdk.String("authorization")},
+		},
+	}
+	tests := []struct {
+		name                         string
+		getLoggingConfigurationCalls []getLoggingConfigurationCall
+		putLoggingConfigurationCalls []putLoggingConfigurationCall
+		reconcileTimes               int
+		wantErr                      error
+	}{
+		{
+			name: "logging not configured yet",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					err: awserr.New(wafv2sdk.ErrCodeWAFNonexistentItemException, "logging configuration not found", nil),
+				},
+			},
+			putLoggingConfigurationCalls: []putLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.PutLoggingConfigurationInput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:           awssdk.String(webACLARN),
+							LogDestinationConfigs: awssdk.StringSlice([]string{destinationARN}),
+						},
+					},
+					resp: &wafv2sdk.PutLoggingConfigurationOutput{},
+				},
+			},
+			reconcileTimes: 1,
+		},
+		{
+			name: "logging already configured to destination",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					resp: &wafv2sdk.GetLoggingConfigurationOutput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:           awssdk.String(webACLARN),
+							LogDestinationConfigs: awssdk.StringSlice([]string{destinationARN}),
+						},
+					},
+				},
+			},
+			reconcileTimes: 1,
+		},
+		{
+			name: "logging configured to another destination",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					resp: &wafv2sdk.GetLoggingConfigurationOutput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:           awssdk.String(webACLARN),
+							LogDestinationConfigs: awssdk.StringSlice([]string{anotherDestinationARN}),
+							RedactedFields:        redactedFields,
+						},
+					},
+				},
+			},
+			putLoggingConfigurationCalls: []putLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.PutLoggingConfigurationInput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:           awssdk.String(webACLARN),
+							LogDestinationConfigs: awssdk.StringSlice([]string{destinationARN}),
+							RedactedFields:        redactedFields,
+						},
+					},
+					resp: &wafv2sdk.PutLoggingConfigurationOutput{},
+				},
+			},
+			reconcileTimes: 1,
+		},
+		{
+			name: "configured logging destination should be cached",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					err: awserr.New(wafv2sdk.ErrCodeWAFNonexistentItemException, "logging configuration not found", nil),
+				},
+			},
+			putLoggingConfigurationCalls: []putLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.PutLoggingConfigurationInput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:           awssdk.String(webACLARN),
+							LogDestinationConfigs: awssdk.StringSlice([]string{destinationARN}),
+						},
+					},
+					resp: &wafv2sdk.PutLoggingConfigurationOutput{},
+				},
+			},
+			reconcileTimes: 2,
+		},
+		{
+			name: "logging managed by firewall manager",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					resp: &wafv2sdk.GetLoggingConfigurationOutput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:              awssdk.String(webACLARN),
+							LogDestinationConfigs:    awssdk.StringSlice([]string{anotherDestinationARN}),
+							ManagedByFirewallManager: awssdk.Bool(true),
+						},
+					},
+				},
+			},
+			reconcileTimes: 1,
+			wantErr:        errors.New("logging configuration of WAFv2 webACL arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/3ab78708-85b0-49d3-b4e1-7a9615a6613b is managed by Firewall Manager"),
+		},
+		{
+			name: "failed to get logging configuration",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					err: errors.New("some error"),
+				},
+			},
+			reconcileTimes: 1,
+			wantErr:        errors.New("some error"),
+		},
+		{
+			name: "failed to put logging configuration",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					err: awserr.New(wafv2sdk.ErrCodeWAFNonexistentItemException, "logging configuration not found", nil),
+				},
+			},
+			putLoggingConfigurationCalls: []putLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.PutLoggingConfigurationInput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:           awssdk.String(webACLARN),
+							LogDestinationConfigs: awssdk.StringSlice([]string{destinationARN}),
+						},
+					},
+					err: errors.New("some error"),
+				},
+			},
+			reconcileTimes: 1,
+			wantErr:        errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wafv2Client := mock_services.NewMockWAFv2(ctrl)
+			for _, call := range tt.getLoggingConfigurationCalls {
+				wafv2Client.EXPECT().GetLoggingConfigurationWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.putLoggingConfigurationCalls {
+				wafv2Client.EXPECT().PutLoggingConfigurationWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := NewDefaultWebACLLoggingManager(wafv2Client, &log.NullLogger{})
+			for i := 0; i < tt.reconcileTimes; i++ {
+				err := m.ReconcileLoggingDestination(context.Background(), webACLARN, destinationARN)
+				if tt.wantErr != nil {
+					assert.EqualError(t, err, tt.wantErr.Error())
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
+func Test_defaultWebACLLoggingManager_DeleteLoggingConfiguration(t *testing.T) {
+	type getLoggingConfigurationCall struct {
+		req  *wafv2sdk.GetLoggingConfigurationInput
+		resp *wafv2sdk.GetLoggingConfigurationOutput
+		err  error
+	}
+	type deleteLoggingConfigurationCall struct {
+		req  *wafv2sdk.DeleteLoggingConfigurationInput
+		resp *wafv2sdk.DeleteLoggingConfigurationOutput
+		err  error
+	}
+	webACLARN := "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/3ab78708-85b0-49d3-b4e1-7a9615a6613b"
+	destinationARN := "arn:aws:firehose:us-west-2:123456789012:deliverystream/aws-waf-logs-my-stream"
+	anotherDestinationARN := "arn:aws:firehose:us-west-2:123456789012:deliverystream/aws-waf-logs-another-stream"
+	tests := []struct {
+		name                            string
+		getLoggingConfigurationCalls    []getLoggingConfigurationCall
+		deleteLoggingConfigurationCalls []deleteLoggingConfigurationCall
+		wantErr                         error
+	}{
+		{
+			name: "logging configured to destination",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					resp: &wafv2sdk.GetLoggingConfigurationOutput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:           awssdk.String(webACLARN),
+							LogDestinationConfigs: awssdk.StringSlice([]string{destinationARN}),
+						},
+					},
+				},
+			},
+			deleteLoggingConfigurationCalls: []deleteLoggingConfigurationCall{
+				{
+					req:  &wafv2sdk.DeleteLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					resp: &wafv2sdk.DeleteLoggingConfigurationOutput{},
+				},
+			},
+		},
+		{
+			name: "logging not configured",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					err: awserr.New(wafv2sdk.ErrCodeWAFNonexistentItemException, "logging configuration not found", nil),
+				},
+			},
+		},
+		{
+			name: "logging configured to another destination out-of-band",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					resp: &wafv2sdk.GetLoggingConfigurationOutput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:           awssdk.String(webACLARN),
+							LogDestinationConfigs: awssdk.StringSlice([]string{anotherDestinationARN}),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "logging managed by firewall manager",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					resp: &wafv2sdk.GetLoggingConfigurationOutput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:              awssdk.String(webACLARN),
+							LogDestinationConfigs:    awssdk.StringSlice([]string{destinationARN}),
+							ManagedByFirewallManager: awssdk.Bool(true),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "failed to delete logging configuration",
+			getLoggingConfigurationCalls: []getLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.GetLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					resp: &wafv2sdk.GetLoggingConfigurationOutput{
+						LoggingConfiguration: &wafv2sdk.LoggingConfiguration{
+							ResourceArn:           awssdk.String(webACLARN),
+							LogDestinationConfigs: awssdk.StringSlice([]string{destinationARN}),
+						},
+					},
+				},
+			},
+			deleteLoggingConfigurationCalls: []deleteLoggingConfigurationCall{
+				{
+					req: &wafv2sdk.DeleteLoggingConfigurationInput{ResourceArn: awssdk.String(webACLARN)},
+					err: errors.New("some error"),
+				},
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wafv2Client := mock_services.NewMockWAFv2(ctrl)
+			for _, call := range tt.getLoggingConfigurationCalls {
+				wafv2Client.EXPECT().GetLoggingConfigurationWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.deleteLoggingConfigurationCalls {
+				wafv2Client.EXPECT().DeleteLoggingConfigurationWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := NewDefaultWebACLLoggingManager(wafv2Client, &log.NullLogger{})
+			err := m.DeleteLoggingConfiguration(context.Background(), webACLARN, destinationARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
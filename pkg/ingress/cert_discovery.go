@@ -30,6 +30,11 @@ const (
 type CertDiscovery interface {
 	// Discover will try to find valid certificateARNs for each tlsHost.
 	Discover(ctx context.Context, tlsHosts []string) ([]string, error)
+
+	// DiscoverWithWildcardFallback will try to find certificateARNs that exactly match each tlsHost,
+	// tlsHosts without exactly matched certificate fall back to a single wildcard certificate that covers all of them.
+	// the wildcard certificate is returned as the default certificate if it's used.
+	DiscoverWithWildcardFallback(ctx context.Context, tlsHosts []string) ([]string, *string, error)
 }
 
 // NewACMCertDiscovery constructs new acmCertDiscovery
@@ -91,6 +96,51 @@ func (d *acmCertDiscovery) Discover(ctx context.Context, tlsHosts []string) ([]s
 	return certARNs.List(), nil
 }
 
+func (d *acmCertDiscovery) DiscoverWithWildcardFallback(ctx context.Context, tlsHosts []string) ([]string, *string, error) {
+	domainsByCertARN, err := d.loadDomainsForAllCertificates(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	certARNs := sets.NewString()
+	var fallbackHosts []string
+	for _, host := range tlsHosts {
+		var certARNsForHost []string
+		for certARN, domains := range domainsByCertARN {
+			if domains.Has(host) {
+				certARNsForHost = append(certARNsForHost, certARN)
+			}
+		}
+
+		if len(certARNsForHost) > 1 {
+			return nil, nil, errors.Errorf("multiple certificate found for host: %s, certARNs: %v", host, certARNsForHost)
+		}
+		if len(certARNsForHost) == 0 {
+			fallbackHosts = append(fallbackHosts, host)
+			continue
+		}
+		certARNs.Insert(certARNsForHost...)
+	}
+	if len(fallbackHosts) == 0 {
+		return certARNs.List(), nil, nil
+	}
+
+	wildcardCertARNs := sets.NewString()
+	for certARN, domains := range domainsByCertARN {
+		if d.wildcardDomainsCoverHosts(domains, fallbackHosts) {
+			wildcardCertARNs.Insert(certARN)
+		}
+	}
+	if wildcardCertARNs.Len() > 1 {
+		return nil, nil, errors.Errorf("multiple wildcard certificate found for hosts: %v, certARNs: %v", fallbackHosts, wildcardCertARNs.List())
+	}
+	if wildcardCertARNs.Len() == 0 {
+		return nil, nil, errors.Errorf("none wildcard certificate covers hosts: %v", fallbackHosts)
+	}
+	wildcardCertARN := wildcardCertARNs.List()[0]
+	certARNs.Insert(wildcardCertARN)
+	return certARNs.List(), &wildcardCertARN, nil
+}
+
 func (d *acmCertDiscovery) loadDomainsForAllCertificates(ctx context.Context) (map[string]sets.String, error) {
 	d.loadDomainsByCertARNMutex.Lock()
 	defer d.loadDomainsByCertARNMutex.Unlock()
@@ -153,6 +203,23 @@ func (d *acmCertDiscovery) loadDomainsForCertificate(ctx context.Context, certAR
 	return domains, nil
 }
 
+// wildcardDomainsCoverHosts checks whether each of tlsHosts is matched by one of the wildcard domains.
+func (d *acmCertDiscovery) wildcardDomainsCoverHosts(domains sets.String, tlsHosts []string) bool {
+	for _, host := range tlsHosts {
+		covered := false
+		for domain := range domains {
+			if strings.HasPrefix(domain, "*.") && d.domainMatchesHost(domain, host) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
 func (d *acmCertDiscovery) domainMatchesHost(domainName string, tlsHost string) bool {
 	if strings.HasPrefix(domainName, "*.") {
 		ds := strings.Split(domainName, ".")
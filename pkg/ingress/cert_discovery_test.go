@@ -1,10 +1,115 @@
 package ingress
 
 import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
+func Test_acmCertDiscovery_DiscoverWithWildcardFallback(t *testing.T) {
+	tests := []struct {
+		name               string
+		domainsByCertARN   map[string][]string
+		tlsHosts           []string
+		wantCertARNs       []string
+		wantDefaultCertARN *string
+		wantErr            error
+	}{
+		{
+			name: "exactly matched certificate preferred over wildcard certificate",
+			domainsByCertARN: map[string][]string{
+				"cert-app":      {"app.example.com"},
+				"cert-wildcard": {"*.example.com"},
+			},
+			tlsHosts:     []string{"app.example.com"},
+			wantCertARNs: []string{"cert-app"},
+		},
+		{
+			name: "hosts without exactly matched certificate fall back to wildcard certificate",
+			domainsByCertARN: map[string][]string{
+				"cert-app":      {"app.example.com"},
+				"cert-wildcard": {"*.example.com"},
+			},
+			tlsHosts:           []string{"app.example.com", "api.example.com", "www.example.com"},
+			wantCertARNs:       []string{"cert-app", "cert-wildcard"},
+			wantDefaultCertARN: awssdk.String("cert-wildcard"),
+		},
+		{
+			name: "wildcard certificate with multiple wildcard domains",
+			domainsByCertARN: map[string][]string{
+				"cert-wildcard": {"*.example.com", "*.example.org"},
+			},
+			tlsHosts:           []string{"www.example.com", "www.example.org"},
+			wantCertARNs:       []string{"cert-wildcard"},
+			wantDefaultCertARN: awssdk.String("cert-wildcard"),
+		},
+		{
+			name: "wildcard certificate doesn't cover all hosts",
+			domainsByCertARN: map[string][]string{
+				"cert-com": {"*.example.com"},
+				"cert-org": {"*.example.org"},
+			},
+			tlsHosts: []string{"www.example.com", "www.example.org"},
+			wantErr:  errors.New("none wildcard certificate covers hosts: [www.example.com www.example.org]"),
+		},
+		{
+			name: "wildcard certificate doesn't cover nested subdomain",
+			domainsByCertARN: map[string][]string{
+				"cert-wildcard": {"*.example.com"},
+			},
+			tlsHosts: []string{"www.app.example.com"},
+			wantErr:  errors.New("none wildcard certificate covers hosts: [www.app.example.com]"),
+		},
+		{
+			name: "multiple wildcard certificates cover hosts",
+			domainsByCertARN: map[string][]string{
+				"cert-wildcard-1": {"*.example.com"},
+				"cert-wildcard-2": {"example.com", "*.example.com"},
+			},
+			tlsHosts: []string{"www.example.com"},
+			wantErr:  errors.New("multiple wildcard certificate found for hosts: [www.example.com], certARNs: [cert-wildcard-1 cert-wildcard-2]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			acmClient := mock_services.NewMockACM(ctrl)
+			var certSummaries []*acm.CertificateSummary
+			for certARN, domains := range tt.domainsByCertARN {
+				certSummaries = append(certSummaries, &acm.CertificateSummary{CertificateArn: awssdk.String(certARN)})
+				acmClient.EXPECT().DescribeCertificateWithContext(gomock.Any(), &acm.DescribeCertificateInput{
+					CertificateArn: awssdk.String(certARN),
+				}).Return(&acm.DescribeCertificateOutput{
+					Certificate: &acm.CertificateDetail{
+						CertificateArn:          awssdk.String(certARN),
+						SubjectAlternativeNames: awssdk.StringSlice(domains),
+						Type:                    awssdk.String(acm.CertificateTypeAmazonIssued),
+					},
+				}, nil)
+			}
+			acmClient.EXPECT().ListCertificatesAsList(gomock.Any(), gomock.Any()).Return(certSummaries, nil)
+
+			d := NewACMCertDiscovery(acmClient, &log.NullLogger{})
+			gotCertARNs, gotDefaultCertARN, err := d.DiscoverWithWildcardFallback(context.Background(), tt.tlsHosts)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCertARNs, gotCertARNs)
+				assert.Equal(t, tt.wantDefaultCertARN, gotDefaultCertARN)
+			}
+		})
+	}
+}
+
 func Test_acmCertDiscovery_domainMatchesHost(t *testing.T) {
 	type args struct {
 		domainName string
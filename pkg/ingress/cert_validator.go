@@ -0,0 +1,85 @@
+package ingress
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"time"
+)
+
+const (
+	acmARNService = "acm"
+	// the existence of certificates will be cached for 1 minute.
+	defaultExistingCertsCacheTTL = 1 * time.Minute
+)
+
+// CertValidator is responsible for validate TLS certificates referenced by Ingresses.
+type CertValidator interface {
+	// FindMissingCerts returns the certificateARNs that no longer exist.
+	FindMissingCerts(ctx context.Context, certARNs []string) ([]string, error)
+}
+
+// NewACMCertValidator constructs new acmCertValidator
+func NewACMCertValidator(acmClient services.ACM, logger logr.Logger) *acmCertValidator {
+	return &acmCertValidator{
+		acmClient:             acmClient,
+		logger:                logger,
+		existingCertsCache:    cache.NewExpiring(),
+		existingCertsCacheTTL: defaultExistingCertsCacheTTL,
+	}
+}
+
+var _ CertValidator = &acmCertValidator{}
+
+// CertValidator implementation for ACM certificates.
+// certificates from other sources(e.g. IAM server certificates) are assumed to exist.
+type acmCertValidator struct {
+	acmClient services.ACM
+	logger    logr.Logger
+
+	existingCertsCache    *cache.Expiring
+	existingCertsCacheTTL time.Duration
+}
+
+func (v *acmCertValidator) FindMissingCerts(ctx context.Context, certARNs []string) ([]string, error) {
+	var missingCertARNs []string
+	for _, certARN := range certARNs {
+		exists, err := v.certExists(ctx, certARN)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			missingCertARNs = append(missingCertARNs, certARN)
+		}
+	}
+	return missingCertARNs, nil
+}
+
+func (v *acmCertValidator) certExists(ctx context.Context, certARN string) (bool, error) {
+	parsedARN, err := arn.Parse(certARN)
+	if err != nil || parsedARN.Service != acmARNService {
+		return true, nil
+	}
+	if _, ok := v.existingCertsCache.Get(certARN); ok {
+		return true, nil
+	}
+	req := &acm.DescribeCertificateInput{
+		CertificateArn: aws.String(certARN),
+	}
+	if _, err := v.acmClient.DescribeCertificateWithContext(ctx, req); err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == acm.ErrCodeResourceNotFoundException {
+			v.logger.V(1).Info("certificate not found", "certARN", certARN)
+			return false, nil
+		}
+		return false, err
+	}
+	v.existingCertsCache.Set(certARN, true, v.existingCertsCacheTTL)
+	return true, nil
+}
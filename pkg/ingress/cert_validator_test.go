@@ -0,0 +1,89 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_acmCertValidator_FindMissingCerts(t *testing.T) {
+	type describeCertificateCall struct {
+		req  *acm.DescribeCertificateInput
+		resp *acm.DescribeCertificateOutput
+		err  error
+	}
+	certARN1 := "arn:aws:acm:us-west-2:123456789012:certificate/cert-1"
+	certARN2 := "arn:aws:acm:us-west-2:123456789012:certificate/cert-2"
+	iamCertARN := "arn:aws:iam::123456789012:server-certificate/cert-3"
+	tests := []struct {
+		name                     string
+		describeCertificateCalls []describeCertificateCall
+		certARNs                 []string
+		want                     []string
+		wantErr                  error
+	}{
+		{
+			name: "all certificates exist",
+			describeCertificateCalls: []describeCertificateCall{
+				{
+					req:  &acm.DescribeCertificateInput{CertificateArn: awssdk.String(certARN1)},
+					resp: &acm.DescribeCertificateOutput{},
+				},
+			},
+			certARNs: []string{certARN1, iamCertARN},
+			want:     nil,
+		},
+		{
+			name: "some certificates are missing",
+			describeCertificateCalls: []describeCertificateCall{
+				{
+					req:  &acm.DescribeCertificateInput{CertificateArn: awssdk.String(certARN1)},
+					resp: &acm.DescribeCertificateOutput{},
+				},
+				{
+					req: &acm.DescribeCertificateInput{CertificateArn: awssdk.String(certARN2)},
+					err: awserr.New(acm.ErrCodeResourceNotFoundException, "Could not find certificate", nil),
+				},
+			},
+			certARNs: []string{certARN1, certARN2},
+			want:     []string{certARN2},
+		},
+		{
+			name: "failed to describe certificate",
+			describeCertificateCalls: []describeCertificateCall{
+				{
+					req: &acm.DescribeCertificateInput{CertificateArn: awssdk.String(certARN1)},
+					err: errors.New("some error"),
+				},
+			},
+			certARNs: []string{certARN1},
+			wantErr:  errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			acmClient := mock_services.NewMockACM(ctrl)
+			for _, call := range tt.describeCertificateCalls {
+				acmClient.EXPECT().DescribeCertificateWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			v := NewACMCertValidator(acmClient, &log.NullLogger{})
+			got, err := v.FindMissingCerts(context.Background(), tt.certARNs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
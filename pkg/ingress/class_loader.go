@@ -0,0 +1,101 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the controller name used in IngressClass for ALB.
+	ingressClassControllerALB = "ingress.k8s.aws/alb"
+	// the Kind for IngressClassParams CRD.
+	ingressClassParamsKind = "IngressClassParams"
+
+	// IndexKey for IngressClass referenced by Ingress.
+	IndexKeyIngressClassRefName = "ingress.ingressClassRef.name"
+	// IndexKey for IngressClassParams referenced by IngressClass.
+	IndexKeyIngressClassParamsRefName = "ingressClass.ingressClassParamsRef.name"
+)
+
+// ClassConfiguration contains the configurations for an Ingress from its IngressClass.
+type ClassConfiguration struct {
+	// The IngressClass for Ingress.
+	IngClass *networking.IngressClass
+
+	// The IngressClassParams for Ingress if any.
+	IngClassParams *elbv2api.IngressClassParams
+}
+
+// ClassLoader loads IngressClass configurations for Ingress.
+type ClassLoader interface {
+	// Load loads the ClassConfiguration for Ingress.
+	// an empty ClassConfiguration will be returned if Ingress doesn't reference an IngressClass.
+	Load(ctx context.Context, ing *networking.Ingress) (ClassConfiguration, error)
+}
+
+// NewDefaultClassLoader constructs new defaultClassLoader instance.
+func NewDefaultClassLoader(client client.Client) *defaultClassLoader {
+	return &defaultClassLoader{
+		client: client,
+	}
+}
+
+var _ ClassLoader = (*defaultClassLoader)(nil)
+
+// default implementation for ClassLoader
+type defaultClassLoader struct {
+	client client.Client
+}
+
+func (l *defaultClassLoader) Load(ctx context.Context, ing *networking.Ingress) (ClassConfiguration, error) {
+	if ing.Spec.IngressClassName == nil {
+		return ClassConfiguration{}, nil
+	}
+
+	ingClassKey := types.NamespacedName{Name: *ing.Spec.IngressClassName}
+	ingClass := &networking.IngressClass{}
+	if err := l.client.Get(ctx, ingClassKey, ingClass); err != nil {
+		return ClassConfiguration{}, err
+	}
+	if ingClass.Spec.Controller != ingressClassControllerALB || ingClass.Spec.Parameters == nil {
+		return ClassConfiguration{
+			IngClass: ingClass,
+		}, nil
+	}
+
+	params := ingClass.Spec.Parameters
+	if params.APIGroup == nil || *params.APIGroup != elbv2api.GroupVersion.Group || params.Kind != ingressClassParamsKind {
+		return ClassConfiguration{}, errors.Errorf("IngressClass %v references unsupported parameters: %v/%v", ingClass.Name, awssdk.StringValue(params.APIGroup), params.Kind)
+	}
+	ingClassParamsKey := types.NamespacedName{Name: params.Name}
+	ingClassParams := &elbv2api.IngressClassParams{}
+	if err := l.client.Get(ctx, ingClassParamsKey, ingClassParams); err != nil {
+		return ClassConfiguration{}, err
+	}
+	return ClassConfiguration{
+		IngClass:       ingClass,
+		IngClassParams: ingClassParams,
+	}, nil
+}
+
+// BuildIngressClassRefIndexes builds the indexes of IngressClass referenced by Ingress.
+func BuildIngressClassRefIndexes(ing *networking.Ingress) []string {
+	if ing.Spec.IngressClassName == nil {
+		return nil
+	}
+	return []string{*ing.Spec.IngressClassName}
+}
+
+// BuildIngressClassParamsRefIndexes builds the indexes of IngressClassParams referenced by IngressClass.
+func BuildIngressClassParamsRefIndexes(ingClass *networking.IngressClass) []string {
+	params := ingClass.Spec.Parameters
+	if params == nil || params.APIGroup == nil || *params.APIGroup != elbv2api.GroupVersion.Group || params.Kind != ingressClassParamsKind {
+		return nil
+	}
+	return []string{params.Name}
+}
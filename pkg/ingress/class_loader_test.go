@@ -0,0 +1,234 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/equality"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultClassLoader_Load(t *testing.T) {
+	schemeInternal := elbv2api.LoadBalancerSchemeInternal
+	type env struct {
+		ingClasses       []*networking.IngressClass
+		ingClassParamses []*elbv2api.IngressClassParams
+	}
+	tests := []struct {
+		name    string
+		env     env
+		ing     *networking.Ingress
+		want    ClassConfiguration
+		wantErr error
+	}{
+		{
+			name: "ingress without IngressClassName",
+			ing:  &networking.Ingress{},
+			want: ClassConfiguration{},
+		},
+		{
+			name: "IngressClass without parameters",
+			env: env{
+				ingClasses: []*networking.IngressClass{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "alb"},
+						Spec: networking.IngressClassSpec{
+							Controller: "ingress.k8s.aws/alb",
+						},
+					},
+				},
+			},
+			ing: &networking.Ingress{
+				Spec: networking.IngressSpec{IngressClassName: awssdk.String("alb")},
+			},
+			want: ClassConfiguration{
+				IngClass: &networking.IngressClass{
+					ObjectMeta: metav1.ObjectMeta{Name: "alb"},
+					Spec: networking.IngressClassSpec{
+						Controller: "ingress.k8s.aws/alb",
+					},
+				},
+			},
+		},
+		{
+			name: "IngressClass with IngressClassParams",
+			env: env{
+				ingClasses: []*networking.IngressClass{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "alb"},
+						Spec: networking.IngressClassSpec{
+							Controller: "ingress.k8s.aws/alb",
+							Parameters: &corev1.TypedLocalObjectReference{
+								APIGroup: awssdk.String("elbv2.k8s.aws"),
+								Kind:     "IngressClassParams",
+								Name:     "internal-only",
+							},
+						},
+					},
+				},
+				ingClassParamses: []*elbv2api.IngressClassParams{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "internal-only"},
+						Spec: elbv2api.IngressClassParamsSpec{
+							Scheme:              &schemeInternal,
+							SchemeAuthoritative: true,
+						},
+					},
+				},
+			},
+			ing: &networking.Ingress{
+				Spec: networking.IngressSpec{IngressClassName: awssdk.String("alb")},
+			},
+			want: ClassConfiguration{
+				IngClass: &networking.IngressClass{
+					ObjectMeta: metav1.ObjectMeta{Name: "alb"},
+					Spec: networking.IngressClassSpec{
+						Controller: "ingress.k8s.aws/alb",
+						Parameters: &corev1.TypedLocalObjectReference{
+							APIGroup: awssdk.String("elbv2.k8s.aws"),
+							Kind:     "IngressClassParams",
+							Name:     "internal-only",
+						},
+					},
+				},
+				IngClassParams: &elbv2api.IngressClassParams{
+					ObjectMeta: metav1.ObjectMeta{Name: "internal-only"},
+					Spec: elbv2api.IngressClassParamsSpec{
+						Scheme:              &schemeInternal,
+						SchemeAuthoritative: true,
+					},
+				},
+			},
+		},
+		{
+			name: "IngressClass with unsupported parameters",
+			env: env{
+				ingClasses: []*networking.IngressClass{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "alb"},
+						Spec: networking.IngressClassSpec{
+							Controller: "ingress.k8s.aws/alb",
+							Parameters: &corev1.TypedLocalObjectReference{
+								APIGroup: awssdk.String("example.com"),
+								Kind:     "Params",
+								Name:     "awesome-params",
+							},
+						},
+					},
+				},
+			},
+			ing: &networking.Ingress{
+				Spec: networking.IngressSpec{IngressClassName: awssdk.String("alb")},
+			},
+			wantErr: errors.New("IngressClass alb references unsupported parameters: example.com/Params"),
+		},
+		{
+			name: "IngressClassParams not found",
+			env: env{
+				ingClasses: []*networking.IngressClass{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "alb"},
+						Spec: networking.IngressClassSpec{
+							Controller: "ingress.k8s.aws/alb",
+							Parameters: &corev1.TypedLocalObjectReference{
+								APIGroup: awssdk.String("elbv2.k8s.aws"),
+								Kind:     "IngressClassParams",
+								Name:     "internal-only",
+							},
+						},
+					},
+				},
+			},
+			ing: &networking.Ingress{
+				Spec: networking.IngressSpec{IngressClassName: awssdk.String("alb")},
+			},
+			wantErr: errors.New("ingressclassparamses.elbv2.k8s.aws \"internal-only\" not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, ingClass := range tt.env.ingClasses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClass.DeepCopy()))
+			}
+			for _, ingClassParams := range tt.env.ingClassParamses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClassParams.DeepCopy()))
+			}
+
+			l := NewDefaultClassLoader(k8sClient)
+			got, err := l.Load(ctx, tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				opt := equality.IgnoreFakeClientPopulatedFields()
+				assert.True(t, cmp.Equal(tt.want, got, opt), "diff", cmp.Diff(tt.want, got, opt))
+			}
+		})
+	}
+}
+
+func Test_BuildIngressClassParamsRefIndexes(t *testing.T) {
+	tests := []struct {
+		name     string
+		ingClass *networking.IngressClass
+		want     []string
+	}{
+		{
+			name: "IngressClass without parameters",
+			ingClass: &networking.IngressClass{
+				Spec: networking.IngressClassSpec{
+					Controller: "ingress.k8s.aws/alb",
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "IngressClass with IngressClassParams",
+			ingClass: &networking.IngressClass{
+				Spec: networking.IngressClassSpec{
+					Controller: "ingress.k8s.aws/alb",
+					Parameters: &corev1.TypedLocalObjectReference{
+						APIGroup: awssdk.String("elbv2.k8s.aws"),
+						Kind:     "IngressClassParams",
+						Name:     "awesome-class-params",
+					},
+				},
+			},
+			want: []string{"awesome-class-params"},
+		},
+		{
+			name: "IngressClass with other parameters",
+			ingClass: &networking.IngressClass{
+				Spec: networking.IngressClassSpec{
+					Controller: "k8s.io/ingress-nginx",
+					Parameters: &corev1.TypedLocalObjectReference{
+						APIGroup: awssdk.String("k8s.nginx.org"),
+						Kind:     "IngressClassParams",
+						Name:     "awesome-class-params",
+					},
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildIngressClassParamsRefIndexes(tt.ingClass)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
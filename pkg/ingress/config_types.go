@@ -75,14 +75,24 @@ type TargetGroupTuple struct {
 	// the K8s service port
 	ServicePort *intstr.IntOrString `json:"servicePort"`
 
+	// The Amazon Resource Name (ARN) of the Lambda function.
+	// +optional
+	LambdaFunctionARN *string `json:"lambdaFunctionARN,omitempty"`
+
 	// The weight.
 	// +optional
 	Weight *int64 `json:"weight,omitempty"`
 }
 
 func (t *TargetGroupTuple) validate() error {
-	if (t.TargetGroupARN != nil) == (t.ServiceName != nil) {
-		return errors.New("precisely one of targetGroupARN and serviceName can be specified")
+	specifiedTargets := 0
+	for _, target := range []*string{t.TargetGroupARN, t.ServiceName, t.LambdaFunctionARN} {
+		if target != nil {
+			specifiedTargets++
+		}
+	}
+	if specifiedTargets != 1 {
+		return errors.New("precisely one of targetGroupARN, serviceName and lambdaFunctionARN can be specified")
 	}
 
 	if t.ServiceName != nil && t.ServicePort == nil {
@@ -138,6 +148,16 @@ const (
 	ActionTypeRedirect      ActionType = "redirect"
 )
 
+// EmptyEndpointsAction specifies how to route traffic for a service that has no ready endpoints.
+type EmptyEndpointsAction string
+
+const (
+	// EmptyEndpointsActionKeepTargetGroup keeps forwarding to the empty target group.
+	EmptyEndpointsActionKeepTargetGroup EmptyEndpointsAction = "keep-target-group"
+	// EmptyEndpointsActionFixedResponse responds with a fixed response until endpoints return.
+	EmptyEndpointsActionFixedResponse EmptyEndpointsAction = "fixed-response"
+)
+
 type Action struct {
 	// The type of action.
 	Type ActionType `json:"type"`
@@ -7,6 +7,7 @@ import (
 	networking "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 )
 
 const (
@@ -19,6 +20,8 @@ const (
 type EnhancedBackend struct {
 	Conditions []RuleCondition
 	Action     Action
+	// ListenProtocols restricts the listener protocols this backend applies to, empty means all listeners.
+	ListenProtocols []elbv2model.Protocol
 }
 
 // EnhancedBackendBuilder is capable of build  EnhancedBackend for Ingress backend.
@@ -46,6 +49,11 @@ func (b *defaultEnhancedBackendBuilder) Build(ctx context.Context, ing *networki
 		return EnhancedBackend{}, err
 	}
 
+	listenProtocols, err := b.buildListenProtocols(ctx, ing.Annotations, backend.ServiceName)
+	if err != nil {
+		return EnhancedBackend{}, err
+	}
+
 	var action Action
 	if backend.ServicePort.String() == magicServicePortUseAnnotation {
 		action, err = b.buildActionViaAnnotation(ctx, ing.Annotations, backend.ServiceName)
@@ -57,11 +65,25 @@ func (b *defaultEnhancedBackendBuilder) Build(ctx context.Context, ing *networki
 	}
 
 	return EnhancedBackend{
-		Conditions: conditions,
-		Action:     action,
+		Conditions:      conditions,
+		Action:          action,
+		ListenProtocols: listenProtocols,
 	}, nil
 }
 
+// AppliesToProtocol checks whether this backend should be routed on listeners with specific protocol.
+func (b EnhancedBackend) AppliesToProtocol(protocol elbv2model.Protocol) bool {
+	if len(b.ListenProtocols) == 0 {
+		return true
+	}
+	for _, listenProtocol := range b.ListenProtocols {
+		if listenProtocol == protocol {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *defaultEnhancedBackendBuilder) buildConditions(_ context.Context, ingAnnotation map[string]string, svcName string) ([]RuleCondition, error) {
 	var conditions []RuleCondition
 	annotationKey := fmt.Sprintf("conditions.%v", svcName)
@@ -77,6 +99,29 @@ func (b *defaultEnhancedBackendBuilder) buildConditions(_ context.Context, ingAn
 	return conditions, nil
 }
 
+func (b *defaultEnhancedBackendBuilder) buildListenProtocols(_ context.Context, ingAnnotation map[string]string, svcName string) ([]elbv2model.Protocol, error) {
+	var rawListenProtocols []string
+	annotationKey := fmt.Sprintf("listen-protocols.%v", svcName)
+	if exists := b.annotationParser.ParseStringSliceAnnotation(annotationKey, &rawListenProtocols, ingAnnotation); !exists {
+		return nil, nil
+	}
+	if len(rawListenProtocols) == 0 {
+		return nil, errors.Errorf("empty %v configuration", annotationKey)
+	}
+	listenProtocols := make([]elbv2model.Protocol, 0, len(rawListenProtocols))
+	for _, rawListenProtocol := range rawListenProtocols {
+		switch rawListenProtocol {
+		case string(elbv2model.ProtocolHTTP):
+			listenProtocols = append(listenProtocols, elbv2model.ProtocolHTTP)
+		case string(elbv2model.ProtocolHTTPS):
+			listenProtocols = append(listenProtocols, elbv2model.ProtocolHTTPS)
+		default:
+			return nil, errors.Errorf("listen protocol must be within [%v, %v]: %v", elbv2model.ProtocolHTTP, elbv2model.ProtocolHTTPS, rawListenProtocol)
+		}
+	}
+	return listenProtocols, nil
+}
+
 func (b *defaultEnhancedBackendBuilder) buildActionViaAnnotation(_ context.Context, ingAnnotation map[string]string, svcName string) (Action, error) {
 	action := Action{}
 	annotationKey := fmt.Sprintf("actions.%v", svcName)
@@ -10,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
 )
 
@@ -158,6 +159,36 @@ func Test_defaultEnhancedBackendBuilder_Build(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "vanilla serviceBackend with listen protocols",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/listen-protocols.my-svc": "HTTPS",
+						},
+					},
+				},
+				backend: networking.IngressBackend{
+					ServiceName: "my-svc",
+					ServicePort: portHTTP,
+				},
+			},
+			want: EnhancedBackend{
+				Action: Action{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName: awssdk.String("my-svc"),
+								ServicePort: &portHTTP,
+							},
+						},
+					},
+				},
+				ListenProtocols: []elbv2model.Protocol{elbv2model.ProtocolHTTPS},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -176,6 +207,133 @@ func Test_defaultEnhancedBackendBuilder_Build(t *testing.T) {
 	}
 }
 
+func Test_defaultEnhancedBackendBuilder_buildListenProtocols(t *testing.T) {
+	type args struct {
+		ingAnnotation map[string]string
+		svcName       string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []elbv2model.Protocol
+		wantErr error
+	}{
+		{
+			name: "listen protocols not specified",
+			args: args{
+				ingAnnotation: map[string]string{},
+				svcName:       "my-svc",
+			},
+			want: nil,
+		},
+		{
+			name: "HTTP only",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/listen-protocols.my-svc": "HTTP",
+				},
+				svcName: "my-svc",
+			},
+			want: []elbv2model.Protocol{elbv2model.ProtocolHTTP},
+		},
+		{
+			name: "both HTTP and HTTPS",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/listen-protocols.my-svc": "HTTP, HTTPS",
+				},
+				svcName: "my-svc",
+			},
+			want: []elbv2model.Protocol{elbv2model.ProtocolHTTP, elbv2model.ProtocolHTTPS},
+		},
+		{
+			name: "listen protocols for other service",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/listen-protocols.other-svc": "HTTPS",
+				},
+				svcName: "my-svc",
+			},
+			want: nil,
+		},
+		{
+			name: "empty listen protocols",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/listen-protocols.my-svc": "",
+				},
+				svcName: "my-svc",
+			},
+			wantErr: errors.New("empty listen-protocols.my-svc configuration"),
+		},
+		{
+			name: "invalid listen protocol",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/listen-protocols.my-svc": "TCP",
+				},
+				svcName: "my-svc",
+			},
+			wantErr: errors.New("listen protocol must be within [HTTP, HTTPS]: TCP"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			b := &defaultEnhancedBackendBuilder{
+				annotationParser: annotationParser,
+			}
+			got, err := b.buildListenProtocols(context.Background(), tt.args.ingAnnotation, tt.args.svcName)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEnhancedBackend_AppliesToProtocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		backend  EnhancedBackend
+		protocol elbv2model.Protocol
+		want     bool
+	}{
+		{
+			name:     "unscoped backend applies to HTTP",
+			backend:  EnhancedBackend{},
+			protocol: elbv2model.ProtocolHTTP,
+			want:     true,
+		},
+		{
+			name:     "unscoped backend applies to HTTPS",
+			backend:  EnhancedBackend{},
+			protocol: elbv2model.ProtocolHTTPS,
+			want:     true,
+		},
+		{
+			name:     "HTTPS scoped backend applies to HTTPS",
+			backend:  EnhancedBackend{ListenProtocols: []elbv2model.Protocol{elbv2model.ProtocolHTTPS}},
+			protocol: elbv2model.ProtocolHTTPS,
+			want:     true,
+		},
+		{
+			name:     "HTTPS scoped backend doesn't apply to HTTP",
+			backend:  EnhancedBackend{ListenProtocols: []elbv2model.Protocol{elbv2model.ProtocolHTTPS}},
+			protocol: elbv2model.ProtocolHTTP,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.backend.AppliesToProtocol(tt.protocol)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_defaultEnhancedBackendBuilder_buildConditions(t *testing.T) {
 	type args struct {
 		ingAnnotation map[string]string
@@ -597,6 +755,35 @@ func Test_defaultEnhancedBackendBuilder_buildActionViaAnnotation(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "forward action - lambda function",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.forward-lambda": `{"type":"forward","forwardConfig":{"targetGroups":[{"lambdaFunctionARN":"arn:aws:lambda:us-west-2:123456789012:function:my-function"}]}}`,
+				},
+				svcName: "forward-lambda",
+			},
+			want: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{
+							LambdaFunctionARN: awssdk.String("arn:aws:lambda:us-west-2:123456789012:function:my-function"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "forward action - both lambda function and service specified",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.forward-lambda": `{"type":"forward","forwardConfig":{"targetGroups":[{"lambdaFunctionARN":"arn:aws:lambda:us-west-2:123456789012:function:my-function","serviceName":"service-1","servicePort":"http"}]}}`,
+				},
+				svcName: "forward-lambda",
+			},
+			wantErr: errors.New("invalid ForwardConfig: invalid TargetGroupTuple: precisely one of targetGroupARN, serviceName and lambdaFunctionARN can be specified"),
+		},
 		{
 			name: "forward action - advanced schema - old camelcase case json key",
 			args: args{
@@ -4,6 +4,8 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	networking "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"regexp"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
@@ -55,7 +57,11 @@ type defaultGroupLoader struct {
 }
 
 func (m *defaultGroupLoader) FindGroupID(ctx context.Context, ing *networking.Ingress) (*GroupID, error) {
-	if !m.matchesIngressClass(ing) {
+	matchesIngressClass, err := m.matchesIngressClass(ctx, ing)
+	if err != nil {
+		return nil, err
+	}
+	if !matchesIngressClass {
 		return nil, nil
 	}
 
@@ -105,12 +111,29 @@ func (m *defaultGroupLoader) Load(ctx context.Context, groupID GroupID) (Group,
 }
 
 // matchesIngressClass tests whether Ingress matches ingress class of this group manager.
-func (m *defaultGroupLoader) matchesIngressClass(ing *networking.Ingress) bool {
-	ingClass := ing.Annotations[annotations.IngressClass]
-	if m.ingressClass == "" {
-		return ingClass == "" || ingClass == defaultIngressClass
+// the ingress class annotation takes precedence over the IngressClassName field.
+func (m *defaultGroupLoader) matchesIngressClass(ctx context.Context, ing *networking.Ingress) (bool, error) {
+	if ingClass, exists := ing.Annotations[annotations.IngressClass]; exists {
+		if m.ingressClass == "" {
+			return ingClass == "" || ingClass == defaultIngressClass, nil
+		}
+		return ingClass == m.ingressClass, nil
+	}
+	if ing.Spec.IngressClassName != nil {
+		if m.ingressClass != "" && *ing.Spec.IngressClassName != m.ingressClass {
+			return false, nil
+		}
+		ingClass := &networking.IngressClass{}
+		if err := m.client.Get(ctx, types.NamespacedName{Name: *ing.Spec.IngressClassName}, ingClass); err != nil {
+			// Ingresses referencing an IngressClass that doesn't exist yet are not ours, they'll be enqueued once it's created.
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, errors.Wrapf(err, "failed to get IngressClass %v", *ing.Spec.IngressClassName)
+		}
+		return ingClass.Spec.Controller == ingressClassControllerALB, nil
 	}
-	return ingClass == m.ingressClass
+	return m.ingressClass == "", nil
 }
 
 // isGroupMember checks whether an ingress is member of a Ingress group
@@ -2,13 +2,17 @@ package ingress
 
 import (
 	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	mock_client "sigs.k8s.io/aws-load-balancer-controller/mocks/controller-runtime/client"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
 )
 
@@ -594,11 +598,29 @@ func Test_defaultGroupLoader_Load(t *testing.T) {
 }
 
 func Test_defaultGroupLoader_matchesIngressClass(t *testing.T) {
+	albIngressClass := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "alb",
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: "ingress.k8s.aws/alb",
+		},
+	}
+	nginxIngressClass := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "nginx",
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: "k8s.io/ingress-nginx",
+		},
+	}
 	tests := []struct {
 		name         string
 		ingressClass string
+		ingClasses   []*networking.IngressClass
 		ing          *networking.Ingress
 		want         bool
+		wantErr      error
 	}{
 		{
 			name:         "desire empty ingress class and no ingress class specified",
@@ -668,15 +690,100 @@ func Test_defaultGroupLoader_matchesIngressClass(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name:         "desire empty ingress class and IngressClass with alb controller specified",
+			ingressClass: "",
+			ingClasses:   []*networking.IngressClass{albIngressClass, nginxIngressClass},
+			ing: &networking.Ingress{
+				Spec: networking.IngressSpec{
+					IngressClassName: awssdk.String("alb"),
+				},
+			},
+			want: true,
+		},
+		{
+			name:         "desire empty ingress class but IngressClass with another controller specified",
+			ingressClass: "",
+			ingClasses:   []*networking.IngressClass{albIngressClass, nginxIngressClass},
+			ing: &networking.Ingress{
+				Spec: networking.IngressSpec{
+					IngressClassName: awssdk.String("nginx"),
+				},
+			},
+			want: false,
+		},
+		{
+			name:         "desire alb ingress class and IngressClass with alb controller specified",
+			ingressClass: "alb",
+			ingClasses:   []*networking.IngressClass{albIngressClass, nginxIngressClass},
+			ing: &networking.Ingress{
+				Spec: networking.IngressSpec{
+					IngressClassName: awssdk.String("alb"),
+				},
+			},
+			want: true,
+		},
+		{
+			name:         "desire alb ingress class but another IngressClass specified",
+			ingressClass: "alb",
+			ingClasses:   []*networking.IngressClass{albIngressClass, nginxIngressClass},
+			ing: &networking.Ingress{
+				Spec: networking.IngressSpec{
+					IngressClassName: awssdk.String("nginx"),
+				},
+			},
+			want: false,
+		},
+		{
+			name:         "ingress class annotation takes precedence over IngressClass",
+			ingressClass: "",
+			ingClasses:   []*networking.IngressClass{albIngressClass, nginxIngressClass},
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"kubernetes.io/ingress.class": "alb",
+					},
+				},
+				Spec: networking.IngressSpec{
+					IngressClassName: awssdk.String("nginx"),
+				},
+			},
+			want: true,
+		},
+		{
+			name:         "IngressClass not found",
+			ingressClass: "",
+			ing: &networking.Ingress{
+				Spec: networking.IngressSpec{
+					IngressClassName: awssdk.String("alb"),
+				},
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, ingClass := range tt.ingClasses {
+				err := k8sClient.Create(ctx, ingClass.DeepCopy())
+				assert.NoError(t, err)
+			}
+
 			m := &defaultGroupLoader{
+				client:       k8sClient,
 				ingressClass: tt.ingressClass,
 			}
-			got := m.matchesIngressClass(tt.ing)
-			assert.Equal(t, tt.want, got)
+			got, err := m.matchesIngressClass(ctx, tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
 		})
 	}
 }
@@ -0,0 +1,63 @@
+package ingress
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"time"
+)
+
+const (
+	// the existence of health checks will be cached for 5 minute.
+	defaultExistingHealthChecksCacheTTL = 5 * time.Minute
+)
+
+// HealthCheckValidator is responsible for validate Route53 health checks referenced by Ingresses.
+type HealthCheckValidator interface {
+	// Validate checks whether Route53 health check exists.
+	Validate(ctx context.Context, healthCheckID string) error
+}
+
+// NewRoute53HealthCheckValidator constructs new route53HealthCheckValidator.
+func NewRoute53HealthCheckValidator(route53Client services.Route53, logger logr.Logger) *route53HealthCheckValidator {
+	return &route53HealthCheckValidator{
+		route53Client:                route53Client,
+		logger:                       logger,
+		existingHealthChecksCache:    cache.NewExpiring(),
+		existingHealthChecksCacheTTL: defaultExistingHealthChecksCacheTTL,
+	}
+}
+
+var _ HealthCheckValidator = &route53HealthCheckValidator{}
+
+// HealthCheckValidator implementation for Route53 health checks.
+type route53HealthCheckValidator struct {
+	route53Client services.Route53
+	logger        logr.Logger
+
+	existingHealthChecksCache    *cache.Expiring
+	existingHealthChecksCacheTTL time.Duration
+}
+
+func (v *route53HealthCheckValidator) Validate(ctx context.Context, healthCheckID string) error {
+	if _, exists := v.existingHealthChecksCache.Get(healthCheckID); exists {
+		return nil
+	}
+	req := &route53.GetHealthCheckInput{
+		HealthCheckId: aws.String(healthCheckID),
+	}
+	if _, err := v.route53Client.GetHealthCheckWithContext(ctx, req); err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == route53.ErrCodeNoSuchHealthCheck {
+			return errors.Errorf("route53 health check %v doesn't exist", healthCheckID)
+		}
+		return errors.Wrapf(err, "failed to get route53 health check %v", healthCheckID)
+	}
+	v.existingHealthChecksCache.Set(healthCheckID, true, v.existingHealthChecksCacheTTL)
+	return nil
+}
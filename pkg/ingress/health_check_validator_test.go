@@ -0,0 +1,96 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_route53HealthCheckValidator_Validate(t *testing.T) {
+	type getHealthCheckCall struct {
+		req  *route53.GetHealthCheckInput
+		resp *route53.GetHealthCheckOutput
+		err  error
+	}
+	healthCheckID := "abcdef01-2345-6789-abcd-ef0123456789"
+	tests := []struct {
+		name                string
+		getHealthCheckCalls []getHealthCheckCall
+		validateTimes       int
+		wantErr             error
+	}{
+		{
+			name: "health check exists",
+			getHealthCheckCalls: []getHealthCheckCall{
+				{
+					req: &route53.GetHealthCheckInput{HealthCheckId: awssdk.String(healthCheckID)},
+					resp: &route53.GetHealthCheckOutput{
+						HealthCheck: &route53.HealthCheck{Id: awssdk.String(healthCheckID)},
+					},
+				},
+			},
+			validateTimes: 1,
+		},
+		{
+			name: "existing health check should be cached",
+			getHealthCheckCalls: []getHealthCheckCall{
+				{
+					req: &route53.GetHealthCheckInput{HealthCheckId: awssdk.String(healthCheckID)},
+					resp: &route53.GetHealthCheckOutput{
+						HealthCheck: &route53.HealthCheck{Id: awssdk.String(healthCheckID)},
+					},
+				},
+			},
+			validateTimes: 2,
+		},
+		{
+			name: "health check doesn't exist",
+			getHealthCheckCalls: []getHealthCheckCall{
+				{
+					req: &route53.GetHealthCheckInput{HealthCheckId: awssdk.String(healthCheckID)},
+					err: awserr.New(route53.ErrCodeNoSuchHealthCheck, "No health check exists with the specified ID.", nil),
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("route53 health check abcdef01-2345-6789-abcd-ef0123456789 doesn't exist"),
+		},
+		{
+			name: "failed to get health check",
+			getHealthCheckCalls: []getHealthCheckCall{
+				{
+					req: &route53.GetHealthCheckInput{HealthCheckId: awssdk.String(healthCheckID)},
+					err: errors.New("some error"),
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("failed to get route53 health check abcdef01-2345-6789-abcd-ef0123456789: some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			route53Client := mock_services.NewMockRoute53(ctrl)
+			for _, call := range tt.getHealthCheckCalls {
+				route53Client.EXPECT().GetHealthCheckWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			v := NewRoute53HealthCheckValidator(route53Client, &log.NullLogger{})
+			for i := 0; i < tt.validateTimes; i++ {
+				err := v.Validate(context.Background(), healthCheckID)
+				if tt.wantErr != nil {
+					assert.EqualError(t, err, tt.wantErr.Error())
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package ingress
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"time"
+)
+
+const (
+	elbServicePrincipal        = "elasticloadbalancing.amazonaws.com"
+	lambdaActionInvokeFunction = "lambda:InvokeFunction"
+	lambdaActionAll            = "lambda:*"
+	lambdaQualifierLatest      = "$LATEST"
+	// the lambda functions that grant invoke permission to ELB will be cached for 5 minute.
+	defaultLambdaPermissionCacheTTL = 5 * time.Minute
+)
+
+// LambdaPermissionValidator is responsible for validate lambda functions can be invoked by ELB.
+type LambdaPermissionValidator interface {
+	// Validate checks whether lambda function grants invoke permission to ELB.
+	// for lambda function ARN qualified by an alias, it also checks whether the alias exists.
+	Validate(ctx context.Context, lambdaFunctionARN string) error
+}
+
+// NewDefaultLambdaPermissionValidator constructs new defaultLambdaPermissionValidator.
+func NewDefaultLambdaPermissionValidator(lambdaClient services.Lambda, logger logr.Logger) *defaultLambdaPermissionValidator {
+	return &defaultLambdaPermissionValidator{
+		lambdaClient:       lambdaClient,
+		logger:             logger,
+		permissionCache:    cache.NewExpiring(),
+		permissionCacheTTL: defaultLambdaPermissionCacheTTL,
+	}
+}
+
+var _ LambdaPermissionValidator = &defaultLambdaPermissionValidator{}
+
+// default implementation for LambdaPermissionValidator, which inspects lambda function's resource-based policy.
+type defaultLambdaPermissionValidator struct {
+	lambdaClient services.Lambda
+	logger       logr.Logger
+
+	permissionCache    *cache.Expiring
+	permissionCacheTTL time.Duration
+}
+
+func (v *defaultLambdaPermissionValidator) Validate(ctx context.Context, lambdaFunctionARN string) error {
+	if _, exists := v.permissionCache.Get(lambdaFunctionARN); exists {
+		return nil
+	}
+	if err := v.validateAlias(ctx, lambdaFunctionARN); err != nil {
+		return err
+	}
+	req := &lambda.GetPolicyInput{
+		FunctionName: aws.String(lambdaFunctionARN),
+	}
+	resp, err := v.lambdaClient.GetPolicyWithContext(ctx, req)
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == lambda.ErrCodeResourceNotFoundException {
+			return errors.Errorf("lambda function %v doesn't grant invoke permission to %v", lambdaFunctionARN, elbServicePrincipal)
+		}
+		return errors.Wrapf(err, "failed to get policy for lambda function %v", lambdaFunctionARN)
+	}
+	allowed, err := isInvokeAllowedForELB(aws.StringValue(resp.Policy))
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse policy for lambda function %v", lambdaFunctionARN)
+	}
+	if !allowed {
+		return errors.Errorf("lambda function %v doesn't grant invoke permission to %v", lambdaFunctionARN, elbServicePrincipal)
+	}
+	v.permissionCache.Set(lambdaFunctionARN, true, v.permissionCacheTTL)
+	return nil
+}
+
+// validateAlias checks whether the alias that qualifies lambda function ARN exists.
+// lambda function ARNs that are unqualified or qualified by a version are skipped.
+func (v *defaultLambdaPermissionValidator) validateAlias(ctx context.Context, lambdaFunctionARN string) error {
+	unqualifiedARN, qualifier, err := parseLambdaFunctionARN(lambdaFunctionARN)
+	if err != nil {
+		return err
+	}
+	if !isLambdaAliasQualifier(qualifier) {
+		return nil
+	}
+	req := &lambda.GetAliasInput{
+		FunctionName: aws.String(unqualifiedARN),
+		Name:         aws.String(qualifier),
+	}
+	if _, err := v.lambdaClient.GetAliasWithContext(ctx, req); err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == lambda.ErrCodeResourceNotFoundException {
+			return errors.Errorf("lambda alias %v doesn't exist for function %v", qualifier, unqualifiedARN)
+		}
+		return errors.Wrapf(err, "failed to get alias %v for lambda function %v", qualifier, unqualifiedARN)
+	}
+	return nil
+}
+
+// isLambdaAliasQualifier checks whether the qualifier of lambda function ARN is an alias instead of a version.
+func isLambdaAliasQualifier(qualifier string) bool {
+	if qualifier == "" || qualifier == lambdaQualifierLatest {
+		return false
+	}
+	for _, c := range qualifier {
+		if c < '0' || c > '9' {
+			return true
+		}
+	}
+	return false
+}
+
+// lambdaPolicyDocument is the resource-based policy attached to lambda function.
+type lambdaPolicyDocument struct {
+	Statement []lambdaPolicyStatement `json:"Statement"`
+}
+
+type lambdaPolicyStatement struct {
+	Effect    string          `json:"Effect"`
+	Principal json.RawMessage `json:"Principal"`
+	Action    stringOrSlice   `json:"Action"`
+}
+
+type lambdaPolicyPrincipal struct {
+	Service stringOrSlice `json:"Service"`
+}
+
+// stringOrSlice represents policy elements that can either be a single string or a list of strings.
+type stringOrSlice []string
+
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = []string{single}
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return err
+	}
+	*s = multi
+	return nil
+}
+
+// isInvokeAllowedForELB checks whether policy allows ELB to invoke the lambda function.
+func isInvokeAllowedForELB(rawPolicy string) (bool, error) {
+	var policy lambdaPolicyDocument
+	if err := json.Unmarshal([]byte(rawPolicy), &policy); err != nil {
+		return false, err
+	}
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+		if !containsLambdaInvokeAction(statement.Action) {
+			continue
+		}
+		// principal can either be "*" or an object that specifies service principals.
+		var wildcardPrincipal string
+		if err := json.Unmarshal(statement.Principal, &wildcardPrincipal); err == nil {
+			if wildcardPrincipal == "*" {
+				return true, nil
+			}
+			continue
+		}
+		var principal lambdaPolicyPrincipal
+		if err := json.Unmarshal(statement.Principal, &principal); err != nil {
+			continue
+		}
+		for _, service := range principal.Service {
+			if service == elbServicePrincipal {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func containsLambdaInvokeAction(actions []string) bool {
+	for _, action := range actions {
+		if action == lambdaActionInvokeFunction || action == lambdaActionAll || action == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultLambdaPermissionValidator_Validate(t *testing.T) {
+	type getPolicyCall struct {
+		req  *lambda.GetPolicyInput
+		resp *lambda.GetPolicyOutput
+		err  error
+	}
+	type getAliasCall struct {
+		req *lambda.GetAliasInput
+		err error
+	}
+	lambdaARN := "arn:aws:lambda:us-west-2:123456789012:function:my-function"
+	aliasLambdaARN := "arn:aws:lambda:us-west-2:123456789012:function:my-function:live"
+	elbInvokePolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"elasticloadbalancing.amazonaws.com"},"Action":"lambda:InvokeFunction"}]}`
+	tests := []struct {
+		name              string
+		lambdaFunctionARN string
+		getAliasCalls     []getAliasCall
+		getPolicyCalls    []getPolicyCall
+		validateTimes     int
+		wantErr           error
+	}{
+		{
+			name: "invoke permission granted to ELB",
+			getPolicyCalls: []getPolicyCall{
+				{
+					req: &lambda.GetPolicyInput{FunctionName: awssdk.String(lambdaARN)},
+					resp: &lambda.GetPolicyOutput{
+						Policy: awssdk.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"elasticloadbalancing.amazonaws.com"},"Action":"lambda:InvokeFunction","Resource":"arn:aws:lambda:us-west-2:123456789012:function:my-function"}]}`),
+					},
+				},
+			},
+			validateTimes: 1,
+		},
+		{
+			name: "invoke permission granted to ELB should be cached",
+			getPolicyCalls: []getPolicyCall{
+				{
+					req: &lambda.GetPolicyInput{FunctionName: awssdk.String(lambdaARN)},
+					resp: &lambda.GetPolicyOutput{
+						Policy: awssdk.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"elasticloadbalancing.amazonaws.com"},"Action":"lambda:InvokeFunction","Resource":"arn:aws:lambda:us-west-2:123456789012:function:my-function"}]}`),
+					},
+				},
+			},
+			validateTimes: 2,
+		},
+		{
+			name: "invoke permission not granted to ELB",
+			getPolicyCalls: []getPolicyCall{
+				{
+					req: &lambda.GetPolicyInput{FunctionName: awssdk.String(lambdaARN)},
+					resp: &lambda.GetPolicyOutput{
+						Policy: awssdk.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"s3.amazonaws.com"},"Action":"lambda:InvokeFunction","Resource":"arn:aws:lambda:us-west-2:123456789012:function:my-function"}]}`),
+					},
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("lambda function arn:aws:lambda:us-west-2:123456789012:function:my-function doesn't grant invoke permission to elasticloadbalancing.amazonaws.com"),
+		},
+		{
+			name: "lambda function without policy",
+			getPolicyCalls: []getPolicyCall{
+				{
+					req: &lambda.GetPolicyInput{FunctionName: awssdk.String(lambdaARN)},
+					err: awserr.New(lambda.ErrCodeResourceNotFoundException, "The resource you requested does not exist.", nil),
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("lambda function arn:aws:lambda:us-west-2:123456789012:function:my-function doesn't grant invoke permission to elasticloadbalancing.amazonaws.com"),
+		},
+		{
+			name: "failed to get policy",
+			getPolicyCalls: []getPolicyCall{
+				{
+					req: &lambda.GetPolicyInput{FunctionName: awssdk.String(lambdaARN)},
+					err: errors.New("some error"),
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("failed to get policy for lambda function arn:aws:lambda:us-west-2:123456789012:function:my-function: some error"),
+		},
+		{
+			name:              "lambda alias exists and grants invoke permission to ELB",
+			lambdaFunctionARN: aliasLambdaARN,
+			getAliasCalls: []getAliasCall{
+				{
+					req: &lambda.GetAliasInput{FunctionName: awssdk.String(lambdaARN), Name: awssdk.String("live")},
+				},
+			},
+			getPolicyCalls: []getPolicyCall{
+				{
+					req:  &lambda.GetPolicyInput{FunctionName: awssdk.String(aliasLambdaARN)},
+					resp: &lambda.GetPolicyOutput{Policy: awssdk.String(elbInvokePolicy)},
+				},
+			},
+			validateTimes: 2,
+		},
+		{
+			name:              "lambda alias doesn't exist",
+			lambdaFunctionARN: aliasLambdaARN,
+			getAliasCalls: []getAliasCall{
+				{
+					req: &lambda.GetAliasInput{FunctionName: awssdk.String(lambdaARN), Name: awssdk.String("live")},
+					err: awserr.New(lambda.ErrCodeResourceNotFoundException, "Cannot find alias arn", nil),
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("lambda alias live doesn't exist for function arn:aws:lambda:us-west-2:123456789012:function:my-function"),
+		},
+		{
+			name:              "failed to get lambda alias",
+			lambdaFunctionARN: aliasLambdaARN,
+			getAliasCalls: []getAliasCall{
+				{
+					req: &lambda.GetAliasInput{FunctionName: awssdk.String(lambdaARN), Name: awssdk.String("live")},
+					err: errors.New("some error"),
+				},
+			},
+			validateTimes: 1,
+			wantErr:       errors.New("failed to get alias live for lambda function arn:aws:lambda:us-west-2:123456789012:function:my-function: some error"),
+		},
+		{
+			name:              "lambda version doesn't require alias lookup",
+			lambdaFunctionARN: "arn:aws:lambda:us-west-2:123456789012:function:my-function:3",
+			getPolicyCalls: []getPolicyCall{
+				{
+					req:  &lambda.GetPolicyInput{FunctionName: awssdk.String("arn:aws:lambda:us-west-2:123456789012:function:my-function:3")},
+					resp: &lambda.GetPolicyOutput{Policy: awssdk.String(elbInvokePolicy)},
+				},
+			},
+			validateTimes: 1,
+		},
+		{
+			name:              "malformed lambda function ARN",
+			lambdaFunctionARN: "arn:aws:lambda:us-west-2:123456789012:function:my-function:live:extra",
+			validateTimes:     1,
+			wantErr:           errors.New("invalid lambda function ARN: arn:aws:lambda:us-west-2:123456789012:function:my-function:live:extra"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			lambdaClient := mock_services.NewMockLambda(ctrl)
+			for _, call := range tt.getAliasCalls {
+				lambdaClient.EXPECT().GetAliasWithContext(gomock.Any(), call.req).Return(&lambda.AliasConfiguration{}, call.err)
+			}
+			for _, call := range tt.getPolicyCalls {
+				lambdaClient.EXPECT().GetPolicyWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			lambdaFunctionARN := lambdaARN
+			if tt.lambdaFunctionARN != "" {
+				lambdaFunctionARN = tt.lambdaFunctionARN
+			}
+			v := NewDefaultLambdaPermissionValidator(lambdaClient, &log.NullLogger{})
+			for i := 0; i < tt.validateTimes; i++ {
+				err := v.Validate(context.Background(), lambdaFunctionARN)
+				if tt.wantErr != nil {
+					assert.EqualError(t, err, tt.wantErr.Error())
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
+func Test_isInvokeAllowedForELB(t *testing.T) {
+	tests := []struct {
+		name      string
+		rawPolicy string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "allowed via single service principal and action",
+			rawPolicy: `{"Statement":[{"Effect":"Allow","Principal":{"Service":"elasticloadbalancing.amazonaws.com"},"Action":"lambda:InvokeFunction"}]}`,
+			want:      true,
+		},
+		{
+			name:      "allowed via list of service principals and actions",
+			rawPolicy: `{"Statement":[{"Effect":"Allow","Principal":{"Service":["s3.amazonaws.com","elasticloadbalancing.amazonaws.com"]},"Action":["lambda:GetFunction","lambda:*"]}]}`,
+			want:      true,
+		},
+		{
+			name:      "allowed via wildcard principal",
+			rawPolicy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"lambda:InvokeFunction"}]}`,
+			want:      true,
+		},
+		{
+			name:      "denied statement",
+			rawPolicy: `{"Statement":[{"Effect":"Deny","Principal":{"Service":"elasticloadbalancing.amazonaws.com"},"Action":"lambda:InvokeFunction"}]}`,
+			want:      false,
+		},
+		{
+			name:      "other action",
+			rawPolicy: `{"Statement":[{"Effect":"Allow","Principal":{"Service":"elasticloadbalancing.amazonaws.com"},"Action":"lambda:GetFunction"}]}`,
+			want:      false,
+		},
+		{
+			name:      "aws account principal",
+			rawPolicy: `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"lambda:InvokeFunction"}]}`,
+			want:      false,
+		},
+		{
+			name:      "malformed policy",
+			rawPolicy: `{"Statement":`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isInvokeAllowedForELB(tt.rawPolicy)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
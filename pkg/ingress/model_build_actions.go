@@ -6,15 +6,20 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
 	"strings"
 	"unicode"
 )
 
-func (t *defaultModelBuildTask) buildActions(ctx context.Context, protocol elbv2model.Protocol, ing *networking.Ingress, backend EnhancedBackend) ([]elbv2model.Action, error) {
+// buildActions builds the actions for backend, service backends will be forwarded to sticky targetGroups if stickiness is true.
+func (t *defaultModelBuildTask) buildActions(ctx context.Context, protocol elbv2model.Protocol, ing *networking.Ingress, backend EnhancedBackend, stickiness bool) ([]elbv2model.Action, error) {
 	var actions []elbv2model.Action
 	if protocol == elbv2model.ProtocolHTTPS {
 		authAction, err := t.buildAuthAction(ctx, ing, backend)
@@ -25,7 +30,7 @@ func (t *defaultModelBuildTask) buildActions(ctx context.Context, protocol elbv2
 			actions = append(actions, *authAction)
 		}
 	}
-	backendAction, err := t.buildBackendAction(ctx, ing, backend.Action)
+	backendAction, err := t.buildBackendAction(ctx, ing, backend.Action, stickiness)
 	if err != nil {
 		return nil, err
 	}
@@ -33,14 +38,14 @@ func (t *defaultModelBuildTask) buildActions(ctx context.Context, protocol elbv2
 	return actions, nil
 }
 
-func (t *defaultModelBuildTask) buildBackendAction(ctx context.Context, ing *networking.Ingress, actionCfg Action) (elbv2model.Action, error) {
+func (t *defaultModelBuildTask) buildBackendAction(ctx context.Context, ing *networking.Ingress, actionCfg Action, stickiness bool) (elbv2model.Action, error) {
 	switch actionCfg.Type {
 	case ActionTypeFixedResponse:
 		return t.buildFixedResponseAction(ctx, actionCfg)
 	case ActionTypeRedirect:
 		return t.buildRedirectAction(ctx, actionCfg)
 	case ActionTypeForward:
-		return t.buildForwardAction(ctx, ing, actionCfg)
+		return t.buildForwardAction(ctx, ing, actionCfg, stickiness)
 	}
 	return elbv2model.Action{}, errors.Errorf("unknown action type: %v", actionCfg.Type)
 }
@@ -118,16 +123,27 @@ func (t *defaultModelBuildTask) buildRedirectAction(_ context.Context, actionCfg
 	}, nil
 }
 
-func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing *networking.Ingress, actionCfg Action) (elbv2model.Action, error) {
+func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing *networking.Ingress, actionCfg Action, stickiness bool) (elbv2model.Action, error) {
 	if actionCfg.ForwardConfig == nil {
 		return elbv2model.Action{}, errors.New("missing ForwardConfig")
 	}
 
 	var targetGroupTuples []elbv2model.TargetGroupTuple
+	var backendSVCs []*corev1.Service
 	for _, tgt := range actionCfg.ForwardConfig.TargetGroups {
 		var tgARN core.StringToken
 		if tgt.TargetGroupARN != nil {
 			tgARN = core.LiteralStringToken(*tgt.TargetGroupARN)
+		} else if tgt.LambdaFunctionARN != nil {
+			lambdaFunctionARN := awssdk.StringValue(tgt.LambdaFunctionARN)
+			if err := t.lambdaPermissionValidator.Validate(ctx, lambdaFunctionARN); err != nil {
+				return elbv2model.Action{}, err
+			}
+			tg, err := t.buildLambdaTargetGroup(ctx, ing, lambdaFunctionARN)
+			if err != nil {
+				return elbv2model.Action{}, err
+			}
+			tgARN = tg.TargetGroupARN()
 		} else {
 			svcKey := types.NamespacedName{
 				Namespace: ing.Namespace,
@@ -137,17 +153,29 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing *net
 			if err := t.k8sClient.Get(ctx, svcKey, svc); err != nil {
 				return elbv2model.Action{}, err
 			}
-			tg, err := t.buildTargetGroup(ctx, ing, svc, *tgt.ServicePort)
+			tg, err := t.buildTargetGroup(ctx, ing, svc, *tgt.ServicePort, stickiness)
 			if err != nil {
 				return elbv2model.Action{}, err
 			}
 			tgARN = tg.TargetGroupARN()
+			backendSVCs = append(backendSVCs, svc)
 		}
 		targetGroupTuples = append(targetGroupTuples, elbv2model.TargetGroupTuple{
 			TargetGroupARN: tgARN,
 			Weight:         tgt.Weight,
 		})
 	}
+	// the target group is still built when service have no endpoints, so that targets get registered once endpoints return.
+	if len(targetGroupTuples) == 1 && len(backendSVCs) == 1 {
+		emptyEndpointsAction, err := t.buildEmptyEndpointsAction(ctx, ing, backendSVCs[0])
+		if err != nil {
+			return elbv2model.Action{}, err
+		}
+		if emptyEndpointsAction != nil {
+			return *emptyEndpointsAction, nil
+		}
+	}
+
 	var stickinessCfg *elbv2model.TargetGroupStickinessConfig
 	if actionCfg.ForwardConfig.TargetGroupStickinessConfig != nil {
 		stickinessCfg = &elbv2model.TargetGroupStickinessConfig{
@@ -165,6 +193,105 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing *net
 	}, nil
 }
 
+// buildEmptyEndpointsAction builds the action that replaces forwarding to service without ready endpoints.
+// returns nil if traffic should still be forwarded to service's target group.
+func (t *defaultModelBuildTask) buildEmptyEndpointsAction(ctx context.Context, ing *networking.Ingress, svc *corev1.Service) (*elbv2model.Action, error) {
+	svcAndIngAnnotations := algorithm.MergeStringMap(svc.Annotations, ing.Annotations)
+	rawEmptyEndpointsAction := string(EmptyEndpointsActionKeepTargetGroup)
+	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixEmptyEndpointsAction, &rawEmptyEndpointsAction, svcAndIngAnnotations)
+	switch EmptyEndpointsAction(rawEmptyEndpointsAction) {
+	case EmptyEndpointsActionKeepTargetGroup:
+		return nil, nil
+	case EmptyEndpointsActionFixedResponse:
+	default:
+		return nil, errors.Errorf("unknown empty endpoints action: %v", rawEmptyEndpointsAction)
+	}
+
+	fixedResponseCfg := FixedResponseActionConfig{
+		ContentType: awssdk.String("text/plain"),
+		MessageBody: awssdk.String("Service Unavailable"),
+		StatusCode:  "503",
+	}
+	if _, err := t.annotationParser.ParseJSONAnnotation(annotations.IngressSuffixEmptyEndpointsFixedResponse, &fixedResponseCfg, svcAndIngAnnotations); err != nil {
+		return nil, err
+	}
+	if err := fixedResponseCfg.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid empty endpoints fixed response for service %v", k8s.NamespacedName(svc))
+	}
+
+	hasReadyEndpoints, err := t.hasReadyEndpoints(ctx, svc)
+	if err != nil {
+		return nil, err
+	}
+	if hasReadyEndpoints {
+		return nil, nil
+	}
+	action, err := t.buildFixedResponseAction(ctx, Action{
+		Type:                ActionTypeFixedResponse,
+		FixedResponseConfig: &fixedResponseCfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
+// hasReadyEndpoints checks whether service have any ready endpoints.
+// not ready endpoints whose pods are only blocked by the targetHealth readiness gate are considered ready,
+// since their readiness depends on receiving traffic from the target group.
+func (t *defaultModelBuildTask) hasReadyEndpoints(ctx context.Context, svc *corev1.Service) (bool, error) {
+	epsKey := k8s.NamespacedName(svc) // k8s Endpoints have same name as k8s Service
+	eps := &corev1.Endpoints{}
+	if err := t.k8sClient.Get(ctx, epsKey, eps); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, subset := range eps.Subsets {
+		if len(subset.Addresses) != 0 {
+			return true, nil
+		}
+	}
+	for _, subset := range eps.Subsets {
+		for _, epAddr := range subset.NotReadyAddresses {
+			if epAddr.TargetRef == nil || epAddr.TargetRef.Kind != "Pod" {
+				continue
+			}
+			pod := &corev1.Pod{}
+			if err := t.k8sClient.Get(ctx, types.NamespacedName{Namespace: svc.Namespace, Name: epAddr.TargetRef.Name}, pod); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return false, err
+			}
+			if isPodOnlyBlockedByTargetHealthReadinessGate(pod) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// isPodOnlyBlockedByTargetHealthReadinessGate checks whether pod is containersReady and only waiting for targetHealth readiness gates.
+func isPodOnlyBlockedByTargetHealthReadinessGate(pod *corev1.Pod) bool {
+	if !k8s.IsPodContainersReady(pod) {
+		return false
+	}
+	blockedByTargetHealth := false
+	for _, rg := range pod.Spec.ReadinessGates {
+		cond := k8s.GetPodCondition(pod, rg.ConditionType)
+		if cond != nil && cond.Status == corev1.ConditionTrue {
+			continue
+		}
+		if !strings.HasPrefix(string(rg.ConditionType), targetgroupbinding.TargetHealthPodConditionTypePrefix+"/") {
+			return false
+		}
+		blockedByTargetHealth = true
+	}
+	return blockedByTargetHealth
+}
+
 func (t *defaultModelBuildTask) buildAuthenticateCognitoAction(_ context.Context, authCfg AuthConfig) (elbv2model.Action, error) {
 	if authCfg.IDPConfigCognito == nil {
 		return elbv2model.Action{}, errors.New("missing IDPConfigCognito")
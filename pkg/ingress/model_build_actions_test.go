@@ -3,12 +3,17 @@ package ingress
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	mock_ingress "sigs.k8s.io/aws-load-balancer-controller/mocks/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
@@ -248,3 +253,383 @@ func Test_defaultModelBuildTask_buildAuthenticateOIDCAction(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildForwardAction_lambdaFunction(t *testing.T) {
+	type validateCall struct {
+		lambdaFunctionARN string
+		err               error
+	}
+	type args struct {
+		actionCfg Action
+	}
+	lambdaARN := "arn:aws:lambda:us-west-2:123456789012:function:my-function"
+	tests := []struct {
+		name                   string
+		validateCalls          []validateCall
+		args                   args
+		wantLambdaFunctionARNs []string
+		wantErr                error
+	}{
+		{
+			name: "lambda function with invoke permission",
+			validateCalls: []validateCall{
+				{
+					lambdaFunctionARN: lambdaARN,
+				},
+			},
+			args: args{
+				actionCfg: Action{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								LambdaFunctionARN: awssdk.String(lambdaARN),
+							},
+						},
+					},
+				},
+			},
+			wantLambdaFunctionARNs: []string{lambdaARN},
+		},
+		{
+			name: "lambda function without invoke permission",
+			validateCalls: []validateCall{
+				{
+					lambdaFunctionARN: lambdaARN,
+					err:               errors.New("lambda function arn:aws:lambda:us-west-2:123456789012:function:my-function doesn't grant invoke permission to elasticloadbalancing.amazonaws.com"),
+				},
+			},
+			args: args{
+				actionCfg: Action{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								LambdaFunctionARN: awssdk.String(lambdaARN),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("lambda function arn:aws:lambda:us-west-2:123456789012:function:my-function doesn't grant invoke permission to elasticloadbalancing.amazonaws.com"),
+		},
+		{
+			name: "lambda alias",
+			validateCalls: []validateCall{
+				{
+					lambdaFunctionARN: lambdaARN + ":live",
+				},
+			},
+			args: args{
+				actionCfg: Action{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								LambdaFunctionARN: awssdk.String(lambdaARN + ":live"),
+							},
+						},
+					},
+				},
+			},
+			wantLambdaFunctionARNs: []string{lambdaARN + ":live"},
+		},
+		{
+			name: "different aliases of same lambda function",
+			validateCalls: []validateCall{
+				{
+					lambdaFunctionARN: lambdaARN + ":live",
+				},
+				{
+					lambdaFunctionARN: lambdaARN + ":canary",
+				},
+			},
+			args: args{
+				actionCfg: Action{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								LambdaFunctionARN: awssdk.String(lambdaARN + ":live"),
+							},
+							{
+								LambdaFunctionARN: awssdk.String(lambdaARN + ":canary"),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting lambda function ARNs arn:aws:lambda:us-west-2:123456789012:function:my-function:live and arn:aws:lambda:us-west-2:123456789012:function:my-function:canary within Ingress ns-1/name-1, each lambda function can only be referenced with a single alias or version"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			lambdaPermissionValidator := mock_ingress.NewMockLambdaPermissionValidator(ctrl)
+			for _, call := range tt.validateCalls {
+				lambdaPermissionValidator.EXPECT().Validate(gomock.Any(), call.lambdaFunctionARN).Return(call.err)
+			}
+			stack := core.NewDefaultStack(core.StackID{Namespace: "ns-1", Name: "name-1"})
+			task := &defaultModelBuildTask{
+				clusterName:               "my-cluster",
+				ingGroup:                  Group{ID: GroupID{Namespace: "ns-1", Name: "name-1"}},
+				annotationParser:          annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				lambdaPermissionValidator: lambdaPermissionValidator,
+				stack:                     stack,
+				tgByResID:                 make(map[string]*elbv2model.TargetGroup),
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "name-1",
+				},
+			}
+			got, err := task.buildForwardAction(context.Background(), ing, tt.args.actionCfg, false)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, elbv2model.ActionTypeForward, got.Type)
+			assert.Len(t, got.ForwardConfig.TargetGroups, len(tt.wantLambdaFunctionARNs))
+
+			var resTGs []*elbv2model.TargetGroup
+			assert.NoError(t, stack.ListResources(&resTGs))
+			var gotLambdaFunctionARNs []string
+			for _, tg := range resTGs {
+				assert.Equal(t, elbv2model.TargetTypeLambda, tg.Spec.TargetType)
+				gotLambdaFunctionARNs = append(gotLambdaFunctionARNs, awssdk.StringValue(tg.Spec.LambdaFunctionARN))
+			}
+			assert.Equal(t, tt.wantLambdaFunctionARNs, gotLambdaFunctionARNs)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildEmptyEndpointsAction(t *testing.T) {
+	fixedResponseAction503 := &elbv2model.Action{
+		Type: elbv2model.ActionTypeFixedResponse,
+		FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+			ContentType: awssdk.String("text/plain"),
+			MessageBody: awssdk.String("Service Unavailable"),
+			StatusCode:  "503",
+		},
+	}
+	readyEndpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "svc-1",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{{IP: "192.168.1.1"}},
+			},
+		},
+	}
+	unreadyEndpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "svc-1",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				NotReadyAddresses: []corev1.EndpointAddress{{IP: "192.168.1.1"}},
+			},
+		},
+	}
+	gatedEndpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "svc-1",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				NotReadyAddresses: []corev1.EndpointAddress{
+					{
+						IP:        "192.168.1.1",
+						TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "ns-1", Name: "pod-1"},
+					},
+				},
+			},
+		},
+	}
+	buildGatedPod := func(containersReady corev1.ConditionStatus, readinessGates []corev1.PodConditionType, conditions []corev1.PodCondition) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-1",
+				Name:      "pod-1",
+			},
+			Status: corev1.PodStatus{
+				Conditions: append([]corev1.PodCondition{
+					{
+						Type:   corev1.ContainersReady,
+						Status: containersReady,
+					},
+				}, conditions...),
+			},
+		}
+		for _, rg := range readinessGates {
+			pod.Spec.ReadinessGates = append(pod.Spec.ReadinessGates, corev1.PodReadinessGate{ConditionType: rg})
+		}
+		return pod
+	}
+	tests := []struct {
+		name           string
+		endpoints      *corev1.Endpoints
+		pods           []*corev1.Pod
+		svcAnnotations map[string]string
+		ingAnnotations map[string]string
+		want           *elbv2model.Action
+		wantErr        error
+	}{
+		{
+			name:      "keep target group by default",
+			endpoints: nil,
+			want:      nil,
+		},
+		{
+			name: "keep target group explicitly",
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-action": "keep-target-group",
+			},
+			endpoints: nil,
+			want:      nil,
+		},
+		{
+			name: "fixed response when endpoints not exist",
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-action": "fixed-response",
+			},
+			endpoints: nil,
+			want:      fixedResponseAction503,
+		},
+		{
+			name: "fixed response when endpoints only have unready addresses",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-action": "fixed-response",
+			},
+			endpoints: unreadyEndpoints,
+			want:      fixedResponseAction503,
+		},
+		{
+			name: "forward to target group when unready addresses are only blocked by targetHealth readiness gate",
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-action": "fixed-response",
+			},
+			endpoints: gatedEndpoints,
+			pods: []*corev1.Pod{
+				buildGatedPod(corev1.ConditionTrue, []corev1.PodConditionType{"target-health.elbv2.k8s.aws/k8s-ns1-svc1-abcdef", "example.com/feature"},
+					[]corev1.PodCondition{
+						{
+							Type:   "example.com/feature",
+							Status: corev1.ConditionTrue,
+						},
+					}),
+			},
+			want: nil,
+		},
+		{
+			name: "fixed response when unready addresses are blocked by other readiness gate",
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-action": "fixed-response",
+			},
+			endpoints: gatedEndpoints,
+			pods: []*corev1.Pod{
+				buildGatedPod(corev1.ConditionTrue, []corev1.PodConditionType{"target-health.elbv2.k8s.aws/k8s-ns1-svc1-abcdef", "example.com/feature"}, nil),
+			},
+			want: fixedResponseAction503,
+		},
+		{
+			name: "fixed response when unready addresses are not containersReady",
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-action": "fixed-response",
+			},
+			endpoints: gatedEndpoints,
+			pods: []*corev1.Pod{
+				buildGatedPod(corev1.ConditionFalse, []corev1.PodConditionType{"target-health.elbv2.k8s.aws/k8s-ns1-svc1-abcdef"}, nil),
+			},
+			want: fixedResponseAction503,
+		},
+		{
+			name: "forward to target group when endpoints have ready addresses",
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-action": "fixed-response",
+			},
+			endpoints: readyEndpoints,
+			want:      nil,
+		},
+		{
+			name: "custom fixed response from service overrides ingress",
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-action":         "fixed-response",
+				"alb.ingress.kubernetes.io/empty-endpoints-fixed-response": `{"contentType":"text/html","messageBody":"<h1>maintenance</h1>","statusCode":"502"}`,
+			},
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-fixed-response": `{"statusCode":"500"}`,
+			},
+			endpoints: nil,
+			want: &elbv2model.Action{
+				Type: elbv2model.ActionTypeFixedResponse,
+				FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+					ContentType: awssdk.String("text/html"),
+					MessageBody: awssdk.String("<h1>maintenance</h1>"),
+					StatusCode:  "502",
+				},
+			},
+		},
+		{
+			name: "unknown empty endpoints action",
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-action": "drop",
+			},
+			wantErr: errors.New("unknown empty endpoints action: drop"),
+		},
+		{
+			name: "fixed response without statusCode",
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/empty-endpoints-action":         "fixed-response",
+				"alb.ingress.kubernetes.io/empty-endpoints-fixed-response": `{"statusCode":""}`,
+			},
+			wantErr: errors.New("invalid empty endpoints fixed response for service ns-1/svc-1: statusCode is required"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			if tt.endpoints != nil {
+				assert.NoError(t, k8sClient.Create(context.Background(), tt.endpoints.DeepCopy()))
+			}
+			for _, pod := range tt.pods {
+				assert.NoError(t, k8sClient.Create(context.Background(), pod.DeepCopy()))
+			}
+			task := &defaultModelBuildTask{
+				k8sClient:        k8sClient,
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns-1",
+					Name:        "ing-1",
+					Annotations: tt.ingAnnotations,
+				},
+			}
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns-1",
+					Name:        "svc-1",
+					Annotations: tt.svcAnnotations,
+				},
+			}
+			got, err := task.buildEmptyEndpointsAction(context.Background(), ing, svc)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
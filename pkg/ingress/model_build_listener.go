@@ -6,17 +6,29 @@ import (
 	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"net"
+	"regexp"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"strconv"
 	"strings"
 )
 
+const (
+	hstsConfigMaxAge            = "max-age"
+	hstsConfigIncludeSubDomains = "include-subdomains"
+	hstsConfigPreload           = "preload"
+	// HSTS preload lists require max-age of at least one year.
+	hstsMinPreloadMaxAge = 31536000
+)
+
 func (t *defaultModelBuildTask) buildListener(ctx context.Context, lbARN core.StringToken, port int64, config listenPortConfig, ingList []*networking.Ingress) (*elbv2model.Listener, error) {
 	lsSpec, err := t.buildListenerSpec(ctx, lbARN, port, config, ingList)
 	if err != nil {
@@ -32,12 +44,7 @@ func (t *defaultModelBuildTask) buildListenerSpec(ctx context.Context, lbARN cor
 	if err != nil {
 		return elbv2model.ListenerSpec{}, err
 	}
-	certs := make([]elbv2model.Certificate, 0, len(config.tlsCerts))
-	for _, certARN := range config.tlsCerts {
-		certs = append(certs, elbv2model.Certificate{
-			CertificateARN: awssdk.String(certARN),
-		})
-	}
+	certs := buildListenerCertificates(config.tlsCerts, config.defaultTLSCert)
 	return elbv2model.ListenerSpec{
 		LoadBalancerARN: lbARN,
 		Port:            port,
@@ -71,7 +78,27 @@ func (t *defaultModelBuildTask) buildListenerDefaultActions(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	return t.buildActions(ctx, protocol, ing, enhancedBackend)
+	return t.buildActions(ctx, protocol, ing, enhancedBackend, false)
+}
+
+// buildListenerCertificates builds the listener certificates, the first certificate will be used as listener's default certificate.
+// the defaultTLSCert will be placed first if specified, otherwise the first of tlsCerts will be used.
+func buildListenerCertificates(tlsCerts []string, defaultTLSCert *string) []elbv2model.Certificate {
+	certs := make([]elbv2model.Certificate, 0, len(tlsCerts))
+	if defaultTLSCert != nil {
+		certs = append(certs, elbv2model.Certificate{
+			CertificateARN: awssdk.String(*defaultTLSCert),
+		})
+	}
+	for _, certARN := range tlsCerts {
+		if defaultTLSCert != nil && certARN == *defaultTLSCert {
+			continue
+		}
+		certs = append(certs, elbv2model.Certificate{
+			CertificateARN: awssdk.String(certARN),
+		})
+	}
+	return certs
 }
 
 // the listen port config for specific Ingress's port
@@ -79,33 +106,52 @@ type listenPortConfig struct {
 	protocol       elbv2model.Protocol
 	inboundCIDRv4s []string
 	inboundCIDRv6s []string
+	prefixListIDs  []string
 	sslPolicy      *string
 	tlsCerts       []string
+	defaultTLSCert *string
 }
 
 func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context.Context, ing *networking.Ingress) (map[int64]listenPortConfig, error) {
 	explicitTLSCertARNs := t.computeIngressExplicitTLSCertARNs(ctx, ing)
+	explicitDefaultTLSCertARN, err := t.computeIngressExplicitDefaultTLSCertARN(ctx, ing, explicitTLSCertARNs)
+	if err != nil {
+		return nil, err
+	}
 	explicitSSLPolicy := t.computeIngressExplicitSSLPolicy(ctx, ing)
 	inboundCIDRv4s, inboundCIDRV6s, err := t.computeIngressExplicitInboundCIDRs(ctx, ing)
 	if err != nil {
 		return nil, err
 	}
+	prefixListIDs, err := t.computeIngressExplicitPrefixListIDs(ctx, ing)
+	if err != nil {
+		return nil, err
+	}
 	preferTLS := len(explicitTLSCertARNs) != 0
 	listenPorts, err := t.computeIngressListenPorts(ctx, ing, preferTLS)
 	if err != nil {
 		return nil, err
 	}
 
-	containsHTTPSPort := false
-	for _, protocol := range listenPorts {
-		if protocol == elbv2model.ProtocolHTTPS {
-			containsHTTPSPort = true
-			break
+	if err := t.validateIngressStrictTransportSecurity(ctx, ing, listenPorts); err != nil {
+		return nil, err
+	}
+
+	containsHTTPSPort := containsHTTPSListenPort(listenPorts)
+	if containsHTTPSPort && len(explicitTLSCertARNs) != 0 {
+		explicitTLSCertARNs, err = t.computeIngressAvailableTLSCertARNs(ctx, ing, explicitTLSCertARNs, listenPorts)
+		if err != nil {
+			return nil, err
+		}
+		containsHTTPSPort = containsHTTPSListenPort(listenPorts)
+		if explicitDefaultTLSCertARN != nil && !sets.NewString(explicitTLSCertARNs...).Has(*explicitDefaultTLSCertARN) {
+			explicitDefaultTLSCertARN = nil
 		}
 	}
 	var inferredTLSCertARNs []string
+	var inferredDefaultTLSCertARN *string
 	if containsHTTPSPort && len(explicitTLSCertARNs) == 0 {
-		inferredTLSCertARNs, err = t.computeIngressInferredTLSCertARNs(ctx, ing)
+		inferredTLSCertARNs, inferredDefaultTLSCertARN, err = t.computeIngressInferredTLSCertARNs(ctx, ing)
 		if err != nil {
 			return nil, err
 		}
@@ -117,12 +163,15 @@ func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context
 			protocol:       protocol,
 			inboundCIDRv4s: inboundCIDRv4s,
 			inboundCIDRv6s: inboundCIDRV6s,
+			prefixListIDs:  prefixListIDs,
 		}
 		if protocol == elbv2model.ProtocolHTTPS {
 			if len(explicitTLSCertARNs) == 0 {
 				cfg.tlsCerts = inferredTLSCertARNs
+				cfg.defaultTLSCert = inferredDefaultTLSCertARN
 			} else {
 				cfg.tlsCerts = explicitTLSCertARNs
+				cfg.defaultTLSCert = explicitDefaultTLSCertARN
 			}
 			cfg.sslPolicy = explicitSSLPolicy
 		}
@@ -138,7 +187,59 @@ func (t *defaultModelBuildTask) computeIngressExplicitTLSCertARNs(_ context.Cont
 	return rawTLSCertARNs
 }
 
-func (t *defaultModelBuildTask) computeIngressInferredTLSCertARNs(ctx context.Context, ing *networking.Ingress) ([]string, error) {
+// computeIngressExplicitDefaultTLSCertARN computes the TLS certificate explicitly designated as listener's default certificate.
+// the designated certificate must be one of the explicit TLS certificates.
+func (t *defaultModelBuildTask) computeIngressExplicitDefaultTLSCertARN(_ context.Context, ing *networking.Ingress, explicitTLSCertARNs []string) (*string, error) {
+	var rawDefaultTLSCertARN string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixDefaultCertificateARN, &rawDefaultTLSCertARN, ing.Annotations); !exists {
+		return nil, nil
+	}
+	if !sets.NewString(explicitTLSCertARNs...).Has(rawDefaultTLSCertARN) {
+		return nil, errors.Errorf("default certificate %v must be one of %v: %v", rawDefaultTLSCertARN, annotations.IngressSuffixCertificateARN, explicitTLSCertARNs)
+	}
+	return &rawDefaultTLSCertARN, nil
+}
+
+// computeIngressAvailableTLSCertARNs handles explicit TLS certificates that no longer exist according to missingCertificatePolicy.
+// the HTTPS ports will be removed from listenPorts if they should be skipped.
+func (t *defaultModelBuildTask) computeIngressAvailableTLSCertARNs(ctx context.Context, ing *networking.Ingress,
+	explicitTLSCertARNs []string, listenPorts map[int64]elbv2model.Protocol) ([]string, error) {
+	missingTLSCertARNs, err := t.certValidator.FindMissingCerts(ctx, explicitTLSCertARNs)
+	if err != nil {
+		return nil, err
+	}
+	if len(missingTLSCertARNs) == 0 {
+		return explicitTLSCertARNs, nil
+	}
+
+	switch t.missingCertificatePolicy {
+	case config.MissingCertificatePolicyFallback:
+		t.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonMissingCertificate,
+			fmt.Sprintf("Certificates not found, falling back to remaining certificates: %v", missingTLSCertARNs))
+		return sets.NewString(explicitTLSCertARNs...).Delete(missingTLSCertARNs...).List(), nil
+	case config.MissingCertificatePolicySkipListener:
+		t.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonMissingCertificate,
+			fmt.Sprintf("Certificates not found, skipping HTTPS listeners: %v", missingTLSCertARNs))
+		for port, protocol := range listenPorts {
+			if protocol == elbv2model.ProtocolHTTPS {
+				delete(listenPorts, port)
+			}
+		}
+		return nil, nil
+	default:
+		t.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonMissingCertificate,
+			fmt.Sprintf("Certificates not found: %v", missingTLSCertARNs))
+		return nil, errors.Errorf("certificates not found: %v", missingTLSCertARNs)
+	}
+}
+
+// computeIngressInferredTLSCertARNs discovers the TLS certificates for hosts of Ingress.
+// with wildcard certificate fallback, the wildcard certificate used for hosts without exactly matched certificate is returned as default certificate.
+func (t *defaultModelBuildTask) computeIngressInferredTLSCertARNs(ctx context.Context, ing *networking.Ingress) ([]string, *string, error) {
+	wildcardFallback := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixWildcardCertificateFallback, &wildcardFallback, ing.Annotations); err != nil {
+		return nil, nil, err
+	}
 	hosts := sets.NewString()
 	for _, r := range ing.Spec.Rules {
 		if len(r.Host) != 0 {
@@ -148,7 +249,11 @@ func (t *defaultModelBuildTask) computeIngressInferredTLSCertARNs(ctx context.Co
 	for _, t := range ing.Spec.TLS {
 		hosts.Insert(t.Hosts...)
 	}
-	return t.certDiscovery.Discover(ctx, hosts.List())
+	if wildcardFallback {
+		return t.certDiscovery.DiscoverWithWildcardFallback(ctx, hosts.List())
+	}
+	certARNs, err := t.certDiscovery.Discover(ctx, hosts.List())
+	return certARNs, nil, err
 }
 
 func (t *defaultModelBuildTask) computeIngressListenPorts(_ context.Context, ing *networking.Ingress, preferTLS bool) (map[int64]elbv2model.Protocol, error) {
@@ -188,6 +293,15 @@ func (t *defaultModelBuildTask) computeIngressListenPorts(_ context.Context, ing
 	return portAndProtocols, nil
 }
 
+func containsHTTPSListenPort(listenPorts map[int64]elbv2model.Protocol) bool {
+	for _, protocol := range listenPorts {
+		if protocol == elbv2model.ProtocolHTTPS {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *defaultModelBuildTask) computeIngressExplicitInboundCIDRs(_ context.Context, ing *networking.Ingress) ([]string, []string, error) {
 	var rawInboundCIDRs []string
 	_ = t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixInboundCIDRs, &rawInboundCIDRs, ing.Annotations)
@@ -207,6 +321,26 @@ func (t *defaultModelBuildTask) computeIngressExplicitInboundCIDRs(_ context.Con
 	return inboundCIDRv4s, inboundCIDRv6s, nil
 }
 
+var prefixListIDPattern = regexp.MustCompile(`^pl-[0-9a-f]+$`)
+
+func (t *defaultModelBuildTask) computeIngressExplicitPrefixListIDs(_ context.Context, ing *networking.Ingress) ([]string, error) {
+	var rawPrefixListIDs []string
+	_ = t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixSecurityGroupPrefixLists, &rawPrefixListIDs, ing.Annotations)
+
+	prefixListIDs := sets.NewString()
+	for _, prefixListID := range rawPrefixListIDs {
+		if !prefixListIDPattern.MatchString(prefixListID) {
+			return nil, errors.Errorf("invalid %v settings on Ingress: %v, prefixList ID %v must be in format pl-xxxxxxxx",
+				annotations.IngressSuffixSecurityGroupPrefixLists, k8s.NamespacedName(ing), prefixListID)
+		}
+		prefixListIDs.Insert(prefixListID)
+	}
+	if len(prefixListIDs) == 0 {
+		return nil, nil
+	}
+	return prefixListIDs.List(), nil
+}
+
 func (t *defaultModelBuildTask) computeIngressExplicitSSLPolicy(_ context.Context, ing *networking.Ingress) *string {
 	var rawSSLPolicy string
 	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSSLPolicy, &rawSSLPolicy, ing.Annotations); !exists {
@@ -214,3 +348,69 @@ func (t *defaultModelBuildTask) computeIngressExplicitSSLPolicy(_ context.Contex
 	}
 	return &rawSSLPolicy
 }
+
+// validateIngressStrictTransportSecurity validates the HSTS header injection configured for Ingress's HTTPS listeners.
+// the HSTS header is injected via ALB listener attributes, which aren't supported by the ELBV2 API this controller uses,
+// so a valid configuration is rejected instead of being silently ignored.
+func (t *defaultModelBuildTask) validateIngressStrictTransportSecurity(_ context.Context, ing *networking.Ingress, listenPorts map[int64]elbv2model.Protocol) error {
+	var rawHSTSConfig map[string]string
+	exists, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixStrictTransportSecurity, &rawHSTSConfig, ing.Annotations)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	headerValue, err := buildStrictTransportSecurityHeaderValue(rawHSTSConfig)
+	if err != nil {
+		return err
+	}
+	if !containsHTTPSListenPort(listenPorts) {
+		return errors.New("strict-transport-security can only be configured with HTTPS listeners")
+	}
+	return errors.Errorf("unsupported strict-transport-security %q: listener attributes aren't supported by this controller", headerValue)
+}
+
+// buildStrictTransportSecurityHeaderValue builds the Strict-Transport-Security header value from HSTS configuration.
+// max-age is required, preload additionally requires include-subdomains and a max-age of at least one year.
+func buildStrictTransportSecurityHeaderValue(hstsConfig map[string]string) (string, error) {
+	var maxAge int64
+	var includeSubDomains, preload bool
+	var err error
+	for key, rawValue := range hstsConfig {
+		switch key {
+		case hstsConfigMaxAge:
+			maxAge, err = strconv.ParseInt(rawValue, 10, 64)
+			if err != nil || maxAge < 0 {
+				return "", errors.Errorf("invalid strict-transport-security %v: %v", key, rawValue)
+			}
+		case hstsConfigIncludeSubDomains:
+			includeSubDomains, err = strconv.ParseBool(rawValue)
+			if err != nil {
+				return "", errors.Errorf("invalid strict-transport-security %v: %v", key, rawValue)
+			}
+		case hstsConfigPreload:
+			preload, err = strconv.ParseBool(rawValue)
+			if err != nil {
+				return "", errors.Errorf("invalid strict-transport-security %v: %v", key, rawValue)
+			}
+		default:
+			return "", errors.Errorf("unknown strict-transport-security configuration: %v", key)
+		}
+	}
+	if _, exists := hstsConfig[hstsConfigMaxAge]; !exists {
+		return "", errors.Errorf("strict-transport-security %v must be specified", hstsConfigMaxAge)
+	}
+	if preload && (!includeSubDomains || maxAge < hstsMinPreloadMaxAge) {
+		return "", errors.Errorf("strict-transport-security %v requires %v and %v of at least %v",
+			hstsConfigPreload, hstsConfigIncludeSubDomains, hstsConfigMaxAge, hstsMinPreloadMaxAge)
+	}
+	directives := []string{fmt.Sprintf("max-age=%d", maxAge)}
+	if includeSubDomains {
+		directives = append(directives, "includeSubDomains")
+	}
+	if preload {
+		directives = append(directives, "preload")
+	}
+	return strings.Join(directives, "; "), nil
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
@@ -12,7 +17,13 @@ import (
 
 func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN core.StringToken, port int64, protocol elbv2model.Protocol, ingList []*networking.Ingress) error {
 	var rules []Rule
+	ruleClaimants := make(map[ruleClaim]types.NamespacedName)
 	for _, ing := range ingList {
+		ingKey := k8s.NamespacedName(ing)
+		stickinessPaths, err := t.buildStickinessPaths(ctx, ing)
+		if err != nil {
+			return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
+		}
 		for _, rule := range ing.Spec.Rules {
 			if rule.HTTP == nil {
 				continue
@@ -22,18 +33,37 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
 				}
+				if !enhancedBackend.AppliesToProtocol(protocol) {
+					continue
+				}
+				if len(enhancedBackend.Conditions) == 0 {
+					claim := ruleClaim{host: rule.Host, path: path.Path}
+					if claimant, exists := ruleClaimants[claim]; !exists {
+						ruleClaimants[claim] = ingKey
+					} else if claimant != ingKey {
+						if err := t.handleDuplicateRule(ctx, ing, claim, claimant); err != nil {
+							return err
+						}
+					}
+				}
 				conditions, err := t.buildRuleConditions(ctx, rule, path, enhancedBackend)
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
 				}
-				actions, err := t.buildActions(ctx, protocol, ing, enhancedBackend)
+				conditionsList, err := t.buildRuleConditionsWithinLimits(ctx, conditions)
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
 				}
-				rules = append(rules, Rule{
-					Conditions: conditions,
-					Actions:    actions,
-				})
+				actions, err := t.buildActions(ctx, protocol, ing, enhancedBackend, stickinessPaths.Has(rule.Host+path.Path))
+				if err != nil {
+					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
+				}
+				for _, ruleConditions := range conditionsList {
+					rules = append(rules, Rule{
+						Conditions: ruleConditions,
+						Actions:    actions,
+					})
+				}
 			}
 		}
 	}
@@ -42,6 +72,7 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 		return err
 	}
 
+	// rules are always numbered contiguously from 1, so that priorities never fragment as rules churn.
 	priority := int64(1)
 	for _, rule := range optimizedRules {
 		ruleResID := fmt.Sprintf("%v:%v", port, priority)
@@ -57,6 +88,62 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 	return nil
 }
 
+// ruleClaim is the host and path combination claimed by an Ingress rule.
+type ruleClaim struct {
+	host string
+	path string
+}
+
+// duplicateRule is the rule of Ingress that claims the host and path already claimed by another Ingress.
+type duplicateRule struct {
+	ingKey types.NamespacedName
+	claim  ruleClaim
+}
+
+// handleDuplicateRule handles rules from different Ingresses within the IngressGroup that claim the same host and path according to duplicateRulePolicy.
+// each duplicate rule is only reported once, even if it's claimed on multiple listener ports.
+func (t *defaultModelBuildTask) handleDuplicateRule(_ context.Context, ing *networking.Ingress, claim ruleClaim, claimant types.NamespacedName) error {
+	message := fmt.Sprintf("host %q and path %q is already claimed by ingress %v", claim.host, claim.path, claimant)
+	switch t.duplicateRulePolicy {
+	case config.DuplicateRulePolicyWarn:
+		rule := duplicateRule{ingKey: k8s.NamespacedName(ing), claim: claim}
+		if t.reportedDuplicateRules[rule] {
+			return nil
+		}
+		t.reportedDuplicateRules[rule] = true
+		t.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonDuplicateRule,
+			fmt.Sprintf("Duplicate rule, %v", message))
+		return nil
+	default:
+		t.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonDuplicateRule,
+			fmt.Sprintf("Duplicate rule rejected, %v", message))
+		return errors.Errorf("ingress: %v: duplicate rule, %v", k8s.NamespacedName(ing), message)
+	}
+}
+
+// buildStickinessPaths builds the Ingress paths whose backends should be forwarded to sticky targetGroups.
+// each path must be specified identically to a path within Ingress rules, prefixed with the host of the rule if any.
+func (t *defaultModelBuildTask) buildStickinessPaths(_ context.Context, ing *networking.Ingress) (sets.String, error) {
+	var rawStickinessPaths []string
+	if exists := t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixStickinessPaths, &rawStickinessPaths, ing.Annotations); !exists {
+		return sets.NewString(), nil
+	}
+	ingPaths := sets.NewString()
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			ingPaths.Insert(rule.Host + path.Path)
+		}
+	}
+	stickinessPaths := sets.NewString(rawStickinessPaths...)
+	if unknownPaths := stickinessPaths.Difference(ingPaths); len(unknownPaths) != 0 {
+		return nil, errors.Errorf("unknown paths in %v annotation: %v", annotations.IngressSuffixStickinessPaths, unknownPaths.List())
+	}
+	return stickinessPaths, nil
+}
+
 func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule networking.IngressRule,
 	path networking.HTTPIngressPath, backend EnhancedBackend) ([]elbv2model.RuleCondition, error) {
 	var hosts []string
@@ -118,6 +205,131 @@ func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule ne
 	return conditions, nil
 }
 
+// buildRuleConditionsWithinLimits validates the rule conditions against the condition values limits.
+// if splitRuleConditions is enabled, conditions exceeding the limits are split into multiple sets of conditions instead,
+// the rules built from each set of conditions together match the same requests as the original rule.
+func (t *defaultModelBuildTask) buildRuleConditionsWithinLimits(_ context.Context, conditions []elbv2model.RuleCondition) ([][]elbv2model.RuleCondition, error) {
+	if t.splitRuleConditions {
+		return splitRuleConditions(conditions, t.ruleConditionValuesLimit, t.ruleValuesLimit)
+	}
+	ruleValueCount := 0
+	for _, condition := range conditions {
+		conditionValueCount := countRuleConditionValues(condition)
+		if t.ruleConditionValuesLimit != 0 && conditionValueCount > t.ruleConditionValuesLimit {
+			return nil, errors.Errorf("%v condition contains %v values, exceeds the limit %v", condition.Field, conditionValueCount, t.ruleConditionValuesLimit)
+		}
+		ruleValueCount += conditionValueCount
+	}
+	if t.ruleValuesLimit != 0 && ruleValueCount > t.ruleValuesLimit {
+		return nil, errors.Errorf("rule contains %v condition values, exceeds the limit %v", ruleValueCount, t.ruleValuesLimit)
+	}
+	return [][]elbv2model.RuleCondition{conditions}, nil
+}
+
+// splitRuleConditions splits the values of conditions exceeding the limits into multiple sets of conditions.
+// since values within a condition are ORed while conditions are ANDed, a condition is split into chunks of values
+// and each chunk combined with the other conditions recursively, until all sets of conditions are within the limits.
+// conditions exceeding conditionValuesLimit are split first, then the condition with most values is split to fit ruleValuesLimit.
+// limits with value 0 are considered as unlimited.
+func splitRuleConditions(conditions []elbv2model.RuleCondition, conditionValuesLimit int, ruleValuesLimit int) ([][]elbv2model.RuleCondition, error) {
+	ruleValueCount := 0
+	splitConditionIndex := -1
+	splitConditionValueCount := 0
+	for index, condition := range conditions {
+		conditionValueCount := countRuleConditionValues(condition)
+		ruleValueCount += conditionValueCount
+		if conditionValueCount > splitConditionValueCount {
+			splitConditionIndex = index
+			splitConditionValueCount = conditionValueCount
+		}
+	}
+
+	var chunkSize int
+	switch {
+	case conditionValuesLimit != 0 && splitConditionValueCount > conditionValuesLimit:
+		chunkSize = conditionValuesLimit
+	case ruleValuesLimit != 0 && ruleValueCount > ruleValuesLimit:
+		if splitConditionValueCount <= 1 {
+			return nil, errors.Errorf("rule contains %v condition values, cannot be split within the limit %v", ruleValueCount, ruleValuesLimit)
+		}
+		chunkSize = ruleValuesLimit - (ruleValueCount - splitConditionValueCount)
+		if chunkSize < 1 {
+			chunkSize = 1
+		}
+	default:
+		return [][]elbv2model.RuleCondition{conditions}, nil
+	}
+
+	var conditionsList [][]elbv2model.RuleCondition
+	for start := 0; start < splitConditionValueCount; start += chunkSize {
+		end := start + chunkSize
+		if end > splitConditionValueCount {
+			end = splitConditionValueCount
+		}
+		chunkConditions := make([]elbv2model.RuleCondition, len(conditions))
+		copy(chunkConditions, conditions)
+		chunkConditions[splitConditionIndex] = sliceRuleConditionValues(conditions[splitConditionIndex], start, end)
+		chunkConditionsList, err := splitRuleConditions(chunkConditions, conditionValuesLimit, ruleValuesLimit)
+		if err != nil {
+			return nil, err
+		}
+		conditionsList = append(conditionsList, chunkConditionsList...)
+	}
+	return conditionsList, nil
+}
+
+// countRuleConditionValues returns the number of values within the condition.
+func countRuleConditionValues(condition elbv2model.RuleCondition) int {
+	switch {
+	case condition.HostHeaderConfig != nil:
+		return len(condition.HostHeaderConfig.Values)
+	case condition.HTTPHeaderConfig != nil:
+		return len(condition.HTTPHeaderConfig.Values)
+	case condition.HTTPRequestMethodConfig != nil:
+		return len(condition.HTTPRequestMethodConfig.Values)
+	case condition.PathPatternConfig != nil:
+		return len(condition.PathPatternConfig.Values)
+	case condition.QueryStringConfig != nil:
+		return len(condition.QueryStringConfig.Values)
+	case condition.SourceIPConfig != nil:
+		return len(condition.SourceIPConfig.Values)
+	}
+	return 0
+}
+
+// sliceRuleConditionValues returns a copy of the condition that only contains values within [start, end).
+func sliceRuleConditionValues(condition elbv2model.RuleCondition, start int, end int) elbv2model.RuleCondition {
+	slicedCondition := elbv2model.RuleCondition{Field: condition.Field}
+	switch {
+	case condition.HostHeaderConfig != nil:
+		slicedCondition.HostHeaderConfig = &elbv2model.HostHeaderConditionConfig{
+			Values: condition.HostHeaderConfig.Values[start:end],
+		}
+	case condition.HTTPHeaderConfig != nil:
+		slicedCondition.HTTPHeaderConfig = &elbv2model.HTTPHeaderConditionConfig{
+			HTTPHeaderName: condition.HTTPHeaderConfig.HTTPHeaderName,
+			Values:         condition.HTTPHeaderConfig.Values[start:end],
+		}
+	case condition.HTTPRequestMethodConfig != nil:
+		slicedCondition.HTTPRequestMethodConfig = &elbv2model.HTTPRequestMethodConditionConfig{
+			Values: condition.HTTPRequestMethodConfig.Values[start:end],
+		}
+	case condition.PathPatternConfig != nil:
+		slicedCondition.PathPatternConfig = &elbv2model.PathPatternConditionConfig{
+			Values: condition.PathPatternConfig.Values[start:end],
+		}
+	case condition.QueryStringConfig != nil:
+		slicedCondition.QueryStringConfig = &elbv2model.QueryStringConditionConfig{
+			Values: condition.QueryStringConfig.Values[start:end],
+		}
+	case condition.SourceIPConfig != nil:
+		slicedCondition.SourceIPConfig = &elbv2model.SourceIPConditionConfig{
+			Values: condition.SourceIPConfig.Values[start:end],
+		}
+	}
+	return slicedCondition
+}
+
 func (t *defaultModelBuildTask) buildHTTPHeaderCondition(_ context.Context, condition RuleCondition) (elbv2model.RuleCondition, error) {
 	if condition.HTTPHeaderConfig == nil {
 		return elbv2model.RuleCondition{}, errors.New("missing HTTPHeaderConfig")
@@ -0,0 +1,849 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildListenerRules(t *testing.T) {
+	redirectAction := elbv2model.Action{
+		Type: elbv2model.ActionTypeRedirect,
+		RedirectConfig: &elbv2model.RedirectActionConfig{
+			Port:       awssdk.String("443"),
+			Protocol:   awssdk.String("HTTPS"),
+			StatusCode: "HTTP_301",
+		},
+	}
+	fixedResponseAction := elbv2model.Action{
+		Type: elbv2model.ActionTypeFixedResponse,
+		FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+			ContentType: awssdk.String("text/plain"),
+			StatusCode:  "200",
+			MessageBody: awssdk.String("ok"),
+		},
+	}
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-1",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/actions.ssl-redirect":          `{"type":"redirect","redirectConfig":{"port":"443","protocol":"HTTPS","statusCode":"HTTP_301"}}`,
+				"alb.ingress.kubernetes.io/listen-protocols.ssl-redirect": "HTTP",
+				"alb.ingress.kubernetes.io/actions.response-ok":           `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/plain","statusCode":"200","messageBody":"ok"}}`,
+				"alb.ingress.kubernetes.io/listen-protocols.response-ok":  "HTTPS",
+			},
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path: "/*",
+									Backend: networking.IngressBackend{
+										ServiceName: "ssl-redirect",
+										ServicePort: intstr.FromString("use-annotation"),
+									},
+								},
+								{
+									Path: "/api",
+									Backend: networking.IngressBackend{
+										ServiceName: "response-ok",
+										ServicePort: intstr.FromString("use-annotation"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		port     int64
+		protocol elbv2model.Protocol
+		want     []elbv2model.ListenerRuleSpec
+	}{
+		{
+			name:     "HTTP listener only contains HTTP scoped rules",
+			port:     80,
+			protocol: elbv2model.ProtocolHTTP,
+			want: []elbv2model.ListenerRuleSpec{
+				{
+					ListenerARN: core.LiteralStringToken("ls-arn"),
+					Priority:    1,
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldPathPattern,
+							PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+								Values: []string{"/*"},
+							},
+						},
+					},
+					Actions: []elbv2model.Action{redirectAction},
+				},
+			},
+		},
+		{
+			name:     "HTTPS listener only contains HTTPS scoped rules",
+			port:     443,
+			protocol: elbv2model.ProtocolHTTPS,
+			want: []elbv2model.ListenerRuleSpec{
+				{
+					ListenerARN: core.LiteralStringToken("ls-arn"),
+					Priority:    1,
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldPathPattern,
+							PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+								Values: []string{"/api"},
+							},
+						},
+					},
+					Actions: []elbv2model.Action{fixedResponseAction},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				stack:                  stack,
+				annotationParser:       annotationParser,
+				enhancedBackendBuilder: NewDefaultEnhancedBackendBuilder(annotationParser),
+				authConfigBuilder:      NewDefaultAuthConfigBuilder(annotationParser),
+				ruleOptimizer:          NewDefaultRuleOptimizer(&log.NullLogger{}),
+			}
+			err := task.buildListenerRules(context.Background(), core.LiteralStringToken("ls-arn"), tt.port, tt.protocol, []*networking.Ingress{ing})
+			assert.NoError(t, err)
+
+			var resLRs []*elbv2model.ListenerRule
+			assert.NoError(t, stack.ListResources(&resLRs))
+			var got []elbv2model.ListenerRuleSpec
+			for _, lr := range resLRs {
+				got = append(got, lr.Spec)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildListenerRules_duplicateRules(t *testing.T) {
+	buildIngress := func(name string, host string, path string, svcName string) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      name,
+				Annotations: map[string]string{
+					"alb.ingress.kubernetes.io/actions." + svcName: `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/plain","statusCode":"200","messageBody":"` + name + `"}}`,
+				},
+			},
+			Spec: networking.IngressSpec{
+				Rules: []networking.IngressRule{
+					{
+						Host: host,
+						IngressRuleValue: networking.IngressRuleValue{
+							HTTP: &networking.HTTPIngressRuleValue{
+								Paths: []networking.HTTPIngressPath{
+									{
+										Path: path,
+										Backend: networking.IngressBackend{
+											ServiceName: svcName,
+											ServicePort: intstr.FromString("use-annotation"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name                string
+		duplicateRulePolicy string
+		ingList             []*networking.Ingress
+		ports               []int64
+		wantRuleCount       int
+		wantEvents          []string
+		wantErr             error
+	}{
+		{
+			name:                "different host and path",
+			duplicateRulePolicy: config.DuplicateRulePolicyError,
+			ingList: []*networking.Ingress{
+				buildIngress("ing-1", "app.example.com", "/api", "svc-1"),
+				buildIngress("ing-2", "app.example.com", "/web", "svc-2"),
+				buildIngress("ing-3", "other.example.com", "/api", "svc-3"),
+			},
+			wantRuleCount: 3,
+		},
+		{
+			name:                "same host and path within same ingress",
+			duplicateRulePolicy: config.DuplicateRulePolicyError,
+			ingList: []*networking.Ingress{
+				buildIngress("ing-1", "app.example.com", "/api", "svc-1"),
+				buildIngress("ing-1", "app.example.com", "/api", "svc-2"),
+			},
+			wantRuleCount: 2,
+		},
+		{
+			name:                "same host and path across ingresses should be rejected",
+			duplicateRulePolicy: config.DuplicateRulePolicyError,
+			ingList: []*networking.Ingress{
+				buildIngress("ing-1", "app.example.com", "/api", "svc-1"),
+				buildIngress("ing-2", "app.example.com", "/api", "svc-2"),
+			},
+			wantEvents: []string{
+				`Warning DuplicateRule Duplicate rule rejected, host "app.example.com" and path "/api" is already claimed by ingress awesome-ns/ing-1`,
+			},
+			wantErr: errors.New(`ingress: awesome-ns/ing-2: duplicate rule, host "app.example.com" and path "/api" is already claimed by ingress awesome-ns/ing-1`),
+		},
+		{
+			name:                "same host and path across ingresses should be warned",
+			duplicateRulePolicy: config.DuplicateRulePolicyWarn,
+			ingList: []*networking.Ingress{
+				buildIngress("ing-1", "app.example.com", "/api", "svc-1"),
+				buildIngress("ing-2", "app.example.com", "/api", "svc-2"),
+			},
+			wantRuleCount: 2,
+			wantEvents: []string{
+				`Warning DuplicateRule Duplicate rule, host "app.example.com" and path "/api" is already claimed by ingress awesome-ns/ing-1`,
+			},
+		},
+		{
+			name:                "same host and path across ingresses on multiple ports should be warned once",
+			duplicateRulePolicy: config.DuplicateRulePolicyWarn,
+			ingList: []*networking.Ingress{
+				buildIngress("ing-1", "app.example.com", "/api", "svc-1"),
+				buildIngress("ing-2", "app.example.com", "/api", "svc-2"),
+			},
+			ports:         []int64{80, 8080},
+			wantRuleCount: 4,
+			wantEvents: []string{
+				`Warning DuplicateRule Duplicate rule, host "app.example.com" and path "/api" is already claimed by ingress awesome-ns/ing-1`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			eventRecorder := record.NewFakeRecorder(10)
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				stack:                  stack,
+				eventRecorder:          eventRecorder,
+				duplicateRulePolicy:    tt.duplicateRulePolicy,
+				annotationParser:       annotationParser,
+				enhancedBackendBuilder: NewDefaultEnhancedBackendBuilder(annotationParser),
+				authConfigBuilder:      NewDefaultAuthConfigBuilder(annotationParser),
+				ruleOptimizer:          NewDefaultRuleOptimizer(&log.NullLogger{}),
+				reportedDuplicateRules: make(map[duplicateRule]bool),
+			}
+			ports := tt.ports
+			if len(ports) == 0 {
+				ports = []int64{80}
+			}
+			var err error
+			for _, port := range ports {
+				if err = task.buildListenerRules(context.Background(), core.LiteralStringToken("ls-arn"), port, elbv2model.ProtocolHTTP, tt.ingList); err != nil {
+					break
+				}
+			}
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				var resLRs []*elbv2model.ListenerRule
+				assert.NoError(t, stack.ListResources(&resLRs))
+				assert.Equal(t, tt.wantRuleCount, len(resLRs))
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildRuleConditionsWithinLimits(t *testing.T) {
+	buildPathPatternCondition := func(paths ...string) elbv2model.RuleCondition {
+		return elbv2model.RuleCondition{
+			Field: elbv2model.RuleConditionFieldPathPattern,
+			PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+				Values: paths,
+			},
+		}
+	}
+	buildHostHeaderCondition := func(hosts ...string) elbv2model.RuleCondition {
+		return elbv2model.RuleCondition{
+			Field: elbv2model.RuleConditionFieldHostHeader,
+			HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+				Values: hosts,
+			},
+		}
+	}
+	tests := []struct {
+		name                     string
+		ruleConditionValuesLimit int
+		ruleValuesLimit          int
+		splitRuleConditions      bool
+		conditions               []elbv2model.RuleCondition
+		want                     [][]elbv2model.RuleCondition
+		wantErr                  error
+	}{
+		{
+			name:                     "conditions within limits",
+			ruleConditionValuesLimit: 3,
+			ruleValuesLimit:          5,
+			conditions: []elbv2model.RuleCondition{
+				buildHostHeaderCondition("a.example.com", "b.example.com"),
+				buildPathPatternCondition("/api", "/web", "/static"),
+			},
+			want: [][]elbv2model.RuleCondition{
+				{
+					buildHostHeaderCondition("a.example.com", "b.example.com"),
+					buildPathPatternCondition("/api", "/web", "/static"),
+				},
+			},
+		},
+		{
+			name:                     "condition exceeds condition values limit",
+			ruleConditionValuesLimit: 3,
+			ruleValuesLimit:          5,
+			conditions: []elbv2model.RuleCondition{
+				buildPathPatternCondition("/a", "/b", "/c", "/d"),
+			},
+			wantErr: errors.New("path-pattern condition contains 4 values, exceeds the limit 3"),
+		},
+		{
+			name:                     "conditions exceeds rule values limit",
+			ruleConditionValuesLimit: 3,
+			ruleValuesLimit:          5,
+			conditions: []elbv2model.RuleCondition{
+				buildHostHeaderCondition("a.example.com", "b.example.com", "c.example.com"),
+				buildPathPatternCondition("/api", "/web", "/static"),
+			},
+			wantErr: errors.New("rule contains 6 condition values, exceeds the limit 5"),
+		},
+		{
+			name: "limits disabled",
+			conditions: []elbv2model.RuleCondition{
+				buildHostHeaderCondition("a.example.com", "b.example.com", "c.example.com"),
+				buildPathPatternCondition("/a", "/b", "/c", "/d"),
+			},
+			want: [][]elbv2model.RuleCondition{
+				{
+					buildHostHeaderCondition("a.example.com", "b.example.com", "c.example.com"),
+					buildPathPatternCondition("/a", "/b", "/c", "/d"),
+				},
+			},
+		},
+		{
+			name:                     "condition exceeds condition values limit is split",
+			ruleConditionValuesLimit: 3,
+			ruleValuesLimit:          5,
+			splitRuleConditions:      true,
+			conditions: []elbv2model.RuleCondition{
+				buildPathPatternCondition("/a", "/b", "/c", "/d"),
+			},
+			want: [][]elbv2model.RuleCondition{
+				{
+					buildPathPatternCondition("/a", "/b", "/c"),
+				},
+				{
+					buildPathPatternCondition("/d"),
+				},
+			},
+		},
+		{
+			name:                     "conditions exceeds rule values limit is split",
+			ruleConditionValuesLimit: 3,
+			ruleValuesLimit:          5,
+			splitRuleConditions:      true,
+			conditions: []elbv2model.RuleCondition{
+				buildHostHeaderCondition("a.example.com", "b.example.com", "c.example.com"),
+				buildPathPatternCondition("/api", "/web", "/static"),
+			},
+			want: [][]elbv2model.RuleCondition{
+				{
+					buildHostHeaderCondition("a.example.com", "b.example.com"),
+					buildPathPatternCondition("/api", "/web", "/static"),
+				},
+				{
+					buildHostHeaderCondition("c.example.com"),
+					buildPathPatternCondition("/api", "/web", "/static"),
+				},
+			},
+		},
+		{
+			name:                     "multiple conditions exceeds condition values limit are split",
+			ruleConditionValuesLimit: 3,
+			ruleValuesLimit:          5,
+			splitRuleConditions:      true,
+			conditions: []elbv2model.RuleCondition{
+				buildHostHeaderCondition("a.example.com", "b.example.com", "c.example.com", "d.example.com"),
+				buildPathPatternCondition("/a", "/b", "/c", "/d"),
+			},
+			want: [][]elbv2model.RuleCondition{
+				{
+					buildHostHeaderCondition("a.example.com", "b.example.com"),
+					buildPathPatternCondition("/a", "/b", "/c"),
+				},
+				{
+					buildHostHeaderCondition("c.example.com"),
+					buildPathPatternCondition("/a", "/b", "/c"),
+				},
+				{
+					buildHostHeaderCondition("a.example.com", "b.example.com", "c.example.com"),
+					buildPathPatternCondition("/d"),
+				},
+				{
+					buildHostHeaderCondition("d.example.com"),
+					buildPathPatternCondition("/a", "/b", "/c"),
+				},
+				{
+					buildHostHeaderCondition("d.example.com"),
+					buildPathPatternCondition("/d"),
+				},
+			},
+		},
+		{
+			name:                     "split preserves http header name",
+			ruleConditionValuesLimit: 2,
+			splitRuleConditions:      true,
+			conditions: []elbv2model.RuleCondition{
+				{
+					Field: elbv2model.RuleConditionFieldHTTPHeader,
+					HTTPHeaderConfig: &elbv2model.HTTPHeaderConditionConfig{
+						HTTPHeaderName: "User-Agent",
+						Values:         []string{"a", "b", "c"},
+					},
+				},
+			},
+			want: [][]elbv2model.RuleCondition{
+				{
+					{
+						Field: elbv2model.RuleConditionFieldHTTPHeader,
+						HTTPHeaderConfig: &elbv2model.HTTPHeaderConditionConfig{
+							HTTPHeaderName: "User-Agent",
+							Values:         []string{"a", "b"},
+						},
+					},
+				},
+				{
+					{
+						Field: elbv2model.RuleConditionFieldHTTPHeader,
+						HTTPHeaderConfig: &elbv2model.HTTPHeaderConditionConfig{
+							HTTPHeaderName: "User-Agent",
+							Values:         []string{"c"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:                     "conditions with single values cannot be split",
+			ruleConditionValuesLimit: 3,
+			ruleValuesLimit:          2,
+			splitRuleConditions:      true,
+			conditions: []elbv2model.RuleCondition{
+				buildHostHeaderCondition("a.example.com"),
+				buildPathPatternCondition("/api"),
+				{
+					Field: elbv2model.RuleConditionFieldSourceIP,
+					SourceIPConfig: &elbv2model.SourceIPConditionConfig{
+						Values: []string{"192.168.0.0/16"},
+					},
+				},
+			},
+			wantErr: errors.New("rule contains 3 condition values, cannot be split within the limit 2"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				ruleConditionValuesLimit: tt.ruleConditionValuesLimit,
+				ruleValuesLimit:          tt.ruleValuesLimit,
+				splitRuleConditions:      tt.splitRuleConditions,
+			}
+			got, err := task.buildRuleConditionsWithinLimits(context.Background(), tt.conditions)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildListenerRules_splitRuleConditions(t *testing.T) {
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-1",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/actions.response-ok":    `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/plain","statusCode":"200","messageBody":"ok"}}`,
+				"alb.ingress.kubernetes.io/conditions.response-ok": `[{"field":"host-header","hostHeaderConfig":{"values":["a.example.com","b.example.com","c.example.com","d.example.com"]}}]`,
+			},
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path: "/api",
+									Backend: networking.IngressBackend{
+										ServiceName: "response-ok",
+										ServicePort: intstr.FromString("use-annotation"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	fixedResponseAction := elbv2model.Action{
+		Type: elbv2model.ActionTypeFixedResponse,
+		FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+			ContentType: awssdk.String("text/plain"),
+			StatusCode:  "200",
+			MessageBody: awssdk.String("ok"),
+		},
+	}
+	pathPatternCondition := elbv2model.RuleCondition{
+		Field: elbv2model.RuleConditionFieldPathPattern,
+		PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+			Values: []string{"/api"},
+		},
+	}
+	tests := []struct {
+		name                string
+		splitRuleConditions bool
+		want                []elbv2model.ListenerRuleSpec
+		wantErr             error
+	}{
+		{
+			name:                "rule conditions exceeding limits are rejected",
+			splitRuleConditions: false,
+			wantErr:             errors.New("ingress: awesome-ns/ing-1: host-header condition contains 4 values, exceeds the limit 3"),
+		},
+		{
+			name:                "rule conditions exceeding limits are split into rules with same actions",
+			splitRuleConditions: true,
+			want: []elbv2model.ListenerRuleSpec{
+				{
+					ListenerARN: core.LiteralStringToken("ls-arn"),
+					Priority:    1,
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"a.example.com", "b.example.com", "c.example.com"},
+							},
+						},
+						pathPatternCondition,
+					},
+					Actions: []elbv2model.Action{fixedResponseAction},
+				},
+				{
+					ListenerARN: core.LiteralStringToken("ls-arn"),
+					Priority:    2,
+					Conditions: []elbv2model.RuleCondition{
+						{
+							Field: elbv2model.RuleConditionFieldHostHeader,
+							HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+								Values: []string{"d.example.com"},
+							},
+						},
+						pathPatternCondition,
+					},
+					Actions: []elbv2model.Action{fixedResponseAction},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				stack:                    stack,
+				ruleConditionValuesLimit: 3,
+				ruleValuesLimit:          5,
+				splitRuleConditions:      tt.splitRuleConditions,
+				annotationParser:         annotationParser,
+				enhancedBackendBuilder:   NewDefaultEnhancedBackendBuilder(annotationParser),
+				authConfigBuilder:        NewDefaultAuthConfigBuilder(annotationParser),
+				ruleOptimizer:            NewDefaultRuleOptimizer(&log.NullLogger{}),
+			}
+			err := task.buildListenerRules(context.Background(), core.LiteralStringToken("ls-arn"), 80, elbv2model.ProtocolHTTP, []*networking.Ingress{ing})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var resLRs []*elbv2model.ListenerRule
+			assert.NoError(t, stack.ListResources(&resLRs))
+			var got []elbv2model.ListenerRuleSpec
+			for _, lr := range resLRs {
+				got = append(got, lr.Spec)
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildListenerRules_stickinessPaths(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-1",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+					NodePort:   32768,
+				},
+			},
+		},
+	}
+	svcBackend := networking.IngressBackend{
+		ServiceName: "svc-1",
+		ServicePort: intstr.FromString("http"),
+	}
+	tests := []struct {
+		name             string
+		ingAnnotations   map[string]string
+		wantRuleTGResIDs map[string]string
+		wantTGAttributes map[string][]elbv2model.TargetGroupAttribute
+		wantTGBResIDs    []string
+		wantErr          error
+	}{
+		{
+			name:           "without stickiness paths",
+			ingAnnotations: map[string]string{},
+			wantRuleTGResIDs: map[string]string{
+				"/cart":                "awesome-ns/ing-1-svc-1:http",
+				"/checkout":            "awesome-ns/ing-1-svc-1:http",
+				"/static":              "awesome-ns/ing-1-svc-1:http",
+				"www.example.com/cart": "awesome-ns/ing-1-svc-1:http",
+			},
+			wantTGAttributes: map[string][]elbv2model.TargetGroupAttribute{
+				"awesome-ns/ing-1-svc-1:http": {},
+			},
+			wantTGBResIDs: []string{"awesome-ns/ing-1-svc-1:http"},
+		},
+		{
+			name: "sticky paths share a distinct targetGroup",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/stickiness-paths": "/cart,/checkout",
+			},
+			wantRuleTGResIDs: map[string]string{
+				"/cart":                "awesome-ns/ing-1-svc-1:http:sticky",
+				"/checkout":            "awesome-ns/ing-1-svc-1:http:sticky",
+				"/static":              "awesome-ns/ing-1-svc-1:http",
+				"www.example.com/cart": "awesome-ns/ing-1-svc-1:http",
+			},
+			wantTGAttributes: map[string][]elbv2model.TargetGroupAttribute{
+				"awesome-ns/ing-1-svc-1:http": {},
+				"awesome-ns/ing-1-svc-1:http:sticky": {
+					{Key: "stickiness.enabled", Value: "true"},
+					{Key: "stickiness.type", Value: "lb_cookie"},
+				},
+			},
+			wantTGBResIDs: []string{"awesome-ns/ing-1-svc-1:http", "awesome-ns/ing-1-svc-1:http:sticky"},
+		},
+		{
+			name: "sticky paths override stickiness from targetGroup attributes",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/stickiness-paths":        "/cart",
+				"alb.ingress.kubernetes.io/target-group-attributes": "stickiness.enabled=false,stickiness.lb_cookie.duration_seconds=60",
+			},
+			wantRuleTGResIDs: map[string]string{
+				"/cart":                "awesome-ns/ing-1-svc-1:http:sticky",
+				"/checkout":            "awesome-ns/ing-1-svc-1:http",
+				"/static":              "awesome-ns/ing-1-svc-1:http",
+				"www.example.com/cart": "awesome-ns/ing-1-svc-1:http",
+			},
+			wantTGAttributes: map[string][]elbv2model.TargetGroupAttribute{
+				"awesome-ns/ing-1-svc-1:http": {
+					{Key: "stickiness.enabled", Value: "false"},
+					{Key: "stickiness.lb_cookie.duration_seconds", Value: "60"},
+				},
+				"awesome-ns/ing-1-svc-1:http:sticky": {
+					{Key: "stickiness.lb_cookie.duration_seconds", Value: "60"},
+					{Key: "stickiness.enabled", Value: "true"},
+					{Key: "stickiness.type", Value: "lb_cookie"},
+				},
+			},
+			wantTGBResIDs: []string{"awesome-ns/ing-1-svc-1:http", "awesome-ns/ing-1-svc-1:http:sticky"},
+		},
+		{
+			name: "sticky paths are specific to the host of rules",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/stickiness-paths": "www.example.com/cart",
+			},
+			wantRuleTGResIDs: map[string]string{
+				"/cart":                "awesome-ns/ing-1-svc-1:http",
+				"/checkout":            "awesome-ns/ing-1-svc-1:http",
+				"/static":              "awesome-ns/ing-1-svc-1:http",
+				"www.example.com/cart": "awesome-ns/ing-1-svc-1:http:sticky",
+			},
+			wantTGAttributes: map[string][]elbv2model.TargetGroupAttribute{
+				"awesome-ns/ing-1-svc-1:http": {},
+				"awesome-ns/ing-1-svc-1:http:sticky": {
+					{Key: "stickiness.enabled", Value: "true"},
+					{Key: "stickiness.type", Value: "lb_cookie"},
+				},
+			},
+			wantTGBResIDs: []string{"awesome-ns/ing-1-svc-1:http", "awesome-ns/ing-1-svc-1:http:sticky"},
+		},
+		{
+			name: "unknown stickiness paths",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/stickiness-paths": "/cart,/orders,example.com/cart",
+			},
+			wantErr: errors.New("ingress: awesome-ns/ing-1: unknown paths in stickiness-paths annotation: [/orders example.com/cart]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "awesome-ns",
+					Name:        "ing-1",
+					Annotations: tt.ingAnnotations,
+				},
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{
+						{
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{
+										{Path: "/cart", Backend: svcBackend},
+										{Path: "/checkout", Backend: svcBackend},
+										{Path: "/static", Backend: svcBackend},
+									},
+								},
+							},
+						},
+						{
+							Host: "www.example.com",
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{
+										{Path: "/cart", Backend: svcBackend},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				k8sClient:                         k8sClient,
+				clusterName:                       "my-cluster",
+				ingGroup:                          Group{ID: GroupID{Namespace: "awesome-ns", Name: "ing-1"}},
+				stack:                             stack,
+				annotationParser:                  annotationParser,
+				classLoader:                       NewDefaultClassLoader(k8sClient),
+				enhancedBackendBuilder:            NewDefaultEnhancedBackendBuilder(annotationParser),
+				authConfigBuilder:                 NewDefaultAuthConfigBuilder(annotationParser),
+				ruleOptimizer:                     NewDefaultRuleOptimizer(&log.NullLogger{}),
+				defaultTargetType:                 elbv2model.TargetTypeInstance,
+				defaultBackendProtocol:            elbv2model.ProtocolHTTP,
+				defaultBackendProtocolVersion:     elbv2model.ProtocolVersionHTTP1,
+				defaultHealthCheckPath:            "/",
+				defaultHealthCheckIntervalSeconds: 15,
+				defaultHealthCheckTimeoutSeconds:  5,
+				defaultHealthCheckMatcherHTTPCode: "200",
+				tgByResID:                         make(map[string]*elbv2model.TargetGroup),
+			}
+			err := task.buildListenerRules(ctx, core.LiteralStringToken("ls-arn"), 80, elbv2model.ProtocolHTTP, []*networking.Ingress{ing})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+
+			var resLRs []*elbv2model.ListenerRule
+			assert.NoError(t, stack.ListResources(&resLRs))
+			gotRuleTGResIDs := make(map[string]string)
+			for _, lr := range resLRs {
+				var host, path string
+				for _, condition := range lr.Spec.Conditions {
+					if condition.HostHeaderConfig != nil {
+						host = condition.HostHeaderConfig.Values[0]
+					}
+					if condition.PathPatternConfig != nil {
+						path = condition.PathPatternConfig.Values[0]
+					}
+				}
+				for _, dep := range lr.Spec.Actions[0].ForwardConfig.TargetGroups[0].TargetGroupARN.Dependencies() {
+					gotRuleTGResIDs[host+path] = dep.ID()
+				}
+			}
+			assert.Equal(t, tt.wantRuleTGResIDs, gotRuleTGResIDs)
+
+			var resTGs []*elbv2model.TargetGroup
+			assert.NoError(t, stack.ListResources(&resTGs))
+			assert.Len(t, resTGs, len(tt.wantTGAttributes))
+			tgNames := make(map[string]bool)
+			for _, tg := range resTGs {
+				wantAttributes, exists := tt.wantTGAttributes[tg.ID()]
+				assert.True(t, exists, "unexpected targetGroup %v", tg.ID())
+				assert.ElementsMatch(t, wantAttributes, tg.Spec.TargetGroupAttributes)
+				tgNames[tg.Spec.Name] = true
+			}
+			assert.Len(t, tgNames, len(resTGs))
+
+			var resTGBs []*elbv2model.TargetGroupBindingResource
+			assert.NoError(t, stack.ListResources(&resTGBs))
+			var gotTGBResIDs []string
+			for _, tgb := range resTGBs {
+				gotTGBResIDs = append(gotTGBResIDs, tgb.ID())
+			}
+			assert.ElementsMatch(t, tt.wantTGBResIDs, gotTGBResIDs)
+		})
+	}
+}
@@ -0,0 +1,596 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	mock_ingress "sigs.k8s.io/aws-load-balancer-controller/mocks/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_computeIngressListenPortConfigByPort_missingCerts(t *testing.T) {
+	type findMissingCertsCall struct {
+		certARNs []string
+		resp     []string
+		err      error
+	}
+	type discoverCall struct {
+		tlsHosts []string
+		resp     []string
+		err      error
+	}
+	type fields struct {
+		missingCertificatePolicy string
+		findMissingCertsCalls    []findMissingCertsCall
+		discoverCalls            []discoverCall
+	}
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "name-1",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":    `[{"HTTP": 80}, {"HTTPS": 443}]`,
+				"alb.ingress.kubernetes.io/certificate-arn": "cert-1,cert-2",
+			},
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: "app.example.com",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		want       map[int64][]string
+		wantEvents int
+		wantErr    error
+	}{
+		{
+			name: "all certificates exist",
+			fields: fields{
+				missingCertificatePolicy: config.MissingCertificatePolicyError,
+				findMissingCertsCalls: []findMissingCertsCall{
+					{
+						certARNs: []string{"cert-1", "cert-2"},
+						resp:     nil,
+					},
+				},
+			},
+			want: map[int64][]string{
+				80:  nil,
+				443: {"cert-1", "cert-2"},
+			},
+		},
+		{
+			name: "missing certificates with error policy",
+			fields: fields{
+				missingCertificatePolicy: config.MissingCertificatePolicyError,
+				findMissingCertsCalls: []findMissingCertsCall{
+					{
+						certARNs: []string{"cert-1", "cert-2"},
+						resp:     []string{"cert-2"},
+					},
+				},
+			},
+			wantEvents: 1,
+			wantErr:    errors.New("certificates not found: [cert-2]"),
+		},
+		{
+			name: "missing certificates with fallback policy - remaining certificates used",
+			fields: fields{
+				missingCertificatePolicy: config.MissingCertificatePolicyFallback,
+				findMissingCertsCalls: []findMissingCertsCall{
+					{
+						certARNs: []string{"cert-1", "cert-2"},
+						resp:     []string{"cert-2"},
+					},
+				},
+			},
+			want: map[int64][]string{
+				80:  nil,
+				443: {"cert-1"},
+			},
+			wantEvents: 1,
+		},
+		{
+			name: "missing certificates with fallback policy - discovered certificates used",
+			fields: fields{
+				missingCertificatePolicy: config.MissingCertificatePolicyFallback,
+				findMissingCertsCalls: []findMissingCertsCall{
+					{
+						certARNs: []string{"cert-1", "cert-2"},
+						resp:     []string{"cert-1", "cert-2"},
+					},
+				},
+				discoverCalls: []discoverCall{
+					{
+						tlsHosts: []string{"app.example.com"},
+						resp:     []string{"cert-3"},
+					},
+				},
+			},
+			want: map[int64][]string{
+				80:  nil,
+				443: {"cert-3"},
+			},
+			wantEvents: 1,
+		},
+		{
+			name: "missing certificates with skip-listener policy",
+			fields: fields{
+				missingCertificatePolicy: config.MissingCertificatePolicySkipListener,
+				findMissingCertsCalls: []findMissingCertsCall{
+					{
+						certARNs: []string{"cert-1", "cert-2"},
+						resp:     []string{"cert-2"},
+					},
+				},
+			},
+			want: map[int64][]string{
+				80: nil,
+			},
+			wantEvents: 1,
+		},
+		{
+			name: "failed to find missing certificates",
+			fields: fields{
+				missingCertificatePolicy: config.MissingCertificatePolicyError,
+				findMissingCertsCalls: []findMissingCertsCall{
+					{
+						certARNs: []string{"cert-1", "cert-2"},
+						err:      errors.New("some error"),
+					},
+				},
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			certValidator := mock_ingress.NewMockCertValidator(ctrl)
+			for _, call := range tt.fields.findMissingCertsCalls {
+				certValidator.EXPECT().FindMissingCerts(gomock.Any(), call.certARNs).Return(call.resp, call.err)
+			}
+			certDiscovery := mock_ingress.NewMockCertDiscovery(ctrl)
+			for _, call := range tt.fields.discoverCalls {
+				certDiscovery.EXPECT().Discover(gomock.Any(), call.tlsHosts).Return(call.resp, call.err)
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				eventRecorder:            eventRecorder,
+				annotationParser:         annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				certDiscovery:            certDiscovery,
+				certValidator:            certValidator,
+				missingCertificatePolicy: tt.fields.missingCertificatePolicy,
+			}
+			got, err := task.computeIngressListenPortConfigByPort(context.Background(), ing)
+			assert.Len(t, eventRecorder.Events, tt.wantEvents)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			gotTLSCertsByPort := make(map[int64][]string, len(got))
+			for port, cfg := range got {
+				gotTLSCertsByPort[port] = cfg.tlsCerts
+				if port == 443 {
+					assert.Equal(t, elbv2model.ProtocolHTTPS, cfg.protocol)
+				}
+			}
+			assert.Equal(t, tt.want, gotTLSCertsByPort)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_computeIngressListenPortConfigByPort_defaultCert(t *testing.T) {
+	type findMissingCertsCall struct {
+		certARNs []string
+		resp     []string
+	}
+	tests := []struct {
+		name                  string
+		ingAnnotations        map[string]string
+		missingCertPolicy     string
+		findMissingCertsCalls []findMissingCertsCall
+		want                  *string
+		wantErr               error
+	}{
+		{
+			name: "default certificate not specified",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":    `[{"HTTPS": 443}]`,
+				"alb.ingress.kubernetes.io/certificate-arn": "cert-1,cert-2",
+			},
+			missingCertPolicy: config.MissingCertificatePolicyError,
+			findMissingCertsCalls: []findMissingCertsCall{
+				{
+					certARNs: []string{"cert-1", "cert-2"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "default certificate specified",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":            `[{"HTTPS": 443}]`,
+				"alb.ingress.kubernetes.io/certificate-arn":         "cert-1,cert-2",
+				"alb.ingress.kubernetes.io/default-certificate-arn": "cert-2",
+			},
+			missingCertPolicy: config.MissingCertificatePolicyError,
+			findMissingCertsCalls: []findMissingCertsCall{
+				{
+					certARNs: []string{"cert-1", "cert-2"},
+				},
+			},
+			want: awssdk.String("cert-2"),
+		},
+		{
+			name: "default certificate not among certificates",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":            `[{"HTTPS": 443}]`,
+				"alb.ingress.kubernetes.io/certificate-arn":         "cert-1,cert-2",
+				"alb.ingress.kubernetes.io/default-certificate-arn": "cert-3",
+			},
+			missingCertPolicy: config.MissingCertificatePolicyError,
+			wantErr:           errors.New("default certificate cert-3 must be one of certificate-arn: [cert-1 cert-2]"),
+		},
+		{
+			name: "default certificate without certificates",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":            `[{"HTTPS": 443}]`,
+				"alb.ingress.kubernetes.io/default-certificate-arn": "cert-1",
+			},
+			missingCertPolicy: config.MissingCertificatePolicyError,
+			wantErr:           errors.New("default certificate cert-1 must be one of certificate-arn: []"),
+		},
+		{
+			name: "missing default certificate with fallback policy",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":            `[{"HTTPS": 443}]`,
+				"alb.ingress.kubernetes.io/certificate-arn":         "cert-1,cert-2",
+				"alb.ingress.kubernetes.io/default-certificate-arn": "cert-2",
+			},
+			missingCertPolicy: config.MissingCertificatePolicyFallback,
+			findMissingCertsCalls: []findMissingCertsCall{
+				{
+					certARNs: []string{"cert-1", "cert-2"},
+					resp:     []string{"cert-2"},
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			certValidator := mock_ingress.NewMockCertValidator(ctrl)
+			for _, call := range tt.findMissingCertsCalls {
+				certValidator.EXPECT().FindMissingCerts(gomock.Any(), call.certARNs).Return(call.resp, nil)
+			}
+			task := &defaultModelBuildTask{
+				eventRecorder:            record.NewFakeRecorder(10),
+				annotationParser:         annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				certValidator:            certValidator,
+				missingCertificatePolicy: tt.missingCertPolicy,
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns-1",
+					Name:        "name-1",
+					Annotations: tt.ingAnnotations,
+				},
+			}
+			got, err := task.computeIngressListenPortConfigByPort(context.Background(), ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got[443].defaultTLSCert)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_computeIngressListenPortConfigByPort_wildcardCertFallback(t *testing.T) {
+	type discoverCall struct {
+		tlsHosts []string
+		resp     []string
+		err      error
+	}
+	type discoverWithWildcardFallbackCall struct {
+		tlsHosts    []string
+		resp        []string
+		respDefault *string
+		err         error
+	}
+	tests := []struct {
+		name                              string
+		ingAnnotations                    map[string]string
+		discoverCalls                     []discoverCall
+		discoverWithWildcardFallbackCalls []discoverWithWildcardFallbackCall
+		wantTLSCerts                      []string
+		wantDefaultTLSCert                *string
+		wantErr                           error
+	}{
+		{
+			name: "wildcard fallback not enabled",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports": `[{"HTTPS": 443}]`,
+			},
+			discoverCalls: []discoverCall{
+				{
+					tlsHosts: []string{"app.example.com", "www.example.com"},
+					resp:     []string{"cert-1"},
+				},
+			},
+			wantTLSCerts: []string{"cert-1"},
+		},
+		{
+			name: "wildcard fallback enabled",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":                  `[{"HTTPS": 443}]`,
+				"alb.ingress.kubernetes.io/wildcard-certificate-fallback": "true",
+			},
+			discoverWithWildcardFallbackCalls: []discoverWithWildcardFallbackCall{
+				{
+					tlsHosts:    []string{"app.example.com", "www.example.com"},
+					resp:        []string{"cert-app", "cert-wildcard"},
+					respDefault: awssdk.String("cert-wildcard"),
+				},
+			},
+			wantTLSCerts:       []string{"cert-app", "cert-wildcard"},
+			wantDefaultTLSCert: awssdk.String("cert-wildcard"),
+		},
+		{
+			name: "wildcard fallback enabled without wildcard certificate covering hosts",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":                  `[{"HTTPS": 443}]`,
+				"alb.ingress.kubernetes.io/wildcard-certificate-fallback": "true",
+			},
+			discoverWithWildcardFallbackCalls: []discoverWithWildcardFallbackCall{
+				{
+					tlsHosts: []string{"app.example.com", "www.example.com"},
+					err:      errors.New("none wildcard certificate covers hosts: [www.example.com]"),
+				},
+			},
+			wantErr: errors.New("none wildcard certificate covers hosts: [www.example.com]"),
+		},
+		{
+			name: "invalid wildcard fallback annotation",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":                  `[{"HTTPS": 443}]`,
+				"alb.ingress.kubernetes.io/wildcard-certificate-fallback": "yes",
+			},
+			wantErr: errors.New("failed to parse bool annotation, alb.ingress.kubernetes.io/wildcard-certificate-fallback: yes: strconv.ParseBool: parsing \"yes\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			certDiscovery := mock_ingress.NewMockCertDiscovery(ctrl)
+			for _, call := range tt.discoverCalls {
+				certDiscovery.EXPECT().Discover(gomock.Any(), call.tlsHosts).Return(call.resp, call.err)
+			}
+			for _, call := range tt.discoverWithWildcardFallbackCalls {
+				certDiscovery.EXPECT().DiscoverWithWildcardFallback(gomock.Any(), call.tlsHosts).Return(call.resp, call.respDefault, call.err)
+			}
+			task := &defaultModelBuildTask{
+				eventRecorder:    record.NewFakeRecorder(10),
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				certDiscovery:    certDiscovery,
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns-1",
+					Name:        "name-1",
+					Annotations: tt.ingAnnotations,
+				},
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{
+						{
+							Host: "www.example.com",
+						},
+						{
+							Host: "app.example.com",
+						},
+					},
+				},
+			}
+			got, err := task.computeIngressListenPortConfigByPort(context.Background(), ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTLSCerts, got[443].tlsCerts)
+			assert.Equal(t, tt.wantDefaultTLSCert, got[443].defaultTLSCert)
+		})
+	}
+}
+
+func Test_buildListenerCertificates(t *testing.T) {
+	tests := []struct {
+		name           string
+		tlsCerts       []string
+		defaultTLSCert *string
+		want           []elbv2model.Certificate
+	}{
+		{
+			name:     "no certificates",
+			tlsCerts: nil,
+			want:     []elbv2model.Certificate{},
+		},
+		{
+			name:     "first certificate used as default without designated default",
+			tlsCerts: []string{"cert-1", "cert-2", "cert-3"},
+			want: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-1")},
+				{CertificateARN: awssdk.String("cert-2")},
+				{CertificateARN: awssdk.String("cert-3")},
+			},
+		},
+		{
+			name:           "designated default certificate placed first",
+			tlsCerts:       []string{"cert-1", "cert-2", "cert-3"},
+			defaultTLSCert: awssdk.String("cert-2"),
+			want: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-2")},
+				{CertificateARN: awssdk.String("cert-1")},
+				{CertificateARN: awssdk.String("cert-3")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildListenerCertificates(tt.tlsCerts, tt.defaultTLSCert)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_validateIngressStrictTransportSecurity(t *testing.T) {
+	tests := []struct {
+		name           string
+		ingAnnotations map[string]string
+		listenPorts    map[int64]elbv2model.Protocol
+		wantErr        error
+	}{
+		{
+			name:        "strict-transport-security not specified",
+			listenPorts: map[int64]elbv2model.Protocol{443: elbv2model.ProtocolHTTPS},
+		},
+		{
+			name: "strict-transport-security with HTTPS listener is rejected as unsupported",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/strict-transport-security": "max-age=31536000,include-subdomains=true,preload=true",
+			},
+			listenPorts: map[int64]elbv2model.Protocol{80: elbv2model.ProtocolHTTP, 443: elbv2model.ProtocolHTTPS},
+			wantErr:     errors.New(`unsupported strict-transport-security "max-age=31536000; includeSubDomains; preload": listener attributes aren't supported by this controller`),
+		},
+		{
+			name: "strict-transport-security without HTTPS listener",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/strict-transport-security": "max-age=31536000",
+			},
+			listenPorts: map[int64]elbv2model.Protocol{80: elbv2model.ProtocolHTTP},
+			wantErr:     errors.New("strict-transport-security can only be configured with HTTPS listeners"),
+		},
+		{
+			name: "invalid strict-transport-security",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/strict-transport-security": "include-subdomains=true",
+			},
+			listenPorts: map[int64]elbv2model.Protocol{443: elbv2model.ProtocolHTTPS},
+			wantErr:     errors.New("strict-transport-security max-age must be specified"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns-1",
+					Name:        "name-1",
+					Annotations: tt.ingAnnotations,
+				},
+			}
+			err := task.validateIngressStrictTransportSecurity(context.Background(), ing, tt.listenPorts)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_buildStrictTransportSecurityHeaderValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		hstsConfig map[string]string
+		want       string
+		wantErr    error
+	}{
+		{
+			name:       "max-age only",
+			hstsConfig: map[string]string{"max-age": "86400"},
+			want:       "max-age=86400",
+		},
+		{
+			name:       "max-age with includeSubDomains",
+			hstsConfig: map[string]string{"max-age": "86400", "include-subdomains": "true"},
+			want:       "max-age=86400; includeSubDomains",
+		},
+		{
+			name:       "max-age with includeSubDomains and preload",
+			hstsConfig: map[string]string{"max-age": "63072000", "include-subdomains": "true", "preload": "true"},
+			want:       "max-age=63072000; includeSubDomains; preload",
+		},
+		{
+			name:       "directives explicitly disabled",
+			hstsConfig: map[string]string{"max-age": "0", "include-subdomains": "false", "preload": "false"},
+			want:       "max-age=0",
+		},
+		{
+			name:       "missing max-age",
+			hstsConfig: map[string]string{"include-subdomains": "true"},
+			wantErr:    errors.New("strict-transport-security max-age must be specified"),
+		},
+		{
+			name:       "negative max-age",
+			hstsConfig: map[string]string{"max-age": "-1"},
+			wantErr:    errors.New("invalid strict-transport-security max-age: -1"),
+		},
+		{
+			name:       "non-boolean include-subdomains",
+			hstsConfig: map[string]string{"max-age": "86400", "include-subdomains": "yes"},
+			wantErr:    errors.New("invalid strict-transport-security include-subdomains: yes"),
+		},
+		{
+			name:       "preload without includeSubDomains",
+			hstsConfig: map[string]string{"max-age": "31536000", "preload": "true"},
+			wantErr:    errors.New("strict-transport-security preload requires include-subdomains and max-age of at least 31536000"),
+		},
+		{
+			name:       "preload with short max-age",
+			hstsConfig: map[string]string{"max-age": "86400", "include-subdomains": "true", "preload": "true"},
+			wantErr:    errors.New("strict-transport-security preload requires include-subdomains and max-age of at least 31536000"),
+		},
+		{
+			name:       "unknown configuration",
+			hstsConfig: map[string]string{"max-age": "86400", "includeSubDomains": "true"},
+			wantErr:    errors.New("unknown strict-transport-security configuration: includeSubDomains"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildStrictTransportSecurityHeaderValue(tt.hstsConfig)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -9,20 +9,66 @@ import (
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"regexp"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/equality"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
-	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"strconv"
 	"strings"
 )
 
 const (
 	resourceIDLoadBalancer = "LoadBalancer"
+
+	lbAttrsAccessLogsPrefix        = "access_logs."
+	lbAttrsAccessLogsS3Enabled     = "access_logs.s3.enabled"
+	lbAttrsAccessLogsS3Bucket      = "access_logs.s3.bucket"
+	lbAttrsAccessLogsS3Prefix      = "access_logs.s3.prefix"
+	lbAttrsConnectionLogsPrefix    = "connection_logs."
+	lbAttrsConnectionLogsS3Enabled = "connection_logs.s3.enabled"
+	lbAttrsConnectionLogsS3Bucket  = "connection_logs.s3.bucket"
+	lbAttrsConnectionLogsS3Prefix  = "connection_logs.s3.prefix"
+	lbAttrsIdleTimeoutSeconds      = "idle_timeout.timeout_seconds"
+	lbAttrsTLSHeadersEnabled       = "routing.http.x_amzn_tls_version_and_cipher_suite.enabled"
+	lbAttrsXFFClientPortEnabled    = "routing.http.xff_client_port.enabled"
+	lbAttrsWAFFailOpenEnabled      = "waf.fail_open.enabled"
+
+	minIdleTimeoutSeconds = 1
+	maxIdleTimeoutSeconds = 4000
+)
+
+// supportedLogAttributes are the log related loadBalancerAttributes supported by ALB.
+var supportedLogAttributes = sets.NewString(
+	lbAttrsAccessLogsS3Enabled, lbAttrsAccessLogsS3Bucket, lbAttrsAccessLogsS3Prefix,
+	lbAttrsConnectionLogsS3Enabled, lbAttrsConnectionLogsS3Bucket, lbAttrsConnectionLogsS3Prefix,
 )
 
+// booleanAttributes are the loadBalancerAttributes that only accept boolean values.
+var booleanAttributes = sets.NewString(
+	lbAttrsTLSHeadersEnabled, lbAttrsXFFClientPortEnabled, lbAttrsWAFFailOpenEnabled,
+)
+
+// bucketAttributeByLogToggle are the bucket attribute required for each log toggle attribute.
+var bucketAttributeByLogToggle = map[string]string{
+	lbAttrsAccessLogsS3Enabled:     lbAttrsAccessLogsS3Bucket,
+	lbAttrsConnectionLogsS3Enabled: lbAttrsConnectionLogsS3Bucket,
+}
+
+// prefixAttributeByLogToggle are the prefix attribute for each log toggle attribute.
+var prefixAttributeByLogToggle = map[string]string{
+	lbAttrsAccessLogsS3Enabled:     lbAttrsAccessLogsS3Prefix,
+	lbAttrsConnectionLogsS3Enabled: lbAttrsConnectionLogsS3Prefix,
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancer(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig) (*elbv2model.LoadBalancer, error) {
 	lbSpec, err := t.buildLoadBalancerSpec(ctx, listenPortConfigByPort)
 	if err != nil {
@@ -42,7 +88,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, liste
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
-	subnetMappings, err := t.buildLoadBalancerSubnetMappings(ctx, scheme)
+	subnetMappings, err := t.buildLoadBalancerSubnetMappings(ctx, scheme, ipAddressType)
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
@@ -50,7 +96,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, liste
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
-	loadBalancerAttributes, err := t.buildLoadBalancerAttributes(ctx)
+	loadBalancerAttributes, err := t.buildLoadBalancerAttributes(ctx, listenPortConfigByPort)
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
@@ -90,11 +136,14 @@ func (t *defaultModelBuildTask) buildLoadBalancerName(_ context.Context, scheme
 	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
 }
 
-func (t *defaultModelBuildTask) buildLoadBalancerScheme(_ context.Context) (elbv2model.LoadBalancerScheme, error) {
+func (t *defaultModelBuildTask) buildLoadBalancerScheme(ctx context.Context) (elbv2model.LoadBalancerScheme, error) {
 	explicitSchemes := sets.String{}
 	for _, ing := range t.ingGroup.Members {
-		rawSchema := ""
-		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixScheme, &rawSchema, ing.Annotations); !exists {
+		rawSchema, err := t.buildIngressExplicitScheme(ctx, ing)
+		if err != nil {
+			return "", err
+		}
+		if rawSchema == "" {
 			continue
 		}
 		explicitSchemes.Insert(rawSchema)
@@ -116,6 +165,31 @@ func (t *defaultModelBuildTask) buildLoadBalancerScheme(_ context.Context) (elbv
 	}
 }
 
+// buildIngressExplicitScheme builds the scheme explicitly specified for Ingress via annotation or its IngressClassParams.
+// returns empty string if no scheme is specified.
+// scheme annotation takes precedence over the scheme in IngressClassParams unless it's authoritative.
+func (t *defaultModelBuildTask) buildIngressExplicitScheme(ctx context.Context, ing *networking.Ingress) (string, error) {
+	classConfig, err := t.classLoader.Load(ctx, ing)
+	if err != nil {
+		return "", err
+	}
+	rawSchema := ""
+	annotationExists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixScheme, &rawSchema, ing.Annotations)
+	if classConfig.IngClassParams == nil || classConfig.IngClassParams.Spec.Scheme == nil {
+		return rawSchema, nil
+	}
+	ingClassParams := classConfig.IngClassParams
+	classScheme := string(*ingClassParams.Spec.Scheme)
+	if !annotationExists {
+		return classScheme, nil
+	}
+	if ingClassParams.Spec.SchemeAuthoritative && rawSchema != classScheme {
+		return "", errors.Errorf("scheme %v for Ingress %v is not allowed, IngressClassParams %v enforces scheme %v",
+			rawSchema, k8s.NamespacedName(ing), ingClassParams.Name, classScheme)
+	}
+	return rawSchema, nil
+}
+
 // buildLoadBalancerIPAddressType builds the LoadBalancer IPAddressType.
 func (t *defaultModelBuildTask) buildLoadBalancerIPAddressType(_ context.Context) (elbv2model.IPAddressType, error) {
 	explicitIPAddressTypes := sets.NewString()
@@ -143,7 +217,25 @@ func (t *defaultModelBuildTask) buildLoadBalancerIPAddressType(_ context.Context
 	}
 }
 
-func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Context, scheme elbv2model.LoadBalancerScheme) ([]elbv2model.SubnetMapping, error) {
+// fetchExistingLoadBalancer fetches the existing LoadBalancer of IngressGroup, nil is returned if it doesn't exist yet.
+func (t *defaultModelBuildTask) fetchExistingLoadBalancer(ctx context.Context) (*elbv2deploy.LoadBalancerWithTags, error) {
+	if t.existingLoadBalancerFetched {
+		return t.existingLoadBalancer, nil
+	}
+	stackTags := t.trackingProvider.StackTags(t.stack)
+	sdkLBs, err := t.elbv2TaggingManager.ListLoadBalancers(ctx, tracking.TagsAsTagFilter(stackTags))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch existing loadBalancer")
+	}
+	// duplicated loadBalancers will be deleted by deployer, none of them is considered as existing one.
+	if len(sdkLBs) == 1 {
+		t.existingLoadBalancer = &sdkLBs[0]
+	}
+	t.existingLoadBalancerFetched = true
+	return t.existingLoadBalancer, nil
+}
+
+func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Context, scheme elbv2model.LoadBalancerScheme, ipAddressType elbv2model.IPAddressType) ([]elbv2model.SubnetMapping, error) {
 	var explicitSubnetNameOrIDsList [][]string
 	for _, ing := range t.ingGroup.Members {
 		var rawSubnetNameOrIDs []string
@@ -154,13 +246,25 @@ func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Cont
 	}
 
 	if len(explicitSubnetNameOrIDsList) == 0 {
+		existingLB, err := t.fetchExistingLoadBalancer(ctx)
+		if err != nil {
+			return nil, err
+		}
 		chosenSubnets, err := t.subnetsResolver.ResolveViaDiscovery(ctx,
-			networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
-			networking.WithSubnetsResolveLBScheme(scheme),
+			networkingpkg.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+			networkingpkg.WithSubnetsResolveLBScheme(scheme),
+			networkingpkg.WithSubnetsResolvePreferAvailableIPAddresses(t.subnetDiscoveryPreferAvailableIPs),
+			networkingpkg.WithSubnetsResolvePreferredSubnetIDs(elbv2deploy.ExtractLoadBalancerSubnetIDs(existingLB)),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "couldn't auto-discover subnets")
 		}
+		if t.azExpansionPolicy == config.AZExpansionPolicyIgnore {
+			chosenSubnets = elbv2deploy.FilterSubnetsWithinLoadBalancerAZs(chosenSubnets, existingLB)
+		}
+		if err := validateSubnetsForIPAddressType(chosenSubnets, ipAddressType); err != nil {
+			return nil, err
+		}
 		return buildLoadBalancerSubnetMappingsWithSubnets(chosenSubnets), nil
 	}
 
@@ -172,12 +276,15 @@ func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Cont
 		}
 	}
 	chosenSubnets, err := t.subnetsResolver.ResolveViaNameOrIDSlice(ctx, chosenSubnetNameOrIDs,
-		networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
-		networking.WithSubnetsResolveLBScheme(scheme),
+		networkingpkg.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+		networkingpkg.WithSubnetsResolveLBScheme(scheme),
 	)
 	if err != nil {
 		return nil, err
 	}
+	if err := validateSubnetsForIPAddressType(chosenSubnets, ipAddressType); err != nil {
+		return nil, err
+	}
 	return buildLoadBalancerSubnetMappingsWithSubnets(chosenSubnets), nil
 }
 
@@ -205,7 +312,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerSecurityGroups(ctx context.Cont
 			return nil, errors.Errorf("conflicting securityGroups: %v | %v", chosenSGNameOrIDs, sgNameOrIDs)
 		}
 	}
-	chosenSGIDs, err := t.resolveSecurityGroupIDsViaNameOrIDSlice(ctx, chosenSGNameOrIDs)
+	chosenSGIDs, err := t.sgResolver.ResolveViaNameOrID(ctx, chosenSGNameOrIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +323,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerSecurityGroups(ctx context.Cont
 	return sgIDTokens, nil
 }
 
-func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) ([]elbv2model.LoadBalancerAttribute, error) {
+func (t *defaultModelBuildTask) buildLoadBalancerAttributes(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig) ([]elbv2model.LoadBalancerAttribute, error) {
 	mergedAttributes := make(map[string]string)
 	for _, ing := range t.ingGroup.Members {
 		var rawAttributes map[string]string
@@ -230,6 +337,25 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 			mergedAttributes[attrKey] = attrValue
 		}
 	}
+	if err := t.mergeWAFv2FailOpenAttribute(ctx, mergedAttributes); err != nil {
+		return nil, err
+	}
+	if err := validateLoadBalancerLogAttributes(mergedAttributes); err != nil {
+		return nil, err
+	}
+	if err := validateLoadBalancerIdleTimeoutAttribute(mergedAttributes); err != nil {
+		return nil, err
+	}
+	if err := validateLoadBalancerBooleanAttributes(mergedAttributes); err != nil {
+		return nil, err
+	}
+	if err := validateLoadBalancerTLSHeadersAttribute(mergedAttributes, listenPortConfigByPort); err != nil {
+		return nil, err
+	}
+	if err := t.validateLoadBalancerLogBuckets(ctx, mergedAttributes); err != nil {
+		return nil, err
+	}
+	resetDisabledLogAttributes(mergedAttributes)
 	attributes := make([]elbv2model.LoadBalancerAttribute, 0, len(mergedAttributes))
 	for attrKey, attrValue := range mergedAttributes {
 		attributes = append(attributes, elbv2model.LoadBalancerAttribute{
@@ -240,11 +366,135 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 	return attributes, nil
 }
 
+// mergeWAFv2FailOpenAttribute merges the WAF fail open setting into loadBalancerAttributes.
+// the fail open attribute is pinned whenever WAFv2 WebACL is specified, it defaults to false unless set via annotation or loadBalancerAttributes,
+// so that it never lingers from previous settings. loadBalancerAttributes are reconciled prior to WAFv2 WebACL association,
+// thus the WebACL won't be associated while fail open is mis-set.
+func (t *defaultModelBuildTask) mergeWAFv2FailOpenAttribute(_ context.Context, attributes map[string]string) error {
+	webACLSpecified := false
+	explicitFailOpens := make(map[bool]struct{})
+	for _, ing := range t.ingGroup.Members {
+		rawWebACLARN := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixWAFv2ACLARN, &rawWebACLARN, ing.Annotations); exists && rawWebACLARN != "" {
+			webACLSpecified = true
+		}
+		rawFailOpen := false
+		exists, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixWAFv2FailOpen, &rawFailOpen, ing.Annotations)
+		if err != nil {
+			return err
+		}
+		if exists {
+			explicitFailOpens[rawFailOpen] = struct{}{}
+		}
+	}
+	if len(explicitFailOpens) > 1 {
+		return errors.New("conflicting WAFv2 fail open")
+	}
+	if len(explicitFailOpens) == 0 {
+		if _, exists := attributes[lbAttrsWAFFailOpenEnabled]; webACLSpecified && !exists {
+			attributes[lbAttrsWAFFailOpenEnabled] = "false"
+		}
+		return nil
+	}
+	if !webACLSpecified {
+		return errors.New("WAFv2 fail open requires WAFv2 WebACL to be specified")
+	}
+	_, failOpen := explicitFailOpens[true]
+	rawFailOpen := strconv.FormatBool(failOpen)
+	if existingFailOpen, exists := attributes[lbAttrsWAFFailOpenEnabled]; exists && existingFailOpen != rawFailOpen {
+		return errors.Errorf("conflicting loadBalancerAttribute %v: %v | %v", lbAttrsWAFFailOpenEnabled, existingFailOpen, rawFailOpen)
+	}
+	attributes[lbAttrsWAFFailOpenEnabled] = rawFailOpen
+	return nil
+}
+
+// validateLoadBalancerLogAttributes validates the log related loadBalancerAttributes against the supported set.
+func validateLoadBalancerLogAttributes(attributes map[string]string) error {
+	for attrKey := range attributes {
+		if !strings.HasPrefix(attrKey, lbAttrsAccessLogsPrefix) && !strings.HasPrefix(attrKey, lbAttrsConnectionLogsPrefix) {
+			continue
+		}
+		if !supportedLogAttributes.Has(attrKey) {
+			return errors.Errorf("unsupported loadBalancerAttribute %v, supported log attributes: %v", attrKey, supportedLogAttributes.List())
+		}
+	}
+	for _, toggleAttrKey := range sets.StringKeySet(bucketAttributeByLogToggle).List() {
+		bucketAttrKey := bucketAttributeByLogToggle[toggleAttrKey]
+		rawEnabled, exists := attributes[toggleAttrKey]
+		if !exists {
+			continue
+		}
+		enabled, err := strconv.ParseBool(rawEnabled)
+		if err != nil {
+			return errors.Errorf("invalid loadBalancerAttribute %v: %v", toggleAttrKey, rawEnabled)
+		}
+		if enabled && len(attributes[bucketAttrKey]) == 0 {
+			return errors.Errorf("loadBalancerAttribute %v must be specified when %v is enabled", bucketAttrKey, toggleAttrKey)
+		}
+	}
+	return nil
+}
+
+// validateLoadBalancerLogBuckets validates the buckets of enabled logs permit log delivery.
+func (t *defaultModelBuildTask) validateLoadBalancerLogBuckets(ctx context.Context, attributes map[string]string) error {
+	if t.accessLogBucketValidator == nil {
+		return nil
+	}
+	for _, toggleAttrKey := range sets.StringKeySet(bucketAttributeByLogToggle).List() {
+		if enabled, _ := strconv.ParseBool(attributes[toggleAttrKey]); !enabled {
+			continue
+		}
+		bucketAttrKey := bucketAttributeByLogToggle[toggleAttrKey]
+		if err := t.accessLogBucketValidator.Validate(ctx, attributes[bucketAttrKey]); err != nil {
+			return errors.Wrapf(err, "invalid loadBalancerAttribute %v", bucketAttrKey)
+		}
+	}
+	return nil
+}
+
+// resetDisabledLogAttributes resets the bucket and prefix attributes of disabled logs to empty unless explicitly specified,
+// so that stale bucket and prefix don't linger on the LoadBalancer after logs are disabled.
+func resetDisabledLogAttributes(attributes map[string]string) {
+	for _, toggleAttrKey := range sets.StringKeySet(bucketAttributeByLogToggle).List() {
+		rawEnabled, exists := attributes[toggleAttrKey]
+		if !exists {
+			continue
+		}
+		if enabled, _ := strconv.ParseBool(rawEnabled); enabled {
+			continue
+		}
+		for _, attrKey := range []string{bucketAttributeByLogToggle[toggleAttrKey], prefixAttributeByLogToggle[toggleAttrKey]} {
+			if _, exists := attributes[attrKey]; !exists {
+				attributes[attrKey] = ""
+			}
+		}
+	}
+}
+
+// validateLoadBalancerIdleTimeoutAttribute validates the idle timeout loadBalancerAttribute is within the supported range.
+// the idle timeout applies to the whole LoadBalancer instead of individual listeners.
+func validateLoadBalancerIdleTimeoutAttribute(attributes map[string]string) error {
+	rawIdleTimeout, exists := attributes[lbAttrsIdleTimeoutSeconds]
+	if !exists {
+		return nil
+	}
+	idleTimeout, err := strconv.ParseInt(rawIdleTimeout, 10, 64)
+	if err != nil {
+		return errors.Errorf("invalid loadBalancerAttribute %v: %v", lbAttrsIdleTimeoutSeconds, rawIdleTimeout)
+	}
+	if idleTimeout < minIdleTimeoutSeconds || idleTimeout > maxIdleTimeoutSeconds {
+		return errors.Errorf("loadBalancerAttribute %v must be within %v-%v seconds: %v",
+			lbAttrsIdleTimeoutSeconds, minIdleTimeoutSeconds, maxIdleTimeoutSeconds, rawIdleTimeout)
+	}
+	return nil
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancerTags(_ context.Context) (map[string]string, error) {
 	mergedTags := make(map[string]string)
 	for _, ing := range t.ingGroup.Members {
 		var rawTags map[string]string
-		if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTags, &rawTags, ing.Annotations); err != nil {
+		if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTags, &rawTags, ing.Annotations,
+			annotations.WithRejectDuplicateKeys()); err != nil {
 			return nil, err
 		}
 		for tagKey, tagValue := range rawTags {
@@ -254,57 +504,43 @@ func (t *defaultModelBuildTask) buildLoadBalancerTags(_ context.Context) (map[st
 			mergedTags[tagKey] = tagValue
 		}
 	}
-	return mergedTags, nil
+	return t.buildTagsWithDefaults(mergedTags), nil
 }
 
-func (t *defaultModelBuildTask) resolveSecurityGroupIDsViaNameOrIDSlice(ctx context.Context, sgNameOrIDs []string) ([]string, error) {
-	var sgIDs []string
-	var sgNames []string
-	for _, nameOrID := range sgNameOrIDs {
-		if strings.HasPrefix(nameOrID, "sg-") {
-			sgIDs = append(sgIDs, nameOrID)
-		} else {
-			sgNames = append(sgNames, nameOrID)
-		}
+// buildTagsWithDefaults merges the default tags into user-specified tags, user-specified tags take precedence on key collision.
+func (t *defaultModelBuildTask) buildTagsWithDefaults(tags map[string]string) map[string]string {
+	if len(t.defaultTags) == 0 {
+		return tags
 	}
-	var resolvedSGs []*ec2sdk.SecurityGroup
-	if len(sgIDs) > 0 {
-		req := &ec2sdk.DescribeSecurityGroupsInput{
-			GroupIds: awssdk.StringSlice(sgIDs),
-		}
-		sgs, err := t.ec2Client.DescribeSecurityGroupsAsList(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-		resolvedSGs = append(resolvedSGs, sgs...)
-	}
-	if len(sgNames) > 0 {
-		req := &ec2sdk.DescribeSecurityGroupsInput{
-			Filters: []*ec2sdk.Filter{
-				{
-					Name:   awssdk.String("tag:Name"),
-					Values: awssdk.StringSlice(sgNames),
-				},
-				{
-					Name:   awssdk.String("vpc-id"),
-					Values: awssdk.StringSlice([]string{t.vpcID}),
-				},
-			},
-		}
-		sgs, err := t.ec2Client.DescribeSecurityGroupsAsList(ctx, req)
-		if err != nil {
-			return nil, err
+	return algorithm.MergeStringMap(tags, t.defaultTags)
+}
+
+// validateSubnetsForIPAddressType checks whether subnets can be used by LoadBalancer with specific IPAddressType.
+// dualstack LoadBalancers requires all subnets to have an IPv6 CIDR block associated.
+func validateSubnetsForIPAddressType(subnets []*ec2sdk.Subnet, ipAddressType elbv2model.IPAddressType) error {
+	if ipAddressType != elbv2model.IPAddressTypeDualStack {
+		return nil
+	}
+	var subnetIDsWithoutIPv6 []string
+	for _, subnet := range subnets {
+		if !hasAssociatedIPv6CIDRBlock(subnet) {
+			subnetIDsWithoutIPv6 = append(subnetIDsWithoutIPv6, awssdk.StringValue(subnet.SubnetId))
 		}
-		resolvedSGs = append(resolvedSGs, sgs...)
 	}
-	resolvedSGIDs := make([]string, 0, len(resolvedSGs))
-	for _, sg := range resolvedSGs {
-		resolvedSGIDs = append(resolvedSGIDs, awssdk.StringValue(sg.GroupId))
+	if len(subnetIDsWithoutIPv6) != 0 {
+		return errors.Errorf("subnets must have IPv6 CIDR block associated for %v IPAddressType: %v", elbv2model.IPAddressTypeDualStack, subnetIDsWithoutIPv6)
 	}
-	if len(resolvedSGIDs) != len(sgNameOrIDs) {
-		return nil, errors.Errorf("couldn't found all securityGroups, nameOrIDs: %v, found: %v", sgNameOrIDs, resolvedSGIDs)
+	return nil
+}
+
+func hasAssociatedIPv6CIDRBlock(subnet *ec2sdk.Subnet) bool {
+	for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+		if association.Ipv6CidrBlockState != nil &&
+			awssdk.StringValue(association.Ipv6CidrBlockState.State) == ec2sdk.SubnetCidrBlockStateCodeAssociated {
+			return true
+		}
 	}
-	return resolvedSGIDs, nil
+	return false
 }
 
 func buildLoadBalancerSubnetMappingsWithSubnets(subnets []*ec2sdk.Subnet) []elbv2model.SubnetMapping {
@@ -316,3 +552,36 @@ func buildLoadBalancerSubnetMappingsWithSubnets(subnets []*ec2sdk.Subnet) []elbv
 	}
 	return subnetMappings
 }
+
+// validateLoadBalancerBooleanAttributes validates the loadBalancerAttributes that only accept boolean values.
+// only `true` and `false` are accepted, since ELB rejects the other forms accepted by strconv.ParseBool, like `1` or `True`.
+func validateLoadBalancerBooleanAttributes(attributes map[string]string) error {
+	for _, attrKey := range booleanAttributes.List() {
+		rawValue, exists := attributes[attrKey]
+		if !exists {
+			continue
+		}
+		if rawValue != "true" && rawValue != "false" {
+			return errors.Errorf("invalid loadBalancerAttribute %v: %v", attrKey, rawValue)
+		}
+	}
+	return nil
+}
+
+// validateLoadBalancerTLSHeadersAttribute validates the TLS version and cipher suite headers loadBalancerAttribute.
+// the headers are only added to requests received by HTTPS listeners, so it can only be enabled with HTTPS listeners.
+func validateLoadBalancerTLSHeadersAttribute(attributes map[string]string, listenPortConfigByPort map[int64]listenPortConfig) error {
+	rawEnabled, exists := attributes[lbAttrsTLSHeadersEnabled]
+	if !exists {
+		return nil
+	}
+	if rawEnabled != "true" {
+		return nil
+	}
+	for _, cfg := range listenPortConfigByPort {
+		if cfg.protocol == elbv2model.ProtocolHTTPS {
+			return nil
+		}
+	}
+	return errors.Errorf("loadBalancerAttribute %v can only be enabled with %v listeners", lbAttrsTLSHeadersEnabled, elbv2model.ProtocolHTTPS)
+}
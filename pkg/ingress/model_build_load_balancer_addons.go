@@ -2,15 +2,21 @@ package ingress
 
 import (
 	"context"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
 	wafregionalmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafregional"
 	wafv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafv2"
+	"strings"
 )
 
+// the name of WAFv2 logging destinations must start with this prefix.
+const wafv2LoggingDestinationNamePrefix = "aws-waf-logs-"
+
 func (t *defaultModelBuildTask) buildLoadBalancerAddOns(ctx context.Context, lbARN core.StringToken) error {
 	if _, err := t.buildWAFv2WebACLAssociation(ctx, lbARN); err != nil {
 		return err
@@ -24,7 +30,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerAddOns(ctx context.Context, lbA
 	return nil
 }
 
-func (t *defaultModelBuildTask) buildWAFv2WebACLAssociation(_ context.Context, lbARN core.StringToken) (*wafv2model.WebACLAssociation, error) {
+func (t *defaultModelBuildTask) buildWAFv2WebACLAssociation(ctx context.Context, lbARN core.StringToken) (*wafv2model.WebACLAssociation, error) {
 	explicitWebACLARNs := sets.NewString()
 	for _, ing := range t.ingGroup.Members {
 		rawWebACLARN := ""
@@ -32,21 +38,84 @@ func (t *defaultModelBuildTask) buildWAFv2WebACLAssociation(_ context.Context, l
 			explicitWebACLARNs.Insert(rawWebACLARN)
 		}
 	}
-	if len(explicitWebACLARNs) == 0 {
-		return nil, nil
+	loggingDestinationARN, err := t.buildWAFv2LoggingDestinationARN(ctx)
+	if err != nil {
+		return nil, err
 	}
 	if len(explicitWebACLARNs) > 1 {
 		return nil, errors.Errorf("conflicting WAFv2 WebACL ARNs: %v", explicitWebACLARNs.List())
 	}
 	webACLARN, _ := explicitWebACLARNs.PopAny()
-	if webACLARN != "" {
-		association := wafv2model.NewWebACLAssociation(t.stack, resourceIDLoadBalancer, wafv2model.WebACLAssociationSpec{
-			WebACLARN:   webACLARN,
-			ResourceARN: lbARN,
-		})
-		return association, nil
+	if webACLARN == "" {
+		if loggingDestinationARN != "" {
+			return nil, errors.New("WAFv2 logging destination requires WAFv2 WebACL to be specified")
+		}
+		return nil, nil
 	}
-	return nil, nil
+	existingLB, err := t.fetchExistingLoadBalancer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var appliedLoggingDestinationARN string
+	if existingLB != nil {
+		appliedLoggingDestinationARN = existingLB.Tags[elbv2deploy.LoadBalancerTagKeyWAFv2LoggingDestination]
+	}
+	association := wafv2model.NewWebACLAssociation(t.stack, resourceIDLoadBalancer, wafv2model.WebACLAssociationSpec{
+		WebACLARN:                    webACLARN,
+		ResourceARN:                  lbARN,
+		LoggingDestinationARN:        loggingDestinationARN,
+		AppliedLoggingDestinationARN: appliedLoggingDestinationARN,
+	})
+	return association, nil
+}
+
+// buildWAFv2LoggingDestinationARN builds the logging destination for WAFv2 WebACL, returns empty if not specified.
+func (t *defaultModelBuildTask) buildWAFv2LoggingDestinationARN(_ context.Context) (string, error) {
+	explicitDestinationARNs := sets.NewString()
+	for _, ing := range t.ingGroup.Members {
+		rawDestinationARN := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixWAFv2LoggingDestinationARN, &rawDestinationARN, ing.Annotations); exists {
+			explicitDestinationARNs.Insert(rawDestinationARN)
+		}
+	}
+	if len(explicitDestinationARNs) == 0 {
+		return "", nil
+	}
+	if len(explicitDestinationARNs) > 1 {
+		return "", errors.Errorf("conflicting WAFv2 logging destination ARNs: %v", explicitDestinationARNs.List())
+	}
+	destinationARN, _ := explicitDestinationARNs.PopAny()
+	if destinationARN == "" {
+		return "", nil
+	}
+	if err := validateWAFv2LoggingDestinationARN(destinationARN); err != nil {
+		return "", err
+	}
+	return destinationARN, nil
+}
+
+// validateWAFv2LoggingDestinationARN validates the logging destination for WAFv2 WebACL.
+// the destination must be a Kinesis Data Firehose delivery stream, S3 bucket or CloudWatch Logs log group with name prefixed by "aws-waf-logs-".
+func validateWAFv2LoggingDestinationARN(destinationARN string) error {
+	parsedARN, err := arn.Parse(destinationARN)
+	if err != nil {
+		return errors.Wrapf(err, "invalid WAFv2 logging destination ARN: %v", destinationARN)
+	}
+	var destinationName string
+	switch parsedARN.Service {
+	case "firehose":
+		destinationName = strings.TrimPrefix(parsedARN.Resource, "deliverystream/")
+	case "s3":
+		destinationName = strings.SplitN(parsedARN.Resource, "/", 2)[0]
+	case "logs":
+		destinationName = strings.TrimPrefix(parsedARN.Resource, "log-group:")
+	default:
+		return errors.Errorf("unsupported WAFv2 logging destination ARN: %v, must be firehose, s3 or logs resource", destinationARN)
+	}
+	if !strings.HasPrefix(destinationName, wafv2LoggingDestinationNamePrefix) {
+		return errors.Errorf("invalid WAFv2 logging destination ARN: %v, destination name must start with %v", destinationARN, wafv2LoggingDestinationNamePrefix)
+	}
+	return nil
 }
 
 func (t *defaultModelBuildTask) buildWAFRegionalWebACLAssociation(_ context.Context, lbARN core.StringToken) (*wafregionalmodel.WebACLAssociation, error) {
@@ -76,7 +145,7 @@ func (t *defaultModelBuildTask) buildWAFRegionalWebACLAssociation(_ context.Cont
 	return nil, nil
 }
 
-func (t *defaultModelBuildTask) buildShieldProtection(_ context.Context, lbARN core.StringToken) (*shieldmodel.Protection, error) {
+func (t *defaultModelBuildTask) buildShieldProtection(ctx context.Context, lbARN core.StringToken) (*shieldmodel.Protection, error) {
 	explicitEnableProtections := make(map[bool]struct{})
 	for _, ing := range t.ingGroup.Members {
 		rawEnableProtection := false
@@ -88,17 +157,44 @@ func (t *defaultModelBuildTask) buildShieldProtection(_ context.Context, lbARN c
 			explicitEnableProtections[rawEnableProtection] = struct{}{}
 		}
 	}
-	if len(explicitEnableProtections) == 0 {
-		return nil, nil
+	healthCheckID, err := t.buildShieldHealthCheckID(ctx)
+	if err != nil {
+		return nil, err
 	}
 	if len(explicitEnableProtections) > 1 {
 		return nil, errors.New("conflicting enable shield advanced protection")
 	}
-	if _, enableProtection := explicitEnableProtections[true]; enableProtection {
-		protection := shieldmodel.NewProtection(t.stack, resourceIDLoadBalancer, shieldmodel.ProtectionSpec{
-			ResourceARN: lbARN,
-		})
-		return protection, nil
+	if _, enableProtection := explicitEnableProtections[true]; !enableProtection {
+		if healthCheckID != "" {
+			return nil, errors.New("shield advanced health check requires shield advanced protection to be enabled")
+		}
+		return nil, nil
 	}
-	return nil, nil
+	protection := shieldmodel.NewProtection(t.stack, resourceIDLoadBalancer, shieldmodel.ProtectionSpec{
+		ResourceARN:   lbARN,
+		HealthCheckID: healthCheckID,
+	})
+	return protection, nil
+}
+
+// buildShieldHealthCheckID builds the Route53 health check for shield advanced health-based detection, returns empty if not specified.
+func (t *defaultModelBuildTask) buildShieldHealthCheckID(ctx context.Context) (string, error) {
+	explicitHealthCheckIDs := sets.NewString()
+	for _, ing := range t.ingGroup.Members {
+		rawHealthCheckID := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixShieldAdvancedHealthCheckID, &rawHealthCheckID, ing.Annotations); exists {
+			explicitHealthCheckIDs.Insert(rawHealthCheckID)
+		}
+	}
+	if len(explicitHealthCheckIDs) > 1 {
+		return "", errors.Errorf("conflicting shield advanced health check IDs: %v", explicitHealthCheckIDs.List())
+	}
+	healthCheckID, _ := explicitHealthCheckIDs.PopAny()
+	if healthCheckID == "" {
+		return "", nil
+	}
+	if err := t.healthCheckValidator.Validate(ctx, healthCheckID); err != nil {
+		return "", err
+	}
+	return healthCheckID, nil
 }
@@ -0,0 +1,442 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
+	wafv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildWAFv2WebACLAssociation(t *testing.T) {
+	webACLARN := "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/3ab78708-85b0-49d3-b4e1-7a9615a6613b"
+	destinationARN := "arn:aws:firehose:us-west-2:123456789012:deliverystream/aws-waf-logs-my-stream"
+	tests := []struct {
+		name       string
+		ings       []*networking.Ingress
+		existingLB *elbv2deploy.LoadBalancerWithTags
+		want       *wafv2model.WebACLAssociationSpec
+		wantErr    error
+	}{
+		{
+			name: "no WAFv2 annotations",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "WebACL without logging destination",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/wafv2-acl-arn": webACLARN,
+						},
+					},
+				},
+			},
+			want: &wafv2model.WebACLAssociationSpec{
+				WebACLARN:   webACLARN,
+				ResourceARN: core.LiteralStringToken("lb-arn"),
+			},
+		},
+		{
+			name: "WebACL with logging destination",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/wafv2-acl-arn":                 webACLARN,
+							"alb.ingress.kubernetes.io/wafv2-logging-destination-arn": destinationARN,
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-2",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/wafv2-logging-destination-arn": destinationARN,
+						},
+					},
+				},
+			},
+			want: &wafv2model.WebACLAssociationSpec{
+				WebACLARN:             webACLARN,
+				ResourceARN:           core.LiteralStringToken("lb-arn"),
+				LoggingDestinationARN: destinationARN,
+			},
+		},
+		{
+			name: "WebACL with logging destination applied previously",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/wafv2-acl-arn":                 webACLARN,
+							"alb.ingress.kubernetes.io/wafv2-logging-destination-arn": destinationARN,
+						},
+					},
+				},
+			},
+			existingLB: &elbv2deploy.LoadBalancerWithTags{
+				LoadBalancer: &elbv2sdk.LoadBalancer{LoadBalancerArn: awssdk.String("lb-arn")},
+				Tags: map[string]string{
+					"elbv2.k8s.aws/wafv2-logging-destination": destinationARN,
+				},
+			},
+			want: &wafv2model.WebACLAssociationSpec{
+				WebACLARN:                    webACLARN,
+				ResourceARN:                  core.LiteralStringToken("lb-arn"),
+				LoggingDestinationARN:        destinationARN,
+				AppliedLoggingDestinationARN: destinationARN,
+			},
+		},
+		{
+			name: "WebACL with logging destination removed",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/wafv2-acl-arn": webACLARN,
+						},
+					},
+				},
+			},
+			existingLB: &elbv2deploy.LoadBalancerWithTags{
+				LoadBalancer: &elbv2sdk.LoadBalancer{LoadBalancerArn: awssdk.String("lb-arn")},
+				Tags: map[string]string{
+					"elbv2.k8s.aws/wafv2-logging-destination": destinationARN,
+				},
+			},
+			want: &wafv2model.WebACLAssociationSpec{
+				WebACLARN:                    webACLARN,
+				ResourceARN:                  core.LiteralStringToken("lb-arn"),
+				AppliedLoggingDestinationARN: destinationARN,
+			},
+		},
+		{
+			name: "logging destination without WebACL",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/wafv2-logging-destination-arn": destinationARN,
+						},
+					},
+				},
+			},
+			wantErr: errors.New("WAFv2 logging destination requires WAFv2 WebACL to be specified"),
+		},
+		{
+			name: "conflicting logging destinations",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/wafv2-acl-arn":                 webACLARN,
+							"alb.ingress.kubernetes.io/wafv2-logging-destination-arn": destinationARN,
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-2",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/wafv2-logging-destination-arn": "arn:aws:s3:::aws-waf-logs-my-bucket",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting WAFv2 logging destination ARNs: [arn:aws:firehose:us-west-2:123456789012:deliverystream/aws-waf-logs-my-stream arn:aws:s3:::aws-waf-logs-my-bucket]"),
+		},
+		{
+			name: "invalid logging destination",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/wafv2-acl-arn":                 webACLARN,
+							"alb.ingress.kubernetes.io/wafv2-logging-destination-arn": "arn:aws:firehose:us-west-2:123456789012:deliverystream/my-stream",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid WAFv2 logging destination ARN: arn:aws:firehose:us-west-2:123456789012:deliverystream/my-stream, destination name must start with aws-waf-logs-"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				ingGroup: Group{
+					Members: tt.ings,
+				},
+				stack:            stack,
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+
+				existingLoadBalancer:        tt.existingLB,
+				existingLoadBalancerFetched: true,
+			}
+			got, err := task.buildWAFv2WebACLAssociation(context.Background(), core.LiteralStringToken("lb-arn"))
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				if tt.want == nil {
+					assert.Nil(t, got)
+				} else {
+					assert.Equal(t, *tt.want, got.Spec)
+				}
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildShieldProtection(t *testing.T) {
+	type getHealthCheckCall struct {
+		req  *route53.GetHealthCheckInput
+		resp *route53.GetHealthCheckOutput
+		err  error
+	}
+	healthCheckID := "abcdef01-2345-6789-abcd-ef0123456789"
+	tests := []struct {
+		name                string
+		ings                []*networking.Ingress
+		getHealthCheckCalls []getHealthCheckCall
+		want                *shieldmodel.ProtectionSpec
+		wantErr             error
+	}{
+		{
+			name: "no shield annotations",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "protection without health check",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/shield-advanced-protection": "true",
+						},
+					},
+				},
+			},
+			want: &shieldmodel.ProtectionSpec{
+				ResourceARN: core.LiteralStringToken("lb-arn"),
+			},
+		},
+		{
+			name: "protection with health check",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/shield-advanced-protection":      "true",
+							"alb.ingress.kubernetes.io/shield-advanced-health-check-id": healthCheckID,
+						},
+					},
+				},
+			},
+			getHealthCheckCalls: []getHealthCheckCall{
+				{
+					req:  &route53.GetHealthCheckInput{HealthCheckId: awssdk.String(healthCheckID)},
+					resp: &route53.GetHealthCheckOutput{},
+				},
+			},
+			want: &shieldmodel.ProtectionSpec{
+				ResourceARN:   core.LiteralStringToken("lb-arn"),
+				HealthCheckID: healthCheckID,
+			},
+		},
+		{
+			name: "health check that doesn't exist",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/shield-advanced-protection":      "true",
+							"alb.ingress.kubernetes.io/shield-advanced-health-check-id": healthCheckID,
+						},
+					},
+				},
+			},
+			getHealthCheckCalls: []getHealthCheckCall{
+				{
+					req: &route53.GetHealthCheckInput{HealthCheckId: awssdk.String(healthCheckID)},
+					err: awserr.New(route53.ErrCodeNoSuchHealthCheck, "No health check exists with the specified ID.", nil),
+				},
+			},
+			wantErr: errors.New("route53 health check abcdef01-2345-6789-abcd-ef0123456789 doesn't exist"),
+		},
+		{
+			name: "health check without protection",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/shield-advanced-health-check-id": healthCheckID,
+						},
+					},
+				},
+			},
+			getHealthCheckCalls: []getHealthCheckCall{
+				{
+					req:  &route53.GetHealthCheckInput{HealthCheckId: awssdk.String(healthCheckID)},
+					resp: &route53.GetHealthCheckOutput{},
+				},
+			},
+			wantErr: errors.New("shield advanced health check requires shield advanced protection to be enabled"),
+		},
+		{
+			name: "conflicting health checks",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/shield-advanced-protection":      "true",
+							"alb.ingress.kubernetes.io/shield-advanced-health-check-id": healthCheckID,
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-2",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/shield-advanced-health-check-id": "fedcba98-7654-3210-fedc-ba9876543210",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting shield advanced health check IDs: [abcdef01-2345-6789-abcd-ef0123456789 fedcba98-7654-3210-fedc-ba9876543210]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			route53Client := mock_services.NewMockRoute53(ctrl)
+			for _, call := range tt.getHealthCheckCalls {
+				route53Client.EXPECT().GetHealthCheckWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				ingGroup: Group{
+					Members: tt.ings,
+				},
+				stack:                stack,
+				annotationParser:     annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				healthCheckValidator: NewRoute53HealthCheckValidator(route53Client, &log.NullLogger{}),
+			}
+			got, err := task.buildShieldProtection(context.Background(), core.LiteralStringToken("lb-arn"))
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				if tt.want == nil {
+					assert.Nil(t, got)
+				} else {
+					assert.Equal(t, *tt.want, got.Spec)
+				}
+			}
+		})
+	}
+}
+
+func Test_validateWAFv2LoggingDestinationARN(t *testing.T) {
+	tests := []struct {
+		name           string
+		destinationARN string
+		wantErr        error
+	}{
+		{
+			name:           "firehose delivery stream",
+			destinationARN: "arn:aws:firehose:us-west-2:123456789012:deliverystream/aws-waf-logs-my-stream",
+		},
+		{
+			name:           "s3 bucket",
+			destinationARN: "arn:aws:s3:::aws-waf-logs-my-bucket",
+		},
+		{
+			name:           "s3 bucket with prefix",
+			destinationARN: "arn:aws:s3:::aws-waf-logs-my-bucket/my-prefix",
+		},
+		{
+			name:           "cloudwatch logs log group",
+			destinationARN: "arn:aws:logs:us-west-2:123456789012:log-group:aws-waf-logs-my-group",
+		},
+		{
+			name:           "destination name without required prefix",
+			destinationARN: "arn:aws:s3:::my-bucket",
+			wantErr:        errors.New("invalid WAFv2 logging destination ARN: arn:aws:s3:::my-bucket, destination name must start with aws-waf-logs-"),
+		},
+		{
+			name:           "unsupported service",
+			destinationARN: "arn:aws:kinesis:us-west-2:123456789012:stream/aws-waf-logs-my-stream",
+			wantErr:        errors.New("unsupported WAFv2 logging destination ARN: arn:aws:kinesis:us-west-2:123456789012:stream/aws-waf-logs-my-stream, must be firehose, s3 or logs resource"),
+		},
+		{
+			name:           "malformed ARN",
+			destinationARN: "aws-waf-logs-my-stream",
+			wantErr:        errors.New("invalid WAFv2 logging destination ARN: aws-waf-logs-my-stream: arn: invalid prefix"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWAFv2LoggingDestinationARN(tt.destinationARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,788 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_aws "sigs.k8s.io/aws-load-balancer-controller/mocks/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildLoadBalancerAttributes(t *testing.T) {
+	tests := []struct {
+		name                   string
+		ingAnnotations         map[string]string
+		listenPortConfigByPort map[int64]listenPortConfig
+		want                   []elbv2model.LoadBalancerAttribute
+		wantErr                error
+	}{
+		{
+			name: "access logs enabled",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "access_logs.s3.enabled=true,access_logs.s3.bucket=my-bucket,access_logs.s3.prefix=my-app",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "access_logs.s3.enabled",
+					Value: "true",
+				},
+				{
+					Key:   "access_logs.s3.bucket",
+					Value: "my-bucket",
+				},
+				{
+					Key:   "access_logs.s3.prefix",
+					Value: "my-app",
+				},
+			},
+		},
+		{
+			name: "access logs disabled",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "access_logs.s3.enabled=false",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "access_logs.s3.enabled",
+					Value: "false",
+				},
+				{
+					Key:   "access_logs.s3.bucket",
+					Value: "",
+				},
+				{
+					Key:   "access_logs.s3.prefix",
+					Value: "",
+				},
+			},
+		},
+		{
+			name: "access logs disabled with explicit bucket",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "access_logs.s3.enabled=false,access_logs.s3.bucket=my-bucket",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "access_logs.s3.enabled",
+					Value: "false",
+				},
+				{
+					Key:   "access_logs.s3.bucket",
+					Value: "my-bucket",
+				},
+				{
+					Key:   "access_logs.s3.prefix",
+					Value: "",
+				},
+			},
+		},
+		{
+			name: "connection logs disabled",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "connection_logs.s3.enabled=false",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "connection_logs.s3.enabled",
+					Value: "false",
+				},
+				{
+					Key:   "connection_logs.s3.bucket",
+					Value: "",
+				},
+				{
+					Key:   "connection_logs.s3.prefix",
+					Value: "",
+				},
+			},
+		},
+		{
+			name: "connection logs enabled",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "connection_logs.s3.enabled=true,connection_logs.s3.bucket=my-bucket",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "connection_logs.s3.enabled",
+					Value: "true",
+				},
+				{
+					Key:   "connection_logs.s3.bucket",
+					Value: "my-bucket",
+				},
+			},
+		},
+		{
+			name: "non-log attributes are not validated",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=600",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "idle_timeout.timeout_seconds",
+					Value: "600",
+				},
+			},
+		},
+		{
+			name: "idle timeout at 1 seconds",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=1",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "idle_timeout.timeout_seconds",
+					Value: "1",
+				},
+			},
+		},
+		{
+			name: "idle timeout at 4000 seconds",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=4000",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "idle_timeout.timeout_seconds",
+					Value: "4000",
+				},
+			},
+		},
+		{
+			name: "idle timeout at 0 seconds",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=0",
+			},
+			wantErr: errors.New("loadBalancerAttribute idle_timeout.timeout_seconds must be within 1-4000 seconds: 0"),
+		},
+		{
+			name: "idle timeout at 4001 seconds",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=4001",
+			},
+			wantErr: errors.New("loadBalancerAttribute idle_timeout.timeout_seconds must be within 1-4000 seconds: 4001"),
+		},
+		{
+			name: "invalid idle timeout",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=1m",
+			},
+			wantErr: errors.New("invalid loadBalancerAttribute idle_timeout.timeout_seconds: 1m"),
+		},
+		{
+			name: "unsupported access log attribute",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "access_logs.s3.fields=client_ip",
+			},
+			wantErr: errors.New("unsupported loadBalancerAttribute access_logs.s3.fields, supported log attributes: [access_logs.s3.bucket access_logs.s3.enabled access_logs.s3.prefix connection_logs.s3.bucket connection_logs.s3.enabled connection_logs.s3.prefix]"),
+		},
+		{
+			name: "invalid access log toggle",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "access_logs.s3.enabled=yes-please",
+			},
+			wantErr: errors.New("invalid loadBalancerAttribute access_logs.s3.enabled: yes-please"),
+		},
+		{
+			name: "access logs enabled without bucket",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "access_logs.s3.enabled=true",
+			},
+			wantErr: errors.New("loadBalancerAttribute access_logs.s3.bucket must be specified when access_logs.s3.enabled is enabled"),
+		},
+		{
+			name: "connection logs enabled without bucket",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "connection_logs.s3.enabled=true",
+			},
+			wantErr: errors.New("loadBalancerAttribute connection_logs.s3.bucket must be specified when connection_logs.s3.enabled is enabled"),
+		},
+		{
+			name: "tls headers enabled with HTTPS listener",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.x_amzn_tls_version_and_cipher_suite.enabled=true",
+			},
+			listenPortConfigByPort: map[int64]listenPortConfig{
+				80:  {protocol: elbv2model.ProtocolHTTP},
+				443: {protocol: elbv2model.ProtocolHTTPS},
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "routing.http.x_amzn_tls_version_and_cipher_suite.enabled",
+					Value: "true",
+				},
+			},
+		},
+		{
+			name: "tls headers disabled without HTTPS listener",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.x_amzn_tls_version_and_cipher_suite.enabled=false",
+			},
+			listenPortConfigByPort: map[int64]listenPortConfig{
+				80: {protocol: elbv2model.ProtocolHTTP},
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "routing.http.x_amzn_tls_version_and_cipher_suite.enabled",
+					Value: "false",
+				},
+			},
+		},
+		{
+			name: "tls headers enabled without HTTPS listener",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.x_amzn_tls_version_and_cipher_suite.enabled=true",
+			},
+			listenPortConfigByPort: map[int64]listenPortConfig{
+				80: {protocol: elbv2model.ProtocolHTTP},
+			},
+			wantErr: errors.New("loadBalancerAttribute routing.http.x_amzn_tls_version_and_cipher_suite.enabled can only be enabled with HTTPS listeners"),
+		},
+		{
+			name: "tls headers with non-boolean value",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.x_amzn_tls_version_and_cipher_suite.enabled=yes",
+			},
+			listenPortConfigByPort: map[int64]listenPortConfig{
+				443: {protocol: elbv2model.ProtocolHTTPS},
+			},
+			wantErr: errors.New("invalid loadBalancerAttribute routing.http.x_amzn_tls_version_and_cipher_suite.enabled: yes"),
+		},
+		{
+			name: "tls headers with boolean value other than true or false",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.x_amzn_tls_version_and_cipher_suite.enabled=True",
+			},
+			listenPortConfigByPort: map[int64]listenPortConfig{
+				443: {protocol: elbv2model.ProtocolHTTPS},
+			},
+			wantErr: errors.New("invalid loadBalancerAttribute routing.http.x_amzn_tls_version_and_cipher_suite.enabled: True"),
+		},
+		{
+			name: "xff client port enabled",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.xff_client_port.enabled=true",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "routing.http.xff_client_port.enabled",
+					Value: "true",
+				},
+			},
+		},
+		{
+			name: "xff client port disabled",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.xff_client_port.enabled=false",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "routing.http.xff_client_port.enabled",
+					Value: "false",
+				},
+			},
+		},
+		{
+			name: "xff client port with non-boolean value",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.xff_client_port.enabled=on",
+			},
+			wantErr: errors.New("invalid loadBalancerAttribute routing.http.xff_client_port.enabled: on"),
+		},
+		{
+			name: "xff client port with boolean value other than true or false",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "routing.http.xff_client_port.enabled=1",
+			},
+			wantErr: errors.New("invalid loadBalancerAttribute routing.http.xff_client_port.enabled: 1"),
+		},
+		{
+			name: "WAFv2 WebACL with fail open",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/wafv2-acl-arn":   "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/3ab78708-85b0-49d3-b4e1-7a9615a6613b",
+				"alb.ingress.kubernetes.io/wafv2-fail-open": "true",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "waf.fail_open.enabled",
+					Value: "true",
+				},
+			},
+		},
+		{
+			name: "WAFv2 WebACL without fail open",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/wafv2-acl-arn": "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/3ab78708-85b0-49d3-b4e1-7a9615a6613b",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "waf.fail_open.enabled",
+					Value: "false",
+				},
+			},
+		},
+		{
+			name: "WAFv2 WebACL with fail open via loadBalancerAttributes",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/wafv2-acl-arn":            "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/3ab78708-85b0-49d3-b4e1-7a9615a6613b",
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "waf.fail_open.enabled=true",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "waf.fail_open.enabled",
+					Value: "true",
+				},
+			},
+		},
+		{
+			name: "WAFv2 fail open conflicts with loadBalancerAttributes",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/wafv2-acl-arn":            "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/3ab78708-85b0-49d3-b4e1-7a9615a6613b",
+				"alb.ingress.kubernetes.io/wafv2-fail-open":          "false",
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "waf.fail_open.enabled=true",
+			},
+			wantErr: errors.New("conflicting loadBalancerAttribute waf.fail_open.enabled: true | false"),
+		},
+		{
+			name: "WAFv2 fail open without WebACL",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/wafv2-fail-open": "true",
+			},
+			wantErr: errors.New("WAFv2 fail open requires WAFv2 WebACL to be specified"),
+		},
+		{
+			name: "WAFv2 fail open with non-boolean value",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/wafv2-acl-arn":   "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/my-acl/3ab78708-85b0-49d3-b4e1-7a9615a6613b",
+				"alb.ingress.kubernetes.io/wafv2-fail-open": "on",
+			},
+			wantErr: errors.New("failed to parse bool annotation, alb.ingress.kubernetes.io/wafv2-fail-open: on: strconv.ParseBool: parsing \"on\": invalid syntax"),
+		},
+		{
+			name:           "no WAFv2 WebACL",
+			ingAnnotations: map[string]string{},
+			want:           []elbv2model.LoadBalancerAttribute{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace:   "awesome-ns",
+								Name:        "ing-1",
+								Annotations: tt.ingAnnotations,
+							},
+						},
+					},
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildLoadBalancerAttributes(context.Background(), tt.listenPortConfigByPort)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLoadBalancerAttributes_logBucketValidation(t *testing.T) {
+	type validateCall struct {
+		bucket string
+		err    error
+	}
+	tests := []struct {
+		name           string
+		ingAnnotations map[string]string
+		validateCalls  []validateCall
+		wantErr        error
+	}{
+		{
+			name: "access logs and connection logs buckets permit log delivery",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "access_logs.s3.enabled=true,access_logs.s3.bucket=access-bucket,connection_logs.s3.enabled=true,connection_logs.s3.bucket=connection-bucket",
+			},
+			validateCalls: []validateCall{
+				{bucket: "access-bucket"},
+				{bucket: "connection-bucket"},
+			},
+		},
+		{
+			name: "disabled logs bucket isn't validated",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "access_logs.s3.enabled=false,access_logs.s3.bucket=access-bucket",
+			},
+		},
+		{
+			name: "access logs bucket doesn't permit log delivery",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "access_logs.s3.enabled=true,access_logs.s3.bucket=access-bucket",
+			},
+			validateCalls: []validateCall{
+				{bucket: "access-bucket", err: errors.New("some error")},
+			},
+			wantErr: errors.New("invalid loadBalancerAttribute access_logs.s3.bucket: some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accessLogBucketValidator := mock_aws.NewMockAccessLogBucketValidator(ctrl)
+			for _, call := range tt.validateCalls {
+				accessLogBucketValidator.EXPECT().Validate(gomock.Any(), call.bucket).Return(call.err)
+			}
+			task := &defaultModelBuildTask{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace:   "awesome-ns",
+								Name:        "ing-1",
+								Annotations: tt.ingAnnotations,
+							},
+						},
+					},
+				},
+				annotationParser:         annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				accessLogBucketValidator: accessLogBucketValidator,
+			}
+			_, err := task.buildLoadBalancerAttributes(context.Background(), nil)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLoadBalancerScheme(t *testing.T) {
+	schemeInternal := elbv2api.LoadBalancerSchemeInternal
+	ingClasses := []*networking.IngressClass{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal-authoritative"},
+			Spec: networking.IngressClassSpec{
+				Controller: "ingress.k8s.aws/alb",
+				Parameters: &corev1.TypedLocalObjectReference{
+					APIGroup: awssdk.String("elbv2.k8s.aws"),
+					Kind:     "IngressClassParams",
+					Name:     "internal-authoritative",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal-default"},
+			Spec: networking.IngressClassSpec{
+				Controller: "ingress.k8s.aws/alb",
+				Parameters: &corev1.TypedLocalObjectReference{
+					APIGroup: awssdk.String("elbv2.k8s.aws"),
+					Kind:     "IngressClassParams",
+					Name:     "internal-default",
+				},
+			},
+		},
+	}
+	ingClassParamses := []*elbv2api.IngressClassParams{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal-authoritative"},
+			Spec: elbv2api.IngressClassParamsSpec{
+				Scheme:              &schemeInternal,
+				SchemeAuthoritative: true,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal-default"},
+			Spec: elbv2api.IngressClassParamsSpec{
+				Scheme: &schemeInternal,
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		ings    []*networking.Ingress
+		want    elbv2model.LoadBalancerScheme
+		wantErr error
+	}{
+		{
+			name: "no explicit scheme",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"},
+				},
+			},
+			want: elbv2model.LoadBalancerSchemeInternetFacing,
+		},
+		{
+			name: "scheme via annotation",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internal",
+						},
+					},
+				},
+			},
+			want: elbv2model.LoadBalancerSchemeInternal,
+		},
+		{
+			name: "scheme via authoritative IngressClassParams",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"},
+					Spec:       networking.IngressSpec{IngressClassName: awssdk.String("internal-authoritative")},
+				},
+			},
+			want: elbv2model.LoadBalancerSchemeInternal,
+		},
+		{
+			name: "authoritative IngressClassParams allows matching scheme annotation",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internal",
+						},
+					},
+					Spec: networking.IngressSpec{IngressClassName: awssdk.String("internal-authoritative")},
+				},
+			},
+			want: elbv2model.LoadBalancerSchemeInternal,
+		},
+		{
+			name: "authoritative IngressClassParams rejects conflicting scheme annotation",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internet-facing",
+						},
+					},
+					Spec: networking.IngressSpec{IngressClassName: awssdk.String("internal-authoritative")},
+				},
+			},
+			wantErr: errors.New("scheme internet-facing for Ingress awesome-ns/ing-1 is not allowed, IngressClassParams internal-authoritative enforces scheme internal"),
+		},
+		{
+			name: "non-authoritative IngressClassParams can be overridden by scheme annotation",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internet-facing",
+						},
+					},
+					Spec: networking.IngressSpec{IngressClassName: awssdk.String("internal-default")},
+				},
+			},
+			want: elbv2model.LoadBalancerSchemeInternetFacing,
+		},
+		{
+			name: "authoritative IngressClassParams conflicts with other group members",
+			ings: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"},
+					Spec:       networking.IngressSpec{IngressClassName: awssdk.String("internal-authoritative")},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-2",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internet-facing",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting scheme: map[internal:{} internet-facing:{}]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, ingClass := range ingClasses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClass.DeepCopy()))
+			}
+			for _, ingClassParams := range ingClassParamses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClassParams.DeepCopy()))
+			}
+
+			task := &defaultModelBuildTask{
+				ingGroup: Group{
+					Members: tt.ings,
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				classLoader:      NewDefaultClassLoader(k8sClient),
+				defaultScheme:    elbv2model.LoadBalancerSchemeInternetFacing,
+			}
+			got, err := task.buildLoadBalancerScheme(ctx)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_validateSubnetsForIPAddressType(t *testing.T) {
+	subnetWithIPv6 := &ec2sdk.Subnet{
+		SubnetId: awssdk.String("subnet-a"),
+		Ipv6CidrBlockAssociationSet: []*ec2sdk.SubnetIpv6CidrBlockAssociation{
+			{
+				Ipv6CidrBlock: awssdk.String("2600:1f14:f8c:2700::/64"),
+				Ipv6CidrBlockState: &ec2sdk.SubnetCidrBlockState{
+					State: awssdk.String(ec2sdk.SubnetCidrBlockStateCodeAssociated),
+				},
+			},
+		},
+	}
+	subnetWithDisassociatedIPv6 := &ec2sdk.Subnet{
+		SubnetId: awssdk.String("subnet-b"),
+		Ipv6CidrBlockAssociationSet: []*ec2sdk.SubnetIpv6CidrBlockAssociation{
+			{
+				Ipv6CidrBlock: awssdk.String("2600:1f14:f8c:2701::/64"),
+				Ipv6CidrBlockState: &ec2sdk.SubnetCidrBlockState{
+					State: awssdk.String(ec2sdk.SubnetCidrBlockStateCodeDisassociated),
+				},
+			},
+		},
+	}
+	subnetWithoutIPv6 := &ec2sdk.Subnet{
+		SubnetId: awssdk.String("subnet-c"),
+	}
+	tests := []struct {
+		name          string
+		subnets       []*ec2sdk.Subnet
+		ipAddressType elbv2model.IPAddressType
+		wantErr       error
+	}{
+		{
+			name:          "ipv4 with subnets without IPv6",
+			subnets:       []*ec2sdk.Subnet{subnetWithIPv6, subnetWithoutIPv6},
+			ipAddressType: elbv2model.IPAddressTypeIPV4,
+		},
+		{
+			name:          "dualstack with subnets with IPv6",
+			subnets:       []*ec2sdk.Subnet{subnetWithIPv6},
+			ipAddressType: elbv2model.IPAddressTypeDualStack,
+		},
+		{
+			name:          "dualstack with subnets without IPv6",
+			subnets:       []*ec2sdk.Subnet{subnetWithIPv6, subnetWithDisassociatedIPv6, subnetWithoutIPv6},
+			ipAddressType: elbv2model.IPAddressTypeDualStack,
+			wantErr:       errors.New("subnets must have IPv6 CIDR block associated for dualstack IPAddressType: [subnet-b subnet-c]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSubnetsForIPAddressType(tt.subnets, tt.ipAddressType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLoadBalancerTags(t *testing.T) {
+	tests := []struct {
+		name           string
+		ingAnnotations map[string]string
+		defaultTags    map[string]string
+		want           map[string]string
+		wantErr        error
+	}{
+		{
+			name:           "without tags",
+			ingAnnotations: map[string]string{},
+			want:           map[string]string{},
+		},
+		{
+			name:           "default tags only",
+			ingAnnotations: map[string]string{},
+			defaultTags: map[string]string{
+				"kubernetes.io/cluster/my-cluster": "owned",
+				"cost-center":                      "platform",
+			},
+			want: map[string]string{
+				"kubernetes.io/cluster/my-cluster": "owned",
+				"cost-center":                      "platform",
+			},
+		},
+		{
+			name: "user-specified tags override default tags",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/tags": "cost-center=team-a,env=prod",
+			},
+			defaultTags: map[string]string{
+				"kubernetes.io/cluster/my-cluster": "owned",
+				"cost-center":                      "platform",
+			},
+			want: map[string]string{
+				"kubernetes.io/cluster/my-cluster": "owned",
+				"cost-center":                      "team-a",
+				"env":                              "prod",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace:   "awesome-ns",
+								Name:        "ing-1",
+								Annotations: tt.ingAnnotations,
+							},
+						},
+					},
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				defaultTags:      tt.defaultTags,
+			}
+			got, err := task.buildLoadBalancerTags(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -65,7 +65,8 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupTags(_ context.Context)
 	mergedTags := make(map[string]string)
 	for _, ing := range t.ingGroup.Members {
 		var rawTags map[string]string
-		if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTags, &rawTags, ing.Annotations); err != nil {
+		if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTags, &rawTags, ing.Annotations,
+			annotations.WithRejectDuplicateKeys()); err != nil {
 			return nil, err
 		}
 		for tagKey, tagValue := range rawTags {
@@ -75,7 +76,7 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupTags(_ context.Context)
 			mergedTags[tagKey] = tagValue
 		}
 	}
-	return mergedTags, nil
+	return t.buildTagsWithDefaults(mergedTags), nil
 }
 
 func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType) []ec2model.IPPermission {
@@ -93,6 +94,18 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ co
 				},
 			})
 		}
+		for _, prefixListID := range cfg.prefixListIDs {
+			permissions = append(permissions, ec2model.IPPermission{
+				IPProtocol: "tcp",
+				FromPort:   awssdk.Int64(port),
+				ToPort:     awssdk.Int64(port),
+				PrefixLists: []ec2model.PrefixList{
+					{
+						ListID: prefixListID,
+					},
+				},
+			})
+		}
 		if ipAddressType == elbv2model.IPAddressTypeDualStack {
 			for _, cidr := range cfg.inboundCIDRv6s {
 				permissions = append(permissions, ec2model.IPPermission{
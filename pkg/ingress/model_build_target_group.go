@@ -5,6 +5,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
@@ -15,39 +17,198 @@ import (
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"strconv"
+	"strings"
 )
 
 const (
-	healthCheckPortTrafficPort = "traffic-port"
+	healthCheckPortTrafficPort     = "traffic-port"
+	tgAttrsLambdaMultiValueHeaders = "lambda.multi_value_headers.enabled"
+	tgAttrsDeregistrationDelay     = "deregistration_delay.timeout_seconds"
+	tgAttrsStickinessEnabled       = "stickiness.enabled"
+	tgAttrsStickinessType          = "stickiness.type"
+	lambdaARNService               = "lambda"
+	lambdaARNResourceTypeFunction  = "function"
+	targetGroupNameMaxLength       = 32
+	targetGroupNameHashLength      = 10
+
+	// stickinessTypeLBCookie is the default stickiness type for sticky targetGroups.
+	stickinessTypeLBCookie = "lb_cookie"
+	// stickyTargetGroupNameSuffix is the suffix appended to targetGroupNameTemplate for sticky targetGroups.
+	stickyTargetGroupNameSuffix = "-sticky"
+
+	// ALB accepts HTTP success codes within [200, 499] and gRPC success codes within [0, 99].
+	healthCheckMatcherHTTPCodeMin = 200
+	healthCheckMatcherHTTPCodeMax = 499
+	healthCheckMatcherGRPCCodeMin = 0
+	healthCheckMatcherGRPCCodeMax = 99
+
+	// ALB accepts deregistration delay within [0, 3600] seconds.
+	deregistrationDelaySecondsMin = 0
+	deregistrationDelaySecondsMax = 3600
 )
 
+const (
+	// TargetGroupBindingIngressNameLabelKey is the label key that links TargetGroupBinding to its Ingress.
+	TargetGroupBindingIngressNameLabelKey = "ingress.k8s.aws/ingress-name"
+	// TargetGroupBindingServiceNameLabelKey is the label key that links TargetGroupBinding to its backend Service.
+	TargetGroupBindingServiceNameLabelKey = "ingress.k8s.aws/service-name"
+	// TargetGroupBindingServicePortLabelKey is the label key that links TargetGroupBinding to its backend Service port.
+	TargetGroupBindingServicePortLabelKey = "ingress.k8s.aws/service-port"
+
+	// the max length of label values.
+	labelValueMaxLength = 63
+)
+
+// buildTargetGroup builds the targetGroup for service port.
+// sticky and non-sticky backends of the same service port are served by distinct targetGroups, so that stickiness can be enabled per path.
 func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context,
-	ing *networking.Ingress, svc *corev1.Service, port intstr.IntOrString) (*elbv2model.TargetGroup, error) {
-	tgResID := t.buildTargetGroupResourceID(k8s.NamespacedName(ing), k8s.NamespacedName(svc), port)
+	ing *networking.Ingress, svc *corev1.Service, port intstr.IntOrString, stickiness bool) (*elbv2model.TargetGroup, error) {
+	tgResID := t.buildTargetGroupResourceID(k8s.NamespacedName(ing), k8s.NamespacedName(svc), port, stickiness)
+	if tg, exists := t.tgByResID[tgResID]; exists {
+		return tg, nil
+	}
+
+	tgSpec, err := t.buildTargetGroupSpec(ctx, ing, svc, port, stickiness)
+	if err != nil {
+		return nil, err
+	}
+	tg := elbv2model.NewTargetGroup(t.stack, tgResID, tgSpec)
+	t.tgByResID[tgResID] = tg
+	_ = t.buildTargetGroupBinding(ctx, tg, ing, svc, port)
+	return tg, nil
+}
+
+// buildLambdaTargetGroup builds a targetGroup with targetType lambda that registers specified lambda function as target.
+// the targetGroup is identified by the unqualified function ARN, so that changing the alias or version reuses the same targetGroup.
+func (t *defaultModelBuildTask) buildLambdaTargetGroup(ctx context.Context,
+	ing *networking.Ingress, lambdaFunctionARN string) (*elbv2model.TargetGroup, error) {
+	unqualifiedARN, _, err := parseLambdaFunctionARN(lambdaFunctionARN)
+	if err != nil {
+		return nil, err
+	}
+	tgResID := t.buildLambdaTargetGroupResourceID(k8s.NamespacedName(ing), unqualifiedARN)
 	if tg, exists := t.tgByResID[tgResID]; exists {
+		if existingARN := awssdk.StringValue(tg.Spec.LambdaFunctionARN); existingARN != lambdaFunctionARN {
+			return nil, errors.Errorf("conflicting lambda function ARNs %v and %v within Ingress %v, each lambda function can only be referenced with a single alias or version",
+				existingARN, lambdaFunctionARN, k8s.NamespacedName(ing))
+		}
 		return tg, nil
 	}
 
-	tgSpec, err := t.buildTargetGroupSpec(ctx, ing, svc, port)
+	tgSpec, err := t.buildLambdaTargetGroupSpec(ctx, ing, lambdaFunctionARN)
 	if err != nil {
 		return nil, err
 	}
 	tg := elbv2model.NewTargetGroup(t.stack, tgResID, tgSpec)
 	t.tgByResID[tgResID] = tg
-	_ = t.buildTargetGroupBinding(ctx, tg, svc, port)
 	return tg, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString) *elbv2model.TargetGroupBindingResource {
-	tgbSpec := t.buildTargetGroupBindingSpec(ctx, tg, svc, port)
+func (t *defaultModelBuildTask) buildLambdaTargetGroupSpec(ctx context.Context,
+	ing *networking.Ingress, lambdaFunctionARN string) (elbv2model.TargetGroupSpec, error) {
+	unqualifiedARN, _, err := parseLambdaFunctionARN(lambdaFunctionARN)
+	if err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
+	tgAttributes, err := t.buildLambdaTargetGroupAttributes(ctx, ing.Annotations)
+	if err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
+	tags, err := t.buildTargetGroupTags(ctx, ing.Annotations)
+	if err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
+	name := t.buildLambdaTargetGroupName(ctx, k8s.NamespacedName(ing), unqualifiedARN)
+	return elbv2model.TargetGroupSpec{
+		Name:                  name,
+		TargetType:            elbv2model.TargetTypeLambda,
+		TargetGroupAttributes: tgAttributes,
+		LambdaFunctionARN:     &lambdaFunctionARN,
+		Tags:                  tags,
+	}, nil
+}
+
+// parseLambdaFunctionARN parses lambda function ARN that is optionally qualified by an alias or version,
+// returns the unqualified function ARN and the qualifier.
+func parseLambdaFunctionARN(lambdaFunctionARN string) (string, string, error) {
+	parsedARN, err := arn.Parse(lambdaFunctionARN)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "invalid lambda function ARN: %v", lambdaFunctionARN)
+	}
+	// the resource of lambda function ARN is in format function:<name>[:<qualifier>]
+	resourceParts := strings.Split(parsedARN.Resource, ":")
+	if parsedARN.Service != lambdaARNService || len(resourceParts) < 2 || len(resourceParts) > 3 ||
+		resourceParts[0] != lambdaARNResourceTypeFunction || resourceParts[1] == "" {
+		return "", "", errors.Errorf("invalid lambda function ARN: %v", lambdaFunctionARN)
+	}
+	var qualifier string
+	if len(resourceParts) == 3 {
+		qualifier = resourceParts[2]
+		if qualifier == "" {
+			return "", "", errors.Errorf("invalid lambda function ARN: %v", lambdaFunctionARN)
+		}
+	}
+	parsedARN.Resource = strings.Join(resourceParts[:2], ":")
+	return parsedARN.String(), qualifier, nil
+}
+
+// buildLambdaTargetGroupName will calculate the lambda targetGroup's name.
+func (t *defaultModelBuildTask) buildLambdaTargetGroupName(_ context.Context, ingKey types.NamespacedName, lambdaFunctionARN string) string {
+	uuidHash := sha256.New()
+	_, _ = uuidHash.Write([]byte(t.clusterName))
+	_, _ = uuidHash.Write([]byte(t.ingGroup.ID.String()))
+	_, _ = uuidHash.Write([]byte(ingKey.Namespace))
+	_, _ = uuidHash.Write([]byte(ingKey.Name))
+	_, _ = uuidHash.Write([]byte(lambdaFunctionARN))
+	_, _ = uuidHash.Write([]byte(elbv2model.TargetTypeLambda))
+	uuid := hex.EncodeToString(uuidHash.Sum(nil))
+
+	sanitizedNamespace := invalidTargetGroupNamePattern.ReplaceAllString(ingKey.Namespace, "")
+	sanitizedName := invalidTargetGroupNamePattern.ReplaceAllString(ingKey.Name, "")
+	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
+}
+
+func (t *defaultModelBuildTask) buildLambdaTargetGroupAttributes(_ context.Context, ingAnnotations map[string]string) ([]elbv2model.TargetGroupAttribute, error) {
+	var rawAttributes map[string]string
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTargetGroupAttributes, &rawAttributes, ingAnnotations); err != nil {
+		return nil, err
+	}
+	if rawAttributes == nil {
+		rawAttributes = make(map[string]string)
+	}
+	var multiValueHeadersEnabled bool
+	exists, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixLambdaMultiValueHeaders, &multiValueHeadersEnabled, ingAnnotations)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		if rawValue, ok := rawAttributes[tgAttrsLambdaMultiValueHeaders]; ok && rawValue != strconv.FormatBool(multiValueHeadersEnabled) {
+			return nil, errors.Errorf("conflicting %v settings between annotations %v and %v",
+				tgAttrsLambdaMultiValueHeaders, annotations.IngressSuffixTargetGroupAttributes, annotations.IngressSuffixLambdaMultiValueHeaders)
+		}
+		rawAttributes[tgAttrsLambdaMultiValueHeaders] = strconv.FormatBool(multiValueHeadersEnabled)
+	}
+	attributes := make([]elbv2model.TargetGroupAttribute, 0, len(rawAttributes))
+	for attrKey, attrValue := range rawAttributes {
+		attributes = append(attributes, elbv2model.TargetGroupAttribute{
+			Key:   attrKey,
+			Value: attrValue,
+		})
+	}
+	return attributes, nil
+}
+
+func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, tg *elbv2model.TargetGroup, ing *networking.Ingress, svc *corev1.Service, port intstr.IntOrString) *elbv2model.TargetGroupBindingResource {
+	tgbSpec := t.buildTargetGroupBindingSpec(ctx, tg, ing, svc, port)
 	tgb := elbv2model.NewTargetGroupBindingResource(t.stack, tg.ID(), tgbSpec)
 	return tgb
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString) elbv2model.TargetGroupBindingResourceSpec {
+func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context, tg *elbv2model.TargetGroup, ing *networking.Ingress, svc *corev1.Service, port intstr.IntOrString) elbv2model.TargetGroupBindingResourceSpec {
 	targetType := elbv2api.TargetType(tg.Spec.TargetType)
 	tgbNetworking := t.buildTargetGroupBindingNetworking(ctx)
 	return elbv2model.TargetGroupBindingResourceSpec{
@@ -55,6 +216,7 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: svc.Namespace,
 				Name:      tg.Spec.Name,
+				Labels:    t.buildTargetGroupBindingLabels(ctx, ing, svc, port),
 			},
 			Spec: elbv2model.TargetGroupBindingSpec{
 				TargetGroupARN: tg.TargetGroupARN(),
@@ -69,6 +231,27 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 	}
 }
 
+// buildTargetGroupBindingLabels builds the labels that link TargetGroupBinding to its Ingress and backend.
+func (t *defaultModelBuildTask) buildTargetGroupBindingLabels(_ context.Context, ing *networking.Ingress, svc *corev1.Service, port intstr.IntOrString) map[string]string {
+	return map[string]string{
+		TargetGroupBindingIngressNameLabelKey: TargetGroupBindingIngressNameLabelValue(ing.Name),
+		TargetGroupBindingServiceNameLabelKey: svc.Name,
+		TargetGroupBindingServicePortLabelKey: port.String(),
+	}
+}
+
+// TargetGroupBindingIngressNameLabelValue builds the value of TargetGroupBindingIngressNameLabelKey for Ingress.
+// Ingress names can exceed the max length of label values, such names are truncated with a hash suffix to remain unique.
+func TargetGroupBindingIngressNameLabelValue(ingName string) string {
+	if len(ingName) <= labelValueMaxLength {
+		return ingName
+	}
+	uuidHash := sha256.New()
+	_, _ = uuidHash.Write([]byte(ingName))
+	uuid := hex.EncodeToString(uuidHash.Sum(nil))
+	return fmt.Sprintf("%.52s-%.10s", ingName, uuid)
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(_ context.Context) *elbv2model.TargetGroupBindingNetworking {
 	if t.managedSG == nil {
 		return nil
@@ -96,9 +279,9 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(_ context.Cont
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
-	ing *networking.Ingress, svc *corev1.Service, port intstr.IntOrString) (elbv2model.TargetGroupSpec, error) {
+	ing *networking.Ingress, svc *corev1.Service, port intstr.IntOrString, stickiness bool) (elbv2model.TargetGroupSpec, error) {
 	svcAndIngAnnotations := algorithm.MergeStringMap(svc.Annotations, ing.Annotations)
-	targetType, err := t.buildTargetGroupTargetType(ctx, svcAndIngAnnotations)
+	targetType, err := t.buildTargetGroupTargetType(ctx, ing, svcAndIngAnnotations)
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
@@ -114,10 +297,13 @@ func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
-	tgAttributes, err := t.buildTargetGroupAttributes(ctx, svcAndIngAnnotations)
+	tgAttributes, err := t.buildTargetGroupAttributes(ctx, ing, svcAndIngAnnotations)
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
+	if stickiness {
+		tgAttributes = buildStickyTargetGroupAttributes(tgAttributes)
+	}
 	tags, err := t.buildTargetGroupTags(ctx, svcAndIngAnnotations)
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
@@ -126,8 +312,15 @@ func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
-	tgPort := t.buildTargetGroupPort(ctx, targetType, svcPort)
-	name := t.buildTargetGroupName(ctx, k8s.NamespacedName(ing), svc, port, tgPort, targetType, tgProtocol, tgProtocolVersion)
+	tgPort, err := t.buildTargetGroupPort(ctx, svcAndIngAnnotations, targetType, svcPort)
+	if err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
+	recreationConfirmed, err := t.buildTargetGroupRecreationConfirmed(ctx, svcAndIngAnnotations)
+	if err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
+	name := t.buildTargetGroupName(ctx, k8s.NamespacedName(ing), svc, port, tgPort, targetType, tgProtocol, tgProtocolVersion, stickiness)
 	return elbv2model.TargetGroupSpec{
 		Name:                  name,
 		TargetType:            targetType,
@@ -137,15 +330,25 @@ func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
 		HealthCheckConfig:     &healthCheckConfig,
 		TargetGroupAttributes: tgAttributes,
 		Tags:                  tags,
+		RecreationConfirmed:   recreationConfirmed,
 	}, nil
 }
 
+// buildTargetGroupRecreationConfirmed builds whether targetGroup can be recreated when its immutable fields change.
+func (t *defaultModelBuildTask) buildTargetGroupRecreationConfirmed(_ context.Context, svcAndIngAnnotations map[string]string) (bool, error) {
+	rawRecreationConfirmed := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixTargetGroupRecreation, &rawRecreationConfirmed, svcAndIngAnnotations); err != nil {
+		return false, err
+	}
+	return rawRecreationConfirmed, nil
+}
+
 var invalidTargetGroupNamePattern = regexp.MustCompile("[[:^alnum:]]")
 
 // buildTargetGroupName will calculate the targetGroup's name.
 func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context,
 	ingKey types.NamespacedName, svc *corev1.Service, port intstr.IntOrString, tgPort int64,
-	targetType elbv2model.TargetType, tgProtocol elbv2model.Protocol, tgProtocolVersion elbv2model.ProtocolVersion) string {
+	targetType elbv2model.TargetType, tgProtocol elbv2model.Protocol, tgProtocolVersion elbv2model.ProtocolVersion, stickiness bool) string {
 	uuidHash := sha256.New()
 	_, _ = uuidHash.Write([]byte(t.clusterName))
 	_, _ = uuidHash.Write([]byte(t.ingGroup.ID.String()))
@@ -157,16 +360,69 @@ func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context,
 	_, _ = uuidHash.Write([]byte(targetType))
 	_, _ = uuidHash.Write([]byte(tgProtocol))
 	_, _ = uuidHash.Write([]byte(tgProtocolVersion))
+	// only sticky targetGroups hash the stickiness, so that names of existing targetGroups remain unchanged.
+	if stickiness {
+		_, _ = uuidHash.Write([]byte(tgAttrsStickinessEnabled))
+	}
 	uuid := hex.EncodeToString(uuidHash.Sum(nil))
 
+	if t.targetGroupNameTemplate != "" {
+		template := t.targetGroupNameTemplate
+		if stickiness {
+			template += stickyTargetGroupNameSuffix
+		}
+		return renderTargetGroupName(template, svc, port, uuid)
+	}
 	sanitizedNamespace := invalidTargetGroupNamePattern.ReplaceAllString(svc.Namespace, "")
 	sanitizedName := invalidTargetGroupNamePattern.ReplaceAllString(svc.Name, "")
 	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupTargetType(_ context.Context, svcAndIngAnnotations map[string]string) (elbv2model.TargetType, error) {
+var invalidTemplatedTargetGroupNamePattern = regexp.MustCompile("[^a-zA-Z0-9-]")
+
+// renderTargetGroupName will render the targetGroup's name from template.
+// the rendered name is always suffixed with a hash of the targetGroup, so that names stay unique across backends
+// and targetGroups can be replaced when immutable fields change. it's truncated to fit the length limit of targetGroup name.
+func renderTargetGroupName(template string, svc *corev1.Service, port intstr.IntOrString, uuid string) string {
+	replacer := strings.NewReplacer(
+		config.TargetGroupNamePlaceholderNamespace, svc.Namespace,
+		config.TargetGroupNamePlaceholderService, svc.Name,
+		config.TargetGroupNamePlaceholderPort, port.String(),
+	)
+	name := invalidTemplatedTargetGroupNamePattern.ReplaceAllString(replacer.Replace(template), "")
+	name = strings.Trim(name, "-")
+	// reserve space for the hash suffix along with its separator.
+	truncatedName := strings.TrimRight(fmt.Sprintf("%.*s", targetGroupNameMaxLength-targetGroupNameHashLength-1, name), "-")
+	if len(truncatedName) == 0 {
+		return fmt.Sprintf("k8s-%.*s", targetGroupNameHashLength, uuid)
+	}
+	return fmt.Sprintf("%s-%.*s", truncatedName, targetGroupNameHashLength, uuid)
+}
+
+// buildTargetGroupTargetType builds the targetType for targetGroup,
+// targetType in IngressClassParams takes precedence over the annotation if it's authoritative, otherwise the precedence is:
+//  1. targetType annotation on Service or Ingress
+//  2. targetType in Ingress's IngressClassParams
+//  3. default targetType of controller
+func (t *defaultModelBuildTask) buildTargetGroupTargetType(ctx context.Context, ing *networking.Ingress, svcAndIngAnnotations map[string]string) (elbv2model.TargetType, error) {
 	rawTargetType := string(t.defaultTargetType)
-	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixTargetType, &rawTargetType, svcAndIngAnnotations)
+	classConfig, err := t.classLoader.Load(ctx, ing)
+	if err != nil {
+		return "", err
+	}
+	var classTargetType string
+	if classConfig.IngClassParams != nil && classConfig.IngClassParams.Spec.TargetType != nil {
+		classTargetType = string(*classConfig.IngClassParams.Spec.TargetType)
+		rawTargetType = classTargetType
+	}
+	var annotationTargetType string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixTargetType, &annotationTargetType, svcAndIngAnnotations); exists {
+		if classTargetType != "" && classConfig.IngClassParams.Spec.TargetTypeAuthoritative && annotationTargetType != classTargetType {
+			return "", errors.Errorf("targetType %v for Ingress %v is not allowed, IngressClassParams %v enforces targetType %v",
+				annotationTargetType, k8s.NamespacedName(ing), classConfig.IngClassParams.Name, classTargetType)
+		}
+		rawTargetType = annotationTargetType
+	}
 	switch rawTargetType {
 	case string(elbv2model.TargetTypeInstance):
 		return elbv2model.TargetTypeInstance, nil
@@ -180,17 +436,35 @@ func (t *defaultModelBuildTask) buildTargetGroupTargetType(_ context.Context, sv
 // buildTargetGroupPort constructs the TargetGroup's port.
 // Note: TargetGroup's port is not in the data path as we always register targets with port specified.
 // so this settings don't really matter to our controller, and we do our best to use the most appropriate port as targetGroup's port to avoid UX confusing.
-func (t *defaultModelBuildTask) buildTargetGroupPort(_ context.Context, targetType elbv2model.TargetType, svcPort corev1.ServicePort) int64 {
+// an explicit port via annotation takes priority over the port resolved from service.
+// for instance targetType, the explicit port pins the nodePort and must match the nodePort allocated for service port, since targets are registered with it.
+func (t *defaultModelBuildTask) buildTargetGroupPort(_ context.Context, svcAndIngAnnotations map[string]string, targetType elbv2model.TargetType, svcPort corev1.ServicePort) (int64, error) {
+	var rawTargetGroupPort int64
+	exists, err := t.annotationParser.ParseInt64Annotation(annotations.IngressSuffixTargetGroupPort, &rawTargetGroupPort, svcAndIngAnnotations)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		if rawTargetGroupPort < 1 || rawTargetGroupPort > 65535 {
+			return 0, errors.Errorf("target group port must be within [1, 65535]: %v", rawTargetGroupPort)
+		}
+		if targetType == elbv2model.TargetTypeInstance && rawTargetGroupPort != int64(svcPort.NodePort) {
+			return 0, errors.Errorf("target group port %v must match nodePort %v of service port %v for %v targetType",
+				rawTargetGroupPort, svcPort.NodePort, svcPort.Port, targetType)
+		}
+		return rawTargetGroupPort, nil
+	}
+
 	if targetType == elbv2model.TargetTypeInstance {
-		return int64(svcPort.NodePort)
+		return int64(svcPort.NodePort), nil
 	}
 	if svcPort.TargetPort.Type == intstr.Int {
-		return int64(svcPort.TargetPort.IntValue())
+		return int64(svcPort.TargetPort.IntValue()), nil
 	}
 
 	// when a literal targetPort is used, we just use a fixed 1 here as this setting is not in the data path.
 	// also, under extreme edge case, it can actually be different ports for different pods.
-	return 1
+	return 1, nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupProtocol(_ context.Context, svcAndIngAnnotations map[string]string) (elbv2model.Protocol, error) {
@@ -231,7 +505,10 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Co
 		return elbv2model.TargetGroupHealthCheckConfig{}, err
 	}
 	healthCheckPath := t.buildTargetGroupHealthCheckPath(ctx, svcAndIngAnnotations)
-	healthCheckMatcher := t.buildTargetGroupHealthCheckMatcher(ctx, svcAndIngAnnotations, tgProtocolVersion)
+	healthCheckMatcher, err := t.buildTargetGroupHealthCheckMatcher(ctx, svcAndIngAnnotations, tgProtocolVersion)
+	if err != nil {
+		return elbv2model.TargetGroupHealthCheckConfig{}, err
+	}
 	healthCheckIntervalSeconds, err := t.buildTargetGroupHealthCheckIntervalSeconds(ctx, svcAndIngAnnotations)
 	if err != nil {
 		return elbv2model.TargetGroupHealthCheckConfig{}, err
@@ -270,6 +547,9 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Contex
 	}
 	healthCheckPort := intstr.Parse(rawHealthCheckPort)
 	if healthCheckPort.Type == intstr.Int {
+		if err := validateHealthCheckPortInService(svc, healthCheckPort.IntVal, targetType); err != nil {
+			return intstr.IntOrString{}, err
+		}
 		return healthCheckPort, nil
 	}
 
@@ -286,6 +566,29 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Contex
 	return intstr.IntOrString{}, errors.New("cannot use named healthCheckPort for IP TargetType when service's targetPort is a named port")
 }
 
+// validateHealthCheckPortInService validates the numeric healthCheckPort is exposed by the service on its targets,
+// i.e. a NodePort for Instance TargetType or a TargetPort for IP TargetType.
+func validateHealthCheckPortInService(svc *corev1.Service, healthCheckPort int32, targetType elbv2model.TargetType) error {
+	var targetPorts []int32
+	for _, svcPort := range svc.Spec.Ports {
+		if targetType == elbv2model.TargetTypeInstance {
+			targetPorts = append(targetPorts, svcPort.NodePort)
+			continue
+		}
+		// named targetPort can only be resolved against pods, we cannot validate healthCheckPort in such case.
+		if svcPort.TargetPort.Type == intstr.String {
+			return nil
+		}
+		targetPorts = append(targetPorts, svcPort.TargetPort.IntVal)
+	}
+	for _, targetPort := range targetPorts {
+		if targetPort == healthCheckPort {
+			return nil
+		}
+	}
+	return errors.Errorf("healthCheckPort %v not found among service %v ports for %v TargetType", healthCheckPort, k8s.NamespacedName(svc), targetType)
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckProtocol(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocol elbv2model.Protocol) (elbv2model.Protocol, error) {
 	rawHealthCheckProtocol := string(tgProtocol)
 	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixHealthCheckProtocol, &rawHealthCheckProtocol, svcAndIngAnnotations)
@@ -305,17 +608,48 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Contex
 	return rawHealthCheckPath
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) elbv2model.HealthCheckMatcher {
-	rawHealthCheckMatcherHTTPCode := t.defaultHealthCheckMatcherHTTPCode
-	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSuccessCodes, &rawHealthCheckMatcherHTTPCode, svcAndIngAnnotations)
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) (elbv2model.HealthCheckMatcher, error) {
 	if tgProtocolVersion == elbv2model.ProtocolVersionGRPC {
-		return elbv2model.HealthCheckMatcher{
-			GRPCCode: &rawHealthCheckMatcherHTTPCode,
+		rawHealthCheckMatcherGRPCCode := t.defaultHealthCheckMatcherGRPCCode
+		_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSuccessCodes, &rawHealthCheckMatcherGRPCCode, svcAndIngAnnotations)
+		if err := validateHealthCheckMatcherCodes(rawHealthCheckMatcherGRPCCode, healthCheckMatcherGRPCCodeMin, healthCheckMatcherGRPCCodeMax); err != nil {
+			return elbv2model.HealthCheckMatcher{}, errors.Wrapf(err, "invalid success codes for %v protocolVersion", tgProtocolVersion)
 		}
+		return elbv2model.HealthCheckMatcher{
+			GRPCCode: &rawHealthCheckMatcherGRPCCode,
+		}, nil
+	}
+	rawHealthCheckMatcherHTTPCode := t.defaultHealthCheckMatcherHTTPCode
+	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSuccessCodes, &rawHealthCheckMatcherHTTPCode, svcAndIngAnnotations)
+	if err := validateHealthCheckMatcherCodes(rawHealthCheckMatcherHTTPCode, healthCheckMatcherHTTPCodeMin, healthCheckMatcherHTTPCodeMax); err != nil {
+		return elbv2model.HealthCheckMatcher{}, errors.Wrapf(err, "invalid success codes for %v protocolVersion", tgProtocolVersion)
 	}
 	return elbv2model.HealthCheckMatcher{
 		HTTPCode: &rawHealthCheckMatcherHTTPCode,
+	}, nil
+}
+
+// validateHealthCheckMatcherCodes validates the health check success codes are within [minCode, maxCode].
+// success codes can be multiple values(e.g. 200,202) or a range of values(e.g. 200-299).
+func validateHealthCheckMatcherCodes(rawCodes string, minCode int64, maxCode int64) error {
+	for _, rawCodeItem := range strings.Split(rawCodes, ",") {
+		rawCodeRange := strings.SplitN(strings.TrimSpace(rawCodeItem), "-", 2)
+		var codeRange []int64
+		for _, rawCode := range rawCodeRange {
+			code, err := strconv.ParseInt(strings.TrimSpace(rawCode), 10, 64)
+			if err != nil {
+				return errors.Errorf("success code must be integer or range of integers: %v", rawCodeItem)
+			}
+			if code < minCode || code > maxCode {
+				return errors.Errorf("success code must be within [%v, %v]: %v", minCode, maxCode, rawCodeItem)
+			}
+			codeRange = append(codeRange, code)
+		}
+		if len(codeRange) == 2 && codeRange[0] > codeRange[1] {
+			return errors.Errorf("success code range must be ascending: %v", rawCodeItem)
+		}
 	}
+	return nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckIntervalSeconds(_ context.Context, svcAndIngAnnotations map[string]string) (int64, error) {
@@ -354,11 +688,34 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckUnhealthyThresholdCou
 	return rawHealthCheckUnhealthyThresholdCount, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context, svcAndIngAnnotations map[string]string) ([]elbv2model.TargetGroupAttribute, error) {
+// buildTargetGroupAttributes will calculate the targetGroup's attributes.
+// the deregistration delay defaults to the one in Ingress's IngressClassParams unless specified via annotation.
+func (t *defaultModelBuildTask) buildTargetGroupAttributes(ctx context.Context, ing *networking.Ingress, svcAndIngAnnotations map[string]string) ([]elbv2model.TargetGroupAttribute, error) {
 	var rawAttributes map[string]string
 	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTargetGroupAttributes, &rawAttributes, svcAndIngAnnotations); err != nil {
 		return nil, err
 	}
+	if _, ok := rawAttributes[tgAttrsLambdaMultiValueHeaders]; ok {
+		return nil, errors.Errorf("targetGroupAttribute %v is only supported for targetType %v",
+			tgAttrsLambdaMultiValueHeaders, elbv2model.TargetTypeLambda)
+	}
+	if _, ok := rawAttributes[tgAttrsDeregistrationDelay]; !ok {
+		classConfig, err := t.classLoader.Load(ctx, ing)
+		if err != nil {
+			return nil, err
+		}
+		if classConfig.IngClassParams != nil && classConfig.IngClassParams.Spec.DeregistrationDelaySeconds != nil {
+			deregistrationDelay := *classConfig.IngClassParams.Spec.DeregistrationDelaySeconds
+			if deregistrationDelay < deregistrationDelaySecondsMin || deregistrationDelay > deregistrationDelaySecondsMax {
+				return nil, errors.Errorf("deregistrationDelaySeconds %v of IngressClassParams %v must be within [%v, %v]",
+					deregistrationDelay, classConfig.IngClassParams.Name, deregistrationDelaySecondsMin, deregistrationDelaySecondsMax)
+			}
+			if rawAttributes == nil {
+				rawAttributes = make(map[string]string)
+			}
+			rawAttributes[tgAttrsDeregistrationDelay] = strconv.FormatInt(deregistrationDelay, 10)
+		}
+	}
 	attributes := make([]elbv2model.TargetGroupAttribute, 0, len(rawAttributes))
 	for attrKey, attrValue := range rawAttributes {
 		attributes = append(attributes, elbv2model.TargetGroupAttribute{
@@ -369,14 +726,48 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context, sv
 	return attributes, nil
 }
 
+// buildStickyTargetGroupAttributes enables stickiness on top of attributes, stickiness type defaults to lb_cookie unless specified.
+func buildStickyTargetGroupAttributes(attributes []elbv2model.TargetGroupAttribute) []elbv2model.TargetGroupAttribute {
+	stickyAttributes := make([]elbv2model.TargetGroupAttribute, 0, len(attributes)+2)
+	hasStickinessType := false
+	for _, attr := range attributes {
+		switch attr.Key {
+		case tgAttrsStickinessEnabled:
+			continue
+		case tgAttrsStickinessType:
+			hasStickinessType = true
+		}
+		stickyAttributes = append(stickyAttributes, attr)
+	}
+	stickyAttributes = append(stickyAttributes, elbv2model.TargetGroupAttribute{
+		Key:   tgAttrsStickinessEnabled,
+		Value: "true",
+	})
+	if !hasStickinessType {
+		stickyAttributes = append(stickyAttributes, elbv2model.TargetGroupAttribute{
+			Key:   tgAttrsStickinessType,
+			Value: stickinessTypeLBCookie,
+		})
+	}
+	return stickyAttributes
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupTags(_ context.Context, svcAndIngAnnotations map[string]string) (map[string]string, error) {
 	var rawTags map[string]string
-	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTags, &rawTags, svcAndIngAnnotations); err != nil {
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTags, &rawTags, svcAndIngAnnotations,
+		annotations.WithRejectDuplicateKeys()); err != nil {
 		return nil, err
 	}
-	return rawTags, nil
+	return t.buildTagsWithDefaults(rawTags), nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupResourceID(ingKey types.NamespacedName, svcKey types.NamespacedName, port intstr.IntOrString) string {
+func (t *defaultModelBuildTask) buildTargetGroupResourceID(ingKey types.NamespacedName, svcKey types.NamespacedName, port intstr.IntOrString, stickiness bool) string {
+	if stickiness {
+		return fmt.Sprintf("%s/%s-%s:%s:sticky", ingKey.Namespace, ingKey.Name, svcKey.Name, port.String())
+	}
 	return fmt.Sprintf("%s/%s-%s:%s", ingKey.Namespace, ingKey.Name, svcKey.Name, port.String())
 }
+
+func (t *defaultModelBuildTask) buildLambdaTargetGroupResourceID(ingKey types.NamespacedName, lambdaFunctionARN string) string {
+	return fmt.Sprintf("%s/%s-lambda:%s", ingKey.Namespace, ingKey.Name, lambdaFunctionARN)
+}
@@ -2,12 +2,22 @@ package ingress
 
 import (
 	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
 	"testing"
 )
 
@@ -22,9 +32,10 @@ func Test_defaultModelBuildTask_buildTargetGroupName(t *testing.T) {
 		tgProtocolVersion elbv2model.ProtocolVersion
 	}
 	tests := []struct {
-		name string
-		args args
-		want string
+		name                    string
+		targetGroupNameTemplate string
+		args                    args
+		want                    string
 	}{
 		{
 			name: "standard case",
@@ -121,25 +132,199 @@ func Test_defaultModelBuildTask_buildTargetGroupName(t *testing.T) {
 			},
 			want: "k8s-ns1-name1-22fbce26a7",
 		},
+		{
+			name:                    "templated name",
+			targetGroupNameTemplate: "{namespace}-{service}-{port}",
+			args: args{
+				ingKey: types.NamespacedName{Namespace: "ns-1", Name: "name-1"},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+						UID:       "my-uuid",
+					},
+				},
+				port:              intstr.FromString("http"),
+				tgPort:            8080,
+				targetType:        elbv2model.TargetTypeIP,
+				tgProtocol:        elbv2model.ProtocolHTTP,
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			want: "ns-1-name-1-http-2c37289a00",
+		},
+		{
+			name:                    "templated name - differs by protocol",
+			targetGroupNameTemplate: "{namespace}-{service}-{port}",
+			args: args{
+				ingKey: types.NamespacedName{Namespace: "ns-1", Name: "name-1"},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+						UID:       "my-uuid",
+					},
+				},
+				port:              intstr.FromString("http"),
+				tgPort:            8080,
+				targetType:        elbv2model.TargetTypeIP,
+				tgProtocol:        elbv2model.ProtocolHTTPS,
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			want: "ns-1-name-1-http-22fbce26a7",
+		},
+		{
+			name:                    "templated name - exceeds length limit",
+			targetGroupNameTemplate: "{namespace}-{service}-{port}",
+			args: args{
+				ingKey: types.NamespacedName{Namespace: "awesome-ns", Name: "name-1"},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-service-1",
+						UID:       "my-uuid",
+					},
+				},
+				port:              intstr.FromString("http"),
+				tgPort:            8080,
+				targetType:        elbv2model.TargetTypeIP,
+				tgProtocol:        elbv2model.ProtocolHTTP,
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			want: "awesome-ns-awesome-se-f10abec44a",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			task := &defaultModelBuildTask{}
-			got := task.buildTargetGroupName(context.Background(), tt.args.ingKey, tt.args.svc, tt.args.port, tt.args.tgPort, tt.args.targetType, tt.args.tgProtocol, tt.args.tgProtocolVersion)
+			task := &defaultModelBuildTask{
+				targetGroupNameTemplate: tt.targetGroupNameTemplate,
+			}
+			got := task.buildTargetGroupName(context.Background(), tt.args.ingKey, tt.args.svc, tt.args.port, tt.args.tgPort, tt.args.targetType, tt.args.tgProtocol, tt.args.tgProtocolVersion, false)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
-func Test_defaultModelBuildTask_buildTargetGroupPort(t *testing.T) {
+func Test_renderTargetGroupName(t *testing.T) {
+	uuid := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
 	type args struct {
-		targetType elbv2model.TargetType
-		svcPort    corev1.ServicePort
+		template string
+		svc      *corev1.Service
+		port     intstr.IntOrString
 	}
 	tests := []struct {
 		name string
 		args args
-		want int64
+		want string
+	}{
+		{
+			name: "all placeholders",
+			args: args{
+				template: "{namespace}-{service}-{port}",
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "svc-1"},
+				},
+				port: intstr.FromInt(80),
+			},
+			want: "ns-1-svc-1-80-0123456789",
+		},
+		{
+			name: "literal text along with placeholders",
+			args: args{
+				template: "k8s-{service}-{port}",
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "svc-1"},
+				},
+				port: intstr.FromString("http"),
+			},
+			want: "k8s-svc-1-http-0123456789",
+		},
+		{
+			name: "invalid characters are removed",
+			args: args{
+				template: "{namespace}_{service}.{port}",
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "svc.1"},
+				},
+				port: intstr.FromString("http"),
+			},
+			want: "ns-1svc1http-0123456789",
+		},
+		{
+			name: "leading and trailing hyphens are removed",
+			args: args{
+				template: "-{service}-",
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "svc-1"},
+				},
+				port: intstr.FromString("http"),
+			},
+			want: "svc-1-0123456789",
+		},
+		{
+			name: "name at length limit is truncated for hash suffix",
+			args: args{
+				template: "{namespace}-{service}",
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-abcdef", Name: "service-abcdefg"},
+				},
+				port: intstr.FromString("http"),
+			},
+			want: "namespace-abcdef-serv-0123456789",
+		},
+		{
+			name: "name exceeds length limit",
+			args: args{
+				template: "{namespace}-{service}-{port}",
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-abcdef", Name: "service-abcdefg"},
+				},
+				port: intstr.FromString("http"),
+			},
+			want: "namespace-abcdef-serv-0123456789",
+		},
+		{
+			name: "truncated name shouldn't end with hyphen",
+			args: args{
+				template: "{namespace}-{service}",
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-abcdefghij", Name: "service-abcdefg"},
+				},
+				port: intstr.FromString("http"),
+			},
+			want: "namespace-abcdefghij-0123456789",
+		},
+		{
+			name: "empty rendered name",
+			args: args{
+				template: "--",
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "svc-1"},
+				},
+				port: intstr.FromString("http"),
+			},
+			want: "k8s-0123456789",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderTargetGroupName(tt.args.template, tt.args.svc, tt.args.port, uuid)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), 32)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupPort(t *testing.T) {
+	type args struct {
+		svcAndIngAnnotations map[string]string
+		targetType           elbv2model.TargetType
+		svcPort              corev1.ServicePort
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		wantErr error
 	}{
 		{
 			name: "instance targetGroup should use nodePort as port",
@@ -180,12 +365,1118 @@ func Test_defaultModelBuildTask_buildTargetGroupPort(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "instance targetGroup with explicit port matching static nodePort should use explicit port",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/target-group-port": "30080",
+				},
+				targetType: elbv2model.TargetTypeInstance,
+				svcPort: corev1.ServicePort{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+					NodePort:   30080,
+				},
+			},
+			want: 30080,
+		},
+		{
+			name: "instance targetGroup with explicit port mismatching nodePort",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/target-group-port": "15006",
+				},
+				targetType: elbv2model.TargetTypeInstance,
+				svcPort: corev1.ServicePort{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+					NodePort:   32768,
+				},
+			},
+			wantErr: errors.New("target group port 15006 must match nodePort 32768 of service port 80 for instance targetType"),
+		},
+		{
+			name: "instance targetGroup with explicit port without nodePort allocated",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/target-group-port": "30080",
+				},
+				targetType: elbv2model.TargetTypeInstance,
+				svcPort: corev1.ServicePort{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+			wantErr: errors.New("target group port 30080 must match nodePort 0 of service port 80 for instance targetType"),
+		},
+		{
+			name: "ip targetGroup with explicit port should use explicit port",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/target-group-port": "15006",
+				},
+				targetType: elbv2model.TargetTypeIP,
+				svcPort: corev1.ServicePort{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromString("http"),
+					NodePort:   32768,
+				},
+			},
+			want: 15006,
+		},
+		{
+			name: "explicit port out of range",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/target-group-port": "65536",
+				},
+				targetType: elbv2model.TargetTypeIP,
+				svcPort: corev1.ServicePort{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+			wantErr: errors.New("target group port must be within [1, 65535]: 65536"),
+		},
+		{
+			name: "explicit port not numeric",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/target-group-port": "http",
+				},
+				targetType: elbv2model.TargetTypeIP,
+				svcPort: corev1.ServicePort{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+			wantErr: errors.New("failed to parse int64 annotation, alb.ingress.kubernetes.io/target-group-port: http: strconv.ParseInt: parsing \"http\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupPort(context.Background(), tt.args.svcAndIngAnnotations, tt.args.targetType, tt.args.svcPort)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupSpec_explicitPort(t *testing.T) {
+	svc1 := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "svc-1"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+					NodePort:   32768,
+				},
+			},
+		},
+	}
+	svc2 := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "svc-2"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "https",
+					Port:       443,
+					TargetPort: intstr.FromInt(8443),
+					NodePort:   32769,
+				},
+			},
+		},
+	}
+	svc3 := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-3",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-type": "ip",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromString("http"),
+				},
+			},
+		},
+	}
+	svc4 := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-4",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-port": "30080",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+					NodePort:   30080,
+				},
+			},
+		},
+	}
+	type backend struct {
+		svc  *corev1.Service
+		port intstr.IntOrString
+	}
+	tests := []struct {
+		name     string
+		ing      *networking.Ingress
+		backends []backend
+		want     []int64
+	}{
+		{
+			name: "without explicit port",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"},
+			},
+			backends: []backend{
+				{svc: svc1, port: intstr.FromString("http")},
+				{svc: svc2, port: intstr.FromInt(443)},
+				{svc: svc3, port: intstr.FromInt(80)},
+			},
+			want: []int64{32768, 32769, 1},
+		},
+		{
+			name: "explicit port applies to all backends",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/target-type":       "ip",
+						"alb.ingress.kubernetes.io/target-group-port": "15006",
+					},
+				},
+			},
+			backends: []backend{
+				{svc: svc1, port: intstr.FromString("http")},
+				{svc: svc2, port: intstr.FromInt(443)},
+				{svc: svc3, port: intstr.FromInt(80)},
+			},
+			want: []int64{15006, 15006, 15006},
+		},
+		{
+			name: "explicit port pins static nodePort for instance backend",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"},
+			},
+			backends: []backend{
+				{svc: svc1, port: intstr.FromString("http")},
+				{svc: svc4, port: intstr.FromInt(80)},
+			},
+			want: []int64{32768, 30080},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser:                          annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				classLoader:                               NewDefaultClassLoader(nil),
+				defaultTargetType:                         elbv2model.TargetTypeInstance,
+				defaultBackendProtocol:                    elbv2model.ProtocolHTTP,
+				defaultBackendProtocolVersion:             elbv2model.ProtocolVersionHTTP1,
+				defaultHealthCheckPath:                    "/",
+				defaultHealthCheckIntervalSeconds:         15,
+				defaultHealthCheckTimeoutSeconds:          5,
+				defaultHealthCheckHealthyThresholdCount:   2,
+				defaultHealthCheckUnhealthyThresholdCount: 2,
+				defaultHealthCheckMatcherHTTPCode:         "200",
+			}
+			var got []int64
+			for _, b := range tt.backends {
+				tgSpec, err := task.buildTargetGroupSpec(context.Background(), tt.ing, b.svc, b.port, false)
+				assert.NoError(t, err)
+				got = append(got, tgSpec.Port)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLambdaTargetGroupSpec(t *testing.T) {
+	lambdaARN := "arn:aws:lambda:us-west-2:123456789012:function:my-function"
+	aliasLambdaARN := "arn:aws:lambda:us-west-2:123456789012:function:my-function:live"
+	type args struct {
+		ing               *networking.Ingress
+		lambdaFunctionARN string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    elbv2model.TargetGroupSpec
+		wantErr error
+	}{
+		{
+			name: "standard case",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+					},
+				},
+				lambdaFunctionARN: lambdaARN,
+			},
+			want: elbv2model.TargetGroupSpec{
+				Name:                  "k8s-ns1-name1-b69345db0f",
+				TargetType:            elbv2model.TargetTypeLambda,
+				TargetGroupAttributes: []elbv2model.TargetGroupAttribute{},
+				LambdaFunctionARN:     &lambdaARN,
+			},
+		},
+		{
+			name: "multi value headers enabled via annotation",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/lambda-multi-value-headers-enabled": "true",
+							"alb.ingress.kubernetes.io/tags":                               "k1=v1",
+						},
+					},
+				},
+				lambdaFunctionARN: lambdaARN,
+			},
+			want: elbv2model.TargetGroupSpec{
+				Name:       "k8s-ns1-name1-b69345db0f",
+				TargetType: elbv2model.TargetTypeLambda,
+				TargetGroupAttributes: []elbv2model.TargetGroupAttribute{
+					{
+						Key:   "lambda.multi_value_headers.enabled",
+						Value: "true",
+					},
+				},
+				LambdaFunctionARN: &lambdaARN,
+				Tags: map[string]string{
+					"k1": "v1",
+				},
+			},
+		},
+		{
+			name: "multi value headers enabled via target-group-attributes",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-group-attributes": "lambda.multi_value_headers.enabled=true",
+						},
+					},
+				},
+				lambdaFunctionARN: lambdaARN,
+			},
+			want: elbv2model.TargetGroupSpec{
+				Name:       "k8s-ns1-name1-b69345db0f",
+				TargetType: elbv2model.TargetTypeLambda,
+				TargetGroupAttributes: []elbv2model.TargetGroupAttribute{
+					{
+						Key:   "lambda.multi_value_headers.enabled",
+						Value: "true",
+					},
+				},
+				LambdaFunctionARN: &lambdaARN,
+			},
+		},
+		{
+			name: "conflicting multi value headers settings",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-group-attributes":            "lambda.multi_value_headers.enabled=true",
+							"alb.ingress.kubernetes.io/lambda-multi-value-headers-enabled": "false",
+						},
+					},
+				},
+				lambdaFunctionARN: lambdaARN,
+			},
+			wantErr: errors.New("conflicting lambda.multi_value_headers.enabled settings between annotations target-group-attributes and lambda-multi-value-headers-enabled"),
+		},
+		{
+			name: "lambda alias shares targetGroup name with unqualified function",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+					},
+				},
+				lambdaFunctionARN: aliasLambdaARN,
+			},
+			want: elbv2model.TargetGroupSpec{
+				Name:                  "k8s-ns1-name1-b69345db0f",
+				TargetType:            elbv2model.TargetTypeLambda,
+				TargetGroupAttributes: []elbv2model.TargetGroupAttribute{},
+				LambdaFunctionARN:     &aliasLambdaARN,
+			},
+		},
+		{
+			name: "lambda ARN with empty qualifier",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+					},
+				},
+				lambdaFunctionARN: "arn:aws:lambda:us-west-2:123456789012:function:my-function:",
+			},
+			wantErr: errors.New("invalid lambda function ARN: arn:aws:lambda:us-west-2:123456789012:function:my-function:"),
+		},
+		{
+			name: "lambda layer ARN",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+					},
+				},
+				lambdaFunctionARN: "arn:aws:lambda:us-west-2:123456789012:layer:my-layer:1",
+			},
+			wantErr: errors.New("invalid lambda function ARN: arn:aws:lambda:us-west-2:123456789012:layer:my-layer:1"),
+		},
+		{
+			name: "non-lambda ARN",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+					},
+				},
+				lambdaFunctionARN: "arn:aws:s3:::my-bucket",
+			},
+			wantErr: errors.New("invalid lambda function ARN: arn:aws:s3:::my-bucket"),
+		},
+		{
+			name: "malformed ARN",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+					},
+				},
+				lambdaFunctionARN: "my-function",
+			},
+			wantErr: errors.New("invalid lambda function ARN: my-function: arn: invalid prefix"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				clusterName:      "my-cluster",
+				ingGroup:         Group{ID: GroupID{Namespace: "ns-1", Name: "name-1"}},
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildLambdaTargetGroupSpec(context.Background(), tt.args.ing, tt.args.lambdaFunctionARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupAttributes(t *testing.T) {
+	ingClasses := []*networking.IngressClass{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "fast-drain"},
+			Spec: networking.IngressClassSpec{
+				Controller: "ingress.k8s.aws/alb",
+				Parameters: &corev1.TypedLocalObjectReference{
+					APIGroup: awssdk.String("elbv2.k8s.aws"),
+					Kind:     "IngressClassParams",
+					Name:     "fast-drain",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-drain"},
+			Spec: networking.IngressClassSpec{
+				Controller: "ingress.k8s.aws/alb",
+				Parameters: &corev1.TypedLocalObjectReference{
+					APIGroup: awssdk.String("elbv2.k8s.aws"),
+					Kind:     "IngressClassParams",
+					Name:     "invalid-drain",
+				},
+			},
+		},
+	}
+	ingClassParamses := []*elbv2api.IngressClassParams{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "fast-drain"},
+			Spec: elbv2api.IngressClassParamsSpec{
+				DeregistrationDelaySeconds: awssdk.Int64(30),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-drain"},
+			Spec: elbv2api.IngressClassParamsSpec{
+				DeregistrationDelaySeconds: awssdk.Int64(3601),
+			},
+		},
+	}
+	tests := []struct {
+		name                 string
+		ingClassName         *string
+		svcAndIngAnnotations map[string]string
+		want                 []elbv2model.TargetGroupAttribute
+		wantErr              error
+	}{
+		{
+			name: "standard case",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes": "slow_start.duration_seconds=30",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "slow_start.duration_seconds",
+					Value: "30",
+				},
+			},
+		},
+		{
+			name: "lambda attribute on non-lambda targetGroup",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes": "lambda.multi_value_headers.enabled=true",
+			},
+			wantErr: errors.New("targetGroupAttribute lambda.multi_value_headers.enabled is only supported for targetType lambda"),
+		},
+		{
+			name:         "deregistration delay defaults via IngressClassParams",
+			ingClassName: awssdk.String("fast-drain"),
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "deregistration_delay.timeout_seconds",
+					Value: "30",
+				},
+			},
+		},
+		{
+			name:         "deregistration delay annotation overrides IngressClassParams",
+			ingClassName: awssdk.String("fast-drain"),
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes": "deregistration_delay.timeout_seconds=120",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "deregistration_delay.timeout_seconds",
+					Value: "120",
+				},
+			},
+		},
+		{
+			name:         "deregistration delay of IngressClassParams out of range",
+			ingClassName: awssdk.String("invalid-drain"),
+			wantErr:      errors.New("deregistrationDelaySeconds 3601 of IngressClassParams invalid-drain must be within [0, 3600]"),
+		},
+		{
+			name:         "out of range deregistration delay of IngressClassParams is ignored when overridden",
+			ingClassName: awssdk.String("invalid-drain"),
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes": "deregistration_delay.timeout_seconds=60",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "deregistration_delay.timeout_seconds",
+					Value: "60",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, ingClass := range ingClasses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClass.DeepCopy()))
+			}
+			for _, ingClassParams := range ingClassParamses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClassParams.DeepCopy()))
+			}
+
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				classLoader:      NewDefaultClassLoader(k8sClient),
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+				},
+				Spec: networking.IngressSpec{IngressClassName: tt.ingClassName},
+			}
+			got, err := task.buildTargetGroupAttributes(ctx, ing, tt.svcAndIngAnnotations)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupSpec_defaultBackendHealthCheck(t *testing.T) {
+	ruleSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "rule-svc"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+					NodePort:   32768,
+				},
+			},
+		},
+	}
+	defaultBackendSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "default-backend-svc",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/healthcheck-path":             "/fallback/healthz",
+				"alb.ingress.kubernetes.io/healthcheck-interval-seconds": "30",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+					NodePort:   32769,
+				},
+			},
+		},
+	}
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-1",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/healthcheck-path": "/healthz",
+			},
+		},
+	}
+	tests := []struct {
+		name                string
+		svc                 *corev1.Service
+		wantHealthCheckPath string
+		wantIntervalSeconds int64
+	}{
+		{
+			name:                "rule backend uses ingress health check",
+			svc:                 ruleSvc,
+			wantHealthCheckPath: "/healthz",
+			wantIntervalSeconds: 15,
+		},
+		{
+			name:                "default backend uses its own service health check",
+			svc:                 defaultBackendSvc,
+			wantHealthCheckPath: "/fallback/healthz",
+			wantIntervalSeconds: 30,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser:                          annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				classLoader:                               NewDefaultClassLoader(nil),
+				defaultTargetType:                         elbv2model.TargetTypeInstance,
+				defaultBackendProtocol:                    elbv2model.ProtocolHTTP,
+				defaultBackendProtocolVersion:             elbv2model.ProtocolVersionHTTP1,
+				defaultHealthCheckPath:                    "/",
+				defaultHealthCheckIntervalSeconds:         15,
+				defaultHealthCheckTimeoutSeconds:          5,
+				defaultHealthCheckHealthyThresholdCount:   2,
+				defaultHealthCheckUnhealthyThresholdCount: 2,
+				defaultHealthCheckMatcherHTTPCode:         "200",
+			}
+			tgSpec, err := task.buildTargetGroupSpec(context.Background(), ing, tt.svc, intstr.FromString("http"), false)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantHealthCheckPath, awssdk.StringValue(tgSpec.HealthCheckConfig.Path))
+			assert.Equal(t, tt.wantIntervalSeconds, awssdk.Int64Value(tgSpec.HealthCheckConfig.IntervalSeconds))
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPort(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "svc-1"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+					NodePort:   32768,
+				},
+				{
+					Name:       "health",
+					Port:       9090,
+					TargetPort: intstr.FromInt(9091),
+					NodePort:   32769,
+				},
+			},
+		},
+	}
+	svcWithNamedTargetPort := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "svc-2"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromString("http"),
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name            string
+		svc             *corev1.Service
+		healthCheckPort string
+		targetType      elbv2model.TargetType
+		want            intstr.IntOrString
+		wantErr         error
+	}{
+		{
+			name:            "traffic-port",
+			svc:             svc,
+			healthCheckPort: "traffic-port",
+			targetType:      elbv2model.TargetTypeInstance,
+			want:            intstr.FromString("traffic-port"),
+		},
+		{
+			name:            "numeric port matches nodePort for instance targetType",
+			svc:             svc,
+			healthCheckPort: "32769",
+			targetType:      elbv2model.TargetTypeInstance,
+			want:            intstr.FromInt(32769),
+		},
+		{
+			name:            "numeric port matches targetPort for ip targetType",
+			svc:             svc,
+			healthCheckPort: "9091",
+			targetType:      elbv2model.TargetTypeIP,
+			want:            intstr.FromInt(9091),
+		},
+		{
+			name:            "numeric port not among nodePorts for instance targetType",
+			svc:             svc,
+			healthCheckPort: "9091",
+			targetType:      elbv2model.TargetTypeInstance,
+			wantErr:         errors.New("healthCheckPort 9091 not found among service awesome-ns/svc-1 ports for instance TargetType"),
+		},
+		{
+			name:            "numeric port not among targetPorts for ip targetType",
+			svc:             svc,
+			healthCheckPort: "9090",
+			targetType:      elbv2model.TargetTypeIP,
+			wantErr:         errors.New("healthCheckPort 9090 not found among service awesome-ns/svc-1 ports for ip TargetType"),
+		},
+		{
+			name:            "numeric port with named targetPort for ip targetType",
+			svc:             svcWithNamedTargetPort,
+			healthCheckPort: "9090",
+			targetType:      elbv2model.TargetTypeIP,
+			want:            intstr.FromInt(9090),
+		},
+		{
+			name:            "named port resolves to nodePort for instance targetType",
+			svc:             svc,
+			healthCheckPort: "health",
+			targetType:      elbv2model.TargetTypeInstance,
+			want:            intstr.FromInt(32769),
+		},
+		{
+			name:            "named port not found",
+			svc:             svc,
+			healthCheckPort: "metrics",
+			targetType:      elbv2model.TargetTypeInstance,
+			wantErr:         errors.New("failed to resolve healthCheckPort: unable to find port metrics on service awesome-ns/svc-1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			svcAndIngAnnotations := map[string]string{
+				"alb.ingress.kubernetes.io/healthcheck-port": tt.healthCheckPort,
+			}
+			got, err := task.buildTargetGroupHealthCheckPort(context.Background(), tt.svc, svcAndIngAnnotations, tt.targetType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupHealthCheckMatcher(t *testing.T) {
+	tests := []struct {
+		name                 string
+		svcAndIngAnnotations map[string]string
+		tgProtocolVersion    elbv2model.ProtocolVersion
+		want                 elbv2model.HealthCheckMatcher
+		wantErr              error
+	}{
+		{
+			name:                 "default http code matcher for HTTP1",
+			svcAndIngAnnotations: map[string]string{},
+			tgProtocolVersion:    elbv2model.ProtocolVersionHTTP1,
+			want: elbv2model.HealthCheckMatcher{
+				HTTPCode: awssdk.String("200"),
+			},
+		},
+		{
+			name: "http code matcher for HTTP2",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/success-codes": "200-299,302",
+			},
+			tgProtocolVersion: elbv2model.ProtocolVersionHTTP2,
+			want: elbv2model.HealthCheckMatcher{
+				HTTPCode: awssdk.String("200-299,302"),
+			},
+		},
+		{
+			name:                 "default grpc code matcher for GRPC",
+			svcAndIngAnnotations: map[string]string{},
+			tgProtocolVersion:    elbv2model.ProtocolVersionGRPC,
+			want: elbv2model.HealthCheckMatcher{
+				GRPCCode: awssdk.String("12"),
+			},
+		},
+		{
+			name: "grpc code matcher for GRPC",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/success-codes": "0-5,12",
+			},
+			tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			want: elbv2model.HealthCheckMatcher{
+				GRPCCode: awssdk.String("0-5,12"),
+			},
+		},
+		{
+			name: "http code out of range for HTTP1",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/success-codes": "0",
+			},
+			tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			wantErr:           errors.New("invalid success codes for HTTP1 protocolVersion: success code must be within [200, 499]: 0"),
+		},
+		{
+			name: "grpc code out of range for GRPC",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/success-codes": "200",
+			},
+			tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			wantErr:           errors.New("invalid success codes for GRPC protocolVersion: success code must be within [0, 99]: 200"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser:                  annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			}
+			got, err := task.buildTargetGroupHealthCheckMatcher(context.Background(), tt.svcAndIngAnnotations, tt.tgProtocolVersion)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_validateHealthCheckMatcherCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawCodes string
+		minCode  int64
+		maxCode  int64
+		wantErr  error
+	}{
+		{
+			name:     "single code",
+			rawCodes: "200",
+			minCode:  200,
+			maxCode:  499,
+		},
+		{
+			name:     "multiple codes and ranges",
+			rawCodes: "200,202-204, 300",
+			minCode:  200,
+			maxCode:  499,
+		},
+		{
+			name:     "non-integer code",
+			rawCodes: "200,ok",
+			minCode:  200,
+			maxCode:  499,
+			wantErr:  errors.New("success code must be integer or range of integers: ok"),
+		},
+		{
+			name:     "range exceeds max code",
+			rawCodes: "12-100",
+			minCode:  0,
+			maxCode:  99,
+			wantErr:  errors.New("success code must be within [0, 99]: 12-100"),
+		},
+		{
+			name:     "descending range",
+			rawCodes: "12-5",
+			minCode:  0,
+			maxCode:  99,
+			wantErr:  errors.New("success code range must be ascending: 12-5"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHealthCheckMatcherCodes(tt.rawCodes, tt.minCode, tt.maxCode)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupTargetType(t *testing.T) {
+	targetTypeIP := elbv2api.TargetTypeIP
+	ingClasses := []*networking.IngressClass{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ip-authoritative"},
+			Spec: networking.IngressClassSpec{
+				Controller: "ingress.k8s.aws/alb",
+				Parameters: &corev1.TypedLocalObjectReference{
+					APIGroup: awssdk.String("elbv2.k8s.aws"),
+					Kind:     "IngressClassParams",
+					Name:     "ip-authoritative",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ip-default"},
+			Spec: networking.IngressClassSpec{
+				Controller: "ingress.k8s.aws/alb",
+				Parameters: &corev1.TypedLocalObjectReference{
+					APIGroup: awssdk.String("elbv2.k8s.aws"),
+					Kind:     "IngressClassParams",
+					Name:     "ip-default",
+				},
+			},
+		},
+	}
+	ingClassParamses := []*elbv2api.IngressClassParams{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ip-authoritative"},
+			Spec: elbv2api.IngressClassParamsSpec{
+				TargetType:              &targetTypeIP,
+				TargetTypeAuthoritative: true,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ip-default"},
+			Spec: elbv2api.IngressClassParamsSpec{
+				TargetType: &targetTypeIP,
+			},
+		},
+	}
+	tests := []struct {
+		name           string
+		ingClassName   *string
+		ingAnnotations map[string]string
+		svcAnnotations map[string]string
+		// default targetType of controller, defaults to instance.
+		defaultTargetType elbv2model.TargetType
+		want              elbv2model.TargetType
+		wantErr           error
+	}{
+		{
+			name: "targetType defaults to controller's default targetType",
+			want: elbv2model.TargetTypeInstance,
+		},
+		{
+			name:              "targetType via controller's default targetType",
+			defaultTargetType: elbv2model.TargetTypeIP,
+			want:              elbv2model.TargetTypeIP,
+		},
+		{
+			name: "targetType annotation overrides controller's default targetType",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-type": "instance",
+			},
+			defaultTargetType: elbv2model.TargetTypeIP,
+			want:              elbv2model.TargetTypeInstance,
+		},
+		{
+			name:         "targetType via IngressClassParams",
+			ingClassName: awssdk.String("ip-default"),
+			want:         elbv2model.TargetTypeIP,
+		},
+		{
+			name: "targetType via Ingress annotation",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-type": "ip",
+			},
+			want: elbv2model.TargetTypeIP,
+		},
+		{
+			name: "targetType via Service annotation",
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-type": "ip",
+			},
+			want: elbv2model.TargetTypeIP,
+		},
+		{
+			name:         "targetType annotation overrides non-authoritative IngressClassParams",
+			ingClassName: awssdk.String("ip-default"),
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-type": "instance",
+			},
+			want: elbv2model.TargetTypeInstance,
+		},
+		{
+			name:         "targetType annotation matches authoritative IngressClassParams",
+			ingClassName: awssdk.String("ip-authoritative"),
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-type": "ip",
+			},
+			want: elbv2model.TargetTypeIP,
+		},
+		{
+			name:         "authoritative IngressClassParams rejects conflicting targetType annotation",
+			ingClassName: awssdk.String("ip-authoritative"),
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-type": "instance",
+			},
+			wantErr: errors.New("targetType instance for Ingress awesome-ns/ing-1 is not allowed, IngressClassParams ip-authoritative enforces targetType ip"),
+		},
+		{
+			name: "unknown targetType",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-type": "lambda",
+			},
+			wantErr: errors.New("unknown targetType: lambda"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, ingClass := range ingClasses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClass.DeepCopy()))
+			}
+			for _, ingClassParams := range ingClassParamses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClassParams.DeepCopy()))
+			}
+
+			defaultTargetType := elbv2model.TargetTypeInstance
+			if tt.defaultTargetType != "" {
+				defaultTargetType = tt.defaultTargetType
+			}
+			task := &defaultModelBuildTask{
+				annotationParser:  annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				classLoader:       NewDefaultClassLoader(k8sClient),
+				defaultTargetType: defaultTargetType,
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "awesome-ns",
+					Name:        "ing-1",
+					Annotations: tt.ingAnnotations,
+				},
+				Spec: networking.IngressSpec{IngressClassName: tt.ingClassName},
+			}
+			svcAndIngAnnotations := algorithm.MergeStringMap(tt.svcAnnotations, tt.ingAnnotations)
+			got, err := task.buildTargetGroupTargetType(ctx, ing, svcAndIngAnnotations)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_TargetGroupBindingIngressNameLabelValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		ingName string
+		want    string
+	}{
+		{
+			name:    "short ingress name",
+			ingName: "my-ingress",
+			want:    "my-ingress",
+		},
+		{
+			name:    "ingress name of max label value length",
+			ingName: strings.Repeat("a", 63),
+			want:    strings.Repeat("a", 63),
+		},
+		{
+			name:    "ingress name exceeds max label value length",
+			ingName: strings.Repeat("a", 70),
+			want:    strings.Repeat("a", 52) + "-6bd5e50348",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			task := &defaultModelBuildTask{}
-			got := task.buildTargetGroupPort(context.Background(), tt.args.targetType, tt.args.svcPort)
+			got := TargetGroupBindingIngressNameLabelValue(tt.ingName)
 			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), 63)
 		})
 	}
 }
@@ -10,7 +10,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
@@ -31,25 +35,48 @@ type ModelBuilder interface {
 
 // NewDefaultModelBuilder constructs new defaultModelBuilder.
 func NewDefaultModelBuilder(k8sClient client.Client, eventRecorder record.EventRecorder,
-	ec2Client services.EC2, acmClient services.ACM,
-	annotationParser annotations.Parser, subnetsResolver networkingpkg.SubnetsResolver,
-	authConfigBuilder AuthConfigBuilder, enhancedBackendBuilder EnhancedBackendBuilder,
-	vpcID string, clusterName string, logger logr.Logger) *defaultModelBuilder {
+	ec2Client services.EC2, acmClient services.ACM, lambdaClient services.Lambda, route53Client services.Route53,
+	elbv2TaggingManager elbv2deploy.TaggingManager, trackingProvider tracking.Provider,
+	annotationParser annotations.Parser, subnetsResolver networkingpkg.SubnetsResolver, sgResolver networkingpkg.SecurityGroupResolver,
+	authConfigBuilder AuthConfigBuilder, enhancedBackendBuilder EnhancedBackendBuilder, accessLogBucketValidator aws.AccessLogBucketValidator,
+	vpcID string, config config.ControllerConfig, logger logr.Logger) *defaultModelBuilder {
 	certDiscovery := NewACMCertDiscovery(acmClient, logger)
+	certValidator := NewACMCertValidator(acmClient, logger)
+	classLoader := NewDefaultClassLoader(k8sClient)
+	healthCheckValidator := NewRoute53HealthCheckValidator(route53Client, logger)
+	lambdaPermissionValidator := NewDefaultLambdaPermissionValidator(lambdaClient, logger)
 	ruleOptimizer := NewDefaultRuleOptimizer(logger)
 	return &defaultModelBuilder{
-		k8sClient:              k8sClient,
-		eventRecorder:          eventRecorder,
-		ec2Client:              ec2Client,
-		vpcID:                  vpcID,
-		clusterName:            clusterName,
-		annotationParser:       annotationParser,
-		subnetsResolver:        subnetsResolver,
-		certDiscovery:          certDiscovery,
-		authConfigBuilder:      authConfigBuilder,
-		enhancedBackendBuilder: enhancedBackendBuilder,
-		ruleOptimizer:          ruleOptimizer,
-		logger:                 logger,
+		k8sClient:                         k8sClient,
+		eventRecorder:                     eventRecorder,
+		ec2Client:                         ec2Client,
+		elbv2TaggingManager:               elbv2TaggingManager,
+		trackingProvider:                  trackingProvider,
+		vpcID:                             vpcID,
+		clusterName:                       config.ClusterName,
+		missingCertificatePolicy:          config.IngressConfig.MissingCertificatePolicy,
+		duplicateRulePolicy:               config.IngressConfig.DuplicateRulePolicy,
+		targetGroupNameTemplate:           config.IngressConfig.TargetGroupNameTemplate,
+		ruleConditionValuesLimit:          config.IngressConfig.RuleConditionValuesLimit,
+		ruleValuesLimit:                   config.IngressConfig.RuleValuesLimit,
+		splitRuleConditions:               config.IngressConfig.SplitRuleConditions,
+		defaultTargetType:                 elbv2model.TargetType(config.IngressConfig.DefaultTargetType),
+		subnetDiscoveryPreferAvailableIPs: config.SubnetDiscoveryPreferAvailableIPs,
+		azExpansionPolicy:                 config.LoadBalancerAZExpansionPolicy,
+		defaultTags:                       config.DefaultTags,
+		annotationParser:                  annotationParser,
+		subnetsResolver:                   subnetsResolver,
+		sgResolver:                        sgResolver,
+		certDiscovery:                     certDiscovery,
+		certValidator:                     certValidator,
+		classLoader:                       classLoader,
+		healthCheckValidator:              healthCheckValidator,
+		lambdaPermissionValidator:         lambdaPermissionValidator,
+		accessLogBucketValidator:          accessLogBucketValidator,
+		authConfigBuilder:                 authConfigBuilder,
+		enhancedBackendBuilder:            enhancedBackendBuilder,
+		ruleOptimizer:                     ruleOptimizer,
+		logger:                            logger,
 	}
 }
 
@@ -57,19 +84,37 @@ var _ ModelBuilder = &defaultModelBuilder{}
 
 // default implementation for ModelBuilder
 type defaultModelBuilder struct {
-	k8sClient     client.Client
-	eventRecorder record.EventRecorder
-	ec2Client     services.EC2
+	k8sClient           client.Client
+	eventRecorder       record.EventRecorder
+	ec2Client           services.EC2
+	elbv2TaggingManager elbv2deploy.TaggingManager
+	trackingProvider    tracking.Provider
 
-	vpcID       string
-	clusterName string
+	vpcID                             string
+	clusterName                       string
+	missingCertificatePolicy          string
+	duplicateRulePolicy               string
+	targetGroupNameTemplate           string
+	ruleConditionValuesLimit          int
+	ruleValuesLimit                   int
+	splitRuleConditions               bool
+	defaultTargetType                 elbv2model.TargetType
+	subnetDiscoveryPreferAvailableIPs bool
+	azExpansionPolicy                 string
+	defaultTags                       map[string]string
 
-	annotationParser       annotations.Parser
-	subnetsResolver        networkingpkg.SubnetsResolver
-	certDiscovery          CertDiscovery
-	authConfigBuilder      AuthConfigBuilder
-	enhancedBackendBuilder EnhancedBackendBuilder
-	ruleOptimizer          RuleOptimizer
+	annotationParser          annotations.Parser
+	subnetsResolver           networkingpkg.SubnetsResolver
+	sgResolver                networkingpkg.SecurityGroupResolver
+	certDiscovery             CertDiscovery
+	certValidator             CertValidator
+	classLoader               ClassLoader
+	healthCheckValidator      HealthCheckValidator
+	lambdaPermissionValidator LambdaPermissionValidator
+	accessLogBucketValidator  aws.AccessLogBucketValidator
+	authConfigBuilder         AuthConfigBuilder
+	enhancedBackendBuilder    EnhancedBackendBuilder
+	ruleOptimizer             RuleOptimizer
 
 	logger logr.Logger
 }
@@ -78,18 +123,35 @@ type defaultModelBuilder struct {
 func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.Stack, *elbv2model.LoadBalancer, error) {
 	stack := core.NewDefaultStack(core.StackID(ingGroup.ID))
 	task := &defaultModelBuildTask{
-		k8sClient:              b.k8sClient,
-		eventRecorder:          b.eventRecorder,
-		ec2Client:              b.ec2Client,
-		vpcID:                  b.vpcID,
-		clusterName:            b.clusterName,
-		annotationParser:       b.annotationParser,
-		subnetsResolver:        b.subnetsResolver,
-		certDiscovery:          b.certDiscovery,
-		authConfigBuilder:      b.authConfigBuilder,
-		enhancedBackendBuilder: b.enhancedBackendBuilder,
-		ruleOptimizer:          b.ruleOptimizer,
-		logger:                 b.logger,
+		k8sClient:                         b.k8sClient,
+		eventRecorder:                     b.eventRecorder,
+		ec2Client:                         b.ec2Client,
+		elbv2TaggingManager:               b.elbv2TaggingManager,
+		trackingProvider:                  b.trackingProvider,
+		vpcID:                             b.vpcID,
+		clusterName:                       b.clusterName,
+		missingCertificatePolicy:          b.missingCertificatePolicy,
+		duplicateRulePolicy:               b.duplicateRulePolicy,
+		targetGroupNameTemplate:           b.targetGroupNameTemplate,
+		ruleConditionValuesLimit:          b.ruleConditionValuesLimit,
+		ruleValuesLimit:                   b.ruleValuesLimit,
+		splitRuleConditions:               b.splitRuleConditions,
+		subnetDiscoveryPreferAvailableIPs: b.subnetDiscoveryPreferAvailableIPs,
+		azExpansionPolicy:                 b.azExpansionPolicy,
+		defaultTags:                       b.defaultTags,
+		annotationParser:                  b.annotationParser,
+		subnetsResolver:                   b.subnetsResolver,
+		sgResolver:                        b.sgResolver,
+		certDiscovery:                     b.certDiscovery,
+		certValidator:                     b.certValidator,
+		classLoader:                       b.classLoader,
+		healthCheckValidator:              b.healthCheckValidator,
+		lambdaPermissionValidator:         b.lambdaPermissionValidator,
+		accessLogBucketValidator:          b.accessLogBucketValidator,
+		authConfigBuilder:                 b.authConfigBuilder,
+		enhancedBackendBuilder:            b.enhancedBackendBuilder,
+		ruleOptimizer:                     b.ruleOptimizer,
+		logger:                            b.logger,
 
 		ingGroup: ingGroup,
 		stack:    stack,
@@ -97,7 +159,7 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 		defaultIPAddressType:                      elbv2model.IPAddressTypeIPV4,
 		defaultScheme:                             elbv2model.LoadBalancerSchemeInternal,
 		defaultSSLPolicy:                          "ELBSecurityPolicy-2016-08",
-		defaultTargetType:                         elbv2model.TargetTypeInstance,
+		defaultTargetType:                         b.defaultTargetType,
 		defaultBackendProtocol:                    elbv2model.ProtocolHTTP,
 		defaultBackendProtocolVersion:             elbv2model.ProtocolVersionHTTP1,
 		defaultHealthCheckPath:                    "/",
@@ -106,9 +168,11 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 		defaultHealthCheckHealthyThresholdCount:   2,
 		defaultHealthCheckUnhealthyThresholdCount: 2,
 		defaultHealthCheckMatcherHTTPCode:         "200",
+		defaultHealthCheckMatcherGRPCCode:         "12",
 
-		loadBalancer: nil,
-		tgByResID:    make(map[string]*elbv2model.TargetGroup),
+		loadBalancer:           nil,
+		tgByResID:              make(map[string]*elbv2model.TargetGroup),
+		reportedDuplicateRules: make(map[duplicateRule]bool),
 	}
 	if err := task.run(ctx); err != nil {
 		return nil, nil, err
@@ -118,18 +182,35 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 
 // the default model build task
 type defaultModelBuildTask struct {
-	k8sClient              client.Client
-	eventRecorder          record.EventRecorder
-	ec2Client              services.EC2
-	vpcID                  string
-	clusterName            string
-	annotationParser       annotations.Parser
-	subnetsResolver        networkingpkg.SubnetsResolver
-	certDiscovery          CertDiscovery
-	authConfigBuilder      AuthConfigBuilder
-	enhancedBackendBuilder EnhancedBackendBuilder
-	ruleOptimizer          RuleOptimizer
-	logger                 logr.Logger
+	k8sClient                         client.Client
+	eventRecorder                     record.EventRecorder
+	ec2Client                         services.EC2
+	elbv2TaggingManager               elbv2deploy.TaggingManager
+	trackingProvider                  tracking.Provider
+	vpcID                             string
+	clusterName                       string
+	missingCertificatePolicy          string
+	duplicateRulePolicy               string
+	targetGroupNameTemplate           string
+	ruleConditionValuesLimit          int
+	ruleValuesLimit                   int
+	splitRuleConditions               bool
+	subnetDiscoveryPreferAvailableIPs bool
+	azExpansionPolicy                 string
+	defaultTags                       map[string]string
+	annotationParser                  annotations.Parser
+	subnetsResolver                   networkingpkg.SubnetsResolver
+	sgResolver                        networkingpkg.SecurityGroupResolver
+	certDiscovery                     CertDiscovery
+	certValidator                     CertValidator
+	classLoader                       ClassLoader
+	healthCheckValidator              HealthCheckValidator
+	lambdaPermissionValidator         LambdaPermissionValidator
+	accessLogBucketValidator          aws.AccessLogBucketValidator
+	authConfigBuilder                 AuthConfigBuilder
+	enhancedBackendBuilder            EnhancedBackendBuilder
+	ruleOptimizer                     RuleOptimizer
+	logger                            logr.Logger
 
 	ingGroup Group
 	stack    core.Stack
@@ -146,10 +227,17 @@ type defaultModelBuildTask struct {
 	defaultHealthCheckHealthyThresholdCount   int64
 	defaultHealthCheckUnhealthyThresholdCount int64
 	defaultHealthCheckMatcherHTTPCode         string
+	defaultHealthCheckMatcherGRPCCode         string
 
 	loadBalancer *elbv2model.LoadBalancer
 	managedSG    *ec2model.SecurityGroup
 	tgByResID    map[string]*elbv2model.TargetGroup
+	// the duplicate rules already reported, so that each of them is only reported once across listeners.
+	reportedDuplicateRules map[duplicateRule]bool
+
+	// the existing LoadBalancer of IngressGroup, fetched lazily and nil if it doesn't exist yet.
+	existingLoadBalancer        *elbv2deploy.LoadBalancerWithTags
+	existingLoadBalancerFetched bool
 }
 
 func (t *defaultModelBuildTask) run(ctx context.Context) error {
@@ -211,11 +299,17 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 	mergedInboundCIDRv6s := sets.NewString()
 	mergedInboundCIDRv4s := sets.NewString()
 
+	var mergedPrefixListIDsProvider *types.NamespacedName
+	mergedPrefixListIDs := sets.NewString()
+
 	var mergedSSLPolicyProvider *types.NamespacedName
 	var mergedSSLPolicy *string
 
 	mergedTLSCerts := sets.NewString()
 
+	var mergedDefaultTLSCertProvider *types.NamespacedName
+	var mergedDefaultTLSCert *string
+
 	for ingKey, cfg := range listenPortConfigByIngress {
 		if mergedProtocolProvider == nil {
 			mergedProtocolProvider = &ingKey
@@ -240,6 +334,17 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 			}
 		}
 
+		if len(cfg.prefixListIDs) != 0 {
+			cfgPrefixListIDs := sets.NewString(cfg.prefixListIDs...)
+			if mergedPrefixListIDsProvider == nil {
+				mergedPrefixListIDsProvider = &ingKey
+				mergedPrefixListIDs = cfgPrefixListIDs
+			} else if !mergedPrefixListIDs.Equal(cfgPrefixListIDs) {
+				return listenPortConfig{}, errors.Errorf("conflicting security-group-prefix-lists, %v: %v | %v: %v",
+					*mergedPrefixListIDsProvider, mergedPrefixListIDs.List(), ingKey, cfgPrefixListIDs.List())
+			}
+		}
+
 		if cfg.sslPolicy != nil {
 			if mergedSSLPolicyProvider == nil {
 				mergedSSLPolicyProvider = &ingKey
@@ -249,10 +354,19 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 					*mergedSSLPolicyProvider, awssdk.StringValue(mergedSSLPolicy), ingKey, awssdk.StringValue(cfg.sslPolicy))
 			}
 		}
+		if cfg.defaultTLSCert != nil {
+			if mergedDefaultTLSCertProvider == nil {
+				mergedDefaultTLSCertProvider = &ingKey
+				mergedDefaultTLSCert = cfg.defaultTLSCert
+			} else if awssdk.StringValue(mergedDefaultTLSCert) != awssdk.StringValue(cfg.defaultTLSCert) {
+				return listenPortConfig{}, errors.Errorf("conflicting defaultCertificate, %v: %v | %v: %v",
+					*mergedDefaultTLSCertProvider, awssdk.StringValue(mergedDefaultTLSCert), ingKey, awssdk.StringValue(cfg.defaultTLSCert))
+			}
+		}
 		mergedTLSCerts.Insert(cfg.tlsCerts...)
 	}
 
-	if len(mergedInboundCIDRv4s) == 0 && len(mergedInboundCIDRv6s) == 0 {
+	if len(mergedInboundCIDRv4s) == 0 && len(mergedInboundCIDRv6s) == 0 && len(mergedPrefixListIDs) == 0 {
 		mergedInboundCIDRv4s.Insert("0.0.0.0/0")
 		mergedInboundCIDRv6s.Insert("::/0")
 	}
@@ -264,7 +378,9 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 		protocol:       mergedProtocol,
 		inboundCIDRv4s: mergedInboundCIDRv4s.List(),
 		inboundCIDRv6s: mergedInboundCIDRv6s.List(),
+		prefixListIDs:  mergedPrefixListIDs.List(),
 		sslPolicy:      mergedSSLPolicy,
 		tlsCerts:       mergedTLSCerts.List(),
+		defaultTLSCert: mergedDefaultTLSCert,
 	}, nil
 }
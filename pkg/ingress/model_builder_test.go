@@ -10,14 +10,18 @@ import (
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	mock_elbv2 "sigs.k8s.io/aws-load-balancer-controller/mocks/deploy/elbv2"
 	mock_ingress "sigs.k8s.io/aws-load-balancer-controller/mocks/ingress"
 	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
@@ -108,6 +112,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 					TargetPort: intstr.FromInt(8443),
 					NodePort:   32768,
 				},
+				{
+					Name:       "health",
+					Port:       9090,
+					TargetPort: intstr.FromInt(9090),
+					NodePort:   32769,
+				},
 			},
 		},
 	}
@@ -470,7 +480,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                         "metadata":{
                             "name":"k8s-ns1-svc1-9889425938",
                             "namespace":"ns-1",
-                            "creationTimestamp":null
+                            "creationTimestamp":null,
+                            "labels":{
+                                "ingress.k8s.aws/ingress-name":"ing-1",
+                                "ingress.k8s.aws/service-name":"svc-1",
+                                "ingress.k8s.aws/service-port":"http"
+                            }
                         },
                         "spec":{
                             "targetGroupARN":{
@@ -511,7 +526,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                         "metadata":{
                             "name":"k8s-ns1-svc2-9889425938",
                             "namespace":"ns-1",
-                            "creationTimestamp":null
+                            "creationTimestamp":null,
+                            "labels":{
+                                "ingress.k8s.aws/ingress-name":"ing-1",
+                                "ingress.k8s.aws/service-name":"svc-2",
+                                "ingress.k8s.aws/service-port":"http"
+                            }
                         },
                         "spec":{
                             "targetGroupARN":{
@@ -552,7 +572,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                         "metadata":{
                             "name":"k8s-ns1-svc3-bf42870fba",
                             "namespace":"ns-1",
-                            "creationTimestamp":null
+                            "creationTimestamp":null,
+                            "labels":{
+                                "ingress.k8s.aws/ingress-name":"ing-1",
+                                "ingress.k8s.aws/service-name":"svc-3",
+                                "ingress.k8s.aws/service-port":"https"
+                            }
                         },
                         "spec":{
                             "targetGroupARN":{
@@ -919,7 +944,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                         "metadata":{
                             "name":"k8s-ns1-svc1-9889425938",
                             "namespace":"ns-1",
-                            "creationTimestamp":null
+                            "creationTimestamp":null,
+                            "labels":{
+                                "ingress.k8s.aws/ingress-name":"ing-1",
+                                "ingress.k8s.aws/service-name":"svc-1",
+                                "ingress.k8s.aws/service-port":"http"
+                            }
                         },
                         "spec":{
                             "targetGroupARN":{
@@ -960,7 +990,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                         "metadata":{
                             "name":"k8s-ns1-svc2-9889425938",
                             "namespace":"ns-1",
-                            "creationTimestamp":null
+                            "creationTimestamp":null,
+                            "labels":{
+                                "ingress.k8s.aws/ingress-name":"ing-1",
+                                "ingress.k8s.aws/service-name":"svc-2",
+                                "ingress.k8s.aws/service-port":"http"
+                            }
                         },
                         "spec":{
                             "targetGroupARN":{
@@ -1001,7 +1036,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                         "metadata":{
                             "name":"k8s-ns1-svc3-bf42870fba",
                             "namespace":"ns-1",
-                            "creationTimestamp":null
+                            "creationTimestamp":null,
+                            "labels":{
+                                "ingress.k8s.aws/ingress-name":"ing-1",
+                                "ingress.k8s.aws/service-name":"svc-3",
+                                "ingress.k8s.aws/service-port":"https"
+                            }
                         },
                         "spec":{
                             "targetGroupARN":{
@@ -1288,7 +1328,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                         "metadata":{
                             "name":"k8s-ns1-svc1-90b7d93b18",
                             "namespace":"ns-1",
-                            "creationTimestamp":null
+                            "creationTimestamp":null,
+                            "labels":{
+                                "ingress.k8s.aws/ingress-name":"ing-1",
+                                "ingress.k8s.aws/service-name":"svc-1",
+                                "ingress.k8s.aws/service-port":"80"
+                            }
                         },
                         "spec":{
                             "targetGroupARN":{
@@ -1329,7 +1374,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                         "metadata":{
                             "name":"k8s-ns1-svc1-9889425938",
                             "namespace":"ns-1",
-                            "creationTimestamp":null
+                            "creationTimestamp":null,
+                            "labels":{
+                                "ingress.k8s.aws/ingress-name":"ing-1",
+                                "ingress.k8s.aws/service-name":"svc-1",
+                                "ingress.k8s.aws/service-port":"http"
+                            }
                         },
                         "spec":{
                             "targetGroupARN":{
@@ -1398,13 +1448,21 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 
 			stackMarshaller := deploy.NewDefaultStackMarshaller()
 
+			elbv2TaggingManager := mock_elbv2.NewMockTaggingManager(ctrl)
+			elbv2TaggingManager.EXPECT().ListLoadBalancers(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", clusterName)
+
 			b := &defaultModelBuilder{
 				k8sClient:              k8sClient,
 				eventRecorder:          eventRecorder,
 				ec2Client:              ec2Client,
+				elbv2TaggingManager:    elbv2TaggingManager,
+				trackingProvider:       trackingProvider,
 				vpcID:                  vpcID,
 				clusterName:            clusterName,
+				defaultTargetType:      elbv2model.TargetTypeInstance,
 				annotationParser:       annotationParser,
+				classLoader:            NewDefaultClassLoader(k8sClient),
 				subnetsResolver:        subnetsResolver,
 				certDiscovery:          certDiscovery,
 				authConfigBuilder:      authConfigBuilder,
@@ -1425,3 +1483,163 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_mergeListenPortConfigs_defaultTLSCert(t *testing.T) {
+	ingKey1 := types.NamespacedName{Namespace: "ns-1", Name: "ing-1"}
+	ingKey2 := types.NamespacedName{Namespace: "ns-1", Name: "ing-2"}
+	tests := []struct {
+		name                      string
+		listenPortConfigByIngress map[types.NamespacedName]listenPortConfig
+		wantTLSCerts              []string
+		wantDefaultTLSCert        *string
+		wantErr                   bool
+	}{
+		{
+			name: "default certificate designated by single ingress",
+			listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+				ingKey1: {
+					protocol:       elbv2model.ProtocolHTTPS,
+					tlsCerts:       []string{"cert-1", "cert-2"},
+					defaultTLSCert: awssdk.String("cert-2"),
+				},
+				ingKey2: {
+					protocol: elbv2model.ProtocolHTTPS,
+					tlsCerts: []string{"cert-3"},
+				},
+			},
+			wantTLSCerts:       []string{"cert-1", "cert-2", "cert-3"},
+			wantDefaultTLSCert: awssdk.String("cert-2"),
+		},
+		{
+			name: "same default certificate designated by multiple ingresses",
+			listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+				ingKey1: {
+					protocol:       elbv2model.ProtocolHTTPS,
+					tlsCerts:       []string{"cert-1", "cert-2"},
+					defaultTLSCert: awssdk.String("cert-2"),
+				},
+				ingKey2: {
+					protocol:       elbv2model.ProtocolHTTPS,
+					tlsCerts:       []string{"cert-2", "cert-3"},
+					defaultTLSCert: awssdk.String("cert-2"),
+				},
+			},
+			wantTLSCerts:       []string{"cert-1", "cert-2", "cert-3"},
+			wantDefaultTLSCert: awssdk.String("cert-2"),
+		},
+		{
+			name: "conflicting default certificates designated by multiple ingresses",
+			listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+				ingKey1: {
+					protocol:       elbv2model.ProtocolHTTPS,
+					tlsCerts:       []string{"cert-1", "cert-2"},
+					defaultTLSCert: awssdk.String("cert-1"),
+				},
+				ingKey2: {
+					protocol:       elbv2model.ProtocolHTTPS,
+					tlsCerts:       []string{"cert-2", "cert-3"},
+					defaultTLSCert: awssdk.String("cert-3"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				defaultSSLPolicy: "ELBSecurityPolicy-2016-08",
+			}
+			got, err := task.mergeListenPortConfigs(context.Background(), tt.listenPortConfigByIngress)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "conflicting defaultCertificate")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTLSCerts, got.tlsCerts)
+			assert.Equal(t, tt.wantDefaultTLSCert, got.defaultTLSCert)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_mergeListenPortConfigs_prefixLists(t *testing.T) {
+	ingKey1 := types.NamespacedName{Namespace: "ns-1", Name: "ing-1"}
+	ingKey2 := types.NamespacedName{Namespace: "ns-1", Name: "ing-2"}
+	tests := []struct {
+		name                      string
+		listenPortConfigByIngress map[types.NamespacedName]listenPortConfig
+		wantInboundCIDRv4s        []string
+		wantInboundCIDRv6s        []string
+		wantPrefixListIDs         []string
+		wantErr                   string
+	}{
+		{
+			name: "prefixLists designated by single ingress",
+			listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+				ingKey1: {
+					protocol:      elbv2model.ProtocolHTTP,
+					prefixListIDs: []string{"pl-1a2b3c4d"},
+				},
+				ingKey2: {
+					protocol: elbv2model.ProtocolHTTP,
+				},
+			},
+			wantInboundCIDRv4s: []string{},
+			wantInboundCIDRv6s: []string{},
+			wantPrefixListIDs:  []string{"pl-1a2b3c4d"},
+		},
+		{
+			name: "prefixLists along with inbound-cidrs",
+			listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+				ingKey1: {
+					protocol:       elbv2model.ProtocolHTTP,
+					inboundCIDRv4s: []string{"10.0.0.0/16"},
+					prefixListIDs:  []string{"pl-1a2b3c4d"},
+				},
+			},
+			wantInboundCIDRv4s: []string{"10.0.0.0/16"},
+			wantInboundCIDRv6s: []string{},
+			wantPrefixListIDs:  []string{"pl-1a2b3c4d"},
+		},
+		{
+			name: "neither prefixLists nor inbound-cidrs designated",
+			listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+				ingKey1: {
+					protocol: elbv2model.ProtocolHTTP,
+				},
+			},
+			wantInboundCIDRv4s: []string{"0.0.0.0/0"},
+			wantInboundCIDRv6s: []string{"::/0"},
+			wantPrefixListIDs:  []string{},
+		},
+		{
+			name: "conflicting prefixLists designated by multiple ingresses",
+			listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+				ingKey1: {
+					protocol:      elbv2model.ProtocolHTTP,
+					prefixListIDs: []string{"pl-1a2b3c4d"},
+				},
+				ingKey2: {
+					protocol:      elbv2model.ProtocolHTTP,
+					prefixListIDs: []string{"pl-5e6f7a8b"},
+				},
+			},
+			wantErr: "conflicting security-group-prefix-lists",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{}
+			got, err := task.mergeListenPortConfigs(context.Background(), tt.listenPortConfigByIngress)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantInboundCIDRv4s, got.inboundCIDRv4s)
+			assert.Equal(t, tt.wantInboundCIDRv6s, got.inboundCIDRv6s)
+			assert.Equal(t, tt.wantPrefixListIDs, got.prefixListIDs)
+		})
+	}
+}
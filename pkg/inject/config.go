@@ -3,14 +3,24 @@ package inject
 import "github.com/spf13/pflag"
 
 const (
-	flagEnablePodReadinessGateInject = "enable-pod-readiness-gate-inject"
+	flagEnablePodReadinessGateInject      = "enable-pod-readiness-gate-inject"
+	flagEnableInstanceTargetReadinessGate = "enable-instance-target-readiness-gate"
+	flagReadinessGateHealthyThresholdWait = "readiness-gate-healthy-threshold-wait"
 )
 
 type Config struct {
 	EnablePodReadinessGateInject bool
+	// EnableInstanceTargetReadinessGate enables targetHealth readiness gate for pods behind instance targetType TargetGroupBindings.
+	EnableInstanceTargetReadinessGate bool
+	// ReadinessGateHealthyThresholdWait delays the targetHealth readiness gate until targets stayed healthy for TargetGroup's healthyThreshold.
+	ReadinessGateHealthyThresholdWait bool
 }
 
 func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&cfg.EnablePodReadinessGateInject, flagEnablePodReadinessGateInject, true,
 		`If enabled, targetHealth readiness gate will get injected to the pod spec for the matching endpoint pods`)
+	fs.BoolVar(&cfg.EnableInstanceTargetReadinessGate, flagEnableInstanceTargetReadinessGate, false,
+		`If enabled, targetHealth readiness gate will also get injected for TargetGroupBindings with instance targetType, which reflects the targetHealth of the pod's node`)
+	fs.BoolVar(&cfg.ReadinessGateHealthyThresholdWait, flagReadinessGateHealthyThresholdWait, false,
+		`If enabled, targetHealth readiness gate will only be set to true after the target stayed healthy for the target group's healthy threshold, in addition to the healthy threshold already applied by ELB`)
 }
@@ -67,7 +67,11 @@ func (m *PodReadinessGate) computeTargetHealthReadinessGateConditionTypes(ctx co
 	}
 	var targetHealthCondTypes []corev1.PodConditionType
 	for _, tgb := range tgbList.Items {
-		if tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP {
+		if tgb.Spec.TargetType == nil {
+			continue
+		}
+		if (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP &&
+			!((*tgb.Spec.TargetType) == elbv2api.TargetTypeInstance && m.config.EnableInstanceTargetReadinessGate) {
 			continue
 		}
 
@@ -165,6 +165,30 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 				EnablePodReadinessGateInject: true,
 			},
 		},
+		{
+			name:      "matching tgb with instance targetType and instance target readiness gate enabled",
+			namespace: testNS1,
+			services:  []*corev1.Service{svc1},
+			tgbList:   []*elbv2api.TargetGroupBinding{tgb5},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":    "app-1",
+						"svc":    "svc1",
+						"stable": "none",
+					},
+				},
+			},
+			want: []corev1.PodReadinessGate{
+				{
+					ConditionType: "target-health.elbv2.k8s.aws/tgb-5-l6qw5",
+				},
+			},
+			config: Config{
+				EnablePodReadinessGateInject:      true,
+				EnableInstanceTargetReadinessGate: true,
+			},
+		},
 		{
 			name:      "multiple tgb with ip targetType",
 			namespace: testNS1,
@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sync"
+)
+
+// AnnouncementTracker tracks the announcements made via events for objects, keyed by object and topic,
+// so that an event is only emitted when the announcement of a topic changes.
+// The tracked state is kept in memory, so announcements will be made again after controller restarts.
+type AnnouncementTracker struct {
+	// announcements is the last announcement by topic, by object.
+	announcements map[string]map[string]string
+	// mutex protects announcements
+	mutex sync.Mutex
+}
+
+// NewAnnouncementTracker constructs new AnnouncementTracker.
+func NewAnnouncementTracker() *AnnouncementTracker {
+	return &AnnouncementTracker{
+		announcements: make(map[string]map[string]string),
+	}
+}
+
+// Observe records the announcement of topic for object, and returns whether it differs from the last recorded one, i.e. whether it should be announced.
+func (t *AnnouncementTracker) Observe(objKey string, topic string, announcement string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	announcementByTopic, exists := t.announcements[objKey]
+	if !exists {
+		announcementByTopic = make(map[string]string)
+		t.announcements[objKey] = announcementByTopic
+	}
+	if announced, exists := announcementByTopic[topic]; exists && announced == announcement {
+		return false
+	}
+	announcementByTopic[topic] = announcement
+	return true
+}
+
+// Reset drops the announcement of topic for object, so that it will be announced again once observed.
+func (t *AnnouncementTracker) Reset(objKey string, topic string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.announcements[objKey], topic)
+	if len(t.announcements[objKey]) == 0 {
+		delete(t.announcements, objKey)
+	}
+}
+
+// Retain drops the announcements for object except the ones of topics, so that the dropped ones will be announced again once observed.
+func (t *AnnouncementTracker) Retain(objKey string, topics sets.String) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for topic := range t.announcements[objKey] {
+		if !topics.Has(topic) {
+			delete(t.announcements[objKey], topic)
+		}
+	}
+	if len(t.announcements[objKey]) == 0 {
+		delete(t.announcements, objKey)
+	}
+}
+
+// Forget stops tracking the announcements for object.
+func (t *AnnouncementTracker) Forget(objKey string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.announcements, objKey)
+}
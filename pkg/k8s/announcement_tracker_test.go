@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"testing"
+)
+
+func TestAnnouncementTracker(t *testing.T) {
+	type call struct {
+		objKey       string
+		topic        string
+		announcement string
+		// when specified, the topics to retain instead of observing announcement.
+		retainTopics []string
+		reset        bool
+		forget       bool
+		want         bool
+	}
+	tests := []struct {
+		name  string
+		calls []call
+	}{
+		{
+			name: "announcement is made once",
+			calls: []call{
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: false},
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: false},
+			},
+		},
+		{
+			name: "announcement is made again once changed",
+			calls: []call{
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-2", want: true},
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: true},
+			},
+		},
+		{
+			name: "announcements are tracked by object and topic",
+			calls: []call{
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-2", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", topic: "topic-b", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: false},
+			},
+		},
+		{
+			name: "announcement is made again after reset",
+			calls: []call{
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", topic: "topic-b", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", topic: "topic-a", reset: true},
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", topic: "topic-b", announcement: "msg-1", want: false},
+			},
+		},
+		{
+			name: "announcements of topics not retained are made again",
+			calls: []call{
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", topic: "topic-b", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", retainTopics: []string{"topic-b"}},
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", topic: "topic-b", announcement: "msg-1", want: false},
+			},
+		},
+		{
+			name: "announcements are made again after forget",
+			calls: []call{
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-2", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-1", forget: true},
+				{objKey: "ns/svc-1", topic: "topic-a", announcement: "msg-1", want: true},
+				{objKey: "ns/svc-2", topic: "topic-a", announcement: "msg-1", want: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewAnnouncementTracker()
+			for _, call := range tt.calls {
+				switch {
+				case call.forget:
+					tracker.Forget(call.objKey)
+				case call.reset:
+					tracker.Reset(call.objKey, call.topic)
+				case call.retainTopics != nil:
+					tracker.Retain(call.objKey, sets.NewString(call.retainTopics...))
+				default:
+					got := tracker.Observe(call.objKey, call.topic, call.announcement)
+					assert.Equal(t, call.want, got)
+				}
+			}
+		})
+	}
+}
@@ -8,6 +8,8 @@ const (
 	IngressEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
 	IngressEventReasonFailedBuildModel       = "FailedBuildModel"
 	IngressEventReasonFailedDeployModel      = "FailedDeployModel"
+	IngressEventReasonMissingCertificate     = "MissingCertificate"
+	IngressEventReasonDuplicateRule          = "DuplicateRule"
 	IngressEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
 
 	// Service events
@@ -16,6 +18,11 @@ const (
 	ServiceEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
 	ServiceEventReasonFailedBuildModel       = "FailedBuildModel"
 	ServiceEventReasonFailedDeployModel      = "FailedDeployModel"
+	ServiceEventReasonSingleAZSubnets        = "SingleAZSubnets"
+	ServiceEventReasonEIPAllocationReused    = "EIPAllocationReused"
+	ServiceEventReasonInvalidAnnotation      = "InvalidAnnotation"
+	ServiceEventReasonCrossZoneCost          = "CrossZoneLoadBalancingCost"
+	ServiceEventReasonDiscoveredSubnets      = "DiscoveredSubnets"
 	ServiceEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
 
 	// TargetGroupBinding events
@@ -23,5 +30,19 @@ const (
 	TargetGroupBindingEventReasonFailedRemoveFinalizer  = "FailedRemoveFinalizer"
 	TargetGroupBindingEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
 	TargetGroupBindingEventReasonFailedCleanup          = "FailedCleanup"
+	TargetGroupBindingEventReasonFailedRegisterTargets  = "FailedRegisterTargets"
+	TargetGroupBindingEventReasonConflictingTargetGroup = "ConflictingTargetGroup"
+	TargetGroupBindingEventReasonUnmanagedSecurityGroup = "UnmanagedSecurityGroup"
+	TargetGroupBindingEventReasonDrainingTargets        = "DrainingTargets"
 	TargetGroupBindingEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+
+	// PodTargetGroupBinding events
+	PodTargetGroupBindingEventReasonInvalidSpec            = "InvalidSpec"
+	PodTargetGroupBindingEventReasonConflictingTargetGroup = "ConflictingTargetGroup"
+	PodTargetGroupBindingEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
+	PodTargetGroupBindingEventReasonFailedRemoveFinalizer  = "FailedRemoveFinalizer"
+	PodTargetGroupBindingEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
+	PodTargetGroupBindingEventReasonFailedCleanup          = "FailedCleanup"
+	PodTargetGroupBindingEventReasonFailedRegisterTargets  = "FailedRegisterTargets"
+	PodTargetGroupBindingEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
 )
@@ -32,3 +32,15 @@ func ExtractNodeInstanceID(node *corev1.Node) (string, error) {
 	p := strings.Split(providerID, "/")
 	return p[len(p)-1], nil
 }
+
+// GetNodeAvailabilityZone returns the availabilityZone of node based on well-known topology labels.
+// returns empty string if node's availabilityZone is unknown.
+func GetNodeAvailabilityZone(node *corev1.Node) string {
+	if zone, ok := node.Labels[corev1.LabelZoneFailureDomainStable]; ok {
+		return zone
+	}
+	if zone, ok := node.Labels[corev1.LabelZoneFailureDomain]; ok {
+		return zone
+	}
+	return ""
+}
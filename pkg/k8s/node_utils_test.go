@@ -176,3 +176,55 @@ func TestExtractNodeInstanceID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNodeAvailabilityZone(t *testing.T) {
+	type args struct {
+		node *corev1.Node
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "node with stable zone label",
+			args: args{
+				node: &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"topology.kubernetes.io/zone":            "us-west-2a",
+							"failure-domain.beta.kubernetes.io/zone": "us-west-2b",
+						},
+					},
+				},
+			},
+			want: "us-west-2a",
+		},
+		{
+			name: "node with beta zone label",
+			args: args{
+				node: &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"failure-domain.beta.kubernetes.io/zone": "us-west-2b",
+						},
+					},
+				},
+			},
+			want: "us-west-2b",
+		},
+		{
+			name: "node without zone label",
+			args: args{
+				node: &corev1.Node{},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetNodeAvailabilityZone(tt.args.node)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -327,6 +327,7 @@ func Test_buildPodInfo(t *testing.T) {
 						UID:       "pod-uuid",
 					},
 					Spec: corev1.PodSpec{
+						NodeName: "node-1",
 						Containers: []corev1.Container{
 							{
 								Ports: []corev1.ContainerPort{
@@ -408,7 +409,8 @@ func Test_buildPodInfo(t *testing.T) {
 						Status: corev1.ConditionTrue,
 					},
 				},
-				PodIP: "192.168.1.1",
+				PodIP:    "192.168.1.1",
+				NodeName: "node-1",
 			},
 		},
 		{
@@ -0,0 +1,84 @@
+package ec2
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+)
+
+var _ core.Resource = &VPCEndpointService{}
+
+// VPCEndpointService represents a EC2 VPC endpoint service configuration.
+type VPCEndpointService struct {
+	core.ResourceMeta `json:"-"`
+
+	//  desired state of VPCEndpointService
+	Spec VPCEndpointServiceSpec `json:"spec"`
+
+	// observed state of VPCEndpointService
+	Status *VPCEndpointServiceStatus `json:"status,omitempty"`
+}
+
+// NewVPCEndpointService constructs new VPCEndpointService resource.
+func NewVPCEndpointService(stack core.Stack, id string, spec VPCEndpointServiceSpec) *VPCEndpointService {
+	es := &VPCEndpointService{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::VPCEndpointService", id),
+		Spec:         spec,
+		Status:       nil,
+	}
+	stack.AddResource(es)
+	es.registerDependencies(stack)
+	return es
+}
+
+// SetStatus sets the VPCEndpointService's status
+func (es *VPCEndpointService) SetStatus(status VPCEndpointServiceStatus) {
+	es.Status = &status
+}
+
+// ServiceID returns a token for this VPCEndpointService's serviceID.
+func (es *VPCEndpointService) ServiceID() core.StringToken {
+	return core.NewResourceFieldStringToken(es, "status/serviceID",
+		func(ctx context.Context, res core.Resource, fieldPath string) (s string, err error) {
+			es := res.(*VPCEndpointService)
+			if es.Status == nil {
+				return "", errors.Errorf("VPCEndpointService is not fulfilled yet: %v", es.ID())
+			}
+			return es.Status.ServiceID, nil
+		},
+	)
+}
+
+// register dependencies for VPCEndpointService.
+func (es *VPCEndpointService) registerDependencies(stack core.Stack) {
+	for _, lbARN := range es.Spec.NetworkLoadBalancerARNs {
+		for _, dep := range lbARN.Dependencies() {
+			stack.AddDependency(dep, es)
+		}
+	}
+}
+
+// VPCEndpointServiceSpec defines the desired state of VPCEndpointService
+type VPCEndpointServiceSpec struct {
+	// Whether requests from service consumers to create an endpoint to the service must be accepted.
+	AcceptanceRequired bool `json:"acceptanceRequired"`
+
+	// The Network Load Balancers for the service.
+	NetworkLoadBalancerARNs []core.StringToken `json:"networkLoadBalancerARNs"`
+
+	// The ARNs of principals allowed to discover the service.
+	// +optional
+	AllowedPrincipals []string `json:"allowedPrincipals,omitempty"`
+
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// VPCEndpointServiceStatus defines the observed state of VPCEndpointService
+type VPCEndpointServiceStatus struct {
+	// The ID of the endpoint service.
+	ServiceID string `json:"serviceID"`
+
+	// The name of the endpoint service.
+	ServiceName string `json:"serviceName"`
+}
@@ -79,6 +79,16 @@ const (
 	LoadBalancerTypeNetwork     LoadBalancerType = "network"
 )
 
+// LoadBalancerState is the provisioning state of load balancer.
+type LoadBalancerState string
+
+const (
+	LoadBalancerStateActive         LoadBalancerState = "active"
+	LoadBalancerStateProvisioning   LoadBalancerState = "provisioning"
+	LoadBalancerStateActiveImpaired LoadBalancerState = "active_impaired"
+	LoadBalancerStateFailed         LoadBalancerState = "failed"
+)
+
 type IPAddressType string
 
 const (
@@ -102,6 +112,9 @@ type SubnetMapping struct {
 	// [Network Load Balancers] The private IPv4 address for an internal load balancer.
 	PrivateIPv4Address *string `json:"privateIPv4Address,omitempty"`
 
+	// [Network Load Balancers] The IPv6 address for a dualstack load balancer.
+	IPv6Address *string `json:"ipv6Address,omitempty"`
+
 	// The ID of the subnet.
 	SubnetID string `json:"subnetID"`
 }
@@ -140,6 +153,11 @@ type LoadBalancerSpec struct {
 	// +optional
 	SecurityGroups []core.StringToken `json:"securityGroups,omitempty"`
 
+	// [Network Load Balancers] Whether to evaluate the inbound rules of security groups for traffic sent to the load balancer through AWS PrivateLink.
+	// The valid values are on and off.
+	// +optional
+	EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic *string `json:"enforceSecurityGroupInboundRulesOnPrivateLinkTraffic,omitempty"`
+
 	// The load balancer attributes.
 	// +optional
 	LoadBalancerAttributes []LoadBalancerAttribute `json:"loadBalancerAttributes,omitempty"`
@@ -156,4 +174,12 @@ type LoadBalancerStatus struct {
 
 	// The public DNS name of the load balancer.
 	DNSName string `json:"dnsName"`
+
+	// The provisioning state of the load balancer.
+	// +optional
+	State LoadBalancerState `json:"state,omitempty"`
+
+	// Whether cross-zone load balancing is enabled on the load balancer, as applied.
+	// +optional
+	CrossZoneLoadBalancingEnabled *bool `json:"crossZoneLoadBalancingEnabled,omitempty"`
 }
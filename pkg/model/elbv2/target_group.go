@@ -55,6 +55,14 @@ type TargetType string
 const (
 	TargetTypeInstance TargetType = "instance"
 	TargetTypeIP       TargetType = "ip"
+	TargetTypeLambda   TargetType = "lambda"
+)
+
+type TargetGroupIPAddressType string
+
+const (
+	TargetGroupIPAddressTypeIPv4 TargetGroupIPAddressType = "ipv4"
+	TargetGroupIPAddressTypeIPv6 TargetGroupIPAddressType = "ipv6"
 )
 
 // Information to use when checking for a successful response from a target.
@@ -119,15 +127,22 @@ type TargetGroupSpec struct {
 	TargetType TargetType `json:"targetType"`
 
 	// The port on which the targets receive traffic.
+	// Not applicable for targetType lambda.
 	Port int64 `json:"port"`
 
 	// The protocol to use for routing traffic to the targets.
+	// Not applicable for targetType lambda.
 	Protocol Protocol `json:"protocol"`
 
 	// The target group protocol version.
 	// +optional
 	ProtocolVersion *ProtocolVersion `json:"protocolVersion,omitempty"`
 
+	// The IP address type of the target group.
+	// If unspecified, it's inherited from the load balancer.
+	// +optional
+	IPAddressType *TargetGroupIPAddressType `json:"ipAddressType,omitempty"`
+
 	// Configuration for TargetGroup's HealthCheck.
 	// +optional
 	HealthCheckConfig *TargetGroupHealthCheckConfig `json:"healthCheckConfig,omitempty"`
@@ -136,9 +151,17 @@ type TargetGroupSpec struct {
 	// +optional
 	TargetGroupAttributes []TargetGroupAttribute `json:"targetGroupAttributes,omitempty"`
 
+	// [Lambda target groups] The Amazon Resource Name (ARN) of the Lambda function registered as target.
+	// +optional
+	LambdaFunctionARN *string `json:"lambdaFunctionARN,omitempty"`
+
 	// The tags.
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
+
+	// Whether the target group can be recreated when its immutable fields change.
+	// +optional
+	RecreationConfirmed bool `json:"recreationConfirmed,omitempty"`
 }
 
 // TargetGroupStatus defines the observed state of TargetGroup
@@ -62,6 +62,11 @@ type NetworkingPeer struct {
 	// If specified, none of the other fields can be set.
 	// +optional
 	SecurityGroup *SecurityGroup `json:"securityGroup,omitempty"`
+
+	// PrefixList defines a managed PrefixList peer.
+	// If specified, none of the other fields can be set.
+	// +optional
+	PrefixList *elbv2api.PrefixList `json:"prefixList,omitempty"`
 }
 
 type NetworkingIngressRule struct {
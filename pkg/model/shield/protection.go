@@ -30,4 +30,8 @@ func (p *Protection) registerDependencies(stack core.Stack) {
 // ProtectionSpec defines the desired state of Protection.
 type ProtectionSpec struct {
 	ResourceARN core.StringToken `json:"resourceARN"`
+
+	// HealthCheckID is the Route53 health check for health-based detection.
+	// +optional
+	HealthCheckID string `json:"healthCheckID,omitempty"`
 }
@@ -34,4 +34,14 @@ func (a *WebACLAssociation) registerDependencies(stack core.Stack) {
 type WebACLAssociationSpec struct {
 	WebACLARN   string           `json:"webACLARN"`
 	ResourceARN core.StringToken `json:"resourceARN"`
+
+	// LoggingDestinationARN is the logging destination for webACL.
+	// logging configuration of webACL is left untouched if empty, unless it's configured by controller previously.
+	// +optional
+	LoggingDestinationARN string `json:"loggingDestinationARN,omitempty"`
+
+	// AppliedLoggingDestinationARN is the logging destination configured by controller previously, as recorded on the LoadBalancer.
+	// logging configuration of webACL is deleted if LoggingDestinationARN is empty and it still logs to this destination.
+	// +optional
+	AppliedLoggingDestinationARN string `json:"appliedLoggingDestinationARN,omitempty"`
 }
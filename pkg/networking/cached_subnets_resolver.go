@@ -0,0 +1,94 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewCachedSubnetsResolver constructs new cachedSubnetsResolver.
+func NewCachedSubnetsResolver(subnetsResolver SubnetsResolver, cacheTTL time.Duration) *cachedSubnetsResolver {
+	return &cachedSubnetsResolver{
+		subnetsResolver: subnetsResolver,
+		subnetsCache:    cache.NewExpiring(),
+		subnetsCacheTTL: cacheTTL,
+	}
+}
+
+var _ SubnetsResolver = &cachedSubnetsResolver{}
+
+// cachedSubnetsResolver is a SubnetsResolver that caches the subnets resolved via name or ID for a short TTL,
+// so that reconciles of many LoadBalancers referencing the same subnets won't exhaust the EC2 API quota.
+type cachedSubnetsResolver struct {
+	subnetsResolver SubnetsResolver
+
+	// subnetsCache caches resolved subnets, keyed by the normalized subnet name or IDs and resolve options.
+	subnetsCache      *cache.Expiring
+	subnetsCacheMutex sync.Mutex
+	subnetsCacheTTL   time.Duration
+}
+
+func (r *cachedSubnetsResolver) ResolveViaDiscovery(ctx context.Context, opts ...SubnetsResolveOption) ([]*ec2sdk.Subnet, error) {
+	subnets, err := r.subnetsResolver.ResolveViaDiscovery(ctx, opts...)
+	if err != nil {
+		r.invalidateSubnetsCache()
+		return nil, err
+	}
+	return subnets, nil
+}
+
+func (r *cachedSubnetsResolver) ResolveViaNameOrIDSlice(ctx context.Context, subnetNameOrIDs []string, opts ...SubnetsResolveOption) ([]*ec2sdk.Subnet, error) {
+	cacheKey := buildSubnetsCacheKey(subnetNameOrIDs, opts)
+	if subnets, exists := r.fetchSubnetsFromCache(cacheKey); exists {
+		return subnets, nil
+	}
+	subnets, err := r.subnetsResolver.ResolveViaNameOrIDSlice(ctx, subnetNameOrIDs, opts...)
+	if err != nil {
+		r.invalidateSubnetsCache()
+		return nil, err
+	}
+	r.saveSubnetsToCache(cacheKey, subnets)
+	return subnets, nil
+}
+
+func (r *cachedSubnetsResolver) fetchSubnetsFromCache(cacheKey string) ([]*ec2sdk.Subnet, bool) {
+	r.subnetsCacheMutex.Lock()
+	defer r.subnetsCacheMutex.Unlock()
+
+	if rawCacheItem, exists := r.subnetsCache.Get(cacheKey); exists {
+		return rawCacheItem.([]*ec2sdk.Subnet), true
+	}
+	return nil, false
+}
+
+func (r *cachedSubnetsResolver) saveSubnetsToCache(cacheKey string, subnets []*ec2sdk.Subnet) {
+	r.subnetsCacheMutex.Lock()
+	defer r.subnetsCacheMutex.Unlock()
+
+	r.subnetsCache.Set(cacheKey, subnets, r.subnetsCacheTTL)
+}
+
+// invalidateSubnetsCache drops all cached subnets, since a resolve error might indicate changes of subnet topology.
+func (r *cachedSubnetsResolver) invalidateSubnetsCache() {
+	r.subnetsCacheMutex.Lock()
+	defer r.subnetsCacheMutex.Unlock()
+
+	r.subnetsCache = cache.NewExpiring()
+}
+
+// buildSubnetsCacheKey builds the cache key for subnets resolved via name or ID.
+// the subnet name or IDs are sorted, so that the same subnets referenced in different order share the cache entry.
+func buildSubnetsCacheKey(subnetNameOrIDs []string, opts []SubnetsResolveOption) string {
+	resolveOpts := defaultSubnetsResolveOptions()
+	resolveOpts.ApplyOptions(opts)
+
+	sortedNameOrIDs := append([]string(nil), subnetNameOrIDs...)
+	sort.Strings(sortedNameOrIDs)
+	return fmt.Sprintf("%v/%v/%v/%v", resolveOpts.LBType, resolveOpts.LBScheme, resolveOpts.PreferAvailableIPAddresses,
+		strings.Join(sortedNameOrIDs, ","))
+}
@@ -0,0 +1,187 @@
+package networking
+
+import (
+	"context"
+	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_cachedSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
+	type describeSubnetsAsListCall struct {
+		input  *ec2sdk.DescribeSubnetsInput
+		output []*ec2sdk.Subnet
+		err    error
+	}
+	type resolveCall struct {
+		subnetNameOrIDs []string
+		want            []*ec2sdk.Subnet
+		wantErr         error
+	}
+	subnet1 := &ec2sdk.Subnet{
+		SubnetId:         awssdk.String("subnet-1"),
+		AvailabilityZone: awssdk.String("us-west-2a"),
+		VpcId:            awssdk.String("vpc-1"),
+	}
+	subnet2 := &ec2sdk.Subnet{
+		SubnetId:         awssdk.String("subnet-2"),
+		AvailabilityZone: awssdk.String("us-west-2b"),
+		VpcId:            awssdk.String("vpc-1"),
+	}
+	tests := []struct {
+		name                       string
+		describeSubnetsAsListCalls []describeSubnetsAsListCall
+		resolveCalls               []resolveCall
+	}{
+		{
+			name: "cache hit within TTL avoids second EC2 call",
+			describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+				{
+					input: &ec2sdk.DescribeSubnetsInput{
+						SubnetIds: awssdk.StringSlice([]string{"subnet-1", "subnet-2"}),
+					},
+					output: []*ec2sdk.Subnet{subnet1, subnet2},
+				},
+			},
+			resolveCalls: []resolveCall{
+				{
+					subnetNameOrIDs: []string{"subnet-1", "subnet-2"},
+					want:            []*ec2sdk.Subnet{subnet1, subnet2},
+				},
+				{
+					subnetNameOrIDs: []string{"subnet-2", "subnet-1"},
+					want:            []*ec2sdk.Subnet{subnet1, subnet2},
+				},
+			},
+		},
+		{
+			name: "different subnets are cached separately",
+			describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+				{
+					input: &ec2sdk.DescribeSubnetsInput{
+						SubnetIds: awssdk.StringSlice([]string{"subnet-1"}),
+					},
+					output: []*ec2sdk.Subnet{subnet1},
+				},
+				{
+					input: &ec2sdk.DescribeSubnetsInput{
+						SubnetIds: awssdk.StringSlice([]string{"subnet-2"}),
+					},
+					output: []*ec2sdk.Subnet{subnet2},
+				},
+			},
+			resolveCalls: []resolveCall{
+				{
+					subnetNameOrIDs: []string{"subnet-1"},
+					want:            []*ec2sdk.Subnet{subnet1},
+				},
+				{
+					subnetNameOrIDs: []string{"subnet-2"},
+					want:            []*ec2sdk.Subnet{subnet2},
+				},
+				{
+					subnetNameOrIDs: []string{"subnet-1"},
+					want:            []*ec2sdk.Subnet{subnet1},
+				},
+			},
+		},
+		{
+			name: "resolve error invalidates cache",
+			describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+				{
+					input: &ec2sdk.DescribeSubnetsInput{
+						SubnetIds: awssdk.StringSlice([]string{"subnet-1"}),
+					},
+					output: []*ec2sdk.Subnet{subnet1},
+				},
+				{
+					input: &ec2sdk.DescribeSubnetsInput{
+						SubnetIds: awssdk.StringSlice([]string{"subnet-2"}),
+					},
+					err: errors.New("InvalidSubnetID.NotFound"),
+				},
+				{
+					input: &ec2sdk.DescribeSubnetsInput{
+						SubnetIds: awssdk.StringSlice([]string{"subnet-1"}),
+					},
+					output: []*ec2sdk.Subnet{subnet1},
+				},
+			},
+			resolveCalls: []resolveCall{
+				{
+					subnetNameOrIDs: []string{"subnet-1"},
+					want:            []*ec2sdk.Subnet{subnet1},
+				},
+				{
+					subnetNameOrIDs: []string{"subnet-2"},
+					wantErr:         errors.New("InvalidSubnetID.NotFound"),
+				},
+				{
+					subnetNameOrIDs: []string{"subnet-1"},
+					want:            []*ec2sdk.Subnet{subnet1},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.describeSubnetsAsListCalls {
+				ec2Client.EXPECT().DescribeSubnetsAsList(gomock.Any(), call.input).Return(call.output, call.err)
+			}
+			subnetsResolver := NewDefaultSubnetsResolver(ec2Client, "vpc-1", "cluster-1", &log.NullLogger{})
+			r := NewCachedSubnetsResolver(subnetsResolver, time.Minute)
+			for _, call := range tt.resolveCalls {
+				got, err := r.ResolveViaNameOrIDSlice(context.Background(), call.subnetNameOrIDs,
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork))
+				if call.wantErr != nil {
+					assert.EqualError(t, err, call.wantErr.Error())
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, call.want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_buildSubnetsCacheKey(t *testing.T) {
+	tests := []struct {
+		name            string
+		subnetNameOrIDs []string
+		opts            []SubnetsResolveOption
+		want            string
+	}{
+		{
+			name:            "default options",
+			subnetNameOrIDs: []string{"subnet-2", "my-subnet", "subnet-1"},
+			want:            "application/internet-facing/false/my-subnet,subnet-1,subnet-2",
+		},
+		{
+			name:            "with options",
+			subnetNameOrIDs: []string{"subnet-1"},
+			opts: []SubnetsResolveOption{
+				WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
+				WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				WithSubnetsResolvePreferAvailableIPAddresses(true),
+			},
+			want: "network/internal/true/subnet-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildSubnetsCacheKey(tt.subnetNameOrIDs, tt.opts)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package networking
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"strings"
+)
+
+// SecurityGroupResolver is responsible for resolve EC2 SecurityGroups for Load Balancers.
+type SecurityGroupResolver interface {
+	// ResolveViaNameOrID resolves securityGroup IDs using securityGroup name or ID.
+	// securityGroups referenced by name are looked up via the "Name" tag within cluster VPC.
+	ResolveViaNameOrID(ctx context.Context, sgNameOrIDs []string) ([]string, error)
+}
+
+// NewDefaultSecurityGroupResolver constructs new defaultSecurityGroupResolver.
+func NewDefaultSecurityGroupResolver(ec2Client services.EC2, vpcID string) *defaultSecurityGroupResolver {
+	return &defaultSecurityGroupResolver{
+		ec2Client: ec2Client,
+		vpcID:     vpcID,
+	}
+}
+
+var _ SecurityGroupResolver = &defaultSecurityGroupResolver{}
+
+// default implementation for SecurityGroupResolver.
+type defaultSecurityGroupResolver struct {
+	ec2Client services.EC2
+	vpcID     string
+}
+
+func (r *defaultSecurityGroupResolver) ResolveViaNameOrID(ctx context.Context, sgNameOrIDs []string) ([]string, error) {
+	var sgIDs []string
+	var sgNames []string
+	for _, nameOrID := range sgNameOrIDs {
+		if strings.HasPrefix(nameOrID, "sg-") {
+			sgIDs = append(sgIDs, nameOrID)
+		} else {
+			sgNames = append(sgNames, nameOrID)
+		}
+	}
+	var resolvedSGs []*ec2sdk.SecurityGroup
+	if len(sgIDs) > 0 {
+		req := &ec2sdk.DescribeSecurityGroupsInput{
+			GroupIds: awssdk.StringSlice(sgIDs),
+		}
+		sgs, err := r.ec2Client.DescribeSecurityGroupsAsList(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		resolvedSGs = append(resolvedSGs, sgs...)
+	}
+	if len(sgNames) > 0 {
+		req := &ec2sdk.DescribeSecurityGroupsInput{
+			Filters: []*ec2sdk.Filter{
+				{
+					Name:   awssdk.String("tag:Name"),
+					Values: awssdk.StringSlice(sgNames),
+				},
+				{
+					Name:   awssdk.String("vpc-id"),
+					Values: awssdk.StringSlice([]string{r.vpcID}),
+				},
+			},
+		}
+		sgs, err := r.ec2Client.DescribeSecurityGroupsAsList(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		resolvedSGs = append(resolvedSGs, sgs...)
+	}
+	resolvedSGIDs := make([]string, 0, len(resolvedSGs))
+	for _, sg := range resolvedSGs {
+		resolvedSGIDs = append(resolvedSGIDs, awssdk.StringValue(sg.GroupId))
+	}
+	if len(resolvedSGIDs) != len(sgNameOrIDs) {
+		return nil, errors.Errorf("couldn't found all securityGroups, nameOrIDs: %v, found: %v", sgNameOrIDs, resolvedSGIDs)
+	}
+	return resolvedSGIDs, nil
+}
@@ -0,0 +1,110 @@
+package networking
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"testing"
+)
+
+func Test_defaultSecurityGroupResolver_ResolveViaNameOrID(t *testing.T) {
+	type describeSecurityGroupsAsListCall struct {
+		req  *ec2sdk.DescribeSecurityGroupsInput
+		resp []*ec2sdk.SecurityGroup
+		err  error
+	}
+	tests := []struct {
+		name                              string
+		describeSecurityGroupsAsListCalls []describeSecurityGroupsAsListCall
+		sgNameOrIDs                       []string
+		want                              []string
+		wantErr                           error
+	}{
+		{
+			name: "resolve via ID and name",
+			describeSecurityGroupsAsListCalls: []describeSecurityGroupsAsListCall{
+				{
+					req: &ec2sdk.DescribeSecurityGroupsInput{
+						GroupIds: awssdk.StringSlice([]string{"sg-1"}),
+					},
+					resp: []*ec2sdk.SecurityGroup{{GroupId: awssdk.String("sg-1")}},
+				},
+				{
+					req: &ec2sdk.DescribeSecurityGroupsInput{
+						Filters: []*ec2sdk.Filter{
+							{
+								Name:   awssdk.String("tag:Name"),
+								Values: awssdk.StringSlice([]string{"my-sg"}),
+							},
+							{
+								Name:   awssdk.String("vpc-id"),
+								Values: awssdk.StringSlice([]string{"vpc-1"}),
+							},
+						},
+					},
+					resp: []*ec2sdk.SecurityGroup{{GroupId: awssdk.String("sg-2")}},
+				},
+			},
+			sgNameOrIDs: []string{"sg-1", "my-sg"},
+			want:        []string{"sg-1", "sg-2"},
+		},
+		{
+			name: "securityGroup not found",
+			describeSecurityGroupsAsListCalls: []describeSecurityGroupsAsListCall{
+				{
+					req: &ec2sdk.DescribeSecurityGroupsInput{
+						Filters: []*ec2sdk.Filter{
+							{
+								Name:   awssdk.String("tag:Name"),
+								Values: awssdk.StringSlice([]string{"my-sg"}),
+							},
+							{
+								Name:   awssdk.String("vpc-id"),
+								Values: awssdk.StringSlice([]string{"vpc-1"}),
+							},
+						},
+					},
+					resp: nil,
+				},
+			},
+			sgNameOrIDs: []string{"my-sg"},
+			wantErr:     errors.New("couldn't found all securityGroups, nameOrIDs: [my-sg], found: []"),
+		},
+		{
+			name: "describe securityGroups failed",
+			describeSecurityGroupsAsListCalls: []describeSecurityGroupsAsListCall{
+				{
+					req: &ec2sdk.DescribeSecurityGroupsInput{
+						GroupIds: awssdk.StringSlice([]string{"sg-1"}),
+					},
+					err: errors.New("some AWS API error"),
+				},
+			},
+			sgNameOrIDs: []string{"sg-1"},
+			wantErr:     errors.New("some AWS API error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.describeSecurityGroupsAsListCalls {
+				ec2Client.EXPECT().DescribeSecurityGroupsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			r := NewDefaultSecurityGroupResolver(ec2Client, "vpc-1")
+			got, err := r.ResolveViaNameOrID(context.Background(), tt.sgNameOrIDs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -34,6 +34,13 @@ type SubnetsResolveOptions struct {
 	// The Load Balancer Scheme.
 	// By default, it's internet-facing.
 	LBScheme elbv2model.LoadBalancerScheme
+	// Whether to prefer the subnet with most available IP addresses when multiple subnets are discovered for specific AZ.
+	// By default, it's false and the subnet is chosen based on the lexical order of subnetID.
+	PreferAvailableIPAddresses bool
+	// The subnets currently used by the Load Balancer.
+	// When multiple subnets are discovered for specific AZ, the one currently in use is kept as long as it's still discovered,
+	// so that subnets don't churn as available IP addresses change.
+	PreferredSubnetIDs []string
 }
 
 // ApplyOptions applies slice of SubnetsResolveOption.
@@ -67,6 +74,20 @@ func WithSubnetsResolveLBScheme(lbScheme elbv2model.LoadBalancerScheme) SubnetsR
 	}
 }
 
+// WithSubnetsResolvePreferAvailableIPAddresses generates a option that configures PreferAvailableIPAddresses.
+func WithSubnetsResolvePreferAvailableIPAddresses(preferAvailableIPAddresses bool) SubnetsResolveOption {
+	return func(opts *SubnetsResolveOptions) {
+		opts.PreferAvailableIPAddresses = preferAvailableIPAddresses
+	}
+}
+
+// WithSubnetsResolvePreferredSubnetIDs generates a option that configures PreferredSubnetIDs.
+func WithSubnetsResolvePreferredSubnetIDs(subnetIDs []string) SubnetsResolveOption {
+	return func(opts *SubnetsResolveOptions) {
+		opts.PreferredSubnetIDs = subnetIDs
+	}
+}
+
 // SubnetsResolver is responsible for resolve EC2 Subnets for Load Balancers.
 type SubnetsResolver interface {
 	// ResolveViaDiscovery resolve subnets by auto discover matching subnets.
@@ -74,7 +95,9 @@ type SubnetsResolver interface {
 	// Additionally,
 	//   * for internet-facing Load Balancer, "kubernetes.io/role/elb" tag must presents.
 	//   * for internal Load Balancer, "kubernetes.io/role/internal-elb" tag must presents.
-	// If multiple subnets are found for specific AZ, one subnet is chosen based on the lexical order of subnetID.
+	// If multiple subnets are found for specific AZ, the subnet within PreferredSubnetIDs is chosen,
+	// otherwise one subnet is chosen based on the lexical order of subnetID,
+	// or the subnet with most available IP addresses is chosen if PreferAvailableIPAddresses is set.
 	ResolveViaDiscovery(ctx context.Context, opts ...SubnetsResolveOption) ([]*ec2sdk.Subnet, error)
 
 	// ResolveViaNameOrIDSlice resolve subnets using subnet name or ID.
@@ -132,6 +155,7 @@ func (r *defaultSubnetsResolver) ResolveViaDiscovery(ctx context.Context, opts .
 	if err != nil {
 		return nil, err
 	}
+	preferredSubnetIDs := sets.NewString(resolveOpts.PreferredSubnetIDs...)
 	subnetsByAZ := mapSDKSubnetsByAZ(subnets)
 	chosenSubnets := make([]*ec2sdk.Subnet, 0, len(subnetsByAZ))
 	for az, subnets := range subnetsByAZ {
@@ -139,6 +163,18 @@ func (r *defaultSubnetsResolver) ResolveViaDiscovery(ctx context.Context, opts .
 			chosenSubnets = append(chosenSubnets, subnets[0])
 		} else if len(subnets) > 1 {
 			sort.Slice(subnets, func(i, j int) bool {
+				preferredI := preferredSubnetIDs.Has(awssdk.StringValue(subnets[i].SubnetId))
+				preferredJ := preferredSubnetIDs.Has(awssdk.StringValue(subnets[j].SubnetId))
+				if preferredI != preferredJ {
+					return preferredI
+				}
+				if resolveOpts.PreferAvailableIPAddresses {
+					availableIPsI := awssdk.Int64Value(subnets[i].AvailableIpAddressCount)
+					availableIPsJ := awssdk.Int64Value(subnets[j].AvailableIpAddressCount)
+					if availableIPsI != availableIPsJ {
+						return availableIPsI > availableIPsJ
+					}
+				}
 				return awssdk.StringValue(subnets[i].SubnetId) < awssdk.StringValue(subnets[j].SubnetId)
 			})
 			r.logger.Info("multiple subnet in the same AvailabilityZone", "AvailabilityZone", az,
@@ -224,18 +260,25 @@ func (r *defaultSubnetsResolver) ResolveViaNameOrIDSlice(ctx context.Context, su
 }
 
 // validateSDKSubnetsAZExclusivity validates subnets belong to different AZs.
+// all AZs with conflicting subnets are reported, ordered by AZ name.
 // subnets passed-in must be non-empty
 func (r *defaultSubnetsResolver) validateSubnetsAZExclusivity(subnets []*ec2sdk.Subnet) error {
 	subnetsByAZ := mapSDKSubnetsByAZ(subnets)
-	for az, subnets := range subnetsByAZ {
-		if len(subnets) > 1 {
-			subnetIDs := make([]string, 0, len(subnets))
-			for _, subnet := range subnets {
+	var azConflicts []string
+	for _, az := range sets.StringKeySet(subnetsByAZ).List() {
+		azSubnets := subnetsByAZ[az]
+		if len(azSubnets) > 1 {
+			subnetIDs := make([]string, 0, len(azSubnets))
+			for _, subnet := range azSubnets {
 				subnetIDs = append(subnetIDs, awssdk.StringValue(subnet.SubnetId))
 			}
-			return errors.Errorf("multiple subnets in same Availability Zone %v: %v", az, subnetIDs)
+			sort.Strings(subnetIDs)
+			azConflicts = append(azConflicts, fmt.Sprintf("%v: %v", az, subnetIDs))
 		}
 	}
+	if len(azConflicts) != 0 {
+		return errors.Errorf("multiple subnets in same Availability Zone %v", strings.Join(azConflicts, ", "))
+	}
 	return nil
 }
 
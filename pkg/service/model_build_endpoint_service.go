@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"strings"
+)
+
+const (
+	resourceIDEndpointService = "EndpointService"
+
+	endpointServiceAllowAllPrincipals = "*"
+)
+
+func (t *defaultModelBuildTask) buildEndpointService(ctx context.Context, scheme elbv2model.LoadBalancerScheme) error {
+	enabled := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixEndpointServiceEnabled, &enabled, t.service.Annotations); err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+	if scheme != elbv2model.LoadBalancerSchemeInternal {
+		return t.invalidAnnotationError(annotations.SvcLBSuffixEndpointServiceEnabled, errors.Errorf("endpoint service is only supported for internal LoadBalancer, got scheme: %v", scheme))
+	}
+	spec, err := t.buildEndpointServiceSpec(ctx)
+	if err != nil {
+		return err
+	}
+	_ = ec2model.NewVPCEndpointService(t.stack, resourceIDEndpointService, spec)
+	return nil
+}
+
+func (t *defaultModelBuildTask) buildEndpointServiceSpec(ctx context.Context) (ec2model.VPCEndpointServiceSpec, error) {
+	acceptanceRequired := t.defaultEndpointServiceAcceptanceRequired
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixEndpointServiceAcceptance, &acceptanceRequired, t.service.Annotations); err != nil {
+		return ec2model.VPCEndpointServiceSpec{}, err
+	}
+	var allowedPrincipals []string
+	t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixEndpointServicePrincipals, &allowedPrincipals, t.service.Annotations)
+	for _, principal := range allowedPrincipals {
+		if err := validateEndpointServiceAllowedPrincipal(principal); err != nil {
+			return ec2model.VPCEndpointServiceSpec{}, t.invalidAnnotationError(annotations.SvcLBSuffixEndpointServicePrincipals, err)
+		}
+	}
+	tags, err := t.buildAdditionalResourceTags(ctx)
+	if err != nil {
+		return ec2model.VPCEndpointServiceSpec{}, err
+	}
+	return ec2model.VPCEndpointServiceSpec{
+		AcceptanceRequired:      acceptanceRequired,
+		NetworkLoadBalancerARNs: []core.StringToken{t.loadBalancer.LoadBalancerARN()},
+		AllowedPrincipals:       allowedPrincipals,
+		Tags:                    tags,
+	}, nil
+}
+
+// validateEndpointServiceAllowedPrincipal validates the principal allowed to discover the endpoint service.
+// the principal must be "*" or the ARN of an IAM account root, user or role.
+func validateEndpointServiceAllowedPrincipal(principal string) error {
+	if principal == endpointServiceAllowAllPrincipals {
+		return nil
+	}
+	parsedARN, err := arn.Parse(principal)
+	if err != nil {
+		return errors.Wrapf(err, "invalid endpoint service allowed principal: %v", principal)
+	}
+	if parsedARN.Service != "iam" || parsedARN.AccountID == "" {
+		return errors.Errorf("invalid endpoint service allowed principal, must be an IAM principal: %v", principal)
+	}
+	if parsedARN.Resource == "root" || strings.HasPrefix(parsedARN.Resource, "user/") || strings.HasPrefix(parsedARN.Resource, "role/") {
+		return nil
+	}
+	return errors.Errorf("invalid endpoint service allowed principal, must be an IAM account root, user or role: %v", principal)
+}
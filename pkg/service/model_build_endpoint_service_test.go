@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func Test_defaultModelBuilderTask_buildEndpointService(t *testing.T) {
+	type wantEndpointService struct {
+		acceptanceRequired bool
+		allowedPrincipals  []string
+		tags               map[string]string
+	}
+	tests := []struct {
+		testName  string
+		svc       *corev1.Service
+		scheme    elbv2model.LoadBalancerScheme
+		want      *wantEndpointService
+		wantError bool
+	}{
+		{
+			testName: "endpoint service not enabled",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+					},
+				},
+			},
+			scheme: elbv2model.LoadBalancerSchemeInternal,
+			want:   nil,
+		},
+		{
+			testName: "endpoint service enabled with default settings",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-endpoint-service-enabled": "true",
+					},
+				},
+			},
+			scheme: elbv2model.LoadBalancerSchemeInternal,
+			want: &wantEndpointService{
+				acceptanceRequired: true,
+				tags:               map[string]string{},
+			},
+		},
+		{
+			testName: "endpoint service enabled with annotations specified",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                                 "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-endpoint-service-enabled":             "true",
+						"service.beta.kubernetes.io/aws-load-balancer-endpoint-service-acceptance-required": "false",
+						"service.beta.kubernetes.io/aws-load-balancer-endpoint-service-allowed-principals":  "arn:aws:iam::123456789012:root, arn:aws:iam::210987654321:role/consumer",
+						"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags":             "team=platform",
+					},
+				},
+			},
+			scheme: elbv2model.LoadBalancerSchemeInternal,
+			want: &wantEndpointService{
+				acceptanceRequired: false,
+				allowedPrincipals:  []string{"arn:aws:iam::123456789012:root", "arn:aws:iam::210987654321:role/consumer"},
+				tags:               map[string]string{"team": "platform"},
+			},
+		},
+		{
+			testName: "endpoint service enabled on internet-facing LoadBalancer",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-endpoint-service-enabled": "true",
+					},
+				},
+			},
+			scheme:    elbv2model.LoadBalancerSchemeInternetFacing,
+			wantError: true,
+		},
+		{
+			testName: "endpoint service enabled with invalid allowed principal",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                                "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-endpoint-service-enabled":            "true",
+						"service.beta.kubernetes.io/aws-load-balancer-endpoint-service-allowed-principals": "arn:aws:iam::123456789012:root, 123456789012",
+					},
+				},
+			},
+			scheme:    elbv2model.LoadBalancerSchemeInternal,
+			wantError: true,
+		},
+		{
+			testName: "invalid endpoint service enabled value",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-endpoint-service-enabled": "yes please",
+					},
+				},
+			},
+			scheme:    elbv2model.LoadBalancerSchemeInternal,
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+			builder := &defaultModelBuildTask{
+				service:          tt.svc,
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				stack:            stack,
+				loadBalancer:     elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{}),
+
+				defaultEndpointServiceAcceptanceRequired: true,
+			}
+			err := builder.buildEndpointService(context.Background(), tt.scheme)
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var resESes []*ec2model.VPCEndpointService
+			stack.ListResources(&resESes)
+			if tt.want == nil {
+				assert.Empty(t, resESes)
+				return
+			}
+			assert.Len(t, resESes, 1)
+			resES := resESes[0]
+			assert.Equal(t, tt.want.acceptanceRequired, resES.Spec.AcceptanceRequired)
+			assert.Equal(t, tt.want.allowedPrincipals, resES.Spec.AllowedPrincipals)
+			assert.Equal(t, tt.want.tags, resES.Spec.Tags)
+			assert.Len(t, resES.Spec.NetworkLoadBalancerARNs, 1)
+		})
+	}
+}
+
+func Test_validateEndpointServiceAllowedPrincipal(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal string
+		wantErr   string
+	}{
+		{
+			name:      "all principals",
+			principal: "*",
+		},
+		{
+			name:      "account root",
+			principal: "arn:aws:iam::123456789012:root",
+		},
+		{
+			name:      "IAM user",
+			principal: "arn:aws:iam::123456789012:user/consumer",
+		},
+		{
+			name:      "IAM role with path",
+			principal: "arn:aws-cn:iam::123456789012:role/path/consumer",
+		},
+		{
+			name:      "account ID only",
+			principal: "123456789012",
+			wantErr:   "invalid endpoint service allowed principal: 123456789012: arn: invalid prefix",
+		},
+		{
+			name:      "non IAM ARN",
+			principal: "arn:aws:s3:::my-bucket",
+			wantErr:   "invalid endpoint service allowed principal, must be an IAM principal: arn:aws:s3:::my-bucket",
+		},
+		{
+			name:      "IAM group",
+			principal: "arn:aws:iam::123456789012:group/consumers",
+			wantErr:   "invalid endpoint service allowed principal, must be an IAM account root, user or role: arn:aws:iam::123456789012:group/consumers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEndpointServiceAllowedPrincipal(tt.principal)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
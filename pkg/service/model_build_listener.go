@@ -4,18 +4,24 @@ import (
 	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"strconv"
 )
 
 func (t *defaultModelBuildTask) buildListeners(ctx context.Context) error {
-	cfg := t.buildListenerConfig(ctx)
+	cfg, err := t.buildListenerConfig(ctx)
+	if err != nil {
+		return err
+	}
 	for _, port := range t.service.Spec.Ports {
 		_, err := t.buildListener(ctx, port, cfg)
-		if err != nil {
+		if err := t.continueOnValidationError(err); err != nil {
 			return err
 		}
 	}
@@ -55,7 +61,10 @@ func (t *defaultModelBuildTask) buildListenerSpec(ctx context.Context, port core
 		certificates = cfg.certificates
 	}
 
-	defaultActions := t.buildListenerDefaultActions(ctx, targetGroup)
+	defaultActions, err := t.buildListenerDefaultActions(ctx, port, targetGroup)
+	if err != nil {
+		return elbv2model.ListenerSpec{}, err
+	}
 	return elbv2model.ListenerSpec{
 		LoadBalancerARN: t.loadBalancer.LoadBalancerARN(),
 		Port:            int64(port.Port),
@@ -66,19 +75,101 @@ func (t *defaultModelBuildTask) buildListenerSpec(ctx context.Context, port core
 	}, nil
 }
 
-func (t *defaultModelBuildTask) buildListenerDefaultActions(_ context.Context, targetGroup *elbv2model.TargetGroup) []elbv2model.Action {
+// targetGroupWeightsConfig is the weighted target groups for a service port.
+type targetGroupWeightsConfig struct {
+	// weight of the target group for service port, defaults to 1.
+	Weight *int64 `json:"weight,omitempty"`
+	// additional target groups to forward traffic to.
+	TargetGroups []weightedTargetGroup `json:"targetGroups,omitempty"`
+}
+
+// weightedTargetGroup is an additional target group with weight.
+type weightedTargetGroup struct {
+	TargetGroupARN string `json:"targetGroupARN"`
+	// weight of the target group, defaults to 1.
+	Weight *int64 `json:"weight,omitempty"`
+}
+
+func (t *defaultModelBuildTask) buildListenerDefaultActions(ctx context.Context, port corev1.ServicePort, targetGroup *elbv2model.TargetGroup) ([]elbv2model.Action, error) {
+	tgTuple, err := t.buildListenerForwardTargetGroupTuple(ctx, port, targetGroup)
+	if err != nil {
+		return nil, err
+	}
 	return []elbv2model.Action{
 		{
 			Type: elbv2model.ActionTypeForward,
 			ForwardConfig: &elbv2model.ForwardActionConfig{
-				TargetGroups: []elbv2model.TargetGroupTuple{
-					{
-						TargetGroupARN: targetGroup.TargetGroupARN(),
-					},
-				},
+				TargetGroups: []elbv2model.TargetGroupTuple{tgTuple},
 			},
 		},
+	}, nil
+}
+
+// buildListenerForwardTargetGroupTuple builds the target group to forward traffic to for service port, according to the target group weights annotation.
+// NLB listeners can only forward to a single target group, so precisely one target group must have positive weight, which allows cutover between
+// target groups by weights, e.g. blue/green deployments.
+func (t *defaultModelBuildTask) buildListenerForwardTargetGroupTuple(_ context.Context, port corev1.ServicePort, targetGroup *elbv2model.TargetGroup) (elbv2model.TargetGroupTuple, error) {
+	defaultTGTuple := elbv2model.TargetGroupTuple{
+		TargetGroupARN: targetGroup.TargetGroupARN(),
+	}
+	var weightsConfigByPortName map[string]targetGroupWeightsConfig
+	exists, err := t.annotationParser.ParseJSONAnnotation(annotations.SvcLBSuffixTargetGroupWeights, &weightsConfigByPortName, t.service.Annotations)
+	if err != nil {
+		return elbv2model.TargetGroupTuple{}, err
+	}
+	if !exists {
+		return defaultTGTuple, nil
+	}
+	svcPortNames := sets.NewString()
+	for _, svcPort := range t.service.Spec.Ports {
+		svcPortNames.Insert(svcPort.Name)
 	}
+	for portName := range weightsConfigByPortName {
+		if !svcPortNames.Has(portName) {
+			return elbv2model.TargetGroupTuple{}, t.invalidAnnotationError(annotations.SvcLBSuffixTargetGroupWeights, errors.Errorf("unknown service port %v in target group weights", portName))
+		}
+	}
+	weightsConfig, exists := weightsConfigByPortName[port.Name]
+	if !exists {
+		return defaultTGTuple, nil
+	}
+
+	tgTuples := []elbv2model.TargetGroupTuple{defaultTGTuple}
+	tgWeights := []int64{aws.Int64Value(weightsConfig.Weight)}
+	if weightsConfig.Weight == nil {
+		tgWeights[0] = 1
+	}
+	for _, weightedTG := range weightsConfig.TargetGroups {
+		if weightedTG.TargetGroupARN == "" {
+			return elbv2model.TargetGroupTuple{}, t.invalidAnnotationError(annotations.SvcLBSuffixTargetGroupWeights, errors.Errorf("missing targetGroupARN in target group weights of service port %v", port.Name))
+		}
+		weight := int64(1)
+		if weightedTG.Weight != nil {
+			weight = *weightedTG.Weight
+		}
+		tgTuples = append(tgTuples, elbv2model.TargetGroupTuple{
+			TargetGroupARN: core.LiteralStringToken(weightedTG.TargetGroupARN),
+		})
+		tgWeights = append(tgWeights, weight)
+	}
+
+	var weightedTGTuples []elbv2model.TargetGroupTuple
+	for i, weight := range tgWeights {
+		if weight < 0 {
+			return elbv2model.TargetGroupTuple{}, t.invalidAnnotationError(annotations.SvcLBSuffixTargetGroupWeights, errors.Errorf("target group weights of service port %v must be non-negative: %v", port.Name, weight))
+		}
+		if weight > 0 {
+			weightedTGTuples = append(weightedTGTuples, tgTuples[i])
+		}
+	}
+	if len(weightedTGTuples) == 0 {
+		return elbv2model.TargetGroupTuple{}, t.invalidAnnotationError(annotations.SvcLBSuffixTargetGroupWeights, errors.Errorf("at least one target group of service port %v must have positive weight", port.Name))
+	}
+	if len(weightedTGTuples) > 1 {
+		return elbv2model.TargetGroupTuple{}, t.invalidAnnotationError(annotations.SvcLBSuffixTargetGroupWeights, errors.Errorf("NLB listener can only forward to a single target group, but %v target groups of service port %v have positive weight",
+			len(weightedTGTuples), port.Name))
+	}
+	return weightedTGTuples[0], nil
 }
 
 func (t *defaultModelBuildTask) buildSSLNegotiationPolicy(_ context.Context) *string {
@@ -89,6 +180,8 @@ func (t *defaultModelBuildTask) buildSSLNegotiationPolicy(_ context.Context) *st
 	return nil
 }
 
+// buildListenerCertificates builds the certificates for TLS listeners, the first certificate is used as the listener's default certificate,
+// and the rest are added to the listener's certificate list for SNI, same as ALB listeners.
 func (t *defaultModelBuildTask) buildListenerCertificates(_ context.Context) []elbv2model.Certificate {
 	var rawCertificateARNs []string
 	_ = t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSSLCertificate, &rawCertificateARNs, t.service.Annotations)
@@ -113,15 +206,21 @@ func (t *defaultModelBuildTask) buildBackendProtocol(_ context.Context) string {
 }
 
 type listenerConfig struct {
+	// certificates for TLS listeners, the first one is the default certificate.
 	certificates    []elbv2model.Certificate
 	tlsPortsSet     sets.String
 	sslPolicy       *string
 	backendProtocol string
 }
 
-func (t *defaultModelBuildTask) buildListenerConfig(ctx context.Context) listenerConfig {
+// buildListenerConfig builds the TLS and backend settings shared by listeners.
+// TLS ports without certificates are only warned about for now, and these ports are served by TCP listeners as before.
+func (t *defaultModelBuildTask) buildListenerConfig(ctx context.Context) (listenerConfig, error) {
 	certificates := t.buildListenerCertificates(ctx)
 	tlsPortsSet := t.buildTLSPortsSet(ctx)
+	if tlsPortsSet.Len() != 0 && len(certificates) == 0 {
+		t.warnTLSPortsWithoutCertificates(ctx, tlsPortsSet)
+	}
 	backendProtocol := t.buildBackendProtocol(ctx)
 	sslPolicy := t.buildSSLNegotiationPolicy(ctx)
 
@@ -130,5 +229,18 @@ func (t *defaultModelBuildTask) buildListenerConfig(ctx context.Context) listene
 		tlsPortsSet:     tlsPortsSet,
 		sslPolicy:       sslPolicy,
 		backendProtocol: backendProtocol,
+	}, nil
+}
+
+// warnTLSPortsWithoutCertificates reports TLS ports specified without certificates, which will be rejected in a future release.
+// in dry-run mode, it's recorded as validation error of the TLS ports annotation.
+func (t *defaultModelBuildTask) warnTLSPortsWithoutCertificates(_ context.Context, tlsPortsSet sets.String) {
+	err := errors.Errorf("%v annotation must specify at least one certificate for TLS ports in %v annotation: %v",
+		annotations.SvcLBSuffixSSLCertificate, annotations.SvcLBSuffixSSLPorts, tlsPortsSet.List())
+	if t.dryRun {
+		t.recordValidationError(t.invalidAnnotationError(annotations.SvcLBSuffixSSLPorts, err))
+		return
 	}
+	t.announce(announcementTopicTLSPortsWithoutCertificates, corev1.EventTypeWarning, k8s.ServiceEventReasonInvalidAnnotation,
+		fmt.Sprintf("TLS ports are served without TLS, this will be rejected in a future release: %v", err))
 }
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildListenerDefaultActions(t *testing.T) {
+	svcPorts := []corev1.ServicePort{{Name: "http", Port: 80}, {Name: "tls", Port: 443}}
+	tests := []struct {
+		name           string
+		svcAnnotations map[string]string
+		port           corev1.ServicePort
+		wantTGARN      string
+		wantErr        error
+	}{
+		{
+			name:           "without target group weights",
+			svcAnnotations: map[string]string{},
+			port:           corev1.ServicePort{Name: "http", Port: 80},
+			wantTGARN:      "arn:blue",
+		},
+		{
+			name: "target group weights for other port",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-weights": `{"tls":{"weight":0,"targetGroups":[{"targetGroupARN":"arn:green","weight":100}]}}`,
+			},
+			port:      corev1.ServicePort{Name: "http", Port: 80},
+			wantTGARN: "arn:blue",
+		},
+		{
+			name: "target group weights keep service target group",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-weights": `{"http":{"weight":100,"targetGroups":[{"targetGroupARN":"arn:green","weight":0}]}}`,
+			},
+			port:      corev1.ServicePort{Name: "http", Port: 80},
+			wantTGARN: "arn:blue",
+		},
+		{
+			name: "target group weights cutover to additional target group",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-weights": `{"http":{"weight":0,"targetGroups":[{"targetGroupARN":"arn:green","weight":100}]}}`,
+			},
+			port:      corev1.ServicePort{Name: "http", Port: 80},
+			wantTGARN: "arn:green",
+		},
+		{
+			name: "target group weights with default weights",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-weights": `{"http":{"targetGroups":[{"targetGroupARN":"arn:green"}]}}`,
+			},
+			port:    corev1.ServicePort{Name: "http", Port: 80},
+			wantErr: errors.New("NLB listener can only forward to a single target group, but 2 target groups of service port http have positive weight"),
+		},
+		{
+			name: "target group weights with negative weight",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-weights": `{"http":{"weight":-1,"targetGroups":[{"targetGroupARN":"arn:green","weight":100}]}}`,
+			},
+			port:    corev1.ServicePort{Name: "http", Port: 80},
+			wantErr: errors.New("target group weights of service port http must be non-negative: -1"),
+		},
+		{
+			name: "target group weights without positive weight",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-weights": `{"http":{"weight":0,"targetGroups":[{"targetGroupARN":"arn:green","weight":0}]}}`,
+			},
+			port:    corev1.ServicePort{Name: "http", Port: 80},
+			wantErr: errors.New("at least one target group of service port http must have positive weight"),
+		},
+		{
+			name: "target group weights without targetGroupARN",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-weights": `{"http":{"weight":0,"targetGroups":[{"weight":100}]}}`,
+			},
+			port:    corev1.ServicePort{Name: "http", Port: 80},
+			wantErr: errors.New("missing targetGroupARN in target group weights of service port http"),
+		},
+		{
+			name: "target group weights with unknown port",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-weights": `{"https":{"weight":100}}`,
+			},
+			port:    corev1.ServicePort{Name: "http", Port: 80},
+			wantErr: errors.New("unknown service port https in target group weights"),
+		},
+		{
+			name: "target group weights with non-integer weight",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-weights": `{"http":{"weight":"50"}}`,
+			},
+			port:    corev1.ServicePort{Name: "http", Port: 80},
+			wantErr: errors.New("failed to parse json annotation, service.beta.kubernetes.io/aws-load-balancer-target-group-weights: {\"http\":{\"weight\":\"50\"}}: json: cannot unmarshal string into Go struct field .http.weight of type int64"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "my-svc"})
+			targetGroup := elbv2model.NewTargetGroup(stack, "awesome-ns/my-svc:80", elbv2model.TargetGroupSpec{})
+			targetGroup.SetStatus(elbv2model.TargetGroupStatus{TargetGroupARN: "arn:blue"})
+			task := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "my-svc",
+						Annotations: tt.svcAnnotations,
+					},
+					Spec: corev1.ServiceSpec{
+						Ports: svcPorts,
+					},
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+			}
+			got, err := task.buildListenerDefaultActions(context.Background(), tt.port, targetGroup)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, got, 1)
+				assert.Equal(t, elbv2model.ActionTypeForward, got[0].Type)
+				assert.Len(t, got[0].ForwardConfig.TargetGroups, 1)
+				gotTGARN, err := got[0].ForwardConfig.TargetGroups[0].TargetGroupARN.Resolve(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTGARN, gotTGARN)
+				assert.Nil(t, got[0].ForwardConfig.TargetGroups[0].Weight)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildListenerConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		svcAnnotations map[string]string
+		want           listenerConfig
+		wantEvents     []string
+	}{
+		{
+			name:           "without TLS annotations",
+			svcAnnotations: map[string]string{},
+			want: listenerConfig{
+				tlsPortsSet: sets.NewString(),
+			},
+		},
+		{
+			name: "multiple certificates - first one is default, rest for SNI",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-cert":  "arn:aws:acm:us-west-2:123456789012:certificate/cert-1, arn:aws:acm:us-west-2:123456789012:certificate/cert-2",
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-ports": "443",
+			},
+			want: listenerConfig{
+				certificates: []elbv2model.Certificate{
+					{CertificateARN: awssdk.String("arn:aws:acm:us-west-2:123456789012:certificate/cert-1")},
+					{CertificateARN: awssdk.String("arn:aws:acm:us-west-2:123456789012:certificate/cert-2")},
+				},
+				tlsPortsSet: sets.NewString("443"),
+			},
+		},
+		{
+			name: "TLS ports without certificates",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-ports": "443,https",
+			},
+			want: listenerConfig{
+				tlsPortsSet: sets.NewString("443", "https"),
+			},
+			wantEvents: []string{
+				"Warning InvalidAnnotation TLS ports are served without TLS, this will be rejected in a future release: aws-load-balancer-ssl-cert annotation must specify at least one certificate for TLS ports in aws-load-balancer-ssl-ports annotation: [443 https]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "my-svc",
+						Annotations: tt.svcAnnotations,
+					},
+				},
+				annotationParser:    annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				eventRecorder:       eventRecorder,
+				announcementTracker: k8s.NewAnnouncementTracker(),
+				announcedTopics:     sets.NewString(),
+			}
+			got, err := task.buildListenerConfig(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
@@ -6,13 +6,25 @@ import (
 	"encoding/hex"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"net"
 	"regexp"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -20,8 +32,28 @@ const (
 	lbAttrsAccessLogsS3Bucket            = "access_logs.s3.bucket"
 	lbAttrsAccessLogsS3Prefix            = "access_logs.s3.prefix"
 	lbAttrsLoadBalancingCrossZoneEnabled = "load_balancing.cross_zone.enabled"
+	lbAttrsDNSRecordClientRoutingPolicy  = "dns_record.client_routing_policy"
+
+	dnsRecordClientRoutingPolicyAnyAZ             = "any_availability_zone"
+	dnsRecordClientRoutingPolicyAZAffinity        = "availability_zone_affinity"
+	dnsRecordClientRoutingPolicyPartialAZAffinity = "partial_availability_zone_affinity"
+
+	enforceSGInboundRulesOnPrivateLinkTrafficOn  = "on"
+	enforceSGInboundRulesOnPrivateLinkTrafficOff = "off"
 
 	resourceIDLoadBalancer = "LoadBalancer"
+
+	accessLogS3PrefixPlaceholderCluster   = "{cluster}"
+	accessLogS3PrefixPlaceholderNamespace = "{namespace}"
+	accessLogS3PrefixPlaceholderService   = "{service}"
+	// ELB rejects access log prefixes longer than 1024 characters.
+	accessLogS3PrefixMaxLength = 1024
+)
+
+var (
+	accessLogS3PrefixPlaceholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+	// the characters permitted within access log prefixes, i.e. S3's safe key characters and the ones that only require URL encoding, except whitespace.
+	validAccessLogS3PrefixPattern = regexp.MustCompile(`^[a-zA-Z0-9!_.*'()/&$@=;:+,?-]*$`)
 )
 
 func (t *defaultModelBuildTask) buildLoadBalancer(ctx context.Context, scheme elbv2model.LoadBalancerScheme) error {
@@ -34,19 +66,41 @@ func (t *defaultModelBuildTask) buildLoadBalancer(ctx context.Context, scheme el
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, scheme elbv2model.LoadBalancerScheme) (elbv2model.LoadBalancerSpec, error) {
-	ipAddressType := elbv2model.IPAddressTypeIPV4
-	lbAttributes, err := t.buildLoadBalancerAttributes(ctx)
+	ipAddressType, err := t.buildLoadBalancerIPAddressType(ctx)
 	if err != nil {
+		if err := t.continueOnValidationError(err); err != nil {
+			return elbv2model.LoadBalancerSpec{}, err
+		}
+		ipAddressType = elbv2model.IPAddressTypeIPV4
+	}
+	lbAttributes, err := t.buildLoadBalancerAttributes(ctx)
+	if err := t.continueOnValidationError(err); err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
 	tags, err := t.buildLoadBalancerTags(ctx)
-	if err != nil {
+	if err := t.continueOnValidationError(err); err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
-	subnetMappings, err := t.buildLoadBalancerSubnetMappings(ctx, t.ec2Subnets)
-	if err != nil {
+	var subnetMappings []elbv2model.SubnetMapping
+	// in dry-run mode, subnetMappings are only validated if the subnets were resolved.
+	if len(t.ec2Subnets) != 0 || !t.dryRun {
+		subnetMappings, err = t.buildLoadBalancerSubnetMappings(ctx, ipAddressType, t.ec2Subnets)
+		if err := t.continueOnValidationError(err); err != nil {
+			return elbv2model.LoadBalancerSpec{}, err
+		}
+	}
+	securityGroups, sgErr := t.buildLoadBalancerSecurityGroups(ctx)
+	if err := t.continueOnValidationError(sgErr); err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
+	var enforceSGInboundRulesOnPrivateLinkTraffic *string
+	// in dry-run mode, it's only validated if the securityGroups were built.
+	if sgErr == nil {
+		enforceSGInboundRulesOnPrivateLinkTraffic, err = t.buildLoadBalancerEnforceSGInboundRulesOnPrivateLinkTraffic(ctx, securityGroups)
+		if err := t.continueOnValidationError(err); err != nil {
+			return elbv2model.LoadBalancerSpec{}, err
+		}
+	}
 	name := t.buildLoadBalancerName(ctx, scheme)
 	spec := elbv2model.LoadBalancerSpec{
 		Name:                   name,
@@ -54,12 +108,106 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, schem
 		Scheme:                 &scheme,
 		IPAddressType:          &ipAddressType,
 		SubnetMappings:         subnetMappings,
+		SecurityGroups:         securityGroups,
 		LoadBalancerAttributes: lbAttributes,
 		Tags:                   tags,
+
+		EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic: enforceSGInboundRulesOnPrivateLinkTraffic,
 	}
 	return spec, nil
 }
 
+// buildLoadBalancerEnforceSGInboundRulesOnPrivateLinkTraffic builds whether the inbound rules of securityGroups apply to PrivateLink traffic.
+// it's only valid for NLBs with securityGroups attached.
+func (t *defaultModelBuildTask) buildLoadBalancerEnforceSGInboundRulesOnPrivateLinkTraffic(_ context.Context, securityGroups []core.StringToken) (*string, error) {
+	var rawEnforce string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixEnforceSGPrivateLinkTraffic, &rawEnforce, t.service.Annotations); !exists {
+		return nil, nil
+	}
+	if rawEnforce != enforceSGInboundRulesOnPrivateLinkTrafficOn && rawEnforce != enforceSGInboundRulesOnPrivateLinkTrafficOff {
+		return nil, t.invalidAnnotationError(annotations.SvcLBSuffixEnforceSGPrivateLinkTraffic, errors.Errorf("invalid value %v, must be %v or %v",
+			rawEnforce, enforceSGInboundRulesOnPrivateLinkTrafficOn, enforceSGInboundRulesOnPrivateLinkTrafficOff))
+	}
+	if len(securityGroups) == 0 {
+		return nil, t.invalidAnnotationError(annotations.SvcLBSuffixEnforceSGPrivateLinkTraffic, errors.Errorf("annotation %v requires securityGroups specified via annotation %v",
+			annotations.SvcLBSuffixEnforceSGPrivateLinkTraffic, annotations.SvcLBSuffixSecurityGroups))
+	}
+	return &rawEnforce, nil
+}
+
+// buildLoadBalancerSecurityGroups builds the securityGroups specified via annotation.
+// unlike ALBs, the controller doesn't create securityGroups for NLBs, except the frontend securityGroup for source ranges.
+func (t *defaultModelBuildTask) buildLoadBalancerSecurityGroups(ctx context.Context) ([]core.StringToken, error) {
+	var rawSGNameOrIDs []string
+	sgConfigured := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSecurityGroups, &rawSGNameOrIDs, t.service.Annotations)
+	manageBackendSGRules := true
+	manageSGRulesConfigured, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixManageSGRules, &manageBackendSGRules, t.service.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if !sgConfigured {
+		if manageSGRulesConfigured {
+			return nil, t.invalidAnnotationError(annotations.SvcLBSuffixManageSGRules, errors.Errorf("annotation %v requires securityGroups specified via annotation %v, securityGroups won't be created for NLB automatically",
+				annotations.SvcLBSuffixManageSGRules, annotations.SvcLBSuffixSecurityGroups))
+		}
+		if err := t.validateLoadBalancerSecurityGroupsTransition(ctx, false); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	if len(rawSGNameOrIDs) == 0 {
+		return nil, t.invalidAnnotationError(annotations.SvcLBSuffixSecurityGroups, errors.Errorf("annotation %v must specify at least one securityGroup", annotations.SvcLBSuffixSecurityGroups))
+	}
+	sgIDs, err := t.sgResolver.ResolveViaNameOrID(ctx, rawSGNameOrIDs)
+	if err != nil {
+		return nil, t.invalidAnnotationError(annotations.SvcLBSuffixSecurityGroups, errors.Wrapf(err, "couldn't resolve securityGroups %v from annotation %v", rawSGNameOrIDs, annotations.SvcLBSuffixSecurityGroups))
+	}
+	if err := t.validateLoadBalancerSecurityGroupsTransition(ctx, true); err != nil {
+		return nil, err
+	}
+	t.lbSecurityGroupIDs = sgIDs
+	t.manageBackendSGRules = manageBackendSGRules
+	sgIDTokens := make([]core.StringToken, 0, len(sgIDs)+1)
+	for _, sgID := range sgIDs {
+		sgIDTokens = append(sgIDTokens, core.LiteralStringToken(sgID))
+	}
+	sourceRanges := t.buildSourceRanges(ctx)
+	prefixListIDs, err := t.buildSourcePrefixListIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(sourceRanges) != 0 || len(prefixListIDs) != 0 {
+		managedSG, err := t.buildManagedSecurityGroup(ctx, sourceRanges, prefixListIDs)
+		if err != nil {
+			return nil, err
+		}
+		sgIDTokens = append(sgIDTokens, managedSG.GroupID())
+	}
+	return sgIDTokens, nil
+}
+
+// validateLoadBalancerSecurityGroupsTransition validates the existing LoadBalancer can fulfill whether securityGroups are attached.
+// securityGroups can only be changed in place for NLBs created with securityGroups, and they cannot be removed from such NLBs.
+func (t *defaultModelBuildTask) validateLoadBalancerSecurityGroupsTransition(ctx context.Context, sgAttached bool) error {
+	existingLB, err := t.fetchExistingLoadBalancer(ctx)
+	if err != nil {
+		return err
+	}
+	if existingLB == nil {
+		return nil
+	}
+	existingSGAttached := len(existingLB.LoadBalancer.SecurityGroups) != 0
+	if sgAttached && !existingSGAttached {
+		return t.invalidAnnotationError(annotations.SvcLBSuffixSecurityGroups, errors.Errorf("securityGroups cannot be attached to existing loadBalancer %v created without securityGroups, recreate the service to attach them",
+			aws.StringValue(existingLB.LoadBalancer.LoadBalancerName)))
+	}
+	if !sgAttached && existingSGAttached {
+		return t.invalidAnnotationError(annotations.SvcLBSuffixSecurityGroups, errors.Errorf("securityGroups cannot be detached from existing loadBalancer %v created with securityGroups, recreate the service to detach them",
+			aws.StringValue(existingLB.LoadBalancer.LoadBalancerName)))
+	}
+	return nil
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancerScheme(_ context.Context) (elbv2model.LoadBalancerScheme, error) {
 	internal := false
 	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixInternal, &internal, t.service.Annotations); err != nil {
@@ -71,24 +219,76 @@ func (t *defaultModelBuildTask) buildLoadBalancerScheme(_ context.Context) (elbv
 	return elbv2model.LoadBalancerSchemeInternetFacing, nil
 }
 
+// buildLoadBalancerIPAddressType builds the LoadBalancer IPAddressType, which defaults to ipv4.
+func (t *defaultModelBuildTask) buildLoadBalancerIPAddressType(_ context.Context) (elbv2model.IPAddressType, error) {
+	rawIPAddressType := ""
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixIPAddressType, &rawIPAddressType, t.service.Annotations); !exists {
+		return elbv2model.IPAddressTypeIPV4, nil
+	}
+	switch rawIPAddressType {
+	case string(elbv2model.IPAddressTypeIPV4):
+		return elbv2model.IPAddressTypeIPV4, nil
+	case string(elbv2model.IPAddressTypeDualStack):
+		return elbv2model.IPAddressTypeDualStack, nil
+	default:
+		return "", t.invalidAnnotationError(annotations.SvcLBSuffixIPAddressType, errors.Errorf("unknown IPAddressType: %v", rawIPAddressType))
+	}
+}
+
 func (t *defaultModelBuildTask) buildAdditionalResourceTags(_ context.Context) (map[string]string, error) {
 	tags := make(map[string]string)
-	_, err := t.annotationParser.ParseStringMapAnnotation(annotations.SvcLBSuffixAdditionalTags, &tags, t.service.Annotations)
-	if err != nil {
+	// additional tags are critical, falling back to default tags would remove the applied tags.
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.SvcLBSuffixAdditionalTags, &tags, t.service.Annotations,
+		annotations.WithRejectDuplicateKeys()); err != nil {
 		return nil, err
 	}
-	return tags, nil
+	// user-specified tags take precedence over the default tags.
+	return algorithm.MergeStringMap(tags, t.defaultTags), nil
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerTags(ctx context.Context) (map[string]string, error) {
 	return t.buildAdditionalResourceTags(ctx)
 }
 
-func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(_ context.Context, ec2Subnets []*ec2.Subnet) ([]elbv2model.SubnetMapping, error) {
+func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Context, ipAddressType elbv2model.IPAddressType, ec2Subnets []*ec2.Subnet) ([]elbv2model.SubnetMapping, error) {
 	var eipAllocation []string
 	eipConfigured := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixEIPAllocations, &eipAllocation, t.service.Annotations)
+	var ipv4Addresses []string
+	ipv4AddrConfigured := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixPrivateIPv4Addresses, &ipv4Addresses, t.service.Annotations)
+	if ipv4AddrConfigured && eipConfigured {
+		return []elbv2model.SubnetMapping{}, t.invalidAnnotationError(annotations.SvcLBSuffixPrivateIPv4Addresses, errors.New("private IPv4 addresses cannot be combined with EIP allocations"))
+	}
 	if eipConfigured && len(eipAllocation) != len(ec2Subnets) {
-		return []elbv2model.SubnetMapping{}, errors.Errorf("number of EIP allocations (%d) and subnets (%d) must match", len(eipAllocation), len(ec2Subnets))
+		return []elbv2model.SubnetMapping{}, t.invalidAnnotationError(annotations.SvcLBSuffixEIPAllocations, errors.Errorf("number of EIP allocations (%d) and subnets (%d) must match", len(eipAllocation), len(ec2Subnets)))
+	}
+	if eipConfigured {
+		if err := validateEIPAllocationsUnique(eipAllocation); err != nil {
+			return []elbv2model.SubnetMapping{}, t.invalidAnnotationError(annotations.SvcLBSuffixEIPAllocations, err)
+		}
+		if err := t.validateEIPAllocations(ctx, eipAllocation); err != nil {
+			return []elbv2model.SubnetMapping{}, t.invalidAnnotationError(annotations.SvcLBSuffixEIPAllocations, err)
+		}
+	}
+	if ipv4AddrConfigured && len(ipv4Addresses) != len(ec2Subnets) {
+		return []elbv2model.SubnetMapping{}, t.invalidAnnotationError(annotations.SvcLBSuffixPrivateIPv4Addresses, errors.Errorf("number of private IPv4 addresses (%d) and subnets (%d) must match", len(ipv4Addresses), len(ec2Subnets)))
+	}
+	if ipv4AddrConfigured {
+		if err := validatePrivateIPv4Addresses(ipv4Addresses, ec2Subnets); err != nil {
+			return []elbv2model.SubnetMapping{}, t.invalidAnnotationError(annotations.SvcLBSuffixPrivateIPv4Addresses, err)
+		}
+	}
+	var ipv6Addresses []string
+	ipv6AddrConfigured := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixIPv6Addresses, &ipv6Addresses, t.service.Annotations)
+	if ipv6AddrConfigured && ipAddressType != elbv2model.IPAddressTypeDualStack {
+		return []elbv2model.SubnetMapping{}, t.invalidAnnotationError(annotations.SvcLBSuffixIPv6Addresses, errors.Errorf("IPv6 addresses can only be specified with %v IP address type, got %v", elbv2model.IPAddressTypeDualStack, ipAddressType))
+	}
+	if ipv6AddrConfigured && len(ipv6Addresses) != len(ec2Subnets) {
+		return []elbv2model.SubnetMapping{}, t.invalidAnnotationError(annotations.SvcLBSuffixIPv6Addresses, errors.Errorf("number of IPv6 addresses (%d) and subnets (%d) must match", len(ipv6Addresses), len(ec2Subnets)))
+	}
+	if ipv6AddrConfigured {
+		if err := validateIPv6Addresses(ipv6Addresses, ec2Subnets); err != nil {
+			return []elbv2model.SubnetMapping{}, t.invalidAnnotationError(annotations.SvcLBSuffixIPv6Addresses, err)
+		}
 	}
 	subnetMappings := make([]elbv2model.SubnetMapping, 0, len(ec2Subnets))
 	for idx, subnet := range ec2Subnets {
@@ -98,26 +298,206 @@ func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(_ context.Contex
 		if idx < len(eipAllocation) {
 			mapping.AllocationID = aws.String(eipAllocation[idx])
 		}
+		if idx < len(ipv4Addresses) {
+			mapping.PrivateIPv4Address = aws.String(ipv4Addresses[idx])
+		}
+		if idx < len(ipv6Addresses) {
+			mapping.IPv6Address = aws.String(ipv6Addresses[idx])
+		}
 		subnetMappings = append(subnetMappings, mapping)
 	}
 	return subnetMappings, nil
 }
 
+// validateEIPAllocations validates each EIP allocation exists in current account and region.
+// the EIP allocations found are cached, so they won't be described again until the cache expires.
+func (t *defaultModelBuildTask) validateEIPAllocations(ctx context.Context, eipAllocations []string) error {
+	for _, allocationID := range eipAllocations {
+		if _, exists := t.eipAllocationCache.Get(allocationID); exists {
+			continue
+		}
+		req := &ec2.DescribeAddressesInput{
+			AllocationIds: aws.StringSlice([]string{allocationID}),
+		}
+		resp, err := t.ec2Client.DescribeAddressesWithContext(ctx, req)
+		if err != nil {
+			var awsErr awserr.Error
+			if errors.As(err, &awsErr) && awsErr.Code() == "InvalidAllocationID.NotFound" {
+				return errors.Errorf("EIP allocation %v not found in current account and region", allocationID)
+			}
+			return errors.Wrapf(err, "failed to describe EIP allocation %v", allocationID)
+		}
+		if len(resp.Addresses) == 0 {
+			return errors.Errorf("EIP allocation %v not found in current account and region", allocationID)
+		}
+		t.eipAllocationCache.Set(allocationID, true, t.eipAllocationCacheTTL)
+	}
+	return nil
+}
+
+// validateEIPAllocationsUnique validates each EIP allocation is specified at most once.
+func validateEIPAllocationsUnique(eipAllocations []string) error {
+	seen := sets.NewString()
+	for _, allocationID := range eipAllocations {
+		if seen.Has(allocationID) {
+			return errors.Errorf("EIP allocation %v is specified more than once in annotation %v", allocationID, annotations.SvcLBSuffixEIPAllocations)
+		}
+		seen.Insert(allocationID)
+	}
+	return nil
+}
+
+// validatePrivateIPv4Addresses validates each private IPv4 address falls inside the CIDR of corresponding subnet.
+func validatePrivateIPv4Addresses(ipv4Addresses []string, ec2Subnets []*ec2.Subnet) error {
+	for idx, rawIPv4Address := range ipv4Addresses {
+		ipv4Address := net.ParseIP(rawIPv4Address)
+		if ipv4Address == nil || ipv4Address.To4() == nil {
+			return errors.Errorf("invalid private IPv4 address: %v", rawIPv4Address)
+		}
+		subnet := ec2Subnets[idx]
+		_, subnetCIDR, err := net.ParseCIDR(aws.StringValue(subnet.CidrBlock))
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse CIDR of subnet %v", aws.StringValue(subnet.SubnetId))
+		}
+		if !subnetCIDR.Contains(ipv4Address) {
+			return errors.Errorf("private IPv4 address %v not in subnet %v CIDR %v",
+				rawIPv4Address, aws.StringValue(subnet.SubnetId), aws.StringValue(subnet.CidrBlock))
+		}
+	}
+	return nil
+}
+
+// validateIPv6Addresses validates each IPv6 address falls inside the IPv6 CIDRs of corresponding subnet.
+func validateIPv6Addresses(ipv6Addresses []string, ec2Subnets []*ec2.Subnet) error {
+	for idx, rawIPv6Address := range ipv6Addresses {
+		ipv6Address := net.ParseIP(rawIPv6Address)
+		if ipv6Address == nil || ipv6Address.To4() != nil {
+			return errors.Errorf("invalid IPv6 address: %v", rawIPv6Address)
+		}
+		subnet := ec2Subnets[idx]
+		withinSubnet := false
+		for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+			_, subnetIPv6CIDR, err := net.ParseCIDR(aws.StringValue(association.Ipv6CidrBlock))
+			if err != nil {
+				return errors.Wrapf(err, "failed to parse IPv6 CIDR of subnet %v", aws.StringValue(subnet.SubnetId))
+			}
+			if subnetIPv6CIDR.Contains(ipv6Address) {
+				withinSubnet = true
+				break
+			}
+		}
+		if !withinSubnet {
+			return errors.Errorf("IPv6 address %v not in subnet %v IPv6 CIDRs", rawIPv6Address, aws.StringValue(subnet.SubnetId))
+		}
+	}
+	return nil
+}
+
+// fetchExistingLoadBalancer fetches the existing LoadBalancer of service, nil is returned if it doesn't exist yet.
+func (t *defaultModelBuildTask) fetchExistingLoadBalancer(ctx context.Context) (*elbv2deploy.LoadBalancerWithTags, error) {
+	if t.existingLoadBalancerFetched {
+		return t.existingLoadBalancer, nil
+	}
+	stackTags := t.trackingProvider.StackTags(t.stack)
+	sdkLBs, err := t.elbv2TaggingManager.ListLoadBalancers(ctx, tracking.TagsAsTagFilter(stackTags))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch existing loadBalancer")
+	}
+	// duplicated loadBalancers will be deleted by deployer, none of them is considered as existing one.
+	if len(sdkLBs) == 1 {
+		t.existingLoadBalancer = &sdkLBs[0]
+	}
+	t.existingLoadBalancerFetched = true
+	return t.existingLoadBalancer, nil
+}
+
 func (t *defaultModelBuildTask) resolveLoadBalancerSubnets(ctx context.Context, scheme elbv2model.LoadBalancerScheme) ([]*ec2.Subnet, error) {
 	var rawSubnetNameOrIDs []string
 	if exists := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSubnets, &rawSubnetNameOrIDs, t.service.Annotations); exists {
-		return t.subnetsResolver.ResolveViaNameOrIDSlice(ctx, rawSubnetNameOrIDs,
+		subnets, err := t.subnetsResolver.ResolveViaNameOrIDSlice(ctx, rawSubnetNameOrIDs,
 			networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
 			networking.WithSubnetsResolveLBScheme(scheme),
 		)
+		if err != nil {
+			return nil, t.invalidAnnotationError(annotations.SvcLBSuffixSubnets, errors.Wrapf(err, "couldn't resolve subnets %v from annotation %v", rawSubnetNameOrIDs, annotations.SvcLBSuffixSubnets))
+		}
+		if err := t.validateLoadBalancerSubnetsAZCount(subnets, fmt.Sprintf("subnets %v from annotation %v", rawSubnetNameOrIDs, annotations.SvcLBSuffixSubnets)); err != nil {
+			return nil, t.invalidAnnotationError(annotations.SvcLBSuffixSubnets, err)
+		}
+		return subnets, nil
+	}
+	existingLB, err := t.fetchExistingLoadBalancer(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return t.subnetsResolver.ResolveViaDiscovery(ctx,
+	subnets, err := t.subnetsResolver.ResolveViaDiscovery(ctx,
 		networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
 		networking.WithSubnetsResolveLBScheme(scheme),
+		networking.WithSubnetsResolvePreferAvailableIPAddresses(t.subnetDiscoveryPreferAvailableIPs),
+		networking.WithSubnetsResolvePreferredSubnetIDs(elbv2deploy.ExtractLoadBalancerSubnetIDs(existingLB)),
 	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't auto-discover subnets for %v loadBalancer", scheme)
+	}
+	if t.azExpansionPolicy == config.AZExpansionPolicyIgnore {
+		subnets = elbv2deploy.FilterSubnetsWithinLoadBalancerAZs(subnets, existingLB)
+	}
+	if err := t.handleSingleAZDiscoveredSubnets(subnets, scheme); err != nil {
+		return nil, err
+	}
+	if err := t.validateLoadBalancerSubnetsAZCount(subnets, fmt.Sprintf("auto-discovered subnets for %v loadBalancer", scheme)); err != nil {
+		return nil, err
+	}
+	t.announceDiscoveredSubnets(subnets, scheme)
+	return subnets, nil
 }
 
-func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) ([]elbv2model.LoadBalancerAttribute, error) {
+// announceDiscoveredSubnets emits an event summarizing the auto-discovered subnets, when they differ from the last announced ones.
+func (t *defaultModelBuildTask) announceDiscoveredSubnets(subnets []*ec2.Subnet, scheme elbv2model.LoadBalancerScheme) {
+	subnetDescs := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		subnetDescs = append(subnetDescs, fmt.Sprintf("%v(%v)", aws.StringValue(subnet.SubnetId), aws.StringValue(subnet.AvailabilityZone)))
+	}
+	sort.Strings(subnetDescs)
+	t.announce(announcementTopicDiscoveredSubnets, corev1.EventTypeNormal, k8s.ServiceEventReasonDiscoveredSubnets,
+		fmt.Sprintf("auto-discovered subnets for %v loadBalancer: %v", scheme, strings.Join(subnetDescs, ", ")))
+}
+
+// handleSingleAZDiscoveredSubnets handles auto-discovered subnets that span a single availabilityZone according to singleAZDiscoveryPolicy.
+func (t *defaultModelBuildTask) handleSingleAZDiscoveredSubnets(subnets []*ec2.Subnet, scheme elbv2model.LoadBalancerScheme) error {
+	subnetAZs := sets.NewString()
+	for _, subnet := range subnets {
+		subnetAZs.Insert(aws.StringValue(subnet.AvailabilityZone))
+	}
+	if subnetAZs.Len() != 1 {
+		return nil
+	}
+	message := fmt.Sprintf("auto-discovered subnets for %v loadBalancer span single availabilityZone %v", scheme, subnetAZs.List()[0])
+	switch t.singleAZDiscoveryPolicy {
+	case config.SingleAZDiscoveryPolicyWarn:
+		t.announce(announcementTopicSingleAZSubnets, corev1.EventTypeWarning, k8s.ServiceEventReasonSingleAZSubnets, message)
+		return nil
+	case config.SingleAZDiscoveryPolicyError:
+		return errors.New(message)
+	default:
+		return nil
+	}
+}
+
+// validateLoadBalancerSubnetsAZCount checks the subnets span at least minAZCount availabilityZones.
+func (t *defaultModelBuildTask) validateLoadBalancerSubnetsAZCount(subnets []*ec2.Subnet, subnetsDesc string) error {
+	subnetAZs := sets.NewString()
+	for _, subnet := range subnets {
+		subnetAZs.Insert(aws.StringValue(subnet.AvailabilityZone))
+	}
+	if subnetAZs.Len() < t.minAZCount {
+		return errors.Errorf("%v span %v availabilityZones %v, fewer than the minimum of %v",
+			subnetsDesc, subnetAZs.Len(), subnetAZs.List(), t.minAZCount)
+	}
+	return nil
+}
+
+func (t *defaultModelBuildTask) buildLoadBalancerAttributes(ctx context.Context) ([]elbv2model.LoadBalancerAttribute, error) {
 	var attrs []elbv2model.LoadBalancerAttribute
 	accessLogEnabled := t.defaultAccessLogS3Enabled
 	bucketName := t.defaultAccessLogsS3Bucket
@@ -128,10 +508,29 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 	if accessLogEnabled {
 		t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixAccessLogS3BucketName, &bucketName, t.service.Annotations)
 		t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixAccessLogS3BucketPrefix, &bucketPrefix, t.service.Annotations)
+		renderedPrefix, err := t.renderAccessLogS3Prefix(bucketPrefix)
+		if err != nil {
+			return []elbv2model.LoadBalancerAttribute{}, t.invalidAnnotationError(annotations.SvcLBSuffixAccessLogS3BucketPrefix, err)
+		}
+		bucketPrefix = renderedPrefix
+		if t.accessLogBucketValidator != nil && bucketName != "" {
+			if err := t.accessLogBucketValidator.Validate(ctx, bucketName); err != nil {
+				return []elbv2model.LoadBalancerAttribute{}, t.invalidAnnotationError(annotations.SvcLBSuffixAccessLogS3BucketName, err)
+			}
+		}
 	}
 	crossZoneEnabled := t.defaultLoadBalancingCrossZoneEnabled
+	// the cross-zone attribute is left out if its annotation is ignored, so that the currently applied value is kept.
+	crossZoneIgnored := false
 	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixCrossZoneLoadBalancingEnabled, &crossZoneEnabled, t.service.Annotations); err != nil {
-		return []elbv2model.LoadBalancerAttribute{}, err
+		if err := t.handleNonCriticalAnnotationError(annotations.SvcLBSuffixCrossZoneLoadBalancingEnabled, err); err != nil {
+			return []elbv2model.LoadBalancerAttribute{}, err
+		}
+		crossZoneIgnored = true
+	}
+	if !crossZoneIgnored && crossZoneEnabled && t.crossZoneCostWarning {
+		t.announce(announcementTopicCrossZoneCost, corev1.EventTypeWarning, k8s.ServiceEventReasonCrossZoneCost,
+			"Cross-zone load balancing is enabled, traffic routed to targets in other availabilityZones incurs inter-AZ data transfer charges")
 	}
 
 	attrs = []elbv2model.LoadBalancerAttribute{
@@ -147,15 +546,92 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 			Key:   lbAttrsAccessLogsS3Prefix,
 			Value: bucketPrefix,
 		},
-		{
+	}
+	if !crossZoneIgnored {
+		attrs = append(attrs, elbv2model.LoadBalancerAttribute{
 			Key:   lbAttrsLoadBalancingCrossZoneEnabled,
 			Value: strconv.FormatBool(crossZoneEnabled),
-		},
+		})
+	}
+	// the default policy is applied when unspecified, so that the policy is reset once the annotation is removed.
+	dnsRecordClientRoutingPolicy := t.defaultDNSRecordClientRoutingPolicy
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixDNSRecordClientRoutingPolicy, &dnsRecordClientRoutingPolicy, t.service.Annotations); exists {
+		switch dnsRecordClientRoutingPolicy {
+		case dnsRecordClientRoutingPolicyAnyAZ, dnsRecordClientRoutingPolicyAZAffinity, dnsRecordClientRoutingPolicyPartialAZAffinity:
+		default:
+			return []elbv2model.LoadBalancerAttribute{}, t.invalidAnnotationError(annotations.SvcLBSuffixDNSRecordClientRoutingPolicy, errors.Errorf("invalid dns record client routing policy %v, must be one of %v, %v, %v",
+				dnsRecordClientRoutingPolicy, dnsRecordClientRoutingPolicyAnyAZ, dnsRecordClientRoutingPolicyAZAffinity, dnsRecordClientRoutingPolicyPartialAZAffinity))
+		}
+	}
+	attrs = append(attrs, elbv2model.LoadBalancerAttribute{
+		Key:   lbAttrsDNSRecordClientRoutingPolicy,
+		Value: dnsRecordClientRoutingPolicy,
+	})
+	additionalAttrs, err := t.buildLoadBalancerAdditionalAttributes(attrs)
+	if err != nil {
+		return []elbv2model.LoadBalancerAttribute{}, err
 	}
+	attrs = append(attrs, additionalAttrs...)
 
 	return attrs, nil
 }
 
+// buildLoadBalancerAdditionalAttributes builds the free-form attributes specified via annotation.
+// attributes within managedAttrs take precedence, thus the same keys from annotation are ignored.
+func (t *defaultModelBuildTask) buildLoadBalancerAdditionalAttributes(managedAttrs []elbv2model.LoadBalancerAttribute) ([]elbv2model.LoadBalancerAttribute, error) {
+	var rawAttributes map[string]string
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.SvcLBSuffixLoadBalancerAttributes, &rawAttributes, t.service.Annotations,
+		annotations.WithRejectDuplicateKeys()); err != nil {
+		return nil, err
+	}
+	managedKeys := sets.NewString()
+	for _, attr := range managedAttrs {
+		managedKeys.Insert(attr.Key)
+	}
+	var attrs []elbv2model.LoadBalancerAttribute
+	for _, key := range sets.StringKeySet(rawAttributes).List() {
+		if managedKeys.Has(key) {
+			continue
+		}
+		attrs = append(attrs, elbv2model.LoadBalancerAttribute{
+			Key:   key,
+			Value: rawAttributes[key],
+		})
+	}
+	return attrs, nil
+}
+
+// renderAccessLogS3Prefix resolves the placeholders within access log prefix template for this service,
+// and validates the rendered prefix against S3 prefix rules enforced by ELB.
+func (t *defaultModelBuildTask) renderAccessLogS3Prefix(prefixTemplate string) (string, error) {
+	for _, placeholder := range accessLogS3PrefixPlaceholderPattern.FindAllString(prefixTemplate, -1) {
+		switch placeholder {
+		case accessLogS3PrefixPlaceholderCluster, accessLogS3PrefixPlaceholderNamespace, accessLogS3PrefixPlaceholderService:
+		default:
+			return "", errors.Errorf("access log prefix %v contains unsupported placeholder %v, supported placeholders: [%v, %v, %v]",
+				prefixTemplate, placeholder, accessLogS3PrefixPlaceholderCluster, accessLogS3PrefixPlaceholderNamespace, accessLogS3PrefixPlaceholderService)
+		}
+	}
+	prefix := strings.NewReplacer(
+		accessLogS3PrefixPlaceholderCluster, t.clusterName,
+		accessLogS3PrefixPlaceholderNamespace, t.service.Namespace,
+		accessLogS3PrefixPlaceholderService, t.service.Name,
+	).Replace(prefixTemplate)
+	if len(prefix) > accessLogS3PrefixMaxLength {
+		return "", errors.Errorf("access log prefix %v exceeds the maximum length of %v", prefix, accessLogS3PrefixMaxLength)
+	}
+	if strings.HasPrefix(prefix, "/") || strings.HasSuffix(prefix, "/") {
+		return "", errors.Errorf("access log prefix %v must not start or end with a slash", prefix)
+	}
+	if strings.Contains(prefix, "AWSLogs") {
+		return "", errors.Errorf("access log prefix %v must not contain AWSLogs", prefix)
+	}
+	if !validAccessLogS3PrefixPattern.MatchString(prefix) {
+		return "", errors.Errorf("access log prefix %v contains invalid characters", prefix)
+	}
+	return prefix, nil
+}
+
 var invalidLoadBalancerNamePattern = regexp.MustCompile("[[:^alnum:]]")
 
 func (t *defaultModelBuildTask) buildLoadBalancerName(_ context.Context, scheme elbv2model.LoadBalancerScheme) string {
@@ -4,15 +4,32 @@ import (
 	"context"
 	"errors"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	mock_aws "sigs.k8s.io/aws-load-balancer-controller/mocks/aws"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	mock_elbv2 "sigs.k8s.io/aws-load-balancer-controller/mocks/deploy/elbv2"
 	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"strings"
 	"testing"
+	"time"
 )
 
 func Test_defaultModelBuilderTask_buildLBAttributes(t *testing.T) {
@@ -49,6 +66,10 @@ func Test_defaultModelBuilderTask_buildLBAttributes(t *testing.T) {
 					Key:   lbAttrsLoadBalancingCrossZoneEnabled,
 					Value: "false",
 				},
+				{
+					Key:   lbAttrsDNSRecordClientRoutingPolicy,
+					Value: dnsRecordClientRoutingPolicyAnyAZ,
+				},
 			},
 		},
 		{
@@ -82,227 +103,2158 @@ func Test_defaultModelBuilderTask_buildLBAttributes(t *testing.T) {
 					Key:   lbAttrsLoadBalancingCrossZoneEnabled,
 					Value: "true",
 				},
+				{
+					Key:   lbAttrsDNSRecordClientRoutingPolicy,
+					Value: dnsRecordClientRoutingPolicyAnyAZ,
+				},
 			},
 		},
 		{
-			testName: "Annotation invalid",
+			testName: "dns record client routing policy any_availability_zone",
 			svc: &corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						"service.beta.kubernetes.io/aws-load-balancer-type":                              "nlb-ip",
-						"service.beta.kubernetes.io/aws-load-balancer-access-log-enabled":                "FalSe",
-						"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-name":         "nlb-bucket",
-						"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-prefix":       "bkt-pfx",
-						"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "true",
+						"service.beta.kubernetes.io/aws-load-balancer-type":                             "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-dns-record-client-routing-policy": "any_availability_zone",
 					},
 				},
 			},
-			wantError: true,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.testName, func(t *testing.T) {
-			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
-			builder := &defaultModelBuildTask{
-				service:                              tt.svc,
-				annotationParser:                     parser,
-				defaultAccessLogsS3Bucket:            "",
-				defaultAccessLogsS3Prefix:            "",
-				defaultLoadBalancingCrossZoneEnabled: false,
-				defaultProxyProtocolV2Enabled:        false,
-				defaultHealthCheckProtocol:           elbv2.ProtocolTCP,
-				defaultHealthCheckPort:               healthCheckPortTrafficPort,
-				defaultHealthCheckPath:               "/",
-				defaultHealthCheckInterval:           10,
-				defaultHealthCheckTimeout:            10,
-				defaultHealthCheckHealthyThreshold:   3,
-				defaultHealthCheckUnhealthyThreshold: 3,
-			}
-			lbAttributes, err := builder.buildLoadBalancerAttributes(context.Background())
-			if tt.wantError {
-				assert.Error(t, err)
-			} else {
-				assert.Equal(t, tt.wantValue, lbAttributes)
-			}
-		})
-	}
-}
-
-func Test_defaultModelBuilderTask_buildSubnetMappings(t *testing.T) {
-	tests := []struct {
-		name    string
-		subnets []*ec2.Subnet
-		want    []elbv2.SubnetMapping
-		svc     *corev1.Service
-		wantErr error
-	}{
-		{
-			name: "Multiple subnets",
-			subnets: []*ec2.Subnet{
+			wantError: false,
+			wantValue: []elbv2.LoadBalancerAttribute{
 				{
-					SubnetId:         aws.String("subnet-1"),
-					AvailabilityZone: aws.String("us-west-2a"),
-					VpcId:            aws.String("vpc-1"),
+					Key:   lbAttrsAccessLogsS3Enabled,
+					Value: "false",
 				},
 				{
-					SubnetId:         aws.String("subnet-2"),
-					AvailabilityZone: aws.String("us-west-2b"),
-					VpcId:            aws.String("vpc-1"),
+					Key:   lbAttrsAccessLogsS3Bucket,
+					Value: "",
 				},
-			},
-			svc: &corev1.Service{},
-			want: []elbv2.SubnetMapping{
 				{
-					SubnetID: "subnet-1",
+					Key:   lbAttrsAccessLogsS3Prefix,
+					Value: "",
 				},
 				{
-					SubnetID: "subnet-2",
+					Key:   lbAttrsLoadBalancingCrossZoneEnabled,
+					Value: "false",
+				},
+				{
+					Key:   lbAttrsDNSRecordClientRoutingPolicy,
+					Value: "any_availability_zone",
 				},
 			},
 		},
 		{
-			name: "When EIP allocation is configured",
-			subnets: []*ec2.Subnet{
+			testName: "dns record client routing policy availability_zone_affinity",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                             "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-dns-record-client-routing-policy": "availability_zone_affinity",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: []elbv2.LoadBalancerAttribute{
 				{
-					SubnetId:         aws.String("subnet-1"),
-					AvailabilityZone: aws.String("us-west-2a"),
-					VpcId:            aws.String("vpc-1"),
+					Key:   lbAttrsAccessLogsS3Enabled,
+					Value: "false",
 				},
 				{
-					SubnetId:         aws.String("subnet-2"),
-					AvailabilityZone: aws.String("us-west-2b"),
-					VpcId:            aws.String("vpc-1"),
+					Key:   lbAttrsAccessLogsS3Bucket,
+					Value: "",
+				},
+				{
+					Key:   lbAttrsAccessLogsS3Prefix,
+					Value: "",
+				},
+				{
+					Key:   lbAttrsLoadBalancingCrossZoneEnabled,
+					Value: "false",
+				},
+				{
+					Key:   lbAttrsDNSRecordClientRoutingPolicy,
+					Value: "availability_zone_affinity",
 				},
 			},
+		},
+		{
+			testName: "dns record client routing policy partial_availability_zone_affinity",
 			svc: &corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1, eip2",
+						"service.beta.kubernetes.io/aws-load-balancer-type":                             "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-dns-record-client-routing-policy": "partial_availability_zone_affinity",
 					},
 				},
 			},
-			want: []elbv2.SubnetMapping{
+			wantError: false,
+			wantValue: []elbv2.LoadBalancerAttribute{
 				{
-					SubnetID:     "subnet-1",
-					AllocationID: aws.String("eip1"),
+					Key:   lbAttrsAccessLogsS3Enabled,
+					Value: "false",
 				},
 				{
-					SubnetID:     "subnet-2",
-					AllocationID: aws.String("eip2"),
+					Key:   lbAttrsAccessLogsS3Bucket,
+					Value: "",
+				},
+				{
+					Key:   lbAttrsAccessLogsS3Prefix,
+					Value: "",
+				},
+				{
+					Key:   lbAttrsLoadBalancingCrossZoneEnabled,
+					Value: "false",
+				},
+				{
+					Key:   lbAttrsDNSRecordClientRoutingPolicy,
+					Value: "partial_availability_zone_affinity",
 				},
 			},
 		},
 		{
-			name: "When EIP allocation and subnet mismatch",
-			subnets: []*ec2.Subnet{
+			testName: "dns record client routing policy invalid",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                             "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-dns-record-client-routing-policy": "zonal",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "additional attributes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                              "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "true",
+						"service.beta.kubernetes.io/aws-load-balancer-attributes":                        "zonal_shift.config.enabled=true, load_balancing.cross_zone.enabled=false, deletion_protection.enabled=true",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: []elbv2.LoadBalancerAttribute{
 				{
-					SubnetId:         aws.String("subnet-1"),
-					AvailabilityZone: aws.String("us-west-2a"),
-					VpcId:            aws.String("vpc-1"),
+					Key:   lbAttrsAccessLogsS3Enabled,
+					Value: "false",
 				},
 				{
-					SubnetId:         aws.String("subnet-2"),
-					AvailabilityZone: aws.String("us-west-2b"),
-					VpcId:            aws.String("vpc-1"),
+					Key:   lbAttrsAccessLogsS3Bucket,
+					Value: "",
+				},
+				{
+					Key:   lbAttrsAccessLogsS3Prefix,
+					Value: "",
+				},
+				{
+					Key:   lbAttrsLoadBalancingCrossZoneEnabled,
+					Value: "true",
+				},
+				{
+					Key:   lbAttrsDNSRecordClientRoutingPolicy,
+					Value: dnsRecordClientRoutingPolicyAnyAZ,
+				},
+				{
+					Key:   "deletion_protection.enabled",
+					Value: "true",
+				},
+				{
+					Key:   "zonal_shift.config.enabled",
+					Value: "true",
 				},
 			},
+		},
+		{
+			testName: "additional attributes with duplicate keys",
 			svc: &corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1",
+						"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-attributes": "zonal_shift.config.enabled=true, zonal_shift.config.enabled=false",
 					},
 				},
 			},
-			wantErr: errors.New("number of EIP allocations (1) and subnets (2) must match"),
+			wantError: true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
-			builder := &defaultModelBuildTask{service: tt.svc, annotationParser: annotationParser}
-			got, err := builder.buildLoadBalancerSubnetMappings(context.Background(), tt.subnets)
-			if tt.wantErr != nil {
-				assert.EqualError(t, err, tt.wantErr.Error())
-			} else {
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
-}
-
-func Test_defaultModelBuilderTask_resolveLoadBalancerSubnets(t *testing.T) {
-	type resolveSubnetResults struct {
-		subnets []*ec2.Subnet
-		err     error
-	}
-	tests := []struct {
-		name                     string
-		svc                      *corev1.Service
-		scheme                   elbv2.LoadBalancerScheme
-		resolveViaDiscovery      []resolveSubnetResults
-		resolveViaNameOrIDSlilce []resolveSubnetResults
-	}{
 		{
-			name:   "subnet auto-discovery",
-			svc:    &corev1.Service{},
-			scheme: elbv2.LoadBalancerSchemeInternal,
-			resolveViaDiscovery: []resolveSubnetResults{
-				{
-					subnets: []*ec2.Subnet{
-						{
-							SubnetId:  aws.String("subnet-1"),
-							CidrBlock: aws.String("192.168.0.0/19"),
-						},
+			testName: "Annotation invalid",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                              "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-access-log-enabled":                "FalSe",
+						"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-name":         "nlb-bucket",
+						"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-prefix":       "bkt-pfx",
+						"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "true",
 					},
 				},
 			},
+			wantError: true,
 		},
 		{
-			name: "subnet annotation",
+			testName: "Access log prefix with placeholders",
 			svc: &corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-svc",
 					Annotations: map[string]string{
-						"service.beta.kubernetes.io/aws-load-balancer-subnets": "subnet-abc, Subnet Name XYZ",
+						"service.beta.kubernetes.io/aws-load-balancer-type":                        "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-access-log-enabled":          "true",
+						"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-name":   "nlb-bucket",
+						"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-prefix": "{cluster}/{namespace}/{service}",
 					},
 				},
 			},
-			scheme: elbv2.LoadBalancerSchemeInternal,
-			resolveViaNameOrIDSlilce: []resolveSubnetResults{
+			wantError: false,
+			wantValue: []elbv2.LoadBalancerAttribute{
 				{
-					subnets: []*ec2.Subnet{
-						{
-							SubnetId:  aws.String("subnet-abc"),
-							CidrBlock: aws.String("192.168.0.0/19"),
-						},
-						{
-							SubnetId:  aws.String("subnet-xyz"),
-							CidrBlock: aws.String("192.168.0.0/19"),
-						},
-					},
+					Key:   lbAttrsAccessLogsS3Enabled,
+					Value: "true",
 				},
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			subnetsResolver := mock_networking.NewMockSubnetsResolver(ctrl)
-			for _, call := range tt.resolveViaDiscovery {
-				subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any()).Return(call.subnets, call.err)
-			}
-			for _, call := range tt.resolveViaNameOrIDSlilce {
+				{
+					Key:   lbAttrsAccessLogsS3Bucket,
+					Value: "nlb-bucket",
+				},
+				{
+					Key:   lbAttrsAccessLogsS3Prefix,
+					Value: "my-cluster/awesome-ns/my-svc",
+				},
+				{
+					Key:   lbAttrsLoadBalancingCrossZoneEnabled,
+					Value: "false",
+				},
+				{
+					Key:   lbAttrsDNSRecordClientRoutingPolicy,
+					Value: dnsRecordClientRoutingPolicyAnyAZ,
+				},
+			},
+		},
+		{
+			testName: "Access log prefix with unsupported placeholder",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                        "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-access-log-enabled":          "true",
+						"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-name":   "nlb-bucket",
+						"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-prefix": "{port}",
+					},
+				},
+			},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			builder := &defaultModelBuildTask{
+				clusterName:                          "my-cluster",
+				service:                              tt.svc,
+				annotationParser:                     parser,
+				defaultAccessLogsS3Bucket:            "",
+				defaultAccessLogsS3Prefix:            "",
+				defaultLoadBalancingCrossZoneEnabled: false,
+				defaultDNSRecordClientRoutingPolicy:  dnsRecordClientRoutingPolicyAnyAZ,
+				defaultProxyProtocolV2Enabled:        false,
+				defaultHealthCheckProtocol:           elbv2.ProtocolTCP,
+				defaultHealthCheckPort:               healthCheckPortTrafficPort,
+				defaultHealthCheckPath:               "/",
+				defaultHealthCheckInterval:           10,
+				defaultHealthCheckTimeout:            10,
+				defaultHealthCheckHealthyThreshold:   3,
+				defaultHealthCheckUnhealthyThreshold: 3,
+			}
+			lbAttributes, err := builder.buildLoadBalancerAttributes(context.Background())
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.Equal(t, tt.wantValue, lbAttributes)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_buildLBAttributes_crossZoneCostWarning(t *testing.T) {
+	tests := []struct {
+		name                     string
+		svcAnnotations           map[string]string
+		defaultCrossZone         bool
+		crossZoneCostWarning     bool
+		lenientAnnotationParsing bool
+		wantCrossZoneAttrOmitted bool
+		wantEvents               []string
+	}{
+		{
+			name: "cross-zone enabled via annotation",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "true",
+			},
+			crossZoneCostWarning: true,
+			wantEvents: []string{
+				"Warning CrossZoneLoadBalancingCost Cross-zone load balancing is enabled, traffic routed to targets in other availabilityZones incurs inter-AZ data transfer charges",
+			},
+		},
+		{
+			name:                 "cross-zone enabled by default",
+			svcAnnotations:       map[string]string{},
+			defaultCrossZone:     true,
+			crossZoneCostWarning: true,
+			wantEvents: []string{
+				"Warning CrossZoneLoadBalancingCost Cross-zone load balancing is enabled, traffic routed to targets in other availabilityZones incurs inter-AZ data transfer charges",
+			},
+		},
+		{
+			name: "cross-zone enabled with warning suppressed",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "true",
+			},
+			crossZoneCostWarning: false,
+		},
+		{
+			name: "cross-zone disabled",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "false",
+			},
+			crossZoneCostWarning: true,
+		},
+		{
+			name: "malformed cross-zone ignored in lenient mode",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "yes",
+			},
+			defaultCrossZone:         true,
+			crossZoneCostWarning:     true,
+			lenientAnnotationParsing: true,
+			wantCrossZoneAttrOmitted: true,
+			wantEvents: []string{
+				"Warning InvalidAnnotation Ignoring invalid annotation aws-load-balancer-cross-zone-load-balancing-enabled, keeping current value: failed to parse bool annotation, service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled: yes: strconv.ParseBool: parsing \"yes\": invalid syntax",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			builder := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "my-svc",
+						Annotations: tt.svcAnnotations,
+					},
+				},
+				annotationParser:                     annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				eventRecorder:                        eventRecorder,
+				crossZoneCostWarning:                 tt.crossZoneCostWarning,
+				lenientAnnotationParsing:             tt.lenientAnnotationParsing,
+				defaultLoadBalancingCrossZoneEnabled: tt.defaultCrossZone,
+				announcementTracker:                  k8s.NewAnnouncementTracker(),
+				announcedTopics:                      sets.NewString(),
+			}
+			attrs, err := builder.buildLoadBalancerAttributes(context.Background())
+			assert.NoError(t, err)
+			crossZoneAttrExists := false
+			for _, attr := range attrs {
+				if attr.Key == lbAttrsLoadBalancingCrossZoneEnabled {
+					crossZoneAttrExists = true
+				}
+			}
+			assert.Equal(t, !tt.wantCrossZoneAttrOmitted, crossZoneAttrExists)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLoadBalancerAttributes_accessLogBucketValidation(t *testing.T) {
+	type validateCall struct {
+		bucket string
+		err    error
+	}
+	tests := []struct {
+		name           string
+		svcAnnotations map[string]string
+		validateCalls  []validateCall
+		wantErr        error
+	}{
+		{
+			name: "access logs disabled",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-access-log-enabled":        "false",
+				"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-name": "my-bucket",
+			},
+		},
+		{
+			name: "access logs enabled with valid bucket",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-access-log-enabled":        "true",
+				"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-name": "my-bucket",
+			},
+			validateCalls: []validateCall{
+				{
+					bucket: "my-bucket",
+				},
+			},
+		},
+		{
+			name: "access logs enabled with invalid bucket",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-access-log-enabled":        "true",
+				"service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-name": "my-bucket",
+			},
+			validateCalls: []validateCall{
+				{
+					bucket: "my-bucket",
+					err:    errors.New("access log bucket my-bucket is in region eu-west-1, which differs from the loadBalancer region us-west-2"),
+				},
+			},
+			wantErr: errors.New("access log bucket my-bucket is in region eu-west-1, which differs from the loadBalancer region us-west-2"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accessLogBucketValidator := mock_aws.NewMockAccessLogBucketValidator(ctrl)
+			for _, call := range tt.validateCalls {
+				accessLogBucketValidator.EXPECT().Validate(gomock.Any(), call.bucket).Return(call.err)
+			}
+			builder := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "my-svc",
+						Annotations: tt.svcAnnotations,
+					},
+				},
+				annotationParser:         annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				eventRecorder:            record.NewFakeRecorder(10),
+				accessLogBucketValidator: accessLogBucketValidator,
+			}
+			_, err := builder.buildLoadBalancerAttributes(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_renderAccessLogS3Prefix(t *testing.T) {
+	tests := []struct {
+		name           string
+		prefixTemplate string
+		want           string
+		wantErr        error
+	}{
+		{
+			name:           "empty prefix",
+			prefixTemplate: "",
+			want:           "",
+		},
+		{
+			name:           "prefix without placeholders",
+			prefixTemplate: "bkt-pfx",
+			want:           "bkt-pfx",
+		},
+		{
+			name:           "prefix with all placeholders",
+			prefixTemplate: "logs/{cluster}/{namespace}/{service}",
+			want:           "logs/my-cluster/awesome-ns/my-svc",
+		},
+		{
+			name:           "prefix with repeated placeholder",
+			prefixTemplate: "{service}-{service}",
+			want:           "my-svc-my-svc",
+		},
+		{
+			name:           "prefix with unsupported placeholder",
+			prefixTemplate: "{namespace}/{port}",
+			wantErr:        errors.New("access log prefix {namespace}/{port} contains unsupported placeholder {port}, supported placeholders: [{cluster}, {namespace}, {service}]"),
+		},
+		{
+			name:           "prefix starts with slash",
+			prefixTemplate: "/{namespace}",
+			wantErr:        errors.New("access log prefix /awesome-ns must not start or end with a slash"),
+		},
+		{
+			name:           "prefix ends with slash",
+			prefixTemplate: "{namespace}/",
+			wantErr:        errors.New("access log prefix awesome-ns/ must not start or end with a slash"),
+		},
+		{
+			name:           "prefix contains AWSLogs",
+			prefixTemplate: "{namespace}/AWSLogs",
+			wantErr:        errors.New("access log prefix awesome-ns/AWSLogs must not contain AWSLogs"),
+		},
+		{
+			name:           "prefix with special characters",
+			prefixTemplate: "env=prod/{cluster}:{namespace}+{service}",
+			want:           "env=prod/my-cluster:awesome-ns+my-svc",
+		},
+		{
+			name:           "prefix contains invalid characters",
+			prefixTemplate: "{namespace} logs",
+			wantErr:        errors.New("access log prefix awesome-ns logs contains invalid characters"),
+		},
+		{
+			name:           "prefix exceeds maximum length",
+			prefixTemplate: strings.Repeat("a", 1020) + "/{service}",
+			wantErr:        errors.New("access log prefix " + strings.Repeat("a", 1020) + "/my-svc exceeds the maximum length of 1024"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				clusterName: "my-cluster",
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "my-svc",
+					},
+				},
+			}
+			got, err := task.renderAccessLogS3Prefix(tt.prefixTemplate)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_buildSubnetMappings(t *testing.T) {
+	type describeAddressesCall struct {
+		req  *ec2.DescribeAddressesInput
+		resp *ec2.DescribeAddressesOutput
+		err  error
+	}
+	tests := []struct {
+		name                   string
+		subnets                []*ec2.Subnet
+		ipAddressType          elbv2.IPAddressType
+		describeAddressesCalls []describeAddressesCall
+		// the EIP allocations already found by previous builds.
+		cachedEIPAllocations []string
+		want                 []elbv2.SubnetMapping
+		svc                  *corev1.Service
+		wantErr              error
+	}{
+		{
+			name: "Multiple subnets",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+				},
+			},
+			svc: &corev1.Service{},
+			want: []elbv2.SubnetMapping{
+				{
+					SubnetID: "subnet-1",
+				},
+				{
+					SubnetID: "subnet-2",
+				},
+			},
+		},
+		{
+			name: "When EIP allocation is configured",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1, eip2",
+					},
+				},
+			},
+			describeAddressesCalls: []describeAddressesCall{
+				{
+					req:  &ec2.DescribeAddressesInput{AllocationIds: aws.StringSlice([]string{"eip1"})},
+					resp: &ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{AllocationId: aws.String("eip1")}}},
+				},
+				{
+					req:  &ec2.DescribeAddressesInput{AllocationIds: aws.StringSlice([]string{"eip2"})},
+					resp: &ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{AllocationId: aws.String("eip2")}}},
+				},
+			},
+			want: []elbv2.SubnetMapping{
+				{
+					SubnetID:     "subnet-1",
+					AllocationID: aws.String("eip1"),
+				},
+				{
+					SubnetID:     "subnet-2",
+					AllocationID: aws.String("eip2"),
+				},
+			},
+		},
+		{
+			name: "When EIP allocation is configured and partially cached",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1, eip2",
+					},
+				},
+			},
+			cachedEIPAllocations: []string{"eip1"},
+			describeAddressesCalls: []describeAddressesCall{
+				{
+					req:  &ec2.DescribeAddressesInput{AllocationIds: aws.StringSlice([]string{"eip2"})},
+					resp: &ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{AllocationId: aws.String("eip2")}}},
+				},
+			},
+			want: []elbv2.SubnetMapping{
+				{
+					SubnetID:     "subnet-1",
+					AllocationID: aws.String("eip1"),
+				},
+				{
+					SubnetID:     "subnet-2",
+					AllocationID: aws.String("eip2"),
+				},
+			},
+		},
+		{
+			name: "When EIP allocation and subnet mismatch",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1",
+					},
+				},
+			},
+			wantErr: errors.New("number of EIP allocations (1) and subnets (2) must match"),
+		},
+		{
+			name: "When EIP allocation is duplicated",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1, eip1",
+					},
+				},
+			},
+			wantErr: errors.New("EIP allocation eip1 is specified more than once in annotation aws-load-balancer-eip-allocations"),
+		},
+		{
+			name: "When EIP allocation is duplicated and mismatch subnets",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1, eip1, eip2",
+					},
+				},
+			},
+			wantErr: errors.New("number of EIP allocations (3) and subnets (2) must match"),
+		},
+		{
+			name: "When EIP allocation is not found in current account or region",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1, eip2",
+					},
+				},
+			},
+			describeAddressesCalls: []describeAddressesCall{
+				{
+					req:  &ec2.DescribeAddressesInput{AllocationIds: aws.StringSlice([]string{"eip1"})},
+					resp: &ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{AllocationId: aws.String("eip1")}}},
+				},
+				{
+					req: &ec2.DescribeAddressesInput{AllocationIds: aws.StringSlice([]string{"eip2"})},
+					err: awserr.New("InvalidAllocationID.NotFound", "The allocation ID 'eip2' does not exist", nil),
+				},
+			},
+			wantErr: errors.New("EIP allocation eip2 not found in current account and region"),
+		},
+		{
+			name: "When EIP allocation describe failed",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1, eip2",
+					},
+				},
+			},
+			describeAddressesCalls: []describeAddressesCall{
+				{
+					req: &ec2.DescribeAddressesInput{AllocationIds: aws.StringSlice([]string{"eip1"})},
+					err: errors.New("some AWS API error"),
+				},
+			},
+			wantErr: errors.New("failed to describe EIP allocation eip1: some AWS API error"),
+		},
+		{
+			name: "When private IPv4 addresses are configured",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+					CidrBlock:        aws.String("192.168.0.0/19"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					CidrBlock:        aws.String("192.168.32.0/19"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-private-ipv4-addresses": "192.168.3.14, 192.168.63.6",
+					},
+				},
+			},
+			want: []elbv2.SubnetMapping{
+				{
+					SubnetID:           "subnet-1",
+					PrivateIPv4Address: aws.String("192.168.3.14"),
+				},
+				{
+					SubnetID:           "subnet-2",
+					PrivateIPv4Address: aws.String("192.168.63.6"),
+				},
+			},
+		},
+		{
+			name: "When private IPv4 addresses and subnet mismatch",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+					CidrBlock:        aws.String("192.168.0.0/19"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					CidrBlock:        aws.String("192.168.32.0/19"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-private-ipv4-addresses": "192.168.3.14",
+					},
+				},
+			},
+			wantErr: errors.New("number of private IPv4 addresses (1) and subnets (2) must match"),
+		},
+		{
+			name: "When private IPv4 address is outside subnet CIDR",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+					CidrBlock:        aws.String("192.168.0.0/19"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					CidrBlock:        aws.String("192.168.32.0/19"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-private-ipv4-addresses": "192.168.3.14, 192.168.3.15",
+					},
+				},
+			},
+			wantErr: errors.New("private IPv4 address 192.168.3.15 not in subnet subnet-2 CIDR 192.168.32.0/19"),
+		},
+		{
+			name: "When private IPv4 address is invalid",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+					CidrBlock:        aws.String("192.168.0.0/19"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					CidrBlock:        aws.String("192.168.32.0/19"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-private-ipv4-addresses": "192.168.3.14, 2600:1f14::1",
+					},
+				},
+			},
+			wantErr: errors.New("invalid private IPv4 address: 2600:1f14::1"),
+		},
+		{
+			name: "When private IPv4 addresses are combined with EIP allocations",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+					CidrBlock:        aws.String("192.168.0.0/19"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					CidrBlock:        aws.String("192.168.32.0/19"),
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-private-ipv4-addresses": "192.168.3.14, 192.168.63.6",
+						"service.beta.kubernetes.io/aws-load-balancer-eip-allocations":        "eip1, eip2",
+					},
+				},
+			},
+			wantErr: errors.New("private IPv4 addresses cannot be combined with EIP allocations"),
+		},
+		{
+			name: "When IPv6 addresses are configured for dualstack",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+					Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+						{Ipv6CidrBlock: aws.String("2600:1f14:f8c:2700::/64")},
+					},
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+						{Ipv6CidrBlock: aws.String("2600:1f14:f8c:2701::/64")},
+					},
+				},
+			},
+			ipAddressType: elbv2.IPAddressTypeDualStack,
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-ipv6-addresses": "2600:1f14:f8c:2700::1, 2600:1f14:f8c:2701::1",
+					},
+				},
+			},
+			want: []elbv2.SubnetMapping{
+				{
+					SubnetID:    "subnet-1",
+					IPv6Address: aws.String("2600:1f14:f8c:2700::1"),
+				},
+				{
+					SubnetID:    "subnet-2",
+					IPv6Address: aws.String("2600:1f14:f8c:2701::1"),
+				},
+			},
+		},
+		{
+			name: "When IPv6 addresses are configured for ipv4",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+					Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+						{Ipv6CidrBlock: aws.String("2600:1f14:f8c:2700::/64")},
+					},
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+						{Ipv6CidrBlock: aws.String("2600:1f14:f8c:2701::/64")},
+					},
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-ipv6-addresses": "2600:1f14:f8c:2700::1, 2600:1f14:f8c:2701::1",
+					},
+				},
+			},
+			wantErr: errors.New("IPv6 addresses can only be specified with dualstack IP address type, got ipv4"),
+		},
+		{
+			name: "When IPv6 addresses and subnet mismatch",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+					Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+						{Ipv6CidrBlock: aws.String("2600:1f14:f8c:2700::/64")},
+					},
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+						{Ipv6CidrBlock: aws.String("2600:1f14:f8c:2701::/64")},
+					},
+				},
+			},
+			ipAddressType: elbv2.IPAddressTypeDualStack,
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-ipv6-addresses": "2600:1f14:f8c:2700::1",
+					},
+				},
+			},
+			wantErr: errors.New("number of IPv6 addresses (1) and subnets (2) must match"),
+		},
+		{
+			name: "When IPv6 address is invalid",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+					Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+						{Ipv6CidrBlock: aws.String("2600:1f14:f8c:2700::/64")},
+					},
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+						{Ipv6CidrBlock: aws.String("2600:1f14:f8c:2701::/64")},
+					},
+				},
+			},
+			ipAddressType: elbv2.IPAddressTypeDualStack,
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-ipv6-addresses": "2600:1f14:f8c:2700::1, 192.168.63.6",
+					},
+				},
+			},
+			wantErr: errors.New("invalid IPv6 address: 192.168.63.6"),
+		},
+		{
+			name: "When IPv6 address is outside subnet IPv6 CIDRs",
+			subnets: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					VpcId:            aws.String("vpc-1"),
+					Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+						{Ipv6CidrBlock: aws.String("2600:1f14:f8c:2700::/64")},
+					},
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+					VpcId:            aws.String("vpc-1"),
+					Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+						{Ipv6CidrBlock: aws.String("2600:1f14:f8c:2701::/64")},
+					},
+				},
+			},
+			ipAddressType: elbv2.IPAddressTypeDualStack,
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-ipv6-addresses": "2600:1f14:f8c:2700::1, 2600:1f14:f8c:2700::2",
+					},
+				},
+			},
+			wantErr: errors.New("IPv6 address 2600:1f14:f8c:2700::2 not in subnet subnet-2 IPv6 CIDRs"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.describeAddressesCalls {
+				ec2Client.EXPECT().DescribeAddressesWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			eipAllocationCache := cache.NewExpiring()
+			for _, allocationID := range tt.cachedEIPAllocations {
+				eipAllocationCache.Set(allocationID, true, time.Minute)
+			}
+			builder := &defaultModelBuildTask{service: tt.svc, annotationParser: annotationParser, ec2Client: ec2Client,
+				eipAllocationCache: eipAllocationCache, eipAllocationCacheTTL: time.Minute}
+			ipAddressType := elbv2.IPAddressTypeIPV4
+			if tt.ipAddressType != "" {
+				ipAddressType = tt.ipAddressType
+			}
+			got, err := builder.buildLoadBalancerSubnetMappings(context.Background(), ipAddressType, tt.subnets)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_resolveLoadBalancerSubnets(t *testing.T) {
+	type resolveSubnetResults struct {
+		subnets []*ec2.Subnet
+		err     error
+	}
+	tests := []struct {
+		name                     string
+		svc                      *corev1.Service
+		scheme                   elbv2.LoadBalancerScheme
+		minAZCount               int
+		singleAZDiscoveryPolicy  string
+		azExpansionPolicy        string
+		existingLBs              []elbv2deploy.LoadBalancerWithTags
+		resolveViaDiscovery      []resolveSubnetResults
+		resolveViaNameOrIDSlilce []resolveSubnetResults
+		wantPreferredSubnetIDs   []string
+		want                     []*ec2.Subnet
+		wantErr                  error
+		wantEvents               []string
+	}{
+		{
+			name:   "subnet auto-discovery keeps subnets of existing loadBalancer",
+			svc:    &corev1.Service{},
+			scheme: elbv2.LoadBalancerSchemeInternal,
+			existingLBs: []elbv2deploy.LoadBalancerWithTags{
+				{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{
+								SubnetId: aws.String("subnet-1"),
+								ZoneName: aws.String("us-west-2a"),
+							},
+						},
+					},
+				},
+			},
+			resolveViaDiscovery: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:  aws.String("subnet-1"),
+							CidrBlock: aws.String("192.168.0.0/19"),
+						},
+					},
+				},
+			},
+			wantPreferredSubnetIDs: []string{"subnet-1"},
+			want: []*ec2.Subnet{
+				{
+					SubnetId:  aws.String("subnet-1"),
+					CidrBlock: aws.String("192.168.0.0/19"),
+				},
+			},
+			wantEvents: []string{
+				"Normal DiscoveredSubnets auto-discovered subnets for internal loadBalancer: subnet-1()",
+			},
+		},
+		{
+			name:              "subnet auto-discovery ignores new availabilityZones of existing loadBalancer with ignore policy",
+			svc:               &corev1.Service{},
+			scheme:            elbv2.LoadBalancerSchemeInternal,
+			azExpansionPolicy: config.AZExpansionPolicyIgnore,
+			existingLBs: []elbv2deploy.LoadBalancerWithTags{
+				{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{
+								SubnetId: aws.String("subnet-1"),
+								ZoneName: aws.String("us-west-2a"),
+							},
+						},
+					},
+				},
+			},
+			resolveViaDiscovery: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-1"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+						{
+							SubnetId:         aws.String("subnet-2"),
+							AvailabilityZone: aws.String("us-west-2b"),
+						},
+					},
+				},
+			},
+			wantPreferredSubnetIDs: []string{"subnet-1"},
+			want: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+				},
+			},
+			wantEvents: []string{
+				"Normal DiscoveredSubnets auto-discovered subnets for internal loadBalancer: subnet-1(us-west-2a)",
+			},
+		},
+		{
+			name:   "subnet auto-discovery",
+			svc:    &corev1.Service{},
+			scheme: elbv2.LoadBalancerSchemeInternal,
+			resolveViaDiscovery: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:  aws.String("subnet-1"),
+							CidrBlock: aws.String("192.168.0.0/19"),
+						},
+					},
+				},
+			},
+			want: []*ec2.Subnet{
+				{
+					SubnetId:  aws.String("subnet-1"),
+					CidrBlock: aws.String("192.168.0.0/19"),
+				},
+			},
+			wantEvents: []string{
+				"Normal DiscoveredSubnets auto-discovered subnets for internal loadBalancer: subnet-1()",
+			},
+		},
+		{
+			name:   "subnet auto-discovery failed",
+			svc:    &corev1.Service{},
+			scheme: elbv2.LoadBalancerSchemeInternal,
+			resolveViaDiscovery: []resolveSubnetResults{
+				{
+					err: errors.New("unable to resolve at least one subnet"),
+				},
+			},
+			wantErr: errors.New("couldn't auto-discover subnets for internal loadBalancer: unable to resolve at least one subnet"),
+		},
+		{
+			name:       "subnet auto-discovery spans minimum availabilityZones",
+			svc:        &corev1.Service{},
+			scheme:     elbv2.LoadBalancerSchemeInternal,
+			minAZCount: 2,
+			resolveViaDiscovery: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-1"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+						{
+							SubnetId:         aws.String("subnet-2"),
+							AvailabilityZone: aws.String("us-west-2b"),
+						},
+					},
+				},
+			},
+			want: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2b"),
+				},
+			},
+			wantEvents: []string{
+				"Normal DiscoveredSubnets auto-discovered subnets for internal loadBalancer: subnet-1(us-west-2a), subnet-2(us-west-2b)",
+			},
+		},
+		{
+			name:       "subnet auto-discovery spans fewer than minimum availabilityZones",
+			svc:        &corev1.Service{},
+			scheme:     elbv2.LoadBalancerSchemeInternetFacing,
+			minAZCount: 2,
+			resolveViaDiscovery: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-1"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+					},
+				},
+			},
+			wantErr: errors.New("auto-discovered subnets for internet-facing loadBalancer span 1 availabilityZones [us-west-2a], fewer than the minimum of 2"),
+		},
+		{
+			name:                    "single availabilityZone auto-discovery with warn policy",
+			svc:                     &corev1.Service{},
+			scheme:                  elbv2.LoadBalancerSchemeInternal,
+			singleAZDiscoveryPolicy: config.SingleAZDiscoveryPolicyWarn,
+			resolveViaDiscovery: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-1"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+						{
+							SubnetId:         aws.String("subnet-2"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+					},
+				},
+			},
+			want: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2a"),
+				},
+			},
+			wantEvents: []string{
+				"Warning SingleAZSubnets auto-discovered subnets for internal loadBalancer span single availabilityZone us-west-2a",
+				"Normal DiscoveredSubnets auto-discovered subnets for internal loadBalancer: subnet-1(us-west-2a), subnet-2(us-west-2a)",
+			},
+		},
+		{
+			name:                    "single availabilityZone auto-discovery with error policy",
+			svc:                     &corev1.Service{},
+			scheme:                  elbv2.LoadBalancerSchemeInternal,
+			singleAZDiscoveryPolicy: config.SingleAZDiscoveryPolicyError,
+			resolveViaDiscovery: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-1"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+						{
+							SubnetId:         aws.String("subnet-2"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+					},
+				},
+			},
+			wantErr: errors.New("auto-discovered subnets for internal loadBalancer span single availabilityZone us-west-2a"),
+		},
+		{
+			name:                    "single availabilityZone auto-discovery with proceed policy",
+			svc:                     &corev1.Service{},
+			scheme:                  elbv2.LoadBalancerSchemeInternal,
+			singleAZDiscoveryPolicy: config.SingleAZDiscoveryPolicyProceed,
+			resolveViaDiscovery: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-1"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+						{
+							SubnetId:         aws.String("subnet-2"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+					},
+				},
+			},
+			want: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					AvailabilityZone: aws.String("us-west-2a"),
+				},
+			},
+			wantEvents: []string{
+				"Normal DiscoveredSubnets auto-discovered subnets for internal loadBalancer: subnet-1(us-west-2a), subnet-2(us-west-2a)",
+			},
+		},
+		{
+			name: "subnet annotation spans fewer than minimum availabilityZones",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-subnets": "subnet-abc, subnet-xyz",
+					},
+				},
+			},
+			scheme:     elbv2.LoadBalancerSchemeInternal,
+			minAZCount: 3,
+			resolveViaNameOrIDSlilce: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-abc"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+						{
+							SubnetId:         aws.String("subnet-xyz"),
+							AvailabilityZone: aws.String("us-west-2b"),
+						},
+					},
+				},
+			},
+			wantErr: errors.New("subnets [subnet-abc subnet-xyz] from annotation aws-load-balancer-subnets span 2 availabilityZones [us-west-2a us-west-2b], fewer than the minimum of 3"),
+		},
+		{
+			name: "subnet annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-subnets": "subnet-abc, Subnet Name XYZ",
+					},
+				},
+			},
+			scheme: elbv2.LoadBalancerSchemeInternal,
+			resolveViaNameOrIDSlilce: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:  aws.String("subnet-abc"),
+							CidrBlock: aws.String("192.168.0.0/19"),
+						},
+						{
+							SubnetId:  aws.String("subnet-xyz"),
+							CidrBlock: aws.String("192.168.0.0/19"),
+						},
+					},
+				},
+			},
+			want: []*ec2.Subnet{
+				{
+					SubnetId:  aws.String("subnet-abc"),
+					CidrBlock: aws.String("192.168.0.0/19"),
+				},
+				{
+					SubnetId:  aws.String("subnet-xyz"),
+					CidrBlock: aws.String("192.168.0.0/19"),
+				},
+			},
+		},
+		{
+			name: "subnet annotation isn't affected by ignore policy",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-subnets": "subnet-abc, subnet-xyz",
+					},
+				},
+			},
+			scheme:            elbv2.LoadBalancerSchemeInternal,
+			azExpansionPolicy: config.AZExpansionPolicyIgnore,
+			existingLBs: []elbv2deploy.LoadBalancerWithTags{
+				{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{
+								SubnetId: aws.String("subnet-abc"),
+								ZoneName: aws.String("us-west-2a"),
+							},
+						},
+					},
+				},
+			},
+			resolveViaNameOrIDSlilce: []resolveSubnetResults{
+				{
+					subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-abc"),
+							AvailabilityZone: aws.String("us-west-2a"),
+						},
+						{
+							SubnetId:         aws.String("subnet-xyz"),
+							AvailabilityZone: aws.String("us-west-2b"),
+						},
+					},
+				},
+			},
+			want: []*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-abc"),
+					AvailabilityZone: aws.String("us-west-2a"),
+				},
+				{
+					SubnetId:         aws.String("subnet-xyz"),
+					AvailabilityZone: aws.String("us-west-2b"),
+				},
+			},
+		},
+		{
+			name: "subnet annotation failed",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-subnets": "subnet-abc",
+					},
+				},
+			},
+			scheme: elbv2.LoadBalancerSchemeInternal,
+			resolveViaNameOrIDSlilce: []resolveSubnetResults{
+				{
+					err: errors.New("Throttling: Rate exceeded"),
+				},
+			},
+			wantErr: errors.New("couldn't resolve subnets [subnet-abc] from annotation aws-load-balancer-subnets: Throttling: Rate exceeded"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			subnetsResolver := mock_networking.NewMockSubnetsResolver(ctrl)
+			for _, call := range tt.resolveViaDiscovery {
+				call := call
+				subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, opts ...networking.SubnetsResolveOption) ([]*ec2.Subnet, error) {
+						resolveOpts := networking.SubnetsResolveOptions{}
+						resolveOpts.ApplyOptions(opts)
+						assert.Equal(t, tt.wantPreferredSubnetIDs, resolveOpts.PreferredSubnetIDs)
+						return call.subnets, call.err
+					})
+			}
+			for _, call := range tt.resolveViaNameOrIDSlilce {
 				subnetsResolver.EXPECT().ResolveViaNameOrIDSlice(gomock.Any(), gomock.Any(), gomock.Any()).Return(call.subnets, call.err)
 			}
+			elbv2TaggingManager := mock_elbv2.NewMockTaggingManager(ctrl)
+			elbv2TaggingManager.EXPECT().ListLoadBalancers(gomock.Any(), gomock.Any()).Return(tt.existingLBs, nil).AnyTimes()
 			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
-			builder := &defaultModelBuildTask{service: tt.svc, annotationParser: annotationParser, subnetsResolver: subnetsResolver}
+			eventRecorder := record.NewFakeRecorder(10)
+			builder := &defaultModelBuildTask{
+				service:                 tt.svc,
+				stack:                   core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "my-svc"}),
+				annotationParser:        annotationParser,
+				subnetsResolver:         subnetsResolver,
+				elbv2TaggingManager:     elbv2TaggingManager,
+				trackingProvider:        tracking.NewDefaultProvider("service.k8s.aws", "my-cluster"),
+				eventRecorder:           eventRecorder,
+				minAZCount:              tt.minAZCount,
+				singleAZDiscoveryPolicy: tt.singleAZDiscoveryPolicy,
+				azExpansionPolicy:       tt.azExpansionPolicy,
+
+				announcementTracker: k8s.NewAnnouncementTracker(),
+				announcedTopics:     sets.NewString(),
+			}
+
+			got, err := builder.resolveLoadBalancerSubnets(context.Background(), tt.scheme)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_resolveLoadBalancerSubnets_discoveredSubnetsEvents(t *testing.T) {
+	subnetsAZ1AZ2 := []*ec2.Subnet{
+		{
+			SubnetId:         aws.String("subnet-2"),
+			AvailabilityZone: aws.String("us-west-2b"),
+		},
+		{
+			SubnetId:         aws.String("subnet-1"),
+			AvailabilityZone: aws.String("us-west-2a"),
+		},
+	}
+	subnetsAZ1AZ3 := []*ec2.Subnet{
+		{
+			SubnetId:         aws.String("subnet-1"),
+			AvailabilityZone: aws.String("us-west-2a"),
+		},
+		{
+			SubnetId:         aws.String("subnet-3"),
+			AvailabilityZone: aws.String("us-west-2c"),
+		},
+	}
+	type resolveCall struct {
+		svcAnnotations    map[string]string
+		discoveredSubnets []*ec2.Subnet
+		nameOrIDSubnets   []*ec2.Subnet
+		wantEvents        []string
+	}
+	tests := []struct {
+		name         string
+		resolveCalls []resolveCall
+	}{
+		{
+			name: "event is emitted once for unchanged subnets",
+			resolveCalls: []resolveCall{
+				{
+					discoveredSubnets: subnetsAZ1AZ2,
+					wantEvents: []string{
+						"Normal DiscoveredSubnets auto-discovered subnets for internet-facing loadBalancer: subnet-1(us-west-2a), subnet-2(us-west-2b)",
+					},
+				},
+				{
+					discoveredSubnets: subnetsAZ1AZ2,
+				},
+				{
+					discoveredSubnets: []*ec2.Subnet{subnetsAZ1AZ2[1], subnetsAZ1AZ2[0]},
+				},
+			},
+		},
+		{
+			name: "event is emitted when subnets changed",
+			resolveCalls: []resolveCall{
+				{
+					discoveredSubnets: subnetsAZ1AZ2,
+					wantEvents: []string{
+						"Normal DiscoveredSubnets auto-discovered subnets for internet-facing loadBalancer: subnet-1(us-west-2a), subnet-2(us-west-2b)",
+					},
+				},
+				{
+					discoveredSubnets: subnetsAZ1AZ3,
+					wantEvents: []string{
+						"Normal DiscoveredSubnets auto-discovered subnets for internet-facing loadBalancer: subnet-1(us-west-2a), subnet-3(us-west-2c)",
+					},
+				},
+			},
+		},
+		{
+			name: "event is emitted again when falling back to discovery from subnet annotation",
+			resolveCalls: []resolveCall{
+				{
+					discoveredSubnets: subnetsAZ1AZ2,
+					wantEvents: []string{
+						"Normal DiscoveredSubnets auto-discovered subnets for internet-facing loadBalancer: subnet-1(us-west-2a), subnet-2(us-west-2b)",
+					},
+				},
+				{
+					svcAnnotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-subnets": "subnet-1, subnet-2",
+					},
+					nameOrIDSubnets: subnetsAZ1AZ2,
+				},
+				{
+					discoveredSubnets: subnetsAZ1AZ2,
+					wantEvents: []string{
+						"Normal DiscoveredSubnets auto-discovered subnets for internet-facing loadBalancer: subnet-1(us-west-2a), subnet-2(us-west-2b)",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tracker := k8s.NewAnnouncementTracker()
+			for _, call := range tt.resolveCalls {
+				subnetsResolver := mock_networking.NewMockSubnetsResolver(ctrl)
+				if call.nameOrIDSubnets != nil {
+					subnetsResolver.EXPECT().ResolveViaNameOrIDSlice(gomock.Any(), gomock.Any(), gomock.Any()).Return(call.nameOrIDSubnets, nil)
+				} else {
+					subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any()).Return(call.discoveredSubnets, nil)
+				}
+				eventRecorder := record.NewFakeRecorder(10)
+				builder := &defaultModelBuildTask{
+					service: &corev1.Service{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:   "awesome-ns",
+							Name:        "my-svc",
+							Annotations: call.svcAnnotations,
+						},
+					},
+					annotationParser:    annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+					subnetsResolver:     subnetsResolver,
+					eventRecorder:       eventRecorder,
+					announcementTracker: tracker,
+					announcedTopics:     sets.NewString(),
+
+					existingLoadBalancerFetched: true,
+				}
+				_, err := builder.resolveLoadBalancerSubnets(context.Background(), elbv2.LoadBalancerSchemeInternetFacing)
+				assert.NoError(t, err)
+				// announcements not made are dropped after each successful build.
+				tracker.Retain("awesome-ns/my-svc", builder.announcedTopics)
+				close(eventRecorder.Events)
+				var gotEvents []string
+				for event := range eventRecorder.Events {
+					gotEvents = append(gotEvents, event)
+				}
+				assert.Equal(t, call.wantEvents, gotEvents)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_buildLoadBalancerSecurityGroups(t *testing.T) {
+	type resolveViaNameOrIDCall struct {
+		sgNameOrIDs []string
+		sgIDs       []string
+		err         error
+	}
+	lbWithoutSGs := &elbv2deploy.LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			LoadBalancerName: aws.String("k8s-awesome-ns-svc-1"),
+		},
+	}
+	lbWithSGs := &elbv2deploy.LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			LoadBalancerName: aws.String("k8s-awesome-ns-svc-1"),
+			SecurityGroups:   aws.StringSlice([]string{"sg-1"}),
+		},
+	}
+	tests := []struct {
+		name                     string
+		annotations              map[string]string
+		sourceRanges             []string
+		existingLB               *elbv2deploy.LoadBalancerWithTags
+		resolveViaNameOrIDCalls  []resolveViaNameOrIDCall
+		want                     []core.StringToken
+		wantLBSecurityGroupIDs   []string
+		wantManageBackendSGRules bool
+		wantManagedSGIngress     []ec2model.IPPermission
+		wantErr                  error
+	}{
+		{
+			name:        "securityGroups not specified",
+			annotations: map[string]string{},
+			want:        nil,
+		},
+		{
+			name: "securityGroups specified by name and ID",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-security-groups": "sg-1, my-sg",
+			},
+			resolveViaNameOrIDCalls: []resolveViaNameOrIDCall{
+				{
+					sgNameOrIDs: []string{"sg-1", "my-sg"},
+					sgIDs:       []string{"sg-1", "sg-2"},
+				},
+			},
+			want:                     []core.StringToken{core.LiteralStringToken("sg-1"), core.LiteralStringToken("sg-2")},
+			wantLBSecurityGroupIDs:   []string{"sg-1", "sg-2"},
+			wantManageBackendSGRules: true,
+		},
+		{
+			name: "securityGroups specified without managing backend rules",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-security-groups":                     "sg-1",
+				"service.beta.kubernetes.io/aws-load-balancer-manage-backend-security-group-rules": "false",
+			},
+			resolveViaNameOrIDCalls: []resolveViaNameOrIDCall{
+				{
+					sgNameOrIDs: []string{"sg-1"},
+					sgIDs:       []string{"sg-1"},
+				},
+			},
+			want:                     []core.StringToken{core.LiteralStringToken("sg-1")},
+			wantLBSecurityGroupIDs:   []string{"sg-1"},
+			wantManageBackendSGRules: false,
+		},
+		{
+			name: "securityGroups specified for existing loadBalancer with securityGroups",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-security-groups": "sg-1,sg-2",
+			},
+			existingLB: lbWithSGs,
+			resolveViaNameOrIDCalls: []resolveViaNameOrIDCall{
+				{
+					sgNameOrIDs: []string{"sg-1", "sg-2"},
+					sgIDs:       []string{"sg-1", "sg-2"},
+				},
+			},
+			want:                     []core.StringToken{core.LiteralStringToken("sg-1"), core.LiteralStringToken("sg-2")},
+			wantLBSecurityGroupIDs:   []string{"sg-1", "sg-2"},
+			wantManageBackendSGRules: true,
+		},
+		{
+			name: "securityGroups specified for existing loadBalancer without securityGroups",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-security-groups": "sg-1",
+			},
+			existingLB: lbWithoutSGs,
+			resolveViaNameOrIDCalls: []resolveViaNameOrIDCall{
+				{
+					sgNameOrIDs: []string{"sg-1"},
+					sgIDs:       []string{"sg-1"},
+				},
+			},
+			wantErr: errors.New("securityGroups cannot be attached to existing loadBalancer k8s-awesome-ns-svc-1 created without securityGroups, recreate the service to attach them"),
+		},
+		{
+			name:        "securityGroups not specified for existing loadBalancer with securityGroups",
+			annotations: map[string]string{},
+			existingLB:  lbWithSGs,
+			wantErr:     errors.New("securityGroups cannot be detached from existing loadBalancer k8s-awesome-ns-svc-1 created with securityGroups, recreate the service to detach them"),
+		},
+		{
+			name: "securityGroups specified with source ranges",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-security-groups": "sg-1",
+			},
+			sourceRanges: []string{"10.0.0.0/16", "2001:db8::/32"},
+			resolveViaNameOrIDCalls: []resolveViaNameOrIDCall{
+				{
+					sgNameOrIDs: []string{"sg-1"},
+					sgIDs:       []string{"sg-1"},
+				},
+			},
+			wantLBSecurityGroupIDs:   []string{"sg-1"},
+			wantManageBackendSGRules: true,
+			wantManagedSGIngress: []ec2model.IPPermission{
+				{
+					IPProtocol: "tcp",
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					IPRanges:   []ec2model.IPRange{{CIDRIP: "10.0.0.0/16"}},
+				},
+				{
+					IPProtocol: "tcp",
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					IPv6Range:  []ec2model.IPv6Range{{CIDRIPv6: "2001:db8::/32"}},
+				},
+				{
+					IPProtocol: "udp",
+					FromPort:   aws.Int64(53),
+					ToPort:     aws.Int64(53),
+					IPRanges:   []ec2model.IPRange{{CIDRIP: "10.0.0.0/16"}},
+				},
+				{
+					IPProtocol: "udp",
+					FromPort:   aws.Int64(53),
+					ToPort:     aws.Int64(53),
+					IPv6Range:  []ec2model.IPv6Range{{CIDRIPv6: "2001:db8::/32"}},
+				},
+			},
+		},
+		{
+			name: "securityGroups specified with source prefixLists",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-security-groups":             "sg-1",
+				"service.beta.kubernetes.io/aws-load-balancer-security-group-prefix-lists": "pl-1a2b3c4d",
+			},
+			resolveViaNameOrIDCalls: []resolveViaNameOrIDCall{
+				{
+					sgNameOrIDs: []string{"sg-1"},
+					sgIDs:       []string{"sg-1"},
+				},
+			},
+			wantLBSecurityGroupIDs:   []string{"sg-1"},
+			wantManageBackendSGRules: true,
+			wantManagedSGIngress: []ec2model.IPPermission{
+				{
+					IPProtocol:  "tcp",
+					FromPort:    aws.Int64(80),
+					ToPort:      aws.Int64(80),
+					PrefixLists: []ec2model.PrefixList{{ListID: "pl-1a2b3c4d"}},
+				},
+				{
+					IPProtocol:  "udp",
+					FromPort:    aws.Int64(53),
+					ToPort:      aws.Int64(53),
+					PrefixLists: []ec2model.PrefixList{{ListID: "pl-1a2b3c4d"}},
+				},
+			},
+		},
+		{
+			name: "securityGroups specified with invalid source prefixLists",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-security-groups":             "sg-1",
+				"service.beta.kubernetes.io/aws-load-balancer-security-group-prefix-lists": "my-pl",
+			},
+			resolveViaNameOrIDCalls: []resolveViaNameOrIDCall{
+				{
+					sgNameOrIDs: []string{"sg-1"},
+					sgIDs:       []string{"sg-1"},
+				},
+			},
+			wantErr: errors.New("invalid prefixList ID my-pl in annotation aws-load-balancer-security-group-prefix-lists, must be in format pl-xxxxxxxx"),
+		},
+		{
+			name: "manage backend rules without securityGroups",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-manage-backend-security-group-rules": "true",
+			},
+			wantErr: errors.New("annotation aws-load-balancer-manage-backend-security-group-rules requires securityGroups specified via annotation aws-load-balancer-security-groups, securityGroups won't be created for NLB automatically"),
+		},
+		{
+			name: "empty securityGroups",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-security-groups": "",
+			},
+			wantErr: errors.New("annotation aws-load-balancer-security-groups must specify at least one securityGroup"),
+		},
+		{
+			name: "securityGroups couldn't be resolved",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-security-groups": "my-sg",
+			},
+			resolveViaNameOrIDCalls: []resolveViaNameOrIDCall{
+				{
+					sgNameOrIDs: []string{"my-sg"},
+					err:         errors.New("couldn't found all securityGroups, nameOrIDs: [my-sg], found: []"),
+				},
+			},
+			wantErr: errors.New("couldn't resolve securityGroups [my-sg] from annotation aws-load-balancer-security-groups: couldn't found all securityGroups, nameOrIDs: [my-sg], found: []"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sgResolver := mock_networking.NewMockSecurityGroupResolver(ctrl)
+			for _, call := range tt.resolveViaNameOrIDCalls {
+				sgResolver.EXPECT().ResolveViaNameOrID(gomock.Any(), call.sgNameOrIDs).Return(call.sgIDs, call.err)
+			}
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "svc-1"})
+			builder := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "svc-1",
+						Annotations: tt.annotations,
+					},
+					Spec: corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{
+							{
+								Port:     80,
+								Protocol: corev1.ProtocolTCP,
+							},
+							{
+								Port:     53,
+								Protocol: corev1.ProtocolUDP,
+							},
+						},
+						LoadBalancerSourceRanges: tt.sourceRanges,
+					},
+				},
+				annotationParser:            annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				sgResolver:                  sgResolver,
+				stack:                       stack,
+				clusterName:                 "my-cluster",
+				existingLoadBalancer:        tt.existingLB,
+				existingLoadBalancerFetched: true,
+			}
+			got, err := builder.buildLoadBalancerSecurityGroups(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantLBSecurityGroupIDs, builder.lbSecurityGroupIDs)
+				assert.Equal(t, tt.wantManageBackendSGRules, builder.manageBackendSGRules)
+				var resSGs []*ec2model.SecurityGroup
+				assert.NoError(t, stack.ListResources(&resSGs))
+				if tt.wantManagedSGIngress != nil {
+					assert.Len(t, resSGs, 1)
+					assert.Equal(t, "k8s-awesomen-svc1-808d4fe490", resSGs[0].Spec.GroupName)
+					assert.Equal(t, tt.wantManagedSGIngress, resSGs[0].Spec.Ingress)
+					assert.Equal(t, len(tt.wantLBSecurityGroupIDs)+1, len(got))
+				} else {
+					assert.Empty(t, resSGs)
+					assert.Equal(t, tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_buildLoadBalancerEnforceSGInboundRulesOnPrivateLinkTraffic(t *testing.T) {
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		securityGroups []core.StringToken
+		want           *string
+		wantErr        error
+	}{
+		{
+			name:           "not specified",
+			annotations:    map[string]string{},
+			securityGroups: []core.StringToken{core.LiteralStringToken("sg-1")},
+			want:           nil,
+		},
+		{
+			name: "enforced on PrivateLink traffic",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-enforce-security-group-inbound-rules-on-private-link-traffic": "on",
+			},
+			securityGroups: []core.StringToken{core.LiteralStringToken("sg-1")},
+			want:           aws.String("on"),
+		},
+		{
+			name: "not enforced on PrivateLink traffic",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-enforce-security-group-inbound-rules-on-private-link-traffic": "off",
+			},
+			securityGroups: []core.StringToken{core.LiteralStringToken("sg-1")},
+			want:           aws.String("off"),
+		},
+		{
+			name: "invalid value",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-enforce-security-group-inbound-rules-on-private-link-traffic": "true",
+			},
+			securityGroups: []core.StringToken{core.LiteralStringToken("sg-1")},
+			wantErr:        errors.New("invalid value true, must be on or off"),
+		},
+		{
+			name: "specified without securityGroups",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-enforce-security-group-inbound-rules-on-private-link-traffic": "off",
+			},
+			wantErr: errors.New("annotation aws-load-balancer-enforce-security-group-inbound-rules-on-private-link-traffic requires securityGroups specified via annotation aws-load-balancer-security-groups"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "svc-1",
+						Annotations: tt.annotations,
+					},
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+			}
+			got, err := builder.buildLoadBalancerEnforceSGInboundRulesOnPrivateLinkTraffic(context.Background(), tt.securityGroups)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_buildLoadBalancerIPAddressType(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        elbv2.IPAddressType
+		wantErr     error
+	}{
+		{
+			name:        "ipv4 by default",
+			annotations: map[string]string{},
+			want:        elbv2.IPAddressTypeIPV4,
+		},
+		{
+			name: "ipv4",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ip-address-type": "ipv4",
+			},
+			want: elbv2.IPAddressTypeIPV4,
+		},
+		{
+			name: "dualstack",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ip-address-type": "dualstack",
+			},
+			want: elbv2.IPAddressTypeDualStack,
+		},
+		{
+			name: "invalid value",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ip-address-type": "ipv6",
+			},
+			wantErr: errors.New("unknown IPAddressType: ipv6"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "svc-1",
+						Annotations: tt.annotations,
+					},
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+			}
+			got, err := builder.buildLoadBalancerIPAddressType(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
 
-			builder.resolveLoadBalancerSubnets(context.Background(), tt.scheme)
+func Test_defaultModelBuilderTask_buildAdditionalResourceTags(t *testing.T) {
+	tests := []struct {
+		name           string
+		svcAnnotations map[string]string
+		defaultTags    map[string]string
+		want           map[string]string
+	}{
+		{
+			name:           "without tags",
+			svcAnnotations: map[string]string{},
+			want:           map[string]string{},
+		},
+		{
+			name:           "default tags only",
+			svcAnnotations: map[string]string{},
+			defaultTags: map[string]string{
+				"kubernetes.io/cluster/my-cluster": "owned",
+			},
+			want: map[string]string{
+				"kubernetes.io/cluster/my-cluster": "owned",
+			},
+		},
+		{
+			name: "user-specified tags override default tags",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "cost-center=team-a, env=prod",
+			},
+			defaultTags: map[string]string{
+				"kubernetes.io/cluster/my-cluster": "owned",
+				"cost-center":                      "platform",
+			},
+			want: map[string]string{
+				"kubernetes.io/cluster/my-cluster": "owned",
+				"cost-center":                      "team-a",
+				"env":                              "prod",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "my-svc",
+						Annotations: tt.svcAnnotations,
+					},
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				defaultTags:      tt.defaultTags,
+			}
+			got, err := task.buildAdditionalResourceTags(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
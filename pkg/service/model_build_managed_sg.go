@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	corev1 "k8s.io/api/core/v1"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"strings"
+)
+
+const (
+	resourceIDManagedSecurityGroup = "ManagedLBSecurityGroup"
+)
+
+// buildManagedSecurityGroup builds the frontend securityGroup that restricts client traffic to the listeners by source ranges and source prefixLists.
+// it's only built for NLBs with securityGroups attached, since traffic to NLBs with securityGroups bypasses the source ranges on backends.
+func (t *defaultModelBuildTask) buildManagedSecurityGroup(ctx context.Context, sourceRanges []string, prefixListIDs []string) (*ec2model.SecurityGroup, error) {
+	tags, err := t.buildAdditionalResourceTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sgSpec := ec2model.SecurityGroupSpec{
+		GroupName:   t.buildManagedSecurityGroupName(ctx),
+		Description: "[k8s] Managed SecurityGroup for LoadBalancer",
+		Tags:        tags,
+		Ingress:     t.buildManagedSecurityGroupIngressPermissions(ctx, sourceRanges, prefixListIDs),
+	}
+	return ec2model.NewSecurityGroup(t.stack, resourceIDManagedSecurityGroup, sgSpec), nil
+}
+
+func (t *defaultModelBuildTask) buildManagedSecurityGroupName(_ context.Context) string {
+	uuidHash := sha256.New()
+	_, _ = uuidHash.Write([]byte(t.clusterName))
+	_, _ = uuidHash.Write([]byte(t.service.Namespace))
+	_, _ = uuidHash.Write([]byte(t.service.Name))
+	uuid := hex.EncodeToString(uuidHash.Sum(nil))
+
+	sanitizedNamespace := invalidLoadBalancerNamePattern.ReplaceAllString(t.service.Namespace, "")
+	sanitizedName := invalidLoadBalancerNamePattern.ReplaceAllString(t.service.Name, "")
+	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
+}
+
+// buildManagedSecurityGroupIngressPermissions builds the permissions that allow traffic from source ranges and source prefixLists to each service port.
+func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ context.Context, sourceRanges []string, prefixListIDs []string) []ec2model.IPPermission {
+	var permissions []ec2model.IPPermission
+	for _, port := range t.service.Spec.Ports {
+		ipProtocol := "tcp"
+		if port.Protocol == corev1.ProtocolUDP {
+			ipProtocol = "udp"
+		}
+		for _, cidr := range sourceRanges {
+			permission := ec2model.IPPermission{
+				IPProtocol: ipProtocol,
+				FromPort:   awssdk.Int64(int64(port.Port)),
+				ToPort:     awssdk.Int64(int64(port.Port)),
+			}
+			if strings.Contains(cidr, ":") {
+				permission.IPv6Range = []ec2model.IPv6Range{{CIDRIPv6: cidr}}
+			} else {
+				permission.IPRanges = []ec2model.IPRange{{CIDRIP: cidr}}
+			}
+			permissions = append(permissions, permission)
+		}
+		for _, prefixListID := range prefixListIDs {
+			permissions = append(permissions, ec2model.IPPermission{
+				IPProtocol:  ipProtocol,
+				FromPort:    awssdk.Int64(int64(port.Port)),
+				ToPort:      awssdk.Int64(int64(port.Port)),
+				PrefixLists: []ec2model.PrefixList{{ListID: prefixListID}},
+			})
+		}
+	}
+	return permissions
+}
@@ -11,19 +11,47 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"regexp"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
-	tgAttrsProxyProtocolV2Enabled  = "proxy_protocol_v2.enabled"
-	tgAttrsPreserveClientIPEnabled = "preserve_client_ip.enabled"
-	healthCheckPortTrafficPort     = "traffic-port"
+	tgAttrsProxyProtocolV2Enabled                          = "proxy_protocol_v2.enabled"
+	tgAttrsPreserveClientIPEnabled                         = "preserve_client_ip.enabled"
+	tgAttrsDeregistrationDelayConnectionTerminationEnabled = "deregistration_delay.connection_termination.enabled"
+	healthCheckPortTrafficPort                             = "traffic-port"
+
+	maxTargetGroupNameLength = 32
+	// the hash portion of targetGroup names for single port services, it's truncated to fit long name prefix.
+	targetGroupNameHashLength = 10
+	// the minimum hash portion of targetGroup names, namespace and name are truncated further to keep it for long name prefix.
+	minTargetGroupNameHashLength = 8
+	defaultTargetGroupNamePrefix = "k8s"
+
+	// ICMP "destination unreachable - fragmentation needed" and ICMPv6 "packet too big" messages are required by path MTU discovery.
+	icmpTypeDestinationUnreachable = 3
+	icmpCodeFragmentationNeeded    = 4
+	icmpv6TypePacketTooBig         = 2
+	icmpv6CodePacketTooBig         = 0
+
+	// the range of HTTP codes supported by NLB health check success codes.
+	healthCheckSuccessCodeMin = 200
+	healthCheckSuccessCodeMax = 599
+	// healthCheckDefaultSuccessCodes is the default success codes of HTTP and HTTPS health checks.
+	healthCheckDefaultSuccessCodes = "200-399"
+)
+
+// booleanTargetGroupAttributes are the targetGroupAttributes that only accept boolean values.
+var booleanTargetGroupAttributes = sets.NewString(
+	tgAttrsDeregistrationDelayConnectionTerminationEnabled,
 )
 
 func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev1.ServicePort, tgProtocol elbv2model.Protocol) (*elbv2model.TargetGroup, error) {
@@ -32,7 +60,7 @@ func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev
 	if targetGroup, exists := t.tgByResID[tgResourceID]; exists {
 		return targetGroup, nil
 	}
-	healthCheckConfig, err := t.buildTargetGroupHealthCheckConfig(ctx)
+	healthCheckConfig, err := t.buildTargetGroupHealthCheckConfig(ctx, port)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +82,9 @@ func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev
 	}
 	targetGroup := elbv2model.NewTargetGroup(t.stack, tgResourceID, tgSpec)
 	t.tgByResID[tgResourceID] = targetGroup
-	_ = t.buildTargetGroupBinding(ctx, targetGroup, preserveClientIP, port, healthCheckConfig)
+	if _, err := t.buildTargetGroupBinding(ctx, targetGroup, preserveClientIP, port, healthCheckConfig); err != nil {
+		return nil, err
+	}
 	return targetGroup, nil
 }
 
@@ -64,28 +94,100 @@ func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context, tgProt
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
+	ipAddressType, err := t.buildTargetGroupIPAddressType(ctx, targetType)
+	if err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
+	recreationConfirmed, err := t.buildTargetGroupRecreationConfirmed(ctx)
+	if err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
 	targetPort := t.buildTargetGroupPort(ctx, targetType, port)
-	tgName := t.buildTargetGroupName(ctx, intstr.FromInt(int(port.Port)), targetPort, targetType, tgProtocol, healthCheckConfig)
+	tgName := t.buildTargetGroupName(ctx, intstr.FromInt(int(port.Port)), targetPort, targetType, tgProtocol, ipAddressType, healthCheckConfig)
 	return elbv2model.TargetGroupSpec{
 		Name:                  tgName,
 		TargetType:            targetType,
 		Port:                  targetPort,
 		Protocol:              tgProtocol,
+		IPAddressType:         ipAddressType,
 		HealthCheckConfig:     healthCheckConfig,
 		TargetGroupAttributes: tgAttrs,
 		Tags:                  tags,
+		RecreationConfirmed:   recreationConfirmed,
 	}, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Context) (*elbv2model.TargetGroupHealthCheckConfig, error) {
-	healthCheckProtocol, err := t.buildTargetGroupHealthCheckProtocol(ctx)
+// buildTargetGroupRecreationConfirmed builds whether targetGroups can be recreated when their immutable fields change.
+func (t *defaultModelBuildTask) buildTargetGroupRecreationConfirmed(_ context.Context) (bool, error) {
+	rawRecreationConfirmed := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixTargetGroupRecreation, &rawRecreationConfirmed, t.service.Annotations); err != nil {
+		return false, err
+	}
+	return rawRecreationConfirmed, nil
+}
+
+// buildTargetGroupIPAddressType builds the IP address type of targetGroup, nil is returned if not specified so that ELBV2 defaults it to ipv4.
+// ipv6 is only supported for targetGroups with ip targetType behind dualstack loadBalancers.
+func (t *defaultModelBuildTask) buildTargetGroupIPAddressType(_ context.Context, targetType elbv2model.TargetType) (*elbv2model.TargetGroupIPAddressType, error) {
+	rawIPAddressType := ""
+	if !t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixTargetIPAddressType, &rawIPAddressType, t.service.Annotations) {
+		return nil, nil
+	}
+	switch rawIPAddressType {
+	case string(elbv2model.TargetGroupIPAddressTypeIPv4):
+		ipAddressType := elbv2model.TargetGroupIPAddressTypeIPv4
+		return &ipAddressType, nil
+	case string(elbv2model.TargetGroupIPAddressTypeIPv6):
+		if targetType != elbv2model.TargetTypeIP {
+			return nil, t.invalidAnnotationError(annotations.SvcLBSuffixTargetIPAddressType, errors.Errorf("target IP address type %v is only supported with target type %v, got %v",
+				rawIPAddressType, elbv2model.TargetTypeIP, targetType))
+		}
+		if t.loadBalancer == nil || t.loadBalancer.Spec.IPAddressType == nil || *t.loadBalancer.Spec.IPAddressType != elbv2model.IPAddressTypeDualStack {
+			return nil, t.invalidAnnotationError(annotations.SvcLBSuffixTargetIPAddressType, errors.Errorf("target IP address type %v requires %v load balancer IP address type",
+				rawIPAddressType, elbv2model.IPAddressTypeDualStack))
+		}
+		ipAddressType := elbv2model.TargetGroupIPAddressTypeIPv6
+		return &ipAddressType, nil
+	default:
+		return nil, t.invalidAnnotationError(annotations.SvcLBSuffixTargetIPAddressType, errors.Errorf("unsupported target IP address type %v, must be within [%v, %v]",
+			rawIPAddressType, elbv2model.TargetGroupIPAddressTypeIPv4, elbv2model.TargetGroupIPAddressTypeIPv6))
+	}
+}
+
+// healthCheckOverride is the health check configuration for a service port, which overrides the service level health check configuration.
+type healthCheckOverride struct {
+	Protocol                *string `json:"protocol,omitempty"`
+	IntervalSeconds         *int64  `json:"intervalSeconds,omitempty"`
+	TimeoutSeconds          *int64  `json:"timeoutSeconds,omitempty"`
+	HealthyThresholdCount   *int64  `json:"healthyThresholdCount,omitempty"`
+	UnhealthyThresholdCount *int64  `json:"unhealthyThresholdCount,omitempty"`
+}
+
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Context, port corev1.ServicePort) (*elbv2model.TargetGroupHealthCheckConfig, error) {
+	hcOverride, err := t.buildTargetGroupHealthCheckOverride(ctx, port)
 	if err != nil {
 		return nil, err
 	}
+	rawHealthCheckProtocol := string(t.defaultHealthCheckProtocol)
+	t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixHCProtocol, &rawHealthCheckProtocol, t.service.Annotations)
+	if hcOverride.Protocol != nil {
+		rawHealthCheckProtocol = *hcOverride.Protocol
+	}
+	healthCheckProtocol, err := t.buildTargetGroupHealthCheckProtocol(ctx, rawHealthCheckProtocol)
+	if err != nil {
+		if hcOverride.Protocol != nil {
+			return nil, t.invalidAnnotationError(annotations.SvcLBSuffixHCConfig, err)
+		}
+		return nil, t.invalidAnnotationError(annotations.SvcLBSuffixHCProtocol, err)
+	}
 	var healthCheckPathPtr *string
 	if healthCheckProtocol != elbv2model.ProtocolTCP {
 		healthCheckPathPtr = t.buildTargetGroupHealthCheckPath(ctx)
 	}
+	healthCheckMatcher, err := t.buildTargetGroupHealthCheckMatcher(ctx, healthCheckProtocol)
+	if err != nil {
+		return nil, err
+	}
 	healthCheckPort, err := t.buildTargetGroupHealthCheckPort(ctx)
 	if err != nil {
 		return nil, err
@@ -94,33 +196,71 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Co
 	if err != nil {
 		return nil, err
 	}
-	timeoutSeconds, err := t.buildTargetGroupHealthCheckTimeoutSeconds(ctx)
+	if hcOverride.IntervalSeconds != nil {
+		intervalSeconds = *hcOverride.IntervalSeconds
+	}
+	// timeout and threshold counts are nil if their annotation is ignored, so that the currently applied values are kept.
+	timeoutSeconds, err := t.buildTargetGroupHealthCheckTimeoutSeconds(ctx, healthCheckProtocol)
 	if err != nil {
 		return nil, err
 	}
+	if hcOverride.TimeoutSeconds != nil {
+		timeoutSeconds = hcOverride.TimeoutSeconds
+	}
 	healthyThresholdCount, err := t.buildTargetGroupHealthCheckHealthyThresholdCount(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if hcOverride.HealthyThresholdCount != nil {
+		healthyThresholdCount = hcOverride.HealthyThresholdCount
+	}
 	unhealthyThresholdCount, err := t.buildTargetGroupHealthCheckUnhealthyThresholdCount(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if hcOverride.UnhealthyThresholdCount != nil {
+		unhealthyThresholdCount = hcOverride.UnhealthyThresholdCount
+	}
 	return &elbv2model.TargetGroupHealthCheckConfig{
 		Port:                    &healthCheckPort,
 		Protocol:                &healthCheckProtocol,
 		Path:                    healthCheckPathPtr,
+		Matcher:                 healthCheckMatcher,
 		IntervalSeconds:         &intervalSeconds,
-		TimeoutSeconds:          &timeoutSeconds,
-		HealthyThresholdCount:   &healthyThresholdCount,
-		UnhealthyThresholdCount: &unhealthyThresholdCount,
+		TimeoutSeconds:          timeoutSeconds,
+		HealthyThresholdCount:   healthyThresholdCount,
+		UnhealthyThresholdCount: unhealthyThresholdCount,
 	}, nil
 }
 
+// buildTargetGroupHealthCheckOverride builds the health check override for service port from the health check config annotation.
+// the annotation maps service port names to healthCheckOverride, an empty override is returned if the port isn't listed.
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckOverride(_ context.Context, port corev1.ServicePort) (healthCheckOverride, error) {
+	var hcOverrideByPortName map[string]healthCheckOverride
+	exists, err := t.annotationParser.ParseJSONAnnotation(annotations.SvcLBSuffixHCConfig, &hcOverrideByPortName, t.service.Annotations)
+	if err != nil {
+		return healthCheckOverride{}, err
+	}
+	if !exists {
+		return healthCheckOverride{}, nil
+	}
+	svcPortNames := sets.NewString()
+	for _, svcPort := range t.service.Spec.Ports {
+		svcPortNames.Insert(svcPort.Name)
+	}
+	for portName := range hcOverrideByPortName {
+		if !svcPortNames.Has(portName) {
+			return healthCheckOverride{}, t.invalidAnnotationError(annotations.SvcLBSuffixHCConfig, errors.Errorf("unknown service port %v in health check config", portName))
+		}
+	}
+	return hcOverrideByPortName[port.Name], nil
+}
+
 var invalidTargetGroupNamePattern = regexp.MustCompile("[[:^alnum:]]")
 
 func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context, svcPort intstr.IntOrString, tgPort int64,
-	targetType elbv2model.TargetType, tgProtocol elbv2model.Protocol, hc *elbv2model.TargetGroupHealthCheckConfig) string {
+	targetType elbv2model.TargetType, tgProtocol elbv2model.Protocol, ipAddressType *elbv2model.TargetGroupIPAddressType,
+	hc *elbv2model.TargetGroupHealthCheckConfig) string {
 	healthCheckProtocol := string(elbv2model.ProtocolTCP)
 	healthCheckInterval := strconv.FormatInt(t.defaultHealthCheckInterval, 10)
 	if hc.Protocol != nil {
@@ -138,11 +278,42 @@ func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context, svcPort
 	_, _ = uuidHash.Write([]byte(tgProtocol))
 	_, _ = uuidHash.Write([]byte(healthCheckProtocol))
 	_, _ = uuidHash.Write([]byte(healthCheckInterval))
+	// ipAddressType is immutable, ipv6 targetGroups are named differently so that they can be created alongside existing ipv4 ones.
+	if ipAddressType != nil && *ipAddressType == elbv2model.TargetGroupIPAddressTypeIPv6 {
+		_, _ = uuidHash.Write([]byte(*ipAddressType))
+	}
 	uuid := hex.EncodeToString(uuidHash.Sum(nil))
 
+	namePrefix := defaultTargetGroupNamePrefix
+	if t.targetGroupNamePrefix != "" {
+		namePrefix = t.targetGroupNamePrefix
+	}
 	sanitizedNamespace := invalidTargetGroupNamePattern.ReplaceAllString(t.service.Namespace, "")
 	sanitizedName := invalidTargetGroupNamePattern.ReplaceAllString(t.service.Name, "")
-	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
+	// for services with multiple ports, the service port is included so that each port's targetGroup is identifiable.
+	// the uuid fills remaining length, which is at least 8 characters given service port has at most 5 digits.
+	if len(t.service.Spec.Ports) > 1 {
+		segmentLength := targetGroupNameSegmentLength(6, len(namePrefix)+len(svcPort.String())+4)
+		tgNamePrefix := fmt.Sprintf("%s-%.*s-%.*s-%v-", namePrefix, segmentLength, sanitizedNamespace, segmentLength, sanitizedName, svcPort.String())
+		return fmt.Sprintf("%s%.*s", tgNamePrefix, maxTargetGroupNameLength-len(tgNamePrefix), uuid)
+	}
+	segmentLength := targetGroupNameSegmentLength(8, len(namePrefix)+3)
+	tgNamePrefix := fmt.Sprintf("%s-%.*s-%.*s-", namePrefix, segmentLength, sanitizedNamespace, segmentLength, sanitizedName)
+	hashLength := targetGroupNameHashLength
+	if maxTargetGroupNameLength-len(tgNamePrefix) < hashLength {
+		hashLength = maxTargetGroupNameLength - len(tgNamePrefix)
+	}
+	return fmt.Sprintf("%s%.*s", tgNamePrefix, hashLength, uuid)
+}
+
+// targetGroupNameSegmentLength returns the length namespace and name are truncated to within targetGroup names, given the length of other parts except the hash.
+// it's defaultLength unless that leaves fewer than minTargetGroupNameHashLength characters for the hash.
+func targetGroupNameSegmentLength(defaultLength int, otherPartsLength int) int {
+	segmentLength := (maxTargetGroupNameLength - otherPartsLength - minTargetGroupNameHashLength) / 2
+	if segmentLength < defaultLength {
+		return segmentLength
+	}
+	return defaultLength
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context) ([]elbv2model.TargetGroupAttribute, error) {
@@ -153,21 +324,38 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context) ([
 	if rawAttributes == nil {
 		rawAttributes = make(map[string]string)
 	}
+	if err := normalizeTargetGroupBooleanAttributes(rawAttributes); err != nil {
+		return nil, t.invalidAnnotationError(annotations.SvcLBSuffixTargetGroupAttributes, err)
+	}
 	if _, ok := rawAttributes[tgAttrsProxyProtocolV2Enabled]; !ok {
 		rawAttributes[tgAttrsProxyProtocolV2Enabled] = strconv.FormatBool(t.defaultProxyProtocolV2Enabled)
 	}
 	proxyV2Annotation := ""
 	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixProxyProtocol, &proxyV2Annotation, t.service.Annotations); exists {
 		if proxyV2Annotation != "*" {
-			return []elbv2model.TargetGroupAttribute{}, errors.Errorf("invalid value %v for Load Balancer proxy protocol v2 annotation, only value currently supported is *", proxyV2Annotation)
+			return []elbv2model.TargetGroupAttribute{}, t.invalidAnnotationError(annotations.SvcLBSuffixProxyProtocol, errors.Errorf("invalid value %v for Load Balancer proxy protocol v2 annotation, only value currently supported is *", proxyV2Annotation))
 		}
 		rawAttributes[tgAttrsProxyProtocolV2Enabled] = "true"
 	}
 	if rawPreserveIPEnabled, ok := rawAttributes[tgAttrsPreserveClientIPEnabled]; ok {
 		_, err := strconv.ParseBool(rawPreserveIPEnabled)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse attribute %v=%v", tgAttrsPreserveClientIPEnabled, rawPreserveIPEnabled)
+			return nil, t.invalidAnnotationError(annotations.SvcLBSuffixTargetGroupAttributes, errors.Wrapf(err, "failed to parse attribute %v=%v", tgAttrsPreserveClientIPEnabled, rawPreserveIPEnabled))
+		}
+	}
+	var preserveClientIP bool
+	exists, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixPreserveClientIP, &preserveClientIP, t.service.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		if rawPreserveIPEnabled, ok := rawAttributes[tgAttrsPreserveClientIPEnabled]; ok {
+			if preserveIPEnabled, _ := strconv.ParseBool(rawPreserveIPEnabled); preserveIPEnabled != preserveClientIP {
+				return nil, t.invalidAnnotationError(annotations.SvcLBSuffixPreserveClientIP, errors.Errorf("conflicting preserve client IP settings, annotation %v and attribute %v=%v",
+					preserveClientIP, tgAttrsPreserveClientIPEnabled, rawPreserveIPEnabled))
+			}
 		}
+		rawAttributes[tgAttrsPreserveClientIPEnabled] = strconv.FormatBool(preserveClientIP)
 	}
 	attributes := make([]elbv2model.TargetGroupAttribute, 0, len(rawAttributes))
 	for attrKey, attrValue := range rawAttributes {
@@ -179,6 +367,23 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context) ([
 	return attributes, nil
 }
 
+// normalizeTargetGroupBooleanAttributes validates the targetGroupAttributes that only accept boolean values,
+// and normalizes them into "true" or "false", since ELBV2 rejects other forms accepted by strconv.ParseBool like "1" or "T".
+func normalizeTargetGroupBooleanAttributes(attributes map[string]string) error {
+	for _, attrKey := range booleanTargetGroupAttributes.List() {
+		rawValue, exists := attributes[attrKey]
+		if !exists {
+			continue
+		}
+		value, err := strconv.ParseBool(rawValue)
+		if err != nil {
+			return errors.Errorf("invalid targetGroupAttribute %v: %v", attrKey, rawValue)
+		}
+		attributes[attrKey] = strconv.FormatBool(value)
+	}
+	return nil
+}
+
 func (t *defaultModelBuildTask) buildPreserveClientIPFlag(_ context.Context, targetType elbv2model.TargetType, tgAttrs []elbv2model.TargetGroupAttribute) (bool, error) {
 	for _, attr := range tgAttrs {
 		if attr.Key == tgAttrsPreserveClientIPEnabled {
@@ -211,9 +416,7 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Contex
 	return intstr.FromInt(int(portVal)), nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckProtocol(_ context.Context) (elbv2model.Protocol, error) {
-	rawHealthCheckProtocol := string(t.defaultHealthCheckProtocol)
-	t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixHCProtocol, &rawHealthCheckProtocol, t.service.Annotations)
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckProtocol(_ context.Context, rawHealthCheckProtocol string) (elbv2model.Protocol, error) {
 	switch strings.ToUpper(rawHealthCheckProtocol) {
 	case string(elbv2model.ProtocolTCP):
 		return elbv2model.ProtocolTCP, nil
@@ -248,36 +451,133 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Contex
 	return &healthCheckPath
 }
 
+// buildTargetGroupHealthCheckMatcher builds the health check matcher from the success codes annotation.
+// the default success codes are used for HTTP and HTTPS health checks without the annotation, so that removing the annotation resets the matcher.
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, healthCheckProtocol elbv2model.Protocol) (*elbv2model.HealthCheckMatcher, error) {
+	var rawSuccessCodes string
+	if !t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixHCSuccessCodes, &rawSuccessCodes, t.service.Annotations) {
+		if healthCheckProtocol == elbv2model.ProtocolTCP {
+			return nil, nil
+		}
+		return &elbv2model.HealthCheckMatcher{
+			HTTPCode: aws.String(healthCheckDefaultSuccessCodes),
+		}, nil
+	}
+	if healthCheckProtocol == elbv2model.ProtocolTCP {
+		return nil, t.invalidAnnotationError(annotations.SvcLBSuffixHCSuccessCodes, errors.Errorf("health check success codes via annotation %v are not supported with %v health check protocol",
+			annotations.SvcLBSuffixHCSuccessCodes, healthCheckProtocol))
+	}
+	successCodes, err := normalizeHealthCheckSuccessCodes(rawSuccessCodes)
+	if err != nil {
+		return nil, t.invalidAnnotationError(annotations.SvcLBSuffixHCSuccessCodes, errors.Wrapf(err, "invalid health check success codes"))
+	}
+	return &elbv2model.HealthCheckMatcher{
+		HTTPCode: &successCodes,
+	}, nil
+}
+
+// normalizeHealthCheckSuccessCodes validates the health check success codes are within [healthCheckSuccessCodeMin, healthCheckSuccessCodeMax],
+// and returns them without whitespaces(e.g. "200, 202" is normalized into "200,202").
+// success codes can be multiple values(e.g. 200,202) or a range of values(e.g. 200-299).
+func normalizeHealthCheckSuccessCodes(rawCodes string) (string, error) {
+	var codeItems []string
+	for _, rawCodeItem := range strings.Split(rawCodes, ",") {
+		rawCodeRange := strings.SplitN(strings.TrimSpace(rawCodeItem), "-", 2)
+		var codeRange []int64
+		var rawCodeRangeItems []string
+		for _, rawCode := range rawCodeRange {
+			code, err := strconv.ParseInt(strings.TrimSpace(rawCode), 10, 64)
+			if err != nil {
+				return "", errors.Errorf("success code must be integer or range of integers: %v", rawCodeItem)
+			}
+			if code < healthCheckSuccessCodeMin || code > healthCheckSuccessCodeMax {
+				return "", errors.Errorf("success code must be within [%v, %v]: %v", healthCheckSuccessCodeMin, healthCheckSuccessCodeMax, rawCodeItem)
+			}
+			codeRange = append(codeRange, code)
+			rawCodeRangeItems = append(rawCodeRangeItems, strconv.FormatInt(code, 10))
+		}
+		if len(codeRange) == 2 && codeRange[0] > codeRange[1] {
+			return "", errors.Errorf("success code range must be ascending: %v", rawCodeItem)
+		}
+		codeItems = append(codeItems, strings.Join(rawCodeRangeItems, "-"))
+	}
+	return strings.Join(codeItems, ","), nil
+}
+
+// buildTargetGroupHealthCheckIntervalSeconds builds the health check interval.
+// the interval is part of targetGroup name, so its annotation is critical: falling back would replace the targetGroup.
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckIntervalSeconds(_ context.Context) (int64, error) {
 	intervalSeconds := t.defaultHealthCheckInterval
-	if _, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixHCInterval, &intervalSeconds, t.service.Annotations); err != nil {
+	if err := t.parseHealthCheckSecondsAnnotation(annotations.SvcLBSuffixHCInterval, &intervalSeconds); err != nil {
 		return 0, err
 	}
 	return intervalSeconds, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckTimeoutSeconds(_ context.Context) (int64, error) {
+// buildTargetGroupHealthCheckTimeoutSeconds builds the health check timeout, nil is returned if its annotation is ignored.
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckTimeoutSeconds(_ context.Context, healthCheckProtocol elbv2model.Protocol) (*int64, error) {
 	timeoutSeconds := t.defaultHealthCheckTimeout
-	if _, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixHCTimeout, &timeoutSeconds, t.service.Annotations); err != nil {
-		return 0, err
+	protocolDefaults := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixHCProtocolDefaults, &protocolDefaults, t.service.Annotations); err != nil {
+		return nil, err
+	}
+	// HTTP health checks of NLB time out sooner than TCP and HTTPS health checks.
+	if protocolDefaults && healthCheckProtocol == elbv2model.ProtocolHTTP {
+		timeoutSeconds = t.defaultHTTPHealthCheckTimeout
+	}
+	if err := t.parseHealthCheckSecondsAnnotation(annotations.SvcLBSuffixHCTimeout, &timeoutSeconds); err != nil {
+		if err := t.handleNonCriticalAnnotationError(annotations.SvcLBSuffixHCTimeout, err); err != nil {
+			return nil, err
+		}
+		return nil, nil
 	}
-	return timeoutSeconds, nil
+	return &timeoutSeconds, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckHealthyThresholdCount(_ context.Context) (int64, error) {
+// parseHealthCheckSecondsAnnotation parses health check annotation in seconds,
+// the annotation can either be an integer number of seconds or a duration(e.g. 30s, 1m) in whole seconds.
+func (t *defaultModelBuildTask) parseHealthCheckSecondsAnnotation(annotation string, seconds *int64) error {
+	var rawSeconds string
+	if exists := t.annotationParser.ParseStringAnnotation(annotation, &rawSeconds, t.service.Annotations); !exists {
+		return nil
+	}
+	if _, err := strconv.ParseInt(rawSeconds, 10, 64); err == nil {
+		_, err := t.annotationParser.ParseInt64Annotation(annotation, seconds, t.service.Annotations)
+		return err
+	}
+	var duration time.Duration
+	if _, err := t.annotationParser.ParseDurationAnnotation(annotation, &duration, t.service.Annotations); err != nil {
+		return err
+	}
+	if duration%time.Second != 0 {
+		return t.invalidAnnotationError(annotation, errors.Errorf("health check annotation %v: %v must be a whole number of seconds", annotation, rawSeconds))
+	}
+	*seconds = int64(duration / time.Second)
+	return nil
+}
+
+// buildTargetGroupHealthCheckHealthyThresholdCount builds the healthy threshold count, nil is returned if its annotation is ignored.
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckHealthyThresholdCount(_ context.Context) (*int64, error) {
 	healthyThresholdCount := t.defaultHealthCheckHealthyThreshold
 	if _, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixHCHealthyThreshold, &healthyThresholdCount, t.service.Annotations); err != nil {
-		return 0, err
+		if err := t.handleNonCriticalAnnotationError(annotations.SvcLBSuffixHCHealthyThreshold, err); err != nil {
+			return nil, err
+		}
+		return nil, nil
 	}
-	return healthyThresholdCount, nil
+	return &healthyThresholdCount, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckUnhealthyThresholdCount(_ context.Context) (int64, error) {
+// buildTargetGroupHealthCheckUnhealthyThresholdCount builds the unhealthy threshold count, nil is returned if its annotation is ignored.
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckUnhealthyThresholdCount(_ context.Context) (*int64, error) {
 	unhealthyThresholdCount := t.defaultHealthCheckUnhealthyThreshold
 	if _, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixHCUnhealthyThreshold, &unhealthyThresholdCount, t.service.Annotations); err != nil {
-		return 0, err
+		if err := t.handleNonCriticalAnnotationError(annotations.SvcLBSuffixHCUnhealthyThreshold, err); err != nil {
+			return nil, err
+		}
+		return nil, nil
 	}
-	return unhealthyThresholdCount, nil
+	return &unhealthyThresholdCount, nil
 }
 
 func (t *defaultModelBuildTask) buildTargetType(_ context.Context) (elbv2model.TargetType, error) {
@@ -293,14 +593,20 @@ func (t *defaultModelBuildTask) buildTargetGroupTags(ctx context.Context) (map[s
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, targetGroup *elbv2model.TargetGroup, preserveClientIP bool,
-	port corev1.ServicePort, hc *elbv2model.TargetGroupHealthCheckConfig) *elbv2model.TargetGroupBindingResource {
-	tgbSpec := t.buildTargetGroupBindingSpec(ctx, targetGroup, preserveClientIP, port, hc)
-	return elbv2model.NewTargetGroupBindingResource(t.stack, targetGroup.ID(), tgbSpec)
+	port corev1.ServicePort, hc *elbv2model.TargetGroupHealthCheckConfig) (*elbv2model.TargetGroupBindingResource, error) {
+	tgbSpec, err := t.buildTargetGroupBindingSpec(ctx, targetGroup, preserveClientIP, port, hc)
+	if err != nil {
+		return nil, err
+	}
+	return elbv2model.NewTargetGroupBindingResource(t.stack, targetGroup.ID(), tgbSpec), nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context, targetGroup *elbv2model.TargetGroup, preserveClientIP bool,
-	port corev1.ServicePort, hc *elbv2model.TargetGroupHealthCheckConfig) elbv2model.TargetGroupBindingResourceSpec {
-	tgbNetworking := t.buildTargetGroupBindingNetworking(ctx, port.TargetPort, preserveClientIP, *hc.Port, port.Protocol)
+	port corev1.ServicePort, hc *elbv2model.TargetGroupHealthCheckConfig) (elbv2model.TargetGroupBindingResourceSpec, error) {
+	tgbNetworking, err := t.buildTargetGroupBindingNetworking(ctx, port.TargetPort, preserveClientIP, *hc.Port, port.Protocol)
+	if err != nil {
+		return elbv2model.TargetGroupBindingResourceSpec{}, err
+	}
 	targetType := elbv2api.TargetType(targetGroup.Spec.TargetType)
 	return elbv2model.TargetGroupBindingResourceSpec{
 		Template: elbv2model.TargetGroupBindingTemplate{
@@ -318,19 +624,19 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 				Networking: tgbNetworking,
 			},
 		},
-	}
+	}, nil
 }
 
-func (t *defaultModelBuildTask) buildPeersFromSourceRanges(_ context.Context) []elbv2model.NetworkingPeer {
-	var sourceRanges []string
+// buildPeersFromSourceRanges builds peers from the source ranges and source prefixLists.
+// traffic from any IPv4 address is allowed if neither is specified.
+func (t *defaultModelBuildTask) buildPeersFromSourceRanges(ctx context.Context) ([]elbv2model.NetworkingPeer, error) {
 	var peers []elbv2model.NetworkingPeer
-	for _, cidr := range t.service.Spec.LoadBalancerSourceRanges {
-		sourceRanges = append(sourceRanges, cidr)
-	}
-	if len(sourceRanges) == 0 {
-		t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSourceRanges, &sourceRanges, t.service.Annotations)
+	sourceRanges := t.buildSourceRanges(ctx)
+	prefixListIDs, err := t.buildSourcePrefixListIDs(ctx)
+	if err != nil {
+		return nil, err
 	}
-	if len(sourceRanges) == 0 {
+	if len(sourceRanges) == 0 && len(prefixListIDs) == 0 {
 		sourceRanges = append(sourceRanges, "0.0.0.0/0")
 	}
 	for _, cidr := range sourceRanges {
@@ -340,11 +646,77 @@ func (t *defaultModelBuildTask) buildPeersFromSourceRanges(_ context.Context) []
 			},
 		})
 	}
-	return peers
+	for _, prefixListID := range prefixListIDs {
+		peers = append(peers, elbv2model.NetworkingPeer{
+			PrefixList: &elbv2api.PrefixList{
+				PrefixListID: prefixListID,
+			},
+		})
+	}
+	return peers, nil
+}
+
+// buildSourceRanges builds the CIDRs that are allowed to access the LoadBalancer.
+// loadBalancerSourceRanges of service takes precedence over the source ranges annotation.
+func (t *defaultModelBuildTask) buildSourceRanges(_ context.Context) []string {
+	var sourceRanges []string
+	for _, cidr := range t.service.Spec.LoadBalancerSourceRanges {
+		sourceRanges = append(sourceRanges, cidr)
+	}
+	if len(sourceRanges) == 0 {
+		t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSourceRanges, &sourceRanges, t.service.Annotations)
+	}
+	return sourceRanges
+}
+
+var prefixListIDPattern = regexp.MustCompile(`^pl-[0-9a-f]+$`)
+
+// buildSourcePrefixListIDs builds the managed prefixLists that are allowed to access the targets, duplicates are removed.
+func (t *defaultModelBuildTask) buildSourcePrefixListIDs(_ context.Context) ([]string, error) {
+	var rawPrefixListIDs []string
+	t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSourcePrefixLists, &rawPrefixListIDs, t.service.Annotations)
+	var prefixListIDs []string
+	seen := sets.NewString()
+	for _, prefixListID := range rawPrefixListIDs {
+		if !prefixListIDPattern.MatchString(prefixListID) {
+			return nil, t.invalidAnnotationError(annotations.SvcLBSuffixSourcePrefixLists, errors.Errorf("invalid prefixList ID %v in annotation %v, must be in format pl-xxxxxxxx",
+				prefixListID, annotations.SvcLBSuffixSourcePrefixLists))
+		}
+		if seen.Has(prefixListID) {
+			continue
+		}
+		seen.Insert(prefixListID)
+		prefixListIDs = append(prefixListIDs, prefixListID)
+	}
+	return prefixListIDs, nil
+}
+
+// isPeersAllowingAllIPv4 checks whether peers allows traffic from any IPv4 address.
+func isPeersAllowingAllIPv4(peers []elbv2model.NetworkingPeer) bool {
+	for _, peer := range peers {
+		if peer.IPBlock != nil && peer.IPBlock.CIDR == "0.0.0.0/0" {
+			return true
+		}
+	}
+	return false
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(ctx context.Context, tgPort intstr.IntOrString, preserveClientIP bool,
-	hcPort intstr.IntOrString, tgProtocol corev1.Protocol) *elbv2model.TargetGroupBindingNetworking {
+	hcPort intstr.IntOrString, tgProtocol corev1.Protocol) (*elbv2model.TargetGroupBindingNetworking, error) {
+	allowICMPFragmentation := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixAllowICMPFragmentation, &allowICMPFragmentation, t.service.Annotations); err != nil {
+		return nil, err
+	}
+	if len(t.lbSecurityGroupIDs) != 0 {
+		if !t.manageBackendSGRules {
+			return nil, nil
+		}
+		tgbNetworking := t.buildTargetGroupBindingNetworkingFromSecurityGroups(ctx, tgPort, hcPort, tgProtocol)
+		if allowICMPFragmentation {
+			tgbNetworking.Ingress = append(tgbNetworking.Ingress, buildICMPFragmentationIngressRules(tgbNetworking.Ingress[0].From)...)
+		}
+		return tgbNetworking, nil
+	}
 	var fromVPC []elbv2model.NetworkingPeer
 	for _, subnet := range t.ec2Subnets {
 		fromVPC = append(fromVPC, elbv2model.NetworkingPeer{
@@ -365,7 +737,11 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(ctx context.Co
 	}
 	trafficSource := fromVPC
 	if networkingProtocol == elbv2api.NetworkingProtocolUDP || preserveClientIP {
-		trafficSource = t.buildPeersFromSourceRanges(ctx)
+		var err error
+		trafficSource, err = t.buildPeersFromSourceRanges(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 	tgbNetworking := &elbv2model.TargetGroupBindingNetworking{
 		Ingress: []elbv2model.NetworkingIngressRule{
@@ -375,7 +751,11 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(ctx context.Co
 			},
 		},
 	}
-	if tgProtocol == corev1.ProtocolUDP || (hcPort.String() != healthCheckPortTrafficPort && hcPort.IntValue() != tgPort.IntValue()) {
+	// health check traffic originates from the LoadBalancer within VPC, it needs explicit allowance unless it's already
+	// permitted by the traffic rule.
+	hcPortDiffers := hcPort.String() != healthCheckPortTrafficPort && hcPort.IntValue() != tgPort.IntValue()
+	hcSourceExcluded := networkingProtocol == elbv2api.NetworkingProtocolUDP || (preserveClientIP && !isPeersAllowingAllIPv4(trafficSource))
+	if hcPortDiffers || hcSourceExcluded {
 		var healthCheckPorts []elbv2api.NetworkingPort
 		networkingProtocolTCP := elbv2api.NetworkingProtocolTCP
 		networkingHealthCheckPort := hcPort
@@ -391,5 +771,97 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(ctx context.Co
 			Ports: healthCheckPorts,
 		})
 	}
-	return tgbNetworking
+	if allowICMPFragmentation {
+		tgbNetworking.Ingress = append(tgbNetworking.Ingress, buildICMPFragmentationIngressRules(trafficSource)...)
+	}
+	return tgbNetworking, nil
+}
+
+// buildICMPFragmentationIngressRules builds the rules that allow ICMP "fragmentation needed" messages from the traffic peers,
+// so that path MTU discovery works for clients. IPv6 peers are allowed with the ICMPv6 "packet too big" message instead.
+func buildICMPFragmentationIngressRules(peers []elbv2model.NetworkingPeer) []elbv2model.NetworkingIngressRule {
+	var ipv4Peers, ipv6Peers []elbv2model.NetworkingPeer
+	for _, peer := range peers {
+		if peer.IPBlock != nil && strings.Contains(peer.IPBlock.CIDR, ":") {
+			ipv6Peers = append(ipv6Peers, peer)
+		} else {
+			ipv4Peers = append(ipv4Peers, peer)
+		}
+	}
+	var rules []elbv2model.NetworkingIngressRule
+	if len(ipv4Peers) != 0 {
+		networkingProtocolICMP := elbv2api.NetworkingProtocolICMP
+		rules = append(rules, elbv2model.NetworkingIngressRule{
+			From: ipv4Peers,
+			Ports: []elbv2api.NetworkingPort{
+				{
+					Protocol: &networkingProtocolICMP,
+					ICMP: &elbv2api.NetworkingICMP{
+						Type: icmpTypeDestinationUnreachable,
+						Code: icmpCodeFragmentationNeeded,
+					},
+				},
+			},
+		})
+	}
+	if len(ipv6Peers) != 0 {
+		networkingProtocolICMPv6 := elbv2api.NetworkingProtocolICMPv6
+		rules = append(rules, elbv2model.NetworkingIngressRule{
+			From: ipv6Peers,
+			Ports: []elbv2api.NetworkingPort{
+				{
+					Protocol: &networkingProtocolICMPv6,
+					ICMP: &elbv2api.NetworkingICMP{
+						Type: icmpv6TypePacketTooBig,
+						Code: icmpv6CodePacketTooBig,
+					},
+				},
+			},
+		})
+	}
+	return rules
+}
+
+// buildTargetGroupBindingNetworkingFromSecurityGroups builds networking rules that allow traffic from the LoadBalancer securityGroups.
+// both client traffic and health checks are sourced from the LoadBalancer securityGroups regardless of client IP preservation.
+func (t *defaultModelBuildTask) buildTargetGroupBindingNetworkingFromSecurityGroups(_ context.Context, tgPort intstr.IntOrString,
+	hcPort intstr.IntOrString, tgProtocol corev1.Protocol) *elbv2model.TargetGroupBindingNetworking {
+	fromLB := make([]elbv2model.NetworkingPeer, 0, len(t.lbSecurityGroupIDs))
+	for _, sgID := range t.lbSecurityGroupIDs {
+		fromLB = append(fromLB, elbv2model.NetworkingPeer{
+			SecurityGroup: &elbv2model.SecurityGroup{
+				GroupID: core.LiteralStringToken(sgID),
+			},
+		})
+	}
+	networkingProtocol := elbv2api.NetworkingProtocolTCP
+	if tgProtocol == corev1.ProtocolUDP {
+		networkingProtocol = elbv2api.NetworkingProtocolUDP
+	}
+	ports := []elbv2api.NetworkingPort{
+		{
+			Port:     &tgPort,
+			Protocol: &networkingProtocol,
+		},
+	}
+	hcPortDiffers := hcPort.String() != healthCheckPortTrafficPort && hcPort.IntValue() != tgPort.IntValue()
+	if hcPortDiffers || networkingProtocol == elbv2api.NetworkingProtocolUDP {
+		networkingProtocolTCP := elbv2api.NetworkingProtocolTCP
+		networkingHealthCheckPort := hcPort
+		if hcPort.String() == healthCheckPortTrafficPort {
+			networkingHealthCheckPort = tgPort
+		}
+		ports = append(ports, elbv2api.NetworkingPort{
+			Port:     &networkingHealthCheckPort,
+			Protocol: &networkingProtocolTCP,
+		})
+	}
+	return &elbv2model.TargetGroupBindingNetworking{
+		Ingress: []elbv2model.NetworkingIngressRule{
+			{
+				From:  fromLB,
+				Ports: ports,
+			},
+		},
+	}
 }
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sort"
 	"testing"
@@ -123,6 +124,72 @@ func Test_defaultModelBuilderTask_targetGroupAttrs(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			testName: "preserve client IP annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-preserve-client-ip": "false",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   tgAttrsPreserveClientIPEnabled,
+					Value: "false",
+				},
+			},
+		},
+		{
+			testName: "preserve client IP annotation consistent with attribute",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-preserve-client-ip":      "true",
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": tgAttrsPreserveClientIPEnabled + "=true",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   tgAttrsPreserveClientIPEnabled,
+					Value: "true",
+				},
+			},
+		},
+		{
+			testName: "preserve client IP annotation conflicts with attribute",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-preserve-client-ip":      "true",
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": tgAttrsPreserveClientIPEnabled + "=false",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "preserve client IP annotation parse error",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-preserve-client-ip": "off",
+					},
+				},
+			},
+			wantError: true,
+		},
 		{
 			testName: "IP enabled attribute parse error",
 			svc: &corev1.Service{
@@ -134,6 +201,81 @@ func Test_defaultModelBuilderTask_targetGroupAttrs(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			testName: "connection termination enabled",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": "deregistration_delay.timeout_seconds=120, deregistration_delay.connection_termination.enabled=true",
+					},
+				},
+			},
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   "deregistration_delay.timeout_seconds",
+					Value: "120",
+				},
+				{
+					Key:   tgAttrsDeregistrationDelayConnectionTerminationEnabled,
+					Value: "true",
+				},
+			},
+		},
+		{
+			testName: "connection termination absent",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": "deregistration_delay.timeout_seconds=120",
+					},
+				},
+			},
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   "deregistration_delay.timeout_seconds",
+					Value: "120",
+				},
+			},
+		},
+		{
+			testName: "connection termination attribute normalized",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": tgAttrsDeregistrationDelayConnectionTerminationEnabled + "=1",
+					},
+				},
+			},
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   tgAttrsDeregistrationDelayConnectionTerminationEnabled,
+					Value: "true",
+				},
+			},
+		},
+		{
+			testName: "connection termination attribute parse error",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": tgAttrsDeregistrationDelayConnectionTerminationEnabled + "=yes",
+					},
+				},
+			},
+			wantError: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -164,6 +306,7 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 	tests := []struct {
 		testName  string
 		svc       *corev1.Service
+		port      corev1.ServicePort
 		wantError bool
 		wantValue *elbv2.TargetGroupHealthCheckConfig
 	}{
@@ -204,12 +347,55 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 				Port:                    &port8888,
 				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
 				Path:                    aws.String("/healthz"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200-399")},
 				IntervalSeconds:         aws.Int64(10),
 				TimeoutSeconds:          aws.Int64(30),
 				HealthyThresholdCount:   aws.Int64(2),
 				UnhealthyThresholdCount: aws.Int64(2),
 			},
 		},
+		{
+			testName: "With duration annotations",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval": "1m",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-timeout":  "20s",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String(string(elbv2.ProtocolTCP))),
+				IntervalSeconds:         aws.Int64(60),
+				TimeoutSeconds:          aws.Int64(20),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "duration annotation with fractional seconds",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval": "1500ms",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "malformed duration annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-timeout": "ten seconds",
+					},
+				},
+			},
+			wantError: true,
+		},
 		{
 			testName: "default path",
 			svc: &corev1.Service{
@@ -224,6 +410,7 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 				Port:                    &trafficPort,
 				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
 				Path:                    aws.String("/"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200-399")},
 				IntervalSeconds:         aws.Int64(10),
 				TimeoutSeconds:          aws.Int64(10),
 				HealthyThresholdCount:   aws.Int64(3),
@@ -244,68 +431,731 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 					},
 				},
 			},
-			wantError: true,
+			wantError: true,
+		},
+		{
+			testName: "with health check config override for port",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval": "30",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-config":   `{"http":{"protocol":"HTTP","intervalSeconds":20,"healthyThresholdCount":5},"tls":{"timeoutSeconds":6}}`,
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80}, {Name: "tls", Port: 443}},
+				},
+			},
+			port:      corev1.ServicePort{Name: "http", Port: 80},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
+				Path:                    aws.String("/"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200-399")},
+				IntervalSeconds:         aws.Int64(20),
+				TimeoutSeconds:          aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(5),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "with health check config override for other port",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval": "30",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-config":   `{"http":{"protocol":"HTTP","intervalSeconds":20}}`,
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80}, {Name: "tls", Port: 443}},
+				},
+			},
+			port:      corev1.ServicePort{Name: "tls", Port: 443},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String(string(elbv2.ProtocolTCP))),
+				IntervalSeconds:         aws.Int64(30),
+				TimeoutSeconds:          aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "health check config with unknown port",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-config": `{"https":{"intervalSeconds":20}}`,
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80}},
+				},
+			},
+			port:      corev1.ServicePort{Name: "http", Port: 80},
+			wantError: true,
+		},
+		{
+			testName: "health check config with invalid json",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-config": `{"http":`,
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80}},
+				},
+			},
+			port:      corev1.ServicePort{Name: "http", Port: 80},
+			wantError: true,
+		},
+		{
+			testName: "UDP target group with separate health check port",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol": "HTTP",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port":     "8888",
+					},
+				},
+			},
+			port:      corev1.ServicePort{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &port8888,
+				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
+				Path:                    aws.String("/"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200-399")},
+				IntervalSeconds:         aws.Int64(10),
+				TimeoutSeconds:          aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "UDP target group with traffic-port health check",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+			},
+			port:      corev1.ServicePort{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String(string(elbv2.ProtocolTCP))),
+				IntervalSeconds:         aws.Int64(10),
+				TimeoutSeconds:          aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "UDP target group with UDP health check protocol",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol": "UDP",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port":     "8888",
+					},
+				},
+			},
+			port:      corev1.ServicePort{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+			wantError: true,
+		},
+		{
+			testName: "protocol defaults for TCP health check",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol-defaults": "true",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String(string(elbv2.ProtocolTCP))),
+				IntervalSeconds:         aws.Int64(10),
+				TimeoutSeconds:          aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "protocol defaults for HTTP health check",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol":          "HTTP",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol-defaults": "true",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
+				Path:                    aws.String("/"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200-399")},
+				IntervalSeconds:         aws.Int64(10),
+				TimeoutSeconds:          aws.Int64(6),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "protocol defaults for HTTPS health check",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol":          "HTTPS",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol-defaults": "true",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String("HTTPS")),
+				Path:                    aws.String("/"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200-399")},
+				IntervalSeconds:         aws.Int64(10),
+				TimeoutSeconds:          aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "protocol defaults don't override explicit timeout",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol":          "HTTP",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-timeout":           "8",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol-defaults": "true",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
+				Path:                    aws.String("/"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200-399")},
+				IntervalSeconds:         aws.Int64(10),
+				TimeoutSeconds:          aws.Int64(8),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "protocol defaults for HTTP health check from config override",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-config":            `{"http":{"protocol":"HTTP"}}`,
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol-defaults": "true",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80}},
+				},
+			},
+			port:      corev1.ServicePort{Name: "http", Port: 80},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
+				Path:                    aws.String("/"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200-399")},
+				IntervalSeconds:         aws.Int64(10),
+				TimeoutSeconds:          aws.Int64(6),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "HTTP health check with success codes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol":      "HTTP",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes": "200-299, 404",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
+				Path:                    aws.String("/"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200-299,404")},
+				IntervalSeconds:         aws.Int64(10),
+				TimeoutSeconds:          aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+		},
+		{
+			testName: "HTTPS health check with invalid success codes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol":      "HTTPS",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes": "299-200",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "HTTP health check with out of range success codes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol":      "HTTP",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes": "100-200",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "TCP health check with success codes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes": "200",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "invalid protocol defaults annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol-defaults": "yes",
+					},
+				},
+			},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			builder := &defaultModelBuildTask{
+				service:                              tt.svc,
+				annotationParser:                     parser,
+				defaultAccessLogsS3Bucket:            "",
+				defaultAccessLogsS3Prefix:            "",
+				defaultLoadBalancingCrossZoneEnabled: false,
+				defaultProxyProtocolV2Enabled:        false,
+				defaultHealthCheckProtocol:           elbv2.ProtocolTCP,
+				defaultHealthCheckPort:               healthCheckPortTrafficPort,
+				defaultHealthCheckPath:               "/",
+				defaultHealthCheckInterval:           10,
+				defaultHealthCheckTimeout:            10,
+				defaultHTTPHealthCheckTimeout:        6,
+				defaultHealthCheckHealthyThreshold:   3,
+				defaultHealthCheckUnhealthyThreshold: 3,
+			}
+			hc, err := builder.buildTargetGroupHealthCheckConfig(context.Background(), tt.port)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.Equal(t, tt.wantValue, hc)
+			}
+		})
+	}
+}
+
+func Test_normalizeHealthCheckSuccessCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawCodes string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "single code",
+			rawCodes: "200",
+			want:     "200",
+		},
+		{
+			name:     "multiple codes and ranges",
+			rawCodes: "200, 202 - 204,599",
+			want:     "200,202-204,599",
+		},
+		{
+			name:     "non-integer code",
+			rawCodes: "200,2xx",
+			wantErr:  errors.New("success code must be integer or range of integers: 2xx"),
+		},
+		{
+			name:     "code out of range",
+			rawCodes: "600",
+			wantErr:  errors.New("success code must be within [200, 599]: 600"),
+		},
+		{
+			name:     "descending range",
+			rawCodes: "299-200",
+			wantErr:  errors.New("success code range must be ascending: 299-200"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeHealthCheckSuccessCodes(tt.rawCodes)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T) {
+	networkingProtocolTCP := elbv2api.NetworkingProtocolTCP
+	networkingProtocolUDP := elbv2api.NetworkingProtocolUDP
+	networkingProtocolICMP := elbv2api.NetworkingProtocolICMP
+	networkingProtocolICMPv6 := elbv2api.NetworkingProtocolICMPv6
+	port80 := intstr.FromInt(80)
+	port808 := intstr.FromInt(808)
+	trafficPort := intstr.FromString("traffic-port")
+
+	tests := []struct {
+		name                 string
+		svc                  *corev1.Service
+		tgPort               intstr.IntOrString
+		hcPort               intstr.IntOrString
+		subnets              []*ec2.Subnet
+		tgProtocol           corev1.Protocol
+		preserveClientIP     bool
+		lbSecurityGroupIDs   []string
+		manageBackendSGRules bool
+		want                 *elbv2.TargetGroupBindingNetworking
+		wantErr              error
+	}{
+		{
+			name: "udp-service with source ranges",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					LoadBalancerSourceRanges: []string{"10.0.0.0/16", "1.2.3.4/24"},
+				},
+			},
+			tgPort: port80,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+			}},
+			tgProtocol: corev1.ProtocolUDP,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "10.0.0.0/16",
+								},
+							},
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "1.2.3.4/24",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolUDP,
+								Port:     &port80,
+							},
+						},
+					},
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "172.16.0.0/19",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "udp-service with source ranges annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/load-balancer-source-ranges": "1.2.3.4/17, 5.6.7.8/18",
+					},
+				},
+			},
+			tgPort: port80,
+			hcPort: port808,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+			}},
+			tgProtocol: corev1.ProtocolUDP,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "1.2.3.4/17",
+								},
+							},
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "5.6.7.8/18",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolUDP,
+								Port:     &port80,
+							},
+						},
+					},
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "172.16.0.0/19",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port808,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "udp-service with no source ranges configuration",
+			svc:    &corev1.Service{},
+			tgPort: port80,
+			hcPort: port808,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+			}},
+			tgProtocol: corev1.ProtocolUDP,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "0.0.0.0/0",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolUDP,
+								Port:     &port80,
+							},
+						},
+					},
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "172.16.0.0/19",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port808,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "tcp-service with traffic-port hc",
+			svc:    &corev1.Service{},
+			tgPort: port80,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{
+				{
+					CidrBlock: aws.String("172.16.0.0/19"),
+					SubnetId:  aws.String("sn-1"),
+				},
+				{
+					CidrBlock: aws.String("1.2.3.4/19"),
+					SubnetId:  aws.String("sn-2"),
+				},
+			},
+			tgProtocol: corev1.ProtocolTCP,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "172.16.0.0/19",
+								},
+							},
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "1.2.3.4/19",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "tcp-service with preserveClient IP, traffic-port hc",
+			svc:    &corev1.Service{},
+			tgPort: port80,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{
+				{
+					CidrBlock: aws.String("172.16.0.0/19"),
+					SubnetId:  aws.String("sn-1"),
+				},
+				{
+					CidrBlock: aws.String("1.2.3.4/19"),
+					SubnetId:  aws.String("sn-2"),
+				},
+			},
+			tgProtocol:       corev1.ProtocolTCP,
+			preserveClientIP: true,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "0.0.0.0/0",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "tcp-service with preserveClient IP, hc different",
+			svc:    &corev1.Service{},
+			tgPort: port80,
+			hcPort: port808,
+			subnets: []*ec2.Subnet{
+				{
+					CidrBlock: aws.String("172.16.0.0/19"),
+					SubnetId:  aws.String("sn-1"),
+				},
+				{
+					CidrBlock: aws.String("1.2.3.4/19"),
+					SubnetId:  aws.String("sn-2"),
+				},
+			},
+			tgProtocol:       corev1.ProtocolTCP,
+			preserveClientIP: true,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "0.0.0.0/0",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "172.16.0.0/19",
+								},
+							},
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "1.2.3.4/19",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port808,
+							},
+						},
+					},
+				},
+			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.testName, func(t *testing.T) {
-			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
-			builder := &defaultModelBuildTask{
-				service:                              tt.svc,
-				annotationParser:                     parser,
-				defaultAccessLogsS3Bucket:            "",
-				defaultAccessLogsS3Prefix:            "",
-				defaultLoadBalancingCrossZoneEnabled: false,
-				defaultProxyProtocolV2Enabled:        false,
-				defaultHealthCheckProtocol:           elbv2.ProtocolTCP,
-				defaultHealthCheckPort:               healthCheckPortTrafficPort,
-				defaultHealthCheckPath:               "/",
-				defaultHealthCheckInterval:           10,
-				defaultHealthCheckTimeout:            10,
-				defaultHealthCheckHealthyThreshold:   3,
-				defaultHealthCheckUnhealthyThreshold: 3,
-			}
-			hc, err := builder.buildTargetGroupHealthCheckConfig(context.Background())
-			if tt.wantError {
-				assert.Error(t, err)
-			} else {
-				assert.Equal(t, tt.wantValue, hc)
-			}
-		})
-	}
-}
-
-func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T) {
-	networkingProtocolTCP := elbv2api.NetworkingProtocolTCP
-	networkingProtocolUDP := elbv2api.NetworkingProtocolUDP
-	port80 := intstr.FromInt(80)
-	port808 := intstr.FromInt(808)
-	trafficPort := intstr.FromString("traffic-port")
-
-	tests := []struct {
-		name             string
-		svc              *corev1.Service
-		tgPort           intstr.IntOrString
-		hcPort           intstr.IntOrString
-		subnets          []*ec2.Subnet
-		tgProtocol       corev1.Protocol
-		preserveClientIP bool
-		want             *elbv2.TargetGroupBindingNetworking
-	}{
 		{
-			name: "udp-service with source ranges",
+			name: "tcp-service with preserve Client IP with different hc port and source range specified",
 			svc: &corev1.Service{
 				Spec: corev1.ServiceSpec{
 					LoadBalancerSourceRanges: []string{"10.0.0.0/16", "1.2.3.4/24"},
 				},
 			},
 			tgPort: port80,
-			hcPort: trafficPort,
-			subnets: []*ec2.Subnet{{
-				CidrBlock: aws.String("172.16.0.0/19"),
-				SubnetId:  aws.String("az-1"),
-			}},
-			tgProtocol: corev1.ProtocolUDP,
+			hcPort: port808,
+			subnets: []*ec2.Subnet{
+				{
+					CidrBlock: aws.String("172.16.0.0/19"),
+					SubnetId:  aws.String("sn-1"),
+				},
+				{
+					CidrBlock: aws.String("1.2.3.4/19"),
+					SubnetId:  aws.String("sn-2"),
+				},
+			},
+			tgProtocol:       corev1.ProtocolTCP,
+			preserveClientIP: true,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
 					{
@@ -323,7 +1173,7 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 						},
 						Ports: []elbv2api.NetworkingPort{
 							{
-								Protocol: &networkingProtocolUDP,
+								Protocol: &networkingProtocolTCP,
 								Port:     &port80,
 							},
 						},
@@ -335,11 +1185,16 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 									CIDR: "172.16.0.0/19",
 								},
 							},
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "1.2.3.4/19",
+								},
+							},
 						},
 						Ports: []elbv2api.NetworkingPort{
 							{
 								Protocol: &networkingProtocolTCP,
-								Port:     &port80,
+								Port:     &port808,
 							},
 						},
 					},
@@ -347,43 +1202,71 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 			},
 		},
 		{
-			name: "udp-service with source ranges annotation",
+			name: "tcp-service with preserve Client IP with traffic-port hc and source range specified",
 			svc: &corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{
-					Annotations: map[string]string{
-						"service.beta.kubernetes.io/load-balancer-source-ranges": "1.2.3.4/17, 5.6.7.8/18",
-					},
+				Spec: corev1.ServiceSpec{
+					LoadBalancerSourceRanges: []string{"10.0.0.0/16"},
 				},
 			},
 			tgPort: port80,
-			hcPort: port808,
-			subnets: []*ec2.Subnet{{
-				CidrBlock: aws.String("172.16.0.0/19"),
-				SubnetId:  aws.String("az-1"),
-			}},
-			tgProtocol: corev1.ProtocolUDP,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{
+				{
+					CidrBlock: aws.String("172.16.0.0/19"),
+					SubnetId:  aws.String("sn-1"),
+				},
+			},
+			tgProtocol:       corev1.ProtocolTCP,
+			preserveClientIP: true,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
 					{
 						From: []elbv2.NetworkingPeer{
 							{
 								IPBlock: &elbv2api.IPBlock{
-									CIDR: "1.2.3.4/17",
+									CIDR: "10.0.0.0/16",
 								},
 							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+					{
+						From: []elbv2.NetworkingPeer{
 							{
 								IPBlock: &elbv2api.IPBlock{
-									CIDR: "5.6.7.8/18",
+									CIDR: "172.16.0.0/19",
 								},
 							},
 						},
 						Ports: []elbv2api.NetworkingPort{
 							{
-								Protocol: &networkingProtocolUDP,
+								Protocol: &networkingProtocolTCP,
 								Port:     &port80,
 							},
 						},
 					},
+				},
+			},
+		},
+		{
+			name:   "tcp-service without preserve Client IP, traffic-port hc",
+			svc:    &corev1.Service{},
+			tgPort: port80,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{
+				{
+					CidrBlock: aws.String("172.16.0.0/19"),
+					SubnetId:  aws.String("sn-1"),
+				},
+			},
+			tgProtocol: corev1.ProtocolTCP,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
 					{
 						From: []elbv2.NetworkingPeer{
 							{
@@ -395,7 +1278,7 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 						Ports: []elbv2api.NetworkingPort{
 							{
 								Protocol: &networkingProtocolTCP,
-								Port:     &port808,
+								Port:     &port80,
 							},
 						},
 					},
@@ -403,15 +1286,65 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 			},
 		},
 		{
-			name:   "udp-service with no source ranges configuration",
+			name:   "tcp-service with loadBalancer securityGroups",
 			svc:    &corev1.Service{},
 			tgPort: port80,
-			hcPort: port808,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{
+				{
+					CidrBlock: aws.String("172.16.0.0/19"),
+					SubnetId:  aws.String("sn-1"),
+				},
+			},
+			tgProtocol:           corev1.ProtocolTCP,
+			preserveClientIP:     true,
+			lbSecurityGroupIDs:   []string{"sg-1", "sg-2"},
+			manageBackendSGRules: true,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								SecurityGroup: &elbv2.SecurityGroup{
+									GroupID: core.LiteralStringToken("sg-1"),
+								},
+							},
+							{
+								SecurityGroup: &elbv2.SecurityGroup{
+									GroupID: core.LiteralStringToken("sg-2"),
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "tcp-service with ICMP fragmentation allowed for dualstack source ranges",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-security-group-allow-icmp-fragmentation": "true",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					LoadBalancerSourceRanges: []string{"0.0.0.0/0", "2600:1f13::/32"},
+				},
+			},
+			tgPort: port80,
+			hcPort: trafficPort,
 			subnets: []*ec2.Subnet{{
 				CidrBlock: aws.String("172.16.0.0/19"),
 				SubnetId:  aws.String("az-1"),
 			}},
-			tgProtocol: corev1.ProtocolUDP,
+			tgProtocol:       corev1.ProtocolTCP,
+			preserveClientIP: true,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
 					{
@@ -421,10 +1354,15 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 									CIDR: "0.0.0.0/0",
 								},
 							},
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "2600:1f13::/32",
+								},
+							},
 						},
 						Ports: []elbv2api.NetworkingPort{
 							{
-								Protocol: &networkingProtocolUDP,
+								Protocol: &networkingProtocolTCP,
 								Port:     &port80,
 							},
 						},
@@ -433,14 +1371,35 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 						From: []elbv2.NetworkingPeer{
 							{
 								IPBlock: &elbv2api.IPBlock{
-									CIDR: "172.16.0.0/19",
+									CIDR: "0.0.0.0/0",
 								},
 							},
 						},
 						Ports: []elbv2api.NetworkingPort{
 							{
-								Protocol: &networkingProtocolTCP,
-								Port:     &port808,
+								Protocol: &networkingProtocolICMP,
+								ICMP: &elbv2api.NetworkingICMP{
+									Type: 3,
+									Code: 4,
+								},
+							},
+						},
+					},
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "2600:1f13::/32",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolICMPv6,
+								ICMP: &elbv2api.NetworkingICMP{
+									Type: 2,
+									Code: 0,
+								},
 							},
 						},
 					},
@@ -448,8 +1407,14 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 			},
 		},
 		{
-			name:   "tcp-service with traffic-port hc",
-			svc:    &corev1.Service{},
+			name: "tcp-service with ICMP fragmentation allowed for loadBalancer securityGroups",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-security-group-allow-icmp-fragmentation": "true",
+					},
+				},
+			},
 			tgPort: port80,
 			hcPort: trafficPort,
 			subnets: []*ec2.Subnet{
@@ -457,11 +1422,63 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 					CidrBlock: aws.String("172.16.0.0/19"),
 					SubnetId:  aws.String("sn-1"),
 				},
-				{
-					CidrBlock: aws.String("1.2.3.4/19"),
-					SubnetId:  aws.String("sn-2"),
+			},
+			tgProtocol:           corev1.ProtocolTCP,
+			lbSecurityGroupIDs:   []string{"sg-1"},
+			manageBackendSGRules: true,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								SecurityGroup: &elbv2.SecurityGroup{
+									GroupID: core.LiteralStringToken("sg-1"),
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								SecurityGroup: &elbv2.SecurityGroup{
+									GroupID: core.LiteralStringToken("sg-1"),
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolICMP,
+								ICMP: &elbv2api.NetworkingICMP{
+									Type: 3,
+									Code: 4,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "tcp-service with ICMP fragmentation disallowed",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-security-group-allow-icmp-fragmentation": "false",
+					},
 				},
 			},
+			tgPort: port80,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+			}},
 			tgProtocol: corev1.ProtocolTCP,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
@@ -472,11 +1489,6 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 									CIDR: "172.16.0.0/19",
 								},
 							},
-							{
-								IPBlock: &elbv2api.IPBlock{
-									CIDR: "1.2.3.4/19",
-								},
-							},
 						},
 						Ports: []elbv2api.NetworkingPort{
 							{
@@ -489,57 +1501,88 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 			},
 		},
 		{
-			name:   "tcp-service with preserveClient IP, traffic-port hc",
+			name: "tcp-service with invalid ICMP fragmentation annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-security-group-allow-icmp-fragmentation": "yes",
+					},
+				},
+			},
+			tgPort:     port80,
+			hcPort:     trafficPort,
+			tgProtocol: corev1.ProtocolTCP,
+			wantErr:    errors.New("failed to parse bool annotation, service.beta.kubernetes.io/aws-load-balancer-security-group-allow-icmp-fragmentation: yes: strconv.ParseBool: parsing \"yes\": invalid syntax"),
+		},
+		{
+			name:   "udp-service with loadBalancer securityGroups and separate health check port",
 			svc:    &corev1.Service{},
 			tgPort: port80,
-			hcPort: trafficPort,
+			hcPort: port808,
 			subnets: []*ec2.Subnet{
 				{
 					CidrBlock: aws.String("172.16.0.0/19"),
 					SubnetId:  aws.String("sn-1"),
 				},
-				{
-					CidrBlock: aws.String("1.2.3.4/19"),
-					SubnetId:  aws.String("sn-2"),
-				},
 			},
-			tgProtocol:       corev1.ProtocolTCP,
-			preserveClientIP: true,
+			tgProtocol:           corev1.ProtocolUDP,
+			lbSecurityGroupIDs:   []string{"sg-1"},
+			manageBackendSGRules: true,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
 					{
 						From: []elbv2.NetworkingPeer{
 							{
-								IPBlock: &elbv2api.IPBlock{
-									CIDR: "0.0.0.0/0",
+								SecurityGroup: &elbv2.SecurityGroup{
+									GroupID: core.LiteralStringToken("sg-1"),
 								},
 							},
 						},
 						Ports: []elbv2api.NetworkingPort{
 							{
-								Protocol: &networkingProtocolTCP,
+								Protocol: &networkingProtocolUDP,
 								Port:     &port80,
 							},
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port808,
+							},
 						},
 					},
 				},
 			},
 		},
 		{
-			name:   "tcp-service with preserveClient IP, hc different",
+			name:   "loadBalancer securityGroups without managing backend rules",
 			svc:    &corev1.Service{},
 			tgPort: port80,
-			hcPort: port808,
+			hcPort: trafficPort,
 			subnets: []*ec2.Subnet{
 				{
 					CidrBlock: aws.String("172.16.0.0/19"),
 					SubnetId:  aws.String("sn-1"),
 				},
-				{
-					CidrBlock: aws.String("1.2.3.4/19"),
-					SubnetId:  aws.String("sn-2"),
+			},
+			tgProtocol:           corev1.ProtocolTCP,
+			lbSecurityGroupIDs:   []string{"sg-1"},
+			manageBackendSGRules: false,
+			want:                 nil,
+		},
+		{
+			name: "preserve client IP with source prefixLists",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-security-group-prefix-lists": "pl-0a1b2c3d, pl-4e5f6789, pl-0a1b2c3d",
+					},
 				},
 			},
+			tgPort: port80,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+			}},
 			tgProtocol:       corev1.ProtocolTCP,
 			preserveClientIP: true,
 			want: &elbv2.TargetGroupBindingNetworking{
@@ -547,8 +1590,13 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 					{
 						From: []elbv2.NetworkingPeer{
 							{
-								IPBlock: &elbv2api.IPBlock{
-									CIDR: "0.0.0.0/0",
+								PrefixList: &elbv2api.PrefixList{
+									PrefixListID: "pl-0a1b2c3d",
+								},
+							},
+							{
+								PrefixList: &elbv2api.PrefixList{
+									PrefixListID: "pl-4e5f6789",
 								},
 							},
 						},
@@ -566,16 +1614,11 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 									CIDR: "172.16.0.0/19",
 								},
 							},
-							{
-								IPBlock: &elbv2api.IPBlock{
-									CIDR: "1.2.3.4/19",
-								},
-							},
 						},
 						Ports: []elbv2api.NetworkingPort{
 							{
 								Protocol: &networkingProtocolTCP,
-								Port:     &port808,
+								Port:     &port80,
 							},
 						},
 					},
@@ -583,26 +1626,24 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 			},
 		},
 		{
-			name: "tcp-service with preserve Client IP with different hc port and source range specified",
+			name: "udp-service with both source ranges and source prefixLists",
 			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-security-group-prefix-lists": "pl-0a1b2c3d",
+					},
+				},
 				Spec: corev1.ServiceSpec{
-					LoadBalancerSourceRanges: []string{"10.0.0.0/16", "1.2.3.4/24"},
+					LoadBalancerSourceRanges: []string{"10.0.0.0/16"},
 				},
 			},
 			tgPort: port80,
 			hcPort: port808,
-			subnets: []*ec2.Subnet{
-				{
-					CidrBlock: aws.String("172.16.0.0/19"),
-					SubnetId:  aws.String("sn-1"),
-				},
-				{
-					CidrBlock: aws.String("1.2.3.4/19"),
-					SubnetId:  aws.String("sn-2"),
-				},
-			},
-			tgProtocol:       corev1.ProtocolTCP,
-			preserveClientIP: true,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+			}},
+			tgProtocol: corev1.ProtocolUDP,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
 					{
@@ -613,14 +1654,14 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 								},
 							},
 							{
-								IPBlock: &elbv2api.IPBlock{
-									CIDR: "1.2.3.4/24",
+								PrefixList: &elbv2api.PrefixList{
+									PrefixListID: "pl-0a1b2c3d",
 								},
 							},
 						},
 						Ports: []elbv2api.NetworkingPort{
 							{
-								Protocol: &networkingProtocolTCP,
+								Protocol: &networkingProtocolUDP,
 								Port:     &port80,
 							},
 						},
@@ -632,11 +1673,6 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 									CIDR: "172.16.0.0/19",
 								},
 							},
-							{
-								IPBlock: &elbv2api.IPBlock{
-									CIDR: "1.2.3.4/19",
-								},
-							},
 						},
 						Ports: []elbv2api.NetworkingPort{
 							{
@@ -648,13 +1684,37 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 				},
 			},
 		},
+		{
+			name: "udp-service with invalid source prefixList",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-security-group-prefix-lists": "pl-0a1b2c3d, sg-12345",
+					},
+				},
+			},
+			tgPort: port80,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+			}},
+			tgProtocol: corev1.ProtocolUDP,
+			wantErr:    errors.New("invalid prefixList ID sg-12345 in annotation aws-load-balancer-security-group-prefix-lists, must be in format pl-xxxxxxxx"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
-			builder := &defaultModelBuildTask{service: tt.svc, annotationParser: parser, ec2Subnets: tt.subnets}
-			got := builder.buildTargetGroupBindingNetworking(context.Background(), tt.tgPort, tt.preserveClientIP, tt.hcPort, tt.tgProtocol)
-			assert.Equal(t, tt.want, got)
+			builder := &defaultModelBuildTask{service: tt.svc, annotationParser: parser, ec2Subnets: tt.subnets,
+				lbSecurityGroupIDs: tt.lbSecurityGroupIDs, manageBackendSGRules: tt.manageBackendSGRules}
+			got, err := builder.buildTargetGroupBindingNetworking(context.Background(), tt.tgPort, tt.preserveClientIP, tt.hcPort, tt.tgProtocol)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
 		})
 	}
 }
@@ -752,3 +1812,301 @@ func Test_defaultModelBuilder_buildPreserveClientIPFlag(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuilder_buildPreserveClientIPFlag_annotation(t *testing.T) {
+	tests := []struct {
+		testName    string
+		targetType  elbv2.TargetType
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			testName:    "IP mode default off",
+			targetType:  elbv2.TargetTypeIP,
+			annotations: map[string]string{},
+			want:        false,
+		},
+		{
+			testName:   "IP mode toggled on",
+			targetType: elbv2.TargetTypeIP,
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-preserve-client-ip": "true",
+			},
+			want: true,
+		},
+		{
+			testName:   "IP mode toggled off",
+			targetType: elbv2.TargetTypeIP,
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-preserve-client-ip": "false",
+			},
+			want: false,
+		},
+		{
+			testName:    "Instance mode default on",
+			targetType:  elbv2.TargetTypeInstance,
+			annotations: map[string]string{},
+			want:        true,
+		},
+		{
+			testName:   "Instance mode toggled off",
+			targetType: elbv2.TargetTypeInstance,
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-preserve-client-ip": "false",
+			},
+			want: false,
+		},
+		{
+			testName:   "Instance mode toggled off via attribute",
+			targetType: elbv2.TargetTypeInstance,
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": "preserve_client_ip.enabled=false",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			builder := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: tt.annotations,
+					},
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+			}
+			tgAttrs, err := builder.buildTargetGroupAttributes(context.Background())
+			assert.NoError(t, err)
+			got, err := builder.buildPreserveClientIPFlag(context.Background(), tt.targetType, tgAttrs)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_buildTargetGroupIPAddressType(t *testing.T) {
+	ipv4 := elbv2.TargetGroupIPAddressTypeIPv4
+	ipv6 := elbv2.TargetGroupIPAddressTypeIPv6
+	tests := []struct {
+		testName        string
+		targetType      elbv2.TargetType
+		lbIPAddressType elbv2.IPAddressType
+		annotations     map[string]string
+		want            *elbv2.TargetGroupIPAddressType
+		wantErr         error
+	}{
+		{
+			testName:        "ELBV2 default by default",
+			targetType:      elbv2.TargetTypeIP,
+			lbIPAddressType: elbv2.IPAddressTypeDualStack,
+			annotations:     map[string]string{},
+			want:            nil,
+		},
+		{
+			testName:        "ipv4 for dualstack loadBalancer",
+			targetType:      elbv2.TargetTypeIP,
+			lbIPAddressType: elbv2.IPAddressTypeDualStack,
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-ip-address-type": "ipv4",
+			},
+			want: &ipv4,
+		},
+		{
+			testName:        "ipv4 for instance target type",
+			targetType:      elbv2.TargetTypeInstance,
+			lbIPAddressType: elbv2.IPAddressTypeIPV4,
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-ip-address-type": "ipv4",
+			},
+			want: &ipv4,
+		},
+		{
+			testName:        "ipv6 for dualstack loadBalancer",
+			targetType:      elbv2.TargetTypeIP,
+			lbIPAddressType: elbv2.IPAddressTypeDualStack,
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-ip-address-type": "ipv6",
+			},
+			want: &ipv6,
+		},
+		{
+			testName:        "ipv6 for instance target type",
+			targetType:      elbv2.TargetTypeInstance,
+			lbIPAddressType: elbv2.IPAddressTypeDualStack,
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-ip-address-type": "ipv6",
+			},
+			wantErr: errors.New("target IP address type ipv6 is only supported with target type ip, got instance"),
+		},
+		{
+			testName:        "ipv6 for ipv4 loadBalancer",
+			targetType:      elbv2.TargetTypeIP,
+			lbIPAddressType: elbv2.IPAddressTypeIPV4,
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-ip-address-type": "ipv6",
+			},
+			wantErr: errors.New("target IP address type ipv6 requires dualstack load balancer IP address type"),
+		},
+		{
+			testName:        "unsupported IP address type",
+			targetType:      elbv2.TargetTypeIP,
+			lbIPAddressType: elbv2.IPAddressTypeDualStack,
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-target-ip-address-type": "dualstack",
+			},
+			wantErr: errors.New("unsupported target IP address type dualstack, must be within [ipv4, ipv6]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+			builder := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: tt.annotations,
+					},
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				loadBalancer: elbv2.NewLoadBalancer(stack, "LoadBalancer", elbv2.LoadBalancerSpec{
+					IPAddressType: &tt.lbIPAddressType,
+				}),
+			}
+			got, err := builder.buildTargetGroupIPAddressType(context.Background(), tt.targetType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_buildTargetGroupName(t *testing.T) {
+	tcpProtocol := elbv2.ProtocolTCP
+	intervalSeconds := int64(10)
+	hc := &elbv2.TargetGroupHealthCheckConfig{
+		Protocol:        &tcpProtocol,
+		IntervalSeconds: &intervalSeconds,
+	}
+	tests := []struct {
+		name                  string
+		svc                   *corev1.Service
+		svcPort               intstr.IntOrString
+		tgPort                int64
+		tgProtocol            elbv2.Protocol
+		targetGroupNamePrefix string
+		want                  string
+	}{
+		{
+			name: "single port service",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc", UID: "my-uuid"},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Port: 80}},
+				},
+			},
+			svcPort:    intstr.FromInt(80),
+			tgPort:     8080,
+			tgProtocol: elbv2.ProtocolTCP,
+			want:       "k8s-awesomen-awesomes-78923d49f9",
+		},
+		{
+			name: "multiple port service includes service port",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc", UID: "my-uuid"},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Port: 80}, {Port: 443}},
+				},
+			},
+			svcPort:    intstr.FromInt(80),
+			tgPort:     8080,
+			tgProtocol: elbv2.ProtocolTCP,
+			want:       "k8s-awesom-awesom-80-78923d49f96",
+		},
+		{
+			name: "multiple port service with 5 digits service port",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc", UID: "my-uuid"},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Port: 80}, {Port: 65535}},
+				},
+			},
+			svcPort:    intstr.FromInt(65535),
+			tgPort:     8443,
+			tgProtocol: elbv2.ProtocolTCP,
+			want:       "k8s-awesom-awesom-65535-f09245f4",
+		},
+		{
+			name: "single port service with name prefix",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc", UID: "my-uuid"},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Port: 80}},
+				},
+			},
+			svcPort:               intstr.FromInt(80),
+			tgPort:                8080,
+			tgProtocol:            elbv2.ProtocolTCP,
+			targetGroupNamePrefix: "prod",
+			want:                  "prod-awesomen-awesomes-78923d49f",
+		},
+		{
+			name: "single port service with short names and name prefix",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc", UID: "my-uuid"},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Port: 80}},
+				},
+			},
+			svcPort:               intstr.FromInt(80),
+			tgPort:                8080,
+			tgProtocol:            elbv2.ProtocolTCP,
+			targetGroupNamePrefix: "prod",
+			want:                  "prod-ns-svc-78923d49f9",
+		},
+		{
+			name: "single port service with max length name prefix",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc", UID: "my-uuid"},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Port: 80}},
+				},
+			},
+			svcPort:               intstr.FromInt(80),
+			tgPort:                8080,
+			tgProtocol:            elbv2.ProtocolTCP,
+			targetGroupNamePrefix: "prod-use",
+			want:                  "prod-use-awesom-awesom-78923d49f",
+		},
+		{
+			name: "multiple port service with 5 digits service port and max length name prefix",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc", UID: "my-uuid"},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Port: 80}, {Port: 65535}},
+				},
+			},
+			svcPort:               intstr.FromInt(65535),
+			tgPort:                8443,
+			tgProtocol:            elbv2.ProtocolTCP,
+			targetGroupNamePrefix: "prod-use",
+			want:                  "prod-use-awe-awe-65535-f09245f48",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &defaultModelBuildTask{
+				clusterName:           "my-cluster",
+				service:               tt.svc,
+				targetGroupNamePrefix: tt.targetGroupNamePrefix,
+			}
+			got := builder.buildTargetGroupName(context.Background(), tt.svcPort, tt.tgPort, elbv2.TargetTypeIP, tt.tgProtocol, nil, hc)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), maxTargetGroupNameLength)
+			// the name must be stable across reconciles for the same inputs.
+			gotAgain := builder.buildTargetGroupName(context.Background(), tt.svcPort, tt.tgPort, elbv2.TargetTypeIP, tt.tgProtocol, nil, hc)
+			assert.Equal(t, got, gotAgain)
+		})
+	}
+}
@@ -2,13 +2,39 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// topics of the announcements made for services.
+	announcementTopicInvalidAnnotation           = "invalid-annotation/"
+	announcementTopicTLSPortsWithoutCertificates = "tls-ports-without-certificates"
+	announcementTopicSingleAZSubnets             = "single-az-subnets"
+	announcementTopicCrossZoneCost               = "cross-zone-cost"
+	announcementTopicDiscoveredSubnets           = "discovered-subnets"
+
+	// the EIP allocations found in current account and region will be cached for 1 minute.
+	defaultEIPAllocationCacheTTL = 1 * time.Minute
 )
 
 // ModelBuilder builds the model stack for the service resource.
@@ -17,29 +43,132 @@ type ModelBuilder interface {
 	Build(ctx context.Context, service *corev1.Service) (core.Stack, *elbv2model.LoadBalancer, error)
 }
 
+// AnnotationsValidator validates the load balancer annotations of service without provisioning anything.
+type AnnotationsValidator interface {
+	// ValidateAnnotations runs the model build pipeline in dry-run mode, and returns the validation errors found.
+	// the independent build steps keep running after an error, so that the errors of all of them are collected.
+	ValidateAnnotations(ctx context.Context, service *corev1.Service) []AnnotationValidationError
+}
+
+// AnnotationValidationError is a validation error found in dry-run mode.
+type AnnotationValidationError struct {
+	// Annotation is the annotation that failed validation, it's empty if the error cannot be attributed to an annotation.
+	Annotation string `json:"annotation"`
+	// Message describes the validation error.
+	Message string `json:"message"`
+}
+
 // NewDefaultModelBuilder construct a new defaultModelBuilder
-func NewDefaultModelBuilder(annotationParser annotations.Parser, subnetsResolver networking.SubnetsResolver, clusterName string) *defaultModelBuilder {
+func NewDefaultModelBuilder(annotationParser annotations.Parser, subnetsResolver networking.SubnetsResolver, sgResolver networking.SecurityGroupResolver, ec2Client services.EC2,
+	elbv2TaggingManager elbv2deploy.TaggingManager, trackingProvider tracking.Provider, eventRecorder record.EventRecorder,
+	accessLogBucketValidator aws.AccessLogBucketValidator, announcementTracker *k8s.AnnouncementTracker, config config.ControllerConfig) *defaultModelBuilder {
 	return &defaultModelBuilder{
-		annotationParser: annotationParser,
-		subnetsResolver:  subnetsResolver,
-		clusterName:      clusterName,
+		annotationParser:         annotationParser,
+		subnetsResolver:          subnetsResolver,
+		sgResolver:               sgResolver,
+		ec2Client:                ec2Client,
+		elbv2TaggingManager:      elbv2TaggingManager,
+		trackingProvider:         trackingProvider,
+		eventRecorder:            eventRecorder,
+		clusterName:              config.ClusterName,
+		minAZCount:               config.NLBMinAZCount,
+		singleAZDiscoveryPolicy:  config.NLBSingleAZDiscoveryPolicy,
+		lenientAnnotationParsing: config.ServiceLenientAnnotationParsing,
+		crossZoneCostWarning:     config.NLBCrossZoneCostWarning,
+		targetGroupNamePrefix:    config.ServiceTargetGroupNamePrefix,
+		defaultTags:              config.DefaultTags,
+
+		subnetDiscoveryPreferAvailableIPs: config.SubnetDiscoveryPreferAvailableIPs,
+		azExpansionPolicy:                 config.LoadBalancerAZExpansionPolicy,
+		announcementTracker:               announcementTracker,
+		accessLogBucketValidator:          accessLogBucketValidator,
+		eipAllocationCache:                cache.NewExpiring(),
+		eipAllocationCacheTTL:             defaultEIPAllocationCacheTTL,
 	}
 }
 
 var _ ModelBuilder = &defaultModelBuilder{}
+var _ AnnotationsValidator = &defaultModelBuilder{}
 
 type defaultModelBuilder struct {
-	annotationParser annotations.Parser
-	subnetsResolver  networking.SubnetsResolver
-	clusterName      string
+	annotationParser        annotations.Parser
+	subnetsResolver         networking.SubnetsResolver
+	sgResolver              networking.SecurityGroupResolver
+	ec2Client               services.EC2
+	elbv2TaggingManager     elbv2deploy.TaggingManager
+	trackingProvider        tracking.Provider
+	eventRecorder           record.EventRecorder
+	clusterName             string
+	minAZCount              int
+	singleAZDiscoveryPolicy string
+	// whether to ignore malformed non-critical annotations.
+	lenientAnnotationParsing bool
+	// whether to warn about the data transfer cost of cross-zone load balancing.
+	crossZoneCostWarning bool
+	// prefix for the generated targetGroup names.
+	targetGroupNamePrefix string
+	// default tags for the generated resources.
+	defaultTags map[string]string
+	// whether to prefer subnets with more available IP addresses during subnet discovery.
+	subnetDiscoveryPreferAvailableIPs bool
+	// how to handle discovered subnets in availabilityZones not yet enabled for existing LoadBalancer.
+	azExpansionPolicy string
+	// tracks the announcements made for services, owned by the caller so that it can be forgotten once service is gone.
+	announcementTracker *k8s.AnnouncementTracker
+	// validates the access log buckets, nil if validation is disabled.
+	accessLogBucketValidator aws.AccessLogBucketValidator
+	// the EIP allocations found in current account and region, shared across builds to avoid describing them on every reconcile.
+	eipAllocationCache    *cache.Expiring
+	eipAllocationCacheTTL time.Duration
 }
 
 func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service) (core.Stack, *elbv2model.LoadBalancer, error) {
+	task := b.newModelBuildTask(service)
+	if err := task.run(ctx); err != nil {
+		return nil, nil, err
+	}
+	return task.stack, task.loadBalancer, nil
+}
+
+func (b *defaultModelBuilder) ValidateAnnotations(ctx context.Context, service *corev1.Service) []AnnotationValidationError {
+	task := b.newModelBuildTask(service)
+	task.dryRun = true
+	// events and announcements are left untouched in dry-run mode.
+	task.eventRecorder = noopEventRecorder{}
+	task.announcementTracker = k8s.NewAnnouncementTracker()
+	if err := task.run(ctx); err != nil {
+		task.recordValidationError(err)
+	}
+	return task.validationErrors
+}
+
+func (b *defaultModelBuilder) newModelBuildTask(service *corev1.Service) *defaultModelBuildTask {
 	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(service)))
-	task := &defaultModelBuildTask{
+	return &defaultModelBuildTask{
 		clusterName:      b.clusterName,
 		annotationParser: b.annotationParser,
 		subnetsResolver:  b.subnetsResolver,
+		sgResolver:       b.sgResolver,
+		ec2Client:        b.ec2Client,
+		eventRecorder:    b.eventRecorder,
+
+		elbv2TaggingManager: b.elbv2TaggingManager,
+		trackingProvider:    b.trackingProvider,
+
+		minAZCount:               b.minAZCount,
+		singleAZDiscoveryPolicy:  b.singleAZDiscoveryPolicy,
+		lenientAnnotationParsing: b.lenientAnnotationParsing,
+		crossZoneCostWarning:     b.crossZoneCostWarning,
+		targetGroupNamePrefix:    b.targetGroupNamePrefix,
+		defaultTags:              b.defaultTags,
+
+		subnetDiscoveryPreferAvailableIPs: b.subnetDiscoveryPreferAvailableIPs,
+		azExpansionPolicy:                 b.azExpansionPolicy,
+		announcementTracker:               b.announcementTracker,
+		announcedTopics:                   sets.NewString(),
+		accessLogBucketValidator:          b.accessLogBucketValidator,
+		eipAllocationCache:                b.eipAllocationCache,
+		eipAllocationCacheTTL:             b.eipAllocationCacheTTL,
 
 		service:   service,
 		stack:     stack,
@@ -49,25 +178,59 @@ func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service
 		defaultAccessLogsS3Bucket:            "",
 		defaultAccessLogsS3Prefix:            "",
 		defaultLoadBalancingCrossZoneEnabled: false,
+		defaultDNSRecordClientRoutingPolicy:  dnsRecordClientRoutingPolicyAnyAZ,
 		defaultProxyProtocolV2Enabled:        false,
 		defaultHealthCheckProtocol:           elbv2model.ProtocolTCP,
 		defaultHealthCheckPort:               healthCheckPortTrafficPort,
 		defaultHealthCheckPath:               "/",
 		defaultHealthCheckInterval:           10,
 		defaultHealthCheckTimeout:            10,
+		defaultHTTPHealthCheckTimeout:        6,
 		defaultHealthCheckHealthyThreshold:   3,
 		defaultHealthCheckUnhealthyThreshold: 3,
+
+		defaultEndpointServiceAcceptanceRequired: true,
 	}
-	if err := task.run(ctx); err != nil {
-		return nil, nil, err
-	}
-	return task.stack, task.loadBalancer, nil
 }
 
 type defaultModelBuildTask struct {
 	clusterName      string
 	annotationParser annotations.Parser
 	subnetsResolver  networking.SubnetsResolver
+	sgResolver       networking.SecurityGroupResolver
+	ec2Client        services.EC2
+	eventRecorder    record.EventRecorder
+	// finds the existing LoadBalancer of service by its stack tags.
+	elbv2TaggingManager elbv2deploy.TaggingManager
+	trackingProvider    tracking.Provider
+	// minimum number of availabilityZones the LoadBalancer subnets must span.
+	minAZCount int
+	// how to handle auto-discovered LoadBalancer subnets that span a single availabilityZone.
+	singleAZDiscoveryPolicy string
+	// whether to ignore malformed non-critical annotations instead of failing the build, keeping the currently applied values.
+	lenientAnnotationParsing bool
+	// whether to emit warning event when cross-zone load balancing is enabled, given the inter-AZ data transfer cost.
+	crossZoneCostWarning bool
+	// prefix for the generated targetGroup names, the default k8s prefix is used if empty.
+	targetGroupNamePrefix string
+	// default tags for the generated resources, overridden by user-specified tags on key collision.
+	defaultTags map[string]string
+	// whether to choose the subnet with most available IP addresses when multiple subnets are discovered within an availabilityZone.
+	subnetDiscoveryPreferAvailableIPs bool
+	// how to handle discovered subnets in availabilityZones not yet enabled for existing LoadBalancer.
+	azExpansionPolicy string
+	// tracks the announcements made, so that an event is only emitted when an announcement appears or changes.
+	announcementTracker *k8s.AnnouncementTracker
+	// the topics announced during this build, the announcements of other topics are dropped after a successful build.
+	announcedTopics sets.String
+	// validates the S3 bucket for access logs exists within the LoadBalancer region, nil if validation is disabled.
+	accessLogBucketValidator aws.AccessLogBucketValidator
+	// caches the EIP allocations found in current account and region.
+	eipAllocationCache    *cache.Expiring
+	eipAllocationCacheTTL time.Duration
+	// whether to collect validation errors into validationErrors instead of failing on the first one.
+	dryRun           bool
+	validationErrors []AnnotationValidationError
 
 	service *corev1.Service
 
@@ -75,45 +238,152 @@ type defaultModelBuildTask struct {
 	loadBalancer *elbv2model.LoadBalancer
 	tgByResID    map[string]*elbv2model.TargetGroup
 	ec2Subnets   []*ec2.Subnet
+	// the existing LoadBalancer of service, fetched lazily and nil if it doesn't exist yet.
+	existingLoadBalancer        *elbv2deploy.LoadBalancerWithTags
+	existingLoadBalancerFetched bool
+	// securityGroups attached to the LoadBalancer, NLBs without securityGroups are referenced by VPC CIDRs.
+	lbSecurityGroupIDs []string
+	// whether to manage the ingress rules from the LoadBalancer securityGroups on backends.
+	manageBackendSGRules bool
 
 	defaultAccessLogS3Enabled            bool
 	defaultAccessLogsS3Bucket            string
 	defaultAccessLogsS3Prefix            string
 	defaultLoadBalancingCrossZoneEnabled bool
+	defaultDNSRecordClientRoutingPolicy  string
 	defaultProxyProtocolV2Enabled        bool
 	defaultHealthCheckProtocol           elbv2model.Protocol
 	defaultHealthCheckPort               string
 	defaultHealthCheckPath               string
 	defaultHealthCheckInterval           int64
 	defaultHealthCheckTimeout            int64
+	defaultHTTPHealthCheckTimeout        int64
 	defaultHealthCheckHealthyThreshold   int64
 	defaultHealthCheckUnhealthyThreshold int64
+
+	defaultEndpointServiceAcceptanceRequired bool
 }
 
 func (t *defaultModelBuildTask) run(ctx context.Context) error {
 	if !t.service.DeletionTimestamp.IsZero() {
 		return nil
 	}
-	err := t.buildModel(ctx)
-	return err
+	if err := t.buildModel(ctx); err != nil {
+		return err
+	}
+	// announcements no longer made are dropped, so that they're announced again once they reappear.
+	t.announcementTracker.Retain(k8s.NamespacedName(t.service).String(), t.announcedTopics)
+	return nil
+}
+
+// announce emits an event for topic, unless the same announcement of topic has been made for service already.
+func (t *defaultModelBuildTask) announce(topic string, eventType string, reason string, message string) {
+	t.announcedTopics.Insert(topic)
+	if !t.announcementTracker.Observe(k8s.NamespacedName(t.service).String(), topic, message) {
+		return
+	}
+	t.eventRecorder.Event(t.service, eventType, reason, message)
 }
 
+// buildModel builds the model, in dry-run mode the independent build steps keep running after an error, so that the errors of all of them are recorded.
 func (t *defaultModelBuildTask) buildModel(ctx context.Context) error {
 	scheme, err := t.buildLoadBalancerScheme(ctx)
 	if err != nil {
-		return err
+		if err := t.continueOnValidationError(err); err != nil {
+			return err
+		}
+		scheme = elbv2model.LoadBalancerSchemeInternetFacing
 	}
 	t.ec2Subnets, err = t.resolveLoadBalancerSubnets(ctx, scheme)
-	if err != nil {
+	if err := t.continueOnValidationError(err); err != nil {
 		return err
 	}
 	err = t.buildLoadBalancer(ctx, scheme)
-	if err != nil {
+	if err := t.continueOnValidationError(err); err != nil {
 		return err
 	}
 	err = t.buildListeners(ctx)
-	if err != nil {
+	if err := t.continueOnValidationError(err); err != nil {
+		return err
+	}
+	err = t.buildEndpointService(ctx, scheme)
+	if err := t.continueOnValidationError(err); err != nil {
+		return err
+	}
+	return nil
+}
+
+// continueOnValidationError returns err, unless in dry-run mode, where err is recorded as validation error and nil is returned.
+func (t *defaultModelBuildTask) continueOnValidationError(err error) error {
+	if err == nil || !t.dryRun {
 		return err
 	}
+	t.recordValidationError(err)
 	return nil
 }
+
+// recordValidationError records err as validation error, along with the annotation it's attributed to.
+// identical errors, e.g. reported for each service port, are only recorded once.
+func (t *defaultModelBuildTask) recordValidationError(err error) {
+	annotation := ""
+	var invalidAnnotationErr *annotations.InvalidAnnotationError
+	if errors.As(err, &invalidAnnotationErr) {
+		annotation = invalidAnnotationErr.Key
+	}
+	validationErr := AnnotationValidationError{Annotation: annotation, Message: err.Error()}
+	for _, recorded := range t.validationErrors {
+		if recorded == validationErr {
+			return
+		}
+	}
+	t.validationErrors = append(t.validationErrors, validationErr)
+}
+
+// invalidAnnotationError attributes err to the service annotation with suffix.
+func (t *defaultModelBuildTask) invalidAnnotationError(suffix string, err error) error {
+	return annotations.NewInvalidAnnotationError(t.annotationKey(suffix), err)
+}
+
+// annotationKey returns the key of service annotation with suffix, the suffix is returned if service doesn't have such annotation.
+func (t *defaultModelBuildTask) annotationKey(suffix string) string {
+	keys := make([]string, 0, len(t.service.Annotations))
+	for key := range t.service.Annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if strings.HasSuffix(key, "/"+suffix) {
+			return key
+		}
+	}
+	return suffix
+}
+
+// handleNonCriticalAnnotationError handles the parse error of non-critical annotation.
+// with lenient annotation parsing, the error is reported via warning event and the annotation is ignored,
+// callers leave the corresponding setting out of the model so that the currently applied value is kept.
+// in dry-run mode, the error is recorded as validation error and the annotation is ignored.
+func (t *defaultModelBuildTask) handleNonCriticalAnnotationError(annotation string, err error) error {
+	if t.dryRun {
+		t.recordValidationError(t.invalidAnnotationError(annotation, err))
+		return nil
+	}
+	if !t.lenientAnnotationParsing {
+		return err
+	}
+	t.announce(announcementTopicInvalidAnnotation+annotation, corev1.EventTypeWarning, k8s.ServiceEventReasonInvalidAnnotation,
+		fmt.Sprintf("Ignoring invalid annotation %v, keeping current value: %v", annotation, err))
+	return nil
+}
+
+// noopEventRecorder is an EventRecorder that drops all events.
+type noopEventRecorder struct{}
+
+var _ record.EventRecorder = noopEventRecorder{}
+
+func (noopEventRecorder) Event(_ k8sruntime.Object, _, _, _ string) {}
+
+func (noopEventRecorder) Eventf(_ k8sruntime.Object, _, _, _ string, _ ...interface{}) {}
+
+func (noopEventRecorder) AnnotatedEventf(_ k8sruntime.Object, _ map[string]string, _, _, _ string, _ ...interface{}) {
+}
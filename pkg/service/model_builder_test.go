@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
@@ -9,9 +10,17 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	mock_elbv2 "sigs.k8s.io/aws-load-balancer-controller/mocks/deploy/elbv2"
 	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"strings"
 	"testing"
 	"time"
 )
@@ -148,6 +157,10 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                 {
                    "key":"load_balancing.cross_zone.enabled",
                    "value":"false"
+                },
+                {
+                   "key":"dns_record.client_routing_policy",
+                   "value":"any_availability_zone"
                 }
              ]
           }
@@ -346,6 +359,10 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                 {
                    "key":"load_balancing.cross_zone.enabled",
                    "value":"false"
+                },
+                {
+                   "key":"dns_record.client_routing_policy",
+                   "value":"any_availability_zone"
                 }
              ]
           }
@@ -354,7 +371,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
     "AWS::ElasticLoadBalancingV2::TargetGroup":{
        "default/nlb-ip-svc:80":{
           "spec":{
-             "name":"k8s-default-nlbipsvc-62f81639fc",
+             "name":"k8s-defaul-nlbips-80-62f81639fc8",
              "targetType":"ip",
              "port":80,
              "protocol":"TCP",
@@ -362,6 +379,9 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                 "port":8888,
                 "protocol":"HTTP",
                 "path":"/healthz",
+                "matcher":{
+                   "httpCode":"200-399"
+                },
                 "intervalSeconds":10,
                 "timeoutSeconds":30,
                 "healthyThresholdCount":2,
@@ -377,7 +397,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
        },
        "default/nlb-ip-svc:83":{
           "spec":{
-             "name":"k8s-default-nlbipsvc-3ede6b28b6",
+             "name":"k8s-defaul-nlbips-83-3ede6b28b63",
              "targetType":"ip",
              "port":80,
              "protocol":"TCP",
@@ -385,6 +405,9 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                 "port":8888,
                 "protocol":"HTTP",
                 "path":"/healthz",
+                "matcher":{
+                   "httpCode":"200-399"
+                },
                 "intervalSeconds":10,
                 "timeoutSeconds":30,
                 "healthyThresholdCount":2,
@@ -404,7 +427,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
           "spec":{
              "template":{
                 "metadata":{
-                   "name":"k8s-default-nlbipsvc-62f81639fc",
+                   "name":"k8s-defaul-nlbips-80-62f81639fc8",
                    "namespace":"default",
                    "creationTimestamp":null
                 },
@@ -469,7 +492,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
           "spec":{
              "template":{
                 "metadata":{
-                   "name":"k8s-default-nlbipsvc-3ede6b28b6",
+                   "name":"k8s-defaul-nlbips-83-3ede6b28b63",
                    "namespace":"default",
                    "creationTimestamp":null
                 },
@@ -675,6 +698,10 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                 {
                    "key":"load_balancing.cross_zone.enabled",
                    "value":"true"
+                },
+                {
+                   "key":"dns_record.client_routing_policy",
+                   "value":"any_availability_zone"
                 }
              ]
           }
@@ -683,7 +710,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
     "AWS::ElasticLoadBalancingV2::TargetGroup":{
        "default/nlb-ip-svc-tls:80":{
           "spec":{
-             "name":"k8s-default-nlbipsvc-62f81639fc",
+             "name":"k8s-defaul-nlbips-80-62f81639fc8",
              "targetType":"ip",
              "port":80,
              "protocol":"TCP",
@@ -691,6 +718,9 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                 "port":80,
                 "protocol":"HTTP",
                 "path":"/healthz",
+                "matcher":{
+                   "httpCode":"200-399"
+                },
                 "intervalSeconds":10,
                 "timeoutSeconds":30,
                 "healthyThresholdCount":2,
@@ -706,7 +736,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
        },
        "default/nlb-ip-svc-tls:83":{
           "spec":{
-             "name":"k8s-default-nlbipsvc-77ea0c7734",
+             "name":"k8s-defaul-nlbips-83-77ea0c7734b",
              "targetType":"ip",
              "port":8883,
              "protocol":"TCP",
@@ -714,6 +744,9 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                 "port":80,
                 "protocol":"HTTP",
                 "path":"/healthz",
+                "matcher":{
+                   "httpCode":"200-399"
+                },
                 "intervalSeconds":10,
                 "timeoutSeconds":30,
                 "healthyThresholdCount":2,
@@ -733,7 +766,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
           "spec":{
              "template":{
                 "metadata":{
-                   "name":"k8s-default-nlbipsvc-62f81639fc",
+                   "name":"k8s-defaul-nlbips-80-62f81639fc8",
                    "namespace":"default",
                    "creationTimestamp":null
                 },
@@ -783,7 +816,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
           "spec":{
              "template":{
                 "metadata":{
-                   "name":"k8s-default-nlbipsvc-77ea0c7734",
+                   "name":"k8s-defaul-nlbips-83-77ea0c7734b",
                    "namespace":"default",
                    "creationTimestamp":null
                 },
@@ -889,9 +922,19 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 			for _, call := range tt.resolveViaDiscoveryCalls {
 				subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any()).Return(call.subnets, call.err)
 			}
+			sgResolver := mock_networking.NewMockSecurityGroupResolver(ctrl)
 
 			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
-			builder := NewDefaultModelBuilder(annotationParser, subnetsResolver, "my-cluster")
+			elbv2TaggingManager := mock_elbv2.NewMockTaggingManager(ctrl)
+			elbv2TaggingManager.EXPECT().ListLoadBalancers(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			trackingProvider := tracking.NewDefaultProvider("service.k8s.aws", "my-cluster")
+			builder := NewDefaultModelBuilder(annotationParser, subnetsResolver, sgResolver, nil, elbv2TaggingManager, trackingProvider, record.NewFakeRecorder(10),
+				nil, k8s.NewAnnouncementTracker(), config.ControllerConfig{
+					ClusterName:                "my-cluster",
+					NLBMinAZCount:              1,
+					NLBSingleAZDiscoveryPolicy: config.SingleAZDiscoveryPolicyWarn,
+					NLBCrossZoneCostWarning:    true,
+				})
 			ctx := context.Background()
 			stack, _, err := builder.Build(ctx, tt.svc)
 			if tt.wantError {
@@ -909,3 +952,391 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_lenientAnnotationParsing(t *testing.T) {
+	tests := []struct {
+		name                     string
+		lenientAnnotationParsing bool
+		svcAnnotations           map[string]string
+		wantIntervalSeconds      int64
+		wantTimeoutSeconds       *int64
+		wantTags                 map[string]string
+		wantErr                  error
+		wantEvents               []string
+	}{
+		{
+			name: "strict mode fails on malformed non-critical annotation",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval": "ten",
+			},
+			wantErr: errors.New("failed to parse duration annotation, service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval: ten: time: invalid duration \"ten\""),
+		},
+		{
+			name:                     "lenient mode ignores malformed non-critical annotations",
+			lenientAnnotationParsing: true,
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-timeout": "ten",
+			},
+			wantIntervalSeconds: 10,
+			wantTimeoutSeconds:  nil,
+			wantTags:            map[string]string{},
+			wantEvents: []string{
+				"Warning InvalidAnnotation Ignoring invalid annotation aws-load-balancer-healthcheck-timeout, keeping current value: failed to parse duration annotation, service.beta.kubernetes.io/aws-load-balancer-healthcheck-timeout: ten: time: invalid duration \"ten\"",
+			},
+		},
+		{
+			name:                     "lenient mode still fails on malformed interval annotation",
+			lenientAnnotationParsing: true,
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval": "ten",
+			},
+			wantErr: errors.New("failed to parse duration annotation, service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval: ten: time: invalid duration \"ten\""),
+		},
+		{
+			name:                     "lenient mode still fails on malformed additional resource tags annotation",
+			lenientAnnotationParsing: true,
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "team",
+			},
+			wantErr: errors.New("failed to parse stringMap annotation, service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags: team"),
+		},
+		{
+			name:                     "lenient mode keeps valid annotations",
+			lenientAnnotationParsing: true,
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval":     "30",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "team=platform",
+			},
+			wantIntervalSeconds: 30,
+			wantTimeoutSeconds:  aws.Int64(10),
+			wantTags:            map[string]string{"team": "platform"},
+		},
+		{
+			name:                     "lenient mode still fails on malformed critical annotation",
+			lenientAnnotationParsing: true,
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port": "invalid",
+			},
+			wantErr: errors.New("failed to parse int64 annotation, service.beta.kubernetes.io/aws-load-balancer-healthcheck-port: invalid: strconv.ParseInt: parsing \"invalid\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				annotationParser:         annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				eventRecorder:            recorder,
+				lenientAnnotationParsing: tt.lenientAnnotationParsing,
+				announcementTracker:      k8s.NewAnnouncementTracker(),
+				announcedTopics:          sets.NewString(),
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "my-svc",
+						Annotations: tt.svcAnnotations,
+					},
+				},
+				defaultHealthCheckProtocol:           elbv2model.ProtocolTCP,
+				defaultHealthCheckPort:               healthCheckPortTrafficPort,
+				defaultHealthCheckPath:               "/",
+				defaultHealthCheckInterval:           10,
+				defaultHealthCheckTimeout:            10,
+				defaultHealthCheckHealthyThreshold:   3,
+				defaultHealthCheckUnhealthyThreshold: 3,
+			}
+			ctx := context.Background()
+			hc, err := task.buildTargetGroupHealthCheckConfig(ctx, corev1.ServicePort{Name: "http", Port: 80})
+			var tags map[string]string
+			if err == nil {
+				tags, err = task.buildAdditionalResourceTags(ctx)
+			}
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantIntervalSeconds, aws.Int64Value(hc.IntervalSeconds))
+				assert.Equal(t, tt.wantTimeoutSeconds, hc.TimeoutSeconds)
+				assert.Equal(t, tt.wantTags, tags)
+			}
+			close(recorder.Events)
+			var gotEvents []string
+			for event := range recorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_defaultModelBuilder_ValidateAnnotations(t *testing.T) {
+	type resolveViaDiscoveryCall struct {
+		subnets []*ec2.Subnet
+		err     error
+	}
+	discoveredSubnets := []*ec2.Subnet{
+		{
+			SubnetId:         aws.String("subnet-1"),
+			CidrBlock:        aws.String("192.168.0.0/19"),
+			AvailabilityZone: aws.String("us-west-2a"),
+		},
+		{
+			SubnetId:         aws.String("subnet-2"),
+			CidrBlock:        aws.String("192.168.32.0/19"),
+			AvailabilityZone: aws.String("us-west-2b"),
+		},
+	}
+	tests := []struct {
+		name                     string
+		svcAnnotations           map[string]string
+		resolveViaDiscoveryCalls []resolveViaDiscoveryCall
+		want                     []AnnotationValidationError
+	}{
+		{
+			name: "valid annotations",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":                 "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval": "30",
+			},
+			resolveViaDiscoveryCalls: []resolveViaDiscoveryCall{{subnets: discoveredSubnets}},
+			want:                     nil,
+		},
+		{
+			name: "non-critical annotation errors are collected",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":                              "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-healthy-threshold":     "two",
+				"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes":           "preserve_client_ip.enabled=maybe",
+				"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "on",
+			},
+			resolveViaDiscoveryCalls: []resolveViaDiscoveryCall{{subnets: discoveredSubnets}},
+			want: []AnnotationValidationError{
+				{
+					Annotation: "service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled",
+					Message:    "failed to parse bool annotation, service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled: on: strconv.ParseBool: parsing \"on\": invalid syntax",
+				},
+				{
+					Annotation: "service.beta.kubernetes.io/aws-load-balancer-healthcheck-healthy-threshold",
+					Message:    "failed to parse int64 annotation, service.beta.kubernetes.io/aws-load-balancer-healthcheck-healthy-threshold: two: strconv.ParseInt: parsing \"two\": invalid syntax",
+				},
+				{
+					Annotation: "service.beta.kubernetes.io/aws-load-balancer-target-group-attributes",
+					Message:    "failed to parse attribute preserve_client_ip.enabled=maybe: strconv.ParseBool: parsing \"maybe\": invalid syntax",
+				},
+			},
+		},
+		{
+			name: "critical errors are collected from all build steps",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-internal":                 "yes",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "k1=v1,k2",
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval":     "ten",
+			},
+			resolveViaDiscoveryCalls: []resolveViaDiscoveryCall{{subnets: discoveredSubnets}},
+			want: []AnnotationValidationError{
+				{
+					Annotation: "service.beta.kubernetes.io/aws-load-balancer-internal",
+					Message:    "failed to parse bool annotation, service.beta.kubernetes.io/aws-load-balancer-internal: yes: strconv.ParseBool: parsing \"yes\": invalid syntax",
+				},
+				{
+					Annotation: "service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags",
+					Message:    "failed to parse stringMap annotation, service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags: k1=v1,k2",
+				},
+				{
+					Annotation: "service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval",
+					Message:    "failed to parse duration annotation, service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval: ten: time: invalid duration \"ten\"",
+				},
+			},
+		},
+		{
+			name: "errors not caused by annotations are collected without annotation",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+			},
+			resolveViaDiscoveryCalls: []resolveViaDiscoveryCall{{err: errors.New("Throttling: Rate exceeded")}},
+			want: []AnnotationValidationError{
+				{
+					Annotation: "",
+					Message:    "couldn't auto-discover subnets for internet-facing loadBalancer: Throttling: Rate exceeded",
+				},
+			},
+		},
+		{
+			name: "invalid annotation value",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":           "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-proxy-protocol": "tcp",
+			},
+			resolveViaDiscoveryCalls: []resolveViaDiscoveryCall{{subnets: discoveredSubnets}},
+			want: []AnnotationValidationError{
+				{
+					Annotation: "service.beta.kubernetes.io/aws-load-balancer-proxy-protocol",
+					Message:    "invalid value tcp for Load Balancer proxy protocol v2 annotation, only value currently supported is *",
+				},
+			},
+		},
+		{
+			name: "TLS ports without certificates",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":      "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-ports": "443",
+			},
+			resolveViaDiscoveryCalls: []resolveViaDiscoveryCall{{subnets: discoveredSubnets}},
+			want: []AnnotationValidationError{
+				{
+					Annotation: "service.beta.kubernetes.io/aws-load-balancer-ssl-ports",
+					Message:    "aws-load-balancer-ssl-cert annotation must specify at least one certificate for TLS ports in aws-load-balancer-ssl-ports annotation: [443]",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			subnetsResolver := mock_networking.NewMockSubnetsResolver(ctrl)
+			for _, call := range tt.resolveViaDiscoveryCalls {
+				subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any()).Return(call.subnets, call.err)
+			}
+			sgResolver := mock_networking.NewMockSecurityGroupResolver(ctrl)
+			recorder := record.NewFakeRecorder(10)
+			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			elbv2TaggingManager := mock_elbv2.NewMockTaggingManager(ctrl)
+			elbv2TaggingManager.EXPECT().ListLoadBalancers(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			trackingProvider := tracking.NewDefaultProvider("service.k8s.aws", "my-cluster")
+			builder := NewDefaultModelBuilder(annotationParser, subnetsResolver, sgResolver, nil, elbv2TaggingManager, trackingProvider, recorder,
+				nil, k8s.NewAnnouncementTracker(), config.ControllerConfig{
+					ClusterName:                "my-cluster",
+					NLBMinAZCount:              1,
+					NLBSingleAZDiscoveryPolicy: config.SingleAZDiscoveryPolicyWarn,
+					NLBCrossZoneCostWarning:    true,
+				})
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "awesome-ns",
+					Name:        "my-svc",
+					Annotations: tt.svcAnnotations,
+				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "http",
+							Port:       80,
+							TargetPort: intstr.FromInt(8080),
+							Protocol:   corev1.ProtocolTCP,
+						},
+						{
+							Name:       "alt-http",
+							Port:       8080,
+							TargetPort: intstr.FromInt(8080),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+				},
+			}
+			got := builder.ValidateAnnotations(context.Background(), svc)
+			assert.Equal(t, tt.want, got)
+			close(recorder.Events)
+			var gotEvents []string
+			for event := range recorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Empty(t, gotEvents)
+		})
+	}
+}
+
+func Test_defaultModelBuilder_Build_announcesWarningsOnTransition(t *testing.T) {
+	type buildCall struct {
+		crossZoneEnabled string
+		wantEvents       []string
+	}
+	crossZoneCostEvent := "Warning CrossZoneLoadBalancingCost Cross-zone load balancing is enabled, traffic routed to targets in other availabilityZones incurs inter-AZ data transfer charges"
+	tests := []struct {
+		name       string
+		buildCalls []buildCall
+	}{
+		{
+			name: "warning is announced once while it persists",
+			buildCalls: []buildCall{
+				{crossZoneEnabled: "true", wantEvents: []string{crossZoneCostEvent}},
+				{crossZoneEnabled: "true"},
+				{crossZoneEnabled: "true"},
+			},
+		},
+		{
+			name: "warning is announced again once it reappears",
+			buildCalls: []buildCall{
+				{crossZoneEnabled: "true", wantEvents: []string{crossZoneCostEvent}},
+				{crossZoneEnabled: "false"},
+				{crossZoneEnabled: "true", wantEvents: []string{crossZoneCostEvent}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			subnetsResolver := mock_networking.NewMockSubnetsResolver(ctrl)
+			subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any()).Return([]*ec2.Subnet{
+				{
+					SubnetId:         aws.String("subnet-1"),
+					CidrBlock:        aws.String("192.168.0.0/19"),
+					AvailabilityZone: aws.String("us-west-2a"),
+				},
+				{
+					SubnetId:         aws.String("subnet-2"),
+					CidrBlock:        aws.String("192.168.32.0/19"),
+					AvailabilityZone: aws.String("us-west-2b"),
+				},
+			}, nil).AnyTimes()
+			sgResolver := mock_networking.NewMockSecurityGroupResolver(ctrl)
+			elbv2TaggingManager := mock_elbv2.NewMockTaggingManager(ctrl)
+			elbv2TaggingManager.EXPECT().ListLoadBalancers(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			trackingProvider := tracking.NewDefaultProvider("service.k8s.aws", "my-cluster")
+			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			recorder := record.NewFakeRecorder(10)
+			builder := NewDefaultModelBuilder(annotationParser, subnetsResolver, sgResolver, nil, elbv2TaggingManager, trackingProvider, recorder,
+				nil, k8s.NewAnnouncementTracker(), config.ControllerConfig{
+					ClusterName:             "my-cluster",
+					NLBMinAZCount:           1,
+					NLBCrossZoneCostWarning: true,
+				})
+			for _, call := range tt.buildCalls {
+				svc := &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "my-svc",
+						Annotations: map[string]string{
+							"service.beta.kubernetes.io/aws-load-balancer-type":                              "nlb-ip",
+							"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": call.crossZoneEnabled,
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						Type: corev1.ServiceTypeLoadBalancer,
+						Ports: []corev1.ServicePort{
+							{
+								Port:       80,
+								TargetPort: intstr.FromInt(8080),
+								Protocol:   corev1.ProtocolTCP,
+							},
+						},
+					},
+				}
+				_, _, err := builder.Build(context.Background(), svc)
+				assert.NoError(t, err)
+				var gotEvents []string
+				for len(recorder.Events) > 0 {
+					event := <-recorder.Events
+					if strings.HasPrefix(event, "Warning") {
+						gotEvents = append(gotEvents, event)
+					}
+				}
+				assert.Equal(t, call.wantEvents, gotEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAvailabilityZonesCacheTTL = 10 * time.Minute
+)
+
+// AvailabilityZoneResolver resolves the availabilityZones that a TargetGroup's LoadBalancers are enabled in.
+type AvailabilityZoneResolver interface {
+	// ResolveLoadBalancerAvailabilityZones returns the availabilityZones enabled for LoadBalancers attached to TargetGroup.
+	// returns nil if TargetGroup is not attached to any LoadBalancer.
+	ResolveLoadBalancerAvailabilityZones(ctx context.Context, tgARN string) (sets.String, error)
+}
+
+// NewCachedAvailabilityZoneResolver constructs new cachedAvailabilityZoneResolver.
+func NewCachedAvailabilityZoneResolver(elbv2Client services.ELBV2) *cachedAvailabilityZoneResolver {
+	return &cachedAvailabilityZoneResolver{
+		elbv2Client: elbv2Client,
+		azCache:     cache.NewExpiring(),
+		azCacheTTL:  defaultAvailabilityZonesCacheTTL,
+	}
+}
+
+var _ AvailabilityZoneResolver = &cachedAvailabilityZoneResolver{}
+
+// cachedAvailabilityZoneResolver is an cached implementation for AvailabilityZoneResolver.
+// availabilityZones for each TargetGroup will be refreshed per azCacheTTL.
+type cachedAvailabilityZoneResolver struct {
+	elbv2Client services.ELBV2
+
+	// cache of availabilityZones by targetGroupARN.
+	azCache *cache.Expiring
+	// TTL for each targetGroup's availabilityZones.
+	azCacheTTL time.Duration
+	// azCacheMutex protects azCache
+	azCacheMutex sync.RWMutex
+}
+
+func (r *cachedAvailabilityZoneResolver) ResolveLoadBalancerAvailabilityZones(ctx context.Context, tgARN string) (sets.String, error) {
+	r.azCacheMutex.Lock()
+	defer r.azCacheMutex.Unlock()
+
+	if rawCacheItem, exists := r.azCache.Get(tgARN); exists {
+		return rawCacheItem.(sets.String), nil
+	}
+	azs, err := r.resolveLoadBalancerAvailabilityZonesFromAWS(ctx, tgARN)
+	if err != nil {
+		return nil, err
+	}
+	// we don't cache the result when TargetGroup is not attached to any LoadBalancer yet.
+	if azs != nil {
+		r.azCache.Set(tgARN, azs, r.azCacheTTL)
+	}
+	return azs, nil
+}
+
+func (r *cachedAvailabilityZoneResolver) resolveLoadBalancerAvailabilityZonesFromAWS(ctx context.Context, tgARN string) (sets.String, error) {
+	tgList, err := r.elbv2Client.DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(tgList) != 1 {
+		return nil, errors.Errorf("expect exactly one targetGroup: %v", tgARN)
+	}
+	lbARNs := tgList[0].LoadBalancerArns
+	if len(lbARNs) == 0 {
+		return nil, nil
+	}
+	lbList, err := r.elbv2Client.DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{
+		LoadBalancerArns: lbARNs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	azs := sets.NewString()
+	for _, lb := range lbList {
+		for _, az := range lb.AvailabilityZones {
+			azs.Insert(awssdk.StringValue(az.ZoneName))
+		}
+	}
+	return azs, nil
+}
@@ -0,0 +1,150 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"testing"
+	"time"
+)
+
+func Test_cachedAvailabilityZoneResolver_ResolveLoadBalancerAvailabilityZones(t *testing.T) {
+	type describeTargetGroupsAsListCall struct {
+		req  *elbv2sdk.DescribeTargetGroupsInput
+		resp []*elbv2sdk.TargetGroup
+		err  error
+	}
+	type describeLoadBalancersAsListCall struct {
+		req  *elbv2sdk.DescribeLoadBalancersInput
+		resp []*elbv2sdk.LoadBalancer
+		err  error
+	}
+	type fields struct {
+		describeTargetGroupsAsListCalls  []describeTargetGroupsAsListCall
+		describeLoadBalancersAsListCalls []describeLoadBalancersAsListCall
+	}
+	type args struct {
+		tgARN string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    sets.String
+		wantErr error
+	}{
+		{
+			name: "targetGroup attached to loadBalancer",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-arn"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn:   awssdk.String("tg-arn"),
+								LoadBalancerArns: awssdk.StringSlice([]string{"lb-arn"}),
+							},
+						},
+					},
+				},
+				describeLoadBalancersAsListCalls: []describeLoadBalancersAsListCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancersInput{
+							LoadBalancerArns: awssdk.StringSlice([]string{"lb-arn"}),
+						},
+						resp: []*elbv2sdk.LoadBalancer{
+							{
+								LoadBalancerArn: awssdk.String("lb-arn"),
+								AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+									{ZoneName: awssdk.String("us-west-2a")},
+									{ZoneName: awssdk.String("us-west-2b")},
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-arn",
+			},
+			want: sets.NewString("us-west-2a", "us-west-2b"),
+		},
+		{
+			name: "targetGroup not attached to loadBalancer",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-arn"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("tg-arn"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-arn",
+			},
+			want: nil,
+		},
+		{
+			name: "describeTargetGroups fails",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-arn"}),
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-arn",
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetGroupsAsListCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.describeLoadBalancersAsListCalls {
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			r := &cachedAvailabilityZoneResolver{
+				elbv2Client: elbv2Client,
+				azCache:     cache.NewExpiring(),
+				azCacheTTL:  time.Minute,
+			}
+			got, err := r.ResolveLoadBalancerAvailabilityZones(context.Background(), tt.args.tgARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				// second call should be served from cache when loadBalancer is attached.
+				if tt.want != nil {
+					got, err = r.ResolveLoadBalancerAvailabilityZones(context.Background(), tt.args.tgARN)
+					assert.NoError(t, err)
+					assert.Equal(t, tt.want, got)
+				}
+			}
+		})
+	}
+}
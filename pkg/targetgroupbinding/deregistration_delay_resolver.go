@@ -0,0 +1,78 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	tgAttrsDeregistrationDelayTimeoutSeconds = "deregistration_delay.timeout_seconds"
+	// the default deregistration delay of ELBV2 TargetGroups.
+	defaultDeregistrationDelay         = 300 * time.Second
+	defaultDeregistrationDelayCacheTTL = 1 * time.Minute
+)
+
+// DeregistrationDelayResolver resolves the duration targets stay in draining state after deregistered from TargetGroup.
+type DeregistrationDelayResolver interface {
+	// ResolveDeregistrationDelay returns the deregistration_delay.timeout_seconds attribute of TargetGroup.
+	ResolveDeregistrationDelay(ctx context.Context, tgARN string) (time.Duration, error)
+}
+
+// NewCachedDeregistrationDelayResolver constructs new cachedDeregistrationDelayResolver.
+func NewCachedDeregistrationDelayResolver(elbv2Client services.ELBV2) *cachedDeregistrationDelayResolver {
+	return &cachedDeregistrationDelayResolver{
+		elbv2Client:   elbv2Client,
+		delayCache:    cache.NewExpiring(),
+		delayCacheTTL: defaultDeregistrationDelayCacheTTL,
+	}
+}
+
+var _ DeregistrationDelayResolver = &cachedDeregistrationDelayResolver{}
+
+// cachedDeregistrationDelayResolver is an cached implementation for DeregistrationDelayResolver.
+// deregistration delay for each TargetGroup will be refreshed per delayCacheTTL.
+type cachedDeregistrationDelayResolver struct {
+	elbv2Client services.ELBV2
+
+	// cache of deregistration delay by targetGroupARN.
+	delayCache *cache.Expiring
+	// TTL for each targetGroup's deregistration delay.
+	delayCacheTTL time.Duration
+	// delayCacheMutex protects delayCache
+	delayCacheMutex sync.RWMutex
+}
+
+func (r *cachedDeregistrationDelayResolver) ResolveDeregistrationDelay(ctx context.Context, tgARN string) (time.Duration, error) {
+	r.delayCacheMutex.Lock()
+	defer r.delayCacheMutex.Unlock()
+
+	if rawCacheItem, exists := r.delayCache.Get(tgARN); exists {
+		return rawCacheItem.(time.Duration), nil
+	}
+	resp, err := r.elbv2Client.DescribeTargetGroupAttributesWithContext(ctx, &elbv2sdk.DescribeTargetGroupAttributesInput{
+		TargetGroupArn: awssdk.String(tgARN),
+	})
+	if err != nil {
+		return 0, err
+	}
+	delay := defaultDeregistrationDelay
+	for _, attr := range resp.Attributes {
+		if awssdk.StringValue(attr.Key) != tgAttrsDeregistrationDelayTimeoutSeconds {
+			continue
+		}
+		seconds, err := strconv.ParseInt(awssdk.StringValue(attr.Value), 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse attribute %v of targetGroup %v", tgAttrsDeregistrationDelayTimeoutSeconds, tgARN)
+		}
+		delay = time.Duration(seconds) * time.Second
+	}
+	r.delayCache.Set(tgARN, delay, r.delayCacheTTL)
+	return delay, nil
+}
@@ -0,0 +1,148 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/cache"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"testing"
+	"time"
+)
+
+func Test_cachedDeregistrationDelayResolver_ResolveDeregistrationDelay(t *testing.T) {
+	type describeTargetGroupAttributesCall struct {
+		req  *elbv2sdk.DescribeTargetGroupAttributesInput
+		resp *elbv2sdk.DescribeTargetGroupAttributesOutput
+		err  error
+	}
+	type fields struct {
+		describeTargetGroupAttributesCalls []describeTargetGroupAttributesCall
+	}
+	type args struct {
+		tgARN string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    time.Duration
+		wantErr error
+	}{
+		{
+			name: "targetGroup with deregistration delay attribute",
+			fields: fields{
+				describeTargetGroupAttributesCalls: []describeTargetGroupAttributesCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("tg-arn"),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupAttributesOutput{
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("stickiness.enabled"),
+									Value: awssdk.String("false"),
+								},
+								{
+									Key:   awssdk.String("deregistration_delay.timeout_seconds"),
+									Value: awssdk.String("60"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-arn",
+			},
+			want: 60 * time.Second,
+		},
+		{
+			name: "targetGroup without deregistration delay attribute",
+			fields: fields{
+				describeTargetGroupAttributesCalls: []describeTargetGroupAttributesCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("tg-arn"),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupAttributesOutput{},
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-arn",
+			},
+			want: 300 * time.Second,
+		},
+		{
+			name: "targetGroup with invalid deregistration delay attribute",
+			fields: fields{
+				describeTargetGroupAttributesCalls: []describeTargetGroupAttributesCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("tg-arn"),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupAttributesOutput{
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("deregistration_delay.timeout_seconds"),
+									Value: awssdk.String("sixty"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-arn",
+			},
+			wantErr: errors.New("failed to parse attribute deregistration_delay.timeout_seconds of targetGroup tg-arn: strconv.ParseInt: parsing \"sixty\": invalid syntax"),
+		},
+		{
+			name: "describeTargetGroupAttributes fails",
+			fields: fields{
+				describeTargetGroupAttributesCalls: []describeTargetGroupAttributesCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("tg-arn"),
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-arn",
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetGroupAttributesCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupAttributesWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			r := &cachedDeregistrationDelayResolver{
+				elbv2Client:   elbv2Client,
+				delayCache:    cache.NewExpiring(),
+				delayCacheTTL: time.Minute,
+			}
+			got, err := r.ResolveDeregistrationDelay(context.Background(), tt.args.tgARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				// second call should be served from cache.
+				got, err = r.ResolveDeregistrationDelay(context.Background(), tt.args.tgARN)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package targetgroupbinding
+
+import (
+	"sync"
+	"time"
+)
+
+// drainingTargetsTracker tracks the time targets are first observed in draining state for each TargetGroup.
+// the deregistration time isn't exposed by ELBV2 API, so it's approximated by the time we first observed a target draining.
+type drainingTargetsTracker struct {
+	// mutex protects drainingSinceByTG
+	mutex sync.Mutex
+	// the time each draining target is first observed, by targetGroupARN and target's uniqueID.
+	drainingSinceByTG map[string]map[string]time.Time
+}
+
+// newDrainingTargetsTracker constructs new drainingTargetsTracker.
+func newDrainingTargetsTracker() *drainingTargetsTracker {
+	return &drainingTargetsTracker{
+		drainingSinceByTG: make(map[string]map[string]time.Time),
+	}
+}
+
+// track records the draining targets of TargetGroup, targets that are no longer draining will be untracked.
+// returns the latest time these targets are first observed draining, or zero time if there are no draining targets.
+func (t *drainingTargetsTracker) track(tgARN string, drainingTargetIDs []string, now time.Time) time.Time {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(drainingTargetIDs) == 0 {
+		delete(t.drainingSinceByTG, tgARN)
+		return time.Time{}
+	}
+	prevDrainingSince := t.drainingSinceByTG[tgARN]
+	drainingSince := make(map[string]time.Time, len(drainingTargetIDs))
+	var latestDrainingSince time.Time
+	for _, targetID := range drainingTargetIDs {
+		since, exists := prevDrainingSince[targetID]
+		if !exists {
+			since = now
+		}
+		drainingSince[targetID] = since
+		if since.After(latestDrainingSince) {
+			latestDrainingSince = since
+		}
+	}
+	t.drainingSinceByTG[tgARN] = drainingSince
+	return latestDrainingSince
+}
@@ -0,0 +1,114 @@
+package targetgroupbinding
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_drainingTargetsTracker_track(t *testing.T) {
+	t0 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	type trackCall struct {
+		tgARN             string
+		drainingTargetIDs []string
+		now               time.Time
+		want              time.Time
+	}
+	tests := []struct {
+		name       string
+		trackCalls []trackCall
+	}{
+		{
+			name: "no draining targets",
+			trackCalls: []trackCall{
+				{
+					tgARN: "tg-1",
+					now:   t0,
+					want:  time.Time{},
+				},
+			},
+		},
+		{
+			name: "draining targets keep the time first observed",
+			trackCalls: []trackCall{
+				{
+					tgARN:             "tg-1",
+					drainingTargetIDs: []string{"192.168.1.1:8080"},
+					now:               t0,
+					want:              t0,
+				},
+				{
+					tgARN:             "tg-1",
+					drainingTargetIDs: []string{"192.168.1.1:8080"},
+					now:               t0.Add(time.Minute),
+					want:              t0,
+				},
+			},
+		},
+		{
+			name: "new draining targets advance the latest time",
+			trackCalls: []trackCall{
+				{
+					tgARN:             "tg-1",
+					drainingTargetIDs: []string{"192.168.1.1:8080"},
+					now:               t0,
+					want:              t0,
+				},
+				{
+					tgARN:             "tg-1",
+					drainingTargetIDs: []string{"192.168.1.1:8080", "192.168.1.2:8080"},
+					now:               t0.Add(time.Minute),
+					want:              t0.Add(time.Minute),
+				},
+			},
+		},
+		{
+			name: "targets no longer draining are untracked",
+			trackCalls: []trackCall{
+				{
+					tgARN:             "tg-1",
+					drainingTargetIDs: []string{"192.168.1.1:8080", "192.168.1.2:8080"},
+					now:               t0,
+					want:              t0,
+				},
+				{
+					tgARN: "tg-1",
+					now:   t0.Add(time.Minute),
+					want:  time.Time{},
+				},
+				{
+					tgARN:             "tg-1",
+					drainingTargetIDs: []string{"192.168.1.1:8080"},
+					now:               t0.Add(2 * time.Minute),
+					want:              t0.Add(2 * time.Minute),
+				},
+			},
+		},
+		{
+			name: "targets are tracked per targetGroup",
+			trackCalls: []trackCall{
+				{
+					tgARN:             "tg-1",
+					drainingTargetIDs: []string{"192.168.1.1:8080"},
+					now:               t0,
+					want:              t0,
+				},
+				{
+					tgARN:             "tg-2",
+					drainingTargetIDs: []string{"192.168.1.1:8080"},
+					now:               t0.Add(time.Minute),
+					want:              t0.Add(time.Minute),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newDrainingTargetsTracker()
+			for _, call := range tt.trackCalls {
+				got := tracker.track(call.tgARN, call.drainingTargetIDs, call.now)
+				assert.Equal(t, call.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+// HealthCheckManager is responsible for apply health check settings from TargetGroupBinding to TargetGroup.
+type HealthCheckManager interface {
+	// Reconcile health check settings for TargetGroupBinding.
+	Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error
+}
+
+// NewDefaultHealthCheckManager constructs new defaultHealthCheckManager.
+func NewDefaultHealthCheckManager(elbv2Client services.ELBV2, logger logr.Logger) *defaultHealthCheckManager {
+	return &defaultHealthCheckManager{
+		elbv2Client: elbv2Client,
+		logger:      logger,
+	}
+}
+
+var _ HealthCheckManager = &defaultHealthCheckManager{}
+
+// default implementation for HealthCheckManager.
+type defaultHealthCheckManager struct {
+	elbv2Client services.ELBV2
+	logger      logr.Logger
+}
+
+func (m *defaultHealthCheckManager) Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if tgb.Spec.HealthCheck == nil {
+		return nil
+	}
+	tgARN := tgb.Spec.TargetGroupARN
+	req := &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+	}
+	tgList, err := m.elbv2Client.DescribeTargetGroupsAsList(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(tgList) != 1 {
+		return errors.Errorf("expecting a single targetGroup but got %v", len(tgList))
+	}
+	sdkTG := tgList[0]
+	if !isSDKTargetGroupHealthCheckDrifted(*tgb.Spec.HealthCheck, sdkTG) {
+		return nil
+	}
+
+	modifyReq := buildSDKModifyTargetGroupHealthCheckInput(*tgb.Spec.HealthCheck)
+	modifyReq.TargetGroupArn = awssdk.String(tgARN)
+	m.logger.Info("modifying targetGroup healthCheck",
+		"tgb", k8s.NamespacedName(tgb),
+		"arn", tgARN)
+	if _, err := m.elbv2Client.ModifyTargetGroupWithContext(ctx, modifyReq); err != nil {
+		return err
+	}
+	m.logger.Info("modified targetGroup healthCheck",
+		"tgb", k8s.NamespacedName(tgb),
+		"arn", tgARN)
+	return nil
+}
+
+func isSDKTargetGroupHealthCheckDrifted(hc elbv2api.TargetGroupHealthCheck, sdkTG *elbv2sdk.TargetGroup) bool {
+	if hc.Protocol != nil && string(*hc.Protocol) != awssdk.StringValue(sdkTG.HealthCheckProtocol) {
+		return true
+	}
+	if hc.Port != nil && hc.Port.String() != awssdk.StringValue(sdkTG.HealthCheckPort) {
+		return true
+	}
+	if hc.Path != nil && awssdk.StringValue(hc.Path) != awssdk.StringValue(sdkTG.HealthCheckPath) {
+		return true
+	}
+	if hc.IntervalSeconds != nil && awssdk.Int64Value(hc.IntervalSeconds) != awssdk.Int64Value(sdkTG.HealthCheckIntervalSeconds) {
+		return true
+	}
+	if hc.TimeoutSeconds != nil && awssdk.Int64Value(hc.TimeoutSeconds) != awssdk.Int64Value(sdkTG.HealthCheckTimeoutSeconds) {
+		return true
+	}
+	if hc.HealthyThresholdCount != nil && awssdk.Int64Value(hc.HealthyThresholdCount) != awssdk.Int64Value(sdkTG.HealthyThresholdCount) {
+		return true
+	}
+	if hc.UnhealthyThresholdCount != nil && awssdk.Int64Value(hc.UnhealthyThresholdCount) != awssdk.Int64Value(sdkTG.UnhealthyThresholdCount) {
+		return true
+	}
+	return false
+}
+
+func buildSDKModifyTargetGroupHealthCheckInput(hc elbv2api.TargetGroupHealthCheck) *elbv2sdk.ModifyTargetGroupInput {
+	sdkObj := &elbv2sdk.ModifyTargetGroupInput{}
+	sdkObj.HealthCheckEnabled = awssdk.Bool(true)
+	if hc.Protocol != nil {
+		sdkObj.HealthCheckProtocol = awssdk.String(string(*hc.Protocol))
+	}
+	if hc.Port != nil {
+		sdkObj.HealthCheckPort = awssdk.String(hc.Port.String())
+	}
+	sdkObj.HealthCheckPath = hc.Path
+	sdkObj.HealthCheckIntervalSeconds = hc.IntervalSeconds
+	sdkObj.HealthCheckTimeoutSeconds = hc.TimeoutSeconds
+	sdkObj.HealthyThresholdCount = hc.HealthyThresholdCount
+	sdkObj.UnhealthyThresholdCount = hc.UnhealthyThresholdCount
+	return sdkObj
+}
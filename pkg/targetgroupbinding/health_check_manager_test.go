@@ -0,0 +1,211 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultHealthCheckManager_Reconcile(t *testing.T) {
+	type describeTargetGroupsAsListCall struct {
+		req  *elbv2sdk.DescribeTargetGroupsInput
+		resp []*elbv2sdk.TargetGroup
+		err  error
+	}
+	type modifyTargetGroupCall struct {
+		req  *elbv2sdk.ModifyTargetGroupInput
+		resp *elbv2sdk.ModifyTargetGroupOutput
+		err  error
+	}
+	type fields struct {
+		describeTargetGroupsAsListCalls []describeTargetGroupsAsListCall
+		modifyTargetGroupCalls          []modifyTargetGroupCall
+	}
+
+	protocolHTTP := elbv2api.HealthCheckProtocolHTTP
+	portTraffic := intstr.FromString("traffic-port")
+	healthCheck := &elbv2api.TargetGroupHealthCheck{
+		Protocol:        &protocolHTTP,
+		Port:            &portTraffic,
+		Path:            awssdk.String("/healthz"),
+		IntervalSeconds: awssdk.Int64(10),
+		TimeoutSeconds:  awssdk.Int64(5),
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		healthCheck *elbv2api.TargetGroupHealthCheck
+		wantErr     error
+	}{
+		{
+			name:        "healthCheck is not set",
+			healthCheck: nil,
+		},
+		{
+			name: "healthCheck is up to date",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn:             awssdk.String("tg-1"),
+								HealthCheckProtocol:        awssdk.String("HTTP"),
+								HealthCheckPort:            awssdk.String("traffic-port"),
+								HealthCheckPath:            awssdk.String("/healthz"),
+								HealthCheckIntervalSeconds: awssdk.Int64(10),
+								HealthCheckTimeoutSeconds:  awssdk.Int64(5),
+								HealthyThresholdCount:      awssdk.Int64(5),
+								UnhealthyThresholdCount:    awssdk.Int64(2),
+							},
+						},
+					},
+				},
+			},
+			healthCheck: healthCheck,
+		},
+		{
+			name: "healthCheck drifted",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn:             awssdk.String("tg-1"),
+								HealthCheckProtocol:        awssdk.String("HTTP"),
+								HealthCheckPort:            awssdk.String("traffic-port"),
+								HealthCheckPath:            awssdk.String("/"),
+								HealthCheckIntervalSeconds: awssdk.Int64(30),
+								HealthCheckTimeoutSeconds:  awssdk.Int64(5),
+							},
+						},
+					},
+				},
+				modifyTargetGroupCalls: []modifyTargetGroupCall{
+					{
+						req: &elbv2sdk.ModifyTargetGroupInput{
+							TargetGroupArn:             awssdk.String("tg-1"),
+							HealthCheckEnabled:         awssdk.Bool(true),
+							HealthCheckProtocol:        awssdk.String("HTTP"),
+							HealthCheckPort:            awssdk.String("traffic-port"),
+							HealthCheckPath:            awssdk.String("/healthz"),
+							HealthCheckIntervalSeconds: awssdk.Int64(10),
+							HealthCheckTimeoutSeconds:  awssdk.Int64(5),
+						},
+						resp: &elbv2sdk.ModifyTargetGroupOutput{},
+					},
+				},
+			},
+			healthCheck: healthCheck,
+		},
+		{
+			name: "failed to modify targetGroup",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn:      awssdk.String("tg-1"),
+								HealthCheckProtocol: awssdk.String("TCP"),
+							},
+						},
+					},
+				},
+				modifyTargetGroupCalls: []modifyTargetGroupCall{
+					{
+						req: &elbv2sdk.ModifyTargetGroupInput{
+							TargetGroupArn:             awssdk.String("tg-1"),
+							HealthCheckEnabled:         awssdk.Bool(true),
+							HealthCheckProtocol:        awssdk.String("HTTP"),
+							HealthCheckPort:            awssdk.String("traffic-port"),
+							HealthCheckPath:            awssdk.String("/healthz"),
+							HealthCheckIntervalSeconds: awssdk.Int64(10),
+							HealthCheckTimeoutSeconds:  awssdk.Int64(5),
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			healthCheck: healthCheck,
+			wantErr:     errors.New("some error"),
+		},
+		{
+			name: "failed to describe targetGroup",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			healthCheck: healthCheck,
+			wantErr:     errors.New("some error"),
+		},
+		{
+			name: "targetGroup not found",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						resp: nil,
+					},
+				},
+			},
+			healthCheck: healthCheck,
+			wantErr:     errors.New("expecting a single targetGroup but got 0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetGroupsAsListCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.modifyTargetGroupCalls {
+				elbv2Client.EXPECT().ModifyTargetGroupWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := NewDefaultHealthCheckManager(elbv2Client, &log.NullLogger{})
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "tgb-1",
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					HealthCheck:    tt.healthCheck,
+				},
+			}
+			err := m.Reconcile(context.Background(), tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHealthyThresholdCacheTTL = 1 * time.Minute
+)
+
+// HealthyThresholdResolver resolves the duration a target must stay healthy to be considered ready per TargetGroup's healthCheck settings.
+type HealthyThresholdResolver interface {
+	// ResolveHealthyThresholdDuration returns the healthyThresholdCount * healthCheckIntervalSeconds of TargetGroup.
+	ResolveHealthyThresholdDuration(ctx context.Context, tgARN string) (time.Duration, error)
+}
+
+// NewCachedHealthyThresholdResolver constructs new cachedHealthyThresholdResolver.
+func NewCachedHealthyThresholdResolver(elbv2Client services.ELBV2) *cachedHealthyThresholdResolver {
+	return &cachedHealthyThresholdResolver{
+		elbv2Client:       elbv2Client,
+		thresholdCache:    cache.NewExpiring(),
+		thresholdCacheTTL: defaultHealthyThresholdCacheTTL,
+	}
+}
+
+var _ HealthyThresholdResolver = &cachedHealthyThresholdResolver{}
+
+// cachedHealthyThresholdResolver is an cached implementation for HealthyThresholdResolver.
+// healthyThreshold for each TargetGroup will be refreshed per thresholdCacheTTL.
+type cachedHealthyThresholdResolver struct {
+	elbv2Client services.ELBV2
+
+	// cache of healthyThreshold duration by targetGroupARN.
+	thresholdCache *cache.Expiring
+	// TTL for each targetGroup's healthyThreshold duration.
+	thresholdCacheTTL time.Duration
+	// thresholdCacheMutex protects thresholdCache
+	thresholdCacheMutex sync.RWMutex
+}
+
+func (r *cachedHealthyThresholdResolver) ResolveHealthyThresholdDuration(ctx context.Context, tgARN string) (time.Duration, error) {
+	r.thresholdCacheMutex.Lock()
+	defer r.thresholdCacheMutex.Unlock()
+
+	if rawCacheItem, exists := r.thresholdCache.Get(tgARN); exists {
+		return rawCacheItem.(time.Duration), nil
+	}
+	tgList, err := r.elbv2Client.DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(tgList) != 1 {
+		return 0, errors.Errorf("expect exactly one targetGroup: %v", tgARN)
+	}
+	sdkTG := tgList[0]
+	threshold := time.Duration(awssdk.Int64Value(sdkTG.HealthyThresholdCount)*awssdk.Int64Value(sdkTG.HealthCheckIntervalSeconds)) * time.Second
+	r.thresholdCache.Set(tgARN, threshold, r.thresholdCacheTTL)
+	return threshold, nil
+}
@@ -0,0 +1,119 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/cache"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"testing"
+	"time"
+)
+
+func Test_cachedHealthyThresholdResolver_ResolveHealthyThresholdDuration(t *testing.T) {
+	type describeTargetGroupsAsListCall struct {
+		req  *elbv2sdk.DescribeTargetGroupsInput
+		resp []*elbv2sdk.TargetGroup
+		err  error
+	}
+	type fields struct {
+		describeTargetGroupsAsListCalls []describeTargetGroupsAsListCall
+	}
+	type args struct {
+		tgARN string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    time.Duration
+		wantErr error
+	}{
+		{
+			name: "targetGroup with healthCheck settings",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-arn"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn:             awssdk.String("tg-arn"),
+								HealthyThresholdCount:      awssdk.Int64(3),
+								HealthCheckIntervalSeconds: awssdk.Int64(10),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-arn",
+			},
+			want: 30 * time.Second,
+		},
+		{
+			name: "describeTargetGroups returns no targetGroup",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-arn"}),
+						},
+						resp: nil,
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-arn",
+			},
+			wantErr: errors.New("expect exactly one targetGroup: tg-arn"),
+		},
+		{
+			name: "describeTargetGroups fails",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-arn"}),
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			args: args{
+				tgARN: "tg-arn",
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetGroupsAsListCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			r := &cachedHealthyThresholdResolver{
+				elbv2Client:       elbv2Client,
+				thresholdCache:    cache.NewExpiring(),
+				thresholdCacheTTL: time.Minute,
+			}
+			got, err := r.ResolveHealthyThresholdDuration(context.Background(), tt.args.tgARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				// second call should be served from cache.
+				got, err = r.ResolveHealthyThresholdDuration(context.Background(), tt.args.tgARN)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
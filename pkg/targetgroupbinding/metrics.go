@@ -0,0 +1,56 @@
+package targetgroupbinding
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricSubsystemTargetGroupBinding = "targetgroupbinding"
+
+	metricTargetHealthCacheLookupsTotal = "target_health_cache_lookups_total"
+)
+
+const (
+	labelResult = "result"
+
+	resultCacheHit  = "hit"
+	resultCacheMiss = "miss"
+)
+
+type targetsManagerInstruments struct {
+	targetHealthCacheLookupsTotal *prometheus.CounterVec
+}
+
+// newTargetsManagerInstruments allocates and register new metrics to registerer
+// if the metrics are already registered, the existing ones will be reused.
+func newTargetsManagerInstruments(registerer prometheus.Registerer) (*targetsManagerInstruments, error) {
+	targetHealthCacheLookupsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemTargetGroupBinding,
+		Name:      metricTargetHealthCacheLookupsTotal,
+		Help:      "Total number of target health lookups for TargetGroups, partitioned by whether it's served from cache",
+	}, []string{labelResult})
+
+	if err := registerer.Register(targetHealthCacheLookupsTotal); err != nil {
+		var alreadyRegisteredErr prometheus.AlreadyRegisteredError
+		if !errors.As(err, &alreadyRegisteredErr) {
+			return nil, err
+		}
+		targetHealthCacheLookupsTotal = alreadyRegisteredErr.ExistingCollector.(*prometheus.CounterVec)
+	}
+	return &targetsManagerInstruments{
+		targetHealthCacheLookupsTotal: targetHealthCacheLookupsTotal,
+	}, nil
+}
+
+// observeTargetHealthCacheLookup records a target health lookup.
+func (i *targetsManagerInstruments) observeTargetHealthCacheLookup(cacheHit bool) {
+	if i == nil {
+		return
+	}
+	result := resultCacheMiss
+	if cacheHit {
+		result = resultCacheHit
+	}
+	i.targetHealthCacheLookupsTotal.WithLabelValues(result).Inc()
+}
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"net"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
@@ -26,6 +27,9 @@ import (
 const (
 	tgbNetworkingIPPermissionLabelKey   = "elbv2.k8s.aws/targetGroupBinding"
 	tgbNetworkingIPPermissionLabelValue = "shared"
+
+	// announcementTopicPrefixUnmanagedSG is the topic prefix for the warnings on unmanaged securityGroups, followed by securityGroup ID.
+	announcementTopicPrefixUnmanagedSG = "unmanaged-security-group/"
 )
 
 // NetworkingManager manages the networking for targetGroupBindings.
@@ -41,11 +45,12 @@ type NetworkingManager interface {
 }
 
 // NewDefaultNetworkingManager constructs defaultNetworkingManager.
-func NewDefaultNetworkingManager(k8sClient client.Client, podENIResolver networking.PodENIInfoResolver, nodeENIResolver networking.NodeENIInfoResolver,
+func NewDefaultNetworkingManager(k8sClient client.Client, eventRecorder record.EventRecorder, podENIResolver networking.PodENIInfoResolver, nodeENIResolver networking.NodeENIInfoResolver,
 	sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler, vpcID string, clusterName string, logger logr.Logger) *defaultNetworkingManager {
 
 	return &defaultNetworkingManager{
 		k8sClient:       k8sClient,
+		eventRecorder:   eventRecorder,
 		podENIResolver:  podENIResolver,
 		nodeENIResolver: nodeENIResolver,
 		sgManager:       sgManager,
@@ -58,12 +63,14 @@ func NewDefaultNetworkingManager(k8sClient client.Client, podENIResolver network
 		ingressPermissionsPerSGByTGB:  make(map[types.NamespacedName]map[string][]networking.IPPermissionInfo),
 		trackedEndpointSGs:            sets.NewString(),
 		trackedEndpointSGsInitialized: false,
+		announcementTracker:           k8s.NewAnnouncementTracker(),
 	}
 }
 
 // default implementation for NetworkingManager.
 type defaultNetworkingManager struct {
 	k8sClient       client.Client
+	eventRecorder   record.EventRecorder
 	podENIResolver  networking.PodENIInfoResolver
 	nodeENIResolver networking.NodeENIInfoResolver
 	sgManager       networking.SecurityGroupManager
@@ -84,6 +91,8 @@ type defaultNetworkingManager struct {
 	// we discovery endpointSGs from VPC using clusterTags once, so we can still GC rules if some SGs are no longer referenced.
 	// a SG/nodeGroup might be removed from cluster while this controller is not running.
 	trackedEndpointSGsInitialized bool
+	// announcementTracker tracks the warnings on unmanaged securityGroups per TargetGroupBinding, so they are only emitted on change.
+	announcementTracker *k8s.AnnouncementTracker
 }
 
 func (m *defaultNetworkingManager) ReconcileForPodEndpoints(ctx context.Context, tgb *elbv2api.TargetGroupBinding, endpoints []backend.PodEndpoint) error {
@@ -191,9 +200,18 @@ func (m *defaultNetworkingManager) reconcileWithIngressPermissionsPerSG(ctx cont
 	}
 	computedForAllTGBs := m.consolidateIngressPermissionsPerSGByTGB(ctx, tgbsWithNetworking)
 	aggregatedIngressPermissionsPerSG := m.computeAggregatedIngressPermissionsPerSG(ctx)
+	unmanagedSGs := computeUnmanagedSGs(tgb, tgbsWithNetworking, m.ingressPermissionsPerSGByTGB)
+	if err := m.warnBlockedIngressPermissionsOnUnmanagedSGs(ctx, tgb, ingressPermissionsPerSG, unmanagedSGs); err != nil {
+		return err
+	}
 
 	permissionSelector := labels.SelectorFromSet(labels.Set{tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue})
 	for sgID, permissions := range aggregatedIngressPermissionsPerSG {
+		if unmanagedSGs.Has(sgID) {
+			m.logger.V(1).Info("skipping ingress rules reconcile on unmanaged securityGroup",
+				"securityGroup", sgID)
+			continue
+		}
 		if err := m.sgReconciler.ReconcileIngress(ctx, sgID, permissions,
 			networking.WithPermissionSelector(permissionSelector),
 			networking.WithAuthorizeOnly(!computedForAllTGBs)); err != nil {
@@ -202,7 +220,7 @@ func (m *defaultNetworkingManager) reconcileWithIngressPermissionsPerSG(ctx cont
 	}
 
 	if computedForAllTGBs {
-		if err := m.gcIngressPermissionsFromUnusedEndpointSGs(ctx, aggregatedIngressPermissionsPerSG); err != nil {
+		if err := m.gcIngressPermissionsFromUnusedEndpointSGs(ctx, aggregatedIngressPermissionsPerSG, unmanagedSGs); err != nil {
 			return err
 		}
 	}
@@ -285,6 +303,7 @@ type sdkFromToPortPair struct {
 
 // computePermissionsForPeerPort computes the needed Inbound IPPermissions for specified peer and port.
 // an optional list of pods if provided if pod endpoints are used, and named ports will be resolved to the pod port.
+// for ICMP and ICMPv6 protocols, the ICMP type and code are used as the fromPort and toPort respectively.
 func (m *defaultNetworkingManager) computePermissionsForPeerPort(ctx context.Context, peer elbv2api.NetworkingPeer, port elbv2api.NetworkingPort, pods []k8s.PodInfo) ([]networking.IPPermissionInfo, error) {
 	sdkProtocol := "tcp"
 	if port.Protocol != nil {
@@ -293,11 +312,28 @@ func (m *defaultNetworkingManager) computePermissionsForPeerPort(ctx context.Con
 			sdkProtocol = "tcp"
 		case elbv2api.NetworkingProtocolUDP:
 			sdkProtocol = "udp"
+		case elbv2api.NetworkingProtocolICMP:
+			sdkProtocol = "icmp"
+		case elbv2api.NetworkingProtocolICMPv6:
+			sdkProtocol = "icmpv6"
 		}
 	}
 
 	var sdkFromToPortPairs []sdkFromToPortPair
-	if port.Port != nil {
+	if sdkProtocol == "icmp" || sdkProtocol == "icmpv6" {
+		// -1 indicates all ICMP types or codes.
+		icmpPair := sdkFromToPortPair{
+			fromPort: -1,
+			toPort:   -1,
+		}
+		if port.ICMP != nil {
+			icmpPair = sdkFromToPortPair{
+				fromPort: port.ICMP.Type,
+				toPort:   port.ICMP.Code,
+			}
+		}
+		sdkFromToPortPairs = append(sdkFromToPortPairs, icmpPair)
+	} else if port.Port != nil {
 		numericalPorts, err := m.computeNumericalPorts(ctx, *port.Port, pods)
 		if err != nil {
 			return nil, err
@@ -345,7 +381,17 @@ func (m *defaultNetworkingManager) computePermissionsForPeerPort(ctx context.Con
 		return permissions, nil
 	}
 
-	return nil, errors.New("either ipBlock or securityGroup should be specified")
+	if peer.PrefixList != nil {
+		prefixListID := peer.PrefixList.PrefixListID
+		permissions := make([]networking.IPPermissionInfo, 0, len(sdkFromToPortPairs))
+		for _, portPair := range sdkFromToPortPairs {
+			permission := networking.NewPrefixListIDPermission(sdkProtocol, awssdk.Int64(portPair.fromPort), awssdk.Int64(portPair.toPort), prefixListID, permissionLabels)
+			permissions = append(permissions, permission)
+		}
+		return permissions, nil
+	}
+
+	return nil, errors.New("either ipBlock, securityGroup or prefixList should be specified")
 }
 
 // computeNumericalPorts computes the numerical ports if a named is used.
@@ -370,13 +416,14 @@ func (m *defaultNetworkingManager) computeNumericalPorts(_ context.Context, port
 }
 
 // gcIngressPermissionsFromUnusedEndpointSGs will garbage collect ingress permissions from endpoint SecurityGroups that are no longer used.
-func (m *defaultNetworkingManager) gcIngressPermissionsFromUnusedEndpointSGs(ctx context.Context, ingressPermissionsPerSG map[string][]networking.IPPermissionInfo) error {
+// unmanagedSGs will be left untouched.
+func (m *defaultNetworkingManager) gcIngressPermissionsFromUnusedEndpointSGs(ctx context.Context, ingressPermissionsPerSG map[string][]networking.IPPermissionInfo, unmanagedSGs sets.String) error {
 	endpointSGs, err := m.fetchEndpointSGs(ctx)
 	if err != nil {
 		return err
 	}
 	usedEndpointSGs := sets.StringKeySet(ingressPermissionsPerSG)
-	unusedEndpointSGs := endpointSGs.Difference(usedEndpointSGs)
+	unusedEndpointSGs := endpointSGs.Difference(usedEndpointSGs).Difference(unmanagedSGs)
 
 	permissionSelector := labels.SelectorFromSet(labels.Set{tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue})
 	for sgID := range unusedEndpointSGs {
@@ -393,6 +440,93 @@ func (m *defaultNetworkingManager) gcIngressPermissionsFromUnusedEndpointSGs(ctx
 	return nil
 }
 
+// warnBlockedIngressPermissionsOnUnmanagedSGs emits a warning event for TargetGroupBinding if ingress permissions it needs are
+// absent from unmanaged securityGroups, given traffic to its targets would be blocked.
+// the warning is only emitted when it changes since last reconcile of TargetGroupBinding.
+func (m *defaultNetworkingManager) warnBlockedIngressPermissionsOnUnmanagedSGs(ctx context.Context, tgb *elbv2api.TargetGroupBinding,
+	ingressPermissionsPerSG map[string][]networking.IPPermissionInfo, unmanagedSGs sets.String) error {
+	tgbKey := k8s.NamespacedName(tgb).String()
+	warnedTopics := sets.NewString()
+	var sgIDsToCheck []string
+	for sgID, permissions := range ingressPermissionsPerSG {
+		if unmanagedSGs.Has(sgID) && len(permissions) != 0 {
+			sgIDsToCheck = append(sgIDsToCheck, sgID)
+		}
+	}
+	if len(sgIDsToCheck) == 0 {
+		m.announcementTracker.Retain(tgbKey, warnedTopics)
+		return nil
+	}
+	sgInfoByID, err := m.sgManager.FetchSGInfosByID(ctx, sgIDsToCheck)
+	if err != nil {
+		return err
+	}
+	for _, sgID := range sets.NewString(sgIDsToCheck...).List() {
+		existingPermissions := sets.NewString()
+		for _, permission := range sgInfoByID[sgID].Ingress {
+			existingPermissions.Insert(permission.HashCode())
+		}
+		for _, permission := range ingressPermissionsPerSG[sgID] {
+			if !existingPermissions.Has(permission.HashCode()) {
+				topic := announcementTopicPrefixUnmanagedSG + sgID
+				message := fmt.Sprintf("Traffic to targets might be blocked, unmanaged securityGroup %v lacks ingress permission: %v", sgID, permission.HashCode())
+				warnedTopics.Insert(topic)
+				if m.announcementTracker.Observe(tgbKey, topic, message) {
+					m.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonUnmanagedSecurityGroup, message)
+				}
+				break
+			}
+		}
+	}
+	m.announcementTracker.Retain(tgbKey, warnedTopics)
+	return nil
+}
+
+// computeUnmanagedSGs returns the securityGroups specified via unmanaged-security-groups annotation of TargetGroupBindings,
+// the controller will not modify ingress rules on these securityGroups.
+// the annotation is scoped to the namespace of TargetGroupBinding, so a securityGroup is only unmanaged if no TargetGroupBinding
+// from other namespaces that don't specify it needs ingress permissions on it.
+func computeUnmanagedSGs(tgb *elbv2api.TargetGroupBinding, tgbsWithNetworking map[types.NamespacedName]*elbv2api.TargetGroupBinding,
+	ingressPermissionsPerSGByTGB map[types.NamespacedName]map[string][]networking.IPPermissionInfo) sets.String {
+	unmanagedSGsByNamespace := map[string]sets.String{
+		tgb.Namespace: sets.NewString(parseUnmanagedSGs(tgb)...),
+	}
+	for _, tgbWithNetworking := range tgbsWithNetworking {
+		if _, exists := unmanagedSGsByNamespace[tgbWithNetworking.Namespace]; !exists {
+			unmanagedSGsByNamespace[tgbWithNetworking.Namespace] = sets.NewString()
+		}
+		unmanagedSGsByNamespace[tgbWithNetworking.Namespace].Insert(parseUnmanagedSGs(tgbWithNetworking)...)
+	}
+
+	unmanagedSGs := sets.NewString()
+	for _, sgIDs := range unmanagedSGsByNamespace {
+		unmanagedSGs.Insert(sgIDs.UnsortedList()...)
+	}
+	for tgbKey, ingressPermissionsPerSG := range ingressPermissionsPerSGByTGB {
+		for sgID, permissions := range ingressPermissionsPerSG {
+			if len(permissions) != 0 && !unmanagedSGsByNamespace[tgbKey.Namespace].Has(sgID) {
+				unmanagedSGs.Delete(sgID)
+			}
+		}
+	}
+	return unmanagedSGs
+}
+
+// parseUnmanagedSGs parses the comma separated securityGroup IDs from unmanaged-security-groups annotation of TargetGroupBinding.
+func parseUnmanagedSGs(tgb *elbv2api.TargetGroupBinding) []string {
+	rawValue, exists := tgb.Annotations[AnnotationKeyUnmanagedSecurityGroups]
+	if !exists {
+		return nil
+	}
+	var sgIDs []string
+	for _, sgID := range strings.Split(rawValue, ",") {
+		if sgID = strings.TrimSpace(sgID); sgID != "" {
+			sgIDs = append(sgIDs, sgID)
+		}
+	}
+	return sgIDs
+}
+
 // fetchTGBsWithNetworking returns all targetGroupsBindings with networking rules in cluster.
 func (m *defaultNetworkingManager) fetchTGBsWithNetworking(ctx context.Context) (map[types.NamespacedName]*elbv2api.TargetGroupBinding, error) {
 	tgbList := &elbv2api.TargetGroupBindingList{}
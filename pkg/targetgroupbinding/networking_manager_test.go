@@ -5,13 +5,20 @@ import (
 	"errors"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -242,6 +249,8 @@ func Test_defaultNetworkingManager_computePermissionsForPeerPort(t *testing.T) {
 	port8080 := intstr.FromInt(8080)
 	portHTTP := intstr.FromString("http")
 	protocolUDP := elbv2api.NetworkingProtocolUDP
+	protocolICMP := elbv2api.NetworkingProtocolICMP
+	protocolICMPv6 := elbv2api.NetworkingProtocolICMPv6
 	type args struct {
 		peer elbv2api.NetworkingPeer
 		port elbv2api.NetworkingPort
@@ -284,6 +293,37 @@ func Test_defaultNetworkingManager_computePermissionsForPeerPort(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "permission for prefixList peer",
+			args: args{
+				peer: elbv2api.NetworkingPeer{
+					PrefixList: &elbv2api.PrefixList{
+						PrefixListID: "pl-abcdefg",
+					},
+				},
+				port: elbv2api.NetworkingPort{
+					Protocol: &protocolUDP,
+					Port:     &port8080,
+				},
+				pods: nil,
+			},
+			want: []networking.IPPermissionInfo{
+				{
+					Permission: ec2sdk.IpPermission{
+						IpProtocol: awssdk.String("udp"),
+						FromPort:   awssdk.Int64(8080),
+						ToPort:     awssdk.Int64(8080),
+						PrefixListIds: []*ec2sdk.PrefixListId{
+							{
+								Description:  awssdk.String("elbv2.k8s.aws/targetGroupBinding=shared"),
+								PrefixListId: awssdk.String("pl-abcdefg"),
+							},
+						},
+					},
+					Labels: map[string]string{tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue},
+				},
+			},
+		},
 		{
 			name: "permission for IPBlock peer with IPv4 CIDR",
 			args: args{
@@ -471,6 +511,71 @@ func Test_defaultNetworkingManager_computePermissionsForPeerPort(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "permission for ICMP type and code",
+			args: args{
+				peer: elbv2api.NetworkingPeer{
+					IPBlock: &elbv2api.IPBlock{
+						CIDR: "192.168.0.0/16",
+					},
+				},
+				port: elbv2api.NetworkingPort{
+					Protocol: &protocolICMP,
+					Port:     &port8080,
+					ICMP: &elbv2api.NetworkingICMP{
+						Type: 3,
+						Code: 4,
+					},
+				},
+				pods: nil,
+			},
+			want: []networking.IPPermissionInfo{
+				{
+					Permission: ec2sdk.IpPermission{
+						IpProtocol: awssdk.String("icmp"),
+						FromPort:   awssdk.Int64(3),
+						ToPort:     awssdk.Int64(4),
+						IpRanges: []*ec2sdk.IpRange{
+							{
+								CidrIp:      awssdk.String("192.168.0.0/16"),
+								Description: awssdk.String("elbv2.k8s.aws/targetGroupBinding=shared"),
+							},
+						},
+					},
+					Labels: map[string]string{tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue},
+				},
+			},
+		},
+		{
+			name: "permission when ICMPv6 type and code defaults to all",
+			args: args{
+				peer: elbv2api.NetworkingPeer{
+					SecurityGroup: &elbv2api.SecurityGroup{
+						GroupID: "sg-abcdefg",
+					},
+				},
+				port: elbv2api.NetworkingPort{
+					Protocol: &protocolICMPv6,
+				},
+				pods: nil,
+			},
+			want: []networking.IPPermissionInfo{
+				{
+					Permission: ec2sdk.IpPermission{
+						IpProtocol: awssdk.String("icmpv6"),
+						FromPort:   awssdk.Int64(-1),
+						ToPort:     awssdk.Int64(-1),
+						UserIdGroupPairs: []*ec2sdk.UserIdGroupPair{
+							{
+								Description: awssdk.String("elbv2.k8s.aws/targetGroupBinding=shared"),
+								GroupId:     awssdk.String("sg-abcdefg"),
+							},
+						},
+					},
+					Labels: map[string]string{tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -859,3 +964,232 @@ func Test_defaultNetworkingManager_computeAggregatedIngressPermissionsPerSG(t *t
 		})
 	}
 }
+
+func Test_defaultNetworkingManager_reconcileWithIngressPermissionsPerSG(t *testing.T) {
+	permission := networking.IPPermissionInfo{
+		Permission: ec2sdk.IpPermission{
+			IpProtocol: awssdk.String("tcp"),
+			FromPort:   awssdk.Int64(80),
+			ToPort:     awssdk.Int64(8080),
+			IpRanges: []*ec2sdk.IpRange{
+				{
+					CidrIp: awssdk.String("192.168.0.0/16"),
+				},
+			},
+		},
+	}
+	type fetchSGInfosByIDCall struct {
+		sgIDs []string
+		resp  map[string]networking.SecurityGroupInfo
+	}
+	type args struct {
+		tgbAnnotations                  map[string]string
+		otherTGBNamespace               string
+		otherTGBAnnotations             map[string]string
+		otherTGBIngressPermissionsPerSG map[string][]networking.IPPermissionInfo
+		ingressPermissionsPerSG         map[string][]networking.IPPermissionInfo
+		// number of reconciles, defaults to 1.
+		reconcileTimes int
+	}
+	tests := []struct {
+		name                    string
+		fetchSGInfosByIDCalls   []fetchSGInfosByIDCall
+		args                    args
+		wantReconciledSGs       []string
+		wantGarbageCollectedSGs []string
+		wantEvents              []string
+	}{
+		{
+			name: "reconcile all securityGroups when there is no unmanaged securityGroups",
+			args: args{
+				ingressPermissionsPerSG: map[string][]networking.IPPermissionInfo{
+					"sg-a": {permission},
+					"sg-b": {permission},
+				},
+			},
+			wantReconciledSGs:       []string{"sg-a", "sg-b"},
+			wantGarbageCollectedSGs: []string{"sg-c", "sg-d"},
+		},
+		{
+			name: "skip unmanaged securityGroup that already permits traffic",
+			fetchSGInfosByIDCalls: []fetchSGInfosByIDCall{
+				{
+					sgIDs: []string{"sg-b"},
+					resp: map[string]networking.SecurityGroupInfo{
+						"sg-b": {
+							SecurityGroupID: "sg-b",
+							Ingress:         []networking.IPPermissionInfo{permission},
+						},
+					},
+				},
+			},
+			args: args{
+				tgbAnnotations: map[string]string{
+					AnnotationKeyUnmanagedSecurityGroups: "sg-b",
+				},
+				ingressPermissionsPerSG: map[string][]networking.IPPermissionInfo{
+					"sg-a": {permission},
+					"sg-b": {permission},
+				},
+			},
+			wantReconciledSGs:       []string{"sg-a"},
+			wantGarbageCollectedSGs: []string{"sg-c", "sg-d"},
+		},
+		{
+			name: "skip unmanaged securityGroup that blocks traffic and warn",
+			fetchSGInfosByIDCalls: []fetchSGInfosByIDCall{
+				{
+					sgIDs: []string{"sg-b"},
+					resp: map[string]networking.SecurityGroupInfo{
+						"sg-b": {
+							SecurityGroupID: "sg-b",
+						},
+					},
+				},
+			},
+			args: args{
+				otherTGBAnnotations: map[string]string{
+					AnnotationKeyUnmanagedSecurityGroups: "sg-b, sg-c",
+				},
+				ingressPermissionsPerSG: map[string][]networking.IPPermissionInfo{
+					"sg-a": {permission},
+					"sg-b": {permission},
+				},
+			},
+			wantReconciledSGs:       []string{"sg-a"},
+			wantGarbageCollectedSGs: []string{"sg-d"},
+			wantEvents: []string{
+				"Warning UnmanagedSecurityGroup Traffic to targets might be blocked, unmanaged securityGroup sg-b lacks ingress permission: " +
+					"IpProtocol: tcp, FromPort: 80, ToPort: 8080, IpRange: 192.168.0.0/16",
+			},
+		},
+		{
+			name: "warn unmanaged securityGroup that blocks traffic only once across reconciles",
+			fetchSGInfosByIDCalls: []fetchSGInfosByIDCall{
+				{
+					sgIDs: []string{"sg-b"},
+					resp: map[string]networking.SecurityGroupInfo{
+						"sg-b": {
+							SecurityGroupID: "sg-b",
+						},
+					},
+				},
+			},
+			args: args{
+				tgbAnnotations: map[string]string{
+					AnnotationKeyUnmanagedSecurityGroups: "sg-b",
+				},
+				ingressPermissionsPerSG: map[string][]networking.IPPermissionInfo{
+					"sg-a": {permission},
+					"sg-b": {permission},
+				},
+				reconcileTimes: 3,
+			},
+			wantReconciledSGs:       []string{"sg-a"},
+			wantGarbageCollectedSGs: []string{"sg-c", "sg-d"},
+			wantEvents: []string{
+				"Warning UnmanagedSecurityGroup Traffic to targets might be blocked, unmanaged securityGroup sg-b lacks ingress permission: " +
+					"IpProtocol: tcp, FromPort: 80, ToPort: 8080, IpRange: 192.168.0.0/16",
+			},
+		},
+		{
+			name: "unmanaged securityGroup from other namespace doesn't apply",
+			args: args{
+				otherTGBNamespace: "ns-2",
+				otherTGBAnnotations: map[string]string{
+					AnnotationKeyUnmanagedSecurityGroups: "sg-b",
+				},
+				ingressPermissionsPerSG: map[string][]networking.IPPermissionInfo{
+					"sg-a": {permission},
+					"sg-b": {permission},
+				},
+			},
+			wantReconciledSGs:       []string{"sg-a", "sg-b"},
+			wantGarbageCollectedSGs: []string{"sg-c", "sg-d"},
+		},
+		{
+			name: "unmanaged securityGroup needed by other namespace is still managed",
+			args: args{
+				tgbAnnotations: map[string]string{
+					AnnotationKeyUnmanagedSecurityGroups: "sg-b",
+				},
+				otherTGBNamespace: "ns-2",
+				otherTGBIngressPermissionsPerSG: map[string][]networking.IPPermissionInfo{
+					"sg-b": {permission},
+				},
+				ingressPermissionsPerSG: map[string][]networking.IPPermissionInfo{
+					"sg-a": {permission},
+					"sg-b": {permission},
+				},
+			},
+			wantReconciledSGs:       []string{"sg-a", "sg-b"},
+			wantGarbageCollectedSGs: []string{"sg-c", "sg-d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reconcileTimes := tt.args.reconcileTimes
+			if reconcileTimes == 0 {
+				reconcileTimes = 1
+			}
+			sgManager := mock_networking.NewMockSecurityGroupManager(ctrl)
+			for _, call := range tt.fetchSGInfosByIDCalls {
+				sgManager.EXPECT().FetchSGInfosByID(gomock.Any(), call.sgIDs).Return(call.resp, nil).Times(reconcileTimes)
+			}
+			sgReconciler := mock_networking.NewMockSecurityGroupReconciler(ctrl)
+			for _, sgID := range tt.wantReconciledSGs {
+				sgReconciler.EXPECT().ReconcileIngress(gomock.Any(), sgID, tt.args.ingressPermissionsPerSG[sgID], gomock.Any(), gomock.Any()).Return(nil).Times(reconcileTimes)
+			}
+			for _, sgID := range tt.wantGarbageCollectedSGs {
+				sgReconciler.EXPECT().ReconcileIngress(gomock.Any(), sgID, nil, gomock.Any()).Return(nil).Times(reconcileTimes)
+			}
+			otherTGBNamespace := tt.args.otherTGBNamespace
+			if otherTGBNamespace == "" {
+				otherTGBNamespace = "ns-1"
+			}
+
+			k8sSchema := runtime.NewScheme()
+			elbv2api.AddToScheme(k8sSchema)
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns-1",
+					Name:        "tgb-1",
+					Annotations: tt.args.tgbAnnotations,
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					Networking: &elbv2api.TargetGroupBindingNetworking{},
+				},
+			}
+			otherTGB := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   otherTGBNamespace,
+					Name:        "tgb-2",
+					Annotations: tt.args.otherTGBAnnotations,
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					Networking: &elbv2api.TargetGroupBindingNetworking{},
+				},
+			}
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema, tgb, otherTGB)
+			eventRecorder := record.NewFakeRecorder(10)
+			m := NewDefaultNetworkingManager(k8sClient, eventRecorder, nil, nil, sgManager, sgReconciler, "vpc-1", "cluster-1", &log.NullLogger{})
+			m.ingressPermissionsPerSGByTGB[k8s.NamespacedName(otherTGB)] = tt.args.otherTGBIngressPermissionsPerSG
+			m.trackedEndpointSGs.Insert("sg-c", "sg-d")
+			m.trackedEndpointSGsInitialized = true
+
+			for i := 0; i < reconcileTimes; i++ {
+				err := m.reconcileWithIngressPermissionsPerSG(context.Background(), tgb, tt.args.ingressPermissionsPerSG)
+				assert.NoError(t, err)
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"reflect"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"strings"
+)
+
+const (
+	// the ARN service of ELBV2 resources.
+	elbv2ARNService = "elasticloadbalancing"
+	// the ARN resource prefix of ELBV2 TargetGroups.
+	targetGroupARNResourcePrefix = "targetgroup/"
+)
+
+// PodResourceManager manages the PodTargetGroupBinding resource.
+type PodResourceManager interface {
+	// Reconcile registers pods selected by ptgb as targets into TargetGroup, and returns the number of registered pods.
+	// the registered targets are tracked in ptgb's status, and only tracked targets are deregistered.
+	// ptgb is expected to be validated by ValidatePodTargetGroupBinding already.
+	Reconcile(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding) (int32, error)
+	// Cleanup deregisters the targets registered by ptgb from TargetGroup.
+	Cleanup(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding) error
+}
+
+// NewDefaultPodResourceManager constructs new defaultPodResourceManager.
+func NewDefaultPodResourceManager(k8sClient client.Client, targetsManager TargetsManager, logger logr.Logger) *defaultPodResourceManager {
+	return &defaultPodResourceManager{
+		k8sClient:      k8sClient,
+		targetsManager: targetsManager,
+		logger:         logger,
+	}
+}
+
+var _ PodResourceManager = &defaultPodResourceManager{}
+
+// default implementation for PodResourceManager.
+// pods are always registered with their IP, so the TargetGroup must be of ip TargetType.
+type defaultPodResourceManager struct {
+	k8sClient      client.Client
+	targetsManager TargetsManager
+	logger         logr.Logger
+}
+
+func (m *defaultPodResourceManager) Reconcile(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding) (int32, error) {
+	pods, err := m.listSelectedPods(ctx, ptgb)
+	if err != nil {
+		return 0, err
+	}
+	tgARN := ptgb.Spec.TargetGroupARN
+	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
+	if err != nil {
+		return 0, err
+	}
+	desiredTargets := buildPodTargets(pods, ptgb.Spec.Port)
+	desiredTargetIDs := sets.NewString()
+	for _, target := range desiredTargets {
+		desiredTargetIDs.Insert(UniqueIDForTargetDescription(target))
+	}
+	registeredTargetIDs := sets.NewString()
+	for _, target := range buildRegisteredTargets(ptgb) {
+		registeredTargetIDs.Insert(UniqueIDForTargetDescription(target))
+	}
+	existingTargetIDs := sets.NewString()
+	var unmatchedTargets []elbv2sdk.TargetDescription
+	for _, target := range targets {
+		targetID := UniqueIDForTargetDescription(target.Target)
+		existingTargetIDs.Insert(targetID)
+		// targets not registered by ptgb are left untouched.
+		// draining targets are already being deregistered, deregister them again is redundant.
+		if registeredTargetIDs.Has(targetID) && !desiredTargetIDs.Has(targetID) && !target.IsDraining() {
+			unmatchedTargets = append(unmatchedTargets, target.Target)
+		}
+	}
+	var unmatchedPodTargets []elbv2sdk.TargetDescription
+	// desired targets that are registered by ptgb before, or registered by ptgb in this reconcile.
+	var ownedPodTargets []elbv2sdk.TargetDescription
+	for _, target := range desiredTargets {
+		targetID := UniqueIDForTargetDescription(target)
+		if !existingTargetIDs.Has(targetID) {
+			unmatchedPodTargets = append(unmatchedPodTargets, target)
+			ownedPodTargets = append(ownedPodTargets, target)
+		} else if registeredTargetIDs.Has(targetID) {
+			ownedPodTargets = append(ownedPodTargets, target)
+		}
+	}
+	if len(unmatchedPodTargets) != 0 {
+		// track targets before registering them, so that they won't be leaked if we fail in the middle.
+		if err := m.updateRegisteredTargets(ctx, ptgb, append(buildRegisteredTargets(ptgb), unmatchedPodTargets...)); err != nil {
+			return 0, err
+		}
+	}
+	if len(unmatchedTargets) != 0 {
+		if err := m.targetsManager.DeregisterTargets(ctx, tgARN, unmatchedTargets); err != nil {
+			return 0, err
+		}
+	}
+	if len(unmatchedPodTargets) != 0 {
+		if err := m.targetsManager.RegisterTargets(ctx, tgARN, unmatchedPodTargets); err != nil {
+			return 0, err
+		}
+	}
+	if err := m.updateRegisteredTargets(ctx, ptgb, ownedPodTargets); err != nil {
+		return 0, err
+	}
+	return int32(len(desiredTargets)), nil
+}
+
+func (m *defaultPodResourceManager) Cleanup(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding) error {
+	registeredTargets := buildRegisteredTargets(ptgb)
+	if len(registeredTargets) == 0 {
+		return nil
+	}
+	targets, err := m.targetsManager.ListTargets(ctx, ptgb.Spec.TargetGroupARN)
+	if err != nil {
+		if isELBV2TargetGroupNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	registeredTargetIDs := sets.NewString()
+	for _, target := range registeredTargets {
+		registeredTargetIDs.Insert(UniqueIDForTargetDescription(target))
+	}
+	notDrainingTargets, _ := partitionTargetsByDrainingStatus(targets)
+	var sdkTargets []elbv2sdk.TargetDescription
+	for _, target := range notDrainingTargets {
+		if registeredTargetIDs.Has(UniqueIDForTargetDescription(target.Target)) {
+			sdkTargets = append(sdkTargets, target.Target)
+		}
+	}
+	if len(sdkTargets) == 0 {
+		return nil
+	}
+	if err := m.targetsManager.DeregisterTargets(ctx, ptgb.Spec.TargetGroupARN, sdkTargets); err != nil {
+		if isELBV2TargetGroupNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// updateRegisteredTargets updates the registered targets tracked in ptgb's status.
+func (m *defaultPodResourceManager) updateRegisteredTargets(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding, targets []elbv2sdk.TargetDescription) error {
+	registeredTargets := make([]elbv2api.PodTarget, 0, len(targets))
+	registeredTargetIDs := sets.NewString()
+	for _, target := range targets {
+		targetID := UniqueIDForTargetDescription(target)
+		if registeredTargetIDs.Has(targetID) {
+			continue
+		}
+		registeredTargetIDs.Insert(targetID)
+		registeredTargets = append(registeredTargets, elbv2api.PodTarget{
+			IP:   awssdk.StringValue(target.Id),
+			Port: awssdk.Int64Value(target.Port),
+		})
+	}
+	sort.Slice(registeredTargets, func(i, j int) bool {
+		if registeredTargets[i].IP != registeredTargets[j].IP {
+			return registeredTargets[i].IP < registeredTargets[j].IP
+		}
+		return registeredTargets[i].Port < registeredTargets[j].Port
+	})
+	if len(registeredTargets) == 0 && len(ptgb.Status.RegisteredTargets) == 0 {
+		return nil
+	}
+	if reflect.DeepEqual(registeredTargets, ptgb.Status.RegisteredTargets) {
+		return nil
+	}
+	ptgbOld := ptgb.DeepCopy()
+	ptgb.Status.RegisteredTargets = registeredTargets
+	if err := m.k8sClient.Status().Patch(ctx, ptgb, client.MergeFrom(ptgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update registered targets: %v", k8s.NamespacedName(ptgb))
+	}
+	return nil
+}
+
+// buildRegisteredTargets builds the targets registered by ptgb from its status.
+func buildRegisteredTargets(ptgb *elbv2api.PodTargetGroupBinding) []elbv2sdk.TargetDescription {
+	targets := make([]elbv2sdk.TargetDescription, 0, len(ptgb.Status.RegisteredTargets))
+	for _, target := range ptgb.Status.RegisteredTargets {
+		targets = append(targets, elbv2sdk.TargetDescription{
+			Id:   awssdk.String(target.IP),
+			Port: awssdk.Int64(target.Port),
+		})
+	}
+	return targets
+}
+
+// listSelectedPods lists the pods selected by ptgb's podSelector within ptgb's namespace.
+func (m *defaultPodResourceManager) listSelectedPods(ctx context.Context, ptgb *elbv2api.PodTargetGroupBinding) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&ptgb.Spec.PodSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid podSelector")
+	}
+	podList := &corev1.PodList{}
+	if err := m.k8sClient.List(ctx, podList, client.InNamespace(ptgb.Namespace),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, errors.Wrap(err, "failed to list pods")
+	}
+	return podList.Items, nil
+}
+
+// buildPodTargets builds targets for pods that are ready to receive traffic.
+// pods that are terminating, not containersReady or without IP yet are excluded.
+func buildPodTargets(pods []corev1.Pod, port int64) []elbv2sdk.TargetDescription {
+	var targets []elbv2sdk.TargetDescription
+	for i := range pods {
+		pod := &pods[i]
+		if !pod.DeletionTimestamp.IsZero() || pod.Status.PodIP == "" || !k8s.IsPodContainersReady(pod) {
+			continue
+		}
+		targets = append(targets, elbv2sdk.TargetDescription{
+			Id:   awssdk.String(pod.Status.PodIP),
+			Port: awssdk.Int64(port),
+		})
+	}
+	return targets
+}
+
+// ValidatePodTargetGroupBinding validates the targetGroupARN and podSelector of PodTargetGroupBinding.
+func ValidatePodTargetGroupBinding(ptgb *elbv2api.PodTargetGroupBinding) error {
+	tgARN, err := arn.Parse(ptgb.Spec.TargetGroupARN)
+	if err != nil {
+		return errors.Wrapf(err, "invalid targetGroupARN %v", ptgb.Spec.TargetGroupARN)
+	}
+	if tgARN.Service != elbv2ARNService || !strings.HasPrefix(tgARN.Resource, targetGroupARNResourcePrefix) {
+		return errors.Errorf("invalid targetGroupARN %v: not an ELBV2 targetGroup", ptgb.Spec.TargetGroupARN)
+	}
+	if len(ptgb.Spec.PodSelector.MatchLabels) == 0 && len(ptgb.Spec.PodSelector.MatchExpressions) == 0 {
+		return errors.New("podSelector must not be empty")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(&ptgb.Spec.PodSelector); err != nil {
+		return errors.Wrap(err, "invalid podSelector")
+	}
+	if ptgb.Spec.Port < 1 || ptgb.Spec.Port > 65535 {
+		return errors.Errorf("invalid port %v: must be between 1 and 65535", ptgb.Spec.Port)
+	}
+	return nil
+}
@@ -0,0 +1,447 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+const testPodTargetGroupARN = "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067"
+
+func newTestPod(name string, labels map[string]string, podIP string, containersReady bool) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if containersReady {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      name,
+			Labels:    labels,
+		},
+		Status: corev1.PodStatus{
+			PodIP: podIP,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.ContainersReady,
+					Status: readyStatus,
+				},
+			},
+		},
+	}
+}
+
+func Test_defaultPodResourceManager_Reconcile(t *testing.T) {
+	type describeTargetHealthWithContextCall struct {
+		req  *elbv2sdk.DescribeTargetHealthInput
+		resp *elbv2sdk.DescribeTargetHealthOutput
+		err  error
+	}
+	type registerTargetsWithContextCall struct {
+		req *elbv2sdk.RegisterTargetsInput
+	}
+	type deregisterTargetsWithContextCall struct {
+		req *elbv2sdk.DeregisterTargetsInput
+	}
+	ptgb := &elbv2api.PodTargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "my-ptgb",
+		},
+		Spec: elbv2api.PodTargetGroupBindingSpec{
+			TargetGroupARN: testPodTargetGroupARN,
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "canary"},
+			},
+			Port: 8080,
+		},
+	}
+	ptgbWithRegisteredTargets := ptgb.DeepCopy()
+	ptgbWithRegisteredTargets.Status.RegisteredTargets = []elbv2api.PodTarget{
+		{IP: "192.168.1.1", Port: 8080},
+		{IP: "192.168.1.5", Port: 8080},
+		{IP: "192.168.1.6", Port: 8080},
+		{IP: "192.168.1.7", Port: 8080},
+	}
+	tests := []struct {
+		name                                 string
+		pods                                 []*corev1.Pod
+		ptgb                                 *elbv2api.PodTargetGroupBinding
+		describeTargetHealthWithContextCalls []describeTargetHealthWithContextCall
+		registerTargetsWithContextCalls      []registerTargetsWithContextCall
+		deregisterTargetsWithContextCalls    []deregisterTargetsWithContextCall
+		want                                 int32
+		wantRegisteredTargets                []elbv2api.PodTarget
+		wantErr                              error
+	}{
+		{
+			name: "register selected ready pods",
+			pods: []*corev1.Pod{
+				newTestPod("pod-1", map[string]string{"app": "canary"}, "192.168.1.1", true),
+				newTestPod("pod-2", map[string]string{"app": "canary"}, "192.168.1.2", false),
+				newTestPod("pod-3", map[string]string{"app": "canary"}, "", true),
+				newTestPod("pod-4", map[string]string{"app": "stable"}, "192.168.1.4", true),
+			},
+			ptgb: ptgb,
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{
+						TargetGroupArn: awssdk.String(testPodTargetGroupARN),
+					},
+					resp: &elbv2sdk.DescribeTargetHealthOutput{},
+				},
+			},
+			registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+				{
+					req: &elbv2sdk.RegisterTargetsInput{
+						TargetGroupArn: awssdk.String(testPodTargetGroupARN),
+						Targets: []*elbv2sdk.TargetDescription{
+							{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)},
+						},
+					},
+				},
+			},
+			want: 1,
+			wantRegisteredTargets: []elbv2api.PodTarget{
+				{IP: "192.168.1.1", Port: 8080},
+			},
+		},
+		{
+			name: "deregister targets registered for pods no longer selected",
+			pods: []*corev1.Pod{
+				newTestPod("pod-1", map[string]string{"app": "canary"}, "192.168.1.1", true),
+			},
+			ptgb: ptgbWithRegisteredTargets,
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{
+						TargetGroupArn: awssdk.String(testPodTargetGroupARN),
+					},
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.5"), Port: awssdk.Int64(8080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.6"), Port: awssdk.Int64(8080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumDraining)},
+							},
+						},
+					},
+				},
+			},
+			deregisterTargetsWithContextCalls: []deregisterTargetsWithContextCall{
+				{
+					req: &elbv2sdk.DeregisterTargetsInput{
+						TargetGroupArn: awssdk.String(testPodTargetGroupARN),
+						Targets: []*elbv2sdk.TargetDescription{
+							{Id: awssdk.String("192.168.1.5"), Port: awssdk.Int64(8080)},
+						},
+					},
+				},
+			},
+			want: 1,
+			wantRegisteredTargets: []elbv2api.PodTarget{
+				{IP: "192.168.1.1", Port: 8080},
+			},
+		},
+		{
+			name: "targets not registered by podTargetGroupBinding are left untouched",
+			pods: []*corev1.Pod{
+				newTestPod("pod-1", map[string]string{"app": "canary"}, "192.168.1.1", true),
+				newTestPod("pod-2", map[string]string{"app": "canary"}, "192.168.1.2", true),
+			},
+			ptgb: ptgb,
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{
+						TargetGroupArn: awssdk.String(testPodTargetGroupARN),
+					},
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.2"), Port: awssdk.Int64(8080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("10.0.0.1"), Port: awssdk.Int64(80)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+						},
+					},
+				},
+			},
+			registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+				{
+					req: &elbv2sdk.RegisterTargetsInput{
+						TargetGroupArn: awssdk.String(testPodTargetGroupARN),
+						Targets: []*elbv2sdk.TargetDescription{
+							{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)},
+						},
+					},
+				},
+			},
+			want: 2,
+			wantRegisteredTargets: []elbv2api.PodTarget{
+				{IP: "192.168.1.1", Port: 8080},
+			},
+		},
+		{
+			name: "failed to list targets",
+			pods: []*corev1.Pod{
+				newTestPod("pod-1", map[string]string{"app": "canary"}, "192.168.1.1", true),
+			},
+			ptgb: ptgb,
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{
+						TargetGroupArn: awssdk.String(testPodTargetGroupARN),
+					},
+					err: errors.New("some aws api error"),
+				},
+			},
+			wantErr: errors.New("some aws api error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeTargetHealthWithContextCalls {
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.registerTargetsWithContextCalls {
+				elbv2Client.EXPECT().RegisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.RegisterTargetsOutput{}, nil)
+			}
+			for _, call := range tt.deregisterTargetsWithContextCalls {
+				elbv2Client.EXPECT().DeregisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeregisterTargetsOutput{}, nil)
+			}
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, pod := range tt.pods {
+				err := k8sClient.Create(ctx, pod.DeepCopy())
+				assert.NoError(t, err)
+			}
+			ptgb := tt.ptgb.DeepCopy()
+			err := k8sClient.Create(ctx, ptgb)
+			assert.NoError(t, err)
+
+			targetsManager := NewCachedTargetsManager(elbv2Client, 1, nil, &log.NullLogger{})
+			m := NewDefaultPodResourceManager(k8sClient, targetsManager, &log.NullLogger{})
+			got, err := m.Reconcile(ctx, ptgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				gotPTGB := &elbv2api.PodTargetGroupBinding{}
+				err := k8sClient.Get(ctx, k8s.NamespacedName(ptgb), gotPTGB)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRegisteredTargets, gotPTGB.Status.RegisteredTargets)
+			}
+		})
+	}
+}
+
+func Test_defaultPodResourceManager_Cleanup(t *testing.T) {
+	type describeTargetHealthWithContextCall struct {
+		resp *elbv2sdk.DescribeTargetHealthOutput
+		err  error
+	}
+	type deregisterTargetsWithContextCall struct {
+		req *elbv2sdk.DeregisterTargetsInput
+	}
+	tests := []struct {
+		name                                 string
+		registeredTargets                    []elbv2api.PodTarget
+		describeTargetHealthWithContextCalls []describeTargetHealthWithContextCall
+		deregisterTargetsWithContextCalls    []deregisterTargetsWithContextCall
+		wantErr                              error
+	}{
+		{
+			name: "deregister registered targets that are not draining",
+			registeredTargets: []elbv2api.PodTarget{
+				{IP: "192.168.1.1", Port: 8080},
+				{IP: "192.168.1.2", Port: 8080},
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.2"), Port: awssdk.Int64(8080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumDraining)},
+							},
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("10.0.0.1"), Port: awssdk.Int64(80)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+						},
+					},
+				},
+			},
+			deregisterTargetsWithContextCalls: []deregisterTargetsWithContextCall{
+				{
+					req: &elbv2sdk.DeregisterTargetsInput{
+						TargetGroupArn: awssdk.String(testPodTargetGroupARN),
+						Targets: []*elbv2sdk.TargetDescription{
+							{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no registered targets",
+		},
+		{
+			name: "targetGroup already deleted",
+			registeredTargets: []elbv2api.PodTarget{
+				{IP: "192.168.1.1", Port: 8080},
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					err: awserr.New("TargetGroupNotFound", "", nil),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeTargetHealthWithContextCalls {
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), gomock.Any()).Return(call.resp, call.err)
+			}
+			for _, call := range tt.deregisterTargetsWithContextCalls {
+				elbv2Client.EXPECT().DeregisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeregisterTargetsOutput{}, nil)
+			}
+
+			targetsManager := NewCachedTargetsManager(elbv2Client, 1, nil, &log.NullLogger{})
+			m := NewDefaultPodResourceManager(nil, targetsManager, &log.NullLogger{})
+			err := m.Cleanup(context.Background(), &elbv2api.PodTargetGroupBinding{
+				Spec: elbv2api.PodTargetGroupBindingSpec{
+					TargetGroupARN: testPodTargetGroupARN,
+				},
+				Status: elbv2api.PodTargetGroupBindingStatus{
+					RegisteredTargets: tt.registeredTargets,
+				},
+			})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePodTargetGroupBinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    elbv2api.PodTargetGroupBindingSpec
+		wantErr error
+	}{
+		{
+			name: "valid spec",
+			spec: elbv2api.PodTargetGroupBindingSpec{
+				TargetGroupARN: testPodTargetGroupARN,
+				PodSelector: metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"canary"}},
+					},
+				},
+				Port: 8080,
+			},
+		},
+		{
+			name: "malformed targetGroupARN",
+			spec: elbv2api.PodTargetGroupBindingSpec{
+				TargetGroupARN: "my-tg",
+				PodSelector:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "canary"}},
+				Port:           8080,
+			},
+			wantErr: errors.New("invalid targetGroupARN my-tg: arn: invalid prefix"),
+		},
+		{
+			name: "targetGroupARN of loadBalancer",
+			spec: elbv2api.PodTargetGroupBindingSpec{
+				TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188",
+				PodSelector:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "canary"}},
+				Port:           8080,
+			},
+			wantErr: errors.New("invalid targetGroupARN arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188: not an ELBV2 targetGroup"),
+		},
+		{
+			name: "empty podSelector",
+			spec: elbv2api.PodTargetGroupBindingSpec{
+				TargetGroupARN: testPodTargetGroupARN,
+				Port:           8080,
+			},
+			wantErr: errors.New("podSelector must not be empty"),
+		},
+		{
+			name: "invalid podSelector operator",
+			spec: elbv2api.PodTargetGroupBindingSpec{
+				TargetGroupARN: testPodTargetGroupARN,
+				PodSelector: metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "app", Operator: "Unknown"},
+					},
+				},
+				Port: 8080,
+			},
+			wantErr: errors.New("invalid podSelector: \"Unknown\" is not a valid pod selector operator"),
+		},
+		{
+			name: "port out of range",
+			spec: elbv2api.PodTargetGroupBindingSpec{
+				TargetGroupARN: testPodTargetGroupARN,
+				PodSelector:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "canary"}},
+				Port:           0,
+			},
+			wantErr: errors.New("invalid port 0: must be between 1 and 65535"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePodTargetGroupBinding(&elbv2api.PodTargetGroupBinding{Spec: tt.spec})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -9,12 +9,15 @@ import (
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/tools/record"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
@@ -27,28 +30,60 @@ import (
 
 const defaultTargetHealthRequeueDuration = 15 * time.Second
 
+const (
+	// targetHealthReasonHealthyThresholdPending is the pod condition reason when target is healthy but not for the healthyThreshold yet.
+	targetHealthReasonHealthyThresholdPending  = "HealthyThresholdPending"
+	targetHealthMessageHealthyThresholdPending = "Target is healthy, waiting for healthy threshold"
+	// targetHealthReasonNodeNotRegistered is the pod condition reason when the pod's node isn't registered as instance target.
+	targetHealthReasonNodeNotRegistered  = "NodeNotRegistered"
+	targetHealthMessageNodeNotRegistered = "Node of the pod is not registered as target, traffic reaches the pod via other nodes"
+	// targetHealthReasonNoReadyEndpoints is the pod condition reason when the service has no ready endpoints for instance targets to route traffic to.
+	targetHealthReasonNoReadyEndpoints  = "NoReadyEndpoints"
+	targetHealthMessageNoReadyEndpoints = "Service has no ready endpoints, nodes cannot pass health checks until pods are ready"
+)
+
 // ResourceManager manages the TargetGroupBinding resource.
 type ResourceManager interface {
 	Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error
 	Cleanup(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error
+	// HandOver releases the TargetGroup of tgb that is deactivated by a conflicting owner.
+	// precedentPTGB is the owner if it's a PodTargetGroupBinding, or nil if the owner is a managed TargetGroupBinding.
+	HandOver(ctx context.Context, tgb *elbv2api.TargetGroupBinding, precedentPTGB *elbv2api.PodTargetGroupBinding) error
 }
 
 // NewDefaultResourceManager constructs new defaultResourceManager.
-func NewDefaultResourceManager(k8sClient client.Client, elbv2Client services.ELBV2,
+func NewDefaultResourceManager(k8sClient client.Client, eventRecorder record.EventRecorder, elbv2Client services.ELBV2,
 	podInfoRepo k8s.PodInfoRepo, podENIResolver networking.PodENIInfoResolver, nodeENIResolver networking.NodeENIInfoResolver,
 	sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler,
-	vpcID string, clusterName string, logger logr.Logger) *defaultResourceManager {
-	targetsManager := NewCachedTargetsManager(elbv2Client, logger)
+	vpcID string, clusterName string, skipOffAZNodes bool, instanceTargetReadinessGate bool, healthyThresholdWait bool,
+	registerTargetsMaxRetries int, metricsRegisterer prometheus.Registerer, logger logr.Logger) *defaultResourceManager {
+	targetsManager := NewCachedTargetsManager(elbv2Client, registerTargetsMaxRetries, metricsRegisterer, logger)
+	azResolver := NewCachedAvailabilityZoneResolver(elbv2Client)
+	var healthyThresholdResolver HealthyThresholdResolver
+	if healthyThresholdWait {
+		healthyThresholdResolver = NewCachedHealthyThresholdResolver(elbv2Client)
+	}
+	deregistrationDelayResolver := NewCachedDeregistrationDelayResolver(elbv2Client)
+	healthCheckManager := NewDefaultHealthCheckManager(elbv2Client, logger)
 	endpointResolver := backend.NewDefaultEndpointResolver(k8sClient, podInfoRepo, logger)
-	networkingManager := NewDefaultNetworkingManager(k8sClient, podENIResolver, nodeENIResolver, sgManager, sgReconciler, vpcID, clusterName, logger)
+	networkingManager := NewDefaultNetworkingManager(k8sClient, eventRecorder, podENIResolver, nodeENIResolver, sgManager, sgReconciler, vpcID, clusterName, logger)
 	return &defaultResourceManager{
-		k8sClient:         k8sClient,
-		targetsManager:    targetsManager,
-		endpointResolver:  endpointResolver,
-		networkingManager: networkingManager,
-		logger:            logger,
+		k8sClient:          k8sClient,
+		eventRecorder:      eventRecorder,
+		targetsManager:     targetsManager,
+		endpointResolver:   endpointResolver,
+		networkingManager:  networkingManager,
+		azResolver:         azResolver,
+		healthCheckManager: healthCheckManager,
+		logger:             logger,
+
+		healthyThresholdResolver:    healthyThresholdResolver,
+		deregistrationDelayResolver: deregistrationDelayResolver,
+		drainingTargetsTracker:      newDrainingTargetsTracker(),
 
 		targetHealthRequeueDuration: defaultTargetHealthRequeueDuration,
+		skipOffAZNodes:              skipOffAZNodes,
+		instanceTargetReadinessGate: instanceTargetReadinessGate,
 	}
 }
 
@@ -56,19 +91,33 @@ var _ ResourceManager = &defaultResourceManager{}
 
 // default implementation for ResourceManager.
 type defaultResourceManager struct {
-	k8sClient         client.Client
-	targetsManager    TargetsManager
-	endpointResolver  backend.EndpointResolver
-	networkingManager NetworkingManager
-	logger            logr.Logger
-
+	k8sClient          client.Client
+	eventRecorder      record.EventRecorder
+	targetsManager     TargetsManager
+	endpointResolver   backend.EndpointResolver
+	networkingManager  NetworkingManager
+	azResolver         AvailabilityZoneResolver
+	healthCheckManager HealthCheckManager
+	logger             logr.Logger
+
+	// resolves the healthyThreshold to wait for before pods' targetHealth condition is set to true, nil if there is no wait.
+	healthyThresholdResolver    HealthyThresholdResolver
+	deregistrationDelayResolver DeregistrationDelayResolver
+	drainingTargetsTracker      *drainingTargetsTracker
 	targetHealthRequeueDuration time.Duration
+	// whether to skip nodes outside LoadBalancer's availabilityZones for instance targets.
+	skipOffAZNodes bool
+	// whether to update the targetHealth readiness gate of pods with the targetHealth of their nodes for instance targets.
+	instanceTargetReadinessGate bool
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	if tgb.Spec.TargetType == nil {
 		return errors.Errorf("targetType is not specified: %v", k8s.NamespacedName(tgb).String())
 	}
+	if err := m.healthCheckManager.Reconcile(ctx, tgb); err != nil {
+		return err
+	}
 	if *tgb.Spec.TargetType == elbv2api.TargetTypeIP {
 		return m.reconcileWithIPTargetType(ctx, tgb)
 	}
@@ -76,15 +125,28 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, tgb *elbv2api.Ta
 }
 
 func (m *defaultResourceManager) Cleanup(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	if err := m.cleanupTargets(ctx, tgb); err != nil {
+	if err := m.cleanupTargets(ctx, tgb, nil); err != nil {
 		return err
 	}
 	if err := m.networkingManager.Cleanup(ctx, tgb); err != nil {
 		return err
 	}
+	m.drainingTargetsTracker.track(tgb.Spec.TargetGroupARN, nil, time.Now())
 	return nil
 }
 
+func (m *defaultResourceManager) HandOver(ctx context.Context, tgb *elbv2api.TargetGroupBinding, precedentPTGB *elbv2api.PodTargetGroupBinding) error {
+	// managed TargetGroupBindings deregister all targets outside their endpoints, so the targets of tgb are left to them.
+	// PodTargetGroupBindings only deregister targets they registered, so the targets of tgb are deregistered except the ones tracked by them.
+	if precedentPTGB != nil {
+		if err := m.cleanupTargets(ctx, tgb, buildRegisteredTargets(precedentPTGB)); err != nil {
+			return err
+		}
+	}
+	// the draining targets are tracked per TargetGroup, which is shared with the owner, so they are kept as is.
+	return m.networkingManager.Cleanup(ctx, tgb)
+}
+
 func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	svcKey := buildServiceReferenceKey(tgb, tgb.Spec.ServiceRef)
 
@@ -114,8 +176,12 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 	if err := m.registerPodEndpoints(ctx, tgARN, unmatchedEndpoints); err != nil {
 		return err
 	}
+	drainingCompletionTime, err := m.updateDrainingStatus(ctx, tgb, append(drainingTargets, unmatchedTargets...))
+	if err != nil {
+		return err
+	}
 
-	anyPodNeedFurtherProbe, err := m.updateTargetHealthPodCondition(ctx, targetHealthCondType, matchedEndpointAndTargets, unmatchedEndpoints)
+	anyPodNeedFurtherProbe, err := m.updateTargetHealthPodCondition(ctx, tgARN, targetHealthCondType, matchedEndpointAndTargets, unmatchedEndpoints)
 	if err != nil {
 		return err
 	}
@@ -131,7 +197,9 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 		return runtime.NewRequeueNeeded("monitor potential ready endpoints")
 	}
 
-	_ = drainingTargets
+	if !drainingCompletionTime.IsZero() {
+		return runtime.NewRequeueNeededAfter("monitor draining targets", m.computeDrainingRequeueDuration(drainingCompletionTime))
+	}
 	return nil
 }
 
@@ -144,12 +212,16 @@ func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Con
 		return err
 	}
 	tgARN := tgb.Spec.TargetGroupARN
+	endpoints, err = m.filterNodePortEndpointsByLoadBalancerAZs(ctx, tgb, endpoints)
+	if err != nil {
+		return err
+	}
 	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
 	if err != nil {
 		return err
 	}
 	notDrainingTargets, drainingTargets := partitionTargetsByDrainingStatus(targets)
-	_, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargets)
+	matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargets)
 
 	if err := m.networkingManager.ReconcileForNodePortEndpoints(ctx, tgb, endpoints); err != nil {
 		return err
@@ -160,11 +232,103 @@ func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Con
 	if err := m.registerNodePortEndpoints(ctx, tgARN, unmatchedEndpoints); err != nil {
 		return err
 	}
-	_ = drainingTargets
+	drainingCompletionTime, err := m.updateDrainingStatus(ctx, tgb, append(drainingTargets, unmatchedTargets...))
+	if err != nil {
+		return err
+	}
+	if m.instanceTargetReadinessGate {
+		anyPodNeedFurtherProbe, err := m.updateInstanceTargetHealthPodCondition(ctx, tgb, matchedEndpointAndTargets, unmatchedEndpoints)
+		if err != nil {
+			return err
+		}
+		if anyPodNeedFurtherProbe {
+			return runtime.NewRequeueNeededAfter("monitor targetHealth", m.targetHealthRequeueDuration)
+		}
+	}
+	if !drainingCompletionTime.IsZero() {
+		return runtime.NewRequeueNeededAfter("monitor draining targets", m.computeDrainingRequeueDuration(drainingCompletionTime))
+	}
 	return nil
 }
 
-func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+// filterNodePortEndpointsByLoadBalancerAZs excludes nodePort endpoints outside the availabilityZones of LoadBalancer if skipOffAZNodes is enabled.
+// the excluded endpoints will be reported, the availabilityZones of LoadBalancer aren't resolved if skipOffAZNodes is disabled.
+func (m *defaultResourceManager) filterNodePortEndpointsByLoadBalancerAZs(ctx context.Context, tgb *elbv2api.TargetGroupBinding,
+	endpoints []backend.NodePortEndpoint) ([]backend.NodePortEndpoint, error) {
+	if !m.skipOffAZNodes {
+		return endpoints, nil
+	}
+	lbAZs, err := m.azResolver.ResolveLoadBalancerAvailabilityZones(ctx, tgb.Spec.TargetGroupARN)
+	if err != nil {
+		return nil, err
+	}
+	inAZEndpoints, offAZEndpoints := partitionNodePortEndpointsByAZs(endpoints, lbAZs)
+	if len(offAZEndpoints) == 0 {
+		return endpoints, nil
+	}
+	offAZInstanceIDs := make([]string, 0, len(offAZEndpoints))
+	for _, endpoint := range offAZEndpoints {
+		offAZInstanceIDs = append(offAZInstanceIDs, endpoint.InstanceID)
+	}
+	m.logger.Info("skipping nodes outside loadBalancer availabilityZones",
+		"tgb", k8s.NamespacedName(tgb),
+		"availabilityZones", lbAZs.List(),
+		"instanceIDs", offAZInstanceIDs)
+	return inAZEndpoints, nil
+}
+
+// updateDrainingStatus reports the draining progress of targets in TargetGroupBinding's status and events.
+// the draining completion is estimated with TargetGroup's deregistration delay since targets are first observed draining.
+// returns the estimated draining completion time, or zero time if there are no draining targets.
+func (m *defaultResourceManager) updateDrainingStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding, drainingTargets []TargetInfo) (time.Time, error) {
+	tgARN := tgb.Spec.TargetGroupARN
+	drainingTargetIDs := make([]string, 0, len(drainingTargets))
+	for _, target := range drainingTargets {
+		drainingTargetIDs = append(drainingTargetIDs, UniqueIDForTargetDescription(target.Target))
+	}
+	drainingSince := m.drainingTargetsTracker.track(tgARN, drainingTargetIDs, time.Now())
+
+	var drainingStatus *elbv2api.TargetGroupBindingDrainingStatus
+	var drainingCompletionTime time.Time
+	if len(drainingTargetIDs) != 0 {
+		deregistrationDelay, err := m.deregistrationDelayResolver.ResolveDeregistrationDelay(ctx, tgARN)
+		if err != nil {
+			return time.Time{}, err
+		}
+		// status timestamps are serialized in seconds precision.
+		drainingCompletionTime = drainingSince.Add(deregistrationDelay).Truncate(time.Second)
+		drainingStatus = &elbv2api.TargetGroupBindingDrainingStatus{
+			Targets:                 int32(len(drainingTargetIDs)),
+			EstimatedCompletionTime: &metav1.Time{Time: drainingCompletionTime},
+		}
+	}
+	if equality.Semantic.DeepEqual(tgb.Status.Draining, drainingStatus) {
+		return drainingCompletionTime, nil
+	}
+
+	tgbOld := tgb.DeepCopy()
+	tgb.Status.Draining = drainingStatus
+	if err := m.k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
+		return time.Time{}, errors.Wrapf(err, "failed to update targetGroupBinding draining status: %v", k8s.NamespacedName(tgb))
+	}
+	if drainingStatus != nil {
+		m.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonDrainingTargets,
+			fmt.Sprintf("Draining %d targets, estimated completion at %v", drainingStatus.Targets, drainingCompletionTime.UTC().Format(time.RFC3339)))
+	}
+	return drainingCompletionTime, nil
+}
+
+// computeDrainingRequeueDuration computes the duration to requeue for monitoring draining targets until drainingCompletionTime.
+func (m *defaultResourceManager) computeDrainingRequeueDuration(drainingCompletionTime time.Time) time.Duration {
+	requeueDuration := time.Until(drainingCompletionTime)
+	if requeueDuration < m.targetHealthRequeueDuration {
+		return m.targetHealthRequeueDuration
+	}
+	return requeueDuration
+}
+
+// cleanupTargets deregisters the targets of tgb's TargetGroup, except retainedTargets.
+func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding, retainedTargets []elbv2sdk.TargetDescription) error {
 	targets, err := m.targetsManager.ListTargets(ctx, tgb.Spec.TargetGroupARN)
 	if err != nil {
 		if isELBV2TargetGroupNotFoundError(err) {
@@ -172,7 +336,19 @@ func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2a
 		}
 		return err
 	}
-	if err := m.deregisterTargets(ctx, tgb.Spec.TargetGroupARN, targets); err != nil {
+	retainedTargetIDs := sets.NewString()
+	for _, target := range retainedTargets {
+		retainedTargetIDs.Insert(UniqueIDForTargetDescription(target))
+	}
+	// draining targets are already being deregistered, deregister them again is redundant.
+	notDrainingTargets, _ := partitionTargetsByDrainingStatus(targets)
+	var unretainedTargets []TargetInfo
+	for _, target := range notDrainingTargets {
+		if !retainedTargetIDs.Has(UniqueIDForTargetDescription(target.Target)) {
+			unretainedTargets = append(unretainedTargets, target)
+		}
+	}
+	if err := m.deregisterTargets(ctx, tgb.Spec.TargetGroupARN, unretainedTargets); err != nil {
 		if isELBV2TargetGroupNotFoundError(err) {
 			return nil
 		}
@@ -183,14 +359,14 @@ func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2a
 
 // updateTargetHealthPodCondition will updates pod's targetHealth condition for matchedEndpointAndTargets and unmatchedEndpoints.
 // returns whether further probe is needed or not
-func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Context, targetHealthCondType corev1.PodConditionType,
+func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Context, tgARN string, targetHealthCondType corev1.PodConditionType,
 	matchedEndpointAndTargets []podEndpointAndTargetPair, unmatchedEndpoints []backend.PodEndpoint) (bool, error) {
 	anyPodNeedFurtherProbe := false
 
 	for _, endpointAndTarget := range matchedEndpointAndTargets {
 		pod := endpointAndTarget.endpoint.Pod
 		targetHealth := endpointAndTarget.target.TargetHealth
-		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType)
+		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, tgARN, pod, targetHealth, targetHealthCondType)
 		if err != nil {
 			return false, err
 		}
@@ -206,7 +382,88 @@ func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Cont
 			Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress),
 			Description: awssdk.String("Target registration is in progress"),
 		}
-		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType)
+		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, tgARN, pod, targetHealth, targetHealthCondType)
+		if err != nil {
+			return false, err
+		}
+		if needFurtherProbe {
+			anyPodNeedFurtherProbe = true
+		}
+	}
+	return anyPodNeedFurtherProbe, nil
+}
+
+// updateInstanceTargetHealthPodCondition updates targetHealth condition of pods backing the service with the targetHealth of their nodes.
+// pods on nodes that aren't registered as targets, because of the node selector or externalTrafficPolicy Local, aren't gated on their node,
+// since the node might never be registered, or only be registered once the pod is ready.
+// kube-proxy only routes nodePort traffic to ready endpoints, so nodes cannot pass health checks while the service has no ready endpoints,
+// in which case the condition is set to true to avoid deadlocking the rollout.
+// bypassed conditions are kept true until the node's target turns healthy.
+// returns whether further probe is needed or not
+func (m *defaultResourceManager) updateInstanceTargetHealthPodCondition(ctx context.Context, tgb *elbv2api.TargetGroupBinding,
+	matchedEndpointAndTargets []nodePortEndpointAndTargetPair, unmatchedEndpoints []backend.NodePortEndpoint) (bool, error) {
+	svcKey := buildServiceReferenceKey(tgb, tgb.Spec.ServiceRef)
+	targetHealthCondType := BuildTargetHealthPodConditionType(tgb)
+	podEndpoints, _, err := m.endpointResolver.ResolvePodEndpoints(ctx, svcKey, tgb.Spec.ServiceRef.Port,
+		backend.WithPodReadinessGate(targetHealthCondType))
+	if err != nil {
+		return false, err
+	}
+
+	targetHealthByNodeName := make(map[string]*elbv2sdk.TargetHealth, len(matchedEndpointAndTargets)+len(unmatchedEndpoints))
+	for _, endpointAndTarget := range matchedEndpointAndTargets {
+		if node := endpointAndTarget.endpoint.Node; node != nil {
+			targetHealthByNodeName[node.Name] = endpointAndTarget.target.TargetHealth
+		}
+	}
+	for _, endpoint := range unmatchedEndpoints {
+		if node := endpoint.Node; node != nil {
+			targetHealthByNodeName[node.Name] = &elbv2sdk.TargetHealth{
+				State:       awssdk.String(elbv2sdk.TargetHealthStateEnumInitial),
+				Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress),
+				Description: awssdk.String("Target registration is in progress"),
+			}
+		}
+	}
+
+	containsReadyEndpoints := false
+	for _, endpoint := range podEndpoints {
+		if readyCond, exists := endpoint.Pod.GetPodCondition(corev1.PodReady); exists && readyCond.Status == corev1.ConditionTrue {
+			containsReadyEndpoints = true
+			break
+		}
+	}
+
+	anyPodNeedFurtherProbe := false
+	for _, endpoint := range podEndpoints {
+		targetHealth, exists := targetHealthByNodeName[endpoint.Pod.NodeName]
+		if !exists {
+			// the pod's node will be enqueued again via endpoints or node events once it becomes eligible, no need to probe.
+			if err := m.bypassTargetHealthPodConditionForPod(ctx, endpoint.Pod, targetHealthCondType,
+				targetHealthReasonNodeNotRegistered, targetHealthMessageNodeNotRegistered); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if awssdk.StringValue(targetHealth.State) != elbv2sdk.TargetHealthStateEnumHealthy {
+			existingTargetHealthCond, exists := endpoint.Pod.GetPodCondition(targetHealthCondType)
+			bypassedBefore := exists && existingTargetHealthCond.Status == corev1.ConditionTrue &&
+				(existingTargetHealthCond.Reason == targetHealthReasonNoReadyEndpoints ||
+					existingTargetHealthCond.Reason == targetHealthReasonNodeNotRegistered)
+			if bypassedBefore {
+				anyPodNeedFurtherProbe = true
+				continue
+			}
+			if !containsReadyEndpoints {
+				if err := m.bypassTargetHealthPodConditionForPod(ctx, endpoint.Pod, targetHealthCondType,
+					targetHealthReasonNoReadyEndpoints, targetHealthMessageNoReadyEndpoints); err != nil {
+					return false, err
+				}
+				anyPodNeedFurtherProbe = true
+				continue
+			}
+		}
+		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, tgb.Spec.TargetGroupARN, endpoint.Pod, targetHealth, targetHealthCondType)
 		if err != nil {
 			return false, err
 		}
@@ -218,15 +475,18 @@ func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Cont
 }
 
 // updateTargetHealthPodConditionForPod updates pod's targetHealth condition for a single pod and its matched target.
+// if healthyThreshold wait is enabled, healthy targets are only considered ready after they stayed healthy for TargetGroup's healthyThreshold.
 // returns whether further probe is needed or not.
-func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx context.Context, pod k8s.PodInfo,
+func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx context.Context, tgARN string, pod k8s.PodInfo,
 	targetHealth *elbv2sdk.TargetHealth, targetHealthCondType corev1.PodConditionType) (bool, error) {
 	if !pod.HasAnyOfReadinessGates([]corev1.PodConditionType{targetHealthCondType}) {
 		return false, nil
 	}
 
+	existingTargetHealthCond, exists := pod.GetPodCondition(targetHealthCondType)
 	targetHealthCondStatus := corev1.ConditionUnknown
 	var reason, message string
+	var lastProbeTime metav1.Time
 	if targetHealth != nil {
 		if awssdk.StringValue(targetHealth.State) == elbv2sdk.TargetHealthStateEnumHealthy {
 			targetHealthCondStatus = corev1.ConditionTrue
@@ -237,22 +497,57 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 		reason = awssdk.StringValue(targetHealth.Reason)
 		message = awssdk.StringValue(targetHealth.Description)
 	}
+	// we wait for the healthyThreshold before flipping the condition to true, the time target is first seen healthy is tracked as lastProbeTime.
+	if m.healthyThresholdResolver != nil && targetHealthCondStatus == corev1.ConditionTrue && !(exists && existingTargetHealthCond.Status == corev1.ConditionTrue) {
+		healthyThreshold, err := m.healthyThresholdResolver.ResolveHealthyThresholdDuration(ctx, tgARN)
+		if err != nil {
+			return false, err
+		}
+		healthySince := metav1.Now()
+		if exists && existingTargetHealthCond.Reason == targetHealthReasonHealthyThresholdPending {
+			healthySince = existingTargetHealthCond.LastProbeTime
+		}
+		if time.Since(healthySince.Time) < healthyThreshold {
+			targetHealthCondStatus = corev1.ConditionFalse
+			reason = targetHealthReasonHealthyThresholdPending
+			message = targetHealthMessageHealthyThresholdPending
+			lastProbeTime = healthySince
+		}
+	}
 	needFurtherProbe := targetHealthCondStatus != corev1.ConditionTrue
+	if err := m.patchTargetHealthPodCondition(ctx, pod, targetHealthCondType, targetHealthCondStatus, reason, message, lastProbeTime); err != nil {
+		return false, err
+	}
+	return needFurtherProbe, nil
+}
 
+// bypassTargetHealthPodConditionForPod sets pod's targetHealth condition to true regardless of its target's health.
+func (m *defaultResourceManager) bypassTargetHealthPodConditionForPod(ctx context.Context, pod k8s.PodInfo, targetHealthCondType corev1.PodConditionType,
+	reason string, message string) error {
+	if !pod.HasAnyOfReadinessGates([]corev1.PodConditionType{targetHealthCondType}) {
+		return nil
+	}
+	return m.patchTargetHealthPodCondition(ctx, pod, targetHealthCondType, corev1.ConditionTrue, reason, message, metav1.Time{})
+}
+
+// patchTargetHealthPodCondition patches pod's targetHealth condition if it differs from current status/reason/message.
+func (m *defaultResourceManager) patchTargetHealthPodCondition(ctx context.Context, pod k8s.PodInfo, targetHealthCondType corev1.PodConditionType,
+	targetHealthCondStatus corev1.ConditionStatus, reason string, message string, lastProbeTime metav1.Time) error {
 	existingTargetHealthCond, exists := pod.GetPodCondition(targetHealthCondType)
 	// we skip patch pod if it matches current computed status/reason/message.
 	if exists &&
 		existingTargetHealthCond.Status == targetHealthCondStatus &&
 		existingTargetHealthCond.Reason == reason &&
 		existingTargetHealthCond.Message == message {
-		return needFurtherProbe, nil
+		return nil
 	}
 
 	newTargetHealthCond := corev1.PodCondition{
-		Type:    targetHealthCondType,
-		Status:  targetHealthCondStatus,
-		Reason:  reason,
-		Message: message,
+		Type:          targetHealthCondType,
+		Status:        targetHealthCondStatus,
+		LastProbeTime: lastProbeTime,
+		Reason:        reason,
+		Message:       message,
 	}
 	if !exists || existingTargetHealthCond.Status != targetHealthCondStatus {
 		newTargetHealthCond.LastTransitionTime = metav1.Now()
@@ -260,7 +555,7 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 
 	patch, err := buildPodConditionPatch(pod, newTargetHealthCond)
 	if err != nil {
-		return false, err
+		return err
 	}
 	k8sPod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -271,15 +566,17 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 	}
 	if err := m.k8sClient.Status().Patch(ctx, k8sPod, patch); err != nil {
 		if apierrors.IsNotFound(err) {
-			return false, nil
+			return nil
 		}
-		return false, err
+		return err
 	}
-
-	return needFurtherProbe, nil
+	return nil
 }
 
 func (m *defaultResourceManager) deregisterTargets(ctx context.Context, tgARN string, targets []TargetInfo) error {
+	if len(targets) == 0 {
+		return nil
+	}
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(targets))
 	for _, target := range targets {
 		sdkTargets = append(sdkTargets, target.Target)
@@ -288,6 +585,9 @@ func (m *defaultResourceManager) deregisterTargets(ctx context.Context, tgARN st
 }
 
 func (m *defaultResourceManager) registerPodEndpoints(ctx context.Context, tgARN string, endpoints []backend.PodEndpoint) error {
+	if len(endpoints) == 0 {
+		return nil
+	}
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(endpoints))
 	for _, endpoint := range endpoints {
 		sdkTargets = append(sdkTargets, elbv2sdk.TargetDescription{
@@ -299,6 +599,9 @@ func (m *defaultResourceManager) registerPodEndpoints(ctx context.Context, tgARN
 }
 
 func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context, tgARN string, endpoints []backend.NodePortEndpoint) error {
+	if len(endpoints) == 0 {
+		return nil
+	}
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(endpoints))
 	for _, endpoint := range endpoints {
 		sdkTargets = append(sdkTargets, elbv2sdk.TargetDescription{
@@ -309,6 +612,29 @@ func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context,
 	return m.targetsManager.RegisterTargets(ctx, tgARN, sdkTargets)
 }
 
+// partitionNodePortEndpointsByAZs partitions nodePort endpoints into those inside and outside specified availabilityZones.
+// endpoints whose node's availabilityZone is unknown are treated as inside availabilityZones.
+// if availabilityZones is nil, all endpoints are treated as inside availabilityZones.
+func partitionNodePortEndpointsByAZs(endpoints []backend.NodePortEndpoint, azs sets.String) ([]backend.NodePortEndpoint, []backend.NodePortEndpoint) {
+	if azs == nil {
+		return endpoints, nil
+	}
+	var inAZEndpoints []backend.NodePortEndpoint
+	var offAZEndpoints []backend.NodePortEndpoint
+	for _, endpoint := range endpoints {
+		nodeAZ := ""
+		if endpoint.Node != nil {
+			nodeAZ = k8s.GetNodeAvailabilityZone(endpoint.Node)
+		}
+		if nodeAZ == "" || azs.Has(nodeAZ) {
+			inAZEndpoints = append(inAZEndpoints, endpoint)
+		} else {
+			offAZEndpoints = append(offAZEndpoints, endpoint)
+		}
+	}
+	return inAZEndpoints, offAZEndpoints
+}
+
 type podEndpointAndTargetPair struct {
 	endpoint backend.PodEndpoint
 	target   TargetInfo
@@ -410,9 +736,14 @@ func matchNodePortEndpointWithTargets(endpoints []backend.NodePortEndpoint, targ
 }
 
 func buildPodConditionPatch(pod k8s.PodInfo, condition corev1.PodCondition) (client.Patch, error) {
+	// existing condition is included in oldData so that fields unset in new condition(e.g. reason) will be cleared.
+	var oldConditions []corev1.PodCondition
+	if existingCondition, exists := pod.GetPodCondition(condition.Type); exists {
+		oldConditions = append(oldConditions, existingCondition)
+	}
 	oldData, err := json.Marshal(corev1.Pod{
 		Status: corev1.PodStatus{
-			Conditions: nil,
+			Conditions: oldConditions,
 		},
 	})
 	if err != nil {
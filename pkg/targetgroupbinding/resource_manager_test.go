@@ -4,24 +4,38 @@ import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	mock_backend "sigs.k8s.io/aws-load-balancer-controller/mocks/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/equality"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	ctrlruntime "sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing.T) {
 	type env struct {
-		pods []*corev1.Pod
+		pods                 []*corev1.Pod
+		healthyThresholdWait bool
+		healthyThreshold     time.Duration
 	}
 
 	type args struct {
@@ -368,135 +382,1600 @@ func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing
 				},
 			},
 		},
+		{
+			name: "pod contains readinessGate and targetHealth is healthy - no healthyThreshold wait by default",
+			env: env{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "my-pod",
+							UID:       "my-pod-uuid",
+						},
+						Spec: corev1.PodSpec{
+							ReadinessGates: []corev1.PodReadinessGate{
+								{
+									ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+								},
+							},
+						},
+						Status: corev1.PodStatus{
+							Conditions: []corev1.PodCondition{
+								{
+									Type:   corev1.ContainersReady,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+					},
+				},
+				healthyThreshold: 30 * time.Second,
+			},
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+					UID: "my-pod-uuid",
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   corev1.ContainersReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+				targetHealth: &elbv2sdk.TargetHealth{
+					State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+				},
+				targetHealthCondType: "target-health.elbv2.k8s.aws/my-tgb",
+			},
+			want: false,
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-pod",
+					UID:       "my-pod-uuid",
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   "target-health.elbv2.k8s.aws/my-tgb",
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   corev1.ContainersReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pod contains readinessGate and targetHealth is healthy - wait for healthyThreshold",
+			env: env{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "my-pod",
+							UID:       "my-pod-uuid",
+						},
+						Spec: corev1.PodSpec{
+							ReadinessGates: []corev1.PodReadinessGate{
+								{
+									ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+								},
+							},
+						},
+						Status: corev1.PodStatus{
+							Conditions: []corev1.PodCondition{
+								{
+									Type:   corev1.ContainersReady,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+					},
+				},
+				healthyThresholdWait: true,
+				healthyThreshold:     30 * time.Second,
+			},
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+					UID: "my-pod-uuid",
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   corev1.ContainersReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+				targetHealth: &elbv2sdk.TargetHealth{
+					State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+				},
+				targetHealthCondType: "target-health.elbv2.k8s.aws/my-tgb",
+			},
+			want: true,
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-pod",
+					UID:       "my-pod-uuid",
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:    "target-health.elbv2.k8s.aws/my-tgb",
+							Status:  corev1.ConditionFalse,
+							Reason:  targetHealthReasonHealthyThresholdPending,
+							Message: targetHealthMessageHealthyThresholdPending,
+						},
+						{
+							Type:   corev1.ContainersReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pod contains readinessGate and targetHealth is healthy - still wait for healthyThreshold",
+			env: env{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "my-pod",
+							UID:       "my-pod-uuid",
+						},
+						Spec: corev1.PodSpec{
+							ReadinessGates: []corev1.PodReadinessGate{
+								{
+									ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+								},
+							},
+						},
+						Status: corev1.PodStatus{
+							Conditions: []corev1.PodCondition{
+								{
+									Type:          "target-health.elbv2.k8s.aws/my-tgb",
+									Status:        corev1.ConditionFalse,
+									LastProbeTime: metav1.NewTime(time.Now().Add(-10 * time.Second)),
+									Reason:        targetHealthReasonHealthyThresholdPending,
+									Message:       targetHealthMessageHealthyThresholdPending,
+								},
+								{
+									Type:   corev1.ContainersReady,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+					},
+				},
+				healthyThresholdWait: true,
+				healthyThreshold:     30 * time.Second,
+			},
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+					UID: "my-pod-uuid",
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+					Conditions: []corev1.PodCondition{
+						{
+							Type:          "target-health.elbv2.k8s.aws/my-tgb",
+							Status:        corev1.ConditionFalse,
+							LastProbeTime: metav1.NewTime(time.Now().Add(-10 * time.Second)),
+							Reason:        targetHealthReasonHealthyThresholdPending,
+							Message:       targetHealthMessageHealthyThresholdPending,
+						},
+						{
+							Type:   corev1.ContainersReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+				targetHealth: &elbv2sdk.TargetHealth{
+					State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+				},
+				targetHealthCondType: "target-health.elbv2.k8s.aws/my-tgb",
+			},
+			want: true,
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-pod",
+					UID:       "my-pod-uuid",
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:    "target-health.elbv2.k8s.aws/my-tgb",
+							Status:  corev1.ConditionFalse,
+							Reason:  targetHealthReasonHealthyThresholdPending,
+							Message: targetHealthMessageHealthyThresholdPending,
+						},
+						{
+							Type:   corev1.ContainersReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pod contains readinessGate and targetHealth is healthy - healthyThreshold elapsed",
+			env: env{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "my-pod",
+							UID:       "my-pod-uuid",
+						},
+						Spec: corev1.PodSpec{
+							ReadinessGates: []corev1.PodReadinessGate{
+								{
+									ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+								},
+							},
+						},
+						Status: corev1.PodStatus{
+							Conditions: []corev1.PodCondition{
+								{
+									Type:          "target-health.elbv2.k8s.aws/my-tgb",
+									Status:        corev1.ConditionFalse,
+									LastProbeTime: metav1.NewTime(time.Now().Add(-60 * time.Second)),
+									Reason:        targetHealthReasonHealthyThresholdPending,
+									Message:       targetHealthMessageHealthyThresholdPending,
+								},
+								{
+									Type:   corev1.ContainersReady,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+					},
+				},
+				healthyThresholdWait: true,
+				healthyThreshold:     30 * time.Second,
+			},
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+					UID: "my-pod-uuid",
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+					Conditions: []corev1.PodCondition{
+						{
+							Type:          "target-health.elbv2.k8s.aws/my-tgb",
+							Status:        corev1.ConditionFalse,
+							LastProbeTime: metav1.NewTime(time.Now().Add(-60 * time.Second)),
+							Reason:        targetHealthReasonHealthyThresholdPending,
+							Message:       targetHealthMessageHealthyThresholdPending,
+						},
+						{
+							Type:   corev1.ContainersReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+				targetHealth: &elbv2sdk.TargetHealth{
+					State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+				},
+				targetHealthCondType: "target-health.elbv2.k8s.aws/my-tgb",
+			},
+			want: false,
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-pod",
+					UID:       "my-pod-uuid",
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   "target-health.elbv2.k8s.aws/my-tgb",
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   corev1.ContainersReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pod contains readinessGate and targetHealth is healthy - already ready",
+			env: env{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "my-pod",
+							UID:       "my-pod-uuid",
+						},
+						Spec: corev1.PodSpec{
+							ReadinessGates: []corev1.PodReadinessGate{
+								{
+									ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+								},
+							},
+						},
+						Status: corev1.PodStatus{
+							Conditions: []corev1.PodCondition{
+								{
+									Type:   "target-health.elbv2.k8s.aws/my-tgb",
+									Status: corev1.ConditionTrue,
+								},
+								{
+									Type:   corev1.ContainersReady,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+					},
+				},
+				healthyThresholdWait: true,
+				healthyThreshold:     30 * time.Second,
+			},
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+					UID: "my-pod-uuid",
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   "target-health.elbv2.k8s.aws/my-tgb",
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   corev1.ContainersReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+				targetHealth: &elbv2sdk.TargetHealth{
+					State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+				},
+				targetHealthCondType: "target-health.elbv2.k8s.aws/my-tgb",
+			},
+			want: false,
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-pod",
+					UID:       "my-pod-uuid",
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   "target-health.elbv2.k8s.aws/my-tgb",
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   corev1.ContainersReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+
+			m := &defaultResourceManager{
+				k8sClient: k8sClient,
+				logger:    &log.NullLogger{},
+			}
+			if tt.env.healthyThresholdWait {
+				healthyThresholdResolver := NewCachedHealthyThresholdResolver(nil)
+				healthyThresholdResolver.thresholdCache.Set("my-tg", tt.env.healthyThreshold, time.Minute)
+				m.healthyThresholdResolver = healthyThresholdResolver
+			}
+
+			ctx := context.Background()
+			for _, pod := range tt.env.pods {
+				err := k8sClient.Create(ctx, pod.DeepCopy())
+				assert.NoError(t, err)
+			}
+
+			got, err := m.updateTargetHealthPodConditionForPod(context.Background(), "my-tg",
+				tt.args.pod, tt.args.targetHealth, tt.args.targetHealthCondType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+
+				updatedPod := &corev1.Pod{}
+				err := m.k8sClient.Get(ctx, tt.args.pod.Key, updatedPod)
+				assert.NoError(t, err)
+
+				opts := cmp.Options{
+					equality.IgnoreFakeClientPopulatedFields(),
+					cmpopts.IgnoreTypes(metav1.Time{}),
+				}
+				assert.True(t, cmp.Equal(tt.wantPod, updatedPod, opts), "diff", cmp.Diff(tt.wantPod, updatedPod, opts))
+			}
+		})
+	}
+}
+
+func Test_defaultResourceManager_updateInstanceTargetHealthPodCondition(t *testing.T) {
+	readinessGates := []corev1.PodReadinessGate{
+		{
+			ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+		},
+	}
+	buildPod := func(name string, nodeName string, conditions []corev1.PodCondition) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				UID:       types.UID(name + "-uuid"),
+			},
+			Spec: corev1.PodSpec{
+				NodeName:       nodeName,
+				ReadinessGates: readinessGates,
+			},
+			Status: corev1.PodStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+	buildPodEndpoint := func(name string, nodeName string, conditions []corev1.PodCondition) backend.PodEndpoint {
+		return backend.PodEndpoint{
+			IP:   "192.168.1.1",
+			Port: 8080,
+			Pod: k8s.PodInfo{
+				Key:            types.NamespacedName{Namespace: "default", Name: name},
+				UID:            types.UID(name + "-uuid"),
+				NodeName:       nodeName,
+				ReadinessGates: readinessGates,
+				Conditions:     conditions,
+			},
+		}
+	}
+	podReadyCond := corev1.PodCondition{
+		Type:   corev1.PodReady,
+		Status: corev1.ConditionTrue,
+	}
+	bypassedTargetHealthCond := corev1.PodCondition{
+		Type:    "target-health.elbv2.k8s.aws/my-tgb",
+		Status:  corev1.ConditionTrue,
+		Reason:  "NoReadyEndpoints",
+		Message: "Service has no ready endpoints, nodes cannot pass health checks until pods are ready",
+	}
+	unhealthyNode1Target := nodePortEndpointAndTargetPair{
+		endpoint: backend.NodePortEndpoint{InstanceID: "i-1", Port: 30080, Node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}},
+		target: TargetInfo{
+			Target: elbv2sdk.TargetDescription{Id: awssdk.String("i-1"), Port: awssdk.Int64(30080)},
+			TargetHealth: &elbv2sdk.TargetHealth{
+				State:       awssdk.String(elbv2sdk.TargetHealthStateEnumUnhealthy),
+				Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks),
+				Description: awssdk.String("Health checks failed"),
+			},
+		},
+	}
+	node1 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	node2 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}}
+
+	type args struct {
+		matchedEndpointAndTargets []nodePortEndpointAndTargetPair
+		unmatchedEndpoints        []backend.NodePortEndpoint
+	}
+	tests := []struct {
+		name         string
+		podEndpoints []backend.PodEndpoint
+		args         args
+		want         bool
+		wantPods     []*corev1.Pod
+	}{
+		{
+			name: "pod on healthy node - pod condition is true",
+			podEndpoints: []backend.PodEndpoint{
+				buildPodEndpoint("pod-1", "node-1", nil),
+			},
+			args: args{
+				matchedEndpointAndTargets: []nodePortEndpointAndTargetPair{
+					{
+						endpoint: backend.NodePortEndpoint{InstanceID: "i-1", Port: 30080, Node: node1},
+						target: TargetInfo{
+							Target: elbv2sdk.TargetDescription{Id: awssdk.String("i-1"), Port: awssdk.Int64(30080)},
+							TargetHealth: &elbv2sdk.TargetHealth{
+								State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+							},
+						},
+					},
+				},
+			},
+			want: false,
+			wantPods: []*corev1.Pod{
+				buildPod("pod-1", "node-1", []corev1.PodCondition{
+					{
+						Type:   "target-health.elbv2.k8s.aws/my-tgb",
+						Status: corev1.ConditionTrue,
+					},
+				}),
+			},
+		},
+		{
+			name: "pods on unhealthy and registering nodes - pod condition is false, pods on unregistered nodes are bypassed",
+			podEndpoints: []backend.PodEndpoint{
+				buildPodEndpoint("pod-1", "node-1", []corev1.PodCondition{podReadyCond}),
+				buildPodEndpoint("pod-2", "node-2", nil),
+				buildPodEndpoint("pod-3", "node-3", nil),
+			},
+			args: args{
+				matchedEndpointAndTargets: []nodePortEndpointAndTargetPair{unhealthyNode1Target},
+				unmatchedEndpoints: []backend.NodePortEndpoint{
+					{InstanceID: "i-2", Port: 30080, Node: node2},
+				},
+			},
+			want: true,
+			wantPods: []*corev1.Pod{
+				buildPod("pod-1", "node-1", []corev1.PodCondition{
+					{
+						Type:    "target-health.elbv2.k8s.aws/my-tgb",
+						Status:  corev1.ConditionFalse,
+						Reason:  elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks,
+						Message: "Health checks failed",
+					},
+					podReadyCond,
+				}),
+				buildPod("pod-2", "node-2", []corev1.PodCondition{
+					{
+						Type:    "target-health.elbv2.k8s.aws/my-tgb",
+						Status:  corev1.ConditionFalse,
+						Reason:  elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress,
+						Message: "Target registration is in progress",
+					},
+				}),
+				buildPod("pod-3", "node-3", []corev1.PodCondition{
+					{
+						Type:    "target-health.elbv2.k8s.aws/my-tgb",
+						Status:  corev1.ConditionTrue,
+						Reason:  "NodeNotRegistered",
+						Message: "Node of the pod is not registered as target, traffic reaches the pod via other nodes",
+					},
+				}),
+			},
+		},
+		{
+			name: "pods only on unregistered nodes - pod condition is true without further probe",
+			podEndpoints: []backend.PodEndpoint{
+				buildPodEndpoint("pod-1", "node-3", nil),
+			},
+			args: args{
+				matchedEndpointAndTargets: []nodePortEndpointAndTargetPair{unhealthyNode1Target},
+			},
+			want: false,
+			wantPods: []*corev1.Pod{
+				buildPod("pod-1", "node-3", []corev1.PodCondition{
+					{
+						Type:    "target-health.elbv2.k8s.aws/my-tgb",
+						Status:  corev1.ConditionTrue,
+						Reason:  "NodeNotRegistered",
+						Message: "Node of the pod is not registered as target, traffic reaches the pod via other nodes",
+					},
+				}),
+			},
+		},
+		{
+			name: "service without ready endpoints - pod condition is true to bootstrap rollout",
+			podEndpoints: []backend.PodEndpoint{
+				buildPodEndpoint("pod-1", "node-1", nil),
+				buildPodEndpoint("pod-2", "node-1", nil),
+			},
+			args: args{
+				matchedEndpointAndTargets: []nodePortEndpointAndTargetPair{unhealthyNode1Target},
+			},
+			want: true,
+			wantPods: []*corev1.Pod{
+				buildPod("pod-1", "node-1", []corev1.PodCondition{bypassedTargetHealthCond}),
+				buildPod("pod-2", "node-1", []corev1.PodCondition{bypassedTargetHealthCond}),
+			},
+		},
+		{
+			name: "pod bypassed before keeps condition true until its node is healthy",
+			podEndpoints: []backend.PodEndpoint{
+				buildPodEndpoint("pod-1", "node-1", []corev1.PodCondition{podReadyCond, bypassedTargetHealthCond}),
+				buildPodEndpoint("pod-2", "node-2", []corev1.PodCondition{podReadyCond, bypassedTargetHealthCond}),
+			},
+			args: args{
+				matchedEndpointAndTargets: []nodePortEndpointAndTargetPair{
+					unhealthyNode1Target,
+					{
+						endpoint: backend.NodePortEndpoint{InstanceID: "i-2", Port: 30080, Node: node2},
+						target: TargetInfo{
+							Target: elbv2sdk.TargetDescription{Id: awssdk.String("i-2"), Port: awssdk.Int64(30080)},
+							TargetHealth: &elbv2sdk.TargetHealth{
+								State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+							},
+						},
+					},
+				},
+			},
+			want: true,
+			wantPods: []*corev1.Pod{
+				buildPod("pod-1", "node-1", []corev1.PodCondition{podReadyCond, bypassedTargetHealthCond}),
+				buildPod("pod-2", "node-2", []corev1.PodCondition{
+					podReadyCond,
+					{
+						Type:   "target-health.elbv2.k8s.aws/my-tgb",
+						Status: corev1.ConditionTrue,
+					},
+				}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			endpointResolver := mock_backend.NewMockEndpointResolver(ctrl)
+			endpointResolver.EXPECT().ResolvePodEndpoints(gomock.Any(), types.NamespacedName{Namespace: "default", Name: "my-svc"},
+				intstr.FromInt(80), gomock.Any()).Return(tt.podEndpoints, false, nil)
+
+			m := newResourceManagerForTest(t, nil, endpointResolver)
+
+			ctx := context.Background()
+			for _, endpoint := range tt.podEndpoints {
+				err := m.k8sClient.Create(ctx, buildPod(endpoint.Pod.Key.Name, endpoint.Pod.NodeName, endpoint.Pod.Conditions))
+				assert.NoError(t, err)
+			}
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-tgb",
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "my-tg",
+					ServiceRef: elbv2api.ServiceReference{
+						Name: "my-svc",
+						Port: intstr.FromInt(80),
+					},
+				},
+			}
+			got, err := m.updateInstanceTargetHealthPodCondition(ctx, tgb, tt.args.matchedEndpointAndTargets, tt.args.unmatchedEndpoints)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			opts := cmp.Options{
+				equality.IgnoreFakeClientPopulatedFields(),
+				cmpopts.IgnoreTypes(metav1.Time{}),
+			}
+			for _, wantPod := range tt.wantPods {
+				updatedPod := &corev1.Pod{}
+				err := m.k8sClient.Get(ctx, types.NamespacedName{Namespace: wantPod.Namespace, Name: wantPod.Name}, updatedPod)
+				assert.NoError(t, err)
+				assert.True(t, cmp.Equal(wantPod, updatedPod, opts), "diff", cmp.Diff(wantPod, updatedPod, opts))
+			}
+		})
+	}
+}
+
+func Test_containsTargetsInInitialState(t *testing.T) {
+	type args struct {
+		matchedEndpointAndTargets []podEndpointAndTargetPair
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "contains initial targets",
+			args: args{
+				matchedEndpointAndTargets: []podEndpointAndTargetPair{
+					{
+						target: TargetInfo{
+							TargetHealth: &elbv2sdk.TargetHealth{
+								State:       awssdk.String(elbv2sdk.TargetHealthStateEnumInitial),
+								Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress),
+								Description: awssdk.String("Target registration is in progress"),
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "contains no initial targets",
+			args: args{
+				matchedEndpointAndTargets: []podEndpointAndTargetPair{
+					{
+						target: TargetInfo{
+							TargetHealth: &elbv2sdk.TargetHealth{
+								State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := containsTargetsInInitialState(tt.args.matchedEndpointAndTargets)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_buildPodConditionPatch(t *testing.T) {
+	type args struct {
+		pod       k8s.PodInfo
+		condition corev1.PodCondition
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantPatch []byte
+		wantErr   error
+	}{
+		{
+			name: "standard case",
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+					UID: "pod-uuid",
+				},
+				condition: corev1.PodCondition{
+					Type:    "custom-condition",
+					Status:  corev1.ConditionTrue,
+					Reason:  "some-reason",
+					Message: "some-msg",
+				},
+			},
+			wantPatch: []byte(`{"metadata":{"uid":"pod-uuid"},"status":{"conditions":[{"lastProbeTime":null,"lastTransitionTime":null,"message":"some-msg","reason":"some-reason","status":"True","type":"custom-condition"}]}}`),
+		},
+		{
+			name: "existing condition - unset fields should be cleared",
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+					UID: "pod-uuid",
+					Conditions: []corev1.PodCondition{
+						{
+							Type:    "custom-condition",
+							Status:  corev1.ConditionFalse,
+							Reason:  "some-reason",
+							Message: "some-msg",
+						},
+					},
+				},
+				condition: corev1.PodCondition{
+					Type:   "custom-condition",
+					Status: corev1.ConditionTrue,
+				},
+			},
+			wantPatch: []byte(`{"metadata":{"uid":"pod-uuid"},"status":{"$setElementOrder/conditions":[{"type":"custom-condition"}],"conditions":[{"message":null,"reason":null,"status":"True","type":"custom-condition"}]}}`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildPodConditionPatch(tt.args.pod, tt.args.condition)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				gotPatch, _ := got.Data(nil)
+				assert.Equal(t, tt.wantPatch, gotPatch)
+				assert.Equal(t, types.StrategicMergePatchType, got.Type())
+			}
+		})
+	}
+}
+
+func Test_partitionNodePortEndpointsByAZs(t *testing.T) {
+	nodeA := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-a",
+			Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "us-west-2a"},
+		},
+	}
+	nodeB := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-b",
+			Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "us-west-2b"},
+		},
+	}
+	nodeWithoutZone := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-c",
+		},
+	}
+	type args struct {
+		endpoints []backend.NodePortEndpoint
+		azs       sets.String
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  []backend.NodePortEndpoint
+		want1 []backend.NodePortEndpoint
+	}{
+		{
+			name: "all endpoints inside availabilityZones",
+			args: args{
+				endpoints: []backend.NodePortEndpoint{
+					{InstanceID: "i-a", Port: 30000, Node: nodeA},
+					{InstanceID: "i-b", Port: 30000, Node: nodeB},
+				},
+				azs: sets.NewString("us-west-2a", "us-west-2b"),
+			},
+			want: []backend.NodePortEndpoint{
+				{InstanceID: "i-a", Port: 30000, Node: nodeA},
+				{InstanceID: "i-b", Port: 30000, Node: nodeB},
+			},
+			want1: nil,
+		},
+		{
+			name: "some endpoints outside availabilityZones",
+			args: args{
+				endpoints: []backend.NodePortEndpoint{
+					{InstanceID: "i-a", Port: 30000, Node: nodeA},
+					{InstanceID: "i-b", Port: 30000, Node: nodeB},
+				},
+				azs: sets.NewString("us-west-2a"),
+			},
+			want: []backend.NodePortEndpoint{
+				{InstanceID: "i-a", Port: 30000, Node: nodeA},
+			},
+			want1: []backend.NodePortEndpoint{
+				{InstanceID: "i-b", Port: 30000, Node: nodeB},
+			},
+		},
+		{
+			name: "endpoints with unknown availabilityZone are treated as inside availabilityZones",
+			args: args{
+				endpoints: []backend.NodePortEndpoint{
+					{InstanceID: "i-c", Port: 30000, Node: nodeWithoutZone},
+				},
+				azs: sets.NewString("us-west-2a"),
+			},
+			want: []backend.NodePortEndpoint{
+				{InstanceID: "i-c", Port: 30000, Node: nodeWithoutZone},
+			},
+			want1: nil,
+		},
+		{
+			name: "all endpoints are inside when availabilityZones is unknown",
+			args: args{
+				endpoints: []backend.NodePortEndpoint{
+					{InstanceID: "i-a", Port: 30000, Node: nodeA},
+					{InstanceID: "i-b", Port: 30000, Node: nodeB},
+				},
+				azs: nil,
+			},
+			want: []backend.NodePortEndpoint{
+				{InstanceID: "i-a", Port: 30000, Node: nodeA},
+				{InstanceID: "i-b", Port: 30000, Node: nodeB},
+			},
+			want1: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := partitionNodePortEndpointsByAZs(tt.args.endpoints, tt.args.azs)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+		})
+	}
+}
+
+func Test_defaultResourceManager_reconcileWithIPTargetType(t *testing.T) {
+	type registerTargetsCall struct {
+		req *elbv2sdk.RegisterTargetsInput
+	}
+	type deregisterTargetsCall struct {
+		req *elbv2sdk.DeregisterTargetsInput
+	}
+	type fields struct {
+		endpoints              []backend.PodEndpoint
+		targets                []*elbv2sdk.TargetHealthDescription
+		registerTargetsCalls   []registerTargetsCall
+		deregisterTargetsCalls []deregisterTargetsCall
+	}
+	tests := []struct {
+		name                string
+		fields              fields
+		wantDrainingTargets int32
+	}{
+		{
+			name: "no API calls when targets are unchanged",
+			fields: fields{
+				endpoints: []backend.PodEndpoint{
+					{
+						IP:   "192.168.1.1",
+						Port: 8080,
+						Pod: k8s.PodInfo{
+							Key: types.NamespacedName{Namespace: "default", Name: "pod-1"},
+						},
+					},
+				},
+				targets: []*elbv2sdk.TargetHealthDescription{
+					{
+						Target: &elbv2sdk.TargetDescription{
+							Id:   awssdk.String("192.168.1.1"),
+							Port: awssdk.Int64(8080),
+						},
+						TargetHealth: &elbv2sdk.TargetHealth{
+							State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "only register and deregister the delta",
+			fields: fields{
+				endpoints: []backend.PodEndpoint{
+					{
+						IP:   "192.168.1.1",
+						Port: 8080,
+						Pod: k8s.PodInfo{
+							Key: types.NamespacedName{Namespace: "default", Name: "pod-1"},
+						},
+					},
+					{
+						IP:   "192.168.1.2",
+						Port: 8080,
+						Pod: k8s.PodInfo{
+							Key: types.NamespacedName{Namespace: "default", Name: "pod-2"},
+						},
+					},
+				},
+				targets: []*elbv2sdk.TargetHealthDescription{
+					{
+						Target: &elbv2sdk.TargetDescription{
+							Id:   awssdk.String("192.168.1.1"),
+							Port: awssdk.Int64(8080),
+						},
+						TargetHealth: &elbv2sdk.TargetHealth{
+							State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+					{
+						Target: &elbv2sdk.TargetDescription{
+							Id:   awssdk.String("192.168.1.3"),
+							Port: awssdk.Int64(8080),
+						},
+						TargetHealth: &elbv2sdk.TargetHealth{
+							State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+				},
+				registerTargetsCalls: []registerTargetsCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+					},
+				},
+				deregisterTargetsCalls: []deregisterTargetsCall{
+					{
+						req: &elbv2sdk.DeregisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.3"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantDrainingTargets: 1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			k8sSchema := runtime.NewScheme()
-			clientgoscheme.AddToScheme(k8sSchema)
-			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-			m := &defaultResourceManager{
-				k8sClient: k8sClient,
-				logger:    &log.NullLogger{},
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{
+				TargetGroupArn: awssdk.String("my-tg"),
+			}).Return(&elbv2sdk.DescribeTargetHealthOutput{TargetHealthDescriptions: tt.fields.targets}, nil)
+			for _, call := range tt.fields.registerTargetsCalls {
+				elbv2Client.EXPECT().RegisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.RegisterTargetsOutput{}, nil)
 			}
-
-			ctx := context.Background()
-			for _, pod := range tt.env.pods {
-				err := k8sClient.Create(ctx, pod.DeepCopy())
-				assert.NoError(t, err)
+			for _, call := range tt.fields.deregisterTargetsCalls {
+				elbv2Client.EXPECT().DeregisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeregisterTargetsOutput{}, nil)
 			}
+			endpointResolver := mock_backend.NewMockEndpointResolver(ctrl)
+			endpointResolver.EXPECT().ResolvePodEndpoints(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.fields.endpoints, false, nil)
 
-			got, err := m.updateTargetHealthPodConditionForPod(context.Background(),
-				tt.args.pod, tt.args.targetHealth, tt.args.targetHealthCondType)
-			if tt.wantErr != nil {
-				assert.EqualError(t, err, tt.wantErr.Error())
+			m := newResourceManagerForTest(t, elbv2Client, endpointResolver)
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-tgb",
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "my-tg",
+					ServiceRef: elbv2api.ServiceReference{
+						Name: "my-svc",
+						Port: intstr.FromInt(80),
+					},
+				},
+			}
+			if tt.wantDrainingTargets != 0 {
+				elbv2Client.EXPECT().DescribeTargetGroupAttributesWithContext(gomock.Any(), &elbv2sdk.DescribeTargetGroupAttributesInput{
+					TargetGroupArn: awssdk.String("my-tg"),
+				}).Return(&elbv2sdk.DescribeTargetGroupAttributesOutput{}, nil)
+				assert.NoError(t, m.k8sClient.Create(context.Background(), tgb))
+			}
+			err := m.reconcileWithIPTargetType(context.Background(), tgb)
+			if tt.wantDrainingTargets != 0 {
+				var requeueNeededAfter *ctrlruntime.RequeueNeededAfter
+				assert.True(t, errors.As(err, &requeueNeededAfter))
+				assert.Equal(t, tt.wantDrainingTargets, tgb.Status.Draining.Targets)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
+				assert.Nil(t, tgb.Status.Draining)
+			}
+		})
+	}
+}
 
-				updatedPod := &corev1.Pod{}
-				err := m.k8sClient.Get(ctx, tt.args.pod.Key, updatedPod)
-				assert.NoError(t, err)
+func Test_defaultResourceManager_reconcileWithInstanceTargetType(t *testing.T) {
+	type registerTargetsCall struct {
+		req *elbv2sdk.RegisterTargetsInput
+	}
+	type deregisterTargetsCall struct {
+		req *elbv2sdk.DeregisterTargetsInput
+	}
+	type fields struct {
+		endpoints              []backend.NodePortEndpoint
+		targets                []*elbv2sdk.TargetHealthDescription
+		registerTargetsCalls   []registerTargetsCall
+		deregisterTargetsCalls []deregisterTargetsCall
+		// when specified, nodes outside these availabilityZones of LoadBalancer are skipped.
+		skipOffAZNodesWithLBAZs []string
+	}
+	nodeA := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-a",
+			Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "us-west-2a"},
+		},
+	}
+	nodeB := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-b",
+			Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "us-west-2b"},
+		},
+	}
+	tests := []struct {
+		name                string
+		fields              fields
+		wantDrainingTargets int32
+	}{
+		{
+			name: "no API calls when targets are unchanged",
+			fields: fields{
+				endpoints: []backend.NodePortEndpoint{
+					{
+						InstanceID: "i-1",
+						Port:       30080,
+					},
+				},
+				targets: []*elbv2sdk.TargetHealthDescription{
+					{
+						Target: &elbv2sdk.TargetDescription{
+							Id:   awssdk.String("i-1"),
+							Port: awssdk.Int64(30080),
+						},
+						TargetHealth: &elbv2sdk.TargetHealth{
+							State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "draining targets won't be deregistered again",
+			fields: fields{
+				endpoints: []backend.NodePortEndpoint{
+					{
+						InstanceID: "i-1",
+						Port:       30080,
+					},
+				},
+				targets: []*elbv2sdk.TargetHealthDescription{
+					{
+						Target: &elbv2sdk.TargetDescription{
+							Id:   awssdk.String("i-1"),
+							Port: awssdk.Int64(30080),
+						},
+						TargetHealth: &elbv2sdk.TargetHealth{
+							State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+					{
+						Target: &elbv2sdk.TargetDescription{
+							Id:   awssdk.String("i-2"),
+							Port: awssdk.Int64(30080),
+						},
+						TargetHealth: &elbv2sdk.TargetHealth{
+							State: awssdk.String(elbv2sdk.TargetHealthStateEnumDraining),
+						},
+					},
+				},
+			},
+			wantDrainingTargets: 1,
+		},
+		{
+			name: "only register and deregister the delta",
+			fields: fields{
+				endpoints: []backend.NodePortEndpoint{
+					{
+						InstanceID: "i-1",
+						Port:       30080,
+					},
+					{
+						InstanceID: "i-2",
+						Port:       30080,
+					},
+				},
+				targets: []*elbv2sdk.TargetHealthDescription{
+					{
+						Target: &elbv2sdk.TargetDescription{
+							Id:   awssdk.String("i-1"),
+							Port: awssdk.Int64(30080),
+						},
+						TargetHealth: &elbv2sdk.TargetHealth{
+							State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+					{
+						Target: &elbv2sdk.TargetDescription{
+							Id:   awssdk.String("i-3"),
+							Port: awssdk.Int64(30080),
+						},
+						TargetHealth: &elbv2sdk.TargetHealth{
+							State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+				},
+				registerTargetsCalls: []registerTargetsCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("i-2"),
+									Port: awssdk.Int64(30080),
+								},
+							},
+						},
+					},
+				},
+				deregisterTargetsCalls: []deregisterTargetsCall{
+					{
+						req: &elbv2sdk.DeregisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("i-3"),
+									Port: awssdk.Int64(30080),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantDrainingTargets: 1,
+		},
+		{
+			name: "nodes outside loadBalancer availabilityZones are skipped",
+			fields: fields{
+				endpoints: []backend.NodePortEndpoint{
+					{
+						InstanceID: "i-1",
+						Port:       30080,
+						Node:       nodeA,
+					},
+					{
+						InstanceID: "i-2",
+						Port:       30080,
+						Node:       nodeB,
+					},
+				},
+				registerTargetsCalls: []registerTargetsCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("i-1"),
+									Port: awssdk.Int64(30080),
+								},
+							},
+						},
+					},
+				},
+				skipOffAZNodesWithLBAZs: []string{"us-west-2a"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-				opts := cmp.Options{
-					equality.IgnoreFakeClientPopulatedFields(),
-					cmpopts.IgnoreTypes(metav1.Time{}),
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			if tt.fields.skipOffAZNodesWithLBAZs != nil {
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{
+					TargetGroupArns: awssdk.StringSlice([]string{"my-tg"}),
+				}).Return([]*elbv2sdk.TargetGroup{{TargetGroupArn: awssdk.String("my-tg"), LoadBalancerArns: awssdk.StringSlice([]string{"my-lb"})}}, nil)
+				var lbAZs []*elbv2sdk.AvailabilityZone
+				for _, az := range tt.fields.skipOffAZNodesWithLBAZs {
+					lbAZs = append(lbAZs, &elbv2sdk.AvailabilityZone{ZoneName: awssdk.String(az)})
 				}
-				assert.True(t, cmp.Equal(tt.wantPod, updatedPod, opts), "diff", cmp.Diff(tt.wantPod, updatedPod, opts))
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), &elbv2sdk.DescribeLoadBalancersInput{
+					LoadBalancerArns: awssdk.StringSlice([]string{"my-lb"}),
+				}).Return([]*elbv2sdk.LoadBalancer{{AvailabilityZones: lbAZs}}, nil)
+			}
+			elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{
+				TargetGroupArn: awssdk.String("my-tg"),
+			}).Return(&elbv2sdk.DescribeTargetHealthOutput{TargetHealthDescriptions: tt.fields.targets}, nil)
+			for _, call := range tt.fields.registerTargetsCalls {
+				elbv2Client.EXPECT().RegisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.RegisterTargetsOutput{}, nil)
+			}
+			for _, call := range tt.fields.deregisterTargetsCalls {
+				elbv2Client.EXPECT().DeregisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeregisterTargetsOutput{}, nil)
+			}
+			endpointResolver := mock_backend.NewMockEndpointResolver(ctrl)
+			endpointResolver.EXPECT().ResolveNodePortEndpoints(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.fields.endpoints, nil)
+
+			m := newResourceManagerForTest(t, elbv2Client, endpointResolver)
+			m.skipOffAZNodes = tt.fields.skipOffAZNodesWithLBAZs != nil
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-tgb",
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "my-tg",
+					ServiceRef: elbv2api.ServiceReference{
+						Name: "my-svc",
+						Port: intstr.FromInt(80),
+					},
+				},
+			}
+			if tt.wantDrainingTargets != 0 {
+				elbv2Client.EXPECT().DescribeTargetGroupAttributesWithContext(gomock.Any(), &elbv2sdk.DescribeTargetGroupAttributesInput{
+					TargetGroupArn: awssdk.String("my-tg"),
+				}).Return(&elbv2sdk.DescribeTargetGroupAttributesOutput{}, nil)
+				assert.NoError(t, m.k8sClient.Create(context.Background(), tgb))
+			}
+			err := m.reconcileWithInstanceTargetType(context.Background(), tgb)
+			if tt.wantDrainingTargets != 0 {
+				var requeueNeededAfter *ctrlruntime.RequeueNeededAfter
+				assert.True(t, errors.As(err, &requeueNeededAfter))
+				assert.Equal(t, tt.wantDrainingTargets, tgb.Status.Draining.Targets)
+			} else {
+				assert.NoError(t, err)
+				assert.Nil(t, tgb.Status.Draining)
 			}
 		})
 	}
 }
 
-func Test_containsTargetsInInitialState(t *testing.T) {
-	type args struct {
-		matchedEndpointAndTargets []podEndpointAndTargetPair
+func Test_defaultResourceManager_cleanupTargets(t *testing.T) {
+	type deregisterTargetsCall struct {
+		req *elbv2sdk.DeregisterTargetsInput
 	}
 	tests := []struct {
-		name string
-		args args
-		want bool
+		name                   string
+		targets                []*elbv2sdk.TargetHealthDescription
+		retainedTargets        []elbv2sdk.TargetDescription
+		deregisterTargetsCalls []deregisterTargetsCall
 	}{
 		{
-			name: "contains initial targets",
-			args: args{
-				matchedEndpointAndTargets: []podEndpointAndTargetPair{
-					{
-						target: TargetInfo{
-							TargetHealth: &elbv2sdk.TargetHealth{
-								State:       awssdk.String(elbv2sdk.TargetHealthStateEnumInitial),
-								Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress),
-								Description: awssdk.String("Target registration is in progress"),
+			name:    "no API calls when there are no targets",
+			targets: nil,
+		},
+		{
+			name: "no API calls when all targets are draining",
+			targets: []*elbv2sdk.TargetHealthDescription{
+				{
+					Target: &elbv2sdk.TargetDescription{
+						Id:   awssdk.String("192.168.1.1"),
+						Port: awssdk.Int64(8080),
+					},
+					TargetHealth: &elbv2sdk.TargetHealth{
+						State: awssdk.String(elbv2sdk.TargetHealthStateEnumDraining),
+					},
+				},
+			},
+		},
+		{
+			name: "only deregister targets that are not draining",
+			targets: []*elbv2sdk.TargetHealthDescription{
+				{
+					Target: &elbv2sdk.TargetDescription{
+						Id:   awssdk.String("192.168.1.1"),
+						Port: awssdk.Int64(8080),
+					},
+					TargetHealth: &elbv2sdk.TargetHealth{
+						State: awssdk.String(elbv2sdk.TargetHealthStateEnumDraining),
+					},
+				},
+				{
+					Target: &elbv2sdk.TargetDescription{
+						Id:   awssdk.String("192.168.1.2"),
+						Port: awssdk.Int64(8080),
+					},
+					TargetHealth: &elbv2sdk.TargetHealth{
+						State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+					},
+				},
+			},
+			deregisterTargetsCalls: []deregisterTargetsCall{
+				{
+					req: &elbv2sdk.DeregisterTargetsInput{
+						TargetGroupArn: awssdk.String("my-tg"),
+						Targets: []*elbv2sdk.TargetDescription{
+							{
+								Id:   awssdk.String("192.168.1.2"),
+								Port: awssdk.Int64(8080),
 							},
 						},
 					},
 				},
 			},
-			want: true,
 		},
 		{
-			name: "contains no initial targets",
-			args: args{
-				matchedEndpointAndTargets: []podEndpointAndTargetPair{
-					{
-						target: TargetInfo{
-							TargetHealth: &elbv2sdk.TargetHealth{
-								State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+			name: "don't deregister retained targets",
+			targets: []*elbv2sdk.TargetHealthDescription{
+				{
+					Target: &elbv2sdk.TargetDescription{
+						Id:   awssdk.String("192.168.1.1"),
+						Port: awssdk.Int64(8080),
+					},
+					TargetHealth: &elbv2sdk.TargetHealth{
+						State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+					},
+				},
+				{
+					Target: &elbv2sdk.TargetDescription{
+						Id:   awssdk.String("192.168.1.2"),
+						Port: awssdk.Int64(8080),
+					},
+					TargetHealth: &elbv2sdk.TargetHealth{
+						State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+					},
+				},
+			},
+			retainedTargets: []elbv2sdk.TargetDescription{
+				{
+					Id:   awssdk.String("192.168.1.1"),
+					Port: awssdk.Int64(8080),
+				},
+			},
+			deregisterTargetsCalls: []deregisterTargetsCall{
+				{
+					req: &elbv2sdk.DeregisterTargetsInput{
+						TargetGroupArn: awssdk.String("my-tg"),
+						Targets: []*elbv2sdk.TargetDescription{
+							{
+								Id:   awssdk.String("192.168.1.2"),
+								Port: awssdk.Int64(8080),
 							},
 						},
 					},
 				},
 			},
-			want: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := containsTargetsInInitialState(tt.args.matchedEndpointAndTargets)
-			assert.Equal(t, tt.want, got)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{
+				TargetGroupArn: awssdk.String("my-tg"),
+			}).Return(&elbv2sdk.DescribeTargetHealthOutput{TargetHealthDescriptions: tt.targets}, nil)
+			for _, call := range tt.deregisterTargetsCalls {
+				elbv2Client.EXPECT().DeregisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeregisterTargetsOutput{}, nil)
+			}
+			m := &defaultResourceManager{
+				targetsManager: NewCachedTargetsManager(elbv2Client, 0, nil, &log.NullLogger{}),
+				logger:         &log.NullLogger{},
+			}
+			tgb := &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "my-tg",
+				},
+			}
+			err := m.cleanupTargets(context.Background(), tgb, tt.retainedTargets)
+			assert.NoError(t, err)
 		})
 	}
 }
 
-func Test_buildPodConditionPatch(t *testing.T) {
-	type args struct {
-		pod       k8s.PodInfo
-		condition corev1.PodCondition
+// newResourceManagerForTest constructs defaultResourceManager that only talks to specified elbv2Client and endpointResolver.
+func newResourceManagerForTest(t *testing.T, elbv2Client services.ELBV2, endpointResolver backend.EndpointResolver) *defaultResourceManager {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+
+	networkingManager := NewDefaultNetworkingManager(k8sClient, record.NewFakeRecorder(10), nil, nil, nil, nil, "vpc-1", "cluster-1", &log.NullLogger{})
+	// skip discovery of endpoint securityGroups from AWS.
+	networkingManager.trackedEndpointSGsInitialized = true
+	return &defaultResourceManager{
+		k8sClient:         k8sClient,
+		targetsManager:    NewCachedTargetsManager(elbv2Client, 0, nil, &log.NullLogger{}),
+		endpointResolver:  endpointResolver,
+		networkingManager: networkingManager,
+		azResolver:        NewCachedAvailabilityZoneResolver(elbv2Client),
+		eventRecorder:     record.NewFakeRecorder(10),
+		logger:            &log.NullLogger{},
+
+		deregistrationDelayResolver: NewCachedDeregistrationDelayResolver(elbv2Client),
+		drainingTargetsTracker:      newDrainingTargetsTracker(),
+		targetHealthRequeueDuration: defaultTargetHealthRequeueDuration,
 	}
+}
+
+func Test_defaultResourceManager_updateDrainingStatus(t *testing.T) {
+	drainingSince := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
 	tests := []struct {
-		name      string
-		args      args
-		wantPatch []byte
-		wantErr   error
+		name               string
+		trackedTargetIDs   []string
+		drainingTargets    []TargetInfo
+		status             elbv2api.TargetGroupBindingStatus
+		wantStatus         elbv2api.TargetGroupBindingStatus
+		wantCompletionTime time.Time
+		wantEvents         []string
 	}{
 		{
-			name: "standard case",
-			args: args{
-				pod: k8s.PodInfo{
-					Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
-					UID: "pod-uuid",
+			name: "no draining targets",
+		},
+		{
+			name:             "draining targets are reported",
+			trackedTargetIDs: []string{"i-1:30080"},
+			drainingTargets: []TargetInfo{
+				{
+					Target: elbv2sdk.TargetDescription{Id: awssdk.String("i-1"), Port: awssdk.Int64(30080)},
 				},
-				condition: corev1.PodCondition{
-					Type:    "custom-condition",
-					Status:  corev1.ConditionTrue,
-					Reason:  "some-reason",
-					Message: "some-msg",
+			},
+			wantStatus: elbv2api.TargetGroupBindingStatus{
+				Draining: &elbv2api.TargetGroupBindingDrainingStatus{
+					Targets:                 1,
+					EstimatedCompletionTime: &metav1.Time{Time: drainingSince.Add(300 * time.Second)},
 				},
 			},
-			wantPatch: []byte(`{"metadata":{"uid":"pod-uuid"},"status":{"conditions":[{"lastProbeTime":null,"lastTransitionTime":null,"message":"some-msg","reason":"some-reason","status":"True","type":"custom-condition"}]}}`),
+			wantCompletionTime: drainingSince.Add(300 * time.Second),
+			wantEvents:         []string{"Normal DrainingTargets Draining 1 targets, estimated completion at 2021-01-01T00:05:00Z"},
+		},
+		{
+			name: "draining status is cleared when draining completes",
+			status: elbv2api.TargetGroupBindingStatus{
+				Draining: &elbv2api.TargetGroupBindingDrainingStatus{
+					Targets:                 1,
+					EstimatedCompletionTime: &metav1.Time{Time: drainingSince.Add(300 * time.Second)},
+				},
+			},
+			wantStatus: elbv2api.TargetGroupBindingStatus{},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := buildPodConditionPatch(tt.args.pod, tt.args.condition)
-			if tt.wantErr != nil {
-				assert.EqualError(t, err, tt.wantErr.Error())
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeTargetGroupAttributesWithContext(gomock.Any(), &elbv2sdk.DescribeTargetGroupAttributesInput{
+				TargetGroupArn: awssdk.String("my-tg"),
+			}).Return(&elbv2sdk.DescribeTargetGroupAttributesOutput{}, nil).AnyTimes()
+			m := newResourceManagerForTest(t, elbv2Client, nil)
+			eventRecorder := record.NewFakeRecorder(10)
+			m.eventRecorder = eventRecorder
+			m.drainingTargetsTracker.track("my-tg", tt.trackedTargetIDs, drainingSince)
+
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-tgb",
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "my-tg",
+				},
+				Status: tt.status,
+			}
+			assert.NoError(t, m.k8sClient.Create(context.Background(), tgb))
+			gotCompletionTime, err := m.updateDrainingStatus(context.Background(), tgb, tt.drainingTargets)
+			assert.NoError(t, err)
+			assert.True(t, tt.wantCompletionTime.Equal(gotCompletionTime))
+			if tt.wantStatus.Draining == nil {
+				assert.Nil(t, tgb.Status.Draining)
 			} else {
-				assert.NoError(t, err)
-				gotPatch, _ := got.Data(nil)
-				assert.Equal(t, tt.wantPatch, gotPatch)
-				assert.Equal(t, types.StrategicMergePatchType, got.Type())
+				assert.Equal(t, tt.wantStatus.Draining.Targets, tgb.Status.Draining.Targets)
+				assert.True(t, tt.wantStatus.Draining.EstimatedCompletionTime.Equal(tgb.Status.Draining.EstimatedCompletionTime))
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
 			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
 		})
 	}
 }
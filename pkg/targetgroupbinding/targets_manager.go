@@ -2,10 +2,15 @@ package targetgroupbinding
 
 import (
 	"context"
+	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sync"
 	"time"
@@ -13,10 +18,40 @@ import (
 
 const (
 	defaultTargetsCacheTTL            = 5 * time.Minute
+	defaultTargetHealthCacheTTL       = 5 * time.Second
 	defaultRegisterTargetsChunkSize   = 200
 	defaultDeregisterTargetsChunkSize = 200
+	defaultRegisterTargetsRetryPeriod = 100 * time.Millisecond
 )
 
+// RegisterTargetsError is returned when some targets failed to be registered into TargetGroup,
+// while the other targets are registered successfully.
+type RegisterTargetsError struct {
+	// the ARN of TargetGroup.
+	TargetGroupARN string
+	// the targets failed to be registered.
+	FailedTargets []elbv2sdk.TargetDescription
+	// the last error encountered when registering failed targets.
+	Err error
+}
+
+func (e *RegisterTargetsError) Error() string {
+	return fmt.Sprintf("failed to register %d targets into targetGroup %v: %v", len(e.FailedTargets), e.TargetGroupARN, e.Err)
+}
+
+func (e *RegisterTargetsError) Unwrap() error {
+	return e.Err
+}
+
+// FailedTargetIDs returns the unique IDs of targets failed to be registered.
+func (e *RegisterTargetsError) FailedTargetIDs() []string {
+	targetIDs := make([]string, 0, len(e.FailedTargets))
+	for _, target := range e.FailedTargets {
+		targetIDs = append(targetIDs, UniqueIDForTargetDescription(target))
+	}
+	return targetIDs
+}
+
 // TargetsManager is an abstraction around ELBV2's targets API.
 type TargetsManager interface {
 	// Register Targets into TargetGroup.
@@ -30,14 +65,30 @@ type TargetsManager interface {
 }
 
 // NewCachedTargetsManager constructs new cachedTargetsManager
-func NewCachedTargetsManager(elbv2Client services.ELBV2, logger logr.Logger) *cachedTargetsManager {
+func NewCachedTargetsManager(elbv2Client services.ELBV2, registerTargetsMaxRetries int, metricsRegisterer prometheus.Registerer, logger logr.Logger) *cachedTargetsManager {
+	var instruments *targetsManagerInstruments
+	if metricsRegisterer != nil {
+		var err error
+		instruments, err = newTargetsManagerInstruments(metricsRegisterer)
+		if err != nil {
+			logger.Error(err, "failed to register targetsManager metrics")
+		}
+	}
 	return &cachedTargetsManager{
 		elbv2Client:                elbv2Client,
 		targetsCache:               cache.NewExpiring(),
 		targetsCacheTTL:            defaultTargetsCacheTTL,
+		targetHealthCacheTTL:       defaultTargetHealthCacheTTL,
 		registerTargetsChunkSize:   defaultRegisterTargetsChunkSize,
 		deregisterTargetsChunkSize: defaultDeregisterTargetsChunkSize,
-		logger:                     logger,
+		registerTargetsRetryBackoff: wait.Backoff{
+			Duration: defaultRegisterTargetsRetryPeriod,
+			Factor:   2.0,
+			Jitter:   0.1,
+			Steps:    registerTargetsMaxRetries + 1,
+		},
+		instruments: instruments,
+		logger:      logger,
 	}
 }
 
@@ -47,6 +98,7 @@ var _ TargetsManager = &cachedTargetsManager{}
 // Targets for each TargetGroup will be refreshed per targetsCacheTTL.
 // When list Targets with RefreshTargets list Option set,
 // only targets with ongoing TargetHealth(unknown/initial/draining) TargetHealth will be refreshed.
+// TargetHealth refreshed within targetHealthCacheTTL will be shared across TargetGroupBindings' reconciles without calling AWS.
 type cachedTargetsManager struct {
 	elbv2Client services.ELBV2
 
@@ -58,13 +110,20 @@ type cachedTargetsManager struct {
 	targetsCacheTTL time.Duration
 	// targetsCacheMutex protects targetsCache
 	targetsCacheMutex sync.RWMutex
+	// TTL for each targetGroup's TargetHealth, within which we won't refresh TargetHealth from AWS.
+	targetHealthCacheTTL time.Duration
 
 	// chunk size for registerTargets API call.
 	registerTargetsChunkSize int
 	// chunk size for deregisterTargets API call.
 	deregisterTargetsChunkSize int
+	// backoff for registering each target individually after a registerTargets API call failed.
+	// Steps is the total attempts for each target, including the initial one.
+	registerTargetsRetryBackoff wait.Backoff
 
-	logger logr.Logger
+	// instruments for metrics, it's optional.
+	instruments *targetsManagerInstruments
+	logger      logr.Logger
 }
 
 // cache entry for targetsCache
@@ -73,9 +132,14 @@ type targetsCacheItem struct {
 	mutex sync.RWMutex
 	// targets is the targets for TargetGroup
 	targets []TargetInfo
+	// lastRefreshTime is the last time that targets' TargetHealth is refreshed from AWS.
+	// a zero value means a refresh is needed.
+	lastRefreshTime time.Time
 }
 
 func (m *cachedTargetsManager) RegisterTargets(ctx context.Context, tgARN string, targets []elbv2sdk.TargetDescription) error {
+	var failedTargets []elbv2sdk.TargetDescription
+	var lastErr error
 	targetsChunks := chunkTargetDescriptions(targets, m.registerTargetsChunkSize)
 	for _, targetsChunk := range targetsChunks {
 		req := &elbv2sdk.RegisterTargetsInput{
@@ -87,15 +151,86 @@ func (m *cachedTargetsManager) RegisterTargets(ctx context.Context, tgARN string
 			"targets", targetsChunk)
 		_, err := m.elbv2Client.RegisterTargetsWithContext(ctx, req)
 		if err != nil {
-			return err
+			// the whole registerTargets call fails if any target cannot be registered,
+			// register targets individually so that the other targets can still be registered.
+			// other errors aren't specific to targets, and registering targets individually won't help.
+			if !isELBV2PerTargetError(err) {
+				return err
+			}
+			m.logger.Info("failed to register targets, registering targets individually",
+				"arn", tgARN,
+				"error", err.Error())
+			chunkFailedTargets, err := m.registerTargetsIndividually(ctx, tgARN, targetsChunk)
+			if len(chunkFailedTargets) != 0 {
+				failedTargets = append(failedTargets, chunkFailedTargets...)
+				lastErr = err
+			}
+			continue
 		}
 		m.logger.Info("registered targets",
 			"arn", tgARN)
 		m.recordSuccessfulRegisterTargetsOperation(tgARN, targetsChunk)
 	}
+	if len(failedTargets) != 0 {
+		return &RegisterTargetsError{
+			TargetGroupARN: tgARN,
+			FailedTargets:  failedTargets,
+			Err:            lastErr,
+		}
+	}
 	return nil
 }
 
+// registerTargetsIndividually registers each target with retries, and returns the targets failed to be registered with the last error.
+func (m *cachedTargetsManager) registerTargetsIndividually(ctx context.Context, tgARN string, targets []elbv2sdk.TargetDescription) ([]elbv2sdk.TargetDescription, error) {
+	var failedTargets []elbv2sdk.TargetDescription
+	var lastErr error
+	for _, target := range targets {
+		if err := m.registerTargetWithRetry(ctx, tgARN, target); err != nil {
+			m.logger.Info("failed to register target",
+				"arn", tgARN,
+				"target", target,
+				"error", err.Error())
+			failedTargets = append(failedTargets, target)
+			lastErr = err
+			continue
+		}
+		m.recordSuccessfulRegisterTargetsOperation(tgARN, []elbv2sdk.TargetDescription{target})
+	}
+	if len(failedTargets) != len(targets) {
+		m.logger.Info("registered targets",
+			"arn", tgARN,
+			"failedCount", len(failedTargets))
+	}
+	return failedTargets, lastErr
+}
+
+// registerTargetWithRetry registers single target, retries with backoff unless the target cannot be registered.
+// the backoff is interrupted once ctx is done.
+func (m *cachedTargetsManager) registerTargetWithRetry(ctx context.Context, tgARN string, target elbv2sdk.TargetDescription) error {
+	req := &elbv2sdk.RegisterTargetsInput{
+		TargetGroupArn: aws.String(tgARN),
+		Targets:        pointerizeTargetDescriptions([]elbv2sdk.TargetDescription{target}),
+	}
+	backoff := m.registerTargetsRetryBackoff
+	for {
+		_, err := m.elbv2Client.RegisterTargetsWithContext(ctx, req)
+		if err == nil || isELBV2PerTargetError(err) || isELBV2TargetGroupNotFoundError(err) {
+			return err
+		}
+		if backoff.Steps <= 1 {
+			return err
+		}
+		timer := time.NewTimer(backoff.Step())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
 func (m *cachedTargetsManager) DeregisterTargets(ctx context.Context, tgARN string, targets []elbv2sdk.TargetDescription) error {
 	targetsChunks := chunkTargetDescriptions(targets, m.deregisterTargetsChunkSize)
 	for _, targetsChunk := range targetsChunks {
@@ -125,21 +260,29 @@ func (m *cachedTargetsManager) ListTargets(ctx context.Context, tgARN string) ([
 		targetsCacheItem := rawTargetsCacheItem.(*targetsCacheItem)
 		targetsCacheItem.mutex.Lock()
 		defer targetsCacheItem.mutex.Unlock()
+		if !targetsCacheItem.lastRefreshTime.IsZero() && time.Since(targetsCacheItem.lastRefreshTime) < m.targetHealthCacheTTL {
+			m.instruments.observeTargetHealthCacheLookup(true)
+			return cloneTargetInfoSlice(targetsCacheItem.targets), nil
+		}
+		m.instruments.observeTargetHealthCacheLookup(false)
 		refreshedTargets, err := m.refreshUnhealthyTargets(ctx, tgARN, targetsCacheItem.targets)
 		if err != nil {
 			return nil, err
 		}
 		targetsCacheItem.targets = refreshedTargets
+		targetsCacheItem.lastRefreshTime = time.Now()
 		return cloneTargetInfoSlice(refreshedTargets), nil
 	}
 
+	m.instruments.observeTargetHealthCacheLookup(false)
 	refreshedTargets, err := m.refreshAllTargets(ctx, tgARN)
 	if err != nil {
 		return nil, err
 	}
 	targetsCacheItem := &targetsCacheItem{
-		mutex:   sync.RWMutex{},
-		targets: refreshedTargets,
+		mutex:           sync.RWMutex{},
+		targets:         refreshedTargets,
+		lastRefreshTime: time.Now(),
 	}
 	m.targetsCache.Set(tgARN, targetsCacheItem, m.targetsCacheTTL)
 	return cloneTargetInfoSlice(refreshedTargets), nil
@@ -224,6 +367,8 @@ func (m *cachedTargetsManager) recordSuccessfulRegisterTargetsOperation(tgARN st
 	targetsCacheItem := rawTargetsCacheItem.(*targetsCacheItem)
 	targetsCacheItem.mutex.Lock()
 	defer targetsCacheItem.mutex.Unlock()
+	// TargetHealth for these targets are unknown now, so we need a refresh on next lookup.
+	targetsCacheItem.lastRefreshTime = time.Time{}
 	for i := range targetsCacheItem.targets {
 		cachedTargetUniqueID := UniqueIDForTargetDescription(targetsCacheItem.targets[i].Target)
 		if _, ok := targetsByUniqueID[cachedTargetUniqueID]; ok {
@@ -257,6 +402,8 @@ func (m *cachedTargetsManager) recordSuccessfulDeregisterTargetsOperation(tgARN
 	targetsCacheItem := rawTargetsCacheItem.(*targetsCacheItem)
 	targetsCacheItem.mutex.Lock()
 	defer targetsCacheItem.mutex.Unlock()
+	// TargetHealth for these targets are unknown now, so we need a refresh on next lookup.
+	targetsCacheItem.lastRefreshTime = time.Time{}
 	for i := range targetsCacheItem.targets {
 		cachedTargetUniqueID := UniqueIDForTargetDescription(targetsCacheItem.targets[i].Target)
 		if _, ok := targetsByUniqueID[cachedTargetUniqueID]; ok {
@@ -296,3 +443,15 @@ func pointerizeTargetDescriptions(targets []elbv2sdk.TargetDescription) []*elbv2
 func cloneTargetInfoSlice(targets []TargetInfo) []TargetInfo {
 	return append(targets[:0:0], targets...)
 }
+
+// isELBV2PerTargetError checks whether the error is caused by specific targets within the registerTargets call.
+func isELBV2PerTargetError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		switch awsErr.Code() {
+		case elbv2sdk.ErrCodeInvalidTargetException, elbv2sdk.ErrCodeTooManyRegistrationsForTargetIdException:
+			return true
+		}
+	}
+	return false
+}
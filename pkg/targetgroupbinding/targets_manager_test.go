@@ -3,10 +3,15 @@ package targetgroupbinding
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/wait"
 	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sync"
@@ -24,17 +29,21 @@ func Test_cachedTargetsManager_RegisterTargets(t *testing.T) {
 	type fields struct {
 		registerTargetsWithContextCalls []registerTargetsWithContextCall
 		targetsCache                    map[string][]TargetInfo
+		registerTargetsMaxAttempts      int
+		registerTargetsRetryPeriod      time.Duration
+		ctxCanceled                     bool
 	}
 	type args struct {
 		tgARN   string
 		targets []elbv2sdk.TargetDescription
 	}
 	tests := []struct {
-		name             string
-		fields           fields
-		args             args
-		wantTargetsCache map[string][]TargetInfo
-		wantErr          error
+		name                string
+		fields              fields
+		args                args
+		wantTargetsCache    map[string][]TargetInfo
+		wantFailedTargetIDs []string
+		wantErr             error
 	}{
 		{
 			name: "register targets and targets for TargetGroup already exists in cache",
@@ -255,6 +264,330 @@ func Test_cachedTargetsManager_RegisterTargets(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "register targets individually when some targets are invalid",
+			fields: fields{
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+								{
+									Id:   awssdk.String("192.168.1.3"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New(elbv2sdk.ErrCodeInvalidTargetException, "target is invalid", nil),
+					},
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						resp: &elbv2sdk.RegisterTargetsOutput{},
+					},
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.3"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New(elbv2sdk.ErrCodeInvalidTargetException, "target is invalid", nil),
+					},
+				},
+				targetsCache: map[string][]TargetInfo{
+					"my-tg": nil,
+				},
+				registerTargetsMaxAttempts: 3,
+			},
+			args: args{
+				tgARN: "my-tg",
+				targets: []elbv2sdk.TargetDescription{
+					{
+						Id:   awssdk.String("192.168.1.2"),
+						Port: awssdk.Int64(8080),
+					},
+					{
+						Id:   awssdk.String("192.168.1.3"),
+						Port: awssdk.Int64(8080),
+					},
+				},
+			},
+			wantTargetsCache: map[string][]TargetInfo{
+				"my-tg": {
+					{
+						Target: elbv2sdk.TargetDescription{
+							Id:   awssdk.String("192.168.1.2"),
+							Port: awssdk.Int64(8080),
+						},
+						TargetHealth: nil,
+					},
+				},
+			},
+			wantFailedTargetIDs: []string{"192.168.1.3:8080"},
+			wantErr:             errors.New("failed to register 1 targets into targetGroup my-tg: InvalidTarget: target is invalid"),
+		},
+		{
+			name: "register targets individually and retry on transient errors",
+			fields: fields{
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New(elbv2sdk.ErrCodeInvalidTargetException, "target is invalid", nil),
+					},
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New("Throttling", "rate exceeded", nil),
+					},
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						resp: &elbv2sdk.RegisterTargetsOutput{},
+					},
+				},
+				targetsCache: map[string][]TargetInfo{
+					"my-tg": nil,
+				},
+				registerTargetsMaxAttempts: 3,
+			},
+			args: args{
+				tgARN: "my-tg",
+				targets: []elbv2sdk.TargetDescription{
+					{
+						Id:   awssdk.String("192.168.1.2"),
+						Port: awssdk.Int64(8080),
+					},
+				},
+			},
+			wantTargetsCache: map[string][]TargetInfo{
+				"my-tg": {
+					{
+						Target: elbv2sdk.TargetDescription{
+							Id:   awssdk.String("192.168.1.2"),
+							Port: awssdk.Int64(8080),
+						},
+						TargetHealth: nil,
+					},
+				},
+			},
+		},
+		{
+			name: "register targets individually and retries exhausted",
+			fields: fields{
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New(elbv2sdk.ErrCodeTooManyRegistrationsForTargetIdException, "too many registrations", nil),
+					},
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New("Throttling", "rate exceeded", nil),
+					},
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New("Throttling", "rate exceeded", nil),
+					},
+				},
+				targetsCache: map[string][]TargetInfo{
+					"my-tg": nil,
+				},
+				registerTargetsMaxAttempts: 2,
+			},
+			args: args{
+				tgARN: "my-tg",
+				targets: []elbv2sdk.TargetDescription{
+					{
+						Id:   awssdk.String("192.168.1.2"),
+						Port: awssdk.Int64(8080),
+					},
+				},
+			},
+			wantTargetsCache: map[string][]TargetInfo{
+				"my-tg": nil,
+			},
+			wantFailedTargetIDs: []string{"192.168.1.2:8080"},
+			wantErr:             errors.New("failed to register 1 targets into targetGroup my-tg: Throttling: rate exceeded"),
+		},
+		{
+			name: "register targets individually and stop retrying once context is done",
+			fields: fields{
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New(elbv2sdk.ErrCodeInvalidTargetException, "target is invalid", nil),
+					},
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New("Throttling", "rate exceeded", nil),
+					},
+				},
+				targetsCache: map[string][]TargetInfo{
+					"my-tg": nil,
+				},
+				registerTargetsMaxAttempts: 3,
+				registerTargetsRetryPeriod: time.Hour,
+				ctxCanceled:                true,
+			},
+			args: args{
+				tgARN: "my-tg",
+				targets: []elbv2sdk.TargetDescription{
+					{
+						Id:   awssdk.String("192.168.1.2"),
+						Port: awssdk.Int64(8080),
+					},
+				},
+			},
+			wantTargetsCache: map[string][]TargetInfo{
+				"my-tg": nil,
+			},
+			wantFailedTargetIDs: []string{"192.168.1.2:8080"},
+			wantErr:             errors.New("failed to register 1 targets into targetGroup my-tg: Throttling: rate exceeded"),
+		},
+		{
+			name: "register targets fails without registering targets individually when error isn't specific to targets",
+			fields: fields{
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+								{
+									Id:   awssdk.String("192.168.1.3"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New("Throttling", "rate exceeded", nil),
+					},
+				},
+				registerTargetsMaxAttempts: 3,
+			},
+			args: args{
+				tgARN: "my-tg",
+				targets: []elbv2sdk.TargetDescription{
+					{
+						Id:   awssdk.String("192.168.1.2"),
+						Port: awssdk.Int64(8080),
+					},
+					{
+						Id:   awssdk.String("192.168.1.3"),
+						Port: awssdk.Int64(8080),
+					},
+				},
+			},
+			wantErr: errors.New("Throttling: rate exceeded"),
+		},
+		{
+			name: "register targets when targetGroup not found",
+			fields: fields{
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.2"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New(elbv2sdk.ErrCodeTargetGroupNotFoundException, "targetGroup not found", nil),
+					},
+				},
+				registerTargetsMaxAttempts: 3,
+			},
+			args: args{
+				tgARN: "my-tg",
+				targets: []elbv2sdk.TargetDescription{
+					{
+						Id:   awssdk.String("192.168.1.2"),
+						Port: awssdk.Int64(8080),
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupNotFound: targetGroup not found"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -273,20 +606,39 @@ func Test_cachedTargetsManager_RegisterTargets(t *testing.T) {
 					targets: targets,
 				}, targetsCacheTTL)
 			}
+			registerTargetsRetryPeriod := time.Millisecond
+			if tt.fields.registerTargetsRetryPeriod != 0 {
+				registerTargetsRetryPeriod = tt.fields.registerTargetsRetryPeriod
+			}
 			m := cachedTargetsManager{
 				elbv2Client:              elbv2Client,
 				targetsCache:             targetsCache,
 				targetsCacheTTL:          targetsCacheTTL,
 				registerTargetsChunkSize: 2,
-				logger:                   log.Log,
+				registerTargetsRetryBackoff: wait.Backoff{
+					Duration: registerTargetsRetryPeriod,
+					Steps:    tt.fields.registerTargetsMaxAttempts,
+				},
+				logger: log.Log,
 			}
 
-			ctx := context.Background()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.fields.ctxCanceled {
+				cancel()
+			}
 			err := m.RegisterTargets(ctx, tt.args.tgARN, tt.args.targets)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
+				if tt.wantFailedTargetIDs != nil {
+					var registerTargetsErr *RegisterTargetsError
+					assert.True(t, errors.As(err, &registerTargetsErr))
+					assert.Equal(t, tt.wantFailedTargetIDs, registerTargetsErr.FailedTargetIDs())
+				}
 			} else {
 				assert.NoError(t, err)
+			}
+			if tt.wantErr == nil || tt.wantFailedTargetIDs != nil {
 				assert.Equal(t, len(tt.wantTargetsCache), targetsCache.Len())
 				for tgARN, targets := range tt.wantTargetsCache {
 					rawTargetsCacheItem, exists := targetsCache.Get(tgARN)
@@ -2121,3 +2473,91 @@ func Test_cloneTargetInfoSlice(t *testing.T) {
 		})
 	}
 }
+
+func Test_cachedTargetsManager_ListTargets_targetHealthCache(t *testing.T) {
+	targetHealthDescriptions := []*elbv2sdk.TargetHealthDescription{
+		{
+			Target: &elbv2sdk.TargetDescription{
+				Id:   awssdk.String("192.168.1.1"),
+				Port: awssdk.Int64(8080),
+			},
+			TargetHealth: &elbv2sdk.TargetHealth{
+				State: awssdk.String(elbv2sdk.TargetHealthStateEnumInitial),
+			},
+		},
+	}
+	wantTargets := []TargetInfo{
+		{
+			Target: elbv2sdk.TargetDescription{
+				Id:   awssdk.String("192.168.1.1"),
+				Port: awssdk.Int64(8080),
+			},
+			TargetHealth: &elbv2sdk.TargetHealth{
+				State: awssdk.String(elbv2sdk.TargetHealthStateEnumInitial),
+			},
+		},
+	}
+	tests := []struct {
+		name                      string
+		targetHealthCacheTTL      time.Duration
+		registerTargetsInBetween  bool
+		wantDescribeTargetsHealth int
+		wantCacheHits             float64
+		wantCacheMisses           float64
+	}{
+		{
+			name:                      "lookups within targetHealthCacheTTL should be served from cache",
+			targetHealthCacheTTL:      1 * time.Minute,
+			wantDescribeTargetsHealth: 1,
+			wantCacheHits:             2,
+			wantCacheMisses:           1,
+		},
+		{
+			name:                      "lookups after targetHealthCacheTTL should be refreshed from AWS",
+			targetHealthCacheTTL:      0,
+			wantDescribeTargetsHealth: 3,
+			wantCacheHits:             0,
+			wantCacheMisses:           3,
+		},
+		{
+			name:                      "lookups after register targets should be refreshed from AWS",
+			targetHealthCacheTTL:      1 * time.Minute,
+			registerTargetsInBetween:  true,
+			wantDescribeTargetsHealth: 2,
+			wantCacheHits:             0,
+			wantCacheMisses:           2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeTargetHealthOutput{
+				TargetHealthDescriptions: targetHealthDescriptions,
+			}, nil).Times(tt.wantDescribeTargetsHealth)
+			if tt.registerTargetsInBetween {
+				elbv2Client.EXPECT().RegisterTargetsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.RegisterTargetsOutput{}, nil)
+			}
+
+			registry := prometheus.NewRegistry()
+			m := NewCachedTargetsManager(elbv2Client, 0, registry, &log.NullLogger{})
+			m.targetHealthCacheTTL = tt.targetHealthCacheTTL
+
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				if tt.registerTargetsInBetween && i == 1 {
+					err := m.RegisterTargets(ctx, "my-tg", []elbv2sdk.TargetDescription{wantTargets[0].Target})
+					assert.NoError(t, err)
+					continue
+				}
+				got, err := m.ListTargets(ctx, "my-tg")
+				assert.NoError(t, err)
+				assert.Equal(t, wantTargets, got)
+			}
+			assert.Equal(t, tt.wantCacheHits, testutil.ToFloat64(m.instruments.targetHealthCacheLookupsTotal.WithLabelValues(resultCacheHit)))
+			assert.Equal(t, tt.wantCacheMisses, testutil.ToFloat64(m.instruments.targetHealthCacheLookupsTotal.WithLabelValues(resultCacheMiss)))
+		})
+	}
+}
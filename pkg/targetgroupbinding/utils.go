@@ -1,11 +1,14 @@
 package targetgroupbinding
 
 import (
+	"context"
 	"fmt"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -16,8 +19,29 @@ const (
 
 	// Index Key for "ServiceReference" index.
 	IndexKeyServiceRefName = "spec.serviceRef.name"
+	// Index Key for "TargetGroupARN" index.
+	IndexKeyTargetGroupARN = "spec.targetGroupARN"
+
+	// Label Key that groups TargetGroupBindings within same namespace into one logical group.
+	LabelKeyTargetGroupBindingGroup = "elbv2.k8s.aws/targetgroupbinding-group"
+	// Annotation Key for the aggregated status summary of TargetGroupBindings within the same group.
+	AnnotationKeyTargetGroupBindingGroupStatus = "elbv2.k8s.aws/targetgroupbinding-group-status"
+	// Annotation Key for comma separated securityGroup IDs whose ingress rules shouldn't be modified by controller.
+	AnnotationKeyUnmanagedSecurityGroups = "elbv2.k8s.aws/unmanaged-security-groups"
 )
 
+// managedTGBLabelKeys are the stack label keys of TargetGroupBindings created by Ingress or Service reconciliation.
+var managedTGBLabelKeys = buildManagedTGBLabelKeys()
+
+// buildManagedTGBLabelKeys builds the stack label keys from the tracking providers used by Ingress and Service reconciliation.
+func buildManagedTGBLabelKeys() []string {
+	var labelKeys []string
+	for _, tagPrefix := range []string{tracking.IngressTagPrefix, tracking.ServiceTagPrefix} {
+		labelKeys = append(labelKeys, tracking.NewDefaultProvider(tagPrefix, "").StackLabelKeys()...)
+	}
+	return labelKeys
+}
+
 // BuildTargetHealthPodConditionType constructs the condition type for TargetHealth pod condition.
 func BuildTargetHealthPodConditionType(tgb *elbv2api.TargetGroupBinding) corev1.PodConditionType {
 	return corev1.PodConditionType(fmt.Sprintf("%s/%s", TargetHealthPodConditionTypePrefix, tgb.Name))
@@ -29,6 +53,173 @@ func IndexFuncServiceRefName(obj runtime.Object) []string {
 	return []string{tgb.Spec.ServiceRef.Name}
 }
 
+// Index Func for "TargetGroupARN" index.
+func IndexFuncTargetGroupARN(obj runtime.Object) []string {
+	tgb := obj.(*elbv2api.TargetGroupBinding)
+	return []string{tgb.Spec.TargetGroupARN}
+}
+
+// Index Func for "TargetGroupARN" index of PodTargetGroupBinding.
+func IndexFuncPodTargetGroupBindingTargetGroupARN(obj runtime.Object) []string {
+	ptgb := obj.(*elbv2api.PodTargetGroupBinding)
+	return []string{ptgb.Spec.TargetGroupARN}
+}
+
+// IsManagedTargetGroupBinding checks whether the TargetGroupBinding is created by Ingress or Service reconciliation.
+func IsManagedTargetGroupBinding(tgb *elbv2api.TargetGroupBinding) bool {
+	for _, labelKey := range managedTGBLabelKeys {
+		if _, ok := tgb.Labels[labelKey]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// FindConflictingTargetGroupBinding finds the managed TargetGroupBinding among tgbList that references the same TargetGroup as a user created tgb.
+// managed TargetGroupBindings take precedence, so nil is returned if tgb itself is managed.
+func FindConflictingTargetGroupBinding(tgb *elbv2api.TargetGroupBinding, tgbList []elbv2api.TargetGroupBinding) *elbv2api.TargetGroupBinding {
+	if IsManagedTargetGroupBinding(tgb) {
+		return nil
+	}
+	for i := range tgbList {
+		other := &tgbList[i]
+		if other.Namespace == tgb.Namespace && other.Name == tgb.Name {
+			continue
+		}
+		if other.Spec.TargetGroupARN != tgb.Spec.TargetGroupARN || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if IsManagedTargetGroupBinding(other) {
+			return other
+		}
+	}
+	return nil
+}
+
+// FindPrecedentPodTargetGroupBinding finds the PodTargetGroupBinding among ptgbList that references the same TargetGroup as a user created tgb and takes precedence over it.
+// managed TargetGroupBindings always take precedence, so nil is returned if tgb itself is managed.
+func FindPrecedentPodTargetGroupBinding(tgb *elbv2api.TargetGroupBinding, ptgbList []elbv2api.PodTargetGroupBinding) *elbv2api.PodTargetGroupBinding {
+	if IsManagedTargetGroupBinding(tgb) {
+		return nil
+	}
+	var precedentPTGB *elbv2api.PodTargetGroupBinding
+	for i := range ptgbList {
+		ptgb := &ptgbList[i]
+		if ptgb.Spec.TargetGroupARN != tgb.Spec.TargetGroupARN || !ptgb.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if !isPodTargetGroupBindingPrecedentOverTargetGroupBinding(ptgb, tgb) {
+			continue
+		}
+		if precedentPTGB == nil || isPodTargetGroupBindingPrecedent(ptgb, precedentPTGB) {
+			precedentPTGB = ptgb
+		}
+	}
+	return precedentPTGB
+}
+
+// FindPrecedentTargetGroupBinding finds the TargetGroupBinding among tgbList that references the same TargetGroup as ptgb and takes precedence over it.
+// managed TargetGroupBindings always take precedence, while user created ones take precedence unless ptgb is created earlier.
+func FindPrecedentTargetGroupBinding(ptgb *elbv2api.PodTargetGroupBinding, tgbList []elbv2api.TargetGroupBinding) *elbv2api.TargetGroupBinding {
+	var precedentTGB *elbv2api.TargetGroupBinding
+	for i := range tgbList {
+		tgb := &tgbList[i]
+		if tgb.Spec.TargetGroupARN != ptgb.Spec.TargetGroupARN || !tgb.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if IsManagedTargetGroupBinding(tgb) {
+			return tgb
+		}
+		if precedentTGB == nil && !isPodTargetGroupBindingPrecedentOverTargetGroupBinding(ptgb, tgb) {
+			precedentTGB = tgb
+		}
+	}
+	return precedentTGB
+}
+
+// FindConflictingPodTargetGroupBinding finds the PodTargetGroupBinding among ptgbList that references the same TargetGroup as ptgb and takes precedence over it.
+// the earliest created PodTargetGroupBinding takes precedence, ties are broken by namespace and name.
+func FindConflictingPodTargetGroupBinding(ptgb *elbv2api.PodTargetGroupBinding, ptgbList []elbv2api.PodTargetGroupBinding) *elbv2api.PodTargetGroupBinding {
+	var conflictingPTGB *elbv2api.PodTargetGroupBinding
+	for i := range ptgbList {
+		other := &ptgbList[i]
+		if other.Namespace == ptgb.Namespace && other.Name == ptgb.Name {
+			continue
+		}
+		if other.Spec.TargetGroupARN != ptgb.Spec.TargetGroupARN || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if !isPodTargetGroupBindingPrecedent(other, ptgb) {
+			continue
+		}
+		if conflictingPTGB == nil || isPodTargetGroupBindingPrecedent(other, conflictingPTGB) {
+			conflictingPTGB = other
+		}
+	}
+	return conflictingPTGB
+}
+
+// isPodTargetGroupBindingPrecedent checks whether ptgb takes precedence over other.
+func isPodTargetGroupBindingPrecedent(ptgb *elbv2api.PodTargetGroupBinding, other *elbv2api.PodTargetGroupBinding) bool {
+	if !ptgb.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return ptgb.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+	if ptgb.Namespace != other.Namespace {
+		return ptgb.Namespace < other.Namespace
+	}
+	return ptgb.Name < other.Name
+}
+
+// isPodTargetGroupBindingPrecedentOverTargetGroupBinding checks whether ptgb takes precedence over a user created tgb.
+// the earlier created one takes precedence, and tgb takes precedence if they are created at the same time.
+func isPodTargetGroupBindingPrecedentOverTargetGroupBinding(ptgb *elbv2api.PodTargetGroupBinding, tgb *elbv2api.TargetGroupBinding) bool {
+	return ptgb.CreationTimestamp.Before(&tgb.CreationTimestamp)
+}
+
+// SetupTargetGroupARNIndexes sets up the "TargetGroupARN" index for both TargetGroupBindings and PodTargetGroupBindings.
+// the index is shared by both reconcilers to find bindings referencing the same TargetGroup.
+func SetupTargetGroupARNIndexes(ctx context.Context, fieldIndexer client.FieldIndexer) error {
+	if err := fieldIndexer.IndexField(ctx, &elbv2api.TargetGroupBinding{},
+		IndexKeyTargetGroupARN, IndexFuncTargetGroupARN); err != nil {
+		return err
+	}
+	if err := fieldIndexer.IndexField(ctx, &elbv2api.PodTargetGroupBinding{},
+		IndexKeyTargetGroupARN, IndexFuncPodTargetGroupBindingTargetGroupARN); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GroupStatusSummary is the aggregated status of TargetGroupBindings within the same group.
+type GroupStatusSummary struct {
+	// total count of TargetGroupBindings within group.
+	Total int
+	// count of TargetGroupBindings whose latest generation is reconciled.
+	Reconciled int
+}
+
+// String returns the human readable format of GroupStatusSummary.
+func (s GroupStatusSummary) String() string {
+	return fmt.Sprintf("%d/%d targetGroupBindings reconciled", s.Reconciled, s.Total)
+}
+
+// ComputeGroupStatusSummary aggregates the status of TargetGroupBindings within the same group.
+// TargetGroupBindings being deleted are excluded from the group.
+func ComputeGroupStatusSummary(tgbList []elbv2api.TargetGroupBinding) GroupStatusSummary {
+	var summary GroupStatusSummary
+	for i := range tgbList {
+		tgb := &tgbList[i]
+		if !tgb.DeletionTimestamp.IsZero() {
+			continue
+		}
+		summary.Total++
+		if tgb.Status.ObservedGeneration != nil && *tgb.Status.ObservedGeneration == tgb.Generation {
+			summary.Reconciled++
+		}
+	}
+	return summary
+}
+
 func buildServiceReferenceKey(tgb *elbv2api.TargetGroupBinding, svcRef elbv2api.ServiceReference) types.NamespacedName {
 	return types.NamespacedName{
 		Namespace: tgb.Namespace,
@@ -0,0 +1,469 @@
+package targetgroupbinding
+
+import (
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"testing"
+	"time"
+)
+
+func TestIsManagedTargetGroupBinding(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   bool
+	}{
+		{
+			name:   "user created targetGroupBinding",
+			labels: map[string]string{"app": "my-app"},
+			want:   false,
+		},
+		{
+			name:   "targetGroupBinding created by explicit IngressGroup",
+			labels: map[string]string{"ingress.k8s.aws/stack": "awesome-group"},
+			want:   true,
+		},
+		{
+			name: "targetGroupBinding created by implicit IngressGroup",
+			labels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "awesome-ns",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+			},
+			want: true,
+		},
+		{
+			name: "targetGroupBinding created by Service",
+			labels: map[string]string{
+				"service.k8s.aws/stack-namespace": "awesome-ns",
+				"service.k8s.aws/stack-name":      "svc-1",
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: tt.labels,
+				},
+			}
+			got := IsManagedTargetGroupBinding(tgb)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindConflictingTargetGroupBinding(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	userTGB := elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "user-tgb",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+		},
+	}
+	managedTGB := elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "managed-tgb",
+			Labels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "awesome-ns",
+				"ingress.k8s.aws/stack-name":      "ing-1",
+			},
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+		},
+	}
+	deletingManagedTGB := *managedTGB.DeepCopy()
+	deletingManagedTGB.DeletionTimestamp = &deletionTimestamp
+	otherUserTGB := *userTGB.DeepCopy()
+	otherUserTGB.Name = "other-user-tgb"
+	otherTGManagedTGB := *managedTGB.DeepCopy()
+	otherTGManagedTGB.Spec.TargetGroupARN = "tg-2"
+
+	tests := []struct {
+		name    string
+		tgb     elbv2api.TargetGroupBinding
+		tgbList []elbv2api.TargetGroupBinding
+		want    *elbv2api.TargetGroupBinding
+	}{
+		{
+			name:    "user targetGroupBinding without others",
+			tgb:     userTGB,
+			tgbList: []elbv2api.TargetGroupBinding{userTGB},
+			want:    nil,
+		},
+		{
+			name:    "user targetGroupBinding conflicts with managed targetGroupBinding",
+			tgb:     userTGB,
+			tgbList: []elbv2api.TargetGroupBinding{userTGB, managedTGB},
+			want:    &managedTGB,
+		},
+		{
+			name:    "managed targetGroupBinding takes precedence",
+			tgb:     managedTGB,
+			tgbList: []elbv2api.TargetGroupBinding{userTGB, managedTGB},
+			want:    nil,
+		},
+		{
+			name:    "user targetGroupBinding with deleting managed targetGroupBinding",
+			tgb:     userTGB,
+			tgbList: []elbv2api.TargetGroupBinding{userTGB, deletingManagedTGB},
+			want:    nil,
+		},
+		{
+			name:    "user targetGroupBinding with other user targetGroupBinding",
+			tgb:     userTGB,
+			tgbList: []elbv2api.TargetGroupBinding{userTGB, otherUserTGB},
+			want:    nil,
+		},
+		{
+			name:    "user targetGroupBinding with managed targetGroupBinding for other targetGroup",
+			tgb:     userTGB,
+			tgbList: []elbv2api.TargetGroupBinding{userTGB, otherTGManagedTGB},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindConflictingTargetGroupBinding(&tt.tgb, tt.tgbList)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindConflictingPodTargetGroupBinding(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	olderPTGB := elbv2api.PodTargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "awesome-ns",
+			Name:              "older-ptgb",
+			CreationTimestamp: metav1.NewTime(deletionTimestamp.Add(-2 * time.Hour)),
+		},
+		Spec: elbv2api.PodTargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+		},
+	}
+	newerPTGB := *olderPTGB.DeepCopy()
+	newerPTGB.Name = "newer-ptgb"
+	newerPTGB.CreationTimestamp = metav1.NewTime(deletionTimestamp.Add(-time.Hour))
+	sameAgePTGB := *olderPTGB.DeepCopy()
+	sameAgePTGB.Name = "another-ptgb"
+	deletingOlderPTGB := *olderPTGB.DeepCopy()
+	deletingOlderPTGB.DeletionTimestamp = &deletionTimestamp
+	otherTGOlderPTGB := *olderPTGB.DeepCopy()
+	otherTGOlderPTGB.Spec.TargetGroupARN = "tg-2"
+
+	tests := []struct {
+		name     string
+		ptgb     elbv2api.PodTargetGroupBinding
+		ptgbList []elbv2api.PodTargetGroupBinding
+		want     *elbv2api.PodTargetGroupBinding
+	}{
+		{
+			name:     "podTargetGroupBinding without others",
+			ptgb:     newerPTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{newerPTGB},
+			want:     nil,
+		},
+		{
+			name:     "newer podTargetGroupBinding conflicts with older one",
+			ptgb:     newerPTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{olderPTGB, newerPTGB},
+			want:     &olderPTGB,
+		},
+		{
+			name:     "older podTargetGroupBinding takes precedence",
+			ptgb:     olderPTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{olderPTGB, newerPTGB},
+			want:     nil,
+		},
+		{
+			name:     "podTargetGroupBindings created at same time are ordered by name",
+			ptgb:     olderPTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{olderPTGB, sameAgePTGB},
+			want:     &sameAgePTGB,
+		},
+		{
+			name:     "newer podTargetGroupBinding with deleting older one",
+			ptgb:     newerPTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{deletingOlderPTGB, newerPTGB},
+			want:     nil,
+		},
+		{
+			name:     "newer podTargetGroupBinding with older one for other targetGroup",
+			ptgb:     newerPTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{otherTGOlderPTGB, newerPTGB},
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindConflictingPodTargetGroupBinding(&tt.ptgb, tt.ptgbList)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindPrecedentPodTargetGroupBinding(t *testing.T) {
+	now := metav1.Now()
+	userTGB := elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "awesome-ns",
+			Name:              "user-tgb",
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+		},
+	}
+	managedTGB := *userTGB.DeepCopy()
+	managedTGB.Labels = map[string]string{
+		"service.k8s.aws/stack-name": "svc-1",
+	}
+	olderPTGB := elbv2api.PodTargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "awesome-ns",
+			Name:              "older-ptgb",
+			CreationTimestamp: metav1.NewTime(now.Add(-3 * time.Hour)),
+		},
+		Spec: elbv2api.PodTargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+		},
+	}
+	oldestPTGB := *olderPTGB.DeepCopy()
+	oldestPTGB.Name = "oldest-ptgb"
+	oldestPTGB.CreationTimestamp = metav1.NewTime(now.Add(-4 * time.Hour))
+	newerPTGB := *olderPTGB.DeepCopy()
+	newerPTGB.Name = "newer-ptgb"
+	newerPTGB.CreationTimestamp = metav1.NewTime(now.Add(-30 * time.Minute))
+	sameAgePTGB := *olderPTGB.DeepCopy()
+	sameAgePTGB.Name = "same-age-ptgb"
+	sameAgePTGB.CreationTimestamp = userTGB.CreationTimestamp
+	deletingOlderPTGB := *olderPTGB.DeepCopy()
+	deletingOlderPTGB.DeletionTimestamp = &now
+	otherTGOlderPTGB := *olderPTGB.DeepCopy()
+	otherTGOlderPTGB.Spec.TargetGroupARN = "tg-2"
+
+	tests := []struct {
+		name     string
+		tgb      elbv2api.TargetGroupBinding
+		ptgbList []elbv2api.PodTargetGroupBinding
+		want     *elbv2api.PodTargetGroupBinding
+	}{
+		{
+			name:     "user targetGroupBinding conflicts with older podTargetGroupBinding",
+			tgb:      userTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{olderPTGB},
+			want:     &olderPTGB,
+		},
+		{
+			name:     "user targetGroupBinding conflicts with the oldest podTargetGroupBinding",
+			tgb:      userTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{olderPTGB, oldestPTGB},
+			want:     &oldestPTGB,
+		},
+		{
+			name:     "user targetGroupBinding takes precedence over newer podTargetGroupBinding",
+			tgb:      userTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{newerPTGB},
+			want:     nil,
+		},
+		{
+			name:     "user targetGroupBinding takes precedence over podTargetGroupBinding created at same time",
+			tgb:      userTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{sameAgePTGB},
+			want:     nil,
+		},
+		{
+			name:     "managed targetGroupBinding always takes precedence",
+			tgb:      managedTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{olderPTGB},
+			want:     nil,
+		},
+		{
+			name:     "user targetGroupBinding with deleting older podTargetGroupBinding",
+			tgb:      userTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{deletingOlderPTGB},
+			want:     nil,
+		},
+		{
+			name:     "user targetGroupBinding with older podTargetGroupBinding for other targetGroup",
+			tgb:      userTGB,
+			ptgbList: []elbv2api.PodTargetGroupBinding{otherTGOlderPTGB},
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindPrecedentPodTargetGroupBinding(&tt.tgb, tt.ptgbList)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindPrecedentTargetGroupBinding(t *testing.T) {
+	now := metav1.Now()
+	ptgb := elbv2api.PodTargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "awesome-ns",
+			Name:              "ptgb",
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+		Spec: elbv2api.PodTargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+		},
+	}
+	olderUserTGB := elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "awesome-ns",
+			Name:              "older-user-tgb",
+			CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+		},
+	}
+	newerUserTGB := *olderUserTGB.DeepCopy()
+	newerUserTGB.Name = "newer-user-tgb"
+	newerUserTGB.CreationTimestamp = metav1.NewTime(now.Add(-30 * time.Minute))
+	sameAgeUserTGB := *olderUserTGB.DeepCopy()
+	sameAgeUserTGB.Name = "same-age-user-tgb"
+	sameAgeUserTGB.CreationTimestamp = ptgb.CreationTimestamp
+	newerManagedTGB := *newerUserTGB.DeepCopy()
+	newerManagedTGB.Name = "newer-managed-tgb"
+	newerManagedTGB.Labels = map[string]string{
+		"ingress.k8s.aws/stack": "awesome-group",
+	}
+	deletingOlderUserTGB := *olderUserTGB.DeepCopy()
+	deletingOlderUserTGB.DeletionTimestamp = &now
+	otherTGOlderUserTGB := *olderUserTGB.DeepCopy()
+	otherTGOlderUserTGB.Spec.TargetGroupARN = "tg-2"
+
+	tests := []struct {
+		name    string
+		ptgb    elbv2api.PodTargetGroupBinding
+		tgbList []elbv2api.TargetGroupBinding
+		want    *elbv2api.TargetGroupBinding
+	}{
+		{
+			name:    "podTargetGroupBinding conflicts with older user targetGroupBinding",
+			ptgb:    ptgb,
+			tgbList: []elbv2api.TargetGroupBinding{olderUserTGB},
+			want:    &olderUserTGB,
+		},
+		{
+			name:    "podTargetGroupBinding conflicts with user targetGroupBinding created at same time",
+			ptgb:    ptgb,
+			tgbList: []elbv2api.TargetGroupBinding{sameAgeUserTGB},
+			want:    &sameAgeUserTGB,
+		},
+		{
+			name:    "podTargetGroupBinding takes precedence over newer user targetGroupBinding",
+			ptgb:    ptgb,
+			tgbList: []elbv2api.TargetGroupBinding{newerUserTGB},
+			want:    nil,
+		},
+		{
+			name:    "podTargetGroupBinding conflicts with newer managed targetGroupBinding",
+			ptgb:    ptgb,
+			tgbList: []elbv2api.TargetGroupBinding{olderUserTGB, newerManagedTGB},
+			want:    &newerManagedTGB,
+		},
+		{
+			name:    "podTargetGroupBinding with deleting older user targetGroupBinding",
+			ptgb:    ptgb,
+			tgbList: []elbv2api.TargetGroupBinding{deletingOlderUserTGB},
+			want:    nil,
+		},
+		{
+			name:    "podTargetGroupBinding with older user targetGroupBinding for other targetGroup",
+			ptgb:    ptgb,
+			tgbList: []elbv2api.TargetGroupBinding{otherTGOlderUserTGB},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindPrecedentTargetGroupBinding(&tt.ptgb, tt.tgbList)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestComputeGroupStatusSummary(t *testing.T) {
+	generation1 := int64(1)
+	generation2 := int64(2)
+	deletionTimestamp := metav1.Now()
+	tests := []struct {
+		name        string
+		tgbList     []elbv2api.TargetGroupBinding
+		want        GroupStatusSummary
+		wantMessage string
+	}{
+		{
+			name:        "empty group",
+			tgbList:     nil,
+			want:        GroupStatusSummary{},
+			wantMessage: "0/0 targetGroupBindings reconciled",
+		},
+		{
+			name: "all targetGroupBindings reconciled",
+			tgbList: []elbv2api.TargetGroupBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tgb-1", Generation: 1},
+					Status:     elbv2api.TargetGroupBindingStatus{ObservedGeneration: &generation1},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tgb-2", Generation: 2},
+					Status:     elbv2api.TargetGroupBindingStatus{ObservedGeneration: &generation2},
+				},
+			},
+			want:        GroupStatusSummary{Total: 2, Reconciled: 2},
+			wantMessage: "2/2 targetGroupBindings reconciled",
+		},
+		{
+			name: "some targetGroupBindings pending reconcile",
+			tgbList: []elbv2api.TargetGroupBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tgb-1", Generation: 1},
+					Status:     elbv2api.TargetGroupBindingStatus{ObservedGeneration: &generation1},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tgb-2", Generation: 2},
+					Status:     elbv2api.TargetGroupBindingStatus{ObservedGeneration: &generation1},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tgb-3", Generation: 1},
+				},
+			},
+			want:        GroupStatusSummary{Total: 3, Reconciled: 1},
+			wantMessage: "1/3 targetGroupBindings reconciled",
+		},
+		{
+			name: "targetGroupBindings being deleted are excluded",
+			tgbList: []elbv2api.TargetGroupBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tgb-1", Generation: 1},
+					Status:     elbv2api.TargetGroupBindingStatus{ObservedGeneration: &generation1},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tgb-2", Generation: 1, DeletionTimestamp: &deletionTimestamp},
+				},
+			},
+			want:        GroupStatusSummary{Total: 1, Reconciled: 1},
+			wantMessage: "1/1 targetGroupBindings reconciled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeGroupStatusSummary(tt.tgbList)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantMessage, got.String())
+		})
+	}
+}
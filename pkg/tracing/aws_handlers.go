@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	apitrace "go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/label"
+)
+
+const (
+	sdkHandlerStartAPICallSpan = "startAPICallSpan"
+	sdkHandlerEndAPICallSpan   = "endAPICallSpan"
+
+	labelAWSService    = "aws.service"
+	labelAWSOperation  = "aws.operation"
+	labelAWSRetryCount = "aws.retry_count"
+	labelAWSErrorCode  = "aws.error_code"
+	labelAWSStatusCode = "http.status_code"
+)
+
+// InjectAWSHandlers injects handlers that trace each AWS API call as a span, including all its retries.
+// the spans are children of the span within the request's context, which is typically the reconcile span.
+func InjectAWSHandlers(handlers *request.Handlers) {
+	handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: sdkHandlerStartAPICallSpan,
+		Fn:   startAPICallSpan,
+	})
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: sdkHandlerEndAPICallSpan,
+		Fn:   endAPICallSpan,
+	})
+}
+
+// apiCallSpanKey is the context key for the span of AWS API call.
+type apiCallSpanKey struct{}
+
+func startAPICallSpan(r *request.Request) {
+	ctx, span := StartSpan(r.Context(), r.ClientInfo.ServiceID+"."+operationForRequest(r),
+		label.String(labelAWSService, r.ClientInfo.ServiceID),
+		label.String(labelAWSOperation, operationForRequest(r)),
+	)
+	r.SetContext(context.WithValue(ctx, apiCallSpanKey{}, span))
+}
+
+func endAPICallSpan(r *request.Request) {
+	ctx := r.Context()
+	// the request might complete without being validated, in which case no span is started for it.
+	span, ok := ctx.Value(apiCallSpanKey{}).(apitrace.Span)
+	if !ok {
+		return
+	}
+	span.SetAttributes(label.Int(labelAWSRetryCount, r.RetryCount))
+	if r.HTTPResponse != nil {
+		span.SetAttributes(label.Int(labelAWSStatusCode, r.HTTPResponse.StatusCode))
+	}
+	if r.Error != nil {
+		if awsErr, ok := r.Error.(awserr.Error); ok {
+			span.SetAttributes(label.String(labelAWSErrorCode, awsErr.Code()))
+		}
+	}
+	EndSpan(ctx, span, r.Error)
+}
+
+// operationForRequest returns the operation for request.
+func operationForRequest(r *request.Request) string {
+	if r.Operation != nil {
+		return r.Operation.Name
+	}
+	return "?"
+}
@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	"net/http"
+	"testing"
+)
+
+func Test_InjectAWSHandlers(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		err            error
+		wantAttributes []label.KeyValue
+		wantStatusCode codes.Code
+	}{
+		{
+			name:       "API call succeeded",
+			statusCode: http.StatusOK,
+			wantAttributes: []label.KeyValue{
+				label.String("aws.service", "Elastic Load Balancing v2"),
+				label.String("aws.operation", "DescribeLoadBalancers"),
+				label.Int("aws.retry_count", 0),
+				label.Int("http.status_code", http.StatusOK),
+			},
+			wantStatusCode: codes.Unset,
+		},
+		{
+			name:       "API call failed",
+			statusCode: http.StatusBadRequest,
+			err:        awserr.New("LoadBalancerNotFound", "not found", nil),
+			wantAttributes: []label.KeyValue{
+				label.String("aws.service", "Elastic Load Balancing v2"),
+				label.String("aws.operation", "DescribeLoadBalancers"),
+				label.Int("aws.retry_count", 0),
+				label.Int("http.status_code", http.StatusBadRequest),
+				label.String("aws.error_code", "LoadBalancerNotFound"),
+			},
+			wantStatusCode: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter, restoreTracerProvider := setupInMemoryTracerProvider()
+			defer restoreTracerProvider()
+			handlers := request.Handlers{}
+			handlers.Send.PushBack(func(r *request.Request) {
+				r.HTTPResponse = &http.Response{StatusCode: tt.statusCode}
+				r.Error = tt.err
+			})
+			InjectAWSHandlers(&handlers)
+
+			ctx, reconcileSpan := StartSpan(context.Background(), "reconcile")
+			req := request.New(aws.Config{}, metadata.ClientInfo{ServiceID: "Elastic Load Balancing v2"}, handlers, nil,
+				&request.Operation{Name: "DescribeLoadBalancers"}, nil, nil)
+			req.SetContext(ctx)
+			_ = req.Send()
+			reconcileSpan.End()
+
+			spans := exporter.GetSpans()
+			assert.Len(t, spans, 2)
+			apiCallSpan := findSpanByName(spans, "Elastic Load Balancing v2.DescribeLoadBalancers")
+			if assert.NotNil(t, apiCallSpan) {
+				assert.Equal(t, reconcileSpan.SpanContext().SpanID, apiCallSpan.ParentSpanID)
+				assert.ElementsMatch(t, tt.wantAttributes, apiCallSpan.Attributes)
+				assert.Equal(t, tt.wantStatusCode, apiCallSpan.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_InjectAWSHandlers_requestNotValidated(t *testing.T) {
+	exporter, restoreTracerProvider := setupInMemoryTracerProvider()
+	defer restoreTracerProvider()
+	handlers := request.Handlers{}
+	InjectAWSHandlers(&handlers)
+
+	ctx, reconcileSpan := StartSpan(context.Background(), "reconcile")
+	req := request.New(aws.Config{}, metadata.ClientInfo{ServiceID: "Elastic Load Balancing v2"}, handlers, nil,
+		&request.Operation{Name: "DescribeLoadBalancers"}, nil, nil)
+	req.SetContext(ctx)
+	req.Error = awserr.New("InvalidParameter", "invalid", nil)
+	_ = req.Send()
+
+	assert.Len(t, exporter.GetSpans(), 0)
+	assert.True(t, reconcileSpan.IsRecording())
+	reconcileSpan.End()
+}
@@ -0,0 +1,24 @@
+package tracing
+
+import "github.com/spf13/pflag"
+
+const (
+	flagTracingOTLPEndpoint = "tracing-otlp-endpoint"
+	flagTracingOTLPInsecure = "tracing-otlp-insecure"
+)
+
+// Config contains the configuration for tracing.
+type Config struct {
+	// OTLPEndpoint is the host:port of the OTLP gRPC collector that traces are exported to.
+	// tracing is disabled if it's empty.
+	OTLPEndpoint string
+	// OTLPInsecure disables the transport security for connections to OTLPEndpoint.
+	OTLPInsecure bool
+}
+
+func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&cfg.OTLPEndpoint, flagTracingOTLPEndpoint, "",
+		"The host:port of the OTLP gRPC collector to export reconcile traces to, tracing is disabled if empty")
+	fs.BoolVar(&cfg.OTLPInsecure, flagTracingOTLPInsecure, false,
+		"Disable the transport security for connections to the OTLP collector")
+}
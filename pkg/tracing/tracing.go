@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/api/global"
+	apitrace "go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"google.golang.org/grpc/credentials"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	tracerName  = "sigs.k8s.io/aws-load-balancer-controller"
+	serviceName = "aws-load-balancer-controller"
+
+	labelController = "k8s.controller"
+	labelNamespace  = "k8s.namespace"
+	labelName       = "k8s.name"
+)
+
+// ShutdownFunc flushes pending spans and releases the resources held for tracing.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup installs the global TracerProvider based on cfg.
+// when tracing is disabled, the global TracerProvider is left as the no-op one, so spans are never recorded.
+func Setup(cfg Config) (ShutdownFunc, error) {
+	if len(cfg.OTLPEndpoint) == 0 {
+		return func(_ context.Context) error { return nil }, nil
+	}
+
+	exporterOpts := []otlp.ExporterOption{otlp.WithAddress(cfg.OTLPEndpoint)}
+	if cfg.OTLPInsecure {
+		exporterOpts = append(exporterOpts, otlp.WithInsecure())
+	} else {
+		exporterOpts = append(exporterOpts, otlp.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+	}
+	exporter, err := otlp.NewExporter(exporterOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize OTLP exporter")
+	}
+	batcher := sdktrace.NewBatchSpanProcessor(exporter)
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(batcher),
+		sdktrace.WithResource(resource.New(semconv.ServiceNameKey.String(serviceName))),
+	)
+	global.SetTracerProvider(tracerProvider)
+	// the TracerProvider of this SDK version has no Shutdown, unregistering the batcher shuts it down,
+	// which exports the batched spans before the exporter is shut down.
+	return func(ctx context.Context) error {
+		tracerProvider.UnregisterSpanProcessor(batcher)
+		return exporter.Shutdown(ctx)
+	}, nil
+}
+
+// StartSpan starts a span named name as child of the span within ctx.
+func StartSpan(ctx context.Context, name string, attrs ...label.KeyValue) (context.Context, apitrace.Span) {
+	return global.Tracer(tracerName).Start(ctx, name, apitrace.WithAttributes(attrs...))
+}
+
+// StartReconcileSpan starts the root span for reconciling req by controller.
+func StartReconcileSpan(ctx context.Context, controller string, req ctrl.Request) (context.Context, apitrace.Span) {
+	return StartSpan(ctx, controller+".Reconcile",
+		label.String(labelController, controller),
+		label.String(labelNamespace, req.Namespace),
+		label.String(labelName, req.Name),
+	)
+}
+
+// EndSpan ends span, and records err on it if any.
+func EndSpan(ctx context.Context, span apitrace.Span, err error) {
+	if err != nil {
+		span.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/api/global"
+	apitrace "go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/export/trace/tracetest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"testing"
+)
+
+// setupInMemoryTracerProvider installs a global TracerProvider that records all spans into the returned exporter.
+// the returned func restores the no-op global TracerProvider.
+func setupInMemoryTracerProvider() (*tracetest.InMemoryExporter, func()) {
+	exporter := tracetest.NewInMemoryExporter()
+	global.SetTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+	))
+	return exporter, func() {
+		global.SetTracerProvider(apitrace.NoopTracerProvider())
+	}
+}
+
+func Test_Setup(t *testing.T) {
+	shutdown, err := Setup(Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, span := StartSpan(context.Background(), "span")
+	assert.False(t, span.IsRecording())
+	span.End()
+}
+
+func Test_StartReconcileSpan(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatusCode codes.Code
+		wantStatusMsg  string
+	}{
+		{
+			name:           "reconcile succeeded",
+			wantStatusCode: codes.Unset,
+		},
+		{
+			name:           "reconcile failed",
+			err:            errors.New("some error"),
+			wantStatusCode: codes.Error,
+			wantStatusMsg:  "some error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter, restoreTracerProvider := setupInMemoryTracerProvider()
+			defer restoreTracerProvider()
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "awesome-svc"}}
+			ctx, span := StartReconcileSpan(context.Background(), "service", req)
+			_, childSpan := StartSpan(ctx, "child")
+			childSpan.End()
+			EndSpan(ctx, span, tt.err)
+
+			spans := exporter.GetSpans()
+			assert.Len(t, spans, 2)
+			assert.Equal(t, "child", spans[0].Name)
+			assert.Equal(t, spans[1].SpanContext.SpanID, spans[0].ParentSpanID)
+
+			reconcileSpan := spans[1]
+			assert.Equal(t, "service.Reconcile", reconcileSpan.Name)
+			assert.ElementsMatch(t, []label.KeyValue{
+				label.String("k8s.controller", "service"),
+				label.String("k8s.namespace", "awesome-ns"),
+				label.String("k8s.name", "awesome-svc"),
+			}, reconcileSpan.Attributes)
+			assert.Equal(t, tt.wantStatusCode, reconcileSpan.StatusCode)
+			assert.Equal(t, tt.wantStatusMsg, reconcileSpan.StatusMessage)
+		})
+	}
+}
+
+func findSpanByName(spans []*exporttrace.SpanData, name string) *exporttrace.SpanData {
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	return nil
+}
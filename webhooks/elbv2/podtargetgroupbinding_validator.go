@@ -0,0 +1,77 @@
+package elbv2
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"strings"
+)
+
+const apiPathValidateELBv2PodTargetGroupBinding = "/validate-elbv2-k8s-aws-v1beta1-podtargetgroupbinding"
+
+// NewPodTargetGroupBindingValidator returns a validator for PodTargetGroupBinding CRD.
+func NewPodTargetGroupBindingValidator(logger logr.Logger) *podTargetGroupBindingValidator {
+	return &podTargetGroupBindingValidator{
+		logger: logger,
+	}
+}
+
+var _ webhook.Validator = &podTargetGroupBindingValidator{}
+
+type podTargetGroupBindingValidator struct {
+	logger logr.Logger
+}
+
+func (v *podTargetGroupBindingValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &elbv2api.PodTargetGroupBinding{}, nil
+}
+
+func (v *podTargetGroupBindingValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	ptgb := obj.(*elbv2api.PodTargetGroupBinding)
+	if err := targetgroupbinding.ValidatePodTargetGroupBinding(ptgb); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *podTargetGroupBindingValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	ptgb := obj.(*elbv2api.PodTargetGroupBinding)
+	oldPTGB := oldObj.(*elbv2api.PodTargetGroupBinding)
+	if err := v.checkImmutableFields(ptgb, oldPTGB); err != nil {
+		return err
+	}
+	if err := targetgroupbinding.ValidatePodTargetGroupBinding(ptgb); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *podTargetGroupBindingValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// checkImmutableFields will check immutable fields are not changed.
+// the targets registered by PodTargetGroupBinding are tracked against its TargetGroup, so the TargetGroup cannot be changed.
+func (v *podTargetGroupBindingValidator) checkImmutableFields(ptgb *elbv2api.PodTargetGroupBinding, oldPTGB *elbv2api.PodTargetGroupBinding) error {
+	var changedImmutableFields []string
+	if ptgb.Spec.TargetGroupARN != oldPTGB.Spec.TargetGroupARN {
+		changedImmutableFields = append(changedImmutableFields, "spec.targetGroupARN")
+	}
+
+	if len(changedImmutableFields) != 0 {
+		return errors.Errorf("%s update may not change these fields: %s", "PodTargetGroupBinding", strings.Join(changedImmutableFields, ","))
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-elbv2-k8s-aws-v1beta1-podtargetgroupbinding,mutating=false,failurePolicy=fail,groups=elbv2.k8s.aws,resources=podtargetgroupbindings,verbs=create;update,versions=v1beta1,name=vpodtargetgroupbinding.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1beta1
+
+func (v *podTargetGroupBindingValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidateELBv2PodTargetGroupBinding, webhook.ValidatingWebhookForValidator(v))
+}
@@ -0,0 +1,129 @@
+package elbv2
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+const (
+	testPodTargetGroupARN      = "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067"
+	testOtherPodTargetGroupARN = "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/other-tg/73e2d6bc24d8a068"
+)
+
+func Test_podTargetGroupBindingValidator_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     *elbv2api.PodTargetGroupBinding
+		wantErr error
+	}{
+		{
+			name: "valid podTargetGroupBinding",
+			obj: &elbv2api.PodTargetGroupBinding{
+				Spec: elbv2api.PodTargetGroupBindingSpec{
+					TargetGroupARN: testPodTargetGroupARN,
+					PodSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "canary"},
+					},
+					Port: 8080,
+				},
+			},
+		},
+		{
+			name: "podSelector is empty",
+			obj: &elbv2api.PodTargetGroupBinding{
+				Spec: elbv2api.PodTargetGroupBindingSpec{
+					TargetGroupARN: testPodTargetGroupARN,
+					Port:           8080,
+				},
+			},
+			wantErr: errors.New("podSelector must not be empty"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewPodTargetGroupBindingValidator(&log.NullLogger{})
+			err := v.ValidateCreate(context.Background(), tt.obj)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_podTargetGroupBindingValidator_ValidateUpdate(t *testing.T) {
+	oldPTGB := &elbv2api.PodTargetGroupBinding{
+		Spec: elbv2api.PodTargetGroupBindingSpec{
+			TargetGroupARN: testPodTargetGroupARN,
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "canary"},
+			},
+			Port: 8080,
+		},
+	}
+	tests := []struct {
+		name    string
+		obj     *elbv2api.PodTargetGroupBinding
+		oldObj  *elbv2api.PodTargetGroupBinding
+		wantErr error
+	}{
+		{
+			name: "podSelector and port changed",
+			obj: &elbv2api.PodTargetGroupBinding{
+				Spec: elbv2api.PodTargetGroupBindingSpec{
+					TargetGroupARN: testPodTargetGroupARN,
+					PodSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "canary", "track": "v2"},
+					},
+					Port: 9090,
+				},
+			},
+			oldObj: oldPTGB,
+		},
+		{
+			name: "targetGroupARN changed",
+			obj: &elbv2api.PodTargetGroupBinding{
+				Spec: elbv2api.PodTargetGroupBindingSpec{
+					TargetGroupARN: testOtherPodTargetGroupARN,
+					PodSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "canary"},
+					},
+					Port: 8080,
+				},
+			},
+			oldObj:  oldPTGB,
+			wantErr: errors.New("PodTargetGroupBinding update may not change these fields: spec.targetGroupARN"),
+		},
+		{
+			name: "port changed to invalid value",
+			obj: &elbv2api.PodTargetGroupBinding{
+				Spec: elbv2api.PodTargetGroupBindingSpec{
+					TargetGroupARN: testPodTargetGroupARN,
+					PodSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "canary"},
+					},
+					Port: 0,
+				},
+			},
+			oldObj:  oldPTGB,
+			wantErr: errors.New("invalid port 0: must be between 1 and 65535"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewPodTargetGroupBindingValidator(&log.NullLogger{})
+			err := v.ValidateUpdate(context.Background(), tt.obj, tt.oldObj)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
@@ -14,7 +15,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-const apiPathMutateELBv2TargetGroupBinding = "/mutate-elbv2-k8s-aws-v1beta1-targetgroupbinding"
+const (
+	apiPathMutateELBv2TargetGroupBinding = "/mutate-elbv2-k8s-aws-v1beta1-targetgroupbinding"
+	defaultHealthCheckPath               = "/"
+)
 
 // NewTargetGroupBindingMutator returns a mutator for TargetGroupBinding CRD.
 func NewTargetGroupBindingMutator(elbv2Client services.ELBV2, logger logr.Logger) *targetGroupBindingMutator {
@@ -40,11 +44,14 @@ func (m *targetGroupBindingMutator) MutateCreate(ctx context.Context, obj runtim
 	if err := m.defaultingTargetType(ctx, tgb); err != nil {
 		return nil, err
 	}
+	m.defaultingHealthCheck(ctx, tgb)
 	return tgb, nil
 }
 
 func (m *targetGroupBindingMutator) MutateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) (runtime.Object, error) {
-	return obj, nil
+	tgb := obj.(*elbv2api.TargetGroupBinding)
+	m.defaultingHealthCheck(ctx, tgb)
+	return tgb, nil
 }
 
 func (m *targetGroupBindingMutator) defaultingTargetType(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
@@ -70,6 +77,21 @@ func (m *targetGroupBindingMutator) defaultingTargetType(ctx context.Context, tg
 	return nil
 }
 
+func (m *targetGroupBindingMutator) defaultingHealthCheck(_ context.Context, tgb *elbv2api.TargetGroupBinding) {
+	hc := tgb.Spec.HealthCheck
+	if hc == nil {
+		return
+	}
+	if hc.Port == nil {
+		trafficPort := intstr.FromString(elbv2api.HealthCheckPortTrafficPort)
+		hc.Port = &trafficPort
+	}
+	if hc.Path == nil && hc.Protocol != nil &&
+		(*hc.Protocol == elbv2api.HealthCheckProtocolHTTP || *hc.Protocol == elbv2api.HealthCheckProtocolHTTPS) {
+		hc.Path = awssdk.String(defaultHealthCheckPath)
+	}
+}
+
 func (m *targetGroupBindingMutator) obtainSDKTargetTypeFromAWS(ctx context.Context, tgARN string) (string, error) {
 	req := &elbv2sdk.DescribeTargetGroupsInput{
 		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
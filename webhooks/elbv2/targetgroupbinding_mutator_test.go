@@ -7,6 +7,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -275,3 +276,92 @@ func Test_targetGroupBindingMutator_obtainSDKTargetTypeFromAWS(t *testing.T) {
 		})
 	}
 }
+
+func Test_targetGroupBindingMutator_defaultingHealthCheck(t *testing.T) {
+	protocolHTTP := elbv2api.HealthCheckProtocolHTTP
+	protocolTCP := elbv2api.HealthCheckProtocolTCP
+	port8080 := intstr.FromInt(8080)
+	portTraffic := intstr.FromString("traffic-port")
+	tests := []struct {
+		name string
+		tgb  *elbv2api.TargetGroupBinding
+		want *elbv2api.TargetGroupBinding
+	}{
+		{
+			name: "healthCheck is not set",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{},
+			},
+			want: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{},
+			},
+		},
+		{
+			name: "HTTP healthCheck without port and path",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					HealthCheck: &elbv2api.TargetGroupHealthCheck{
+						Protocol: &protocolHTTP,
+					},
+				},
+			},
+			want: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					HealthCheck: &elbv2api.TargetGroupHealthCheck{
+						Protocol: &protocolHTTP,
+						Port:     &portTraffic,
+						Path:     awssdk.String("/"),
+					},
+				},
+			},
+		},
+		{
+			name: "TCP healthCheck without port",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					HealthCheck: &elbv2api.TargetGroupHealthCheck{
+						Protocol: &protocolTCP,
+					},
+				},
+			},
+			want: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					HealthCheck: &elbv2api.TargetGroupHealthCheck{
+						Protocol: &protocolTCP,
+						Port:     &portTraffic,
+					},
+				},
+			},
+		},
+		{
+			name: "HTTP healthCheck with port and path",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					HealthCheck: &elbv2api.TargetGroupHealthCheck{
+						Protocol: &protocolHTTP,
+						Port:     &port8080,
+						Path:     awssdk.String("/healthz"),
+					},
+				},
+			},
+			want: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					HealthCheck: &elbv2api.TargetGroupHealthCheck{
+						Protocol: &protocolHTTP,
+						Port:     &port8080,
+						Path:     awssdk.String("/healthz"),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &targetGroupBindingMutator{
+				logger: &log.NullLogger{},
+			}
+			m.defaultingHealthCheck(context.Background(), tt.tgb)
+			assert.Equal(t, tt.want, tt.tgb)
+		})
+	}
+}
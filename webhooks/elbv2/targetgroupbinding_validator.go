@@ -5,6 +5,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -36,6 +37,9 @@ func (v *targetGroupBindingValidator) ValidateCreate(ctx context.Context, obj ru
 	if err := v.checkRequiredFields(tgb); err != nil {
 		return err
 	}
+	if err := v.checkHealthCheck(tgb); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -48,6 +52,9 @@ func (v *targetGroupBindingValidator) ValidateUpdate(ctx context.Context, obj ru
 	if err := v.checkImmutableFields(tgb, oldTgb); err != nil {
 		return err
 	}
+	if err := v.checkHealthCheck(tgb); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -87,6 +94,34 @@ func (v *targetGroupBindingValidator) checkImmutableFields(tgb *elbv2api.TargetG
 	return nil
 }
 
+// checkHealthCheck will check health check settings are valid.
+func (v *targetGroupBindingValidator) checkHealthCheck(tgb *elbv2api.TargetGroupBinding) error {
+	hc := tgb.Spec.HealthCheck
+	if hc == nil {
+		return nil
+	}
+	if hc.Port != nil {
+		if hc.Port.Type == intstr.String && hc.Port.StrVal != elbv2api.HealthCheckPortTrafficPort {
+			return errors.Errorf("spec.healthCheck.port must be either a numerical port or %v: %v", elbv2api.HealthCheckPortTrafficPort, hc.Port.StrVal)
+		}
+		if hc.Port.Type == intstr.Int && (hc.Port.IntVal < 1 || hc.Port.IntVal > 65535) {
+			return errors.Errorf("spec.healthCheck.port must be within [1, 65535]: %v", hc.Port.IntVal)
+		}
+	}
+	if hc.Path != nil {
+		if hc.Protocol != nil && *hc.Protocol == elbv2api.HealthCheckProtocolTCP {
+			return errors.Errorf("spec.healthCheck.path is not supported for %v health checks", elbv2api.HealthCheckProtocolTCP)
+		}
+		if !strings.HasPrefix(*hc.Path, "/") {
+			return errors.Errorf("spec.healthCheck.path must start with /: %v", *hc.Path)
+		}
+	}
+	if hc.IntervalSeconds != nil && hc.TimeoutSeconds != nil && *hc.TimeoutSeconds >= *hc.IntervalSeconds {
+		return errors.Errorf("spec.healthCheck.timeoutSeconds must be smaller than intervalSeconds: %v, %v", *hc.TimeoutSeconds, *hc.IntervalSeconds)
+	}
+	return nil
+}
+
 // +kubebuilder:webhook:path=/validate-elbv2-k8s-aws-v1beta1-targetgroupbinding,mutating=false,failurePolicy=fail,groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=create;update,versions=v1beta1,name=vtargetgroupbinding.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1beta1
 
 func (v *targetGroupBindingValidator) SetupWithManager(mgr ctrl.Manager) {
@@ -2,8 +2,10 @@ package elbv2
 
 import (
 	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
@@ -307,3 +309,143 @@ func Test_targetGroupBindingValidator_checkImmutableFields(t *testing.T) {
 		})
 	}
 }
+
+func Test_targetGroupBindingValidator_checkHealthCheck(t *testing.T) {
+	protocolHTTP := elbv2api.HealthCheckProtocolHTTP
+	protocolTCP := elbv2api.HealthCheckProtocolTCP
+	port8080 := intstr.FromInt(8080)
+	port0 := intstr.FromInt(0)
+	portTraffic := intstr.FromString("traffic-port")
+	portNamed := intstr.FromString("http")
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "healthCheck is not set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "healthCheck is valid",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheck: &elbv2api.TargetGroupHealthCheck{
+							Protocol:                &protocolHTTP,
+							Port:                    &port8080,
+							Path:                    awssdk.String("/healthz"),
+							IntervalSeconds:         awssdk.Int64(10),
+							TimeoutSeconds:          awssdk.Int64(5),
+							HealthyThresholdCount:   awssdk.Int64(2),
+							UnhealthyThresholdCount: awssdk.Int64(3),
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "healthCheck port is traffic-port",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheck: &elbv2api.TargetGroupHealthCheck{
+							Port: &portTraffic,
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "healthCheck port is named port",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheck: &elbv2api.TargetGroupHealthCheck{
+							Port: &portNamed,
+						},
+					},
+				},
+			},
+			wantErr: errors.New("spec.healthCheck.port must be either a numerical port or traffic-port: http"),
+		},
+		{
+			name: "healthCheck port is out of range",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheck: &elbv2api.TargetGroupHealthCheck{
+							Port: &port0,
+						},
+					},
+				},
+			},
+			wantErr: errors.New("spec.healthCheck.port must be within [1, 65535]: 0"),
+		},
+		{
+			name: "healthCheck path with TCP protocol",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheck: &elbv2api.TargetGroupHealthCheck{
+							Protocol: &protocolTCP,
+							Path:     awssdk.String("/healthz"),
+						},
+					},
+				},
+			},
+			wantErr: errors.New("spec.healthCheck.path is not supported for TCP health checks"),
+		},
+		{
+			name: "healthCheck path without leading slash",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheck: &elbv2api.TargetGroupHealthCheck{
+							Protocol: &protocolHTTP,
+							Path:     awssdk.String("healthz"),
+						},
+					},
+				},
+			},
+			wantErr: errors.New("spec.healthCheck.path must start with /: healthz"),
+		},
+		{
+			name: "healthCheck timeout not smaller than interval",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						HealthCheck: &elbv2api.TargetGroupHealthCheck{
+							IntervalSeconds: awssdk.Int64(10),
+							TimeoutSeconds:  awssdk.Int64(10),
+						},
+					},
+				},
+			},
+			wantErr: errors.New("spec.healthCheck.timeoutSeconds must be smaller than intervalSeconds: 10, 10"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkHealthCheck(tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
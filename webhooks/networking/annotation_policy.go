@@ -0,0 +1,102 @@
+package networking
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"regexp"
+	"sort"
+)
+
+// AnnotationValuePolicy contains the allowed values for an annotation.
+// an annotation value is allowed if it's within AllowedValues or matches any of AllowedPatterns.
+type AnnotationValuePolicy struct {
+	// AllowedValues are the exact annotation values allowed.
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	// AllowedPatterns are the regular expressions that annotation values should match.
+	AllowedPatterns []string `json:"allowedPatterns,omitempty"`
+
+	compiledPatterns []*regexp.Regexp
+}
+
+// AnnotationPolicy is the annotation value policy indexed by annotation key.
+type AnnotationPolicy map[string]*AnnotationValuePolicy
+
+// LoadAnnotationPolicy loads AnnotationPolicy from JSON file, an empty policy is returned if policyFile is empty.
+func LoadAnnotationPolicy(policyFile string) (AnnotationPolicy, error) {
+	if policyFile == "" {
+		return AnnotationPolicy{}, nil
+	}
+	rawPolicy, err := ioutil.ReadFile(policyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read annotation policy file %v", policyFile)
+	}
+	return ParseAnnotationPolicy(rawPolicy)
+}
+
+// ParseAnnotationPolicy parses AnnotationPolicy from raw JSON.
+func ParseAnnotationPolicy(rawPolicy []byte) (AnnotationPolicy, error) {
+	policy := AnnotationPolicy{}
+	if err := json.Unmarshal(rawPolicy, &policy); err != nil {
+		return nil, errors.Wrap(err, "failed to parse annotation policy")
+	}
+	for annotation, valuePolicy := range policy {
+		if valuePolicy == nil || (len(valuePolicy.AllowedValues) == 0 && len(valuePolicy.AllowedPatterns) == 0) {
+			return nil, errors.Errorf("annotation policy for %v must specify allowedValues or allowedPatterns", annotation)
+		}
+		for _, pattern := range valuePolicy.AllowedPatterns {
+			compiledPattern, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid allowedPatterns for annotation %v", annotation)
+			}
+			valuePolicy.compiledPatterns = append(valuePolicy.compiledPatterns, compiledPattern)
+		}
+	}
+	return policy, nil
+}
+
+// Validate checks whether annotations satisfies this policy.
+// annotations without policy are always allowed.
+func (p AnnotationPolicy) Validate(annotations map[string]string) error {
+	var violations []string
+	for annotation, value := range annotations {
+		valuePolicy, ok := p[annotation]
+		if !ok {
+			continue
+		}
+		if !valuePolicy.allows(value) {
+			violations = append(violations, fmt.Sprintf("%v: %q %v", annotation, value, valuePolicy.describe()))
+		}
+	}
+	if len(violations) != 0 {
+		sort.Strings(violations)
+		return errors.Errorf("annotation values not allowed by policy: %v", violations)
+	}
+	return nil
+}
+
+func (p *AnnotationValuePolicy) allows(value string) bool {
+	for _, allowedValue := range p.AllowedValues {
+		if value == allowedValue {
+			return true
+		}
+	}
+	for _, pattern := range p.compiledPatterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *AnnotationValuePolicy) describe() string {
+	switch {
+	case len(p.AllowedValues) != 0 && len(p.AllowedPatterns) != 0:
+		return fmt.Sprintf("must be within %v or match %v", p.AllowedValues, p.AllowedPatterns)
+	case len(p.AllowedValues) != 0:
+		return fmt.Sprintf("must be within %v", p.AllowedValues)
+	default:
+		return fmt.Sprintf("must match %v", p.AllowedPatterns)
+	}
+}
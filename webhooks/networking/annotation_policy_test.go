@@ -0,0 +1,120 @@
+package networking
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseAnnotationPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		rawPolicy string
+		wantErr   error
+	}{
+		{
+			name:      "allowed values and patterns",
+			rawPolicy: `{"alb.ingress.kubernetes.io/scheme":{"allowedValues":["internal"]},"alb.ingress.kubernetes.io/load-balancer-name":{"allowedPatterns":["^team-a-"]}}`,
+		},
+		{
+			name:      "empty policy",
+			rawPolicy: `{}`,
+		},
+		{
+			name:      "malformed policy",
+			rawPolicy: `{"alb.ingress.kubernetes.io/scheme":`,
+			wantErr:   errors.New("failed to parse annotation policy: unexpected end of JSON input"),
+		},
+		{
+			name:      "policy without allowed values or patterns",
+			rawPolicy: `{"alb.ingress.kubernetes.io/scheme":{}}`,
+			wantErr:   errors.New("annotation policy for alb.ingress.kubernetes.io/scheme must specify allowedValues or allowedPatterns"),
+		},
+		{
+			name:      "invalid pattern",
+			rawPolicy: `{"alb.ingress.kubernetes.io/load-balancer-name":{"allowedPatterns":["team-a-("]}}`,
+			wantErr:   errors.New("invalid allowedPatterns for annotation alb.ingress.kubernetes.io/load-balancer-name: error parsing regexp: missing closing ): `team-a-(`"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAnnotationPolicy([]byte(tt.rawPolicy))
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAnnotationPolicy_Validate(t *testing.T) {
+	policy, err := ParseAnnotationPolicy([]byte(`{
+		"alb.ingress.kubernetes.io/scheme": {"allowedValues": ["internal"]},
+		"alb.ingress.kubernetes.io/load-balancer-name": {"allowedPatterns": ["^team-a-[a-z0-9-]+$"]},
+		"alb.ingress.kubernetes.io/target-type": {"allowedValues": ["ip"], "allowedPatterns": ["^inst"]}
+	}`))
+	assert.NoError(t, err)
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     error
+	}{
+		{
+			name: "annotations without policy",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/subnets": "subnet-a,subnet-b",
+			},
+		},
+		{
+			name: "allowed value",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme": "internal",
+			},
+		},
+		{
+			name: "allowed pattern",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-name": "team-a-lb",
+			},
+		},
+		{
+			name: "allowed by either value or pattern",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-type": "instance",
+			},
+		},
+		{
+			name: "disallowed value",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme": "internet-facing",
+			},
+			wantErr: errors.New(`annotation values not allowed by policy: [alb.ingress.kubernetes.io/scheme: "internet-facing" must be within [internal]]`),
+		},
+		{
+			name: "disallowed pattern",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-name": "team-b-lb",
+			},
+			wantErr: errors.New(`annotation values not allowed by policy: [alb.ingress.kubernetes.io/load-balancer-name: "team-b-lb" must match [^team-a-[a-z0-9-]+$]]`),
+		},
+		{
+			name: "multiple disallowed values",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme":      "internet-facing",
+				"alb.ingress.kubernetes.io/target-type": "lambda",
+			},
+			wantErr: errors.New(`annotation values not allowed by policy: [alb.ingress.kubernetes.io/scheme: "internet-facing" must be within [internal] alb.ingress.kubernetes.io/target-type: "lambda" must be within [ip] or match [^inst]]`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.annotations)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
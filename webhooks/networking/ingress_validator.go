@@ -0,0 +1,71 @@
+package networking
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const apiPathValidateNetworkingIngress = "/validate-networking-v1beta1-ingress"
+
+// NewIngressValidator returns a validator for Ingress API.
+func NewIngressValidator(annotationPolicy AnnotationPolicy, logger logr.Logger) *ingressValidator {
+	return &ingressValidator{
+		annotationPolicy: annotationPolicy,
+		logger:           logger,
+	}
+}
+
+var _ webhook.Validator = &ingressValidator{}
+
+type ingressValidator struct {
+	annotationPolicy AnnotationPolicy
+	logger           logr.Logger
+}
+
+func (v *ingressValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &networking.Ingress{}, nil
+}
+
+func (v *ingressValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	ing := obj.(*networking.Ingress)
+	if err := v.checkAnnotationPolicy(ing.Annotations); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ingressValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	ing := obj.(*networking.Ingress)
+	oldIng := oldObj.(*networking.Ingress)
+	// only annotations that are added or changed are checked, so that existing Ingresses can still be updated after policy change.
+	changedAnnotations := make(map[string]string)
+	for key, value := range ing.Annotations {
+		if oldValue, exists := oldIng.Annotations[key]; !exists || oldValue != value {
+			changedAnnotations[key] = value
+		}
+	}
+	if err := v.checkAnnotationPolicy(changedAnnotations); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ingressValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// checkAnnotationPolicy will check annotation values are allowed by annotation policy.
+func (v *ingressValidator) checkAnnotationPolicy(annotations map[string]string) error {
+	return v.annotationPolicy.Validate(annotations)
+}
+
+// SetupWithManager registers the ingress validating webhook, it should only be called when there is an annotation policy to enforce.
+// the webhook configuration isn't generated along with other webhooks, it's installed separately via config/webhook/ingress_validator_webhook.yaml.
+func (v *ingressValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidateNetworkingIngress, webhook.ValidatingWebhookForValidator(v))
+}
@@ -0,0 +1,168 @@
+package networking
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_ingressValidator_ValidateCreate(t *testing.T) {
+	policy, err := ParseAnnotationPolicy([]byte(`{"alb.ingress.kubernetes.io/scheme":{"allowedValues":["internal"]}}`))
+	assert.NoError(t, err)
+	type args struct {
+		obj *networking.Ingress
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "annotation value allowed",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internal",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "annotation value not allowed",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internet-facing",
+						},
+					},
+				},
+			},
+			wantErr: errors.New(`annotation values not allowed by policy: [alb.ingress.kubernetes.io/scheme: "internet-facing" must be within [internal]]`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &ingressValidator{
+				annotationPolicy: policy,
+				logger:           &log.NullLogger{},
+			}
+			err := v.ValidateCreate(context.Background(), tt.args.obj)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ingressValidator_ValidateUpdate(t *testing.T) {
+	policy, err := ParseAnnotationPolicy([]byte(`{"alb.ingress.kubernetes.io/scheme":{"allowedValues":["internal"]}}`))
+	assert.NoError(t, err)
+	type args struct {
+		obj    *networking.Ingress
+		oldObj *networking.Ingress
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "annotation changed to allowed value",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internal",
+						},
+					},
+				},
+				oldObj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internet-facing",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "annotation changed to disallowed value",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internet-facing",
+						},
+					},
+				},
+				oldObj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internal",
+						},
+					},
+				},
+			},
+			wantErr: errors.New(`annotation values not allowed by policy: [alb.ingress.kubernetes.io/scheme: "internet-facing" must be within [internal]]`),
+		},
+		{
+			name: "disallowed annotation value added",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internet-facing",
+						},
+					},
+				},
+				oldObj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{},
+				},
+			},
+			wantErr: errors.New(`annotation values not allowed by policy: [alb.ingress.kubernetes.io/scheme: "internet-facing" must be within [internal]]`),
+		},
+		{
+			name: "unchanged disallowed annotation value",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme":  "internet-facing",
+							"alb.ingress.kubernetes.io/subnets": "subnet-a,subnet-b",
+						},
+					},
+				},
+				oldObj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "internet-facing",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &ingressValidator{
+				annotationPolicy: policy,
+				logger:           &log.NullLogger{},
+			}
+			err := v.ValidateUpdate(context.Background(), tt.args.obj, tt.args.oldObj)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}